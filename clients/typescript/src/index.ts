@@ -0,0 +1,2673 @@
+// Code generated by genclient from the Swimo API 1.0 document. DO NOT EDIT.
+
+// Tokens returned by the sign in operations are kept by the client and sent with the following
+// requests. An expired access token is refreshed with the refresh token once and the request
+// retried, new tokens are passed to onTokens to be stored.
+
+const basePath = '/api/v1';
+const refreshPath = '/refresh-token';
+
+/** Tokens of a signed in user */
+export interface Tokens {
+  token: string;
+  refreshToken: string;
+}
+
+export interface ClientOptions {
+  /** Origin serving the API, ex: https://api.swimo.id */
+  baseUrl: string;
+  /** Starts the client signed in, ex: with the tokens stored by a previous session */
+  tokens?: Tokens;
+  /** Called with the new tokens after a sign in or a refresh, and null after a sign out */
+  onTokens?: (tokens: Tokens | null) => void;
+  /** Authenticates the device operations, with the token returned when pairing */
+  deviceToken?: string;
+  /** Sends the requests instead of the global fetch */
+  fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+  signal?: AbortSignal;
+  headers?: Record<string, string>;
+}
+
+/** An error response of the API */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly code: string,
+    message: string,
+    /** By field, for validation errors */
+    readonly errors?: Record<string, string>,
+    readonly requestId?: string,
+  ) {
+    super(message);
+    this.name = 'ApiError';
+  }
+}
+
+export type Auth = 'none' | 'user' | 'device';
+
+export interface Call {
+  method: string;
+  path: string;
+  query?: object;
+  body?: unknown;
+  form?: Record<string, unknown>;
+  auth: Auth;
+}
+
+export interface Envelope<T> {
+  data: T;
+  meta?: unknown;
+}
+
+export class ClientBase {
+  private readonly baseUrl: string;
+  private readonly fetcher: typeof fetch;
+  private tokens: Tokens | null;
+  private deviceToken: string | null;
+  // Concurrent requests failing with the same token share one refresh
+  private refreshing: Promise<boolean> | null = null;
+
+  constructor(private readonly options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/$/, '') + basePath;
+    this.fetcher = options.fetch ?? globalThis.fetch.bind(globalThis);
+    this.tokens = options.tokens ?? null;
+    this.deviceToken = options.deviceToken ?? null;
+  }
+
+  /** The current tokens, null when signed out */
+  getTokens(): Tokens | null {
+    return this.tokens;
+  }
+
+  /** Replaces the tokens sent with the requests, null signs out */
+  setTokens(tokens: Tokens | null): void {
+    this.tokens = tokens;
+    this.options.onTokens?.(tokens);
+  }
+
+  setDeviceToken(token: string | null): void {
+    this.deviceToken = token;
+  }
+
+  /** Sends req and unwraps the response envelope */
+  protected async call<T>(req: Call, init?: RequestOptions): Promise<Envelope<T>> {
+    const res = await this.send(req, init);
+    return (await res.json()) as Envelope<T>;
+  }
+
+  /** Sends req and returns the response body */
+  protected async download(req: Call, init?: RequestOptions): Promise<Blob> {
+    const res = await this.send(req, init);
+    return res.blob();
+  }
+
+  private async send(req: Call, init?: RequestOptions): Promise<Response> {
+    let [res, token] = await this.attempt(req, init);
+
+    if (res.status === 401 && req.auth === 'user' && token && (await this.refresh(token))) {
+      [res] = await this.attempt(req, init);
+    }
+
+    if (!res.ok) {
+      const body = await res.json().catch(() => ({}));
+      throw new ApiError(res.status, body.code ?? res.statusText, body.message ?? res.statusText, body.errors, body.requestId);
+    }
+    return res;
+  }
+
+  /** Sends req once, returns the access token it was sent with */
+  private async attempt(req: Call, init?: RequestOptions): Promise<[Response, string | null]> {
+    const url = new URL(this.baseUrl + req.path);
+    for (const [name, value] of Object.entries(req.query ?? {})) {
+      if (value !== undefined && value !== null) {
+        url.searchParams.set(name, String(value));
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: 'application/json', ...init?.headers };
+    let body: BodyInit | undefined;
+    if (req.form) {
+      // Fields go before files, the server streams files and reads fields as they come
+      const form = new FormData();
+      const entries = Object.entries(req.form);
+      for (const [name, value] of entries) {
+        if (!(value instanceof Blob) && value !== undefined && value !== null) {
+          form.append(name, String(value));
+        }
+      }
+      for (const [name, value] of entries) {
+        if (value instanceof Blob) {
+          form.append(name, value);
+        }
+      }
+      body = form;
+    } else if (req.body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+      body = JSON.stringify(req.body);
+    }
+
+    const token = req.auth === 'user' ? this.tokens?.token ?? null : req.auth === 'device' ? this.deviceToken : null;
+    if (token) {
+      headers.Authorization = `Bearer ${token}`;
+    }
+
+    const res = await this.fetcher(url, { method: req.method, headers, body, signal: init?.signal });
+    return [res, token];
+  }
+
+  /** Exchanges the refresh token after stale was rejected, resolves whether to retry */
+  private refresh(stale: string): Promise<boolean> {
+    const tokens = this.tokens;
+    // Another request refreshed the token meanwhile
+    if (!tokens || tokens.token !== stale) {
+      return Promise.resolve(!!tokens);
+    }
+    if (!refreshPath || !tokens.refreshToken) {
+      return Promise.resolve(false);
+    }
+
+    if (!this.refreshing) {
+      const refreshToken = tokens.refreshToken;
+      this.refreshing = this.call<Tokens>({ method: 'POST', path: refreshPath, body: { refreshToken }, auth: 'none' })
+        .then(({ data }) => {
+          this.setTokens({ token: data.token, refreshToken: data.refreshToken });
+          return true;
+        })
+        .catch(() => false)
+        .finally(() => {
+          this.refreshing = null;
+        });
+    }
+    return this.refreshing;
+  }
+}
+
+/** Data of a paginated operation with its meta */
+export interface Page<T> {
+  data: T;
+  meta: Meta;
+}
+
+/** consent.AcceptRequest */
+export interface AcceptRequest {
+  privacy?: string;
+  terms?: string;
+}
+
+/** usage.AccountUsageResponse */
+export interface AccountUsageResponse {
+  days?: UsageDayResponse[];
+  from?: string;
+  quota?: QuotaResponse;
+  requests?: number;
+  to?: string;
+}
+
+/** coach.AssignRequest */
+export interface AssignRequest {
+  /** UTC date, today or later */
+  dueOn: string;
+  notes?: string;
+  /** minutes/100m */
+  targetPace?: number;
+  trainingId: string;
+}
+
+/** coach.AssignmentResponse */
+export interface AssignmentResponse {
+  athlete?: AssignmentUserResponse;
+  coach?: AssignmentUserResponse;
+  /** unset until completed */
+  completion?: CompletionResponse;
+  createdAt?: string;
+  dueOn?: string;
+  id?: string;
+  notes?: string;
+  status?: 'pending' | 'overdue' | 'completed';
+  targetPace?: number;
+  trainingId?: string;
+  trainingName?: string;
+}
+
+/** coach.AssignmentUserResponse */
+export interface AssignmentUserResponse {
+  name?: string;
+  userId?: string;
+}
+
+/** coach.AthleteComplianceResponse */
+export interface AthleteComplianceResponse {
+  assigned?: number;
+  completed?: number;
+  name?: string;
+  onTime?: number;
+  overdue?: number;
+  /** of those, swum at or under it */
+  paceMet?: number;
+  pending?: number;
+  /** percent completed on time of those no longer pending, unset without any */
+  rate?: number;
+  userId?: string;
+  /** completed with a target pace */
+  withTargetPace?: number;
+}
+
+/** admin.AuditEventResponse */
+export interface AuditEventResponse {
+  action?: string;
+  actorEmail?: string;
+  createdAt?: string;
+  id?: string;
+  metadata?: Record<string, unknown>;
+}
+
+/** user.AvatarResponse */
+export interface AvatarResponse {
+  avatarUrl?: string;
+}
+
+/** billing.BillingResponse */
+export interface BillingResponse {
+  entitlements?: string[];
+  plan?: 'free' | 'premium';
+  /** latest subscription, unset when never subscribed */
+  subscription?: SubscriptionResponse;
+}
+
+/** pool.CheckInRequest */
+export interface CheckInRequest {
+  code?: string;
+  latitude?: number;
+  longitude?: number;
+}
+
+/** pool.CheckInResponse */
+export interface CheckInResponse {
+  checkedInAt?: string;
+  /** sessions finished until then are swum at the pool */
+  expiresAt?: string;
+  id?: string;
+  method?: 'qr' | 'geofence';
+  pool?: PoolSummaryResponse;
+}
+
+/** billing.CheckoutRequest */
+export interface CheckoutRequest {
+  plan: 'premium';
+}
+
+/** billing.CheckoutResponse */
+export interface CheckoutResponse {
+  id?: string;
+  /** where the user pays */
+  url?: string;
+}
+
+/** coach.CompletionResponse */
+export interface CompletionResponse {
+  completedAt?: string;
+  onTime?: boolean;
+  pace?: number;
+  /** unset without target pace */
+  paceMet?: boolean;
+  sessionId?: string;
+}
+
+/** coach.ComplianceResponse */
+export interface ComplianceResponse {
+  athletes?: AthleteComplianceResponse[];
+  from?: string;
+  to?: string;
+}
+
+/** consent.ConsentResponse */
+export interface ConsentResponse {
+  documents?: DocumentResponse[];
+  marketing?: MarketingResponse;
+}
+
+/** auth.DenySignInRequest */
+export interface DenySignInRequest {
+  token: string;
+}
+
+/** device.DeviceResponse */
+export interface DeviceResponse {
+  createdAt?: string;
+  id?: string;
+  lastSeenAt?: string;
+  name?: string;
+  platform?: string;
+}
+
+/** consent.DocumentResponse */
+export interface DocumentResponse {
+  acceptedAt?: string;
+  acceptedVersion?: string;
+  currentVersion?: string;
+  document?: 'terms' | 'privacy';
+  pending?: boolean;
+}
+
+/** race.EntryResponse */
+export interface EntryResponse {
+  durationSeconds?: number;
+  finished?: boolean;
+  finishedAt?: string;
+  rank?: number;
+  registeredAt?: string;
+  sessionId?: string;
+}
+
+/** equipment.EquipmentRequest */
+export interface EquipmentRequest {
+  brand?: string;
+  kind: 'fins' | 'paddles' | 'wetsuit' | 'pull_buoy' | 'kickboard' | 'snorkel' | 'other';
+  name: string;
+  notes?: string;
+  /** Retired gear stays in the stats but is listed last */
+  retired?: boolean;
+}
+
+/** equipment.EquipmentResponse */
+export interface EquipmentResponse {
+  brand?: string;
+  createdAt?: string;
+  id?: string;
+  kind?: string;
+  name?: string;
+  notes?: string;
+  retired?: boolean;
+  updatedAt?: string;
+  /** lifetime */
+  usage?: UsageResponse;
+}
+
+/** equipment.EquipmentStatsResponse */
+export interface EquipmentStatsResponse {
+  equipment?: EquipmentUsageResponse[];
+  kinds?: KindUsageResponse[];
+  year?: number;
+}
+
+/** equipment.EquipmentUsageResponse */
+export interface EquipmentUsageResponse {
+  distanceMeters?: number;
+  durationSeconds?: number;
+  id?: string;
+  kind?: string;
+  lastUsedAt?: string;
+  name?: string;
+  retired?: boolean;
+  sessions?: number;
+}
+
+/** response.FacetValue */
+export interface FacetValue {
+  count?: number;
+  label?: string;
+  value?: string;
+}
+
+/** response.Facets */
+export type Facets = Record<string, FacetValue[]>;
+
+/** race.FinisherResponse */
+export interface FinisherResponse {
+  durationSeconds?: number;
+  finishedAt?: string;
+  name?: string;
+  rank?: number;
+}
+
+/** coach.GrantCoachRequest */
+export interface GrantCoachRequest {
+  email: string;
+}
+
+/** abuse.GuestFlagResponse */
+export interface GuestFlagResponse {
+  createdAt?: string;
+  details?: Record<string, unknown>;
+  fingerprint?: string;
+  id?: string;
+  occurrences?: number;
+  reason?: 'many_sessions' | 'implausible_session';
+  throttled?: boolean;
+  throttledUntil?: string;
+  updatedAt?: string;
+  userAgent?: string;
+}
+
+/** stats.HeartRateSessionResponse */
+export interface HeartRateSessionResponse {
+  createdAt?: string;
+  id?: string;
+  /** time of the laps with a heart rate */
+  seconds?: number;
+  zones?: HeartRateZoneTimeResponse[];
+}
+
+/** stats.HeartRateZoneResponse */
+export interface HeartRateZoneResponse {
+  maxBpm?: number;
+  minBpm?: number;
+  name?: string;
+  percent?: number;
+  seconds?: number;
+  zone?: number;
+}
+
+/** stats.HeartRateZoneTimeResponse */
+export interface HeartRateZoneTimeResponse {
+  percent?: number;
+  seconds?: number;
+  zone?: number;
+}
+
+/** stats.HeartRateZonesResponse */
+export interface HeartRateZonesResponse {
+  from?: string;
+  injuries?: InjuryPeriodResponse[];
+  maxHeartRate?: number;
+  maxHeartRateSource?: 'preferences' | 'age';
+  period?: string;
+  sessions?: HeartRateSessionResponse[];
+  to?: string;
+  zones?: HeartRateZoneResponse[];
+}
+
+/** admin.ImpersonateRequest */
+export interface ImpersonateRequest {
+  readOnly?: boolean;
+  reason: string;
+}
+
+/** admin.ImpersonationResponse */
+export interface ImpersonationResponse {
+  expiresIn?: number;
+  /** audit event of the impersonation */
+  id?: string;
+  readOnly?: boolean;
+  token?: string;
+}
+
+/** stats.InjuryPeriodResponse */
+export interface InjuryPeriodResponse {
+  id?: string;
+  injuredOn?: string;
+  resolvedOn?: string;
+  severity?: 'mild' | 'moderate' | 'severe';
+  type?: string;
+}
+
+/** injury.InjuryRequest */
+export interface InjuryRequest {
+  /** InjuredOn and ResolvedOn are dates, ex: 2025-09-21. Without ResolvedOn the injury is ongoing. */
+  injuredOn: string;
+  notes?: string;
+  resolvedOn?: string;
+  severity: 'mild' | 'moderate' | 'severe';
+  type: string;
+}
+
+/** injury.InjuryResponse */
+export interface InjuryResponse {
+  createdAt?: string;
+  id?: string;
+  injuredOn?: string;
+  notes?: string;
+  ongoing?: boolean;
+  resolvedOn?: string;
+  severity?: 'mild' | 'moderate' | 'severe';
+  type?: string;
+  updatedAt?: string;
+}
+
+/** equipment.KindUsageResponse */
+export interface KindUsageResponse {
+  distanceMeters?: number;
+  durationSeconds?: number;
+  kind?: string;
+  lastUsedAt?: string;
+  sessions?: number;
+}
+
+/** pool.LaneRequest */
+export interface LaneRequest {
+  /** swimmers at most */
+  capacity?: number;
+  label?: string;
+  status: 'open' | 'closed' | 'reserved';
+  swimmers?: number;
+}
+
+/** pool.LaneResponse */
+export interface LaneResponse {
+  /** open and under capacity */
+  available?: boolean;
+  capacity?: number;
+  label?: string;
+  number?: number;
+  status?: 'open' | 'closed' | 'reserved';
+  swimmers?: number;
+  updatedAt?: string;
+}
+
+/** pool.LaneUpdateRequest */
+export interface LaneUpdateRequest {
+  status: 'open' | 'closed' | 'reserved';
+  swimmers?: number;
+}
+
+/** pool.LanesRequest */
+export interface LanesRequest {
+  /** lanes[0] is lane 1 */
+  lanes: LaneRequest[];
+}
+
+/** consent.MarketingRequest */
+export interface MarketingRequest {
+  optedIn: boolean;
+}
+
+/** consent.MarketingResponse */
+export interface MarketingResponse {
+  optedIn?: boolean;
+  optedInAt?: string;
+  optedOutAt?: string;
+}
+
+/** coach.MemberResponse */
+export interface MemberResponse {
+  email?: string;
+  grantedAt?: string;
+  name?: string;
+  userId?: string;
+}
+
+/** response.Message */
+export interface Message {
+  message?: string;
+}
+
+/** response.Meta */
+export interface Meta {
+  facets?: Facets;
+  pagination?: Pagination;
+  requestId?: string;
+}
+
+/** pool.MyPoolResponse */
+export interface MyPoolResponse {
+  address?: string;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  lastSwumAt?: string;
+  pool?: PoolSummaryResponse;
+  sessions?: number;
+}
+
+/** pool.OccupancyResponse */
+export interface OccupancyResponse {
+  availableLanes?: number;
+  lanes?: LaneResponse[];
+  pool?: PoolSummaryResponse;
+  swimmers?: number;
+  /** last lane update */
+  updatedAt?: string;
+}
+
+/** stats.OpenWaterMonthResponse */
+export interface OpenWaterMonthResponse {
+  avgWaterTemperatureC?: number;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  maxWaterTemperatureC?: number;
+  minWaterTemperatureC?: number;
+  month?: string;
+  sessions?: number;
+  wetsuitSessions?: number;
+}
+
+/** stats.OpenWaterStatsResponse */
+export interface OpenWaterStatsResponse {
+  avgWaterTemperatureC?: number;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  injuries?: InjuryPeriodResponse[];
+  maxWaterTemperatureC?: number;
+  minWaterTemperatureC?: number;
+  months?: OpenWaterMonthResponse[];
+  sessions?: number;
+  wetsuitSessions?: number;
+  year?: number;
+}
+
+/** response.Pagination */
+export interface Pagination {
+  limit?: number;
+  page?: number;
+  /** totals are approximate on large lists */
+  totalEstimated?: boolean;
+  totalItems?: number;
+  totalPages?: number;
+}
+
+/** device.PairDeviceRequest */
+export interface PairDeviceRequest {
+  name: string;
+  platform: 'watchos' | 'wearos' | 'garmin' | 'ios' | 'android';
+}
+
+/** device.PairDeviceResponse */
+export interface PairDeviceResponse {
+  device?: DeviceResponse;
+  /** keys the signature of batches, never sent */
+  signingSecret?: string;
+  token?: string;
+}
+
+/** billing.PaymentResponse */
+export interface PaymentResponse {
+  /** smallest currency unit */
+  amount?: number;
+  currency?: string;
+  id?: string;
+  paidAt?: string;
+  refunded?: number;
+  userId?: string;
+}
+
+/** pool.PoolRequest */
+export interface PoolRequest {
+  address?: string;
+  latitude?: number;
+  lengthMeters?: number;
+  longitude?: number;
+  name: string;
+  /** geofence */
+  radiusMeters: number;
+}
+
+/** pool.PoolResponse */
+export interface PoolResponse {
+  address?: string;
+  /** to encode in the QR posted at the pool */
+  checkInCode?: string;
+  createdAt?: string;
+  id?: string;
+  latitude?: number;
+  lengthMeters?: number;
+  longitude?: number;
+  name?: string;
+  radiusMeters?: number;
+  updatedAt?: string;
+}
+
+/** pool.PoolSummaryResponse */
+export interface PoolSummaryResponse {
+  id?: string;
+  lengthMeters?: number;
+  name?: string;
+}
+
+/** user.PreferencesRequest */
+export interface PreferencesRequest {
+  maxHeartRate?: number;
+  timezone: string;
+  /** version read, preferences edited since are refused */
+  version?: number;
+  weeklyDigest?: boolean;
+}
+
+/** user.PreferencesResponse */
+export interface PreferencesResponse {
+  maxHeartRate?: number;
+  timezone?: string;
+  version?: number;
+  weeklyDigest?: boolean;
+}
+
+/** usage.QuotaResponse */
+export interface QuotaResponse {
+  limit?: number;
+  remaining?: number;
+  resetsAt?: string;
+  used?: number;
+}
+
+/** race.RaceRequest */
+export interface RaceRequest {
+  /** slowest accepted time */
+  cutoffSeconds?: number;
+  description?: string;
+  distanceMeters: number;
+  endsAt: string;
+  name: string;
+  startsAt: string;
+}
+
+/** race.RaceResponse */
+export interface RaceResponse {
+  cutoffSeconds?: number;
+  description?: string;
+  distanceMeters?: number;
+  endsAt?: string;
+  entries?: number;
+  /** of the signed in swimmer, when registered */
+  entry?: EntryResponse;
+  finishers?: number;
+  id?: string;
+  name?: string;
+  startsAt?: string;
+  status?: 'upcoming' | 'open' | 'closed';
+}
+
+/** auth.RefreshTokenRequest */
+export interface RefreshTokenRequest {
+  refreshToken: string;
+}
+
+/** auth.RefreshTokenResponse */
+export interface RefreshTokenResponse {
+  expiresInMs?: number;
+  refreshToken?: string;
+  token?: string;
+}
+
+/** billing.RefundRequest */
+export interface RefundRequest {
+  /** smallest currency unit */
+  amount?: number;
+}
+
+/** race.ResultRequest */
+export interface ResultRequest {
+  sessionId: string;
+}
+
+/** equipment.SessionEquipmentRequest */
+export interface SessionEquipmentRequest {
+  equipmentIds?: string[];
+}
+
+/** auth.SignInGuestRequest */
+export interface SignInGuestRequest {
+  age?: number;
+  gender?: 'male' | 'female';
+  height?: number;
+  weight?: number;
+}
+
+/** auth.SignInGuestResponse */
+export interface SignInGuestResponse {
+  age?: number;
+  expiresIn?: number;
+  gender?: string;
+  height?: number;
+  name?: string;
+  refreshToken?: string;
+  token?: string;
+  weight?: number;
+}
+
+/** auth.SignInRequest */
+export interface SignInRequest {
+  email: string;
+  password: string;
+}
+
+/** auth.SignInResponse */
+export interface SignInResponse {
+  age?: number;
+  email?: string;
+  expiresIn?: number;
+  gender?: string;
+  height?: number;
+  name?: string;
+  refreshToken?: string;
+  token?: string;
+  weight?: number;
+}
+
+/** auth.SignUpRequest */
+export interface SignUpRequest {
+  age?: number;
+  confirmPassword: string;
+  email: string;
+  gender?: 'male' | 'female';
+  height?: number;
+  /** opt in to marketing emails, unchecked by default */
+  marketingEmails?: boolean;
+  name: string;
+  password: string;
+  weight?: number;
+}
+
+/** billing.SubscriptionResponse */
+export interface SubscriptionResponse {
+  cancelAtPeriodEnd?: boolean;
+  currentPeriodEnd?: string;
+  plan?: string;
+  /** as the payment provider reports it */
+  status?: string;
+}
+
+/** event.TrackEventRequest */
+export interface TrackEventRequest {
+  anonymousId?: string;
+  name: string;
+  occurredAt?: string;
+  properties?: unknown;
+}
+
+/** event.TrackEventsRequest */
+export interface TrackEventsRequest {
+  events: TrackEventRequest[];
+}
+
+/** training.TrainingAggregatesResponse */
+export interface TrainingAggregatesResponse {
+  /** sessions of the user on the training */
+  completions?: number;
+}
+
+/** training.TrainingApproveRequest */
+export interface TrainingApproveRequest {
+  version?: number;
+}
+
+/** training.TrainingConditionsRequest */
+export interface TrainingConditionsRequest {
+  currentNotes?: string;
+  currentSpeedKmh?: number;
+  latitude?: number;
+  longitude?: number;
+  waterTemperatureC?: number;
+  waveHeightMeters?: number;
+  waveNotes?: string;
+  wetsuit?: boolean;
+}
+
+/** training.TrainingConditionsResponse */
+export interface TrainingConditionsResponse {
+  /** a measurement comes from the weather provider */
+  autoFilled?: boolean;
+  currentNotes?: string;
+  currentSpeedKmh?: number;
+  latitude?: number;
+  longitude?: number;
+  updatedAt?: string;
+  waterTemperatureC?: number;
+  waveHeightMeters?: number;
+  waveNotes?: string;
+  wetsuit?: boolean;
+}
+
+/** training.TrainingDeletedSessionResponse */
+export interface TrainingDeletedSessionResponse {
+  caloriesKcal?: number;
+  conditions?: TrainingConditionsResponse;
+  createdAt?: string;
+  deletedAt?: string;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  id?: string;
+  laps?: TrainingLapResponse[];
+  pace?: number;
+  rpe?: number;
+  trainingId?: string;
+  userId?: string;
+}
+
+/** training.TrainingDuplicateResponse */
+export interface TrainingDuplicateResponse {
+  detectedAt?: string;
+  duplicateOf?: TrainingSessionDetailResponse;
+  id?: string;
+  overlapSeconds?: number;
+  session?: TrainingSessionDetailResponse;
+}
+
+/** training.TrainingExportFileResponse */
+export interface TrainingExportFileResponse {
+  expiresAt?: string;
+  url?: string;
+}
+
+/** training.TrainingFinishSessionRequest */
+export interface TrainingFinishSessionRequest {
+  conditions?: TrainingConditionsRequest;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  laps?: TrainingLapRequest[];
+  /** records a session failing the plausibility checks, admins only */
+  override?: boolean;
+  /** the distance must be a whole number of lengths */
+  poolLengthMeters?: number;
+  /** rate of perceived exertion */
+  rpe?: number;
+}
+
+/** training.TrainingImportSessionRequest */
+export interface TrainingImportSessionRequest {
+  conditions?: TrainingConditionsRequest;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  laps?: TrainingLapRequest[];
+  rpe?: number;
+  startedAt: string;
+  trainingId: string;
+}
+
+/** training.TrainingImportSessionsRequest */
+export interface TrainingImportSessionsRequest {
+  sessions: TrainingImportSessionRequest[];
+  source?: 'watch' | 'google_fit' | 'apple_health';
+}
+
+/** training.TrainingImportSessionsResponse */
+export interface TrainingImportSessionsResponse {
+  imported?: number;
+  possibleDuplicates?: number;
+  sessions?: TrainingSessionResponse[];
+}
+
+/** training.TrainingItemResponse */
+export interface TrainingItemResponse {
+  descriptions?: string;
+  id?: string;
+  level?: string;
+  name?: string;
+  thumbnailUrl?: string;
+}
+
+/** training.TrainingLapRequest */
+export interface TrainingLapRequest {
+  avgHeartRate?: number;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  strokeCount?: number;
+}
+
+/** training.TrainingLapResponse */
+export interface TrainingLapResponse {
+  avgHeartRate?: number;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  number?: number;
+  strokeCount?: number;
+}
+
+/** stats.TrainingLoadDayResponse */
+export interface TrainingLoadDayResponse {
+  acute?: number;
+  chronic?: number;
+  date?: string;
+  load?: number;
+  ratio?: number;
+  sessions?: number;
+}
+
+/** stats.TrainingLoadResponse */
+export interface TrainingLoadResponse {
+  acute?: number;
+  atRisk?: boolean;
+  chronic?: number;
+  days?: TrainingLoadDayResponse[];
+  from?: string;
+  injuries?: InjuryPeriodResponse[];
+  /** unset without chronic load */
+  ratio?: number;
+  riskThreshold?: number;
+  to?: string;
+}
+
+/** training.TrainingMergeDuplicateRequest */
+export interface TrainingMergeDuplicateRequest {
+  keepId?: string;
+}
+
+/** training.TrainingRejectRequest */
+export interface TrainingRejectRequest {
+  reason: string;
+  version?: number;
+}
+
+/** training.TrainingRequest */
+export interface TrainingRequest {
+  caloriesKcal?: number;
+  categoryCode: string;
+  content: string;
+  descriptions: string;
+  level: string;
+  name: string;
+  thumbnailUrl: string;
+  time: string;
+  videoUrl?: string;
+}
+
+/** training.TrainingResponse */
+export interface TrainingResponse {
+  aggregates?: TrainingAggregatesResponse;
+  caloriesKcal?: number;
+  categoryCode?: string;
+  categoryName?: string;
+  content?: string;
+  descriptions?: string;
+  id?: string;
+  level?: string;
+  name?: string;
+  reviewReason?: string;
+  status?: 'pending_review' | 'approved' | 'rejected';
+  thumbnailUrl?: string;
+  timeLabel?: string;
+  /** sent back by edits, a training edited since is refused */
+  version?: number;
+  videoUrl?: string;
+}
+
+/** training.TrainingReviewResponse */
+export interface TrainingReviewResponse {
+  authorId?: string;
+  categoryCode?: string;
+  createdAt?: string;
+  deletedAt?: string;
+  id?: string;
+  level?: string;
+  name?: string;
+  reviewReason?: string;
+  reviewedAt?: string;
+  status?: 'pending_review' | 'approved' | 'rejected';
+  version?: number;
+}
+
+/** training.TrainingSessionDetailResponse */
+export interface TrainingSessionDetailResponse {
+  caloriesKcal?: number;
+  conditions?: TrainingConditionsResponse;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  id?: string;
+  laps?: TrainingLapResponse[];
+  pace?: number;
+  rpe?: number;
+  source?: 'manual' | 'import' | 'watch' | 'google_fit' | 'apple_health' | 'sync';
+  startedAt?: string;
+  trainingId?: string;
+  userId?: string;
+}
+
+/** training.TrainingSessionExportResponse */
+export interface TrainingSessionExportResponse {
+  caloriesKcal?: number;
+  conditions?: TrainingConditionsResponse;
+  createdAt?: string;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  id?: string;
+  laps?: TrainingLapResponse[];
+  pace?: number;
+  rpe?: number;
+  trainingId?: string;
+  userId?: string;
+}
+
+/** training.TrainingSessionResponse */
+export interface TrainingSessionResponse {
+  caloriesKcal?: number;
+  conditions?: TrainingConditionsResponse;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  id?: string;
+  laps?: TrainingLapResponse[];
+  pace?: number;
+  /** pool the swimmer was checked in at */
+  poolId?: string;
+  rpe?: number;
+  trainingId?: string;
+  userId?: string;
+}
+
+/** training.TrainingSyncResult */
+export interface TrainingSyncResult {
+  conflicts?: string[];
+  errors?: Record<string, string>;
+  session?: TrainingSessionResponse;
+  status?: 'created' | 'updated' | 'unchanged' | 'rejected';
+}
+
+/** training.TrainingSyncSessionRequest */
+export interface TrainingSyncSessionRequest {
+  clientId: string;
+  distanceMeters?: number;
+  durationSeconds?: number;
+  laps?: TrainingLapRequest[];
+  startedAt: string;
+  trainingId: string;
+  updatedAt: string;
+}
+
+/** training.TrainingSyncSessionsRequest */
+export interface TrainingSyncSessionsRequest {
+  sessions?: TrainingSyncSessionRequest[];
+}
+
+/** training.TrainingSyncSessionsResponse */
+export interface TrainingSyncSessionsResponse {
+  possibleDuplicates?: number;
+  results?: Record<string, TrainingSyncResult>;
+}
+
+/** usage.UsageClientResponse */
+export interface UsageClientResponse {
+  deviceId?: string;
+  kind?: 'app' | 'device';
+  requests?: number;
+}
+
+/** usage.UsageDayResponse */
+export interface UsageDayResponse {
+  clients?: UsageClientResponse[];
+  date?: string;
+  requests?: number;
+}
+
+/** equipment.UsageResponse */
+export interface UsageResponse {
+  distanceMeters?: number;
+  durationSeconds?: number;
+  lastUsedAt?: string;
+  sessions?: number;
+}
+
+/** admin.UserDetailResponse */
+export interface UserDetailResponse {
+  accountId?: string;
+  activeSignIns?: number;
+  auditEvents?: AuditEventResponse[];
+  createdAt?: string;
+  deletedAt?: string;
+  email?: string;
+  lastActivityAt?: string;
+  locked?: boolean;
+  name?: string;
+  organizationId?: string;
+  role?: 'user' | 'admin';
+  sessionsCount?: number;
+  userId?: string;
+}
+
+/** admin.UserSummaryResponse */
+export interface UserSummaryResponse {
+  accountId?: string;
+  createdAt?: string;
+  deletedAt?: string;
+  email?: string;
+  locked?: boolean;
+  name?: string;
+  role?: 'user' | 'admin';
+  userId?: string;
+}
+
+/** Query parameters of getAPIUsage */
+export interface GetAPIUsageParams {
+  /** Days of the range, today included */
+  days?: number;
+}
+
+/** Query parameters of listFlaggedGuests */
+export interface ListFlaggedGuestsParams {
+  /** Flag reason */
+  reason?: 'many_sessions' | 'implausible_session';
+  /** Page number */
+  page?: number;
+  /** Number of items per page */
+  limit?: number;
+  /** Sort field and direction */
+  sort?: 'updated_at.asc' | 'updated_at.desc' | 'occurrences.asc' | 'occurrences.desc';
+}
+
+/** Query parameters of listTrainingsByReviewStatus */
+export interface ListTrainingsByReviewStatusParams {
+  /** Review status */
+  status?: 'pending_review' | 'approved' | 'rejected';
+}
+
+/** Query parameters of searchUsers */
+export interface SearchUsersParams {
+  /** Part of the email or name */
+  query?: string;
+  /** Search the deleted users */
+  deleted?: boolean;
+  /** Page number */
+  page?: number;
+  /** Number of items per page */
+  limit?: number;
+  /** Sort field and direction */
+  sort?: 'email.asc' | 'email.desc' | 'name.asc' | 'name.desc' | 'created_at.asc' | 'created_at.desc';
+}
+
+/** Query parameters of listAssignedTrainings */
+export interface ListAssignedTrainingsParams {
+  /** Only the assignments in this status */
+  status?: 'pending' | 'overdue' | 'completed';
+}
+
+/** Query parameters of assignmentCompliance */
+export interface AssignmentComplianceParams {
+  /** Days of the range, today included */
+  days?: number;
+}
+
+/** Query parameters of listAthleteAssignments */
+export interface ListAthleteAssignmentsParams {
+  /** Only the assignments in this status */
+  status?: 'pending' | 'overdue' | 'completed';
+}
+
+/** Query parameters of equipmentUsage */
+export interface EquipmentUsageParams {
+  /** Season, the current year by default */
+  year?: number;
+}
+
+/** Query parameters of downloadStoredFile */
+export interface DownloadStoredFileParams {
+  /** Signed link expiry, unix seconds */
+  expires?: number;
+  /** Signed link signature */
+  signature?: string;
+}
+
+/** Query parameters of listRaces */
+export interface ListRacesParams {
+  /** Race status */
+  status?: 'upcoming' | 'open' | 'closed';
+  /** Page number */
+  page?: number;
+  /** Number of items per page */
+  limit?: number;
+  /** Sort field and direction */
+  sort?: 'starts_at.asc' | 'starts_at.desc' | 'name.asc' | 'name.desc';
+}
+
+/** Query parameters of listRaceFinishers */
+export interface ListRaceFinishersParams {
+  /** Page number */
+  page?: number;
+  /** Number of items per page */
+  limit?: number;
+}
+
+/** Query parameters of heartRateZones */
+export interface HeartRateZonesParams {
+  /** Rolling period ending now */
+  period?: 'week' | 'month' | 'year';
+}
+
+/** Query parameters of openWaterSeason */
+export interface OpenWaterSeasonParams {
+  /** Season, the current year by default */
+  year?: number;
+}
+
+/** Query parameters of trainingLoad */
+export interface TrainingLoadParams {
+  /** Days of the range, today included */
+  days?: number;
+}
+
+/** Query parameters of getTrainingsWithPagination */
+export interface GetTrainingsWithPaginationParams {
+  /** Page number */
+  page?: number;
+  /** Number of items per page */
+  limit?: number;
+  /** Sort field and direction */
+  sort?: 'name.asc' | 'name.desc' | 'level.asc' | 'level.desc' | 'created_at.asc' | 'created_at.desc';
+  /** Search term for training name and description */
+  search?: string;
+  /** Category code to filter by */
+  category?: string;
+  /** Level to filter by */
+  level?: string;
+  /** Comma separated filters to count the trainings of by value, returned in meta.facets */
+  facets?: string;
+}
+
+/** Query parameters of exportTrainingSessions */
+export interface ExportTrainingSessionsParams {
+  /** Stream format */
+  format?: 'json' | 'ndjson';
+}
+
+/** Query parameters of getTrainingByID */
+export interface GetTrainingByIDParams {
+  /** Comma separated aggregates to embed */
+  include?: string;
+}
+
+/** Multipart form of uploadTrainingMedia */
+export interface UploadTrainingMediaForm {
+  /** Thumbnail image */
+  thumbnail?: Blob;
+  /** Version of the training read, sent before the files */
+  version?: number;
+  /** Video */
+  video?: Blob;
+}
+
+/** Multipart form of uploadAvatar */
+export interface UploadAvatarForm {
+  /** Avatar image */
+  avatar: Blob;
+}
+
+export class Client extends ClientBase {
+  /**
+   * Get API usage
+   *
+   * Requests of the account on each UTC day of the range ending today, by client: the app, signed in
+   * with an access token, and each paired device calling with its token. Device requests count
+   * against the monthly quota of the account, once it is used up they answer 429 QUOTA_EXCEEDED with
+   * Quota-Limit, Quota-Remaining and Quota-Reset headers until the next UTC month.
+   *
+   * `GET /account/usage`
+   */
+  async getAPIUsage(params?: GetAPIUsageParams, init?: RequestOptions): Promise<AccountUsageResponse> {
+    const { data } = await this.call<AccountUsageResponse>({ method: 'GET', path: '/account/usage', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List flagged guests
+   *
+   * List the open flags of guest clients caught by the abuse heuristics: too many guest sessions
+   * from one fingerprint in an hour, or a reported swim nobody can swim. A throttled fingerprint
+   * must sign up until throttledUntil. Admin only.
+   *
+   * `GET /admin/guests/flagged`
+   */
+  async listFlaggedGuests(params?: ListFlaggedGuestsParams, init?: RequestOptions): Promise<Page<GuestFlagResponse[]>> {
+    const { data, meta } = await this.call<GuestFlagResponse[]>({ method: 'GET', path: '/admin/guests/flagged', query: params, auth: 'user' }, init);
+    return { data, meta: meta as Meta };
+  }
+
+  /**
+   * Clear a guest flag
+   *
+   * Close a flag raised by mistake, its fingerprint may sign in as a guest again unless another open
+   * flag still throttles it. Admin only.
+   *
+   * `DELETE /admin/guests/flagged/{id}`
+   */
+  async clearGuestFlag(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/admin/guests/flagged/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Refund a payment
+   *
+   * Give back an amount of a payment through the payment provider, the rest of it without amount.
+   * The subscription it paid is kept, cancel it at the provider to end it. Sent again with the same
+   * Idempotency-Key, ex: after a timeout, the refund asked first is made once. Admin only.
+   *
+   * `POST /admin/payments/{id}/refund`
+   */
+  async refundPayment(id: string, body: RefundRequest, init?: RequestOptions): Promise<PaymentResponse> {
+    const { data } = await this.call<PaymentResponse>({ method: 'POST', path: `/admin/payments/${encodeURIComponent(id)}/refund`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List pools
+   *
+   * The pools of the organization and the shared ones by name, with the check in code of their QR.
+   * Admin only.
+   *
+   * `GET /admin/pools`
+   */
+  async listPools(init?: RequestOptions): Promise<PoolResponse[]> {
+    const { data } = await this.call<PoolResponse[]>({ method: 'GET', path: '/admin/pools', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Create a pool
+   *
+   * Create a pool of the organization with a new check in code to post as a QR at the pool. Swimmers
+   * within radiusMeters of the location can check in without it. Admin only.
+   *
+   * `POST /admin/pools`
+   */
+  async createPool(body: PoolRequest, init?: RequestOptions): Promise<PoolResponse> {
+    const { data } = await this.call<PoolResponse>({ method: 'POST', path: '/admin/pools', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update a pool
+   *
+   * Replace the details of a pool of the organization, its check in code is kept. Admin only.
+   *
+   * `PUT /admin/pools/{id}`
+   */
+  async updatePool(id: string, body: PoolRequest, init?: RequestOptions): Promise<PoolResponse> {
+    const { data } = await this.call<PoolResponse>({ method: 'PUT', path: `/admin/pools/${encodeURIComponent(id)}`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Publish lanes
+   *
+   * Replace the lane board of a pool of the organization, lanes[0] being lane 1, and push it to the
+   * live boards. Admin only.
+   *
+   * `PUT /admin/pools/{id}/lanes`
+   */
+  async publishLanes(id: string, body: LanesRequest, init?: RequestOptions): Promise<OccupancyResponse> {
+    const { data } = await this.call<OccupancyResponse>({ method: 'PUT', path: `/admin/pools/${encodeURIComponent(id)}/lanes`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update a lane
+   *
+   * Set the status and swimmers of a published lane of a pool of the organization as swimmers come
+   * and go, and push the board to the live boards. Admin only.
+   *
+   * `PUT /admin/pools/{id}/lanes/{number}`
+   */
+  async updateLane(id: string, number: string, body: LaneUpdateRequest, init?: RequestOptions): Promise<OccupancyResponse> {
+    const { data } = await this.call<OccupancyResponse>({ method: 'PUT', path: `/admin/pools/${encodeURIComponent(id)}/lanes/${encodeURIComponent(number)}`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Create a race
+   *
+   * Create a virtual race, swum anywhere between startsAt and endsAt. Admin only.
+   *
+   * `POST /admin/races`
+   */
+  async createRace(body: RaceRequest, init?: RequestOptions): Promise<RaceResponse> {
+    const { data } = await this.call<RaceResponse>({ method: 'POST', path: '/admin/races', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update a race
+   *
+   * Replace the details of a race, results already accepted are kept. Admin only.
+   *
+   * `PUT /admin/races/{id}`
+   */
+  async updateRace(id: string, body: RaceRequest, init?: RequestOptions): Promise<RaceResponse> {
+    const { data } = await this.call<RaceResponse>({ method: 'PUT', path: `/admin/races/${encodeURIComponent(id)}`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Delete a race
+   *
+   * Delete a race nobody registered to yet. Admin only.
+   *
+   * `DELETE /admin/races/{id}`
+   */
+  async deleteRace(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/admin/races/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List trainings by review status
+   *
+   * List up to 100 trainings of the organization in a review status, the pending ones by default.
+   * Oldest first. Admin only.
+   *
+   * `GET /admin/trainings`
+   */
+  async listTrainingsByReviewStatus(params?: ListTrainingsByReviewStatusParams, init?: RequestOptions): Promise<TrainingReviewResponse[]> {
+    const { data } = await this.call<TrainingReviewResponse[]>({ method: 'GET', path: '/admin/trainings', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List deleted trainings
+   *
+   * List up to 100 deleted trainings of the organization, last deleted first. Admin only.
+   *
+   * `GET /admin/trainings/deleted`
+   */
+  async listDeletedTrainings(init?: RequestOptions): Promise<TrainingReviewResponse[]> {
+    const { data } = await this.call<TrainingReviewResponse[]>({ method: 'GET', path: '/admin/trainings/deleted', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Restore a deleted training session
+   *
+   * Put a deleted session back in the history of its user. Admin only.
+   *
+   * `POST /admin/trainings/sessions/{id}/restore`
+   */
+  async restoreDeletedTrainingSession(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: `/admin/trainings/sessions/${encodeURIComponent(id)}/restore`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Delete a training
+   *
+   * Remove a training of the organization from the catalog and the moderation lists, sessions
+   * recorded on it are kept. Trainings of the shared catalog can only be deleted outside an
+   * organization. Admin only.
+   *
+   * `DELETE /admin/trainings/{id}`
+   */
+  async deleteTraining(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/admin/trainings/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Approve a training
+   *
+   * Publish a training pending review to the catalog, its author is notified. With a version, a
+   * training edited since is refused with the current version. Admin only.
+   *
+   * `POST /admin/trainings/{id}/approve`
+   */
+  async approveTraining(id: string, body: TrainingApproveRequest, init?: RequestOptions): Promise<TrainingReviewResponse> {
+    const { data } = await this.call<TrainingReviewResponse>({ method: 'POST', path: `/admin/trainings/${encodeURIComponent(id)}/approve`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Reject a training
+   *
+   * Keep a training pending review out of the catalog, its author is notified with the reason. With
+   * a version, a training edited since is refused with the current version. Admin only.
+   *
+   * `POST /admin/trainings/{id}/reject`
+   */
+  async rejectTraining(id: string, body: TrainingRejectRequest, init?: RequestOptions): Promise<TrainingReviewResponse> {
+    const { data } = await this.call<TrainingReviewResponse>({ method: 'POST', path: `/admin/trainings/${encodeURIComponent(id)}/reject`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Restore a deleted training
+   *
+   * Put a deleted training back in the catalog with the review status it had. Admin only.
+   *
+   * `POST /admin/trainings/{id}/restore`
+   */
+  async restoreDeletedTraining(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: `/admin/trainings/${encodeURIComponent(id)}/restore`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Search users
+   *
+   * Search the users of the organization by email or name, case insensitive and anywhere in the
+   * value. Without query every user is listed. With deleted=true the deleted users are searched
+   * instead. Admin only.
+   *
+   * `GET /admin/users`
+   */
+  async searchUsers(params?: SearchUsersParams, init?: RequestOptions): Promise<Page<UserSummaryResponse[]>> {
+    const { data, meta } = await this.call<UserSummaryResponse[]>({ method: 'GET', path: '/admin/users', query: params, auth: 'user' }, init);
+    return { data, meta: meta as Meta };
+  }
+
+  /**
+   * Get a user
+   *
+   * Account status, recorded sessions, active sign ins, last activity and the 20 latest audit events
+   * of a user. The view itself is audited. Admin only.
+   *
+   * `GET /admin/users/{id}`
+   */
+  async getUser(id: string, init?: RequestOptions): Promise<UserDetailResponse> {
+    const { data } = await this.call<UserDetailResponse>({ method: 'GET', path: `/admin/users/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Delete a user
+   *
+   * Softly delete a user and its account: it can no longer sign in, its sign ins are revoked and it
+   * leaves the lists and stats. Access tokens already issued stay valid until they expire. Admins
+   * cannot be deleted. The deletion is audited and can be undone with restore. Admin only.
+   *
+   * `DELETE /admin/users/{id}`
+   */
+  async deleteUser(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/admin/users/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Impersonate a user
+   *
+   * Mint a token acting as the user to reproduce a reported bug. It is read only unless readOnly is
+   * false, expires after JWT_IMPERSONATION_TTL_MIN minutes and has no refresh token. The
+   * impersonation and every request made with the token are recorded in the audit events of the
+   * user. Admins cannot be impersonated, the token is refused by gRPC. Admin only.
+   *
+   * `POST /admin/users/{id}/impersonate`
+   */
+  async impersonateUser(id: string, body: ImpersonateRequest, init?: RequestOptions): Promise<ImpersonationResponse> {
+    const { data } = await this.call<ImpersonationResponse>({ method: 'POST', path: `/admin/users/${encodeURIComponent(id)}/impersonate`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List the payments of a user
+   *
+   * The invoices paid by a user, last paid first, with the amount refunded of each. Admin only.
+   *
+   * `GET /admin/users/{id}/payments`
+   */
+  async listPaymentsOfUser(id: string, init?: RequestOptions): Promise<PaymentResponse[]> {
+    const { data } = await this.call<PaymentResponse[]>({ method: 'GET', path: `/admin/users/${encodeURIComponent(id)}/payments`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Restore a deleted user
+   *
+   * Restore a deleted user and its account, it can sign in again. The restore is audited. Admin
+   * only.
+   *
+   * `POST /admin/users/{id}/restore`
+   */
+  async restoreDeletedUser(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: `/admin/users/${encodeURIComponent(id)}/restore`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List deleted training sessions of a user
+   *
+   * List up to 100 deleted sessions of a user, last deleted first. Admin only.
+   *
+   * `GET /admin/users/{id}/sessions/deleted`
+   */
+  async listDeletedTrainingSessionsOfUser(id: string, init?: RequestOptions): Promise<TrainingDeletedSessionResponse[]> {
+    const { data } = await this.call<TrainingDeletedSessionResponse[]>({ method: 'GET', path: `/admin/users/${encodeURIComponent(id)}/sessions/deleted`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List assigned trainings
+   *
+   * The trainings assigned to the signed in athlete by their coaches, due in the last 30 days or
+   * later, by due date. A session on the training since the assignment completes it.
+   *
+   * `GET /assignments`
+   */
+  async listAssignedTrainings(params?: ListAssignedTrainingsParams, init?: RequestOptions): Promise<AssignmentResponse[]> {
+    const { data } = await this.call<AssignmentResponse[]>({ method: 'GET', path: '/assignments', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List athletes
+   *
+   * The athletes who granted the signed in coach access to their records, by name
+   *
+   * `GET /athletes`
+   */
+  async listAthletes(init?: RequestOptions): Promise<MemberResponse[]> {
+    const { data } = await this.call<MemberResponse[]>({ method: 'GET', path: '/athletes', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Assignment compliance
+   *
+   * For each athlete of the signed in coach, the assignments due in the range ending today:
+   * completed, on time, overdue, pending while due today, and the completed ones swum at or under
+   * their target pace. The rate is the percent completed on time out of those no longer pending.
+   *
+   * `GET /athletes/compliance`
+   */
+  async assignmentCompliance(params?: AssignmentComplianceParams, init?: RequestOptions): Promise<ComplianceResponse> {
+    const { data } = await this.call<ComplianceResponse>({ method: 'GET', path: '/athletes/compliance', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List athlete assignments
+   *
+   * The assignments of the signed in coach to an athlete due in the last 30 days or later, by due
+   * date
+   *
+   * `GET /athletes/{id}/assignments`
+   */
+  async listAthleteAssignments(id: string, params?: ListAthleteAssignmentsParams, init?: RequestOptions): Promise<AssignmentResponse[]> {
+    const { data } = await this.call<AssignmentResponse[]>({ method: 'GET', path: `/athletes/${encodeURIComponent(id)}/assignments`, query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Assign a training
+   *
+   * Assign an approved training to an athlete who granted the signed in coach access, due on a UTC
+   * date with an optional target pace. The first session of the athlete on the training since the
+   * assignment completes it, whatever the way it was recorded. A coach can have up to 50 assignments
+   * not yet due per athlete.
+   *
+   * `POST /athletes/{id}/assignments`
+   */
+  async assignTraining(id: string, body: AssignRequest, init?: RequestOptions): Promise<AssignmentResponse> {
+    const { data } = await this.call<AssignmentResponse>({ method: 'POST', path: `/athletes/${encodeURIComponent(id)}/assignments`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Remove an assignment
+   *
+   * Remove an assignment of the signed in coach to an athlete, completed or not
+   *
+   * `DELETE /athletes/{id}/assignments/{assignmentId}`
+   */
+  async removeAssignment(id: string, assignmentID: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/athletes/${encodeURIComponent(id)}/assignments/${encodeURIComponent(assignmentID)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List athlete injuries
+   *
+   * The injury and recovery log of an athlete who granted the signed in coach access
+   *
+   * `GET /athletes/{id}/injuries`
+   */
+  async listAthleteInjuries(id: string, init?: RequestOptions): Promise<InjuryResponse[]> {
+    const { data } = await this.call<InjuryResponse[]>({ method: 'GET', path: `/athletes/${encodeURIComponent(id)}/injuries`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get my plan
+   *
+   * The plan and entitlements of the signed in user with their latest subscription
+   *
+   * `GET /billing`
+   */
+  async getMyPlan(init?: RequestOptions): Promise<BillingResponse> {
+    const { data } = await this.call<BillingResponse>({ method: 'GET', path: '/billing', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Subscribe to premium
+   *
+   * Open a hosted checkout of the premium plan for the signed in user, the client sends them to its
+   * url. The plan is granted once the payment provider confirms the subscription.
+   *
+   * `POST /billing/checkout`
+   */
+  async subscribeToPremium(body: CheckoutRequest, init?: RequestOptions): Promise<CheckoutResponse> {
+    const { data } = await this.call<CheckoutResponse>({ method: 'POST', path: '/billing/checkout', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List my payments
+   *
+   * The invoices paid by the signed in user, last paid first, with the amount refunded of each
+   *
+   * `GET /billing/payments`
+   */
+  async listMyPayments(init?: RequestOptions): Promise<PaymentResponse[]> {
+    const { data } = await this.call<PaymentResponse[]>({ method: 'GET', path: '/billing/payments', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List coaches
+   *
+   * The coaches with access to the records of the signed in athlete, newest grant first
+   *
+   * `GET /coaches`
+   */
+  async listCoaches(init?: RequestOptions): Promise<MemberResponse[]> {
+    const { data } = await this.call<MemberResponse[]>({ method: 'GET', path: '/coaches', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Grant coach access
+   *
+   * Give the user signed up with the email read access to the records of the signed in athlete, ex:
+   * injuries
+   *
+   * `POST /coaches`
+   */
+  async grantCoachAccess(body: GrantCoachRequest, init?: RequestOptions): Promise<MemberResponse> {
+    const { data } = await this.call<MemberResponse>({ method: 'POST', path: '/coaches', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Revoke coach access
+   *
+   * Remove the access of a coach to the records of the signed in athlete
+   *
+   * `DELETE /coaches/{id}`
+   */
+  async revokeCoachAccess(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/coaches/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get consents
+   *
+   * The current versions of the terms of service and privacy policy with the versions the account
+   * accepted, and its choice about marketing emails. Reachable while documents are pending.
+   *
+   * `GET /consents`
+   */
+  async getConsents(init?: RequestOptions): Promise<ConsentResponse> {
+    const { data } = await this.call<ConsentResponse>({ method: 'GET', path: '/consents', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Accept legal documents
+   *
+   * Accept the current version of the terms of service, the privacy policy or both. Other endpoints
+   * answer 428 CONSENT_REQUIRED, with the versions to accept by document, until the current versions
+   * are accepted.
+   *
+   * `POST /consents`
+   */
+  async acceptLegalDocuments(body: AcceptRequest, init?: RequestOptions): Promise<ConsentResponse> {
+    const { data } = await this.call<ConsentResponse>({ method: 'POST', path: '/consents', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Set marketing consent
+   *
+   * Opt in or out of marketing emails, the time of the latest opt in and opt out are kept apart from
+   * the legal documents
+   *
+   * `PUT /consents/marketing`
+   */
+  async setMarketingConsent(body: MarketingRequest, init?: RequestOptions): Promise<MarketingResponse> {
+    const { data } = await this.call<MarketingResponse>({ method: 'PUT', path: '/consents/marketing', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List paired devices
+   *
+   * Every device of the user that is not revoked, newest first
+   *
+   * `GET /devices`
+   */
+  async listPairedDevices(init?: RequestOptions): Promise<DeviceResponse[]> {
+    const { data } = await this.call<DeviceResponse[]>({ method: 'GET', path: '/devices', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Pair a device
+   *
+   * Register a watch or companion app install and return its device token and signing secret. They
+   * are shown once. The token never expires and only authorizes the session ingestion of this device
+   * until the device is revoked. The secret signs its batches and is never sent.
+   *
+   * `POST /devices`
+   */
+  async pairDevice(body: PairDeviceRequest, init?: RequestOptions): Promise<PairDeviceResponse> {
+    const { data } = await this.call<PairDeviceResponse>({ method: 'POST', path: '/devices', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Revoke a device
+   *
+   * Unpair a device of the user, its token stops working immediately
+   *
+   * `DELETE /devices/{id}`
+   */
+  async revokeDevice(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/devices/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Upload device sessions
+   *
+   * Import a batch of up to 500 sessions recorded by the device, authenticated with the device token
+   * as bearer. The body is JSON, the same document as msgpack (application/msgpack) or the
+   * swimo.v1.ImportSessionsRequest protobuf message (application/x-protobuf). With replay protection
+   * enabled, the batch is signed: X-Swimo-Timestamp (unix seconds), X-Swimo-Nonce (16 to 128
+   * characters, unique per batch) and X-Swimo-Signature, the hex HMAC-SHA256 keyed with the signing
+   * secret returned at pairing of timestamp, nonce, method, path and body joined by newlines.
+   *
+   * `POST /devices/{id}/sessions`
+   */
+  async uploadDeviceSessions(id: string, body: TrainingImportSessionsRequest, init?: RequestOptions): Promise<TrainingImportSessionsResponse> {
+    const { data } = await this.call<TrainingImportSessionsResponse>({ method: 'POST', path: `/devices/${encodeURIComponent(id)}/sessions`, body, auth: 'device' }, init);
+    return data;
+  }
+
+  /**
+   * List equipment
+   *
+   * Every piece of gear of the user with its lifetime usage, active gear first then newest first
+   *
+   * `GET /equipment`
+   */
+  async listEquipment(init?: RequestOptions): Promise<EquipmentResponse[]> {
+    const { data } = await this.call<EquipmentResponse[]>({ method: 'GET', path: '/equipment', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Register equipment
+   *
+   * Register a piece of gear of the user, ex: fins, paddles or a wetsuit
+   *
+   * `POST /equipment`
+   */
+  async registerEquipment(body: EquipmentRequest, init?: RequestOptions): Promise<EquipmentResponse> {
+    const { data } = await this.call<EquipmentResponse>({ method: 'POST', path: '/equipment', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Equipment usage
+   *
+   * Sessions, distance and duration with each kind of gear and each piece of gear of the user during
+   * a calendar year, ex: the wetsuit sessions of the season. A session counts once per kind.
+   *
+   * `GET /equipment/stats`
+   */
+  async equipmentUsage(params?: EquipmentUsageParams, init?: RequestOptions): Promise<EquipmentStatsResponse> {
+    const { data } = await this.call<EquipmentStatsResponse>({ method: 'GET', path: '/equipment/stats', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get equipment
+   *
+   * Get a piece of gear of the user with its lifetime usage
+   *
+   * `GET /equipment/{id}`
+   */
+  async getEquipment(id: string, init?: RequestOptions): Promise<EquipmentResponse> {
+    const { data } = await this.call<EquipmentResponse>({ method: 'GET', path: `/equipment/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update equipment
+   *
+   * Replace the details of a piece of gear of the user, retired gear keeps its usage
+   *
+   * `PUT /equipment/{id}`
+   */
+  async updateEquipment(id: string, body: EquipmentRequest, init?: RequestOptions): Promise<EquipmentResponse> {
+    const { data } = await this.call<EquipmentResponse>({ method: 'PUT', path: `/equipment/${encodeURIComponent(id)}`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Delete equipment
+   *
+   * Delete a piece of gear of the user and untag it from its sessions, retire it instead to keep its
+   * usage
+   *
+   * `DELETE /equipment/{id}`
+   */
+  async deleteEquipment(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/equipment/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Track analytics events
+   *
+   * Queue up to 100 product events of the app. Events are attributed to the token, sampled, stripped
+   * of personal data and delivered in the background, an accepted event may still be dropped.
+   *
+   * `POST /events`
+   */
+  async trackAnalyticsEvents(body: TrackEventsRequest, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: '/events', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List injuries
+   *
+   * The injury and recovery log of the user, ongoing injuries first then latest first
+   *
+   * `GET /injuries`
+   */
+  async listInjuries(init?: RequestOptions): Promise<InjuryResponse[]> {
+    const { data } = await this.call<InjuryResponse[]>({ method: 'GET', path: '/injuries', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Log injury
+   *
+   * Add an injury to the injury and recovery log of the user, without a resolved date it is ongoing
+   *
+   * `POST /injuries`
+   */
+  async logInjury(body: InjuryRequest, init?: RequestOptions): Promise<InjuryResponse> {
+    const { data } = await this.call<InjuryResponse>({ method: 'POST', path: '/injuries', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get injury
+   *
+   * Get an injury of the user
+   *
+   * `GET /injuries/{id}`
+   */
+  async getInjury(id: string, init?: RequestOptions): Promise<InjuryResponse> {
+    const { data } = await this.call<InjuryResponse>({ method: 'GET', path: `/injuries/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update injury
+   *
+   * Replace an injury of the user, send the resolved date once recovered
+   *
+   * `PUT /injuries/{id}`
+   */
+  async updateInjury(id: string, body: InjuryRequest, init?: RequestOptions): Promise<InjuryResponse> {
+    const { data } = await this.call<InjuryResponse>({ method: 'PUT', path: `/injuries/${encodeURIComponent(id)}`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Delete injury
+   *
+   * Delete an injury of the user
+   *
+   * `DELETE /injuries/{id}`
+   */
+  async deleteInjury(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/injuries/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Download a stored file
+   *
+   * Public media (avatars, training thumbnails and videos) redirect to a short lived signed link of
+   * the storage. With the local storage driver, signed links are served here.
+   *
+   * `GET /media/{key}`
+   */
+  async downloadStoredFile(key: string, params?: DownloadStoredFileParams, init?: RequestOptions): Promise<Blob> {
+    return this.download({ method: 'GET', path: `/media/${encodeURIComponent(key)}`, query: params, auth: 'none' }, init);
+  }
+
+  /**
+   * Get the active check in
+   *
+   * The pool the signed in swimmer is checked in at, until check out or expiry
+   *
+   * `GET /pools/check-in`
+   */
+  async getActiveCheckIn(init?: RequestOptions): Promise<CheckInResponse> {
+    const { data } = await this.call<CheckInResponse>({ method: 'GET', path: '/pools/check-in', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Check in at a pool
+   *
+   * Check the signed in swimmer in at the pool of the code read from its QR, or else at the nearest
+   * pool whose geofence holds the location. Sessions finished in the next 4 hours, until check out
+   * or another check in, are swum at the pool.
+   *
+   * `POST /pools/check-in`
+   */
+  async checkInAtPool(body: CheckInRequest, init?: RequestOptions): Promise<CheckInResponse> {
+    const { data } = await this.call<CheckInResponse>({ method: 'POST', path: '/pools/check-in', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Check out
+   *
+   * End the active check in of the signed in swimmer, sessions finished later have no pool
+   *
+   * `DELETE /pools/check-in`
+   */
+  async checkOut(init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: '/pools/check-in', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List my pools
+   *
+   * The pools the signed in swimmer swam at, last swum first, with the sessions, distance and
+   * duration swum at each
+   *
+   * `GET /pools/mine`
+   */
+  async listMyPools(init?: RequestOptions): Promise<MyPoolResponse[]> {
+    const { data } = await this.call<MyPoolResponse[]>({ method: 'GET', path: '/pools/mine', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get lane occupancy
+   *
+   * The lanes of a managed pool as last published by its staff, with the swimmers in each and
+   * whether one can join. No token is needed so lobby screens can show it.
+   *
+   * `GET /pools/{id}/occupancy`
+   */
+  async getLaneOccupancy(id: string, init?: RequestOptions): Promise<OccupancyResponse> {
+    const { data } = await this.call<OccupancyResponse>({ method: 'GET', path: `/pools/${encodeURIComponent(id)}/occupancy`, auth: 'none' }, init);
+    return data;
+  }
+
+  /**
+   * List races
+   *
+   * Virtual races, newest first by default, with the entry of the signed in swimmer in the races
+   * they registered to. Filter by status: upcoming, open (between the start and the end) or closed.
+   *
+   * `GET /races`
+   */
+  async listRaces(params?: ListRacesParams, init?: RequestOptions): Promise<Page<RaceResponse[]>> {
+    const { data, meta } = await this.call<RaceResponse[]>({ method: 'GET', path: '/races', query: params, auth: 'user' }, init);
+    return { data, meta: meta as Meta };
+  }
+
+  /**
+   * Get a race
+   *
+   * A virtual race with its entry and finisher counts, and the entry of the signed in swimmer when
+   * registered
+   *
+   * `GET /races/{id}`
+   */
+  async getRace(id: string, init?: RequestOptions): Promise<RaceResponse> {
+    const { data } = await this.call<RaceResponse>({ method: 'GET', path: `/races/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Download a race certificate
+   *
+   * The finisher certificate of the signed in swimmer as an SVG image, with their time and rank
+   *
+   * `GET /races/{id}/certificate`
+   */
+  async downloadRaceCertificate(id: string, init?: RequestOptions): Promise<Blob> {
+    return this.download({ method: 'GET', path: `/races/${encodeURIComponent(id)}/certificate`, auth: 'user' }, init);
+  }
+
+  /**
+   * List race finishers
+   *
+   * The finishers of a race ranked fastest first, earlier results first on a tie
+   *
+   * `GET /races/{id}/finishers`
+   */
+  async listRaceFinishers(id: string, params?: ListRaceFinishersParams, init?: RequestOptions): Promise<Page<FinisherResponse[]>> {
+    const { data, meta } = await this.call<FinisherResponse[]>({ method: 'GET', path: `/races/${encodeURIComponent(id)}/finishers`, query: params, auth: 'user' }, init);
+    return { data, meta: meta as Meta };
+  }
+
+  /**
+   * Register to a race
+   *
+   * Enter the signed in swimmer in a race, possible until the race ends. Registering again keeps the
+   * entry.
+   *
+   * `POST /races/{id}/register`
+   */
+  async registerToRace(id: string, init?: RequestOptions): Promise<RaceResponse> {
+    const { data } = await this.call<RaceResponse>({ method: 'POST', path: `/races/${encodeURIComponent(id)}/register`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Submit a race result
+   *
+   * Submit a recorded session as the result of the signed in swimmer, until 48 hours after the race
+   * ends. The session must start during the race, cover at least the race distance, be plausible
+   * and, pro-rated to the race distance, finish within the cutoff. A slower session than the current
+   * result is accepted but doesn't replace it.
+   *
+   * `POST /races/{id}/result`
+   */
+  async submitRaceResult(id: string, body: ResultRequest, init?: RequestOptions): Promise<RaceResponse> {
+    const { data } = await this.call<RaceResponse>({ method: 'POST', path: `/races/${encodeURIComponent(id)}/result`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Refresh JWT token
+   *
+   * Generate new access token using refresh token
+   *
+   * `POST /refresh-token`
+   */
+  async refreshJWTToken(body: RefreshTokenRequest, init?: RequestOptions): Promise<RefreshTokenResponse> {
+    const { data } = await this.call<RefreshTokenResponse>({ method: 'POST', path: '/refresh-token', body, auth: 'user' }, init);
+    this.setTokens({ token: data.token ?? '', refreshToken: data.refreshToken ?? '' });
+    return data;
+  }
+
+  /**
+   * Sign in user
+   *
+   * Authenticate user with email and password, returns JWT tokens. A sign in from a device or
+   * country not seen before on the account emails an alert with a "this wasn't me" link.
+   *
+   * `POST /sign-in`
+   */
+  async signInUser(body: SignInRequest, init?: RequestOptions): Promise<SignInResponse> {
+    const { data } = await this.call<SignInResponse>({ method: 'POST', path: '/sign-in', body, auth: 'none' }, init);
+    this.setTokens({ token: data.token ?? '', refreshToken: data.refreshToken ?? '' });
+    return data;
+  }
+
+  /**
+   * Deny sign in
+   *
+   * Report the sign in of an alert email as not made by the owner of the account, with the token of
+   * its link. The account is locked and every session revoked, access tokens already issued stay
+   * valid until they expire. A token works once.
+   *
+   * `POST /sign-in-alerts/deny`
+   */
+  async denySignIn(body: DenySignInRequest, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: '/sign-in-alerts/deny', body, auth: 'none' }, init);
+    return data;
+  }
+
+  /**
+   * Sign in guest
+   *
+   * Authenticate guest user without credentials, returns limited access tokens
+   *
+   * `POST /sign-in-guest`
+   */
+  async signInGuest(body: SignInGuestRequest, init?: RequestOptions): Promise<SignInGuestResponse> {
+    const { data } = await this.call<SignInGuestResponse>({ method: 'POST', path: '/sign-in-guest', body, auth: 'none' }, init);
+    this.setTokens({ token: data.token ?? '', refreshToken: data.refreshToken ?? '' });
+    return data;
+  }
+
+  /**
+   * Sign out user
+   *
+   * Revoke user session and invalidate JWT tokens
+   *
+   * `POST /sign-out`
+   */
+  async signOutUser(init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: '/sign-out', auth: 'user' }, init);
+    this.setTokens(null);
+    return data;
+  }
+
+  /**
+   * Sign up new user
+   *
+   * Register a new user account with email, password, and profile information
+   *
+   * `POST /sign-up`
+   */
+  async signUpNewUser(body: SignUpRequest, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: '/sign-up', body, auth: 'none' }, init);
+    return data;
+  }
+
+  /**
+   * Heart rate zones
+   *
+   * Time spent in each of the five heart rate zones over a rolling period, aggregated and per
+   * session. Only laps recorded with an average heart rate count. Zones are shares of the max heart
+   * rate set in the preferences, or 220 minus the age when unset. Injuries overlapping the period
+   * are listed so charts can show them.
+   *
+   * `GET /stats/hr-zones`
+   */
+  async heartRateZones(params?: HeartRateZonesParams, init?: RequestOptions): Promise<HeartRateZonesResponse> {
+    const { data } = await this.call<HeartRateZonesResponse>({ method: 'GET', path: '/stats/hr-zones', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Open water season
+   *
+   * Sessions, distance, duration, wetsuit sessions and water temperatures of the open water sessions
+   * of a calendar year, in total and for each month in UTC. Injuries overlapping the year are listed
+   * so charts can show them.
+   *
+   * `GET /stats/open-water`
+   */
+  async openWaterSeason(params?: OpenWaterSeasonParams, init?: RequestOptions): Promise<OpenWaterStatsResponse> {
+    const { data } = await this.call<OpenWaterStatsResponse>({ method: 'GET', path: '/stats/open-water', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Training load
+   *
+   * Acute (7 day) and chronic (42 day) training load for each UTC day of the range ending today. The
+   * load of a session is its minutes times its perceived exertion (RPE), sessions without one count
+   * as moderate. Both loads are exponentially weighted moving averages, an acute:chronic ratio above
+   * the risk threshold flags a sharp ramp up. Injuries overlapping the range are listed so charts
+   * can show them.
+   *
+   * `GET /stats/training-load`
+   */
+  async trainingLoad(params?: TrainingLoadParams, init?: RequestOptions): Promise<TrainingLoadResponse> {
+    const { data } = await this.call<TrainingLoadResponse>({ method: 'GET', path: '/stats/training-load', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Sync offline training sessions
+   *
+   * Sync a batch of sessions recorded offline, identified by a UUID generated on the device. A known
+   * clientId updates its session: trainingId and startedAt keep the server value, distance, duration
+   * and laps keep the value edited last by updatedAt. Results are keyed by clientId (by index when
+   * it is missing) with the status created, updated, unchanged or rejected, and the fields that kept
+   * the server value in conflicts.
+   *
+   * `POST /sync/sessions`
+   */
+  async syncOfflineTrainingSessions(body: TrainingSyncSessionsRequest, init?: RequestOptions): Promise<TrainingSyncSessionsResponse> {
+    const { data } = await this.call<TrainingSyncSessionsResponse>({ method: 'POST', path: '/sync/sessions', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get trainings with pagination
+   *
+   * Retrieve a paginated list of trainings with optional search, filters and sorting. With facets,
+   * the number of trainings per category and level is returned along the page, each count applying
+   * every filter but its own. A search without results is an empty page, not an error.
+   *
+   * `GET /trainings`
+   */
+  async getTrainingsWithPagination(params?: GetTrainingsWithPaginationParams, init?: RequestOptions): Promise<Page<TrainingItemResponse[]>> {
+    const { data, meta } = await this.call<TrainingItemResponse[]>({ method: 'GET', path: '/trainings', query: params, auth: 'user' }, init);
+    return { data, meta: meta as Meta };
+  }
+
+  /**
+   * Create a new training
+   *
+   * Create a new training with the provided details. Trainings created by admins are published right
+   * away, those of other accounts are submitted for review and only listed once approved.
+   *
+   * `POST /trainings`
+   */
+  async createNewTraining(body: TrainingRequest, init?: RequestOptions): Promise<TrainingResponse> {
+    const { data } = await this.call<TrainingResponse>({ method: 'POST', path: '/trainings', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List possible duplicate sessions
+   *
+   * List up to 100 pending pairs of sessions of the user overlapping for at least half of the
+   * shorter one, ex: a manual entry and the watch import of the same swim. Newest first.
+   *
+   * `GET /trainings/sessions/duplicates`
+   */
+  async listPossibleDuplicateSessions(init?: RequestOptions): Promise<TrainingDuplicateResponse[]> {
+    const { data } = await this.call<TrainingDuplicateResponse[]>({ method: 'GET', path: '/trainings/sessions/duplicates', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Dismiss a possible duplicate
+   *
+   * Mark the pair as distinct sessions, both are kept and the pair is not flagged again
+   *
+   * `POST /trainings/sessions/duplicates/{id}/dismiss`
+   */
+  async dismissPossibleDuplicate(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: `/trainings/sessions/duplicates/${encodeURIComponent(id)}/dismiss`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Merge duplicate sessions
+   *
+   * Keep one session of the pair, the one stored first unless keepId is sent, and delete the other.
+   * The kept session takes the laps, water conditions, client id and training of the deleted one
+   * where it has none.
+   *
+   * `POST /trainings/sessions/duplicates/{id}/merge`
+   */
+  async mergeDuplicateSessions(id: string, body: TrainingMergeDuplicateRequest, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'POST', path: `/trainings/sessions/duplicates/${encodeURIComponent(id)}/merge`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Export training sessions
+   *
+   * Stream every training session of the user, newest first. Send Accept: application/x-ndjson (or
+   * format=ndjson) for one session per line, otherwise the sessions are streamed as the data array.
+   *
+   * `GET /trainings/sessions/export`
+   */
+  async exportTrainingSessions(params?: ExportTrainingSessionsParams, init?: RequestOptions): Promise<TrainingSessionExportResponse[]> {
+    const { data } = await this.call<TrainingSessionExportResponse[]>({ method: 'GET', path: '/trainings/sessions/export', query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Export training sessions to a file
+   *
+   * Write every training session of the user to an NDJSON file and return a link downloading it
+   * until expiresAt
+   *
+   * `POST /trainings/sessions/export/file`
+   */
+  async exportTrainingSessionsToFile(init?: RequestOptions): Promise<TrainingExportFileResponse> {
+    const { data } = await this.call<TrainingExportFileResponse>({ method: 'POST', path: '/trainings/sessions/export/file', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Import training sessions
+   *
+   * Import a batch of sessions recorded elsewhere (ex: a watch), with optional laps, in a single
+   * transaction
+   *
+   * `POST /trainings/sessions/import`
+   */
+  async importTrainingSessions(body: TrainingImportSessionsRequest, init?: RequestOptions): Promise<TrainingImportSessionsResponse> {
+    const { data } = await this.call<TrainingImportSessionsResponse>({ method: 'POST', path: '/trainings/sessions/import', body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get user's last training session
+   *
+   * Retrieve the most recent training session
+   *
+   * `GET /trainings/sessions/last`
+   */
+  async getUsersLastTrainingSession(init?: RequestOptions): Promise<TrainingSessionResponse> {
+    const { data } = await this.call<TrainingSessionResponse>({ method: 'GET', path: '/trainings/sessions/last', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get a training session
+   *
+   * Get a session of the user with its laps and, for open water sessions, its water conditions
+   *
+   * `GET /trainings/sessions/{id}`
+   */
+  async getTrainingSession(id: string, init?: RequestOptions): Promise<TrainingSessionDetailResponse> {
+    const { data } = await this.call<TrainingSessionDetailResponse>({ method: 'GET', path: `/trainings/sessions/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Delete a training session
+   *
+   * Remove a session from the history, stats and exports of the user. Admins can restore it.
+   *
+   * `DELETE /trainings/sessions/{id}`
+   */
+  async deleteTrainingSession(id: string, init?: RequestOptions): Promise<Message> {
+    const { data } = await this.call<Message>({ method: 'DELETE', path: `/trainings/sessions/${encodeURIComponent(id)}`, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update water conditions
+   *
+   * Replace the water conditions of an open water session. With latitude and longitude, the water
+   * temperature, wave height and current speed left empty are filled from the weather at the start
+   * of the session when a provider is configured.
+   *
+   * `PUT /trainings/sessions/{id}/conditions`
+   */
+  async updateWaterConditions(id: string, body: TrainingConditionsRequest, init?: RequestOptions): Promise<TrainingSessionDetailResponse> {
+    const { data } = await this.call<TrainingSessionDetailResponse>({ method: 'PUT', path: `/trainings/sessions/${encodeURIComponent(id)}/conditions`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Tag session equipment
+   *
+   * Replace the gear used in a session of the user with up to 10 pieces of their gear, an empty list
+   * clears it
+   *
+   * `PUT /trainings/sessions/{id}/equipment`
+   */
+  async tagSessionEquipment(id: string, body: SessionEquipmentRequest, init?: RequestOptions): Promise<EquipmentResponse[]> {
+    const { data } = await this.call<EquipmentResponse[]>({ method: 'PUT', path: `/trainings/sessions/${encodeURIComponent(id)}/equipment`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * List my training submissions
+   *
+   * List up to 100 trainings submitted by the user with their review status and the reason of a
+   * rejection. Newest first.
+   *
+   * `GET /trainings/submissions`
+   */
+  async listMyTrainingSubmissions(init?: RequestOptions): Promise<TrainingReviewResponse[]> {
+    const { data } = await this.call<TrainingReviewResponse[]>({ method: 'GET', path: '/trainings/submissions', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get training by ID
+   *
+   * Retrieve detailed training information by training ID. Trainings under review or rejected are
+   * only visible to their author and admins. With include, aggregates are embedded in the same
+   * response: completions is the number of sessions of the user on the training.
+   *
+   * `GET /trainings/{id}`
+   */
+  async getTrainingByID(id: string, params?: GetTrainingByIDParams, init?: RequestOptions): Promise<TrainingResponse> {
+    const { data } = await this.call<TrainingResponse>({ method: 'GET', path: `/trainings/${encodeURIComponent(id)}`, query: params, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Finish a training session
+   *
+   * Complete an ongoing training session with distance and duration metrics. Open water sessions may
+   * carry their water conditions. Sessions faster or slower than plausible for the training level,
+   * longer than 6 hours or not a whole number of pool lengths are rejected unless an admin sets
+   * override.
+   *
+   * `POST /trainings/{id}/finish`
+   */
+  async finishTrainingSession(id: string, body: TrainingFinishSessionRequest, init?: RequestOptions): Promise<TrainingSessionResponse> {
+    const { data } = await this.call<TrainingSessionResponse>({ method: 'POST', path: `/trainings/${encodeURIComponent(id)}/finish`, body, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Upload training media
+   *
+   * Replace the thumbnail (JPEG, PNG or WebP) and/or the video (MP4, WebM or QuickTime) of a
+   * training with the files of a multipart form. Files are streamed to storage, large videos are
+   * uploaded in parts. With a version, sent before the files, a training edited since is refused
+   * with the current version. Only the author of the training and admins can replace its media.
+   *
+   * `PUT /trainings/{id}/media`
+   */
+  async uploadTrainingMedia(id: string, form: UploadTrainingMediaForm, init?: RequestOptions): Promise<TrainingResponse> {
+    const { data } = await this.call<TrainingResponse>({ method: 'PUT', path: `/trainings/${encodeURIComponent(id)}/media`, form: { ...form }, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Upload avatar
+   *
+   * Replace the avatar of the user with a JPEG, PNG or WebP image up to 5MB, sent as the avatar
+   * field of a multipart form
+   *
+   * `PUT /users/me/avatar`
+   */
+  async uploadAvatar(form: UploadAvatarForm, init?: RequestOptions): Promise<AvatarResponse> {
+    const { data } = await this.call<AvatarResponse>({ method: 'PUT', path: '/users/me/avatar', form: { ...form }, auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Get notification preferences
+   *
+   * Get the time zone and the notifications the user receives
+   *
+   * `GET /users/me/preferences`
+   */
+  async getNotificationPreferences(init?: RequestOptions): Promise<PreferencesResponse> {
+    const { data } = await this.call<PreferencesResponse>({ method: 'GET', path: '/users/me/preferences', auth: 'user' }, init);
+    return data;
+  }
+
+  /**
+   * Update notification preferences
+   *
+   * Set the IANA time zone of the user, used to send scheduled mail in the morning of the user, and
+   * opt in or out of the weekly digest. With a version, preferences edited since, ex: from another
+   * device, are refused with the current version.
+   *
+   * `PUT /users/me/preferences`
+   */
+  async updateNotificationPreferences(body: PreferencesRequest, init?: RequestOptions): Promise<PreferencesResponse> {
+    const { data } = await this.call<PreferencesResponse>({ method: 'PUT', path: '/users/me/preferences', body, auth: 'user' }, init);
+    return data;
+  }
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/ops"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// runCommand dispatches the CLI subcommands of the main binary
+func runCommand(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	if cfg.Database.Embedded.Enabled {
+		server, err := database.StartEmbedded(&cfg.Database, log)
+		if err != nil {
+			return err
+		}
+		defer server.Stop()
+	}
+
+	switch args[0] {
+	case "migrate":
+		return ops.Migrate(ctx, cfg, log, args[1:])
+	case "seed":
+		return ops.Seed(ctx, cfg, log, args[1:])
+	case "fixtures":
+		return ops.Fixtures(ctx, cfg, log, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, available: migrate, seed, fixtures", args[0])
+	}
+}
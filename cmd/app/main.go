@@ -2,19 +2,14 @@ package main
 
 import (
 	"context"
-	"net/http"
+	"fmt"
 	"os"
+	_ "time/tzdata" // user time zones are validated even on images without a zoneinfo database
 
 	"github.com/rizkyharahap/swimo/config"
-	"github.com/rizkyharahap/swimo/database"
-
-	"github.com/rizkyharahap/swimo/internal/auth"
-	"github.com/rizkyharahap/swimo/internal/health"
-	"github.com/rizkyharahap/swimo/internal/swagger"
-	"github.com/rizkyharahap/swimo/internal/training"
-	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/internal/app"
 	"github.com/rizkyharahap/swimo/pkg/logger"
-	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/secrets"
 	"github.com/rizkyharahap/swimo/pkg/server"
 )
 
@@ -34,11 +29,22 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey DeviceToken
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the token returned when pairing the device.
+
 // @ExternalDocs.url https://github.com/rizkyharahap/swimo
 // @ExternalDocs.description Swimo GitHub Repository
 func main() {
 	// Load configuration
-	cfg := config.Parse()
+	resolver := secrets.NewResolver()
+
+	cfg, err := config.Load(context.Background(), os.Getenv("CONFIG_FILE"), resolver.Resolve)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
 
 	// Initialize logger
 	logConfig := logger.Config{
@@ -46,62 +52,100 @@ func main() {
 		Format: cfg.Log.Format,
 		File:   cfg.Log.File,
 		AddSrc: cfg.Log.AddSrc,
+		Sinks:  cfg.Log.Sinks,
+		Rotation: logger.RotationConfig{
+			MaxSizeMB:  cfg.Log.MaxSizeMB,
+			MaxAgeDays: cfg.Log.MaxAgeDays,
+			MaxBackups: cfg.Log.MaxBackups,
+			Compress:   cfg.Log.Compress,
+		},
+		Syslog: logger.SyslogConfig{
+			Network: cfg.Log.SyslogNetwork,
+			Addr:    cfg.Log.SyslogAddr,
+			Tag:     cfg.Log.SyslogTag,
+		},
+		Redact: cfg.Log.RedactFields,
 	}
 	log := logger.New(logConfig)
+	defer log.Close()
+	logger.SetDefault(log)
+
+	// Run a one-off command instead of the server, ex: swimo migrate up
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), cfg, log, os.Args[1:]); err != nil {
+			log.Error("Command failed", "command", os.Args[1], "error", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	log.Info("Starting application",
-		"name", cfg.App.Name,
+		"app", cfg.App.Name,
 		"env", cfg.App.Env,
 		"version", "1.0.0",
 	)
+	log.Info("Effective configuration", cfg.Summary()...)
 
-	// Create HTTP server
-	httpServer := server.NewServer(cfg.HTTP, log)
-
-	// Initialize database manager through server
-	dbManager := database.NewManager(log)
-
-	// Set up database connection
-	db, err := dbManager.Connect(context.Background(), "primary", &cfg.Database, &cfg.App)
+	// Build dependency graph
+	container, err := app.New(context.Background(), cfg, log, app.WithSecretsResolver(resolver))
 	if err != nil {
-		log.Error("Failed to connect to database", "error", err)
+		log.Error("Failed to initialize application", "error", err)
 		os.Exit(1)
-	} else {
-		log.Info("Database connection established successfully")
 	}
+	defer container.Close()
 
-	// Initialize repositories
-	authRepo := auth.NewAuthRepository(db.Pool)
-	userRepo := user.NewUserRepositry(db.Pool)
-	trainingRepo := training.NewTrainingRepositry(db.Pool)
+	// Create HTTP server
+	httpServer := server.NewServer(cfg.HTTP, log)
+	httpServer.WithHandler(container.Handler())
+
+	// Apply hot reloadable settings on config file change or SIGHUP
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+
+	go container.ConfigStore.Watch(watchCtx, cfg.App.ReloadInterval,
+		func(next *config.Config) {
+			log.SetLevel(next.Log.Level)
+			log.Info("Configuration reloaded", "log_level", next.Log.Level, "cors_origins", next.CORS.AllowOrigins, "guest_enabled", next.Auth.GuestEnabled)
+		},
+		func(err error) {
+			log.Error("Configuration reload failed, keeping current settings", "error", err)
+		},
+	)
 
-	// Initialize usecases
-	authUsecase := auth.NewAuthUsecase(cfg, log, db.Pool, authRepo, userRepo)
-	trainingUsecase := training.NewTrainingUsecase(trainingRepo, userRepo)
+	// Keep secrets fetched from the secrets backend fresh
+	go container.Secrets.Watch(watchCtx, container.ConfigStore, cfg.Secrets.RefreshInterval)
 
-	// Initialize handlers
-	healthHandler := health.NewHealthHandler(log, db)
-	swaggerHandler := swagger.NewSwaggerHandler(cfg)
-	authHandler := auth.NewAuthHandler(authUsecase)
-	trainingHandler := training.NewTrainingHandler(trainingUsecase)
+	// Start scheduled jobs
+	container.Scheduler.Start(context.Background())
 
-	// Create router
-	mux := http.NewServeMux()
+	// Jobs are stopped before the connections they use are closed
+	httpServer.OnShutdown(container.Scheduler.Stop)
 
-	// Setup routes
-	setupRoutes(mux, db, cfg, healthHandler, swaggerHandler, authHandler, trainingHandler)
+	// Start the gRPC server and gateway next to the HTTP server
+	if cfg.GRPC.Enabled {
+		grpcServer := container.GRPCServer()
+		grpcErrors := make(chan error, 2)
 
-	// Apply middlewares
-	handler := middleware.Chain(
-		middleware.ErrorHandler,
-		middleware.RecoverMiddleware(log),
-		middleware.LoggingMiddleware(log),
-		middleware.CORSMiddleware(cfg.CORS),
-		middleware.CompressionMiddleware,
-	)(mux)
+		if err := grpcServer.Start(context.Background(), grpcErrors); err != nil {
+			log.Error("Failed to start gRPC server", "error", err)
+			os.Exit(1)
+		}
+		httpServer.OnShutdown(func(ctx context.Context) error {
+			grpcServer.Stop()
+			return nil
+		})
+
+		go func() {
+			for err := range grpcErrors {
+				log.Error("gRPC server stopped unexpectedly", "error", err)
+			}
+		}()
+	}
 
-	// Set handler
-	httpServer.WithHandler(handler)
+	// Database pool, redis (cache and rate limits), broker and tracker, last
+	httpServer.OnShutdown(func(ctx context.Context) error {
+		return container.Close()
+	})
 
 	// Start server
 	log.Info("Application initialized successfully")
@@ -112,43 +156,3 @@ func main() {
 		panic(err)
 	}
 }
-
-// setupRoutes sets up the application routes
-func setupRoutes(
-	mux *http.ServeMux,
-	db *database.Database,
-	cfg *config.Config,
-	healthHandler *health.HealthHandler,
-	swaggerHandler *swagger.SwaggerHandler,
-	authHandler *auth.AuthHandler,
-	trainingHandler *training.TrainingHandler,
-) {
-
-	// Register swagger routes
-	mux.Handle("/swagger/", swaggerHandler.Handler)
-
-	// Health check endpoint
-	mux.HandleFunc("GET /api/v1/healthz", healthHandler.Check)
-
-	if db != nil {
-		// Public endpoints - no authentication required
-		mux.HandleFunc("POST /api/v1/sign-up", authHandler.SignUp)
-		mux.HandleFunc("POST /api/v1/sign-in", authHandler.SignIn)
-		mux.HandleFunc("POST /api/v1/sign-in-guest", authHandler.SignInGuest)
-		mux.HandleFunc("POST /api/v1/refresh-token", authHandler.RefreshToken)
-
-		// Protected endpoints - require authentication
-		authMiddleware := func(h http.HandlerFunc) http.Handler {
-			return middleware.AuthMiddleware(cfg.Auth.JWTSecret, h)
-		}
-
-		mux.Handle("POST /api/v1/sign-out", authMiddleware(authHandler.SignOut))
-
-		// Training endpoints - require authentication
-		mux.Handle("GET /api/v1/trainings/{id}", authMiddleware(trainingHandler.GetById))
-		mux.Handle("GET /api/v1/trainings", authMiddleware(trainingHandler.GetTrainings))
-		mux.Handle("POST /api/v1/trainings", authMiddleware(trainingHandler.CreateTraining))
-		mux.Handle("GET /api/v1/trainings/sessions/last", authMiddleware(trainingHandler.GetLastSession))
-		mux.Handle("POST /api/v1/trainings/{id}/finish", authMiddleware(trainingHandler.FinishSession))
-	}
-}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{operationName, "Get a race", "GetRace"},
+		{operationName, "Get user's last training session", "GetUsersLastTrainingSession"},
+		{operationName, "Refresh JWT token", "RefreshJWTToken"},
+		{pascal, "sessionId", "SessionID"},
+		{pascal, "equipmentIds", "EquipmentIDs"},
+		{pascal, "avgWaterTemperatureC", "AvgWaterTemperatureC"},
+		{pascal, "pull_buoy", "PullBuoy"},
+		{camel, "RefreshJWTToken", "refreshJWTToken"},
+		{camel, "GetRace", "getRace"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.in); got != tt.want {
+			t.Errorf("%q = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// The committed clients must match the embedded document, run make clients after an API change
+func TestClientsUpToDate(t *testing.T) {
+	args := []string{"--check", "--go", "../../pkg/client", "--ts", "../../clients/typescript/src"}
+	if err := run(context.Background(), args, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,482 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// generateGo renders the Go client: client.go holds the runtime, api.go the types and one
+// method per operation
+func generateGo(a *api, pkg string) (map[string][]byte, error) {
+	runtime, err := render("client.go.tmpl", struct {
+		*api
+		Package string
+	}{a, pkg})
+	if err != nil {
+		return nil, err
+	}
+
+	g := &goGen{api: a, requestTypes: requestTypes(a), mapTypes: map[string]bool{}, imports: map[string]bool{}}
+	for _, t := range a.Types {
+		g.mapTypes[t.Name] = t.Map != nil
+	}
+	for _, t := range a.Types {
+		g.typeDef(t)
+	}
+	for _, op := range a.Operations {
+		g.operation(op)
+	}
+	if g.imports["encoding/json"] && a.MetaType != "" {
+		g.printf("func decodeMeta(raw json.RawMessage) (*%s, error) {\n", a.MetaType)
+		g.printf("if len(raw) == 0 {\nreturn nil, nil\n}\n")
+		g.printf("var meta %s\nif err := json.Unmarshal(raw, &meta); err != nil {\nreturn nil, err\n}\nreturn &meta, nil\n}\n", a.MetaType)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by genclient from the %s %s document. DO NOT EDIT.\n\n", a.Title, a.Version)
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", pkg)
+	for _, imp := range sortedKeys(g.imports) {
+		fmt.Fprintf(&src, "%q\n", imp)
+	}
+	fmt.Fprintf(&src, ")\n\n")
+	src.Write(g.buf.Bytes())
+
+	api, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated Go is invalid: %w", err)
+	}
+	client, err := format.Source(runtime)
+	if err != nil {
+		return nil, fmt.Errorf("generated Go runtime is invalid: %w", err)
+	}
+
+	return map[string][]byte{"client.go": client, "api.go": api}, nil
+}
+
+func render(name string, data any) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// requestTypes are the types sent in request bodies. Their optional scalar fields are pointers
+// so zero values are sent, response types keep plain values.
+func requestTypes(a *api) map[string]bool {
+	byName := make(map[string]*typeDef, len(a.Types))
+	for _, t := range a.Types {
+		byName[t.Name] = t
+	}
+
+	seen := map[string]bool{}
+	var visit func(t *typeRef)
+	visit = func(t *typeRef) {
+		switch {
+		case t == nil:
+		case t.Kind == kindArray || t.Kind == kindMap:
+			visit(t.Elem)
+		case t.Kind == kindRef && !seen[t.Name]:
+			seen[t.Name] = true
+			if def := byName[t.Name]; def != nil {
+				visit(def.Map)
+				for _, f := range def.Fields {
+					visit(f.Type)
+				}
+			}
+		}
+	}
+	for _, op := range a.Operations {
+		visit(op.Body)
+	}
+	return seen
+}
+
+type goGen struct {
+	*api
+	requestTypes map[string]bool
+	mapTypes     map[string]bool // are not pointers when optional
+	imports      map[string]bool
+	buf          bytes.Buffer
+}
+
+func (g *goGen) use(imports ...string) {
+	for _, imp := range imports {
+		g.imports[imp] = true
+	}
+}
+
+func (g *goGen) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *goGen) comment(text string) {
+	for _, line := range wrap(text, 96) {
+		g.printf("// %s\n", line)
+	}
+}
+
+func (g *goGen) typeDef(t *typeDef) {
+	doc := fmt.Sprintf("%s is %s", t.Name, t.Schema)
+	if t.Doc != "" {
+		doc += ", " + t.Doc
+	}
+	g.comment(doc)
+
+	if t.Map != nil {
+		g.printf("type %s map[string]%s\n\n", t.Name, goType(t.Map))
+		return
+	}
+
+	g.printf("type %s struct {\n", t.Name)
+	for _, f := range t.Fields {
+		if doc := fieldDoc(f.Doc, f.Type); doc != "" {
+			g.comment(doc)
+		}
+
+		typ := goType(f.Type)
+		tag := f.Name
+		if !f.Required {
+			tag += ",omitempty"
+			if f.Type.Kind == kindRef && !g.mapTypes[f.Type.Name] || g.requestTypes[t.Name] && isScalar(f.Type) {
+				typ = "*" + typ
+			}
+		}
+		g.printf("%s %s `json:%q`\n", pascal(f.Name), typ, tag)
+	}
+	g.printf("}\n\n")
+}
+
+func (g *goGen) operation(op *operation) {
+	g.use("context", "net/http")
+	if len(op.PathParams) > 0 || len(op.Query) > 0 {
+		g.use("net/url")
+	}
+	if op.Binary {
+		g.use("io")
+	}
+	if len(op.Query) > 0 {
+		g.params(op)
+	}
+	if len(op.Form) > 0 {
+		g.form(op)
+	}
+
+	g.comment(fmt.Sprintf("%s calls %s %s: %s", op.Name, op.Method, op.Path, op.Summary))
+	if op.Description != "" {
+		g.printf("//\n")
+		g.comment(op.Description)
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range op.PathParams {
+		args = append(args, goIdent(p.Name)+" string")
+	}
+	switch {
+	case op.Body != nil:
+		args = append(args, "body "+goArgType(op.Body))
+	case len(op.Form) > 0:
+		args = append(args, "form *"+op.Name+"Form")
+	}
+	if len(op.Query) > 0 {
+		args = append(args, "params *"+op.Name+"Params")
+	}
+
+	result := ""
+	if op.Result != nil {
+		result = goResultType(op.Result)
+		if op.Paginated && g.MetaType != "" || op.Result.Kind == kindAny {
+			g.use("encoding/json")
+		}
+	}
+
+	var returns string
+	switch {
+	case op.Binary:
+		returns = "(io.ReadCloser, error)"
+	case result != "" && op.Paginated && g.MetaType != "":
+		returns = fmt.Sprintf("(%s, *%s, error)", result, g.MetaType)
+	case result != "":
+		returns = fmt.Sprintf("(%s, error)", result)
+	default:
+		returns = "error"
+	}
+	g.printf("func (c *Client) %s(%s) %s {\n", op.Name, strings.Join(args, ", "), returns)
+
+	fields := []string{"method: http.Method" + methodName(op.Method), "path: " + goPath(op)}
+	if len(op.Query) > 0 {
+		g.printf("var query url.Values\nif params != nil {\nquery = params.values()\n}\n")
+		fields = append(fields, "query: query")
+	}
+	switch {
+	case op.Body != nil:
+		fields = append(fields, "body: body")
+	case len(op.Form) > 0:
+		g.printf("var fields map[string]any\nif form != nil {\nfields = form.fields()\n}\n")
+		fields = append(fields, "form: fields")
+	}
+	switch op.Auth {
+	case "":
+	case "DeviceToken":
+		fields = append(fields, "auth: authDevice")
+	default:
+		fields = append(fields, "auth: authUser")
+	}
+	req := "request{" + strings.Join(fields, ", ") + "}"
+
+	switch {
+	case op.Binary:
+		g.printf("return c.download(ctx, %s)\n}\n\n", req)
+		return
+	case result == "":
+		g.printf("_, err := c.do(ctx, %s, nil)\n", req)
+		if op.ClearTokens {
+			g.printf("if err == nil {\nc.SetTokens(Tokens{})\n}\n")
+		}
+		g.printf("return err\n}\n\n")
+		return
+	}
+
+	g.printf("var data %s\n", strings.TrimPrefix(result, "*"))
+	if op.Paginated && g.MetaType != "" {
+		g.printf("raw, err := c.do(ctx, %s, &data)\n", req)
+		g.printf("if err != nil {\nreturn nil, nil, err\n}\n")
+		g.printf("meta, err := decodeMeta(raw)\n")
+		g.printf("if err != nil {\nreturn nil, nil, err\n}\n")
+		g.printf("return %s, meta, nil\n}\n\n", addr(result))
+		return
+	}
+
+	g.printf("if _, err := c.do(ctx, %s, &data); err != nil {\nreturn %s, err\n}\n", req, zero(result))
+	if op.SetsTokens {
+		g.printf("c.SetTokens(Tokens{Token: data.Token, RefreshToken: data.RefreshToken})\n")
+	}
+	if op.ClearTokens {
+		g.printf("c.SetTokens(Tokens{})\n")
+	}
+	g.printf("return %s, nil\n}\n\n", addr(result))
+}
+
+func (g *goGen) params(op *operation) {
+	name := op.Name + "Params"
+	g.comment(fmt.Sprintf("%s are the query parameters of %s", name, op.Name))
+	g.printf("type %s struct {\n", name)
+	for _, p := range op.Query {
+		if doc := fieldDoc(p.Doc, p.Type); doc != "" {
+			g.comment(doc)
+		}
+		typ := goType(p.Type)
+		if !p.Required {
+			typ = "*" + typ
+		}
+		g.printf("%s %s\n", pascal(p.Name), typ)
+	}
+	g.printf("}\n\n")
+
+	g.printf("func (p *%s) values() url.Values {\nq := url.Values{}\n", name)
+	for _, p := range op.Query {
+		value := "p." + pascal(p.Name)
+		if !p.Required {
+			g.printf("if %s != nil {\n", value)
+			value = "*" + value
+		}
+		if isScalar(p.Type) && p.Type.Kind != kindString {
+			g.use("strconv")
+		}
+		g.printf("q.Set(%q, %s)\n", p.Name, formatValue(p.Type, value))
+		if !p.Required {
+			g.printf("}\n")
+		}
+	}
+	g.printf("return q\n}\n\n")
+}
+
+func (g *goGen) form(op *operation) {
+	name := op.Name + "Form"
+	g.comment(fmt.Sprintf("%s is the multipart form of %s", name, op.Name))
+	g.printf("type %s struct {\n", name)
+	for _, f := range op.Form {
+		if f.Doc != "" {
+			g.comment(f.Doc)
+		}
+		typ := "*File"
+		if f.Type.Kind != kindBinary {
+			typ = goType(f.Type)
+			if !f.Required {
+				typ = "*" + typ
+			}
+		}
+		g.printf("%s %s\n", pascal(f.Name), typ)
+	}
+	g.printf("}\n\n")
+
+	g.printf("func (f *%s) fields() map[string]any {\nfields := map[string]any{}\n", name)
+	for _, f := range op.Form {
+		value := "f." + pascal(f.Name)
+		switch {
+		case f.Type.Kind == kindBinary:
+			g.printf("if %s != nil {\nfields[%q] = %s\n}\n", value, f.Name, value)
+		case !f.Required:
+			g.printf("if %s != nil {\nfields[%q] = *%s\n}\n", value, f.Name, value)
+		default:
+			g.printf("fields[%q] = %s\n", f.Name, value)
+		}
+	}
+	g.printf("return fields\n}\n\n")
+}
+
+// fieldDoc is the description of a field, with the allowed values of an enum
+func fieldDoc(doc string, t *typeRef) string {
+	if len(t.Enum) == 0 {
+		return doc
+	}
+
+	values := "one of: " + strings.Join(t.Enum, ", ")
+	if doc == "" {
+		return strings.ToUpper(values[:1]) + values[1:]
+	}
+	return doc + ", " + values
+}
+
+func goType(t *typeRef) string {
+	switch t.Kind {
+	case kindString:
+		return "string"
+	case kindInteger:
+		return "int"
+	case kindNumber:
+		return "float64"
+	case kindBoolean:
+		return "bool"
+	case kindArray:
+		return "[]" + goType(t.Elem)
+	case kindMap:
+		return "map[string]" + goType(t.Elem)
+	case kindRef:
+		return t.Name
+	case kindBinary:
+		return "*File"
+	default:
+		return "any"
+	}
+}
+
+// goArgType passes structs by pointer
+func goArgType(t *typeRef) string {
+	if t.Kind == kindRef {
+		return "*" + t.Name
+	}
+	return goType(t)
+}
+
+// goResultType returns structs by pointer
+func goResultType(t *typeRef) string {
+	if t.Kind == kindAny {
+		return "json.RawMessage"
+	}
+	return goArgType(t)
+}
+
+func isScalar(t *typeRef) bool {
+	switch t.Kind {
+	case kindString, kindInteger, kindNumber, kindBoolean:
+		return true
+	default:
+		return false
+	}
+}
+
+func formatValue(t *typeRef, value string) string {
+	switch t.Kind {
+	case kindInteger:
+		return "strconv.Itoa(" + value + ")"
+	case kindNumber:
+		return "strconv.FormatFloat(" + value + ", 'f', -1, 64)"
+	case kindBoolean:
+		return "strconv.FormatBool(" + value + ")"
+	default:
+		return value
+	}
+}
+
+// goPath builds the path of op with its escaped path parameters
+func goPath(op *operation) string {
+	if len(op.PathParams) == 0 {
+		return fmt.Sprintf("%q", op.Path)
+	}
+
+	parts := []string{}
+	rest := op.Path
+	for _, p := range op.PathParams {
+		before, after, _ := strings.Cut(rest, "{"+p.Name+"}")
+		parts = append(parts, fmt.Sprintf("%q", before), "url.PathEscape("+goIdent(p.Name)+")")
+		rest = after
+	}
+	if rest != "" {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goIdent is an unexported identifier for a parameter name
+func goIdent(name string) string {
+	ident := camel(name)
+	switch ident {
+	case "body", "ctx", "form", "params", "type", "func", "range":
+		return ident + "Param"
+	}
+	return ident
+}
+
+func methodName(method string) string {
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
+
+func addr(result string) string {
+	if strings.HasPrefix(result, "*") {
+		return "&data"
+	}
+	return "data"
+}
+
+// zero is the value returned with an error
+func zero(result string) string {
+	switch result {
+	case "string":
+		return `""`
+	case "int", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	default:
+		return "nil"
+	}
+}
+
+// wrap splits text in lines of at most width characters, on spaces
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
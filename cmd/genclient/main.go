@@ -0,0 +1,110 @@
+// Command genclient generates the typed Go and TypeScript clients of the API from its OpenAPI
+// document, so consumers don't hand write HTTP calls:
+//
+//	genclient [--spec FILE|URL] [--go DIR] [--go-package NAME] [--ts DIR] [--check]
+//
+// Without --spec the embedded swagger document is converted to OpenAPI 3 like the server does
+// for /swagger/openapi.json, pass the URL of a running server to generate from what it serves.
+// Operations are named after their summary, ex: "Get a race" is GetRace in Go and getRace in
+// TypeScript. Both clients keep the tokens returned by the sign in operations and refresh an
+// expired access token once before retrying the request.
+//
+// --check compares the generated files with the ones on disk and fails when they differ, to
+// catch a client not regenerated after a change of the API.
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+)
+
+//go:embed templates
+var templates embed.FS
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "genclient:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("genclient", flag.ContinueOnError)
+	spec := fs.String("spec", "", "OpenAPI or swagger document, a file or an http(s) URL, defaults to the embedded one")
+	goDir := fs.String("go", "./pkg/client", "Output directory of the Go client, empty to skip it")
+	goPkg := fs.String("go-package", "client", "Package name of the Go client")
+	tsDir := fs.String("ts", "./clients/typescript/src", "Output directory of the TypeScript client, empty to skip it")
+	check := fs.Bool("check", false, "Fail when the files on disk differ from the generated ones instead of writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	doc, err := load(ctx, *spec)
+	if err != nil {
+		return fmt.Errorf("failed to load the document: %w", err)
+	}
+
+	a, err := newAPI(doc)
+	if err != nil {
+		return err
+	}
+
+	outputs := map[string]map[string][]byte{}
+	if *goDir != "" {
+		if outputs[*goDir], err = generateGo(a, *goPkg); err != nil {
+			return err
+		}
+	}
+	if *tsDir != "" {
+		if outputs[*tsDir], err = generateTS(a); err != nil {
+			return err
+		}
+	}
+
+	var stale []string
+	for _, dir := range sortedKeys(outputs) {
+		for _, name := range sortedKeys(outputs[dir]) {
+			path := filepath.Join(dir, name)
+			data := outputs[dir][name]
+
+			if *check {
+				current, err := os.ReadFile(path)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+				if !bytes.Equal(current, data) {
+					stale = append(stale, path)
+				}
+				continue
+			}
+
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(stale) > 0 {
+		for _, path := range stale {
+			fmt.Fprintln(stdout, "stale:", path)
+		}
+		return fmt.Errorf("%d generated files are out of date, run make clients", len(stale))
+	}
+
+	fmt.Fprintf(stdout, "%d operations and %d types of %s %s\n", len(a.Operations), len(a.Types), a.Title, a.Version)
+	return nil
+}
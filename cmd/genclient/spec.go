@@ -0,0 +1,661 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rizkyharahap/swimo/docs/swagger"
+)
+
+// Schemas of the response envelope, unwrapped by the client runtime instead of generated
+const (
+	successSchema = "response.Success"
+	errorSchema   = "response.Error"
+	metaSchema    = "response.Meta"
+)
+
+// signOutPath revokes the session, the client forgets its tokens after calling it
+const signOutPath = "/sign-out"
+
+// eventStreamType is read with EventSource, the operations answering with it get no method
+const eventStreamType = "text/event-stream"
+
+// reserved names are declared by the runtime of both clients
+var reserved = []string{
+	"ApiError", "Auth", "Call", "Client", "ClientBase", "ClientOptions", "Envelope", "Error", "File",
+	"Option", "Page", "Ptr", "RequestOptions", "Tokens",
+}
+
+type kind int
+
+const (
+	kindAny kind = iota
+	kindString
+	kindInteger
+	kindNumber
+	kindBoolean
+	kindArray
+	kindMap
+	kindRef
+	kindBinary
+)
+
+type typeRef struct {
+	Kind kind
+	Name string   // of the referenced type
+	Elem *typeRef // of arrays and maps
+	Enum []string
+}
+
+type field struct {
+	Name     string // JSON name
+	Doc      string
+	Type     *typeRef
+	Required bool
+}
+
+type typeDef struct {
+	Name   string
+	Schema string // component name, ex: race.RaceResponse
+	Doc    string
+	Fields []field
+	Map    *typeRef // values of a map type, ex: response.Facets
+}
+
+type param struct {
+	Name     string
+	Doc      string
+	Type     *typeRef
+	Required bool
+}
+
+type operation struct {
+	Name        string
+	Summary     string
+	Description string
+	Method      string
+	Path        string
+	PathParams  []param
+	Query       []param
+	Body        *typeRef // JSON request body
+	Form        []param  // multipart request body, binary fields are files
+	Result      *typeRef // data of the response envelope, nil for none
+	Binary      bool     // raw response body, ex: a file download
+	Paginated   bool
+	Auth        string // security scheme, empty for public operations
+	SetsTokens  bool   // the result carries a token and a refresh token
+	ClearTokens bool
+}
+
+type api struct {
+	Title       string
+	Version     string
+	BasePath    string
+	RefreshPath string // exchanges a refresh token, empty when the API has none
+	MetaType    string
+	Types       []*typeDef
+	Operations  []*operation
+}
+
+// load reads the OpenAPI document at source, a file or an http(s) URL such as the served
+// /swagger/openapi.json. Without source the embedded swagger document is used, converted like
+// the server does before serving it. Swagger 2.0 documents are converted to OpenAPI 3 too.
+func load(ctx context.Context, source string) (*openapi3.T, error) {
+	var data []byte
+	var err error
+
+	switch {
+	case source == "":
+		data = swagger.JSON
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		data, err = fetch(ctx, source)
+	default:
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var version struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if version.Swagger == "" {
+		return openapi3.NewLoader().LoadFromData(data)
+	}
+
+	var v2 openapi2.T
+	if err := json.Unmarshal(data, &v2); err != nil {
+		return nil, fmt.Errorf("invalid swagger document: %w", err)
+	}
+	doc, err := openapi2conv.ToV3(&v2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the swagger document: %w", err)
+	}
+
+	// Servers are only derived with a host, the server sets it from HTTP_BASE_URL
+	if len(doc.Servers) == 0 && v2.BasePath != "" {
+		doc.Servers = openapi3.Servers{{URL: v2.BasePath}}
+	}
+	return doc, nil
+}
+
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+type builder struct {
+	doc   *openapi3.T
+	names map[string]string // component name to type name
+}
+
+// newAPI builds the model both generators render from the document
+func newAPI(doc *openapi3.T) (*api, error) {
+	b := &builder{doc: doc, names: typeNames(doc.Components.Schemas)}
+
+	a := &api{
+		Title:    doc.Info.Title,
+		Version:  doc.Info.Version,
+		BasePath: basePath(doc),
+		MetaType: b.names[metaSchema],
+	}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		if name == successSchema || name == errorSchema {
+			continue
+		}
+		t, err := b.typeDef(name, doc.Components.Schemas[name])
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		a.Types = append(a.Types, t)
+	}
+	sort.Slice(a.Types, func(i, j int) bool { return a.Types[i].Name < a.Types[j].Name })
+
+	seen := map[string]string{}
+	for _, path := range sortedKeys(doc.Paths.Map()) {
+		item := doc.Paths.Value(path)
+		for method, op := range item.Operations() {
+			if streamsEvents(op) || signedByCaller(op) {
+				continue
+			}
+			o, err := b.operation(method, path, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if prev, ok := seen[o.Name]; ok {
+				return nil, fmt.Errorf("%s %s and %s are both named %s, change a summary", method, path, prev, o.Name)
+			}
+			seen[o.Name] = method + " " + path
+
+			if b.isRefresh(op) {
+				a.RefreshPath = path
+			}
+			a.Operations = append(a.Operations, o)
+		}
+	}
+	sort.Slice(a.Operations, func(i, j int) bool {
+		if a.Operations[i].Path != a.Operations[j].Path {
+			return a.Operations[i].Path < a.Operations[j].Path
+		}
+		return methodOrder(a.Operations[i].Method) < methodOrder(a.Operations[j].Method)
+	})
+
+	return a, nil
+}
+
+// typeNames names the types after their schema without the package, ex: race.RaceResponse is
+// RaceResponse. Names used by several packages or by the runtime keep the package as a prefix.
+func typeNames(schemas openapi3.Schemas) map[string]string {
+	count := map[string]int{}
+	for name := range schemas {
+		count[shortName(name)]++
+	}
+
+	names := make(map[string]string, len(schemas))
+	for name := range schemas {
+		short := shortName(name)
+		if count[short] > 1 || slices.Contains(reserved, short) {
+			pkg, _, _ := strings.Cut(name, ".")
+			short = pascal(pkg) + short
+		}
+		names[name] = short
+	}
+	return names
+}
+
+func shortName(schema string) string {
+	return pascal(schema[strings.LastIndex(schema, ".")+1:])
+}
+
+func basePath(doc *openapi3.T) string {
+	if len(doc.Servers) == 0 {
+		return ""
+	}
+	// Relative to the origin, ex: /api/v1 when the document has no host
+	u, err := url.Parse(doc.Servers[0].URL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+func (b *builder) typeDef(name string, ref *openapi3.SchemaRef) (*typeDef, error) {
+	s := ref.Value
+	t := &typeDef{Name: b.names[name], Schema: name, Doc: s.Description}
+
+	if s.AdditionalProperties.Schema != nil {
+		elem, err := b.ref(s.AdditionalProperties.Schema)
+		if err != nil {
+			return nil, err
+		}
+		t.Map = elem
+		return t, nil
+	}
+
+	for _, prop := range sortedKeys(s.Properties) {
+		typ, err := b.ref(s.Properties[prop])
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", prop, err)
+		}
+		t.Fields = append(t.Fields, field{
+			Name:     prop,
+			Doc:      schemaDoc(s.Properties[prop]),
+			Type:     typ,
+			Required: slices.Contains(s.Required, prop),
+		})
+	}
+	return t, nil
+}
+
+// schemaDoc is the description of a property, also when wrapped in an allOf to hold it
+func schemaDoc(ref *openapi3.SchemaRef) string {
+	if ref.Ref != "" || ref.Value == nil {
+		return ""
+	}
+	return ref.Value.Description
+}
+
+func (b *builder) ref(ref *openapi3.SchemaRef) (*typeRef, error) {
+	if ref == nil {
+		return &typeRef{Kind: kindAny}, nil
+	}
+	if ref.Ref != "" {
+		schema := strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+		name, ok := b.names[schema]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema %s", ref.Ref)
+		}
+		return &typeRef{Kind: kindRef, Name: name}, nil
+	}
+
+	s := ref.Value
+	if len(s.AllOf) == 1 {
+		return b.ref(s.AllOf[0])
+	}
+
+	switch {
+	case s.Type.Is(openapi3.TypeString):
+		if s.Format == "binary" {
+			return &typeRef{Kind: kindBinary}, nil
+		}
+		t := &typeRef{Kind: kindString}
+		for _, v := range s.Enum {
+			t.Enum = append(t.Enum, fmt.Sprint(v))
+		}
+		return t, nil
+	case s.Type.Is(openapi3.TypeInteger):
+		return &typeRef{Kind: kindInteger}, nil
+	case s.Type.Is(openapi3.TypeNumber):
+		return &typeRef{Kind: kindNumber}, nil
+	case s.Type.Is(openapi3.TypeBoolean):
+		return &typeRef{Kind: kindBoolean}, nil
+	case s.Type.Is(openapi3.TypeArray):
+		elem, err := b.ref(s.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &typeRef{Kind: kindArray, Elem: elem}, nil
+	case s.Type.Is(openapi3.TypeObject) && s.AdditionalProperties.Schema != nil:
+		elem, err := b.ref(s.AdditionalProperties.Schema)
+		if err != nil {
+			return nil, err
+		}
+		return &typeRef{Kind: kindMap, Elem: elem}, nil
+	default:
+		return &typeRef{Kind: kindAny}, nil
+	}
+}
+
+func (b *builder) operation(method, path string, op *openapi3.Operation) (*operation, error) {
+	o := &operation{
+		Name:        operationName(op.Summary),
+		Summary:     op.Summary,
+		Description: op.Description,
+		Method:      method,
+		Path:        path,
+		ClearTokens: path == signOutPath,
+	}
+	if o.Name == "" {
+		return nil, errors.New("missing summary, it names the client method")
+	}
+
+	for _, p := range op.Parameters {
+		typ, err := b.ref(p.Value.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Value.Name, err)
+		}
+		prm := param{Name: p.Value.Name, Doc: p.Value.Description, Type: typ, Required: p.Value.Required}
+
+		switch p.Value.In {
+		case openapi3.ParameterInPath:
+			o.PathParams = append(o.PathParams, prm)
+		case openapi3.ParameterInQuery:
+			o.Query = append(o.Query, prm)
+			o.Paginated = o.Paginated || p.Value.Name == "page"
+		case openapi3.ParameterInHeader:
+			// Optional headers, ex: Idempotency-Key, are set by the caller with the request options
+		default:
+			return nil, fmt.Errorf("parameter %s in %s is not supported", p.Value.Name, p.Value.In)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if err := b.requestBody(o, op.RequestBody.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.result(o, op.Responses); err != nil {
+		return nil, err
+	}
+	o.SetsTokens = b.hasTokens(o.Result)
+
+	security := op.Security
+	if security == nil {
+		security = &b.doc.Security
+	}
+	for _, requirement := range *security {
+		for scheme := range requirement {
+			o.Auth = scheme
+		}
+	}
+
+	return o, nil
+}
+
+func (b *builder) requestBody(o *operation, body *openapi3.RequestBody) error {
+	if media := body.Content.Get("application/json"); media != nil {
+		typ, err := b.ref(media.Schema)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		o.Body = typ
+		return nil
+	}
+
+	media := body.Content.Get("multipart/form-data")
+	if media == nil {
+		return errors.New("request body must be JSON or a multipart form")
+	}
+	s := media.Schema.Value
+	for _, name := range sortedKeys(s.Properties) {
+		typ, err := b.ref(s.Properties[name])
+		if err != nil {
+			return fmt.Errorf("form field %s: %w", name, err)
+		}
+		o.Form = append(o.Form, param{
+			Name:     name,
+			Doc:      s.Properties[name].Value.Description,
+			Type:     typ,
+			Required: slices.Contains(s.Required, name),
+		})
+	}
+	return nil
+}
+
+// result reads the data type of the first success response, unwrapping the response envelope
+func (b *builder) result(o *operation, responses *openapi3.Responses) error {
+	codes := sortedKeys(responses.Map())
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+
+		res := responses.Value(code).Value
+		for _, media := range res.Content {
+			if media.Schema == nil {
+				continue
+			}
+
+			s := media.Schema
+			if s.Ref == "" && s.Value.Type.Is(openapi3.TypeString) && s.Value.Format == "binary" {
+				o.Binary = true
+				return nil
+			}
+
+			data := b.envelopeData(s)
+			if data == nil {
+				typ, err := b.ref(s)
+				if err != nil {
+					return fmt.Errorf("response %s: %w", code, err)
+				}
+				o.Result = typ
+				return nil
+			}
+
+			// The envelope without data, ex: {} for responses carrying no payload
+			if data.Ref == "" && data.Value.Type == nil && len(data.Value.Properties) == 0 {
+				return nil
+			}
+			typ, err := b.ref(data)
+			if err != nil {
+				return fmt.Errorf("response %s: %w", code, err)
+			}
+			o.Result = typ
+			return nil
+		}
+		return nil
+	}
+	return nil
+}
+
+// envelopeData returns the data schema of a response.Success{data=...} schema, nil for other schemas
+func (b *builder) envelopeData(s *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if s.Ref != "" {
+		return nil
+	}
+
+	wrapped := false
+	var data *openapi3.SchemaRef
+	for _, part := range s.Value.AllOf {
+		if strings.HasSuffix(part.Ref, "/"+successSchema) {
+			wrapped = true
+			continue
+		}
+		if part.Value != nil && part.Value.Properties["data"] != nil {
+			data = part.Value.Properties["data"]
+		}
+	}
+	if !wrapped {
+		return nil
+	}
+	if data == nil {
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{}}
+	}
+	return data
+}
+
+// hasTokens reports whether typ is an object with a token and a refresh token, ex: a sign in
+func (b *builder) hasTokens(typ *typeRef) bool {
+	if typ == nil || typ.Kind != kindRef {
+		return false
+	}
+	for schema, name := range b.names {
+		if name != typ.Name {
+			continue
+		}
+		props := b.doc.Components.Schemas[schema].Value.Properties
+		return props["token"] != nil && props["refreshToken"] != nil
+	}
+	return false
+}
+
+// isRefresh reports whether op exchanges a refresh token for new tokens
+func (b *builder) isRefresh(op *openapi3.Operation) bool {
+	if op.RequestBody == nil {
+		return false
+	}
+	media := op.RequestBody.Value.Content.Get("application/json")
+	if media == nil || media.Schema.Value == nil || media.Schema.Value.Properties["refreshToken"] == nil {
+		return false
+	}
+
+	var res operation
+	if err := b.result(&res, op.Responses); err != nil {
+		return false
+	}
+	return b.hasTokens(res.Result)
+}
+
+// streamsEvents reports whether op answers with server-sent events
+func streamsEvents(op *openapi3.Operation) bool {
+	for code, res := range op.Responses.Map() {
+		if strings.HasPrefix(code, "2") && res.Value.Content.Get(eventStreamType) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// signedByCaller reports whether op requires a header parameter, the signature of the webhooks
+// third parties call. The clients have no use for them.
+func signedByCaller(op *openapi3.Operation) bool {
+	for _, p := range op.Parameters {
+		if p.Value.In == openapi3.ParameterInHeader && p.Value.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func methodOrder(method string) int {
+	return slices.Index([]string{"GET", "PUT", "POST", "PATCH", "DELETE"}, method)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// initialisms are kept upper case in names, ex: sessionId is SessionID
+var initialisms = map[string]string{
+	"api": "API", "hr": "HR", "http": "HTTP", "id": "ID", "ids": "IDs", "ip": "IP",
+	"jwt": "JWT", "json": "JSON", "url": "URL", "uuid": "UUID",
+}
+
+// words splits a summary, a camel case or a snake case name into words
+func words(s string) []string {
+	s = strings.ReplaceAll(s, "'", "")
+
+	var out []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			out = append(out, string(cur))
+			cur = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(cur) > 0:
+			// Split fooBar and the end of an acronym in JWTToken, but not JWT
+			prevLower := unicode.IsLower(cur[len(cur)-1]) || unicode.IsDigit(cur[len(cur)-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				flush()
+			}
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+	return out
+}
+
+// pascal joins words as an exported name, ex: "List race finishers" is ListRaceFinishers
+func pascal(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if v, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(v)
+			continue
+		}
+		runes := []rune(w)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// operationName names an operation after its summary without articles, ex: "Get a race" is
+// GetRace
+func operationName(summary string) string {
+	var kept []string
+	for _, w := range words(summary) {
+		switch strings.ToLower(w) {
+		case "a", "an", "the":
+		default:
+			kept = append(kept, w)
+		}
+	}
+	return pascal(strings.Join(kept, " "))
+}
+
+// camel is pascal with a lower case first word, ex: for TypeScript methods
+func camel(s string) string {
+	ws := words(s)
+	if len(ws) == 0 {
+		return ""
+	}
+	return strings.ToLower(ws[0]) + pascal(strings.Join(ws[1:], " "))
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// generateTS renders the TypeScript client in one module: the runtime, the types and a Client
+// class with one method per operation
+func generateTS(a *api) (map[string][]byte, error) {
+	runtime, err := render("client.ts.tmpl", a)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &tsGen{api: a}
+	g.buf.Write(runtime)
+
+	if a.MetaType != "" {
+		g.printf("\n/** Data of a paginated operation with its meta */\n")
+		g.printf("export interface Page<T> {\n  data: T;\n  meta: %s;\n}\n", a.MetaType)
+	}
+	for _, t := range a.Types {
+		g.typeDef(t)
+	}
+	for _, op := range a.Operations {
+		if len(op.Query) > 0 {
+			g.params(op)
+		}
+		if len(op.Form) > 0 {
+			g.form(op)
+		}
+	}
+
+	g.printf("\nexport class Client extends ClientBase {\n")
+	for i, op := range a.Operations {
+		if i > 0 {
+			g.printf("\n")
+		}
+		g.operation(op)
+	}
+	g.printf("}\n")
+
+	return map[string][]byte{"index.ts": g.buf.Bytes()}, nil
+}
+
+type tsGen struct {
+	*api
+	buf bytes.Buffer
+}
+
+func (g *tsGen) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment writes a JSDoc comment, paragraphs of text are separated by empty strings
+func (g *tsGen) comment(indent string, paragraphs ...string) {
+	var lines []string
+	for i, p := range paragraphs {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, wrap(p, 96)...)
+	}
+
+	if len(lines) == 1 {
+		g.printf("%s/** %s */\n", indent, lines[0])
+		return
+	}
+	g.printf("%s/**\n", indent)
+	for _, line := range lines {
+		g.printf("%s%s\n", indent, strings.TrimRight(" * "+line, " "))
+	}
+	g.printf("%s */\n", indent)
+}
+
+func (g *tsGen) typeDef(t *typeDef) {
+	g.printf("\n")
+	doc := t.Schema
+	if t.Doc != "" {
+		doc += ", " + t.Doc
+	}
+	g.comment("", doc)
+
+	if t.Map != nil {
+		g.printf("export type %s = Record<string, %s>;\n", t.Name, tsType(t.Map))
+		return
+	}
+
+	g.printf("export interface %s {\n", t.Name)
+	for _, f := range t.Fields {
+		if f.Doc != "" {
+			g.comment("  ", f.Doc)
+		}
+		g.printf("  %s%s: %s;\n", tsKey(f.Name), optional(f.Required), tsType(f.Type))
+	}
+	g.printf("}\n")
+}
+
+func (g *tsGen) params(op *operation) {
+	g.printf("\n")
+	g.comment("", fmt.Sprintf("Query parameters of %s", camel(op.Name)))
+	g.printf("export interface %sParams {\n", op.Name)
+	for _, p := range op.Query {
+		if p.Doc != "" {
+			g.comment("  ", p.Doc)
+		}
+		g.printf("  %s%s: %s;\n", tsKey(p.Name), optional(p.Required), tsType(p.Type))
+	}
+	g.printf("}\n")
+}
+
+func (g *tsGen) form(op *operation) {
+	g.printf("\n")
+	g.comment("", fmt.Sprintf("Multipart form of %s", camel(op.Name)))
+	g.printf("export interface %sForm {\n", op.Name)
+	for _, f := range op.Form {
+		if f.Doc != "" {
+			g.comment("  ", f.Doc)
+		}
+		g.printf("  %s%s: %s;\n", tsKey(f.Name), optional(f.Required), tsType(f.Type))
+	}
+	g.printf("}\n")
+}
+
+func (g *tsGen) operation(op *operation) {
+	name := camel(op.Name)
+
+	paragraphs := []string{op.Summary}
+	if op.Description != "" {
+		paragraphs = append(paragraphs, op.Description)
+	}
+	paragraphs = append(paragraphs, fmt.Sprintf("`%s %s`", op.Method, op.Path))
+	g.comment("  ", paragraphs...)
+
+	args := []string{}
+	for _, p := range op.PathParams {
+		args = append(args, camel(p.Name)+": string")
+	}
+	switch {
+	case op.Body != nil:
+		args = append(args, "body: "+tsType(op.Body))
+	case len(op.Form) > 0:
+		args = append(args, "form: "+op.Name+"Form")
+	}
+	if len(op.Query) > 0 {
+		args = append(args, "params?: "+op.Name+"Params")
+	}
+	args = append(args, "init?: RequestOptions")
+
+	result := "void"
+	if op.Result != nil {
+		result = tsType(op.Result)
+	}
+
+	var returns string
+	switch {
+	case op.Binary:
+		returns = "Blob"
+	case op.Result != nil && op.Paginated && g.MetaType != "":
+		returns = fmt.Sprintf("Page<%s>", result)
+	default:
+		returns = result
+	}
+	g.printf("  async %s(%s): Promise<%s> {\n", name, strings.Join(args, ", "), returns)
+
+	fields := []string{fmt.Sprintf("method: '%s'", op.Method), "path: " + tsPath(op)}
+	if len(op.Query) > 0 {
+		fields = append(fields, "query: params")
+	}
+	switch {
+	case op.Body != nil:
+		fields = append(fields, "body")
+	case len(op.Form) > 0:
+		fields = append(fields, "form: { ...form }")
+	}
+	switch op.Auth {
+	case "":
+		fields = append(fields, "auth: 'none'")
+	case "DeviceToken":
+		fields = append(fields, "auth: 'device'")
+	default:
+		fields = append(fields, "auth: 'user'")
+	}
+	req := "{ " + strings.Join(fields, ", ") + " }"
+
+	switch {
+	case op.Binary:
+		g.printf("    return this.download(%s, init);\n", req)
+	case op.Result == nil:
+		g.printf("    await this.call<unknown>(%s, init);\n", req)
+		if op.ClearTokens {
+			g.printf("    this.setTokens(null);\n")
+		}
+	case op.Paginated && g.MetaType != "":
+		g.printf("    const { data, meta } = await this.call<%s>(%s, init);\n", result, req)
+		g.printf("    return { data, meta: meta as %s };\n", g.MetaType)
+	default:
+		g.printf("    const { data } = await this.call<%s>(%s, init);\n", result, req)
+		if op.SetsTokens {
+			g.printf("    this.setTokens({ token: data.token ?? '', refreshToken: data.refreshToken ?? '' });\n")
+		}
+		if op.ClearTokens {
+			g.printf("    this.setTokens(null);\n")
+		}
+		g.printf("    return data;\n")
+	}
+	g.printf("  }\n")
+}
+
+func tsType(t *typeRef) string {
+	switch t.Kind {
+	case kindString:
+		if len(t.Enum) == 0 {
+			return "string"
+		}
+		values := make([]string, len(t.Enum))
+		for i, v := range t.Enum {
+			values[i] = "'" + v + "'"
+		}
+		return strings.Join(values, " | ")
+	case kindInteger, kindNumber:
+		return "number"
+	case kindBoolean:
+		return "boolean"
+	case kindArray:
+		elem := tsType(t.Elem)
+		if len(t.Elem.Enum) > 0 {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case kindMap:
+		return "Record<string, " + tsType(t.Elem) + ">"
+	case kindRef:
+		return t.Name
+	case kindBinary:
+		return "Blob"
+	default:
+		return "unknown"
+	}
+}
+
+func optional(required bool) string {
+	if required {
+		return ""
+	}
+	return "?"
+}
+
+// tsKey quotes property names that are not identifiers
+func tsKey(name string) string {
+	for _, r := range name {
+		if !(r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return "'" + name + "'"
+		}
+	}
+	return name
+}
+
+// tsPath builds the path of op as a template literal with its encoded path parameters
+func tsPath(op *operation) string {
+	if len(op.PathParams) == 0 {
+		return "'" + op.Path + "'"
+	}
+
+	path := op.Path
+	for _, p := range op.PathParams {
+		path = strings.Replace(path, "{"+p.Name+"}", "${encodeURIComponent("+camel(p.Name)+")}", 1)
+	}
+	return "`" + path + "`"
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// diff lists the differences between two JSON documents, one line per changed JSON pointer:
+// "+ /path: value" added, "- /path: value" removed, "~ /path: old -> new" changed.
+func diff(oldDoc, newDoc any) []string {
+	var lines []string
+	diffValue("", oldDoc, newDoc, &lines)
+	return lines
+}
+
+func diffValue(pointer string, oldValue, newValue any, lines *[]string) {
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+
+	oldObj, oldIsObj := oldValue.(map[string]any)
+	newObj, newIsObj := newValue.(map[string]any)
+	if oldIsObj && newIsObj {
+		keys := make(map[string]bool)
+		for key := range oldObj {
+			keys[key] = true
+		}
+		for key := range newObj {
+			keys[key] = true
+		}
+
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			child := pointer + "/" + escapePointer(key)
+			oldChild, inOld := oldObj[key]
+			newChild, inNew := newObj[key]
+
+			switch {
+			case !inOld:
+				*lines = append(*lines, fmt.Sprintf("+ %s: %s", child, compact(newChild)))
+			case !inNew:
+				*lines = append(*lines, fmt.Sprintf("- %s: %s", child, compact(oldChild)))
+			default:
+				diffValue(child, oldChild, newChild, lines)
+			}
+		}
+		return
+	}
+
+	oldArr, oldIsArr := oldValue.([]any)
+	newArr, newIsArr := newValue.([]any)
+	if oldIsArr && newIsArr {
+		for i := 0; i < max(len(oldArr), len(newArr)); i++ {
+			child := pointer + "/" + strconv.Itoa(i)
+
+			switch {
+			case i >= len(oldArr):
+				*lines = append(*lines, fmt.Sprintf("+ %s: %s", child, compact(newArr[i])))
+			case i >= len(newArr):
+				*lines = append(*lines, fmt.Sprintf("- %s: %s", child, compact(oldArr[i])))
+			default:
+				diffValue(child, oldArr[i], newArr[i], lines)
+			}
+		}
+		return
+	}
+
+	if pointer == "" {
+		pointer = "/"
+	}
+	*lines = append(*lines, fmt.Sprintf("~ %s: %s -> %s", pointer, compact(oldValue), compact(newValue)))
+}
+
+// escapePointer escapes a key as a JSON pointer token (RFC 6901)
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+func compact(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
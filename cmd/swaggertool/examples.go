@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// exampleKey identifies a response across documents, paths and methods compare case insensitively
+type exampleKey struct {
+	path   string
+	method string
+	code   string
+}
+
+// extractExamples collects the examples of every response
+func extractExamples(doc any) (map[exampleKey]any, error) {
+	res := make(map[exampleKey]any)
+
+	err := walkResponses(doc, func(key exampleKey, response map[string]any) {
+		if examples, ok := response["examples"]; ok {
+			res[key] = examples
+		}
+	})
+	return res, err
+}
+
+// injectExamples sets the examples on matching responses and returns how many were applied
+func injectExamples(doc any, examples map[exampleKey]any) (int, error) {
+	applied := 0
+
+	err := walkResponses(doc, func(key exampleKey, response map[string]any) {
+		if examples, ok := examples[key]; ok {
+			response["examples"] = examples
+			applied++
+		}
+	})
+	return applied, err
+}
+
+// walkResponses calls fn for every paths.{path}.{method}.responses.{code} object.
+// A document whose structure doesn't match swagger is an error, not silently skipped.
+func walkResponses(doc any, fn func(exampleKey, map[string]any)) error {
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return fmt.Errorf("document is not an object")
+	}
+
+	paths, ok := root["paths"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("document has no paths object")
+	}
+
+	for path, pathValue := range paths {
+		methods, ok := pathValue.(map[string]any)
+		if !ok {
+			return fmt.Errorf("paths.%s is not an object", path)
+		}
+
+		for method, methodValue := range methods {
+			operation, ok := methodValue.(map[string]any)
+			if !ok {
+				// path level parameters are arrays, not operations
+				continue
+			}
+
+			responses, ok := operation["responses"].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			for code, responseValue := range responses {
+				response, ok := responseValue.(map[string]any)
+				if !ok {
+					return fmt.Errorf("paths.%s.%s.responses.%s is not an object", path, method, code)
+				}
+
+				fn(exampleKey{path: strings.ToLower(path), method: strings.ToLower(method), code: code}, response)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+// Command swaggertool post-processes the swag generated swagger.json.
+//
+//	swaggertool merge [--dry-run] [--out FILE] BASE PATCH...
+//	swaggertool restore-examples [--dry-run] --old FILE --new FILE [--out FILE]
+//	swaggertool diff OLD NEW
+//
+// Every subcommand fails on unreadable or invalid JSON instead of writing a partial document.
+// --dry-run prints the changes the write would make and leaves the files untouched.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "swaggertool:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("missing subcommand, available: merge, restore-examples, diff")
+	}
+
+	switch args[0] {
+	case "merge":
+		return runMerge(args[1:], stdout)
+	case "restore-examples":
+		return runRestoreExamples(args[1:], stdout)
+	case "diff":
+		return runDiff(args[1:], stdout)
+	default:
+		return fmt.Errorf("unknown subcommand %q, available: merge, restore-examples, diff", args[0])
+	}
+}
+
+func runMerge(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	out := fs.String("out", "", "Output path, defaults to overwriting BASE")
+	dryRun := fs.Bool("dry-run", false, "Print the diff instead of writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return errors.New("merge needs BASE and at least one PATCH file")
+	}
+
+	base, err := readJSON(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	merged := base
+	for _, path := range fs.Args()[1:] {
+		patch, err := readJSON(path)
+		if err != nil {
+			return err
+		}
+		merged = mergePatch(merged, patch)
+	}
+
+	if *out == "" {
+		*out = fs.Arg(0)
+	}
+	return write(*out, merged, *dryRun, stdout)
+}
+
+func runRestoreExamples(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("restore-examples", flag.ContinueOnError)
+	oldPath := fs.String("old", "./docs/swagger/swagger.json", "Previous swagger.json holding hand written examples")
+	newPath := fs.String("new", "", "Newly generated swagger.json")
+	out := fs.String("out", "", "Output path, defaults to overwriting --new")
+	dryRun := fs.Bool("dry-run", false, "Print the diff instead of writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *newPath == "" {
+		return errors.New("restore-examples needs --new")
+	}
+
+	oldDoc, err := readJSON(*oldPath)
+	if err != nil {
+		return err
+	}
+	newDoc, err := readJSON(*newPath)
+	if err != nil {
+		return err
+	}
+
+	examples, err := extractExamples(oldDoc)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *oldPath, err)
+	}
+
+	applied, err := injectExamples(newDoc, examples)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *newPath, err)
+	}
+	fmt.Fprintf(stdout, "Restored %d of %d examples\n", applied, len(examples))
+
+	if *out == "" {
+		*out = *newPath
+	}
+	return write(*out, newDoc, *dryRun, stdout)
+}
+
+func runDiff(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return errors.New("diff needs OLD and NEW files")
+	}
+
+	oldDoc, err := readJSON(args[0])
+	if err != nil {
+		return err
+	}
+	newDoc, err := readJSON(args[1])
+	if err != nil {
+		return err
+	}
+
+	for _, line := range diff(oldDoc, newDoc) {
+		fmt.Fprintln(stdout, line)
+	}
+	return nil
+}
+
+func readJSON(path string) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: invalid JSON: %w", path, err)
+	}
+	return doc, nil
+}
+
+// write stores doc at path, in dry run mode it prints what would change instead
+func write(path string, doc any, dryRun bool, stdout io.Writer) error {
+	if dryRun {
+		current, err := readJSON(path)
+		if errors.Is(err, os.ErrNotExist) {
+			current = nil
+		} else if err != nil {
+			return err
+		}
+
+		changes := diff(current, doc)
+		for _, line := range changes {
+			fmt.Fprintln(stdout, line)
+		}
+		fmt.Fprintf(stdout, "Dry run, %d changes not written to %s\n", len(changes), path)
+		return nil
+	}
+
+	data, err := encode(doc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// encode formats like swag does: 4 space indent, HTML left unescaped
+func encode(doc any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,26 @@
+package main
+
+// mergePatch applies patch to target with JSON merge patch semantics (RFC 7386):
+// objects merge recursively, a null member removes the key, anything else replaces the target.
+// target is modified in place when both are objects.
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+
+	return targetObj
+}
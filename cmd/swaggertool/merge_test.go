@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{"adds missing keys", `{"a":1}`, `{"b":2}`, `{"a":1,"b":2}`},
+		{"replaces scalars", `{"a":1}`, `{"a":"x"}`, `{"a":"x"}`},
+		{"merges nested objects", `{"info":{"title":"t","version":"1"}}`, `{"info":{"version":"2"}}`, `{"info":{"title":"t","version":"2"}}`},
+		{"null removes a key", `{"a":1,"b":2}`, `{"a":null}`, `{"b":2}`},
+		{"arrays are replaced, not merged", `{"tags":["a","b"]}`, `{"tags":["c"]}`, `{"tags":["c"]}`},
+		{"object replaces scalar", `{"a":1}`, `{"a":{"b":1}}`, `{"a":{"b":1}}`},
+		{"non object patch replaces target", `{"a":1}`, `[1]`, `[1]`},
+		{"nested null on missing key is a no-op", `{}`, `{"a":{"b":null}}`, `{"a":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePatch(decode(t, tt.target), decode(t, tt.patch))
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePatch() = %s, want %s", compact(got), compact(want))
+			}
+		})
+	}
+}
+
+func TestRestoreExamples(t *testing.T) {
+	oldDoc := decode(t, `{"paths":{"/Sign-In":{"POST":{"responses":{"200":{"examples":{"application/json":{"ok":true}}}}}}}}`)
+	newDoc := decode(t, `{"paths":{"/sign-in":{"post":{"responses":{"200":{"description":"OK"},"401":{}}}}}}`)
+
+	examples, err := extractExamples(oldDoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := injectExamples(newDoc, examples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != 1 {
+		t.Fatalf("applied = %d, want 1", applied)
+	}
+
+	want := decode(t, `{"paths":{"/sign-in":{"post":{"responses":{"200":{"description":"OK","examples":{"application/json":{"ok":true}}},"401":{}}}}}}`)
+	if !reflect.DeepEqual(newDoc, want) {
+		t.Errorf("document = %s, want %s", compact(newDoc), compact(want))
+	}
+}
+
+func TestRestoreExamplesRejectsInvalidDocuments(t *testing.T) {
+	if _, err := extractExamples(decode(t, `{"swagger":"2.0"}`)); err == nil {
+		t.Error("expected an error for a document without paths")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	got := diff(
+		decode(t, `{"a":1,"b":{"c":[1,2]},"d/e":true}`),
+		decode(t, `{"a":2,"b":{"c":[1]},"f":"x"}`),
+	)
+	want := []string{
+		"~ /a: 1 -> 2",
+		"- /b/c/1: 2",
+		`- /d~1e: true`,
+		`+ /f: "x"`,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diff() = %q, want %q", got, want)
+	}
+}
+
+func decode(t *testing.T, raw string) any {
+	t.Helper()
+
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -0,0 +1,207 @@
+// Command swimoctl runs operator tasks against the Swimo database:
+//
+//	swimoctl admin create --email <email> [--name <name>] [--password <password>]
+//	swimoctl user reset-password --email <email> [--password <password>]
+//	swimoctl sessions revoke (--email <email> | --all)
+//	swimoctl migrate <up|down [n]|steps <n>|force <version>|status>
+//	swimoctl seed [--force]
+//	swimoctl fixtures [clean] [--users <n>] [--sessions <n>] [--days <n>] [--seed <n>] [--force]
+//	swimoctl jwt rotate [--write <file>]
+//
+// It reads the same environment as the API. Generated passwords and secrets are
+// printed to stdout, logs go to stderr.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/ops"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/secrets"
+)
+
+const usage = `usage: swimoctl <command> [flags]
+
+commands:
+  admin create --email <email> [--name <name>] [--password <password>]
+  user reset-password --email <email> [--password <password>]
+  sessions revoke (--email <email> | --all)
+  migrate <up|down [n]|steps <n>|force <version>|status>
+  seed [--force]
+  fixtures [clean] [--users <n>] [--sessions <n>] [--days <n>] [--seed <n>] [--force]
+  jwt rotate [--write <file>]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "swimoctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	// Secret rotation doesn't need the rest of the configuration to be valid
+	if args[0] == "jwt" {
+		return runJWT(ctx, args[1:])
+	}
+
+	resolver := secrets.NewResolver()
+
+	cfg, err := config.Load(ctx, os.Getenv("CONFIG_FILE"), resolver.Resolve)
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	log := logger.New(logger.Config{Level: cfg.Log.Level, Format: "text", Sinks: []string{"stderr"}, Redact: cfg.Log.RedactFields})
+	defer log.Close()
+
+	if cfg.Database.Embedded.Enabled {
+		server, err := database.StartEmbedded(&cfg.Database, log)
+		if err != nil {
+			return err
+		}
+		defer server.Stop()
+	}
+
+	switch args[0] {
+	case "admin":
+		return runAdmin(ctx, cfg, log, args[1:])
+	case "user":
+		return runUser(ctx, cfg, log, args[1:])
+	case "sessions":
+		return runSessions(ctx, cfg, log, args[1:])
+	case "migrate":
+		return ops.Migrate(ctx, cfg, log, args[1:])
+	case "seed":
+		return ops.Seed(ctx, cfg, log, args[1:])
+	case "fixtures":
+		return ops.Fixtures(ctx, cfg, log, args[1:])
+	default:
+		return errors.New(usage)
+	}
+}
+
+func runAdmin(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errors.New("usage: swimoctl admin create --email <email> [--name <name>] [--password <password>]")
+	}
+
+	var opts ops.AccountOptions
+	fs := flag.NewFlagSet("admin create", flag.ContinueOnError)
+	fs.StringVar(&opts.Email, "email", "", "admin email")
+	fs.StringVar(&opts.Name, "name", "", "profile name")
+	fs.StringVar(&opts.Password, "password", "", "password, generated when empty")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	password, err := ops.CreateAdmin(ctx, cfg, log, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Password == "" {
+		fmt.Println(password)
+	}
+	return nil
+}
+
+func runUser(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	if len(args) == 0 || args[0] != "reset-password" {
+		return errors.New("usage: swimoctl user reset-password --email <email> [--password <password>]")
+	}
+
+	var opts ops.AccountOptions
+	fs := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+	fs.StringVar(&opts.Email, "email", "", "account email")
+	fs.StringVar(&opts.Password, "password", "", "new password, generated when empty")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	password, err := ops.ResetPassword(ctx, cfg, log, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Password == "" {
+		fmt.Println(password)
+	}
+	return nil
+}
+
+func runSessions(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	const sessionsUsage = "usage: swimoctl sessions revoke (--email <email> | --all)"
+	if len(args) == 0 || args[0] != "revoke" {
+		return errors.New(sessionsUsage)
+	}
+
+	var (
+		email string
+		all   bool
+	)
+	fs := flag.NewFlagSet("sessions revoke", flag.ContinueOnError)
+	fs.StringVar(&email, "email", "", "account email")
+	fs.BoolVar(&all, "all", false, "revoke the sessions of every account")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// Revoking everything must be explicit
+	if (email == "") == !all {
+		return errors.New(sessionsUsage)
+	}
+
+	_, err := ops.RevokeSessions(ctx, cfg, log, email)
+	return err
+}
+
+func runJWT(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "rotate" {
+		return errors.New("usage: swimoctl jwt rotate [--write <file>]")
+	}
+
+	var path string
+	fs := flag.NewFlagSet("jwt rotate", flag.ContinueOnError)
+	fs.StringVar(&path, "write", "", "file to write the new secret to, ex: /run/secrets/JWT_SECRET")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// The current key is read from the environment unvalidated, it only moves to the previous keys
+	cfg := config.Parse()
+	if err := secrets.NewResolver().Resolve(ctx, cfg); err != nil {
+		return err
+	}
+
+	rotation, err := ops.RotateJWTSecret(&cfg.Auth, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("JWT_KEY_ID=%s\n", rotation.KeyID)
+	if path == "" {
+		fmt.Printf("JWT_SECRET=%s\n", rotation.Secret)
+	}
+	fmt.Printf("JWT_PREVIOUS_KEYS=%s\n", rotation.PreviousKeys)
+
+	if path != "" {
+		fmt.Fprintf(os.Stderr, "JWT secret written to %s, set the variables above before the API picks it up on its next secrets refresh\n", path)
+	}
+	fmt.Fprintln(os.Stderr, "Apply with a reload (SIGHUP) or restart, drop the replaced key from JWT_PREVIOUS_KEYS once access tokens it signed expired")
+	return nil
+}
@@ -1,26 +1,53 @@
 package config
 
 import (
-	"fmt"
+	"cmp"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type (
 	Config struct {
-		App       AppConfig
-		Log       LogConfig
-		Database  DatabaseConfig
-		HTTP      HTTPConfig
-		CORS      CORSConfig
-		RateLimit RateLimitConfig
-		Auth      AuthConfig
+		App          AppConfig
+		Log          LogConfig
+		Database     DatabaseConfig
+		HTTP         HTTPConfig
+		CORS         CORSConfig
+		Compression  CompressionConfig
+		RateLimit    RateLimitConfig
+		Replay       ReplayConfig
+		Metering     MeteringConfig
+		Auth         AuthConfig
+		Scheduler    SchedulerConfig
+		Broker       BrokerConfig
+		Redis        RedisConfig
+		Cache        CacheConfig
+		Metrics      MetricsConfig
+		Secrets      SecretsConfig
+		Swagger      SwaggerConfig
+		GRPC         GRPCConfig
+		Tenancy      TenancyConfig
+		Storage      StorageConfig
+		Scanner      ScannerConfig
+		Analytics    AnalyticsConfig
+		Warehouse    WarehouseConfig
+		Mailer       MailerConfig
+		Digest       DigestConfig
+		Weather      WeatherConfig
+		Billing      BillingConfig
+		TrainingLoad TrainingLoadConfig
+		Encryption   EncryptionConfig
+		Legal        LegalConfig
 	}
 
 	AppConfig struct {
 		Name string
 		Env  string // dev|staging|prod
+
+		ConfigFile     string        // optional KEY=VALUE file, re-read on change or SIGHUP
+		ReloadInterval time.Duration // how often ConfigFile is checked for changes
 	}
 
 	LogConfig struct {
@@ -28,59 +55,379 @@ type (
 		Format string // json|text
 		File   string // path ke log file (kosong = stderr saja)
 		AddSrc bool   // true untuk AddSource
+
+		Sinks         []string // stderr|file|syslog, kosong = file jika File diisi, selain itu stderr
+		MaxSizeMB     int      // rotate log file setelah ukuran ini
+		MaxAgeDays    int
+		MaxBackups    int
+		Compress      bool
+		SyslogNetwork string // udp|tcp, kosong = syslog lokal
+		SyslogAddr    string
+		SyslogTag     string
+
+		SlowRequestThreshold time.Duration // requests slower than this are logged as warning, 0 = disabled
+		// SamplePaths is the fraction of requests to a path that is logged, 0 never logs them.
+		// Paths left out are always logged.
+		SamplePaths map[string]float64
+		// RedactFields are masked whole in every log line on top of passwords, tokens and secrets,
+		// emails and tokens are masked wherever they appear
+		RedactFields []string
+		Body         BodyLogConfig
+		Access       AccessLogConfig
+	}
+
+	// AccessLogConfig writes one line per request apart from the application logs, for log
+	// pipelines expecting a web server access log
+	AccessLogConfig struct {
+		Enabled bool
+		Format  string // combined|json
+		File    string // rotated like LOG_FILE, empty = stdout
+	}
+
+	BodyLogConfig struct {
+		Enabled    bool    // log request/response bodies, meant for staging only
+		MaxBytes   int     // bodies are truncated to this size
+		SampleRate float64 // fraction of requests logged, 0..1
 	}
 
 	DatabaseConfig struct {
-		URL             string
-		Host            string
-		Port            int
-		User            string
-		Pass            string
-		Name            string
-		SSLMode         string
-		MaxConns        int32
-		MinConns        int32
-		MaxConnLifetime time.Duration
-		MaxConnIdleTime time.Duration
-		HealthTimeout   time.Duration
+		URL                  string
+		Host                 string
+		Port                 int
+		User                 string
+		Pass                 string
+		Name                 string
+		SSLMode              string
+		MaxConns             int32
+		MinConns             int32
+		MaxConnLifetime      time.Duration
+		MaxConnIdleTime      time.Duration
+		HealthTimeout        time.Duration
+		HealthVerbose        bool          // allow ?verbose=true on health endpoints
+		AutoMigrate          bool          // apply embedded migrations on start
+		QueryTimeout         time.Duration // deadline of every repository call, 0 disables
+		StatementTimeout     time.Duration // server side statement_timeout of pool connections, 0 disables
+		BreakerThreshold     int           // consecutive connection failures opening the circuit, 0 disables
+		BreakerCooldown      time.Duration // time the circuit stays open before a probe query
+		AcquireWarnThreshold time.Duration // warn when waiting this long for a pool connection, 0 disables
+		SlowQueryThreshold   time.Duration // warn about queries slower than this, 0 disables
+		LazyConnect          bool          // start serving before the database is up, handlers answer 503 until it is
+		ConnectRetryMax      time.Duration // longest wait between two connection attempts of LazyConnect
+		Embedded             EmbeddedDBConfig
+	}
+
+	// EmbeddedDBConfig runs a local Postgres inside the process for development and tests
+	EmbeddedDBConfig struct {
+		Enabled      bool
+		Port         int
+		DataDir      string // empty uses a temporary directory wiped on every start
+		CacheDir     string // downloaded binaries, empty uses ~/.embedded-postgres-go
+		StartTimeout time.Duration
 	}
 
 	HTTPConfig struct {
-		Host           string
-		Port           int
-		Prefork        bool
-		ReadTimeout    time.Duration
-		WriteTimeout   time.Duration
-		IdleTimeout    time.Duration
-		BodyLimitBytes int
-		EnableETag     bool
-		BaseURL        string
+		Host                 string
+		Port                 int
+		Prefork              bool
+		ReadTimeout          time.Duration
+		WriteTimeout         time.Duration
+		IdleTimeout          time.Duration
+		BodyLimitBytes       int
+		AuthBodyLimitBytes   int
+		UploadBodyLimitBytes int
+		EnableETag           bool
+		BaseURL              string
+		ValidateRequests     bool // check requests against the OpenAPI document
+		TLS                  TLSConfig
+		Listen               ListenConfig
+	}
+
+	ListenConfig struct {
+		Network    string // tcp|unix|systemd
+		SocketPath string // unix socket path
+		SocketMode uint32 // unix socket file permissions, ex: 0660
+	}
+
+	TLSConfig struct {
+		Enabled      bool
+		CertFile     string
+		KeyFile      string
+		AutoCert     bool     // obtain certificates from Let's Encrypt
+		Domains      []string // autocert host whitelist
+		CacheDir     string   // autocert certificate cache
+		Email        string   // autocert account contact
+		RedirectHTTP bool     // serve HTTP -> HTTPS redirect on RedirectPort
+		RedirectPort int
 	}
 
 	CORSConfig struct {
-		AllowOrigins  string
+		AllowOrigins  []string // exact, wildcard (https://*.swimo.id) or *
 		AllowMethods  string
 		AllowHeaders  string
 		ExposeHeaders string
 		Credentials   bool
+		MaxAge        time.Duration // preflight cache duration
+	}
+
+	CompressionConfig struct {
+		MinSize int  // responses smaller than this are sent uncompressed
+		Brotli  bool // prefer brotli when the client accepts it
 	}
 
 	RateLimitConfig struct {
-		Enabled   bool
-		Max       int
-		Window    time.Duration
-		KeyHeader string
+		Enabled       bool
+		Store         string // memory|redis
+		Max           int
+		Window        time.Duration
+		KeyHeader     string
+		AuthMax       int // per-IP limit for sign in/up endpoints
+		AuthWindow    time.Duration
+		AccountMax    int // per-account limit for protected endpoints
+		AccountWindow time.Duration
+		// KindQuotas replace the account limit for a kind of caller: guest, user, admin or device
+		KindQuotas map[string]RateQuota
+		// ExpensiveQuotas limit stats and search per kind of caller on top of the account
+		// limit, kinds left out are not limited further
+		ExpensiveQuotas map[string]RateQuota
+		// TrustedProxies is the number of our proxies appending to KeyHeader, the client IP is
+		// read that many entries from the right since the client controls the rest
+		TrustedProxies int
+	}
+
+	// RateQuota allows Max requests per Window, a zero Max lifts the limit
+	RateQuota struct {
+		Max    int
+		Window time.Duration
+	}
+
+	// ReplayConfig protects signed machine to machine requests, ex: device ingestion, against
+	// stale and repeated messages
+	ReplayConfig struct {
+		Enabled bool
+		Store   string        // memory|redis, remembers the nonces seen
+		Window  time.Duration // how far a signed timestamp may be from the server clock
+	}
+
+	// MeteringConfig counts requests per account and client for the usage endpoint and limits
+	// the requests paired devices, the API keys of an account, make per calendar month
+	MeteringConfig struct {
+		Enabled      bool
+		Store        string        // memory|redis
+		MonthlyQuota int           // device requests per account per UTC month, 0 lifts the quota
+		Retention    time.Duration // how long daily counts are kept
+	}
+
+	RedisConfig struct {
+		URL string // ex: redis://localhost:6379/0
+	}
+
+	CacheConfig struct {
+		Driver           string // memory|redis|none
+		Prefix           string
+		TrainingTTL      time.Duration
+		TrainingCountTTL time.Duration // totals of the training list, by filters
+	}
+
+	// EncryptionConfig sets the keys of the sensitive columns encrypted by the repositories,
+	// ex: injury notes. Without keys they are stored as plaintext.
+	EncryptionConfig struct {
+		KeyID string // id of the key encrypting new values, one of Keys
+		Keys  string // comma separated id=base64 of 32 bytes, older keys kept to read values they encrypted
+	}
+
+	// LegalConfig sets the current versions of the legal documents, accounts that accepted an
+	// older one must accept again before using the API. An empty version is not enforced.
+	LegalConfig struct {
+		TermsVersion   string // ex: 2026-10-01
+		PrivacyVersion string
+	}
+
+	StorageConfig struct {
+		Driver         string // local|s3|gcs
+		Bucket         string // s3 and gcs
+		Region         string // s3, defaults to the AWS credential chain region
+		Endpoint       string // s3 compatible endpoint, ex: http://localhost:9000 for MinIO
+		PathStyle      bool   // s3 path style addressing, required by most s3 compatible servers
+		LocalDir       string
+		SigningKey     string        // signs local download links, defaults to JWT_SECRET
+		SignTTL        time.Duration // lifetime of signed download links
+		PartSize       int64         // uploads larger than this are sent in parts
+		MaxUploadBytes int64
+	}
+
+	ScannerConfig struct {
+		Driver  string // none|clamd|http
+		Addr    string // clamd address, ex: localhost:3310 or unix:/run/clamav/clamd.sock
+		URL     string // http scanning API
+		Token   string // bearer token of the http scanning API
+		Timeout time.Duration
+	}
+
+	SecretsConfig struct {
+		Provider        string        // env|file|vault|aws|gcp
+		RefreshInterval time.Duration // re-fetch secrets periodically, 0 = only at startup
+		FileDir         string        // directory of mounted secret files (docker/k8s)
+		VaultAddr       string
+		VaultToken      string
+		VaultMount      string // kv v2 mount, ex: secret
+		VaultNamespace  string
+		AWSRegion       string
+		GCPProject      string
+	}
+
+	MetricsConfig struct {
+		Enabled bool
+		Path    string // ex: /metrics
+	}
+
+	SwaggerConfig struct {
+		Mode     string // public|basic|jwt|disabled, defaults to public in dev and disabled elsewhere
+		User     string // basic auth credentials
+		Password string
+	}
+
+	GRPCConfig struct {
+		Enabled     bool
+		Host        string
+		Port        int // ex: 9090
+		GatewayPort int // REST gateway in front of the gRPC server, 0 = disabled
+		Reflection  bool
+	}
+
+	TenancyConfig struct {
+		Enabled    bool
+		BaseDomain string        // tenants are served from <slug>.<BaseDomain>, ex: swimo.id
+		CacheTTL   time.Duration // how long a resolved subdomain is cached
+	}
+
+	AnalyticsConfig struct {
+		Sink          string        // none|postgres|kafka|http
+		URL           string        // comma separated kafka brokers or collector url
+		Topic         string        // kafka topic, ex: swimo.analytics
+		Token         string        // bearer token of the http collector
+		BufferSize    int           // events held in memory before new ones are dropped
+		BatchSize     int           // events written to the sink at once
+		FlushInterval time.Duration // longest time an event waits in the buffer
+		Timeout       time.Duration // deadline of one sink write
+		SampleRate    float64       // share of client events kept, 0..1
+		PIIFields     []string      // property names always stripped, ex: email,phone
+		IDSalt        string        // user ids are pseudonymized with this key when set
+	}
+
+	WarehouseConfig struct {
+		Format   string // csv|parquet
+		Prefix   string // storage key prefix of the export, ex: warehouse
+		IDSalt   string // key pseudonymizing user ids, shared with analytics so both join
+		Lookback int    // past days checked for a missing export, covers downtime
+	}
+
+	MailerConfig struct {
+		Driver   string // smtp|log|none, log prints messages instead of sending them
+		Host     string
+		Port     int // 465 uses implicit TLS, other ports upgrade with STARTTLS when offered
+		User     string
+		Password string
+		From     string // ex: Swimo <no-reply@swimo.id>
+		Timeout  time.Duration
+	}
+
+	DigestConfig struct {
+		SendHour  int // local hour on Monday after which the digest of the past week is sent
+		BatchSize int // users handled per run
+	}
+
+	WeatherConfig struct {
+		Provider string // none|openmeteo, fills the water conditions of open water sessions
+		URL      string // base url of the provider, ex: https://marine-api.open-meteo.com
+		Timeout  time.Duration
+	}
+
+	// BillingConfig sets the payment provider of the premium plan
+	BillingConfig struct {
+		Provider       string // none|stripe, none leaves every feature free
+		URL            string // base url of the provider API, ex: https://api.stripe.com
+		SecretKey      string
+		WebhookSecret  string // signs the events posted to the webhook
+		PremiumPriceID string // recurring price of the premium plan
+		SuccessURL     string // checkout redirects, ex: https://swimo.id/billing/success
+		CancelURL      string
+		Timeout        time.Duration
+	}
+
+	// TrainingLoadConfig sets the acute:chronic training load alerts
+	TrainingLoadConfig struct {
+		RiskRatio     float64       // acute:chronic ratio above which the user is notified, ex: 1.5
+		MinChronic    float64       // chronic load below which no alert is sent, new swimmers start from zero
+		DefaultRPE    int           // perceived exertion of sessions recorded without one, 1..10
+		AlertCooldown time.Duration // minimal time between two alerts of a user
 	}
 
 	AuthConfig struct {
 		GuestEnabled       bool
 		GuestRatePerMinute int
-		JWTSecret          string        // minimal 32 chars
-		JWTAccessTTL       time.Duration // ex: 15m
-		JWTRefreshTTL      time.Duration // ex: 720h (30d)
+		// Guest abuse: a fingerprint (client IP and user agent) signing in more than
+		// GuestSessionsPerHour times, or reporting an impossible swim, must sign up for GuestThrottle
+		GuestSessionsPerHour int
+		GuestThrottle        time.Duration
+		JWTSecret            string        // minimal 32 chars, signs new tokens
+		JWTKeyID             string        // kid of JWTSecret, empty signs tokens without kid
+		JWTPreviousKeys      string        // keys replaced by a rotation, accepted until their tokens expire: kid=secret, comma separated
+		JWTAccessTTL         time.Duration // ex: 15m
+		JWTRefreshTTL        time.Duration // ex: 720h (30d), every refresh slides the window this far again
+		SessionMaxAge        time.Duration // absolute lifetime of a session family, refreshes never pass it
+		ImpersonationTTL     time.Duration // lifetime of the support impersonation tokens
+		SignInAlertURL       string        // page of the "this wasn't me" link of sign in alerts, the token is appended as ?token=, empty disables the alerts
+		SignInAlertTTL       time.Duration // how long the link of a sign in alert works
+		CountryHeader        string        // header carrying the client country set by the CDN, ex: CF-IPCountry
+	}
+
+	SchedulerConfig struct {
+		Enabled      bool
+		SessionPurge JobConfig
+		GuestPurge   JobConfig
+		// WarehouseExport checks every interval for finished days not exported yet
+		WarehouseExport JobConfig
+		// WeeklyDigest checks every interval for users whose local send time has passed
+		WeeklyDigest JobConfig
+		// TrainingLoadAlerts checks every interval the users who swam in the last day
+		TrainingLoadAlerts JobConfig
+	}
+
+	BrokerConfig struct {
+		Driver   string // nats|kafka|noop
+		URL      string // nats url or comma separated kafka brokers
+		Prefix   string // subject/topic prefix, ex: swimo
+		Stream   string // jetstream stream name
+		ClientID string
+		Timeout  time.Duration
+	}
+
+	JobConfig struct {
+		Enabled   bool
+		Interval  time.Duration
+		Jitter    time.Duration
+		Retention time.Duration // how long expired/revoked rows are kept before purge
+		BatchSize int           // rows purged per statement, a run loops until none is left
+		Archive   bool          // move purged rows to an archive table rather than deleting them
 	}
 )
 
+// JWTKeys returns every accepted JWT secret by kid, see security.VerifyJWT. Built per call
+// so a rotation published by a secrets refresh or a reload applies right away.
+func (a *AuthConfig) JWTKeys() map[string]string {
+	keys := make(map[string]string)
+	for _, entry := range splitList(a.JWTPreviousKeys) {
+		kid, secret, ok := strings.Cut(entry, "=")
+		if !ok {
+			kid, secret = "", entry
+		}
+		keys[kid] = secret
+	}
+
+	keys[a.JWTKeyID] = a.JWTSecret
+	return keys
+}
+
 func atoiDef(s string, def int) int {
 	n, err := strconv.Atoi(s)
 	if err != nil {
@@ -89,10 +436,60 @@ func atoiDef(s string, def int) int {
 	return n
 }
 
+// parseQuotas parses comma separated kind=max/window quotas, ex: guest=10/1m. Malformed
+// entries are kept with a negative Max for Validate to report.
+func parseQuotas(s string) map[string]RateQuota {
+	quotas := make(map[string]RateQuota)
+	for _, item := range splitList(s) {
+		kind, value, _ := strings.Cut(item, "=")
+		maxRaw, windowRaw, _ := strings.Cut(value, "/")
+
+		limit, err := strconv.Atoi(strings.TrimSpace(maxRaw))
+		window, werr := time.ParseDuration(strings.TrimSpace(windowRaw))
+		if err != nil || werr != nil || limit < 0 || window <= 0 {
+			limit = -1
+		}
+
+		quotas[strings.TrimSpace(kind)] = RateQuota{Max: limit, Window: window}
+	}
+	return quotas
+}
+
+// parseSamples parses comma separated path=percent items into fractions, invalid
+// percents are kept as -1 so validation reports them
+func parseSamples(s string) map[string]float64 {
+	samples := make(map[string]float64)
+	for _, item := range splitList(s) {
+		path, value, _ := strings.Cut(item, "=")
+
+		percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			percent = -100
+		}
+
+		samples[strings.TrimSpace(path)] = percent / 100
+	}
+	return samples
+}
+
+// splitList splits a comma separated value, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func Parse() *Config {
 	app := AppConfig{
 		Name: os.Getenv("APP_NAME"),
 		Env:  os.Getenv("APP_ENV"),
+
+		ConfigFile:     os.Getenv("CONFIG_FILE"),
+		ReloadInterval: time.Duration(atoiDef(os.Getenv("CONFIG_RELOAD_INTERVAL_SEC"), 10)) * time.Second,
 	}
 
 	log := LogConfig{
@@ -100,72 +497,405 @@ func Parse() *Config {
 		Format: os.Getenv("LOG_FORMAT"),
 		File:   os.Getenv("LOG_FILE"),
 		AddSrc: os.Getenv("LOG_ADD_SOURCE") == "true",
+
+		Sinks:         splitList(os.Getenv("LOG_SINKS")),
+		MaxSizeMB:     atoiDef(os.Getenv("LOG_MAX_SIZE_MB"), 100),
+		MaxAgeDays:    atoiDef(os.Getenv("LOG_MAX_AGE_DAYS"), 14),
+		MaxBackups:    atoiDef(os.Getenv("LOG_MAX_BACKUPS"), 10),
+		Compress:      os.Getenv("LOG_COMPRESS") == "true",
+		SyslogNetwork: os.Getenv("LOG_SYSLOG_NETWORK"),
+		SyslogAddr:    os.Getenv("LOG_SYSLOG_ADDR"),
+		SyslogTag:     os.Getenv("LOG_SYSLOG_TAG"),
+
+		SlowRequestThreshold: time.Duration(atoiDef(os.Getenv("LOG_SLOW_REQUEST_MS"), 1000)) * time.Millisecond,
+		// ex: /api/v1/healthz=0,/api/v1/trainings=10, in percent
+		SamplePaths:  parseSamples(cmp.Or(os.Getenv("LOG_SAMPLE_PATHS"), "/api/v1/healthz=0,/api/v1/readyz=0,/metrics=0")),
+		RedactFields: splitList(cmp.Or(os.Getenv("LOG_REDACT_FIELDS"), "email,name,full_name,fullname,phone,authorization,cookie")),
+		Body: BodyLogConfig{
+			Enabled:    os.Getenv("LOG_BODY_ENABLED") == "true",
+			MaxBytes:   atoiDef(os.Getenv("LOG_BODY_MAX_BYTES"), 4<<10), // 4KB
+			SampleRate: float64(atoiDef(os.Getenv("LOG_BODY_SAMPLE_PERCENT"), 100)) / 100,
+		},
+		Access: AccessLogConfig{
+			Enabled: os.Getenv("ACCESS_LOG_ENABLED") == "true",
+			Format:  os.Getenv("ACCESS_LOG_FORMAT"),
+			File:    os.Getenv("ACCESS_LOG_FILE"),
+		},
 	}
 
 	database := DatabaseConfig{
-		URL:             os.Getenv("DATABASE_URL"),
-		Host:            os.Getenv("DB_HOST"),
-		Port:            atoiDef(os.Getenv("DB_PORT"), 5432),
-		User:            os.Getenv("DB_USER"),
-		Pass:            os.Getenv("DB_PASSWORD"),
-		Name:            os.Getenv("DB_NAME"),
-		SSLMode:         os.Getenv("DB_SSLMODE"),
-		MaxConns:        int32(atoiDef(os.Getenv("DB_MAX_CONNS"), 15)),
-		MinConns:        int32(atoiDef(os.Getenv("DB_MIN_CONNS"), 2)),
-		MaxConnLifetime: time.Duration(atoiDef(os.Getenv("DB_MAX_CONN_LIFETIME_SEC"), 3600)) * time.Second,
-		MaxConnIdleTime: time.Duration(atoiDef(os.Getenv("DB_MAX_CONN_IDLE_SEC"), 300)) * time.Second,
-		HealthTimeout:   time.Duration(atoiDef(os.Getenv("DB_HEALTH_TIMEOUT_MS"), 1500)) * time.Millisecond,
-	}
-	if database.URL == "" {
-		database.URL = fmt.Sprintf(
-			"postgres://%s:%s@%s:%d/%s?sslmode=%s",
-			database.User, database.Pass, database.Host, database.Port, database.Name, database.SSLMode,
-		)
+		URL:                  os.Getenv("DATABASE_URL"),
+		Host:                 os.Getenv("DB_HOST"),
+		Port:                 atoiDef(os.Getenv("DB_PORT"), 5432),
+		User:                 os.Getenv("DB_USER"),
+		Pass:                 os.Getenv("DB_PASSWORD"),
+		Name:                 os.Getenv("DB_NAME"),
+		SSLMode:              os.Getenv("DB_SSLMODE"),
+		MaxConns:             int32(atoiDef(os.Getenv("DB_MAX_CONNS"), 15)),
+		MinConns:             int32(atoiDef(os.Getenv("DB_MIN_CONNS"), 2)),
+		MaxConnLifetime:      time.Duration(atoiDef(os.Getenv("DB_MAX_CONN_LIFETIME_SEC"), 3600)) * time.Second,
+		MaxConnIdleTime:      time.Duration(atoiDef(os.Getenv("DB_MAX_CONN_IDLE_SEC"), 300)) * time.Second,
+		HealthTimeout:        time.Duration(atoiDef(os.Getenv("DB_HEALTH_TIMEOUT_MS"), 1500)) * time.Millisecond,
+		HealthVerbose:        os.Getenv("HEALTH_VERBOSE") == "true",
+		AutoMigrate:          os.Getenv("DB_AUTO_MIGRATE") == "true",
+		QueryTimeout:         time.Duration(atoiDef(os.Getenv("DB_QUERY_TIMEOUT_MS"), 5000)) * time.Millisecond,
+		StatementTimeout:     time.Duration(atoiDef(os.Getenv("DB_STATEMENT_TIMEOUT_MS"), 30000)) * time.Millisecond,
+		BreakerThreshold:     atoiDef(os.Getenv("DB_BREAKER_THRESHOLD"), 5),
+		BreakerCooldown:      time.Duration(atoiDef(os.Getenv("DB_BREAKER_COOLDOWN_SEC"), 10)) * time.Second,
+		AcquireWarnThreshold: time.Duration(atoiDef(os.Getenv("DB_ACQUIRE_WARN_MS"), 500)) * time.Millisecond,
+		SlowQueryThreshold:   time.Duration(atoiDef(os.Getenv("DB_SLOW_QUERY_MS"), 200)) * time.Millisecond,
+		LazyConnect:          os.Getenv("DB_LAZY_CONNECT") == "true",
+		ConnectRetryMax:      time.Duration(atoiDef(os.Getenv("DB_CONNECT_RETRY_MAX_SEC"), 30)) * time.Second,
+		Embedded: EmbeddedDBConfig{
+			Enabled:      os.Getenv("DB_EMBEDDED") == "true",
+			Port:         atoiDef(os.Getenv("DB_EMBEDDED_PORT"), 5433),
+			DataDir:      os.Getenv("DB_EMBEDDED_DATA_DIR"),
+			CacheDir:     os.Getenv("DB_EMBEDDED_CACHE_DIR"),
+			StartTimeout: time.Duration(atoiDef(os.Getenv("DB_EMBEDDED_START_TIMEOUT_SEC"), 60)) * time.Second,
+		},
 	}
 
 	http := HTTPConfig{
-		Host:           os.Getenv("HTTP_HOST"),
-		Port:           atoiDef(os.Getenv("HTTP_PORT"), 8080),
-		Prefork:        os.Getenv("HTTP_PREFORK") == "true",
-		ReadTimeout:    time.Duration(atoiDef(os.Getenv("HTTP_READ_TIMEOUT_MS"), 10000)) * time.Millisecond,
-		WriteTimeout:   time.Duration(atoiDef(os.Getenv("HTTP_WRITE_TIMEOUT_MS"), 10000)) * time.Millisecond,
-		IdleTimeout:    time.Duration(atoiDef(os.Getenv("HTTP_IDLE_TIMEOUT_MS"), 60000)) * time.Millisecond,
-		BodyLimitBytes: atoiDef(os.Getenv("HTTP_BODY_LIMIT_BYTES"), 10<<20), // 10MB
-		EnableETag:     os.Getenv("HTTP_ETAG") == "true",
-		BaseURL:        os.Getenv("HTTP_BASE_URL"),
+		Host:                 os.Getenv("HTTP_HOST"),
+		Port:                 atoiDef(os.Getenv("HTTP_PORT"), 8080),
+		Prefork:              os.Getenv("HTTP_PREFORK") == "true",
+		ReadTimeout:          time.Duration(atoiDef(os.Getenv("HTTP_READ_TIMEOUT_MS"), 10000)) * time.Millisecond,
+		WriteTimeout:         time.Duration(atoiDef(os.Getenv("HTTP_WRITE_TIMEOUT_MS"), 10000)) * time.Millisecond,
+		IdleTimeout:          time.Duration(atoiDef(os.Getenv("HTTP_IDLE_TIMEOUT_MS"), 60000)) * time.Millisecond,
+		BodyLimitBytes:       atoiDef(os.Getenv("HTTP_BODY_LIMIT_BYTES"), 10<<20),         // 10MB
+		AuthBodyLimitBytes:   atoiDef(os.Getenv("HTTP_AUTH_BODY_LIMIT_BYTES"), 16<<10),    // 16KB
+		UploadBodyLimitBytes: atoiDef(os.Getenv("HTTP_UPLOAD_BODY_LIMIT_BYTES"), 100<<20), // 100MB
+		EnableETag:           os.Getenv("HTTP_ETAG") == "true",
+		BaseURL:              os.Getenv("HTTP_BASE_URL"),
+		ValidateRequests:     os.Getenv("HTTP_VALIDATE_REQUESTS") == "true",
+		TLS: TLSConfig{
+			Enabled:      os.Getenv("TLS_ENABLED") == "true",
+			CertFile:     os.Getenv("TLS_CERT_FILE"),
+			KeyFile:      os.Getenv("TLS_KEY_FILE"),
+			AutoCert:     os.Getenv("TLS_AUTOCERT") == "true",
+			Domains:      splitList(os.Getenv("TLS_AUTOCERT_DOMAINS")),
+			CacheDir:     os.Getenv("TLS_AUTOCERT_CACHE_DIR"),
+			Email:        os.Getenv("TLS_AUTOCERT_EMAIL"),
+			RedirectHTTP: os.Getenv("TLS_REDIRECT_HTTP") == "true",
+			RedirectPort: atoiDef(os.Getenv("TLS_REDIRECT_PORT"), 80),
+		},
+	}
+	http.Listen = ListenConfig{
+		Network:    os.Getenv("HTTP_LISTEN_NETWORK"),
+		SocketPath: os.Getenv("HTTP_SOCKET_PATH"),
+		SocketMode: 0o660,
+	}
+	if mode, err := strconv.ParseUint(os.Getenv("HTTP_SOCKET_MODE"), 8, 32); err == nil {
+		http.Listen.SocketMode = uint32(mode)
+	}
+	if http.TLS.CacheDir == "" {
+		http.TLS.CacheDir = "./certs"
 	}
 
 	cors := CORSConfig{
-		AllowOrigins:  os.Getenv("CORS_ALLOW_ORIGINS"),
+		AllowOrigins:  splitList(os.Getenv("CORS_ALLOW_ORIGINS")),
 		AllowMethods:  os.Getenv("CORS_ALLOW_METHODS"),
 		AllowHeaders:  os.Getenv("CORS_ALLOW_HEADERS"),
 		ExposeHeaders: os.Getenv("CORS_EXPOSE_HEADERS"),
 		Credentials:   os.Getenv("CORS_CREDENTIALS") == "true",
+		MaxAge:        time.Duration(atoiDef(os.Getenv("CORS_MAX_AGE_SEC"), 600)) * time.Second,
+	}
+
+	compression := CompressionConfig{
+		MinSize: atoiDef(os.Getenv("COMPRESSION_MIN_SIZE_BYTES"), 1024),
+		Brotli:  os.Getenv("COMPRESSION_BROTLI") == "true",
 	}
 
 	rateLimit := RateLimitConfig{
-		Enabled:   os.Getenv("RATE_LIMIT_ENABLED") == "true",
-		Max:       atoiDef(os.Getenv("RATE_LIMIT_MAX"), 120),
-		Window:    time.Duration(atoiDef(os.Getenv("RATE_LIMIT_WINDOW_SEC"), 60)) * time.Second,
-		KeyHeader: os.Getenv("RATE_LIMIT_KEY_HEADER"),
+		Enabled:       os.Getenv("RATE_LIMIT_ENABLED") == "true",
+		Store:         os.Getenv("RATE_LIMIT_STORE"),
+		Max:           atoiDef(os.Getenv("RATE_LIMIT_MAX"), 120),
+		Window:        time.Duration(atoiDef(os.Getenv("RATE_LIMIT_WINDOW_SEC"), 60)) * time.Second,
+		KeyHeader:     os.Getenv("RATE_LIMIT_KEY_HEADER"),
+		AuthMax:       atoiDef(os.Getenv("RATE_LIMIT_AUTH_MAX"), 10),
+		AuthWindow:    time.Duration(atoiDef(os.Getenv("RATE_LIMIT_AUTH_WINDOW_SEC"), 60)) * time.Second,
+		AccountMax:    atoiDef(os.Getenv("RATE_LIMIT_ACCOUNT_MAX"), 300),
+		AccountWindow: time.Duration(atoiDef(os.Getenv("RATE_LIMIT_ACCOUNT_WINDOW_SEC"), 60)) * time.Second,
+		// ex: guest=60/1m,admin=0/1m
+		KindQuotas:      parseQuotas(os.Getenv("RATE_LIMIT_KIND_QUOTAS")),
+		ExpensiveQuotas: parseQuotas(cmp.Or(os.Getenv("RATE_LIMIT_EXPENSIVE_QUOTAS"), "guest=10/1m,user=60/1m")),
+		TrustedProxies:  atoiDef(os.Getenv("RATE_LIMIT_TRUSTED_PROXIES"), 1),
+	}
+
+	replay := ReplayConfig{
+		Enabled: os.Getenv("REPLAY_PROTECTION_ENABLED") == "true",
+		Store:   os.Getenv("REPLAY_STORE"),
+		Window:  time.Duration(atoiDef(os.Getenv("REPLAY_WINDOW_SEC"), 300)) * time.Second,
+	}
+
+	metering := MeteringConfig{
+		Enabled:      os.Getenv("METERING_ENABLED") == "true",
+		Store:        os.Getenv("METERING_STORE"),
+		MonthlyQuota: atoiDef(os.Getenv("METERING_MONTHLY_QUOTA"), 0),
+		Retention:    time.Duration(atoiDef(os.Getenv("METERING_RETENTION_DAYS"), 90)) * 24 * time.Hour,
+	}
+
+	redis := RedisConfig{
+		URL: os.Getenv("REDIS_URL"),
+	}
+
+	cache := CacheConfig{
+		Driver:           os.Getenv("CACHE_DRIVER"),
+		Prefix:           os.Getenv("CACHE_PREFIX"),
+		TrainingTTL:      time.Duration(atoiDef(os.Getenv("CACHE_TRAINING_TTL_SEC"), 300)) * time.Second,
+		TrainingCountTTL: time.Duration(atoiDef(os.Getenv("CACHE_TRAINING_COUNT_TTL_SEC"), 60)) * time.Second,
+	}
+	if cache.Prefix == "" {
+		cache.Prefix = "swimo:cache:"
+	}
+
+	encryption := EncryptionConfig{
+		KeyID: os.Getenv("PII_ENCRYPTION_KEY_ID"),
+		Keys:  os.Getenv("PII_ENCRYPTION_KEYS"),
+	}
+
+	legal := LegalConfig{
+		TermsVersion:   strings.TrimSpace(os.Getenv("TERMS_VERSION")),
+		PrivacyVersion: strings.TrimSpace(os.Getenv("PRIVACY_VERSION")),
+	}
+
+	storage := StorageConfig{
+		Driver:         os.Getenv("STORAGE_DRIVER"),
+		Bucket:         os.Getenv("STORAGE_BUCKET"),
+		Region:         os.Getenv("STORAGE_REGION"),
+		Endpoint:       os.Getenv("STORAGE_ENDPOINT"),
+		PathStyle:      os.Getenv("STORAGE_PATH_STYLE") == "true",
+		LocalDir:       os.Getenv("STORAGE_LOCAL_DIR"),
+		SigningKey:     os.Getenv("STORAGE_SIGNING_KEY"),
+		SignTTL:        time.Duration(atoiDef(os.Getenv("STORAGE_SIGN_TTL_MIN"), 15)) * time.Minute,
+		PartSize:       int64(atoiDef(os.Getenv("STORAGE_PART_SIZE_MB"), 8)) << 20,
+		MaxUploadBytes: int64(atoiDef(os.Getenv("STORAGE_MAX_UPLOAD_MB"), 512)) << 20,
+	}
+
+	metrics := MetricsConfig{
+		Enabled: os.Getenv("METRICS_ENABLED") == "true",
+		Path:    os.Getenv("METRICS_PATH"),
+	}
+	if metrics.Path == "" {
+		metrics.Path = "/metrics"
+	}
+
+	swagger := SwaggerConfig{
+		Mode:     os.Getenv("SWAGGER_MODE"),
+		User:     os.Getenv("SWAGGER_USER"),
+		Password: os.Getenv("SWAGGER_PASSWORD"),
+	}
+
+	grpc := GRPCConfig{
+		Enabled:     os.Getenv("GRPC_ENABLED") == "true",
+		Host:        os.Getenv("GRPC_HOST"),
+		Port:        atoiDef(os.Getenv("GRPC_PORT"), 9090),
+		GatewayPort: atoiDef(os.Getenv("GRPC_GATEWAY_PORT"), 0),
+		Reflection:  os.Getenv("GRPC_REFLECTION") == "true",
+	}
+
+	tenancy := TenancyConfig{
+		Enabled:    os.Getenv("TENANCY_ENABLED") == "true",
+		BaseDomain: strings.ToLower(strings.TrimSpace(os.Getenv("TENANCY_BASE_DOMAIN"))),
+		CacheTTL:   time.Duration(atoiDef(os.Getenv("TENANCY_CACHE_TTL_SEC"), 300)) * time.Second,
+	}
+
+	scanner := ScannerConfig{
+		Driver:  os.Getenv("SCANNER_DRIVER"),
+		Addr:    os.Getenv("SCANNER_ADDR"),
+		URL:     os.Getenv("SCANNER_URL"),
+		Token:   os.Getenv("SCANNER_TOKEN"),
+		Timeout: time.Duration(atoiDef(os.Getenv("SCANNER_TIMEOUT_SEC"), 120)) * time.Second,
+	}
+
+	analytics := AnalyticsConfig{
+		Sink:          os.Getenv("ANALYTICS_SINK"),
+		URL:           os.Getenv("ANALYTICS_URL"),
+		Topic:         os.Getenv("ANALYTICS_TOPIC"),
+		Token:         os.Getenv("ANALYTICS_TOKEN"),
+		BufferSize:    atoiDef(os.Getenv("ANALYTICS_BUFFER_SIZE"), 10000),
+		BatchSize:     atoiDef(os.Getenv("ANALYTICS_BATCH_SIZE"), 500),
+		FlushInterval: time.Duration(atoiDef(os.Getenv("ANALYTICS_FLUSH_INTERVAL_SEC"), 5)) * time.Second,
+		Timeout:       time.Duration(atoiDef(os.Getenv("ANALYTICS_TIMEOUT_SEC"), 10)) * time.Second,
+		SampleRate:    float64(atoiDef(os.Getenv("ANALYTICS_SAMPLE_PERCENT"), 100)) / 100,
+		PIIFields:     splitList(os.Getenv("ANALYTICS_PII_FIELDS")),
+		IDSalt:        os.Getenv("ANALYTICS_ID_SALT"),
+	}
+	if analytics.Topic == "" {
+		analytics.Topic = "swimo.analytics"
+	}
+	if analytics.PIIFields == nil {
+		analytics.PIIFields = []string{"email", "name", "phone", "address", "password", "token", "ip"}
+	}
+
+	warehouse := WarehouseConfig{
+		Format:   os.Getenv("WAREHOUSE_FORMAT"),
+		Prefix:   strings.Trim(os.Getenv("WAREHOUSE_PREFIX"), "/"),
+		IDSalt:   os.Getenv("WAREHOUSE_ID_SALT"),
+		Lookback: atoiDef(os.Getenv("WAREHOUSE_LOOKBACK_DAYS"), 3),
+	}
+	if warehouse.Prefix == "" {
+		warehouse.Prefix = "warehouse"
+	}
+
+	mailer := MailerConfig{
+		Driver:   os.Getenv("MAILER_DRIVER"),
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     atoiDef(os.Getenv("SMTP_PORT"), 587),
+		User:     os.Getenv("SMTP_USER"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("MAIL_FROM"),
+		Timeout:  time.Duration(atoiDef(os.Getenv("SMTP_TIMEOUT_SEC"), 30)) * time.Second,
+	}
+
+	digest := DigestConfig{
+		SendHour:  atoiDef(os.Getenv("DIGEST_SEND_HOUR"), 8),
+		BatchSize: atoiDef(os.Getenv("DIGEST_BATCH_SIZE"), 200),
+	}
+
+	weather := WeatherConfig{
+		Provider: os.Getenv("WEATHER_PROVIDER"),
+		URL:      os.Getenv("WEATHER_URL"),
+		Timeout:  time.Duration(atoiDef(os.Getenv("WEATHER_TIMEOUT_SEC"), 5)) * time.Second,
+	}
+	if weather.URL == "" {
+		weather.URL = "https://marine-api.open-meteo.com"
+	}
+
+	billing := BillingConfig{
+		Provider:       os.Getenv("BILLING_PROVIDER"),
+		URL:            os.Getenv("STRIPE_URL"),
+		SecretKey:      os.Getenv("STRIPE_SECRET_KEY"),
+		WebhookSecret:  os.Getenv("STRIPE_WEBHOOK_SECRET"),
+		PremiumPriceID: os.Getenv("STRIPE_PREMIUM_PRICE_ID"),
+		SuccessURL:     os.Getenv("BILLING_SUCCESS_URL"),
+		CancelURL:      os.Getenv("BILLING_CANCEL_URL"),
+		Timeout:        time.Duration(atoiDef(os.Getenv("BILLING_TIMEOUT_SEC"), 10)) * time.Second,
+	}
+	if billing.URL == "" {
+		billing.URL = "https://api.stripe.com"
+	}
+
+	trainingLoad := TrainingLoadConfig{
+		RiskRatio:     float64(atoiDef(os.Getenv("TRAINING_LOAD_RISK_PERCENT"), 150)) / 100,
+		MinChronic:    float64(atoiDef(os.Getenv("TRAINING_LOAD_MIN_CHRONIC"), 30)),
+		DefaultRPE:    atoiDef(os.Getenv("TRAINING_LOAD_DEFAULT_RPE"), 5),
+		AlertCooldown: time.Duration(atoiDef(os.Getenv("TRAINING_LOAD_ALERT_COOLDOWN_HOURS"), 168)) * time.Hour,
+	}
+
+	secrets := SecretsConfig{
+		Provider:        os.Getenv("SECRETS_PROVIDER"),
+		RefreshInterval: time.Duration(atoiDef(os.Getenv("SECRETS_REFRESH_INTERVAL_SEC"), 0)) * time.Second,
+		FileDir:         os.Getenv("SECRETS_FILE_DIR"),
+		VaultAddr:       os.Getenv("VAULT_ADDR"),
+		VaultToken:      os.Getenv("VAULT_TOKEN"),
+		VaultMount:      os.Getenv("VAULT_MOUNT"),
+		VaultNamespace:  os.Getenv("VAULT_NAMESPACE"),
+		AWSRegion:       os.Getenv("AWS_REGION"),
+		GCPProject:      os.Getenv("GCP_PROJECT"),
+	}
+	if secrets.VaultMount == "" {
+		secrets.VaultMount = "secret"
+	}
+	if secrets.FileDir == "" {
+		secrets.FileDir = "/run/secrets"
 	}
 
 	auth := AuthConfig{
-		GuestEnabled:       os.Getenv("GUEST_ENABLED") == "true",
-		GuestRatePerMinute: atoiDef(os.Getenv("GUEST_SIGNIN_RATE_PER_MIN"), 10),
-		JWTSecret:          os.Getenv("JWT_SECRET"),
-		JWTAccessTTL:       time.Duration(atoiDef(os.Getenv("JWT_ACCESS_TTL_MIN"), 15)) * time.Minute,
-		JWTRefreshTTL:      time.Duration(atoiDef(os.Getenv("JWT_REFRESH_TTL_HOURS"), 720)) * time.Hour,
+		GuestEnabled:         os.Getenv("GUEST_ENABLED") == "true",
+		GuestRatePerMinute:   atoiDef(os.Getenv("GUEST_SIGNIN_RATE_PER_MIN"), 10),
+		GuestSessionsPerHour: atoiDef(os.Getenv("GUEST_ABUSE_SESSIONS_PER_HOUR"), 30),
+		GuestThrottle:        time.Duration(atoiDef(os.Getenv("GUEST_ABUSE_THROTTLE_HOURS"), 24)) * time.Hour,
+		JWTSecret:            os.Getenv("JWT_SECRET"),
+		JWTKeyID:             os.Getenv("JWT_KEY_ID"),
+		JWTPreviousKeys:      os.Getenv("JWT_PREVIOUS_KEYS"),
+		JWTAccessTTL:         time.Duration(atoiDef(os.Getenv("JWT_ACCESS_TTL_MIN"), 15)) * time.Minute,
+		JWTRefreshTTL:        time.Duration(atoiDef(os.Getenv("JWT_REFRESH_TTL_HOURS"), 720)) * time.Hour,
+		SessionMaxAge:        time.Duration(atoiDef(os.Getenv("SESSION_MAX_AGE_DAYS"), 90)) * 24 * time.Hour,
+		ImpersonationTTL:     time.Duration(atoiDef(os.Getenv("JWT_IMPERSONATION_TTL_MIN"), 15)) * time.Minute,
+		SignInAlertURL:       os.Getenv("SIGNIN_ALERT_URL"),
+		SignInAlertTTL:       time.Duration(atoiDef(os.Getenv("SIGNIN_ALERT_TTL_HOURS"), 168)) * time.Hour,
+		CountryHeader:        os.Getenv("CLIENT_COUNTRY_HEADER"),
+	}
+
+	scheduler := SchedulerConfig{
+		Enabled: os.Getenv("SCHEDULER_ENABLED") == "true",
+		SessionPurge: JobConfig{
+			Enabled:   os.Getenv("JOB_SESSION_PURGE_ENABLED") == "true",
+			Interval:  time.Duration(atoiDef(os.Getenv("JOB_SESSION_PURGE_INTERVAL_MIN"), 60)) * time.Minute,
+			Jitter:    time.Duration(atoiDef(os.Getenv("JOB_SESSION_PURGE_JITTER_SEC"), 60)) * time.Second,
+			Retention: time.Duration(atoiDef(os.Getenv("JOB_SESSION_PURGE_RETENTION_HOURS"), 168)) * time.Hour,
+			BatchSize: atoiDef(os.Getenv("JOB_SESSION_PURGE_BATCH_SIZE"), 1000),
+			Archive:   os.Getenv("JOB_SESSION_PURGE_ARCHIVE") == "true",
+		},
+		GuestPurge: JobConfig{
+			Enabled:   os.Getenv("JOB_GUEST_PURGE_ENABLED") == "true",
+			Interval:  time.Duration(atoiDef(os.Getenv("JOB_GUEST_PURGE_INTERVAL_MIN"), 30)) * time.Minute,
+			Jitter:    time.Duration(atoiDef(os.Getenv("JOB_GUEST_PURGE_JITTER_SEC"), 60)) * time.Second,
+			Retention: time.Duration(atoiDef(os.Getenv("JOB_GUEST_PURGE_RETENTION_HOURS"), 24)) * time.Hour,
+			BatchSize: atoiDef(os.Getenv("JOB_GUEST_PURGE_BATCH_SIZE"), 1000),
+			Archive:   os.Getenv("JOB_GUEST_PURGE_ARCHIVE") == "true",
+		},
+		WarehouseExport: JobConfig{
+			Enabled:  os.Getenv("JOB_WAREHOUSE_EXPORT_ENABLED") == "true",
+			Interval: time.Duration(atoiDef(os.Getenv("JOB_WAREHOUSE_EXPORT_INTERVAL_MIN"), 60)) * time.Minute,
+			Jitter:   time.Duration(atoiDef(os.Getenv("JOB_WAREHOUSE_EXPORT_JITTER_SEC"), 300)) * time.Second,
+		},
+		WeeklyDigest: JobConfig{
+			Enabled:  os.Getenv("JOB_WEEKLY_DIGEST_ENABLED") == "true",
+			Interval: time.Duration(atoiDef(os.Getenv("JOB_WEEKLY_DIGEST_INTERVAL_MIN"), 15)) * time.Minute,
+			Jitter:   time.Duration(atoiDef(os.Getenv("JOB_WEEKLY_DIGEST_JITTER_SEC"), 60)) * time.Second,
+		},
+		TrainingLoadAlerts: JobConfig{
+			Enabled:  os.Getenv("JOB_TRAINING_LOAD_ALERTS_ENABLED") == "true",
+			Interval: time.Duration(atoiDef(os.Getenv("JOB_TRAINING_LOAD_ALERTS_INTERVAL_MIN"), 60)) * time.Minute,
+			Jitter:   time.Duration(atoiDef(os.Getenv("JOB_TRAINING_LOAD_ALERTS_JITTER_SEC"), 60)) * time.Second,
+		},
+	}
+
+	broker := BrokerConfig{
+		Driver:   os.Getenv("BROKER_DRIVER"),
+		URL:      os.Getenv("BROKER_URL"),
+		Prefix:   os.Getenv("BROKER_PREFIX"),
+		Stream:   os.Getenv("BROKER_STREAM"),
+		ClientID: os.Getenv("BROKER_CLIENT_ID"),
+		Timeout:  time.Duration(atoiDef(os.Getenv("BROKER_TIMEOUT_MS"), 5000)) * time.Millisecond,
+	}
+	if broker.Stream == "" {
+		broker.Stream = "SWIMO_EVENTS"
 	}
 
 	cfg := &Config{
-		App:       app,
-		Log:       log,
-		Database:  database,
-		HTTP:      http,
-		CORS:      cors,
-		RateLimit: rateLimit,
-		Auth:      auth,
+		App:          app,
+		Log:          log,
+		Database:     database,
+		HTTP:         http,
+		CORS:         cors,
+		Compression:  compression,
+		RateLimit:    rateLimit,
+		Replay:       replay,
+		Metering:     metering,
+		Auth:         auth,
+		Scheduler:    scheduler,
+		Broker:       broker,
+		Redis:        redis,
+		Cache:        cache,
+		Metrics:      metrics,
+		Secrets:      secrets,
+		Swagger:      swagger,
+		GRPC:         grpc,
+		Tenancy:      tenancy,
+		Storage:      storage,
+		Scanner:      scanner,
+		Analytics:    analytics,
+		Warehouse:    warehouse,
+		Mailer:       mailer,
+		Digest:       digest,
+		Weather:      weather,
+		Billing:      billing,
+		TrainingLoad: trainingLoad,
+		Encryption:   encryption,
+		Legal:        legal,
 	}
 
 	return cfg
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ResolveFunc replaces secret references in cfg with their values
+type ResolveFunc func(ctx context.Context, cfg *Config) error
+
+// Load applies the optional env file at path over the process environment,
+// then parses, resolves secrets and validates the configuration.
+// An empty path reads env only, a nil resolve keeps values as-is.
+func Load(ctx context.Context, path string, resolve ResolveFunc) (*Config, error) {
+	if path != "" {
+		if err := loadEnvFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := Parse()
+	if resolve != nil {
+		if err := resolve(ctx, cfg); err != nil {
+			return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadEnvFile sets every KEY=VALUE line of a dotenv style file as environment variable.
+// Values in the file win over the process environment so reloads pick up edits.
+func loadEnvFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		if !ok {
+			return fmt.Errorf("config file %s line %d: expected KEY=VALUE", path, line)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("config file %s line %d: %w", path, line, err)
+		}
+	}
+
+	return scanner.Err()
+}
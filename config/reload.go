@@ -0,0 +1,132 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Store holds the current configuration snapshot. Components that support
+// hot reload read Load() per request instead of keeping a *Config around.
+type Store struct {
+	path    string
+	resolve ResolveFunc
+	mu      sync.Mutex // serializes writers, readers stay lock free
+	current atomic.Pointer[Config]
+}
+
+// NewStore creates a store serving cfg, reloads re-read the env file at path
+// and resolve secret references with resolve
+func NewStore(cfg *Config, path string, resolve ResolveFunc) *Store {
+	s := &Store{path: path, resolve: resolve}
+	s.current.Store(cfg)
+	return s
+}
+
+// Update publishes a copy of the current snapshot modified by fn
+func (s *Store) Update(fn func(cfg *Config)) *Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := *s.Load()
+	fn(&snapshot)
+	s.current.Store(&snapshot)
+	return &snapshot
+}
+
+// Load returns the current snapshot, it must be treated as read-only
+func (s *Store) Load() *Config {
+	return s.current.Load()
+}
+
+// Reload re-reads and validates the configuration, then publishes a new snapshot
+// where only runtime-safe settings changed: log level, rate limits, the monthly quota, CORS, guest sign in,
+// JWT keys and legal document versions. Everything else (listeners, database, other secrets) still requires a restart.
+func (s *Store) Reload() (*Config, error) {
+	next, err := Load(context.Background(), s.path, s.resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Update(func(snapshot *Config) {
+		snapshot.Log.Level = next.Log.Level
+		snapshot.CORS = next.CORS
+		snapshot.Auth.GuestEnabled = next.Auth.GuestEnabled
+		snapshot.Auth.GuestRatePerMinute = next.Auth.GuestRatePerMinute
+		snapshot.Auth.GuestSessionsPerHour = next.Auth.GuestSessionsPerHour
+		snapshot.Auth.GuestThrottle = next.Auth.GuestThrottle
+
+		// A rotation swaps the signing key and keeps the old one accepted, both read per request
+		snapshot.Auth.JWTSecret = next.Auth.JWTSecret
+		snapshot.Auth.JWTKeyID = next.Auth.JWTKeyID
+		snapshot.Auth.JWTPreviousKeys = next.Auth.JWTPreviousKeys
+
+		// A new version of the legal documents asks every account to accept it again
+		snapshot.Legal = next.Legal
+
+		// The store backend and on/off switch are wired at startup, only the limits are live
+		enabled, store := snapshot.RateLimit.Enabled, snapshot.RateLimit.Store
+		snapshot.RateLimit = next.RateLimit
+		snapshot.RateLimit.Enabled, snapshot.RateLimit.Store = enabled, store
+
+		// Same for metering, a new quota applies to the next request
+		snapshot.Metering.MonthlyQuota = next.Metering.MonthlyQuota
+	}), nil
+}
+
+// Watch reloads on SIGHUP and, when a config file is used, whenever its modification
+// time changes, polling every interval. It blocks until ctx is done.
+func (s *Store) Watch(ctx context.Context, interval time.Duration, onReload func(*Config), onError func(error)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var ticker <-chan time.Time
+	if s.path != "" && interval > 0 {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		ticker = t.C
+	}
+
+	lastMod := s.modTime()
+
+	reload := func() {
+		cfg, err := s.Reload()
+		if err != nil {
+			onError(err)
+			return
+		}
+		onReload(cfg)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			lastMod = s.modTime()
+			reload()
+		case <-ticker:
+			if mod := s.modTime(); !mod.Equal(lastMod) {
+				lastMod = mod
+				reload()
+			}
+		}
+	}
+}
+
+func (s *Store) modTime() time.Time {
+	if s.path == "" {
+		return time.Time{}
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,415 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// minJWTSecretLength is the shortest HS256 secret accepted
+const minJWTSecretLength = 32
+
+// quotaKinds are the kinds of caller a rate quota can be set for
+var quotaKinds = []string{"guest", "user", "admin", "device"}
+
+// Validate fills documented defaults and checks required values.
+// Every problem is reported at once so a broken deployment can be fixed in one go.
+func (c *Config) Validate() error {
+	c.applyDefaults()
+
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{"dev", "staging", "prod"}, c.App.Env), "APP_ENV must be dev, staging or prod, got %q", c.App.Env)
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, c.Log.Level), "LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level)
+	check(slices.Contains([]string{"json", "text"}, c.Log.Format), "LOG_FORMAT must be json or text, got %q", c.Log.Format)
+	check(c.Log.Body.SampleRate >= 0 && c.Log.Body.SampleRate <= 1, "LOG_BODY_SAMPLE_PERCENT must be between 0 and 100")
+	for path, rate := range c.Log.SamplePaths {
+		check(strings.HasPrefix(path, "/") && rate >= 0 && rate <= 1, "LOG_SAMPLE_PATHS entry %q must be /path=percent, percent between 0 and 100", path)
+	}
+	check(slices.Contains([]string{"combined", "json"}, c.Log.Access.Format), "ACCESS_LOG_FORMAT must be combined or json, got %q", c.Log.Access.Format)
+	for _, sink := range c.Log.Sinks {
+		check(slices.Contains([]string{"stderr", "file", "syslog"}, sink), "LOG_SINKS contains unknown sink %q", sink)
+		check(sink != "file" || c.Log.File != "", "LOG_FILE is required when LOG_SINKS contains file")
+	}
+
+	// Database
+	check(!c.Database.Embedded.Enabled || c.App.Env != "prod", "DB_EMBEDDED must not be used in prod")
+	if dbURL, err := url.Parse(c.Database.URL); err != nil {
+		errs = append(errs, fmt.Errorf("DATABASE_URL is invalid: %w", err))
+	} else {
+		check(dbURL.Scheme == "postgres" || dbURL.Scheme == "postgresql", "DATABASE_URL must use the postgres scheme")
+		check(dbURL.Hostname() != "", "DATABASE_URL or DB_HOST is required")
+		check(strings.Trim(dbURL.Path, "/") != "", "DATABASE_URL or DB_NAME must name a database")
+	}
+	check(c.Database.QueryTimeout >= 0 && c.Database.StatementTimeout >= 0, "DB_QUERY_TIMEOUT_MS and DB_STATEMENT_TIMEOUT_MS must not be negative")
+	check(c.Database.BreakerThreshold <= 0 || c.Database.BreakerCooldown > 0, "DB_BREAKER_COOLDOWN_SEC must be positive when the breaker is enabled")
+	check(!c.Database.LazyConnect || c.Database.ConnectRetryMax > 0, "DB_CONNECT_RETRY_MAX_SEC must be positive when DB_LAZY_CONNECT is enabled")
+	check(c.Database.MinConns <= c.Database.MaxConns, "DB_MIN_CONNS (%d) must not exceed DB_MAX_CONNS (%d)", c.Database.MinConns, c.Database.MaxConns)
+
+	// HTTP
+	check(c.HTTP.Port > 0 && c.HTTP.Port <= 65535, "HTTP_PORT must be between 1 and 65535, got %d", c.HTTP.Port)
+	if c.HTTP.BaseURL != "" {
+		if err := validateBaseURL(c.HTTP.BaseURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	check(slices.Contains([]string{"tcp", "unix", "systemd"}, c.HTTP.Listen.Network), "HTTP_LISTEN_NETWORK must be tcp, unix or systemd, got %q", c.HTTP.Listen.Network)
+	check(c.HTTP.Listen.Network != "unix" || c.HTTP.Listen.SocketPath != "", "HTTP_SOCKET_PATH is required for unix listeners")
+	if c.HTTP.TLS.Enabled {
+		if c.HTTP.TLS.AutoCert {
+			check(len(c.HTTP.TLS.Domains) > 0, "TLS_AUTOCERT_DOMAINS is required when TLS_AUTOCERT is enabled")
+		} else {
+			check(c.HTTP.TLS.CertFile != "" && c.HTTP.TLS.KeyFile != "", "TLS_CERT_FILE and TLS_KEY_FILE are required when TLS is enabled")
+		}
+		if c.HTTP.TLS.RedirectHTTP {
+			check(c.HTTP.TLS.RedirectPort > 0 && c.HTTP.TLS.RedirectPort <= 65535, "TLS_REDIRECT_PORT must be between 1 and 65535, got %d", c.HTTP.TLS.RedirectPort)
+			check(c.HTTP.TLS.RedirectPort != c.HTTP.Port, "TLS_REDIRECT_PORT must differ from HTTP_PORT")
+			check(c.HTTP.Listen.Network == "tcp", "TLS_REDIRECT_HTTP requires a tcp HTTP_LISTEN_NETWORK")
+		}
+	}
+
+	// gRPC
+	if c.GRPC.Enabled {
+		check(c.GRPC.Port > 0 && c.GRPC.Port <= 65535, "GRPC_PORT must be between 1 and 65535, got %d", c.GRPC.Port)
+		check(c.GRPC.Port != c.HTTP.Port, "GRPC_PORT must differ from HTTP_PORT")
+		check(c.GRPC.GatewayPort >= 0 && c.GRPC.GatewayPort <= 65535, "GRPC_GATEWAY_PORT must be between 0 and 65535, got %d", c.GRPC.GatewayPort)
+		check(c.GRPC.GatewayPort == 0 || (c.GRPC.GatewayPort != c.GRPC.Port && c.GRPC.GatewayPort != c.HTTP.Port), "GRPC_GATEWAY_PORT must differ from GRPC_PORT and HTTP_PORT")
+	}
+
+	// Tenancy
+	if c.Tenancy.Enabled {
+		check(c.Tenancy.BaseDomain != "" && !strings.ContainsAny(c.Tenancy.BaseDomain, "/:"), "TENANCY_BASE_DOMAIN must be a bare domain when tenancy is enabled, got %q", c.Tenancy.BaseDomain)
+	}
+
+	// Encryption, keys are decoded by pkg/crypto once secrets are resolved
+	check((c.Encryption.KeyID == "") == (c.Encryption.Keys == ""), "PII_ENCRYPTION_KEY_ID and PII_ENCRYPTION_KEYS must be set together")
+	check(c.Encryption.Keys == "" || strings.Contains(","+c.Encryption.Keys, ","+c.Encryption.KeyID+"="), "PII_ENCRYPTION_KEYS must contain PII_ENCRYPTION_KEY_ID %q", c.Encryption.KeyID)
+
+	// Legal, versions are stored with each acceptance
+	check(len(c.Legal.TermsVersion) <= 64 && len(c.Legal.PrivacyVersion) <= 64, "TERMS_VERSION and PRIVACY_VERSION must be at most 64 characters")
+
+	// Storage
+	check(slices.Contains([]string{"local", "s3", "gcs"}, c.Storage.Driver), "STORAGE_DRIVER must be local, s3 or gcs, got %q", c.Storage.Driver)
+	check(c.Storage.Driver == "local" || c.Storage.Bucket != "", "STORAGE_BUCKET is required for the %s storage driver", c.Storage.Driver)
+	check(c.Storage.PartSize >= 5<<20, "STORAGE_PART_SIZE_MB must be at least 5")
+	check(c.Storage.SignTTL > 0 && c.Storage.SignTTL <= 7*24*time.Hour, "STORAGE_SIGN_TTL_MIN must be between 1 minute and 7 days")
+	check(c.Storage.MaxUploadBytes > 0, "STORAGE_MAX_UPLOAD_MB must be positive")
+
+	check(slices.Contains([]string{"none", "clamd", "http"}, c.Scanner.Driver), "SCANNER_DRIVER must be none, clamd or http, got %q", c.Scanner.Driver)
+	check(c.Scanner.Driver != "clamd" || c.Scanner.Addr != "", "SCANNER_ADDR is required for the clamd scanner")
+	check(c.Scanner.Driver != "http" || c.Scanner.URL != "", "SCANNER_URL is required for the http scanner")
+
+	// Analytics
+	check(slices.Contains([]string{"none", "postgres", "kafka", "http"}, c.Analytics.Sink), "ANALYTICS_SINK must be none, postgres, kafka or http, got %q", c.Analytics.Sink)
+	check(c.Analytics.Sink == "none" || c.Analytics.Sink == "postgres" || c.Analytics.URL != "", "ANALYTICS_URL is required for the %s analytics sink", c.Analytics.Sink)
+	check(c.Analytics.BufferSize > 0 && c.Analytics.BatchSize > 0 && c.Analytics.BatchSize <= c.Analytics.BufferSize, "ANALYTICS_BATCH_SIZE must be positive and not exceed ANALYTICS_BUFFER_SIZE")
+	check(c.Analytics.FlushInterval > 0 && c.Analytics.Timeout > 0, "ANALYTICS_FLUSH_INTERVAL_SEC and ANALYTICS_TIMEOUT_SEC must be positive")
+	check(c.Analytics.SampleRate >= 0 && c.Analytics.SampleRate <= 1, "ANALYTICS_SAMPLE_PERCENT must be between 0 and 100")
+
+	// Session purge
+	check(c.Scheduler.SessionPurge.BatchSize > 0 && c.Scheduler.GuestPurge.BatchSize > 0, "JOB_SESSION_PURGE_BATCH_SIZE and JOB_GUEST_PURGE_BATCH_SIZE must be positive")
+
+	// Warehouse export
+	if c.Scheduler.Enabled && c.Scheduler.WarehouseExport.Enabled {
+		check(slices.Contains([]string{"csv", "parquet"}, c.Warehouse.Format), "WAREHOUSE_FORMAT must be csv or parquet, got %q", c.Warehouse.Format)
+		check(c.Warehouse.IDSalt != "", "WAREHOUSE_ID_SALT or ANALYTICS_ID_SALT is required for the warehouse export")
+		check(c.Warehouse.Lookback >= 1, "WAREHOUSE_LOOKBACK_DAYS must be at least 1")
+	}
+
+	// Mail
+	check(slices.Contains([]string{"smtp", "log", "none"}, c.Mailer.Driver), "MAILER_DRIVER must be smtp, log or none, got %q", c.Mailer.Driver)
+	check(c.Mailer.Driver != "smtp" || (c.Mailer.Host != "" && c.Mailer.From != ""), "SMTP_HOST and MAIL_FROM are required for the smtp mailer")
+	if c.Scheduler.Enabled && c.Scheduler.WeeklyDigest.Enabled {
+		check(c.Mailer.Driver != "none", "MAILER_DRIVER is required for the weekly digest")
+		check(c.Digest.SendHour >= 0 && c.Digest.SendHour <= 23, "DIGEST_SEND_HOUR must be between 0 and 23, got %d", c.Digest.SendHour)
+		check(c.Digest.BatchSize > 0, "DIGEST_BATCH_SIZE must be positive")
+	}
+
+	// Weather
+	check(slices.Contains([]string{"openmeteo", "none"}, c.Weather.Provider), "WEATHER_PROVIDER must be openmeteo or none, got %q", c.Weather.Provider)
+	check(c.Weather.Timeout > 0, "WEATHER_TIMEOUT_SEC must be positive")
+
+	// Billing
+	check(slices.Contains([]string{"stripe", "none"}, c.Billing.Provider), "BILLING_PROVIDER must be stripe or none, got %q", c.Billing.Provider)
+	if c.Billing.Provider == "stripe" {
+		check(c.Billing.SecretKey != "" && c.Billing.WebhookSecret != "", "STRIPE_SECRET_KEY and STRIPE_WEBHOOK_SECRET are required for stripe billing")
+		check(c.Billing.PremiumPriceID != "", "STRIPE_PREMIUM_PRICE_ID is required for stripe billing")
+		check(c.Billing.SuccessURL != "" && c.Billing.CancelURL != "", "BILLING_SUCCESS_URL and BILLING_CANCEL_URL are required for stripe billing")
+		check(c.Billing.Timeout > 0, "BILLING_TIMEOUT_SEC must be positive")
+	}
+
+	// Training load
+	check(c.TrainingLoad.RiskRatio > 1, "TRAINING_LOAD_RISK_PERCENT must be above 100")
+	check(c.TrainingLoad.MinChronic >= 0, "TRAINING_LOAD_MIN_CHRONIC must not be negative")
+	check(c.TrainingLoad.DefaultRPE >= 1 && c.TrainingLoad.DefaultRPE <= 10, "TRAINING_LOAD_DEFAULT_RPE must be between 1 and 10, got %d", c.TrainingLoad.DefaultRPE)
+	check(c.TrainingLoad.AlertCooldown > 0, "TRAINING_LOAD_ALERT_COOLDOWN_HOURS must be positive")
+
+	// Swagger
+	check(slices.Contains([]string{"public", "basic", "jwt", "disabled"}, c.Swagger.Mode), "SWAGGER_MODE must be public, basic, jwt or disabled, got %q", c.Swagger.Mode)
+	check(c.Swagger.Mode != "basic" || (c.Swagger.User != "" && c.Swagger.Password != ""), "SWAGGER_USER and SWAGGER_PASSWORD are required for basic swagger auth")
+
+	// Auth
+	check(len(c.Auth.JWTSecret) >= minJWTSecretLength, "JWT_SECRET must be at least %d characters", minJWTSecretLength)
+	previous := splitList(c.Auth.JWTPreviousKeys)
+	for _, entry := range previous {
+		kid, secret, ok := strings.Cut(entry, "=")
+		if !ok {
+			kid, secret = "", entry
+		}
+		check(len(secret) >= minJWTSecretLength, "JWT_PREVIOUS_KEYS secret of kid %q must be at least %d characters", kid, minJWTSecretLength)
+		check(kid != c.Auth.JWTKeyID, "JWT_PREVIOUS_KEYS must not repeat JWT_KEY_ID %q", kid)
+	}
+	check(len(c.Auth.JWTKeys()) == len(previous)+1, "JWT_PREVIOUS_KEYS must not repeat a kid")
+	check(c.Auth.JWTAccessTTL > 0 && c.Auth.JWTRefreshTTL > c.Auth.JWTAccessTTL, "JWT_REFRESH_TTL_HOURS must be longer than JWT_ACCESS_TTL_MIN")
+	check(c.Auth.SessionMaxAge >= c.Auth.JWTRefreshTTL, "SESSION_MAX_AGE_DAYS must not be shorter than JWT_REFRESH_TTL_HOURS")
+	check(c.Auth.ImpersonationTTL > 0 && c.Auth.ImpersonationTTL <= time.Hour, "JWT_IMPERSONATION_TTL_MIN must be between 1 and 60")
+	check(c.Auth.GuestSessionsPerHour >= 0, "GUEST_ABUSE_SESSIONS_PER_HOUR must not be negative, 0 disables the check")
+	check(c.Auth.GuestThrottle > 0, "GUEST_ABUSE_THROTTLE_HOURS must be positive")
+	if c.Auth.SignInAlertURL != "" {
+		u, err := url.Parse(c.Auth.SignInAlertURL)
+		check(err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "", "SIGNIN_ALERT_URL must be an absolute http(s) URL, got %q", c.Auth.SignInAlertURL)
+		check(c.Auth.SignInAlertTTL > 0, "SIGNIN_ALERT_TTL_HOURS must be positive")
+	}
+
+	// Backing services
+	check(slices.Contains([]string{"memory", "redis"}, c.RateLimit.Store), "RATE_LIMIT_STORE must be memory or redis, got %q", c.RateLimit.Store)
+	for env, quotas := range map[string]map[string]RateQuota{"RATE_LIMIT_KIND_QUOTAS": c.RateLimit.KindQuotas, "RATE_LIMIT_EXPENSIVE_QUOTAS": c.RateLimit.ExpensiveQuotas} {
+		for kind, quota := range quotas {
+			check(slices.Contains(quotaKinds, kind), "%s kind must be guest, user, admin or device, got %q", env, kind)
+			check(quota.Max >= 0, "%s entry %s must be kind=max/window, ex: guest=10/1m", env, kind)
+		}
+	}
+	check(slices.Contains([]string{"memory", "redis"}, c.Replay.Store), "REPLAY_STORE must be memory or redis, got %q", c.Replay.Store)
+	check(c.Replay.Window > 0, "REPLAY_WINDOW_SEC must be positive")
+	check(slices.Contains([]string{"memory", "redis"}, c.Metering.Store), "METERING_STORE must be memory or redis, got %q", c.Metering.Store)
+	check(c.Metering.MonthlyQuota >= 0, "METERING_MONTHLY_QUOTA must not be negative")
+	check(c.Metering.Retention > 0, "METERING_RETENTION_DAYS must be positive")
+	check(slices.Contains([]string{"memory", "redis", "none"}, c.Cache.Driver), "CACHE_DRIVER must be memory, redis or none, got %q", c.Cache.Driver)
+	check(!c.usesRedis() || c.Redis.URL != "", "REDIS_URL is required when the rate limit store, replay store, metering store or cache driver is redis")
+	check(slices.Contains([]string{"nats", "kafka", "noop"}, c.Broker.Driver), "BROKER_DRIVER must be nats, kafka or noop, got %q", c.Broker.Driver)
+	check(c.Broker.Driver == "noop" || c.Broker.URL != "", "BROKER_URL is required for the %s broker", c.Broker.Driver)
+	check(strings.HasPrefix(c.Metrics.Path, "/"), "METRICS_PATH must start with /")
+	check(slices.Contains([]string{"env", "file", "vault", "aws", "gcp"}, c.Secrets.Provider), "SECRETS_PROVIDER must be env, file, vault, aws or gcp, got %q", c.Secrets.Provider)
+	check(c.Secrets.Provider != "vault" || (c.Secrets.VaultAddr != "" && c.Secrets.VaultToken != ""), "VAULT_ADDR and VAULT_TOKEN are required for the vault secrets provider")
+	check(c.Secrets.Provider != "gcp" || c.Secrets.GCPProject != "", "GCP_PROJECT is required for the gcp secrets provider")
+
+	return errors.Join(errs...)
+}
+
+// applyDefaults fills optional values left empty in the environment
+func (c *Config) applyDefaults() {
+	setDefault(&c.App.Env, "dev")
+	setDefault(&c.Log.Level, "info")
+	setDefault(&c.Log.Format, "text")
+	setDefault(&c.Log.Access.Format, "combined")
+	setDefault(&c.HTTP.Listen.Network, "tcp")
+	setDefault(&c.RateLimit.Store, "memory")
+	setDefault(&c.Replay.Store, "memory")
+	setDefault(&c.Metering.Store, "memory")
+	setDefault(&c.Cache.Driver, "memory")
+	setDefault(&c.Broker.Driver, "noop")
+	setDefault(&c.Secrets.Provider, "env")
+	setDefault(&c.Storage.Driver, "local")
+	setDefault(&c.Storage.LocalDir, "./storage")
+	setDefault(&c.Storage.SigningKey, c.Auth.JWTSecret)
+	setDefault(&c.Scanner.Driver, "none")
+	setDefault(&c.Analytics.Sink, "none")
+	setDefault(&c.Warehouse.Format, "parquet")
+	setDefault(&c.Warehouse.IDSalt, c.Analytics.IDSalt)
+	setDefault(&c.Mailer.Driver, "none")
+	setDefault(&c.Weather.Provider, "none")
+	setDefault(&c.Billing.Provider, "none")
+
+	// The API description is only public by default where nothing is at stake
+	if c.App.Env == "dev" {
+		setDefault(&c.Swagger.Mode, "public")
+	} else {
+		setDefault(&c.Swagger.Mode, "disabled")
+	}
+
+	// The embedded server is always local, its settings replace the connection values
+	if c.Database.Embedded.Enabled {
+		c.Database.Host = "localhost"
+		c.Database.Port = c.Database.Embedded.Port
+		setDefault(&c.Database.User, "swimo")
+		setDefault(&c.Database.Pass, "swimo")
+		setDefault(&c.Database.Name, "swimo")
+		c.Database.SSLMode = "disable"
+		c.Database.URL = ""
+		c.Database.AutoMigrate = true
+	}
+
+	// Built here rather than in Parse so a DB_PASSWORD secret reference is resolved first
+	if c.Database.URL == "" {
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.Database.User, c.Database.Pass),
+			Host:     fmt.Sprintf("%s:%d", c.Database.Host, c.Database.Port),
+			Path:     "/" + c.Database.Name,
+			RawQuery: "sslmode=" + url.QueryEscape(c.Database.SSLMode),
+		}
+		c.Database.URL = dsn.String()
+	}
+}
+
+func setDefault(value *string, def string) {
+	if *value == "" {
+		*value = def
+	}
+}
+
+func (c *Config) usesRedis() bool {
+	return (c.RateLimit.Enabled && c.RateLimit.Store == "redis") || (c.Replay.Enabled && c.Replay.Store == "redis") ||
+		(c.Metering.Enabled && c.Metering.Store == "redis") || c.Cache.Driver == "redis"
+}
+
+// validateBaseURL accepts an absolute http(s) URL without path, ex: https://api.swimo.id
+func validateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("HTTP_BASE_URL is invalid: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("HTTP_BASE_URL must start with http:// or https://, got %q", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("HTTP_BASE_URL must include a host, got %q", raw)
+	}
+	if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("HTTP_BASE_URL must not include a path, query or fragment, got %q", raw)
+	}
+	return nil
+}
+
+// Summary returns the effective configuration as slog attributes with secrets masked
+func (c *Config) Summary() []any {
+	return []any{
+		slog.Group("app", "service", c.App.Name, "env", c.App.Env),
+		slog.Group("log", "level", c.Log.Level, "format", c.Log.Format, "sinks", c.Log.Sinks, "file", c.Log.File,
+			"sample_paths", c.Log.SamplePaths, "access_log", c.Log.Access.Enabled, "access_log_format", c.Log.Access.Format, "access_log_file", c.Log.Access.File),
+		slog.Group("database",
+			"url", redactURL(c.Database.URL),
+			"embedded", c.Database.Embedded.Enabled,
+			"lazy_connect", c.Database.LazyConnect,
+			"max_conns", c.Database.MaxConns,
+			"min_conns", c.Database.MinConns,
+			"query_timeout", c.Database.QueryTimeout,
+			"statement_timeout", c.Database.StatementTimeout,
+			"breaker_threshold", c.Database.BreakerThreshold,
+			"slow_query_threshold", c.Database.SlowQueryThreshold,
+		),
+		slog.Group("http",
+			"network", c.HTTP.Listen.Network,
+			"host", c.HTTP.Host,
+			"port", c.HTTP.Port,
+			"base_url", c.HTTP.BaseURL,
+			"tls", c.HTTP.TLS.Enabled,
+			"autocert", c.HTTP.TLS.AutoCert,
+			"redirect_http", c.HTTP.TLS.RedirectHTTP,
+			"redirect_port", c.HTTP.TLS.RedirectPort,
+			"body_limit_bytes", c.HTTP.BodyLimitBytes,
+			"validate_requests", c.HTTP.ValidateRequests,
+		),
+		slog.Group("cors", "allow_origins", c.CORS.AllowOrigins, "credentials", c.CORS.Credentials),
+		slog.Group("rate_limit", "enabled", c.RateLimit.Enabled, "store", c.RateLimit.Store, "max", c.RateLimit.Max, "window", c.RateLimit.Window,
+			"kind_quotas", len(c.RateLimit.KindQuotas), "expensive_quotas", len(c.RateLimit.ExpensiveQuotas)),
+		slog.Group("replay", "enabled", c.Replay.Enabled, "store", c.Replay.Store, "window", c.Replay.Window),
+		slog.Group("metering", "enabled", c.Metering.Enabled, "store", c.Metering.Store, "monthly_quota", c.Metering.MonthlyQuota, "retention", c.Metering.Retention),
+		slog.Group("auth",
+			"jwt_secret", mask(c.Auth.JWTSecret),
+			"jwt_key_id", c.Auth.JWTKeyID,
+			"jwt_previous_keys", len(splitList(c.Auth.JWTPreviousKeys)),
+			"access_ttl", c.Auth.JWTAccessTTL,
+			"refresh_ttl", c.Auth.JWTRefreshTTL,
+			"session_max_age", c.Auth.SessionMaxAge,
+			"impersonation_ttl", c.Auth.ImpersonationTTL,
+			"guest_enabled", c.Auth.GuestEnabled,
+			"guest_sessions_per_hour", c.Auth.GuestSessionsPerHour,
+			"guest_throttle", c.Auth.GuestThrottle,
+			"signin_alerts", c.Auth.SignInAlertURL != "",
+			"country_header", c.Auth.CountryHeader,
+		),
+		slog.Group("redis", "url", redactURL(c.Redis.URL)),
+		slog.Group("cache", "driver", c.Cache.Driver, "training_ttl", c.Cache.TrainingTTL, "training_count_ttl", c.Cache.TrainingCountTTL),
+		slog.Group("broker", "driver", c.Broker.Driver, "url", redactURL(c.Broker.URL)),
+		slog.Group("scheduler",
+			"enabled", c.Scheduler.Enabled,
+			"session_purge", c.Scheduler.SessionPurge.Enabled,
+			"guest_purge", c.Scheduler.GuestPurge.Enabled,
+			"purge_archive", c.Scheduler.SessionPurge.Archive || c.Scheduler.GuestPurge.Archive,
+		),
+		slog.Group("warehouse",
+			"enabled", c.Scheduler.WarehouseExport.Enabled,
+			"format", c.Warehouse.Format,
+			"prefix", c.Warehouse.Prefix,
+			"id_salt", mask(c.Warehouse.IDSalt),
+		),
+		slog.Group("metrics", "enabled", c.Metrics.Enabled, "path", c.Metrics.Path),
+		slog.Group("grpc", "enabled", c.GRPC.Enabled, "host", c.GRPC.Host, "port", c.GRPC.Port, "gateway_port", c.GRPC.GatewayPort, "reflection", c.GRPC.Reflection),
+		slog.Group("encryption", "enabled", c.Encryption.Keys != "", "key_id", c.Encryption.KeyID),
+		slog.Group("legal", "terms_version", c.Legal.TermsVersion, "privacy_version", c.Legal.PrivacyVersion),
+		slog.Group("storage",
+			"driver", c.Storage.Driver,
+			"bucket", c.Storage.Bucket,
+			"endpoint", c.Storage.Endpoint,
+			"local_dir", c.Storage.LocalDir,
+			"signing_key", mask(c.Storage.SigningKey),
+			"max_upload_bytes", c.Storage.MaxUploadBytes,
+		),
+		slog.Group("scanner", "driver", c.Scanner.Driver, "addr", c.Scanner.Addr, "url", c.Scanner.URL, "token", mask(c.Scanner.Token)),
+		slog.Group("analytics",
+			"sink", c.Analytics.Sink,
+			"url", redactURL(c.Analytics.URL),
+			"token", mask(c.Analytics.Token),
+			"sample_rate", c.Analytics.SampleRate,
+			"pii_fields", c.Analytics.PIIFields,
+			"id_salt", mask(c.Analytics.IDSalt),
+		),
+		slog.Group("mailer", "driver", c.Mailer.Driver, "host", c.Mailer.Host, "port", c.Mailer.Port, "from", c.Mailer.From, "password", mask(c.Mailer.Password)),
+		slog.Group("digest", "enabled", c.Scheduler.WeeklyDigest.Enabled, "send_hour", c.Digest.SendHour),
+		slog.Group("weather", "provider", c.Weather.Provider, "url", c.Weather.URL),
+		slog.Group("billing",
+			"provider", c.Billing.Provider,
+			"url", c.Billing.URL,
+			"secret_key", mask(c.Billing.SecretKey),
+			"webhook_secret", mask(c.Billing.WebhookSecret),
+			"premium_price_id", c.Billing.PremiumPriceID,
+		),
+		slog.Group("training_load",
+			"alerts_enabled", c.Scheduler.TrainingLoadAlerts.Enabled,
+			"risk_ratio", c.TrainingLoad.RiskRatio,
+			"min_chronic", c.TrainingLoad.MinChronic,
+			"default_rpe", c.TrainingLoad.DefaultRPE,
+		),
+		slog.Group("swagger", "mode", c.Swagger.Mode, "user", c.Swagger.User, "password", mask(c.Swagger.Password)),
+		slog.Group("secrets", "provider", c.Secrets.Provider, "refresh_interval", c.Secrets.RefreshInterval, "vault_token", mask(c.Secrets.VaultToken)),
+	}
+}
+
+// mask hides a secret while showing whether it is set
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "********"
+}
+
+// redactURL hides the password of every URL in a comma separated list
+func redactURL(raw string) string {
+	parts := strings.Split(raw, ",")
+	for i, part := range parts {
+		if u, err := url.Parse(part); err == nil && u.User != nil {
+			parts[i] = u.Redacted()
+		}
+	}
+	return strings.Join(parts, ",")
+}
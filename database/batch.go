@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CopyRows bulk inserts items with the COPY protocol in a single round trip.
+// COPY can't skip conflicts, use ExecBatch for upserts.
+func CopyRows[T any](ctx context.Context, db DBTX, table string, columns []string, items []T, row func(T) []any) (int64, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	n, err := db.CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromSlice(len(items), func(i int) ([]any, error) {
+		return row(items[i]), nil
+	}))
+	if err != nil {
+		return n, fmt.Errorf("copy into %s: %w", table, err)
+	}
+
+	return n, nil
+}
+
+// QueryBatch runs sql once per item in a single round trip, scan is called with each
+// item and its result row in order. Statements returning no row (ex: ON CONFLICT DO NOTHING)
+// call scan with a row whose Scan returns pgx.ErrNoRows.
+func QueryBatch[T any](ctx context.Context, db DBTX, sql string, items []T, args func(T) []any, scan func(T, pgx.Row) error) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, item := range items {
+		batch.Queue(sql, args(item)...)
+	}
+
+	results := db.SendBatch(ctx, batch)
+	for i, item := range items {
+		if err := scan(item, results.QueryRow()); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			results.Close()
+			return fmt.Errorf("batch statement %d: %w", i, err)
+		}
+	}
+
+	return results.Close()
+}
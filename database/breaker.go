@@ -0,0 +1,258 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// ErrCircuitOpen is returned without touching the database while the breaker is open
+var ErrCircuitOpen = errors.New("database circuit open")
+
+// BreakerState is the state of a circuit breaker
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // queries flow normally
+	BreakerHalfOpen                     // cooldown elapsed, a single probe query is allowed
+	BreakerOpen                         // queries fail fast with ErrCircuitOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker trips after consecutive connection level failures so callers fail fast
+// instead of piling up on a dead database, then probes it again after a cooldown
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	log       *logger.Logger
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	// OnTrip is called every time the breaker opens
+	OnTrip func()
+}
+
+// NewBreaker creates a breaker opening after threshold consecutive failures for cooldown
+func NewBreaker(name string, threshold int, cooldown time.Duration, log *logger.Logger) *Breaker {
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, log: log}
+}
+
+// State returns the current state, an open breaker whose cooldown elapsed reports half-open
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Open reports whether calls are currently rejected
+func (b *Breaker) Open() bool {
+	return b.State() == BreakerOpen
+}
+
+// RetryAfter returns the time left until the next probe is allowed
+func (b *Breaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != BreakerOpen {
+		return 0
+	}
+	return max(b.cooldown-time.Since(b.openedAt), 0)
+}
+
+// allow reserves a call, only one probe runs at a time while half-open
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+	}
+
+	if b.state == BreakerHalfOpen {
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record updates the breaker with the outcome of a call reserved by allow
+func (b *Breaker) record(err error) {
+	failed := isConnFailure(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.state == BreakerHalfOpen
+	b.probing = false
+
+	switch {
+	case !failed:
+		if wasProbe {
+			b.log.Info("Database circuit closed", "database", b.name)
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+	case wasProbe:
+		b.trip()
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.trip()
+		}
+	}
+}
+
+func (b *Breaker) trip() {
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+
+	b.log.Error("Database circuit opened", "database", b.name, "cooldown", b.cooldown)
+	if b.OnTrip != nil {
+		b.OnTrip()
+	}
+}
+
+// isConnFailure reports whether err means the database is unreachable or overloaded,
+// as opposed to a healthy server rejecting one query (no rows, constraint violation, ...)
+func isConnFailure(err error) bool {
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// connection_exception, insufficient_resources, operator_intervention (shutdown)
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "53") || strings.HasPrefix(pgErr.Code, "57P")
+	}
+
+	return true
+}
+
+// WithBreaker wraps db so every call goes through b
+func WithBreaker(db DBTX, b *Breaker) DBTX {
+	if b == nil || b.threshold <= 0 {
+		return db
+	}
+	return &breakerDB{db: db, b: b}
+}
+
+type breakerDB struct {
+	db DBTX
+	b  *Breaker
+}
+
+func (d *breakerDB) rebind(tx pgx.Tx) DBTX {
+	return &breakerDB{db: Rebind(d.db, tx), b: d.b}
+}
+
+func (d *breakerDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if err := d.b.allow(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	tag, err := d.db.Exec(ctx, sql, args...)
+	d.b.record(err)
+	return tag, err
+}
+
+func (d *breakerDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if err := d.b.allow(); err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(ctx, sql, args...)
+	d.b.record(err)
+	return rows, err
+}
+
+func (d *breakerDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if err := d.b.allow(); err != nil {
+		return errRow{err: err}
+	}
+	return &breakerRow{row: d.db.QueryRow(ctx, sql, args...), b: d.b}
+}
+
+func (d *breakerDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if err := d.b.allow(); err != nil {
+		return 0, err
+	}
+
+	n, err := d.db.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	d.b.record(err)
+	return n, err
+}
+
+func (d *breakerDB) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	if err := d.b.allow(); err != nil {
+		return errBatch{err: err}
+	}
+	return &breakerBatch{BatchResults: d.db.SendBatch(ctx, b), b: d.b}
+}
+
+// breakerBatch records the outcome on Close, which reports the first failed statement
+type breakerBatch struct {
+	pgx.BatchResults
+	b *Breaker
+}
+
+func (r *breakerBatch) Close() error {
+	err := r.BatchResults.Close()
+	r.b.record(err)
+	return err
+}
+
+type errBatch struct{ err error }
+
+func (b errBatch) Exec() (pgconn.CommandTag, error) { return pgconn.CommandTag{}, b.err }
+func (b errBatch) Query() (pgx.Rows, error)         { return nil, b.err }
+func (b errBatch) QueryRow() pgx.Row                { return errRow(b) }
+func (b errBatch) Close() error                     { return b.err }
+
+// breakerRow records the outcome on Scan, where QueryRow errors surface
+type breakerRow struct {
+	row pgx.Row
+	b   *Breaker
+}
+
+func (r *breakerRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	r.b.record(err)
+	return err
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(dest ...any) error { return r.err }
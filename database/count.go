@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// exactCountBelow is the estimate under which an exact count is cheap enough to run anyway
+const exactCountBelow = 10_000
+
+// Count returns the number of rows selected by query (ex: "SELECT 1 FROM trainings WHERE ...").
+// With estimate set the planner's row estimate is used instead of scanning, falling back to
+// an exact COUNT(*) when the estimate is small or unavailable. estimated reports which one was used.
+func Count(ctx context.Context, db DBTX, estimate bool, query string, args ...any) (total int, estimated bool, err error) {
+	if estimate {
+		rows, err := estimateRows(ctx, db, query, args...)
+		if err != nil {
+			return 0, false, err
+		}
+		if rows >= exactCountBelow {
+			return rows, true, nil
+		}
+	}
+
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM ("+query+") AS counted", args...).Scan(&total)
+	return total, false, err
+}
+
+// estimateRows reads the top level "Plan Rows" of the query plan, as accurate as the table statistics
+func estimateRows(ctx context.Context, db DBTX, query string, args ...any) (int, error) {
+	var plan []byte
+	if err := db.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		return 0, fmt.Errorf("explain: %w", err)
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil || len(explained) == 0 {
+		return 0, fmt.Errorf("unexpected explain output: %w", err)
+	}
+
+	return int(explained[0].Plan.Rows), nil
+}
@@ -2,16 +2,27 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
-	"strings"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkyharahap/swimo/config"
 	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+)
+
+// ErrConnecting is returned while a lazily connected database was never reached yet
+var ErrConnecting = errors.New("database is not connected yet")
+
+// Backoff of the background connection of ConnectLazy
+const (
+	connectRetryMin = 500 * time.Millisecond
+	connectTimeout  = 5 * time.Second
 )
 
 // Database represents a single database connection
@@ -21,6 +32,8 @@ type Database struct {
 	log    *logger.Logger
 	mu     sync.RWMutex
 	closed bool
+	ready  chan struct{} // closed once the database answered a ping
+	done   chan struct{} // closed with the pool, stops the background connection
 }
 
 // Manager handles multiple database connections
@@ -28,59 +41,10 @@ type Manager struct {
 	databases map[string]*Database
 	log       *logger.Logger
 	mu        sync.RWMutex
-}
-
-type pgxTracer struct {
-	log *logger.Logger
-}
-
-func (t pgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
-	fullQuery := buildFullQuery(data.SQL, data.Args)
-	t.log.Debug("[PGX] QUERY START", "sql", fullQuery)
-	return ctx
-}
-
-func (t pgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
-	if data.Err != nil {
-		t.log.Error("PGX QUERY ERROR", "err", data.Err)
-	} else {
-		t.log.Debug("PGX QUERY END", "duration", data.CommandTag.String())
-	}
-}
-
-// buildFullQuery safely substitutes $1, $2... placeholders with real argument values
-func buildFullQuery(sql string, args []any) string {
-	result := sql
-
-	for i, arg := range args {
-		placeholder := fmt.Sprintf(`\$%d`, i+1)
-		var replacement string
-
-		switch v := arg.(type) {
-		case string:
-			replacement = fmt.Sprintf("'%s'", escapeQuotes(v))
-		case []byte:
-			replacement = fmt.Sprintf("'%x'", v)
-		case time.Time:
-			replacement = fmt.Sprintf("'%s'", v.Format(time.RFC3339))
-		case nil:
-			replacement = "NULL"
-		default:
-			replacement = fmt.Sprintf("%v", v)
-		}
 
-		result = regexp.MustCompile(placeholder).ReplaceAllString(result, replacement)
-	}
-
-	// Clean multiple spaces & newlines
-	result = strings.ReplaceAll(result, "\n", " ")
-	result = strings.Join(strings.Fields(result), " ")
-	return result
-}
-
-// escapeQuotes escapes single quotes to prevent broken SQL logs
-func escapeQuotes(s string) string {
-	return strings.ReplaceAll(s, "'", "''")
+	// Set by RegisterMetrics, queries of the databases connected afterwards are measured
+	queryDuration *metrics.Histogram
+	queryErrors   *metrics.Counter
 }
 
 // NewManager creates a new database manager
@@ -91,8 +55,19 @@ func NewManager(log *logger.Logger) *Manager {
 	}
 }
 
-// Connect connects to a database with the given name and config
+// Connect connects to a database with the given name and config, failing when it can't be reached
 func (m *Manager) Connect(ctx context.Context, name string, config *config.DatabaseConfig, appConfig *config.AppConfig) (*Database, error) {
+	return m.connect(ctx, name, config, appConfig, false)
+}
+
+// ConnectLazy returns without waiting for the database, it is pinged in the background with
+// exponential backoff up to config.ConnectRetryMax until it answers. Wait or Connected tell
+// when the pool is usable, queries before fail to acquire a connection.
+func (m *Manager) ConnectLazy(ctx context.Context, name string, config *config.DatabaseConfig, appConfig *config.AppConfig) (*Database, error) {
+	return m.connect(ctx, name, config, appConfig, true)
+}
+
+func (m *Manager) connect(ctx context.Context, name string, config *config.DatabaseConfig, appConfig *config.AppConfig, lazy bool) (*Database, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -113,8 +88,27 @@ func (m *Manager) Connect(ctx context.Context, name string, config *config.Datab
 	poolConfig.MaxConnLifetime = config.MaxConnLifetime
 	poolConfig.MaxConnIdleTime = config.MaxConnIdleTime
 
-	if appConfig.Env == "dev" {
-		poolConfig.ConnConfig.Tracer = pgxTracer{log: m.log}
+	// Backstop for queries without a context deadline so a runaway query can't hold a connection for minutes
+	if config.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10)
+	}
+
+	tracers := []pgx.QueryTracer{}
+	if appConfig.Env == "dev" || config.SlowQueryThreshold > 0 {
+		tracers = append(tracers, &queryTracer{
+			log:           m.log,
+			logQueries:    appConfig.Env == "dev",
+			slowThreshold: config.SlowQueryThreshold,
+		})
+	}
+	if config.AcquireWarnThreshold > 0 {
+		tracers = append(tracers, newAcquireTracer(name, config.AcquireWarnThreshold, m.log))
+	}
+	if m.queryDuration != nil {
+		tracers = append(tracers, newQueryMetricsTracer(name, m.queryDuration, m.queryErrors))
+	}
+	if len(tracers) > 0 {
+		poolConfig.ConnConfig.Tracer = multitracer.New(tracers...)
 	}
 
 	// Create connection pool
@@ -123,26 +117,59 @@ func (m *Manager) Connect(ctx context.Context, name string, config *config.Datab
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
-	// Test connection
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
 	// Create database instance
 	db := &Database{
-		Pool: pool,
-		Name: name,
-		log:  m.log,
+		Pool:  pool,
+		Name:  name,
+		log:   m.log,
+		ready: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	if lazy {
+		go db.connect(config.ConnectRetryMax)
+	} else {
+		// Test connection
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to ping database: %w", err)
+		}
+		close(db.ready)
+		m.log.Info("Database connected", "database", name)
 	}
 
 	// Store in manager
 	m.databases[name] = db
-
-	m.log.Info("Database connected", "name", name)
 	return db, nil
 }
 
+// connect pings the database until it answers or the pool is closed, waiting twice as
+// long after every failure up to maxBackoff
+func (db *Database) connect(maxBackoff time.Duration) {
+	backoff := min(connectRetryMin, maxBackoff)
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+		err := db.Pool.Ping(ctx)
+		cancel()
+
+		if err == nil {
+			close(db.ready)
+			db.log.Info("Database connected", "database", db.Name, "attempts", attempt)
+			return
+		}
+
+		db.log.Warn("Database not reachable, retrying", "database", db.Name, "attempt", attempt, "retry_in", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-db.done:
+			return
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
 // Get returns a database connection by name
 func (m *Manager) Get(name string) (*Database, error) {
 	m.mu.RLock()
@@ -187,7 +214,7 @@ func (m *Manager) CloseAll() error {
 
 	for name, db := range m.databases {
 		if err := db.close(); err != nil {
-			m.log.Error("Failed to close database", "name", name, "error", err)
+			m.log.Error("Failed to close database", "database", name, "error", err)
 			errs = append(errs, err)
 		}
 	}
@@ -201,15 +228,54 @@ func (m *Manager) CloseAll() error {
 	return nil
 }
 
+// Ping verifies the database is reachable, used by readiness checks
+func (db *Database) Ping(ctx context.Context) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if db.closed || db.Pool == nil {
+		return fmt.Errorf("database '%s' is closed", db.Name)
+	}
+	if !db.Connected() {
+		return ErrConnecting
+	}
+
+	return db.Pool.Ping(ctx)
+}
+
+// Connected reports whether the database answered once, always true after Connect. Once
+// connected the pool reconnects by itself, outages are left to the Breaker.
+func (db *Database) Connected() bool {
+	select {
+	case <-db.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the database is connected, the pool is closed or ctx is done
+func (db *Database) Wait(ctx context.Context) error {
+	select {
+	case <-db.ready:
+		return nil
+	case <-db.done:
+		return fmt.Errorf("database '%s' is closed", db.Name)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // close internal close method
 func (db *Database) close() error {
 	if db.closed {
 		return nil
 	}
 
+	close(db.done)
 	if db.Pool != nil {
 		db.Pool.Close()
-		db.log.Info("Database closed", "name", db.Name)
+		db.log.Info("Database closed", "database", db.Name)
 	}
 
 	db.closed = true
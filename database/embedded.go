@@ -0,0 +1,77 @@
+package database
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// EmbeddedServer is a throwaway Postgres server run by the app itself, so contributors
+// and tests don't need to provision one. Binaries are downloaded once into the cache dir.
+type EmbeddedServer struct {
+	pg  *embeddedpostgres.EmbeddedPostgres
+	out io.Closer
+}
+
+// StartEmbedded starts Postgres on cfg.Embedded.Port with the credentials of cfg,
+// filled by config.Validate when DB_EMBEDDED is set
+func StartEmbedded(cfg *config.DatabaseConfig, log *logger.Logger) (*EmbeddedServer, error) {
+	out := newLineLogger(log.With("component", "embedded-postgres"))
+
+	pgConfig := embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V17).
+		Port(uint32(cfg.Port)).
+		Username(cfg.User).
+		Password(cfg.Pass).
+		Database(cfg.Name).
+		StartTimeout(cfg.Embedded.StartTimeout).
+		Logger(out)
+
+	if cfg.Embedded.DataDir != "" {
+		dataDir, err := filepath.Abs(cfg.Embedded.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		pgConfig = pgConfig.DataPath(dataDir).RuntimePath(filepath.Join(dataDir, "..", "runtime"))
+	}
+	if cfg.Embedded.CacheDir != "" {
+		pgConfig = pgConfig.CachePath(cfg.Embedded.CacheDir)
+	}
+
+	started := time.Now()
+	pg := embeddedpostgres.NewDatabase(pgConfig)
+	if err := pg.Start(); err != nil {
+		out.Close()
+		return nil, fmt.Errorf("failed to start embedded postgres: %w", err)
+	}
+
+	log.Info("Embedded postgres started", "port", cfg.Port, "data_dir", cfg.Embedded.DataDir, "took", time.Since(started).Round(time.Millisecond))
+	return &EmbeddedServer{pg: pg, out: out}, nil
+}
+
+// Stop shuts the server down, data is kept when a data dir is configured
+func (s *EmbeddedServer) Stop() error {
+	err := s.pg.Stop()
+	s.out.Close()
+	return err
+}
+
+// newLineLogger forwards the server output line by line as debug logs
+func newLineLogger(log *logger.Logger) io.WriteCloser {
+	r, w := io.Pipe()
+
+	go func() {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			log.Debug(scanner.Text())
+		}
+	}()
+
+	return w
+}
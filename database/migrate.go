@@ -0,0 +1,153 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/rizkyharahap/swimo/database/migrations"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// MigrationStatus describes the schema version compared to the embedded migrations
+type MigrationStatus struct {
+	Version uint // 0 when no migration was applied yet
+	Dirty   bool // a migration failed half way and needs Force
+	Latest  uint
+	Pending int
+}
+
+// Migrator applies the SQL migrations embedded in the binary
+type Migrator struct {
+	m        *migrate.Migrate
+	db       *sql.DB
+	versions []uint
+}
+
+// NewMigrator creates a migrator connecting with the pool settings, tracking state in schema_migrations
+// like the golang-migrate CLI so databases migrated by hand keep working
+func NewMigrator(pool *pgxpool.Pool, log *logger.Logger) (*Migrator, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	// A dedicated connection without the pool's statement_timeout, long migrations
+	// (ex: index builds) must not be canceled half way
+	connConfig := pool.Config().ConnConfig.Copy()
+	delete(connConfig.RuntimeParams, "statement_timeout")
+	db := stdlib.OpenDB(*connConfig)
+
+	driver, err := pgxmigrate.WithInstance(db, &pgxmigrate.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "pgx5", driver)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	m.Log = migrateLogger{log: log}
+
+	versions, err := embeddedVersions()
+	if err != nil {
+		m.Close()
+		db.Close()
+		return nil, err
+	}
+
+	return &Migrator{m: m, db: db, versions: versions}, nil
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Steps applies n migrations up, or reverts them when n is negative
+func (m *Migrator) Steps(n int) error {
+	return ignoreNoChange(m.m.Steps(n))
+}
+
+// Force sets the schema version without running migrations, used to recover a dirty state
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Status reports the current version and how many embedded migrations are not applied
+func (m *Migrator) Status() (MigrationStatus, error) {
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, err
+	}
+
+	status := MigrationStatus{Version: version, Dirty: dirty}
+	for _, v := range m.versions {
+		if v > version {
+			status.Pending++
+		}
+	}
+	if len(m.versions) > 0 {
+		status.Latest = m.versions[len(m.versions)-1]
+	}
+
+	return status, nil
+}
+
+// Close releases the migration source and database driver
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr, m.db.Close())
+}
+
+// embeddedVersions returns the sorted versions of the embedded up migrations
+func embeddedVersions() ([]uint, error) {
+	files, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]uint, 0, len(files))
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(file, "_")
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+		versions = append(versions, uint(v))
+	}
+
+	slices.Sort(versions)
+	return versions, nil
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// migrateLogger adapts the logger to migrate.Logger
+type migrateLogger struct {
+	log *logger.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...any) {
+	l.log.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,4 @@
+-- Account roles for admin tooling
+ALTER TABLE accounts
+  ADD COLUMN IF NOT EXISTS role text NOT NULL DEFAULT 'user'
+  CONSTRAINT chk_accounts_role CHECK (role IN ('user','admin'));
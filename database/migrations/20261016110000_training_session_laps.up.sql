@@ -0,0 +1,13 @@
+-- Laps recorded within a training session, ex: per pool length from a watch
+CREATE TABLE IF NOT EXISTS training_session_laps (
+    session_id UUID NOT NULL REFERENCES training_sessions(id) ON DELETE CASCADE,
+    lap_number INT NOT NULL,         -- 1 based order within the session
+
+    distance_meters INT NOT NULL,    -- distance in meters
+    duration_seconds INT NOT NULL,   -- duration in seconds
+    stroke_count INT,                -- strokes, when the device reports them
+
+    PRIMARY KEY (session_id, lap_number),
+    CONSTRAINT chk_lap_distance CHECK (distance_meters > 0),
+    CONSTRAINT chk_lap_duration CHECK (duration_seconds > 0)
+);
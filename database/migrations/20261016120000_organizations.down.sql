@@ -0,0 +1,5 @@
+ALTER TABLE training_sessions DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE trainings DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE sessions DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE accounts DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,23 @@
+-- Organizations: swim schools sharing one deployment
+CREATE TABLE IF NOT EXISTS organizations (
+  id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  slug       citext UNIQUE NOT NULL,            -- subdomain, ex: 'aquatic' for aquatic.swimo.id
+  name       text   NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- NULL organization means the default tenant. Trainings without organization are the
+-- shared catalog visible to every school. Emails and training names stay globally unique.
+ALTER TABLE accounts
+  ADD COLUMN IF NOT EXISTS organization_id uuid REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE sessions
+  ADD COLUMN IF NOT EXISTS organization_id uuid REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE trainings
+  ADD COLUMN IF NOT EXISTS organization_id uuid REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE training_sessions
+  ADD COLUMN IF NOT EXISTS organization_id uuid REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_accounts_organization ON accounts (organization_id);
+CREATE INDEX IF NOT EXISTS idx_trainings_organization ON trainings (organization_id);
+CREATE INDEX IF NOT EXISTS idx_training_sessions_organization ON training_sessions (organization_id);
@@ -0,0 +1,2 @@
+-- Storage key of the user avatar, served through /api/v1/media/{key}
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key text;
@@ -0,0 +1,21 @@
+-- Product analytics events, written in batches by the postgres analytics sink.
+-- Identity columns hold pseudonymized ids when ANALYTICS_ID_SALT is set, so they are not foreign keys.
+CREATE TABLE IF NOT EXISTS analytics_events (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,              -- ex: signup, session_finished, screen_viewed
+    source TEXT NOT NULL,            -- client or server
+
+    user_id TEXT,
+    anonymous_id TEXT,               -- device id sent by the client, or the guest session
+    session_id TEXT,
+    organization_id UUID,
+
+    properties JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT chk_analytics_source CHECK (source IN ('client', 'server'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_events_name_occurred ON analytics_events (name, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_analytics_events_occurred ON analytics_events (occurred_at);
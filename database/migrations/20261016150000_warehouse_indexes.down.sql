@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sessions_guest_created_at;
+DROP INDEX IF EXISTS idx_accounts_created_at;
+DROP INDEX IF EXISTS idx_training_sessions_created_at;
@@ -0,0 +1,4 @@
+-- Day range scans of the nightly warehouse export
+CREATE INDEX IF NOT EXISTS idx_training_sessions_created_at ON training_sessions (created_at);
+CREATE INDEX IF NOT EXISTS idx_accounts_created_at ON accounts (created_at);
+CREATE INDEX IF NOT EXISTS idx_sessions_guest_created_at ON sessions (created_at) WHERE kind = 'guest';
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS last_digest_at;
+ALTER TABLE users DROP COLUMN IF EXISTS weekly_digest;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,4 @@
+-- Notification preferences of the user, the timezone also sets when scheduled mail is sent
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone text NOT NULL DEFAULT 'UTC';  -- IANA name, ex: Asia/Jakarta
+ALTER TABLE users ADD COLUMN IF NOT EXISTS weekly_digest boolean NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_digest_at timestamptz;           -- when the last weekly digest was handled
@@ -0,0 +1,5 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_max_heart_rate;
+ALTER TABLE users DROP COLUMN IF EXISTS max_heart_rate;
+
+ALTER TABLE training_session_laps DROP CONSTRAINT IF EXISTS chk_lap_heart_rate;
+ALTER TABLE training_session_laps DROP COLUMN IF EXISTS avg_heart_rate;
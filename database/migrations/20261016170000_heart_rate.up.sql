@@ -0,0 +1,6 @@
+-- Heart rate of laps as reported by the device, and the max heart rate setting the training zones
+ALTER TABLE training_session_laps ADD COLUMN IF NOT EXISTS avg_heart_rate INT;  -- average bpm over the lap
+ALTER TABLE training_session_laps ADD CONSTRAINT chk_lap_heart_rate CHECK (avg_heart_rate IS NULL OR (avg_heart_rate >= 30 AND avg_heart_rate <= 250));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_heart_rate smallint;            -- bpm, NULL derives it from age_years
+ALTER TABLE users ADD CONSTRAINT chk_max_heart_rate CHECK (max_heart_rate IS NULL OR (max_heart_rate >= 100 AND max_heart_rate <= 230));
@@ -0,0 +1,13 @@
+-- DEVICES: watches and companion app installs paired to a user, they ingest sessions with
+-- their own long lived token instead of an access token
+CREATE TABLE IF NOT EXISTS devices (
+  id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id      uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name         text NOT NULL,                  -- set by the user, ex: 'Apple Watch'
+  platform     text NOT NULL,                  -- watchos|wearos|garmin|ios|android
+  token_hash   text NOT NULL UNIQUE,           -- sha256 of the device token, shown once at pairing
+  created_at   timestamptz NOT NULL DEFAULT now(),
+  last_seen_at timestamptz,
+  revoked_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_devices_user ON devices (user_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uq_training_sessions_client;
+
+ALTER TABLE training_sessions
+  DROP COLUMN IF EXISTS client_updated_at,
+  DROP COLUMN IF EXISTS client_id;
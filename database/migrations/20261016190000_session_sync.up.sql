@@ -0,0 +1,7 @@
+-- Sessions recorded offline carry a UUID generated by the app, syncing them again updates
+-- instead of duplicating
+ALTER TABLE training_sessions
+  ADD COLUMN IF NOT EXISTS client_id         uuid,
+  ADD COLUMN IF NOT EXISTS client_updated_at timestamptz; -- last edit on the device
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_training_sessions_client ON training_sessions (user_id, client_id) WHERE client_id IS NOT NULL;
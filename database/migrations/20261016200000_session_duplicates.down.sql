@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS training_session_duplicates;
+
+ALTER TABLE training_sessions DROP COLUMN IF EXISTS source;
@@ -0,0 +1,20 @@
+-- Where a session was recorded: manual (finished in the app), import, watch, google_fit,
+-- apple_health or sync. Sessions imported before this migration are indistinguishable from
+-- manual ones.
+ALTER TABLE training_sessions
+  ADD COLUMN IF NOT EXISTS source text NOT NULL DEFAULT 'manual';
+
+UPDATE training_sessions SET source = 'sync' WHERE client_id IS NOT NULL;
+
+-- SESSION DUPLICATES: sessions of a user overlapping in time, likely the same swim recorded from
+-- two sources. Merging deletes one of the sessions and with it the pair.
+CREATE TABLE IF NOT EXISTS training_session_duplicates (
+  id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  session_id      uuid NOT NULL REFERENCES training_sessions(id) ON DELETE CASCADE,
+  duplicate_of_id uuid NOT NULL REFERENCES training_sessions(id) ON DELETE CASCADE, -- stored before session_id
+  overlap_seconds int NOT NULL,
+  detected_at     timestamptz NOT NULL DEFAULT now(),
+  dismissed_at    timestamptz,                                                      -- set when the user keeps both
+  CONSTRAINT uq_training_session_duplicates UNIQUE (session_id, duplicate_of_id)
+);
+CREATE INDEX IF NOT EXISTS idx_training_session_duplicates_duplicate_of ON training_session_duplicates (duplicate_of_id);
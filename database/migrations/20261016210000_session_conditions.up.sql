@@ -0,0 +1,15 @@
+-- SESSION CONDITIONS: water conditions of open water sessions, entered by the swimmer or
+-- filled from the weather provider at the location and start of the session
+CREATE TABLE IF NOT EXISTS training_session_conditions (
+  session_id          uuid PRIMARY KEY REFERENCES training_sessions(id) ON DELETE CASCADE,
+  water_temperature_c numeric(4,1) CHECK (water_temperature_c BETWEEN -2 AND 40),
+  wave_height_m       numeric(4,2) CHECK (wave_height_m BETWEEN 0 AND 20),
+  current_speed_kmh   numeric(4,2) CHECK (current_speed_kmh BETWEEN 0 AND 20),
+  wave_notes          text,
+  current_notes       text,
+  wetsuit             boolean NOT NULL DEFAULT false,
+  latitude            double precision CHECK (latitude BETWEEN -90 AND 90),
+  longitude           double precision CHECK (longitude BETWEEN -180 AND 180),
+  auto_filled         boolean NOT NULL DEFAULT false, -- a measurement comes from the weather provider
+  updated_at          timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS training_session_equipment;
+DROP TABLE IF EXISTS equipment;
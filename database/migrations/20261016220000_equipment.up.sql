@@ -0,0 +1,21 @@
+-- EQUIPMENT: gear registered by the swimmer, ex: fins, paddles or a wetsuit
+CREATE TABLE IF NOT EXISTS equipment (
+  id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id    uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name       text NOT NULL,
+  kind       text NOT NULL,           -- fins|paddles|wetsuit|pull_buoy|kickboard|snorkel|other
+  brand      text,
+  notes      text,
+  retired_at timestamptz,             -- retired gear keeps its usage but is listed last
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_equipment_user ON equipment (user_id);
+
+-- SESSION EQUIPMENT: gear used in a session
+CREATE TABLE IF NOT EXISTS training_session_equipment (
+  session_id   uuid NOT NULL REFERENCES training_sessions(id) ON DELETE CASCADE,
+  equipment_id uuid NOT NULL REFERENCES equipment(id) ON DELETE CASCADE,
+  PRIMARY KEY (session_id, equipment_id)
+);
+CREATE INDEX IF NOT EXISTS idx_training_session_equipment_equipment ON training_session_equipment (equipment_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS injuries;
+DROP TABLE IF EXISTS coach_athletes;
@@ -0,0 +1,24 @@
+-- COACH ATHLETES: coaches granted read access to the records of an athlete, by the athlete
+CREATE TABLE IF NOT EXISTS coach_athletes (
+  coach_user_id   uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  athlete_user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (coach_user_id, athlete_user_id),
+  CONSTRAINT chk_coach_athletes_self CHECK (coach_user_id <> athlete_user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_coach_athletes_athlete ON coach_athletes (athlete_user_id);
+
+-- INJURIES: injury and recovery log, ongoing while resolved_on is NULL
+CREATE TABLE IF NOT EXISTS injuries (
+  id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id     uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  type        text NOT NULL,                 -- set by the user, ex: 'Swimmer''s shoulder'
+  severity    text NOT NULL CHECK (severity IN ('mild','moderate','severe')),
+  notes       text,
+  injured_on  date NOT NULL,
+  resolved_on date,
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  updated_at  timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT chk_injuries_resolved CHECK (resolved_on IS NULL OR resolved_on >= injured_on)
+);
+CREATE INDEX IF NOT EXISTS idx_injuries_user ON injuries (user_id, injured_on);
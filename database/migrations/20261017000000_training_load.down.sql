@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS load_alerted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS load_checked_at;
+ALTER TABLE training_sessions DROP COLUMN IF EXISTS rpe;
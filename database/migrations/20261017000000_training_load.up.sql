@@ -0,0 +1,8 @@
+-- Perceived exertion of a session, 1 (very easy) to 10 (maximal). The training load of a
+-- session is its minutes × RPE, sessions without one count with the configured default.
+ALTER TABLE training_sessions
+  ADD COLUMN IF NOT EXISTS rpe smallint CONSTRAINT chk_training_sessions_rpe CHECK (rpe BETWEEN 1 AND 10);
+
+-- Training load alerts: when the sessions of the user were last checked and the last alert
+ALTER TABLE users ADD COLUMN IF NOT EXISTS load_checked_at timestamptz;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS load_alerted_at timestamptz;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_trainings_author;
+DROP INDEX IF EXISTS idx_trainings_pending;
+
+ALTER TABLE trainings DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE trainings DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE trainings DROP COLUMN IF EXISTS review_reason;
+ALTER TABLE trainings DROP COLUMN IF EXISTS author_user_id;
+ALTER TABLE trainings DROP COLUMN IF EXISTS status;
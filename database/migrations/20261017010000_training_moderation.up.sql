@@ -0,0 +1,14 @@
+-- Moderation of submitted trainings. Existing trainings and those created by admins are
+-- approved, submissions of other accounts wait in pending_review until an admin decides.
+ALTER TABLE trainings
+  ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'approved'
+  CONSTRAINT chk_trainings_status CHECK (status IN ('pending_review','approved','rejected'));
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS author_user_id uuid REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS review_reason text;
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS reviewed_by uuid REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS reviewed_at timestamptz;
+
+-- Moderation queue, oldest submission first
+CREATE INDEX IF NOT EXISTS idx_trainings_pending ON trainings (created_at) WHERE status = 'pending_review';
+-- Submissions of an author
+CREATE INDEX IF NOT EXISTS idx_trainings_author ON trainings (author_user_id, created_at) WHERE author_user_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_accounts_email_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,16 @@
+-- AUDIT EVENTS: actions taken by admins and support on accounts, kept for review
+CREATE TABLE IF NOT EXISTS audit_events (
+  id                uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id   uuid REFERENCES organizations(id) ON DELETE CASCADE,
+  actor_account_id  uuid REFERENCES accounts(id) ON DELETE SET NULL,
+  target_account_id uuid REFERENCES accounts(id) ON DELETE CASCADE,
+  action            text NOT NULL,             -- ex: 'admin.user_viewed'
+  metadata          jsonb NOT NULL DEFAULT '{}',
+  created_at        timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_account_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor_account_id, created_at DESC);
+
+-- Admin user search matches names anywhere, emails already have a unique index for exact lookups
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_accounts_email_trgm ON accounts USING gin ((email::text) gin_trgm_ops);
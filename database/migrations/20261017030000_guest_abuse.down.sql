@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS guest_flags;
+DROP INDEX IF EXISTS idx_sessions_guest_fingerprint;
+ALTER TABLE sessions DROP COLUMN IF EXISTS fingerprint;
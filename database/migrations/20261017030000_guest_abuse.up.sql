@@ -0,0 +1,22 @@
+-- Fingerprint of the client that opened a guest session: hash of its IP and user agent
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS fingerprint text;
+CREATE INDEX IF NOT EXISTS idx_sessions_guest_fingerprint ON sessions (fingerprint, created_at) WHERE kind = 'guest';
+
+-- GUEST FLAGS: guest fingerprints caught by the abuse heuristics, throttled until an admin clears them
+CREATE TABLE IF NOT EXISTS guest_flags (
+  id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid REFERENCES organizations(id) ON DELETE CASCADE,
+  fingerprint     text NOT NULL,
+  reason          text NOT NULL CHECK (reason IN ('many_sessions','implausible_session')),
+  occurrences     int NOT NULL DEFAULT 1,
+  user_agent      text,                         -- last user agent seen, for the admin view
+  details         jsonb NOT NULL DEFAULT '{}',  -- last evidence, ex: sessions per hour or the reported swim
+  throttled_until timestamptz NOT NULL,
+  cleared_by      uuid REFERENCES accounts(id) ON DELETE SET NULL,
+  cleared_at      timestamptz,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now()
+);
+-- One open flag per fingerprint and reason, repeated offences bump it
+CREATE UNIQUE INDEX IF NOT EXISTS uq_guest_flags_open ON guest_flags (fingerprint, reason) WHERE cleared_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_guest_flags_recent ON guest_flags (updated_at DESC) WHERE cleared_at IS NULL;
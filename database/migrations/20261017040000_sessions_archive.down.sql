@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sessions_archive;
@@ -0,0 +1,12 @@
+-- SESSIONS ARCHIVE: expired sessions moved out of sessions by the purge jobs with JOB_*_PURGE_ARCHIVE,
+-- the row is kept as jsonb without the refresh token hash so later columns of sessions need no change here
+CREATE TABLE IF NOT EXISTS sessions_archive (
+  id          uuid PRIMARY KEY,
+  account_id  uuid,                  -- no foreign key, archived sessions outlive deleted accounts
+  kind        text NOT NULL,
+  created_at  timestamptz NOT NULL,
+  archived_at timestamptz NOT NULL DEFAULT now(),
+  data        jsonb NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_archive_account ON sessions_archive (account_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_sessions_archive_archived ON sessions_archive (archived_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sessions_family;
+ALTER TABLE sessions DROP COLUMN IF EXISTS family_expires_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,11 @@
+-- SESSION FAMILIES: sessions refreshed from one sign in share a family and its absolute expiry,
+-- refreshes slide refresh_expires_at but never past family_expires_at
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS family_id uuid;
+UPDATE sessions SET family_id = id WHERE family_id IS NULL;
+ALTER TABLE sessions ALTER COLUMN family_id SET NOT NULL;
+ALTER TABLE sessions ALTER COLUMN family_id SET DEFAULT gen_random_uuid();
+
+-- NULL on sessions opened before families, their lifetime starts at the next refresh
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS family_expires_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_family ON sessions (family_id);
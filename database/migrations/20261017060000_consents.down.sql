@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS marketing_consents;
+DROP TABLE IF EXISTS account_consents;
@@ -0,0 +1,22 @@
+-- ACCOUNT CONSENTS: every acceptance of a version of the terms of service or privacy policy,
+-- kept as history, the latest row per document is the version currently accepted
+CREATE TABLE IF NOT EXISTS account_consents (
+  id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id  uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  document    text NOT NULL CHECK (document IN ('terms','privacy')),
+  version     text NOT NULL,                 -- set by the deployment, ex: '2026-10-01'
+  user_agent  text,
+  accepted_at timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT uq_account_consents_version UNIQUE (account_id, document, version)
+);
+CREATE INDEX IF NOT EXISTS idx_account_consents_latest ON account_consents (account_id, document, accepted_at DESC);
+
+-- MARKETING CONSENTS: opt in to marketing emails, apart from the legal documents since it is
+-- optional and can be withdrawn at any time, both timestamps are kept as proof
+CREATE TABLE IF NOT EXISTS marketing_consents (
+  account_id    uuid PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  opted_in      boolean NOT NULL,
+  opted_in_at   timestamptz,
+  opted_out_at  timestamptz,
+  updated_at    timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sign_in_alerts;
+DROP TABLE IF EXISTS account_sign_ins;
@@ -0,0 +1,26 @@
+-- ACCOUNT SIGN INS: user agents and countries an account signed in from, a sign in from a
+-- device or country not seen before sends a suspicious activity alert
+CREATE TABLE IF NOT EXISTS account_sign_ins (
+  account_id    uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  user_agent    text NOT NULL,
+  country       text NOT NULL DEFAULT '',     -- ISO 3166 code from the CDN, '' when unknown
+  first_seen_at timestamptz NOT NULL DEFAULT now(),
+  last_seen_at  timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (account_id, user_agent, country)
+);
+
+-- SIGN IN ALERTS: one per alert email, the token of its "this wasn't me" link locks the
+-- account and revokes its sessions, once
+CREATE TABLE IF NOT EXISTS sign_in_alerts (
+  id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  token_hash text NOT NULL UNIQUE,
+  user_agent text NOT NULL,
+  ip         text,
+  country    text,
+  reasons    text[] NOT NULL,               -- new_device, new_country
+  created_at timestamptz NOT NULL DEFAULT now(),
+  expires_at timestamptz NOT NULL,
+  denied_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_sign_in_alerts_account ON sign_in_alerts (account_id, created_at);
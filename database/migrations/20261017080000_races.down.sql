@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS race_entries;
+DROP TABLE IF EXISTS races;
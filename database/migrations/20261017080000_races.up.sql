@@ -0,0 +1,30 @@
+-- RACES: virtual race events, ex: 5k New Year Swim. Registered swimmers swim the distance
+-- anywhere during the window and submit the session as their result.
+CREATE TABLE IF NOT EXISTS races (
+  id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  name            text NOT NULL,
+  description     text,
+  distance_meters int NOT NULL CONSTRAINT chk_races_distance CHECK (distance_meters > 0),
+  cutoff_seconds  int CONSTRAINT chk_races_cutoff CHECK (cutoff_seconds IS NULL OR cutoff_seconds > 0), -- slowest accepted time
+  starts_at       timestamptz NOT NULL,
+  ends_at         timestamptz NOT NULL,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT chk_races_window CHECK (ends_at > starts_at)
+);
+CREATE INDEX IF NOT EXISTS idx_races_starts_at ON races (starts_at DESC);
+
+-- RACE ENTRIES: one per registered swimmer, finished once a qualifying session is accepted.
+-- A later faster session replaces the result.
+CREATE TABLE IF NOT EXISTS race_entries (
+  id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  race_id          uuid NOT NULL REFERENCES races(id) ON DELETE CASCADE,
+  user_id          uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  session_id       uuid REFERENCES training_sessions(id) ON DELETE SET NULL,
+  duration_seconds int,                       -- result, pro-rated to the race distance
+  finished_at      timestamptz,               -- when the result was accepted
+  registered_at    timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT uq_race_entries_user UNIQUE (race_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_race_entries_results ON race_entries (race_id, duration_seconds) WHERE finished_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_race_entries_user ON race_entries (user_id);
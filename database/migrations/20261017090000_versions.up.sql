@@ -0,0 +1,4 @@
+-- VERSIONS: bumped by every edit of a training or a profile. Edits sending the version they read
+-- only apply when it is still current, a concurrent edit is refused instead of silently lost.
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_training_sessions_deleted;
+DROP INDEX IF EXISTS idx_trainings_deleted;
+DROP INDEX IF EXISTS idx_users_deleted;
+
+ALTER TABLE training_sessions DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE trainings DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE accounts DROP COLUMN IF EXISTS deleted_at;
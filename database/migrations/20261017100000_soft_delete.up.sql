@@ -0,0 +1,10 @@
+-- SOFT DELETE: deleted rows keep their data and relations until an admin restores them.
+-- Reads keep the rows where deleted_at is NULL, partial indexes list the deleted ones.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE training_sessions ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted ON users (deleted_at DESC) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_trainings_deleted ON trainings (deleted_at DESC) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_training_sessions_deleted ON training_sessions (user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_training_sessions_user_training;
+DROP TABLE IF EXISTS coach_assignments;
@@ -0,0 +1,19 @@
+-- COACH ASSIGNMENTS: trainings a coach assigns to an athlete with a due date. An assignment is
+-- completed by the first session of the athlete on the training since it was assigned, found
+-- when it is read so imported, synced and restored sessions count too.
+CREATE TABLE IF NOT EXISTS coach_assignments (
+  id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  coach_user_id   uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  athlete_user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  training_id     uuid NOT NULL REFERENCES trainings(id) ON DELETE CASCADE,
+  due_on          date NOT NULL,
+  target_pace     numeric(6,2) CONSTRAINT chk_coach_assignments_pace CHECK (target_pace IS NULL OR target_pace > 0), -- minutes/100m
+  notes           text,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT chk_coach_assignments_self CHECK (coach_user_id <> athlete_user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_coach_assignments_athlete ON coach_assignments (athlete_user_id, due_on);
+CREATE INDEX IF NOT EXISTS idx_coach_assignments_coach ON coach_assignments (coach_user_id, due_on);
+
+-- Completion lookup: sessions of a user on a training in time order
+CREATE INDEX IF NOT EXISTS idx_training_sessions_user_training ON training_sessions (user_id, training_id, created_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_training_sessions_pool;
+ALTER TABLE training_sessions DROP COLUMN IF EXISTS pool_id;
+
+DROP TABLE IF EXISTS pool_check_ins;
+DROP TABLE IF EXISTS pools;
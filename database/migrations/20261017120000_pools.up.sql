@@ -0,0 +1,34 @@
+-- POOLS: pools swimmers check in at, by the code of the QR posted at the pool or by being
+-- within its geofence. Pools without organization are visible to every school.
+CREATE TABLE IF NOT EXISTS pools (
+  id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid REFERENCES organizations(id) ON DELETE CASCADE,
+  name            text NOT NULL,
+  address         text,
+  latitude        double precision NOT NULL CHECK (latitude BETWEEN -90 AND 90),
+  longitude       double precision NOT NULL CHECK (longitude BETWEEN -180 AND 180),
+  radius_meters   int NOT NULL CONSTRAINT chk_pools_radius CHECK (radius_meters > 0), -- geofence
+  length_meters   int CONSTRAINT chk_pools_length CHECK (length_meters IS NULL OR length_meters > 0),
+  check_in_code   text NOT NULL CONSTRAINT uq_pools_check_in_code UNIQUE,             -- encoded in the QR
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_pools_organization ON pools (organization_id);
+
+-- POOL CHECK INS: presence of a swimmer at a pool until checked out or expired, a new check
+-- in closes the previous one
+CREATE TABLE IF NOT EXISTS pool_check_ins (
+  id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id        uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  pool_id        uuid NOT NULL REFERENCES pools(id) ON DELETE CASCADE,
+  method         text NOT NULL CHECK (method IN ('qr','geofence')),
+  checked_in_at  timestamptz NOT NULL DEFAULT now(),
+  expires_at     timestamptz NOT NULL,
+  checked_out_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_pool_check_ins_user ON pool_check_ins (user_id, checked_in_at DESC);
+
+-- Sessions finished while checked in are swum at that pool
+ALTER TABLE training_sessions
+  ADD COLUMN IF NOT EXISTS pool_id uuid REFERENCES pools(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_training_sessions_pool ON training_sessions (user_id, pool_id) WHERE pool_id IS NOT NULL;
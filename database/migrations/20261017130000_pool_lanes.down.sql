@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pool_lanes;
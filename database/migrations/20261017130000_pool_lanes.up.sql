@@ -0,0 +1,12 @@
+-- POOL LANES: the lane board of a managed pool, published by its staff. Lanes are numbered
+-- from 1, the board is replaced as a whole or updated lane by lane.
+CREATE TABLE IF NOT EXISTS pool_lanes (
+  pool_id    uuid NOT NULL REFERENCES pools(id) ON DELETE CASCADE,
+  number     int NOT NULL CONSTRAINT chk_pool_lanes_number CHECK (number > 0),
+  status     text NOT NULL CHECK (status IN ('open','closed','reserved')),
+  swimmers   int NOT NULL DEFAULT 0 CONSTRAINT chk_pool_lanes_swimmers CHECK (swimmers >= 0),
+  capacity   int CONSTRAINT chk_pool_lanes_capacity CHECK (capacity IS NULL OR capacity > 0), -- swimmers at most
+  label      text,                                                                          -- ex: Fast, Lessons
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (pool_id, number)
+);
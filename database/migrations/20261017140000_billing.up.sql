@@ -0,0 +1,38 @@
+-- SUBSCRIPTIONS: the premium plan of a user at the payment provider, kept in sync by its webhook
+CREATE TABLE IF NOT EXISTS subscriptions (
+  id                       uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id                  uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  plan                     text NOT NULL CHECK (plan IN ('premium')),
+  provider_customer_id     text NOT NULL,
+  provider_subscription_id text NOT NULL CONSTRAINT uq_subscriptions_provider UNIQUE,
+  status                   text NOT NULL, -- as the provider reports it, ex: active, past_due, canceled
+  current_period_end       timestamptz,
+  cancel_at_period_end     boolean NOT NULL DEFAULT false,
+  synced_at                timestamptz NOT NULL, -- time of the last applied event, older ones are ignored
+  created_at               timestamptz NOT NULL DEFAULT now(),
+  updated_at               timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user ON subscriptions (user_id, current_period_end DESC);
+
+-- PAYMENTS: invoices paid for a subscription, refunds add up in refunded
+CREATE TABLE IF NOT EXISTS payments (
+  id                         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id                    uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  subscription_id            uuid REFERENCES subscriptions(id) ON DELETE SET NULL,
+  provider_invoice_id        text NOT NULL CONSTRAINT uq_payments_provider UNIQUE,
+  provider_payment_intent_id text,
+  amount                     bigint NOT NULL CHECK (amount >= 0), -- smallest currency unit
+  refunded                   bigint NOT NULL DEFAULT 0 CONSTRAINT chk_payments_refunded CHECK (refunded BETWEEN 0 AND amount),
+  currency                   text NOT NULL,
+  paid_at                    timestamptz NOT NULL,
+  updated_at                 timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_payments_user ON payments (user_id, paid_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payments_payment_intent ON payments (provider_payment_intent_id);
+
+-- BILLING EVENTS: webhook events already applied, the provider delivers an event more than once
+CREATE TABLE IF NOT EXISTS billing_events (
+  id          text PRIMARY KEY,
+  type        text NOT NULL,
+  received_at timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+-- Device signing secrets: key of the signature of the batches of a device, shown once at pairing
+-- and never sent with a request, unlike the token. Encrypted like the other sensitive columns.
+-- Devices paired before have none, their signed batches are rejected until paired again.
+ALTER TABLE devices
+  ADD COLUMN IF NOT EXISTS signing_secret text;
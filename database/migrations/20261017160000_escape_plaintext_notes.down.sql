@@ -0,0 +1,3 @@
+UPDATE injuries
+SET notes = substr(notes, 5)
+WHERE notes LIKE 'raw:%';
@@ -0,0 +1,7 @@
+-- Escape injury notes stored as plaintext that start with the prefix of ciphertexts, enc:, or
+-- of escaped plaintext, raw:, so they are read as written. A ciphertext is enc:<key id>: and
+-- at least 38 base64 characters, the nonce and tag of an empty value.
+UPDATE injuries
+SET notes = 'raw:' || notes
+WHERE (notes LIKE 'enc:%' AND notes !~ '^enc:[^:]+:[A-Za-z0-9+/]{38,}$')
+  OR notes LIKE 'raw:%';
@@ -0,0 +1,13 @@
+-- REFUNDS: refunds asked by admins, stored pending before the provider is called so a retry with
+-- the same idempotency key refunds once and concurrent refunds can't give back more than paid
+CREATE TABLE IF NOT EXISTS refunds (
+  id                 uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  payment_id         uuid NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+  amount             bigint NOT NULL CHECK (amount > 0), -- smallest currency unit
+  idempotency_key    text, -- sent by the admin, unset when none was
+  status             text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  provider_refund_id text,
+  created_at         timestamptz NOT NULL DEFAULT now(),
+  updated_at         timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT uq_refunds_idempotency_key UNIQUE (payment_id, idempotency_key)
+);
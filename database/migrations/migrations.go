@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL schema migrations into the binary.
+// Files follow the golang-migrate naming: <version>_<name>.up.sql / .down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
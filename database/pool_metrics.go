@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+)
+
+// acquireWarnInterval limits slow acquire warnings to one per interval per pool
+const acquireWarnInterval = 10 * time.Second
+
+// RegisterMetrics exports pgxpool statistics of every named database on each scrape, and the
+// duration and failures of the queries of the databases connected afterwards
+func (m *Manager) RegisterMetrics(reg *metrics.Registry) {
+	m.mu.Lock()
+	m.queryDuration = reg.NewHistogram("db_query_duration_seconds", "Query latency by statement keyword and table.", nil, "database", "query")
+	m.queryErrors = reg.NewCounter("db_query_errors_total", "Failed queries by statement keyword and table.", "database", "query")
+	m.mu.Unlock()
+
+	gauges := map[string]*metrics.Gauge{
+		"acquired":     reg.NewGauge("db_pool_acquired_conns", "Connections currently in use.", "database"),
+		"idle":         reg.NewGauge("db_pool_idle_conns", "Idle connections in the pool.", "database"),
+		"total":        reg.NewGauge("db_pool_total_conns", "Open connections in the pool.", "database"),
+		"max":          reg.NewGauge("db_pool_max_conns", "Maximum size of the pool.", "database"),
+		"constructing": reg.NewGauge("db_pool_constructing_conns", "Connections being established.", "database"),
+	}
+
+	acquires := reg.NewCounter("db_pool_acquires_total", "Successful connection acquires.", "database")
+	emptyAcquires := reg.NewCounter("db_pool_empty_acquires_total", "Acquires that waited because the pool was empty.", "database")
+	canceledAcquires := reg.NewCounter("db_pool_canceled_acquires_total", "Acquires canceled by their context while waiting.", "database")
+	acquireWait := reg.NewCounter("db_pool_acquire_wait_seconds_total", "Time spent waiting for a connection on an empty pool.", "database")
+
+	// Stat counters are cumulative, the previous snapshot turns them into counter increments
+	var (
+		mu   sync.Mutex
+		last = make(map[string]*pgxpool.Stat)
+	)
+
+	reg.OnCollect(func() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for name, db := range m.databases {
+			if db.closed {
+				continue
+			}
+
+			stat := db.Pool.Stat()
+			gauges["acquired"].Set(float64(stat.AcquiredConns()), name)
+			gauges["idle"].Set(float64(stat.IdleConns()), name)
+			gauges["total"].Set(float64(stat.TotalConns()), name)
+			gauges["max"].Set(float64(stat.MaxConns()), name)
+			gauges["constructing"].Set(float64(stat.ConstructingConns()), name)
+
+			prev := last[name]
+			if prev == nil {
+				prev = &pgxpool.Stat{}
+			}
+
+			acquires.Add(float64(stat.AcquireCount()-prev.AcquireCount()), name)
+			emptyAcquires.Add(float64(stat.EmptyAcquireCount()-prev.EmptyAcquireCount()), name)
+			canceledAcquires.Add(float64(stat.CanceledAcquireCount()-prev.CanceledAcquireCount()), name)
+			acquireWait.Add((stat.EmptyAcquireWaitTime() - prev.EmptyAcquireWaitTime()).Seconds(), name)
+
+			last[name] = stat
+		}
+	})
+}
+
+// acquireTracer warns when getting a connection from the pool takes longer than threshold,
+// the first sign of pool exhaustion
+type acquireTracer struct {
+	name      string
+	threshold time.Duration
+	log       *logger.Logger
+
+	mu       sync.Mutex
+	lastWarn time.Time
+	slow     int // slow acquires since the last warning
+}
+
+type acquireStartKey struct{}
+
+func newAcquireTracer(name string, threshold time.Duration, log *logger.Logger) *acquireTracer {
+	return &acquireTracer{name: name, threshold: threshold, log: log}
+}
+
+func (t *acquireTracer) TraceAcquireStart(ctx context.Context, pool *pgxpool.Pool, data pgxpool.TraceAcquireStartData) context.Context {
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+func (t *acquireTracer) TraceAcquireEnd(ctx context.Context, pool *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	start, ok := ctx.Value(acquireStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+
+	wait := time.Since(start)
+	if wait < t.threshold {
+		return
+	}
+
+	t.mu.Lock()
+	t.slow++
+	if time.Since(t.lastWarn) < acquireWarnInterval {
+		t.mu.Unlock()
+		return
+	}
+	slow := t.slow
+	t.slow = 0
+	t.lastWarn = time.Now()
+	t.mu.Unlock()
+
+	stat := pool.Stat()
+	t.log.Warn("Slow database connection acquire",
+		"database", t.name,
+		"wait", wait,
+		"slow_acquires", slow,
+		"acquired", stat.AcquiredConns(),
+		"max", stat.MaxConns(),
+		"error", data.Err,
+	)
+}
+
+// Query tracing is left to the other tracers, acquireTracer only implements
+// pgx.QueryTracer so it can be combined with them in a multitracer
+func (t *acquireTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (t *acquireTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+)
+
+// queryMetricsTracer records the duration and failures of every query by database and query
+// name, ex: "select training_sessions", so a slower query shows on the metrics endpoint without
+// logging SQL
+type queryMetricsTracer struct {
+	database string
+	duration *metrics.Histogram
+	errors   *metrics.Counter
+
+	names sync.Map // SQL -> query name, repositories send a bounded set of statements
+}
+
+type queryMetricsKey struct{}
+
+func newQueryMetricsTracer(database string, duration *metrics.Histogram, errors *metrics.Counter) *queryMetricsTracer {
+	return &queryMetricsTracer{database: database, duration: duration, errors: errors}
+}
+
+func (t *queryMetricsTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryMetricsKey{}, queryStart{sql: data.SQL, start: time.Now()})
+}
+
+type queryStart struct {
+	sql   string
+	start time.Time
+}
+
+func (t *queryMetricsTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryMetricsKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	name := t.name(start.sql)
+	t.duration.Observe(time.Since(start.start).Seconds(), t.database, name)
+	if data.Err != nil {
+		t.errors.Inc(t.database, name)
+	}
+}
+
+func (t *queryMetricsTracer) name(sql string) string {
+	if name, ok := t.names.Load(sql); ok {
+		return name.(string)
+	}
+
+	name := queryName(sql)
+	t.names.Store(sql, name)
+	return name
+}
+
+// queryName names a statement by its first keyword and the table it reads or writes, ex:
+// "insert accounts" or "select trainings". Common table expressions and subqueries are skipped
+// to name the main statement, statements without a table are named by their keyword.
+func queryName(sql string) string {
+	words := topLevelWords(sql)
+	if len(words) == 0 {
+		return "unknown"
+	}
+
+	keyword := words[0]
+	if keyword == "with" {
+		for i, w := range words {
+			if w == "select" || w == "insert" || w == "update" || w == "delete" {
+				keyword, words = w, words[i:]
+				break
+			}
+		}
+	}
+
+	// The word naming the table follows a marker word: INSERT INTO t, UPDATE t, DELETE FROM t, SELECT ... FROM t
+	var marker string
+	switch keyword {
+	case "insert":
+		marker = "into"
+	case "update":
+		marker = "update"
+	case "delete", "select":
+		marker = "from"
+	default:
+		return keyword
+	}
+
+	for i := 0; i < len(words)-1; i++ {
+		if words[i] != marker {
+			continue
+		}
+
+		table := words[i+1]
+		if table == "only" && i+2 < len(words) {
+			table = words[i+2]
+		}
+		if table == "(" {
+			break
+		}
+		return keyword + " " + strings.Trim(table, `"`)
+	}
+	return keyword
+}
+
+// topLevelWords splits sql into lower case words outside of parentheses, string literals and
+// comments. A parenthesized group is kept as a single "(" word.
+func topLevelWords(sql string) []string {
+	var (
+		words []string
+		word  strings.Builder
+		depth int
+	)
+
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, strings.ToLower(word.String()))
+			word.Reset()
+		}
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		switch {
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			flush()
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			flush()
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return words
+			}
+			i += end + 3
+		case c == '\'':
+			flush()
+			for i++; i < len(sql) && sql[i] != '\''; i++ {
+			}
+		case c == '(':
+			flush()
+			if depth == 0 {
+				words = append(words, "(")
+			}
+			depth++
+		case c == ')':
+			flush()
+			depth = max(depth-1, 0)
+		case depth > 0:
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' || c == ';':
+			flush()
+		default:
+			word.WriteByte(c)
+		}
+	}
+	flush()
+
+	return words
+}
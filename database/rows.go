@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Rows of Get, Select and Each are scanned into the fields of T by column name, ignoring case and
+// underscores: calories_kcal fills CaloriesKcal, a db tag names another column. Fields of embedded
+// structs are filled too. A field without a column is left as is, a column without a field is an
+// error, so an expression is selected with an alias, ex: COALESCE(training_id::text, '') AS training_id.
+
+// Get runs q and scans its first row into a T, pgx.ErrNoRows when it returns none
+func Get[T any](ctx context.Context, db DBTX, q string, args ...any) (*T, error) {
+	rows, err := db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByNameLax[T])
+}
+
+// Select runs q and scans every row into a T, nil when it returns none
+func Select[T any](ctx context.Context, db DBTX, q string, args ...any) ([]*T, error) {
+	rows, err := db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.AppendRows([]*T(nil), rows, pgx.RowToAddrOfStructByNameLax[T])
+}
+
+// Each runs q and calls fn with every row scanned into a T as rows arrive, without holding the
+// result in memory. fn must not keep the T.
+func Each[T any](ctx context.Context, db DBTX, q string, args []any, fn func(*T) error) error {
+	rows, err := db.Query(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		value, err := pgx.RowToStructByNameLax[T](rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(&value); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,104 @@
+package seed
+
+// category mirrors the training_categories baseline seeded by the trainings migration
+type category struct {
+	Code        string
+	Name        string
+	Description string
+	MET         float64
+}
+
+type training struct {
+	CategoryCode string
+	Level        string
+	Name         string
+	Descriptions string
+	TimeLabel    string
+	CaloriesKcal int
+	ThumbnailURL string
+	VideoURL     string
+	ContentHTML  string
+}
+
+type demoUser struct {
+	Email    string
+	Name     string
+	Gender   int // 0: male, 1: female
+	WeightKG float64
+	HeightCM float64
+	Age      int
+}
+
+var categories = []category{
+	{"FREESTYLE", "Freestyle", "Front crawl umum; pace moderat", 8.3},
+	{"BREASTSTROKE", "Breaststroke", "Gaya dada; relatif lebih berat", 10.3},
+	{"BACKSTROKE", "Backstroke", "Gaya punggung; intensitas menengah-tinggi", 9.5},
+	{"BUTTERFLY", "Butterfly", "Gaya kupu-kupu; paling berat", 13.8},
+	{"INDIVIDUAL_MEDLEY", "Individual Medley", "Campuran 4 gaya; rata-rata intensitas tinggi", 9.8},
+	{"KICK", "Kick Set", "Papan kaki; kerja kaki dominan", 8.0},
+	{"PULL", "Pull Set", "Pull buoy; kerja lengan dominan", 7.5},
+	{"DRILL", "Drill Technique", "Teknik/skill fokus", 6.0},
+	{"WARM_UP", "Warm Up", "Pemanasan ringan", 5.0},
+	{"COOL_DOWN", "Cool Down", "Pendinginan sangat ringan", 4.0},
+	{"OPEN_WATER", "Open Water", "Renang perairan terbuka; navigasi & gelombang", 9.8},
+}
+
+var trainings = []training{
+	{
+		CategoryCode: "WARM_UP",
+		Level:        "beginner",
+		Name:         "Easy Warm Up 200m",
+		Descriptions: "Pemanasan santai 4x50m dengan istirahat 20 detik",
+		TimeLabel:    "5-10 min",
+		CaloriesKcal: 60,
+		ThumbnailURL: "https://cdn.swimo.id/trainings/warm-up.jpg",
+		ContentHTML:  "<p>4 x 50m freestyle santai, istirahat 20 detik di setiap repetisi.</p>",
+	},
+	{
+		CategoryCode: "FREESTYLE",
+		Level:        "beginner",
+		Name:         "Freestyle Endurance 800m",
+		Descriptions: "Latihan daya tahan gaya bebas dengan pace stabil",
+		TimeLabel:    "20-25 min",
+		CaloriesKcal: 250,
+		ThumbnailURL: "https://cdn.swimo.id/trainings/freestyle-endurance.jpg",
+		VideoURL:     "https://cdn.swimo.id/videos/freestyle-endurance.mp4",
+		ContentHTML:  "<p>8 x 100m freestyle pada pace moderat, istirahat 30 detik.</p>",
+	},
+	{
+		CategoryCode: "BREASTSTROKE",
+		Level:        "intermediate",
+		Name:         "Breaststroke Technique",
+		Descriptions: "Fokus timing tarikan dan tendangan gaya dada",
+		TimeLabel:    "15-20 min",
+		CaloriesKcal: 220,
+		ThumbnailURL: "https://cdn.swimo.id/trainings/breaststroke-technique.jpg",
+		ContentHTML:  "<p>6 x 50m drill 2 tendangan 1 tarikan, lalu 4 x 50m gaya dada penuh.</p>",
+	},
+	{
+		CategoryCode: "BUTTERFLY",
+		Level:        "advanced",
+		Name:         "Butterfly Power Set",
+		Descriptions: "Set intensitas tinggi untuk kekuatan gaya kupu-kupu",
+		TimeLabel:    "15-20 min",
+		CaloriesKcal: 320,
+		ThumbnailURL: "https://cdn.swimo.id/trainings/butterfly-power.jpg",
+		ContentHTML:  "<p>10 x 25m butterfly cepat, istirahat 30 detik.</p>",
+	},
+	{
+		CategoryCode: "COOL_DOWN",
+		Level:        "beginner",
+		Name:         "Relaxed Cool Down",
+		Descriptions: "Pendinginan 200m campuran gaya punggung dan bebas",
+		TimeLabel:    "5-10 min",
+		CaloriesKcal: 40,
+		ThumbnailURL: "https://cdn.swimo.id/trainings/cool-down.jpg",
+		ContentHTML:  "<p>200m santai bergantian backstroke dan freestyle.</p>",
+	},
+}
+
+var demoUsers = []demoUser{
+	{"budi@swimo.dev", "Budi Santoso", 0, 72, 172, 29},
+	{"siti@swimo.dev", "Siti Rahma", 1, 55, 160, 26},
+	{"andi@swimo.dev", "Andi Wijaya", 0, 80, 178, 35},
+}
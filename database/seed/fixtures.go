@@ -0,0 +1,381 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// FixtureDomain is the email domain of the load test accounts, ex: user00042@loadtest.swimo.dev.
+// CleanFixtures deletes the accounts by it, their sessions and race entries cascade.
+const FixtureDomain = "loadtest.swimo.dev"
+
+// fixtureRaceName names the race holding the load test results, its finishers are the leaderboard
+const fixtureRaceName = "Load test 1500m"
+
+// FixtureOptions sizes the load test data set
+type FixtureOptions struct {
+	Users    int
+	Sessions int // spread over the users, a few very active ones swim most of them
+	Days     int // sessions happened within the last Days
+	Password string
+	Seed     uint64 // the same seed generates the same data set
+}
+
+// DefaultFixtureOptions returns a data set in the range of a production database
+func DefaultFixtureOptions() FixtureOptions {
+	return FixtureOptions{
+		Users:    1000,
+		Sessions: 50000,
+		Days:     180,
+		Password: "swimo-load",
+		Seed:     1,
+	}
+}
+
+// FixtureStats counts the rows inserted by Fixtures
+type FixtureStats struct {
+	Users       int64
+	Sessions    int64
+	Laps        int64
+	RaceEntries int64
+}
+
+type fixtureUser struct {
+	ID        string
+	AccountID string
+	Email     string
+	Name      string
+	Gender    int
+	WeightKG  float64
+	HeightCM  float64
+	Age       int
+	CreatedAt time.Time
+
+	pace float64 // usual minutes per 100m
+}
+
+type fixtureSession struct {
+	ID              string
+	UserID          string
+	TrainingID      *string
+	DistanceMeters  int
+	DurationSeconds int
+	Pace            float64
+	CaloriesKcal    int
+	RPE             int
+	CreatedAt       time.Time
+}
+
+type fixtureLap struct {
+	SessionID       string
+	Number          int
+	DistanceMeters  int
+	DurationSeconds int
+	StrokeCount     int
+	AvgHeartRate    int
+}
+
+type fixtureEntry struct {
+	UserID          string
+	DurationSeconds *int // nil until finished
+	FinishedAt      *time.Time
+	RegisteredAt    time.Time
+}
+
+// fixtureSet is the generated data set, built apart from the inserts so it only depends on the options
+type fixtureSet struct {
+	users    []fixtureUser
+	sessions []fixtureSession
+	laps     []fixtureLap
+	entries  []fixtureEntry
+}
+
+// Fixtures replaces the load test data set with a new one of opts: accounts signing in with
+// opts.Password, their sessions with laps and heart rates, and a race with their results
+func Fixtures(ctx context.Context, pool *pgxpool.Pool, log *logger.Logger, opts FixtureOptions) (FixtureStats, error) {
+	var stats FixtureStats
+
+	if opts.Users <= 0 || opts.Sessions < 0 || opts.Days <= 0 {
+		return stats, fmt.Errorf("fixtures need at least one user and day, got %d users, %d sessions and %d days", opts.Users, opts.Sessions, opts.Days)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(opts.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return stats, err
+	}
+
+	var trainingIDs []string
+	rows, err := pool.Query(ctx, `SELECT id FROM trainings ORDER BY name`)
+	if err != nil {
+		return stats, err
+	}
+	if trainingIDs, err = pgx.CollectRows(rows, pgx.RowTo[string]); err != nil {
+		return stats, err
+	}
+
+	started := time.Now()
+	set := newFixtureSet(opts, trainingIDs, time.Now().UTC())
+
+	err = database.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := cleanFixtures(ctx, tx); err != nil {
+			return err
+		}
+
+		if _, err := database.CopyRows(ctx, tx, "accounts", []string{"id", "email", "password_hash", "role", "created_at"}, set.users,
+			func(u fixtureUser) []any {
+				return []any{u.AccountID, u.Email, string(hash), "user", u.CreatedAt}
+			}); err != nil {
+			return err
+		}
+
+		if stats.Users, err = database.CopyRows(ctx, tx, "users", []string{"id", "account_id", "name", "gender", "weight_kg", "height_cm", "age_years", "created_at"}, set.users,
+			func(u fixtureUser) []any {
+				return []any{u.ID, u.AccountID, u.Name, u.Gender, u.WeightKG, u.HeightCM, u.Age, u.CreatedAt}
+			}); err != nil {
+			return err
+		}
+
+		if stats.Sessions, err = database.CopyRows(ctx, tx, "training_sessions", []string{"id", "user_id", "training_id", "distance_meters", "duration_seconds", "pace", "calories_kcal", "rpe", "created_at"}, set.sessions,
+			func(s fixtureSession) []any {
+				return []any{s.ID, s.UserID, s.TrainingID, s.DistanceMeters, s.DurationSeconds, s.Pace, s.CaloriesKcal, s.RPE, s.CreatedAt}
+			}); err != nil {
+			return err
+		}
+
+		if stats.Laps, err = database.CopyRows(ctx, tx, "training_session_laps", []string{"session_id", "lap_number", "distance_meters", "duration_seconds", "stroke_count", "avg_heart_rate"}, set.laps,
+			func(l fixtureLap) []any {
+				return []any{l.SessionID, l.Number, l.DistanceMeters, l.DurationSeconds, l.StrokeCount, l.AvgHeartRate}
+			}); err != nil {
+			return err
+		}
+
+		var raceID string
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO races (name, description, distance_meters, cutoff_seconds, starts_at, ends_at)
+			VALUES ($1, 'Generated for load tests, removed with the fixtures', 1500, 3600, $2, $3)
+			RETURNING id`,
+			fixtureRaceName, started.AddDate(0, 0, -30), started.AddDate(0, 0, 7),
+		).Scan(&raceID); err != nil {
+			return err
+		}
+
+		stats.RaceEntries, err = database.CopyRows(ctx, tx, "race_entries", []string{"race_id", "user_id", "duration_seconds", "finished_at", "registered_at"}, set.entries,
+			func(e fixtureEntry) []any {
+				return []any{raceID, e.UserID, e.DurationSeconds, e.FinishedAt, e.RegisteredAt}
+			})
+		return err
+	})
+	if err != nil {
+		return FixtureStats{}, fmt.Errorf("fixtures: %w", err)
+	}
+
+	log.Info("Fixtures generated",
+		"users", stats.Users,
+		"sessions", stats.Sessions,
+		"laps", stats.Laps,
+		"race_entries", stats.RaceEntries,
+		"took", time.Since(started).Round(time.Millisecond),
+	)
+	return stats, nil
+}
+
+// CleanFixtures deletes the load test data set, returning the number of accounts deleted
+func CleanFixtures(ctx context.Context, pool *pgxpool.Pool, log *logger.Logger) (int64, error) {
+	var deleted int64
+
+	err := database.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		var err error
+		deleted, err = cleanFixtures(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("clean fixtures: %w", err)
+	}
+
+	log.Info("Fixtures deleted", "accounts", deleted)
+	return deleted, nil
+}
+
+func cleanFixtures(ctx context.Context, tx pgx.Tx) (int64, error) {
+	if _, err := tx.Exec(ctx, `DELETE FROM races WHERE name = $1`, fixtureRaceName); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM accounts WHERE email LIKE '%@' || $1`, FixtureDomain)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Session distances in meters and how often they are swum
+var fixtureDistances = []struct {
+	meters int
+	weight float64
+}{
+	{400, 1}, {800, 2}, {1000, 3}, {1500, 4}, {2000, 3}, {2500, 2}, {3000, 1.5}, {4000, 0.5},
+}
+
+// Hours of the day sessions start, before and after work
+var fixtureHours = []int{5, 6, 6, 7, 7, 8, 12, 16, 17, 17, 18, 18, 19, 20}
+
+var (
+	fixtureFirstNames = []string{"Budi", "Siti", "Andi", "Dewi", "Rizky", "Putri", "Agus", "Rina", "Fajar", "Ayu", "Dimas", "Nadia", "Yoga", "Intan", "Bayu", "Lestari"}
+	fixtureLastNames  = []string{"Santoso", "Rahma", "Wijaya", "Pratama", "Saputra", "Lestari", "Hidayat", "Kusuma", "Nugroho", "Permata", "Setiawan", "Anggraini"}
+)
+
+// newFixtureSet generates the data set of opts, sessions reference trainingIDs and happen before now
+func newFixtureSet(opts FixtureOptions, trainingIDs []string, now time.Time) *fixtureSet {
+	r := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x5eed))
+	set := &fixtureSet{users: make([]fixtureUser, opts.Users)}
+
+	// Activity follows a long tail: most swimmers log a few sessions, a few log most of them.
+	// Users are drawn by a binary search of the running total of their activity.
+	activity := make([]float64, opts.Users)
+	var totalActivity float64
+	for i := range set.users {
+		gender := r.IntN(2)
+		height := normal(r, 172, 7, 150, 205)
+		if gender == 1 {
+			height = normal(r, 160, 6, 140, 190)
+		}
+		bmi := normal(r, 23, 3, 17, 35)
+
+		u := fixtureUser{
+			ID:        randomUUID(r),
+			AccountID: randomUUID(r),
+			Email:     fmt.Sprintf("user%05d@%s", i+1, FixtureDomain),
+			Name:      fixtureFirstNames[r.IntN(len(fixtureFirstNames))] + " " + fixtureLastNames[r.IntN(len(fixtureLastNames))],
+			Gender:    gender,
+			HeightCM:  math.Round(height),
+			WeightKG:  math.Round(bmi*height*height/1000) / 10,
+			Age:       int(normal(r, 34, 11, 12, 75)),
+			CreatedAt: now.Add(-time.Duration(r.Float64() * float64(time.Duration(opts.Days)*24*time.Hour))),
+			pace:      normal(r, 2.4, 0.45, 1.2, 4.5),
+		}
+		totalActivity += math.Exp(0.7 * r.NormFloat64())
+		activity[i] = totalActivity
+		set.users[i] = u
+	}
+
+	var totalDistanceWeight float64
+	for _, d := range fixtureDistances {
+		totalDistanceWeight += d.weight
+	}
+
+	set.sessions = make([]fixtureSession, 0, opts.Sessions)
+	for range opts.Sessions {
+		i, _ := slices.BinarySearch(activity, r.Float64()*totalActivity)
+		u := &set.users[min(i, len(set.users)-1)]
+
+		distance := pickDistance(r, totalDistanceWeight)
+
+		pace := math.Round(u.pace*normal(r, 1, 0.06, 0.8, 1.3)*100) / 100
+		duration := int(float64(distance) / 100 * pace * 60)
+
+		// Sessions happen after the account was created, at the usual hours
+		span := now.Sub(u.CreatedAt)
+		day := u.CreatedAt.Add(time.Duration(r.Float64() * float64(span))).Truncate(24 * time.Hour)
+		createdAt := day.Add(time.Duration(fixtureHours[r.IntN(len(fixtureHours))])*time.Hour + time.Duration(r.IntN(60))*time.Minute)
+		if createdAt.After(now) {
+			createdAt = now.Add(-time.Duration(r.IntN(3600)) * time.Second)
+		}
+
+		s := fixtureSession{
+			ID:              randomUUID(r),
+			UserID:          u.ID,
+			DistanceMeters:  distance,
+			DurationSeconds: duration,
+			Pace:            pace,
+			// Freestyle MET of the categories seed, kcal = MET * kg * hours
+			CaloriesKcal: int(8.3 * u.WeightKG * float64(duration) / 3600),
+			RPE:          int(normal(r, 6, 1.5, 1, 10)),
+			CreatedAt:    createdAt,
+		}
+		if len(trainingIDs) > 0 && r.Float64() < 0.7 {
+			s.TrainingID = &trainingIDs[r.IntN(len(trainingIDs))]
+		}
+		set.sessions = append(set.sessions, s)
+
+		set.laps = append(set.laps, fixtureLaps(r, s, u.Age)...)
+	}
+
+	// Two thirds of the swimmers registered for the race, most of them finished
+	for _, u := range set.users {
+		if r.Float64() > 2.0/3 {
+			continue
+		}
+
+		registered := now.AddDate(0, 0, -30).Add(time.Duration(r.IntN(20*24)) * time.Hour)
+		e := fixtureEntry{UserID: u.ID, RegisteredAt: registered}
+		if r.Float64() < 0.8 {
+			duration := int(15 * u.pace * normal(r, 1, 0.04, 0.9, 1.1) * 60)
+			finished := registered.Add(time.Duration(1+r.IntN(9*24)) * time.Hour)
+			if finished.After(now) {
+				finished = now
+			}
+			e.DurationSeconds, e.FinishedAt = &duration, &finished
+		}
+		set.entries = append(set.entries, e)
+	}
+
+	return set
+}
+
+// fixtureLaps splits s in 100m laps with a heart rate drifting up along the session
+func fixtureLaps(r *rand.Rand, s fixtureSession, age int) []fixtureLap {
+	count := s.DistanceMeters / 100
+	laps := make([]fixtureLap, count)
+
+	maxHR := 208 - 0.7*float64(age)
+	effort := 0.6 + 0.03*float64(s.RPE)
+	for i := range laps {
+		drift := float64(i) / float64(count) * 0.08
+		laps[i] = fixtureLap{
+			SessionID:       s.ID,
+			Number:          i + 1,
+			DistanceMeters:  100,
+			DurationSeconds: max(int(s.Pace*60*normal(r, 1, 0.04, 0.85, 1.2)), 1),
+			StrokeCount:     int(normal(r, 72, 10, 40, 110)),
+			AvgHeartRate:    int(maxHR * normal(r, effort+drift, 0.03, 0.5, 1)),
+		}
+	}
+	return laps
+}
+
+// pickDistance returns a session distance drawn with the weights of fixtureDistances
+func pickDistance(r *rand.Rand, total float64) int {
+	pick := r.Float64() * total
+	for _, d := range fixtureDistances {
+		if pick -= d.weight; pick <= 0 {
+			return d.meters
+		}
+	}
+	return fixtureDistances[len(fixtureDistances)-1].meters
+}
+
+// normal draws from a normal distribution clamped to [lo, hi]
+func normal(r *rand.Rand, mean, stddev, lo, hi float64) float64 {
+	return math.Min(math.Max(mean+r.NormFloat64()*stddev, lo), hi)
+}
+
+// randomUUID returns a version 4 UUID drawn from r, so a seed always generates the same ids
+func randomUUID(r *rand.Rand) string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(r.Uint32())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
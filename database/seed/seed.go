@@ -0,0 +1,163 @@
+// Package seed fills a development database with categories, sample trainings,
+// a demo admin and demo users. Every statement is idempotent so it can run repeatedly.
+package seed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Options configures the demo accounts
+type Options struct {
+	AdminEmail    string
+	AdminPassword string
+	UserPassword  string // shared by every demo user
+}
+
+// DefaultOptions returns the documented demo credentials
+func DefaultOptions() Options {
+	return Options{
+		AdminEmail:    "admin@swimo.dev",
+		AdminPassword: "swimo-admin",
+		UserPassword:  "swimo-demo",
+	}
+}
+
+// Run inserts missing seed rows in a single transaction, existing rows are left untouched
+func Run(ctx context.Context, pool *pgxpool.Pool, log *logger.Logger, opts Options) error {
+	steps := []struct {
+		name string
+		run  func(context.Context, pgx.Tx, Options) (int64, error)
+	}{
+		{"training_categories", seedCategories},
+		{"trainings", seedTrainings},
+		{"admin", seedAdmin},
+		{"users", seedUsers},
+	}
+
+	return database.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		for _, step := range steps {
+			inserted, err := step.run(ctx, tx, opts)
+			if err != nil {
+				return fmt.Errorf("seed %s: %w", step.name, err)
+			}
+			log.Info("Seeded", "table", step.name, "inserted", inserted)
+		}
+		return nil
+	})
+}
+
+func seedCategories(ctx context.Context, tx pgx.Tx, opts Options) (int64, error) {
+	var inserted int64
+
+	for _, c := range categories {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO training_categories (code, name, description, met)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (code) DO NOTHING`,
+			c.Code, c.Name, c.Description, c.MET,
+		)
+		if err != nil {
+			return inserted, err
+		}
+		inserted += tag.RowsAffected()
+	}
+
+	return inserted, nil
+}
+
+func seedTrainings(ctx context.Context, tx pgx.Tx, opts Options) (int64, error) {
+	var inserted int64
+
+	for _, t := range trainings {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO trainings (
+				category_id, level, name, descriptions, time_label,
+				calories_kcal, thumbnail_url, video_url, content_html
+			)
+			SELECT id, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9
+			FROM training_categories WHERE code = $1
+			ON CONFLICT (name) DO NOTHING`,
+			t.CategoryCode, t.Level, t.Name, t.Descriptions, t.TimeLabel,
+			t.CaloriesKcal, t.ThumbnailURL, t.VideoURL, t.ContentHTML,
+		)
+		if err != nil {
+			return inserted, err
+		}
+		inserted += tag.RowsAffected()
+	}
+
+	return inserted, nil
+}
+
+func seedAdmin(ctx context.Context, tx pgx.Tx, opts Options) (int64, error) {
+	accountID, created, err := upsertAccount(ctx, tx, opts.AdminEmail, opts.AdminPassword, "admin")
+	if err != nil || !created {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO users (account_id, name, gender)
+		VALUES ($1, 'Swimo Admin', 0)
+		ON CONFLICT (account_id) DO NOTHING`,
+		accountID,
+	)
+	return 1, err
+}
+
+func seedUsers(ctx context.Context, tx pgx.Tx, opts Options) (int64, error) {
+	var inserted int64
+
+	for _, u := range demoUsers {
+		accountID, created, err := upsertAccount(ctx, tx, u.Email, opts.UserPassword, "user")
+		if err != nil {
+			return inserted, err
+		}
+		if !created {
+			continue
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO users (account_id, name, gender, weight_kg, height_cm, age_years)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (account_id) DO NOTHING`,
+			accountID, u.Name, u.Gender, u.WeightKG, u.HeightCM, u.Age,
+		); err != nil {
+			return inserted, err
+		}
+		inserted++
+	}
+
+	return inserted, nil
+}
+
+// upsertAccount creates the account when the email is free, returning its id and whether it was created
+func upsertAccount(ctx context.Context, tx pgx.Tx, email, password, role string) (string, bool, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", false, err
+	}
+
+	var id string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO accounts (email, password_hash, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (email) DO NOTHING
+		RETURNING id`,
+		email, string(hash), role,
+	).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return id, true, nil
+}
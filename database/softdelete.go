@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"strings"
+)
+
+// Tables deleting their rows softly carry a nullable deleted_at column: reads keep the rows
+// where it is NULL, admins list and restore the others. Rows referencing them are left as is.
+
+// Live is the condition keeping the rows of alias not deleted, ex: Live("t") is
+// "t.deleted_at IS NULL", for queries built at run time
+func Live(alias string) string {
+	return column(alias) + " IS NULL"
+}
+
+// Deleted is the condition keeping the deleted rows of alias
+func Deleted(alias string) string {
+	return column(alias) + " IS NOT NULL"
+}
+
+func column(alias string) string {
+	if alias == "" {
+		return "deleted_at"
+	}
+	return alias + ".deleted_at"
+}
+
+// SoftDelete marks the row id of table deleted, false when it is missing, already deleted or
+// out of scope. scope is an extra condition on the row, its placeholders start at $2 for args.
+func SoftDelete(ctx context.Context, db DBTX, table, id, scope string, args ...any) (bool, error) {
+	return setDeleted(ctx, db, `UPDATE `+table+` SET deleted_at = now() WHERE id = $1 AND `+Live(""), scope, id, args)
+}
+
+// Restore clears the deletion of the row id of table, false when it is missing, not deleted or
+// out of scope. scope is like in SoftDelete.
+func Restore(ctx context.Context, db DBTX, table, id, scope string, args ...any) (bool, error) {
+	return setDeleted(ctx, db, `UPDATE `+table+` SET deleted_at = NULL WHERE id = $1 AND `+Deleted(""), scope, id, args)
+}
+
+func setDeleted(ctx context.Context, db DBTX, q, scope, id string, args []any) (bool, error) {
+	if scope = strings.TrimSpace(scope); scope != "" {
+		q += ` AND (` + scope + `)`
+	}
+
+	tag, err := db.Exec(ctx, q, append([]any{id}, args...)...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
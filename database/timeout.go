@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrQueryTimeout is returned when a query exceeds the per call or server statement timeout
+var ErrQueryTimeout = errors.New("query timed out")
+
+// WithQueryTimeout wraps db so every call runs with a deadline of timeout.
+// An earlier deadline already on the context still wins.
+func WithQueryTimeout(db DBTX, timeout time.Duration) DBTX {
+	if timeout <= 0 {
+		return db
+	}
+	return &timeoutDB{db: db, timeout: timeout}
+}
+
+// Rebind returns tx wrapped like db (query timeout, circuit breaker), used by repositories' WithTx
+func Rebind(db DBTX, tx pgx.Tx) DBTX {
+	if w, ok := db.(interface{ rebind(pgx.Tx) DBTX }); ok {
+		return w.rebind(tx)
+	}
+	return tx
+}
+
+type timeoutDB struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func (t *timeoutDB) rebind(tx pgx.Tx) DBTX {
+	return &timeoutDB{db: Rebind(t.db, tx), timeout: t.timeout}
+}
+
+func (t *timeoutDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	qctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	tag, err := t.db.Exec(qctx, sql, args...)
+	return tag, timeoutErr(ctx, qctx, err)
+}
+
+func (t *timeoutDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	qctx, cancel := context.WithTimeout(ctx, t.timeout)
+
+	rows, err := t.db.Query(qctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, timeoutErr(ctx, qctx, err)
+	}
+
+	// The deadline must outlive Query, rows are read by the caller afterwards
+	return &timeoutRows{Rows: rows, parent: ctx, ctx: qctx, cancel: cancel}, nil
+}
+
+func (t *timeoutDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	qctx, cancel := context.WithTimeout(ctx, t.timeout)
+	return &timeoutRow{row: t.db.QueryRow(qctx, sql, args...), parent: ctx, ctx: qctx, cancel: cancel}
+}
+
+func (t *timeoutDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	qctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	n, err := t.db.CopyFrom(qctx, tableName, columnNames, rowSrc)
+	return n, timeoutErr(ctx, qctx, err)
+}
+
+func (t *timeoutDB) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	qctx, cancel := context.WithTimeout(ctx, t.timeout)
+	return &timeoutBatch{BatchResults: t.db.SendBatch(qctx, b), parent: ctx, ctx: qctx, cancel: cancel}
+}
+
+// timeoutRows releases the deadline once the rows are exhausted or closed
+type timeoutRows struct {
+	pgx.Rows
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+func (r *timeoutRows) Err() error {
+	return timeoutErr(r.parent, r.ctx, r.Rows.Err())
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+// timeoutBatch releases the deadline once the batch results are closed
+type timeoutBatch struct {
+	pgx.BatchResults
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (b *timeoutBatch) Close() error {
+	defer b.cancel()
+	return timeoutErr(b.parent, b.ctx, b.BatchResults.Close())
+}
+
+type timeoutRow struct {
+	row    pgx.Row
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return timeoutErr(r.parent, r.ctx, r.row.Scan(dest...))
+}
+
+// timeoutErr marks err as ErrQueryTimeout when our deadline (not the caller's) or the
+// server statement_timeout canceled the query, other errors pass through untouched
+func timeoutErr(parent, ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+		return errors.Join(ErrQueryTimeout, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "57014" { // query_canceled
+		return errors.Join(ErrQueryTimeout, err)
+	}
+
+	return err
+}
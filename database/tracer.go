@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// redactedArg replaces text arguments, which may hold passwords, emails or tokens
+const redactedArg = logger.Redacted
+
+// queryTracer logs parameterized SQL with redacted arguments and the measured duration. The SQL
+// and errors, which may quote literal values, are masked by the logger's redactor like any line.
+// Every query is logged at debug level when logQueries is set, slow and failed queries always.
+type queryTracer struct {
+	log           *logger.Logger
+	logQueries    bool
+	slowThreshold time.Duration // 0 disables slow query warnings
+}
+
+type queryTraceKey struct{}
+
+type queryTrace struct {
+	sql   string
+	args  []any
+	start time.Time
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryTraceKey{}, &queryTrace{sql: data.SQL, args: data.Args, start: time.Now()})
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(queryTraceKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+
+	duration := time.Since(trace.start)
+	slow := t.slowThreshold > 0 && duration >= t.slowThreshold
+
+	if data.Err == nil && !slow && !t.logQueries {
+		return
+	}
+
+	attrs := []any{
+		"sql", compactSQL(trace.sql),
+		"args", redactArgs(trace.args),
+		"duration", duration,
+	}
+
+	log := t.logger(ctx)
+	switch {
+	case data.Err != nil:
+		log.Error("Query failed", append(attrs, "error", data.Err)...)
+	case slow:
+		log.Warn("Slow query", append(attrs, "rows", data.CommandTag.RowsAffected())...)
+	default:
+		log.Debug("Query", append(attrs, "rows", data.CommandTag.RowsAffected())...)
+	}
+}
+
+// logger prefers the request scoped logger so queries carry request_id and account_id
+func (t *queryTracer) logger(ctx context.Context) *logger.Logger {
+	if logger.HasContext(ctx) {
+		return logger.FromContext(ctx)
+	}
+	return t.log
+}
+
+// compactSQL collapses newlines and indentation into single spaces
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// redactArgs keeps numbers, booleans and times readable and hides every text value
+func redactArgs(args []any) []any {
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		redacted[i] = redactArg(arg)
+	}
+	return redacted
+}
+
+func redactArg(arg any) any {
+	switch v := arg.(type) {
+	case nil:
+		return nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case time.Duration:
+		return v.String()
+	case string, []byte:
+		return redactedArg
+	}
+
+	// Repositories often pass pointers to struct fields
+	if rv := reflect.ValueOf(arg); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		return redactArg(rv.Elem().Interface())
+	}
+
+	return fmt.Sprintf("[%T]", arg)
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrStaleVersion is matched by a StaleVersionError, for the error catalog
+var ErrStaleVersion = errors.New("stale version")
+
+// StaleVersionError is returned by a compare and swap update when the row changed since the
+// version the caller read. Current is the version to reload.
+type StaleVersionError struct {
+	Current int
+}
+
+func (e *StaleVersionError) Error() string {
+	return fmt.Sprintf("stale version, current version is %d", e.Current)
+}
+
+func (e *StaleVersionError) Is(target error) bool { return target == ErrStaleVersion }
+
+// Details are sent along the error code, the client learns the current version without reading
+// the row again
+func (e *StaleVersionError) Details() map[string]string {
+	return map[string]string{"version": strconv.Itoa(e.Current)}
+}
+
+// CheckVersion returns a StaleVersionError when the caller expects another version than current,
+// nil when it expects none
+func CheckVersion(expected *int, current int) error {
+	if expected != nil && *expected != current {
+		return &StaleVersionError{Current: current}
+	}
+	return nil
+}
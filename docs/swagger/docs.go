@@ -20,38 +20,35 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/refresh-token": {
-            "post": {
+        "/account/usage": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Generate new access token using refresh token",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Requests of the account on each UTC day of the range ending today, by client: the app, signed in with an access token, and each paired device calling with its token. Device requests count against the monthly quota of the account, once it is used up they answer 429 QUOTA_EXCEEDED with Quota-Limit, Quota-Remaining and Quota-Reset headers until the next UTC month.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Usage"
                 ],
-                "summary": "Refresh JWT token",
+                "summary": "Get API usage",
                 "parameters": [
                     {
-                        "description": "Refresh token request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.RefreshTokenRequest"
-                        }
+                        "type": "integer",
+                        "default": 30,
+                        "maximum": 90,
+                        "minimum": 1,
+                        "description": "Days of the range, today included",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token refreshed successfully",
+                        "description": "Usage retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -61,49 +58,94 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/auth.RefreshTokenResponse"
+                                            "$ref": "#/definitions/usage.AccountUsageResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Invalid or expired refresh token",
+                    "403": {
+                        "description": "Guests have no account",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Usage metering is disabled",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/sign-in": {
-            "post": {
-                "description": "Authenticate user with email and password, returns JWT tokens",
-                "consumes": [
-                    "application/json"
+        "/admin/guests/flagged": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "List the open flags of guest clients caught by the abuse heuristics: too many guest sessions from one fingerprint in an hour, or a reported swim nobody can swim. A throttled fingerprint must sign up until throttledUntil. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Sign in user",
+                "summary": "List flagged guests",
                 "parameters": [
                     {
-                        "description": "Sign in request with user credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.SignInRequest"
-                        }
+                        "enum": [
+                            "many_sessions",
+                            "implausible_session"
+                        ],
+                        "type": "string",
+                        "description": "Flag reason",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "updated_at.asc",
+                            "updated_at.desc",
+                            "occurrences.asc",
+                            "occurrences.desc"
+                        ],
+                        "type": "string",
+                        "default": "updated_at.desc",
+                        "description": "Sort field and direction",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sign in successful",
+                        "description": "Flagged guests retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -113,43 +155,104 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/auth.SignInResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/abuse.GuestFlagResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "403": {
+                        "description": "Insufficient role",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
-                    "401": {
-                        "description": "Invalid email or password",
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/guests/flagged/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Close a flag raised by mistake, its fingerprint may sign in as a guest again unless another open flag still throttles it. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear a guest flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"6e5d4c3b-2a19-4f08-9e7d-6c5b4a392817\"",
+                        "description": "Flag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Guest flag cleared",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
-                    "422": {
-                        "description": "Validation errors",
+                    "404": {
+                        "description": "Guest flag not found",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
                     },
-                    "423": {
-                        "description": "Your account has been locked",
+                    "422": {
+                        "description": "Invalid flag ID",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/sign-in-guest": {
+        "/admin/payments/{id}/refund": {
             "post": {
-                "description": "Authenticate guest user without credentials, returns limited access tokens",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give back an amount of a payment through the payment provider, the rest of it without amount. The subscription it paid is kept, cancel it at the provider to end it. Sent again with the same Idempotency-Key, ex: after a timeout, the refund asked first is made once. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,23 +260,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Billing"
                 ],
-                "summary": "Sign in guest",
+                "summary": "Refund a payment",
                 "parameters": [
                     {
-                        "description": "Guest sign in request with optional user agent",
+                        "type": "string",
+                        "example": "\"0f1e2d3c-4b5a-4697-8877-665544332211\"",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"b7e3c1a2-9f04-4d6e-8a51-2c7d9e0f1a3b\"",
+                        "description": "Unique to the refund, up to 255 characters",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Amount to refund",
                         "name": "request",
                         "in": "body",
-                        "required": true,
+                        "required": false,
                         "schema": {
-                            "$ref": "#/definitions/auth.SignInGuestRequest"
+                            "$ref": "#/definitions/billing.RefundRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Guest sign in successful",
+                        "description": "Payment refunded successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -183,7 +301,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/auth.SignInGuestResponse"
+                                            "$ref": "#/definitions/billing.PaymentResponse"
                                         }
                                     }
                                 }
@@ -193,13 +311,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
                     "403": {
-                        "description": "Guest sign in disabled",
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment fully refunded, amount more than left or idempotency key used by another refund",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
                     "422": {
@@ -208,46 +338,67 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Error"
                         }
                     },
-                    "429": {
-                        "description": "Guest session limit reached",
+                    "503": {
+                        "description": "Billing disabled or payment provider unavailable",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/sign-out": {
-            "post": {
+        "/admin/pools": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Revoke user session and invalidate JWT tokens",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "The pools of the organization and the shared ones by name, with the check in code of their QR. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Pool"
                 ],
-                "summary": "Sign out user",
+                "summary": "List pools",
                 "responses": {
                     "200": {
-                        "description": "Sign out successfully",
+                        "description": "Pools retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/pool.PoolResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
-            }
-        },
-        "/sign-up": {
+            },
             "post": {
-                "description": "Register a new user account with email, password, and profile information",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a pool of the organization with a new check in code to post as a QR at the pool. Swimmers within radiusMeters of the location can check in without it. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -255,37 +406,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Pool"
                 ],
-                "summary": "Sign up new user",
+                "summary": "Create a pool",
                 "parameters": [
                     {
-                        "description": "Sign up request with user details",
+                        "description": "Pool to create",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.SignUpRequest"
+                            "$ref": "#/definitions/pool.PoolRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "User registered successfully",
+                        "description": "Pool created successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pool.PoolResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
-                    "409": {
-                        "description": "Email already exists",
+                    "403": {
+                        "description": "Insufficient role",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
                     "422": {
@@ -297,14 +460,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/trainings": {
-            "get": {
+        "/admin/pools/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of trainings with optional search and sorting",
+                "description": "Replace the details of a pool of the organization, its check in code is kept. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -312,101 +475,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Training"
+                    "Pool"
                 ],
-                "summary": "Get trainings with pagination",
+                "summary": "Update a pool",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "name.asc",
-                            "name.desc",
-                            "level.asc",
-                            "level.desc",
-                            "created_at.asc",
-                            "created_at.desc"
-                        ],
                         "type": "string",
-                        "default": "created_at.desc",
-                        "description": "Sort field and direction",
-                        "name": "sort",
-                        "in": "query"
+                        "example": "\"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b\"",
+                        "description": "Pool ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Search term for training name and description",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Pool details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pool.PoolRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Trainings retrieved successfully",
+                        "description": "Pool updated successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/response.SuccessPagination"
+                                    "$ref": "#/definitions/response.Success"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/training.TrainingItemResponse"
-                                            }
+                                            "$ref": "#/definitions/pool.PoolResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
                     "404": {
-                        "description": "Training not found",
+                        "description": "Pool not found",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.SuccessPagination"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/training.TrainingItemResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/pools/{id}/lanes": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new training with the provided details",
+                "description": "Replace the lane board of a pool of the organization, lanes[0] being lane 1, and push it to the live boards. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -414,23 +558,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Training"
+                    "Pool"
                 ],
-                "summary": "Create a new training",
+                "summary": "Publish lanes",
                 "parameters": [
                     {
-                        "description": "Training creation request",
+                        "type": "string",
+                        "example": "\"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b\"",
+                        "description": "Pool ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lanes of the pool",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/training.TrainingRequest"
+                            "$ref": "#/definitions/pool.LanesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Training created successfully",
+                    "200": {
+                        "description": "Lanes published successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -440,17 +592,29 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/training.TrainingResponse"
+                                            "$ref": "#/definitions/pool.OccupancyResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "409": {
-                        "description": "Training already exists",
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
                     "422": {
@@ -462,14 +626,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/trainings/sessions/last": {
-            "get": {
+        "/admin/pools/{id}/lanes/{number}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve the most recent training session",
+                "description": "Set the status and swimmers of a published lane of a pool of the organization as swimmers come and go, and push the board to the live boards. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -477,12 +641,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Training"
+                    "Pool"
+                ],
+                "summary": "Update a lane",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b\"",
+                        "description": "Pool ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Lane number, from 1",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lane status and swimmers",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pool.LaneUpdateRequest"
+                        }
+                    }
                 ],
-                "summary": "Get user's last training session",
                 "responses": {
                     "200": {
-                        "description": "Last training session retrieved successfully",
+                        "description": "Lane updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -492,30 +683,48 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/training.TrainingSessionResponse"
+                                            "$ref": "#/definitions/pool.OccupancyResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
                     "404": {
-                        "description": "No training sessions found",
+                        "description": "Pool or lane not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/trainings/{id}": {
-            "get": {
+        "/admin/races": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve detailed training information by training ID",
+                "description": "Create a virtual race, swum anywhere between startsAt and endsAt. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -523,22 +732,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Training"
+                    "Race"
                 ],
-                "summary": "Get training by ID",
+                "summary": "Create a race",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
-                        "description": "Training ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Race to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/race.RaceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Training retrieved successfully",
+                    "201": {
+                        "description": "Race created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -548,30 +758,42 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/training.TrainingResponse"
+                                            "$ref": "#/definitions/race.RaceResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Training not found",
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
         },
-        "/trainings/{id}/finish": {
-            "post": {
+        "/admin/races/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Complete an ongoing training session with distance and duration metrics",
+                "description": "Replace the details of a race, results already accepted are kept. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -579,31 +801,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Training"
+                    "Race"
                 ],
-                "summary": "Finish a training session",
+                "summary": "Update a race",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
-                        "description": "Training ID",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Training finish session request",
+                        "description": "Race details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/training.TrainingFinishSessionRequest"
+                            "$ref": "#/definitions/race.RaceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Training session finished successfully",
+                    "200": {
+                        "description": "Race updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -613,15 +835,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/training.TrainingSessionResponse"
+                                            "$ref": "#/definitions/race.RaceResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
                     "404": {
-                        "description": "User not found or Training not found",
+                        "description": "Race not found",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
@@ -633,397 +867,9212 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "auth.RefreshTokenRequest": {
-            "type": "object",
-            "properties": {
-                "refreshToken": {
-                    "type": "string",
-                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
-                }
-            }
-        },
-        "auth.RefreshTokenResponse": {
-            "type": "object",
-            "properties": {
-                "expiresInMs": {
-                    "type": "integer",
-                    "example": 1799999
-                },
-                "refreshToken": {
-                    "type": "string",
-                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
-                },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                }
-            }
-        },
-        "auth.SignInGuestRequest": {
-            "type": "object",
-            "properties": {
-                "age": {
-                    "type": "integer",
-                    "example": 30
-                },
-                "gender": {
-                    "type": "string",
-                    "example": "male"
-                },
-                "height": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a race nobody registered to yet. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "Delete a race",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Race deleted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Race not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Race has entries",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List up to 100 trainings of the organization in a review status, the pending ones by default. Oldest first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "List trainings by review status",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending_review",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending_review",
+                        "description": "Review status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trainings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingReviewResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings/deleted": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List up to 100 deleted trainings of the organization, last deleted first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "List deleted trainings",
+                "responses": {
+                    "200": {
+                        "description": "Deleted trainings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingReviewResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings/sessions/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Put a deleted session back in the history of its user. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Restore a deleted training session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session restored",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid session ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a training of the organization from the catalog and the moderation lists, sessions recorded on it are kept. Trainings of the shared catalog can only be deleted outside an organization. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Delete a training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training deleted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid training ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Publish a training pending review to the catalog, its author is notified. With a version, a training edited since is refused with the current version. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Approve a training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Version of the training reviewed",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingApproveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training approved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Training is not pending review or edited since the version read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Keep a training pending review out of the catalog, its author is notified with the reason. With a version, a training edited since is refused with the current version. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Reject a training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of the rejection",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingRejectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training rejected",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Training is not pending review or edited since the version read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trainings/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Put a deleted training back in the catalog with the review status it had. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Restore a deleted training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training restored",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid training ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Search the users of the organization by email or name, case insensitive and anywhere in the value. Without query every user is listed. With deleted=true the deleted users are searched instead. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Search users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"dina\"",
+                        "description": "Part of the email or name",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Search the deleted users",
+                        "name": "deleted",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "email.asc",
+                            "email.desc",
+                            "name.asc",
+                            "name.desc",
+                            "created_at.asc",
+                            "created_at.desc"
+                        ],
+                        "type": "string",
+                        "default": "created_at.desc",
+                        "description": "Sort field and direction",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/admin.UserSummaryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Softly delete a user and its account: it can no longer sign in, its sign ins are revoked and it leaves the lists and stats. Access tokens already issued stay valid until they expire. Admins cannot be deleted. The deletion is audited and can be undone with restore. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role or admins cannot be deleted",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Account status, recorded sessions, active sign ins, last activity and the 20 latest audit events of a user. The view itself is audited. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/admin.UserDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mint a token acting as the user to reproduce a reported bug. It is read only unless readOnly is false, expires after JWT_IMPERSONATION_TTL_MIN minutes and has no refresh token. The impersonation and every request made with the token are recorded in the audit events of the user. Admins cannot be impersonated, the token is refused by gRPC. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Impersonate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of the impersonation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Impersonation token issued",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/admin.ImpersonationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role or admins cannot be impersonated",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The invoices paid by a user, last paid first, with the amount refunded of each. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Billing"
+                ],
+                "summary": "List the payments of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payments retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/billing.PaymentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a deleted user and its account, it can sign in again. The restore is audited. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore a deleted user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User restored",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted user not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/sessions/deleted": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List up to 100 deleted sessions of a user, last deleted first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "List deleted training sessions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted sessions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingDeletedSessionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/assignments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The trainings assigned to the signed in athlete by their coaches, due in the last 30 days or later, by due date. A session on the training since the assignment completes it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "List assigned trainings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "enum": [
+                            "pending",
+                            "overdue",
+                            "completed"
+                        ],
+                        "description": "Only the assignments in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assignments retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/coach.AssignmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/athletes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The athletes who granted the signed in coach access to their records, by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "List athletes",
+                "responses": {
+                    "200": {
+                        "description": "Athletes retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/coach.MemberResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/athletes/compliance": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "For each athlete of the signed in coach, the assignments due in the range ending today: completed, on time, overdue, pending while due today, and the completed ones swum at or under their target pace. The rate is the percent completed on time out of those no longer pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "Assignment compliance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 28,
+                        "maximum": 365,
+                        "minimum": 7,
+                        "description": "Days of the range, today included",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Compliance retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/coach.ComplianceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/athletes/{id}/assignments": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Assign an approved training to an athlete who granted the signed in coach access, due on a UTC date with an optional target pace. The first session of the athlete on the training since the assignment completes it, whatever the way it was recorded. A coach can have up to 50 assignments not yet due per athlete.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "Assign a training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID of the athlete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Training, due date and target",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/coach.AssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Training assigned successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/coach.AssignmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Athlete or training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Assignment limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The assignments of the signed in coach to an athlete due in the last 30 days or later, by due date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "List athlete assignments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID of the athlete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "enum": [
+                            "pending",
+                            "overdue",
+                            "completed"
+                        ],
+                        "description": "Only the assignments in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assignments retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/coach.AssignmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Athlete not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/athletes/{id}/assignments/{assignmentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove an assignment of the signed in coach to an athlete, completed or not",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "Remove an assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID of the athlete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"4d2c6b1a-9e8f-4a7b-b6c5-d4e3f2a1b0c9\"",
+                        "description": "Assignment ID",
+                        "name": "assignmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assignment removed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Athlete or assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/athletes/{id}/injuries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The injury and recovery log of an athlete who granted the signed in coach access",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injury"
+                ],
+                "summary": "List athlete injuries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID of the athlete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Injuries retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/injury.InjuryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Athlete not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/billing": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The plan and entitlements of the signed in user with their latest subscription",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Billing"
+                ],
+                "summary": "Get my plan",
+                "responses": {
+                    "200": {
+                        "description": "Plan retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/billing.BillingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/billing/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Open a hosted checkout of the premium plan for the signed in user, the client sends them to its url. The plan is granted once the payment provider confirms the subscription.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Billing"
+                ],
+                "summary": "Subscribe to premium",
+                "parameters": [
+                    {
+                        "description": "Plan to subscribe to",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/billing.CheckoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checkout created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/billing.CheckoutResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Already subscribed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Billing disabled or payment provider unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/billing/payments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The invoices paid by the signed in user, last paid first, with the amount refunded of each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Billing"
+                ],
+                "summary": "List my payments",
+                "responses": {
+                    "200": {
+                        "description": "Payments retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/billing.PaymentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/billing/webhook": {
+            "post": {
+                "description": "Called by the payment provider with the events of checkouts, subscriptions, invoices and refunds, signed in the Stripe-Signature header. An event is applied once however often it's delivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Billing"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"t=1700000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd\"",
+                        "description": "Signature of the payload",
+                        "name": "Stripe-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event received",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Event delivered before its subscription",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Billing disabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/coaches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The coaches with access to the records of the signed in athlete, newest grant first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "List coaches",
+                "responses": {
+                    "200": {
+                        "description": "Coaches retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/coach.MemberResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give the user signed up with the email read access to the records of the signed in athlete, ex: injuries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "Grant coach access",
+                "parameters": [
+                    {
+                        "description": "Email of the coach",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/coach.GrantCoachRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Coach access granted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/coach.MemberResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Coach not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Coach limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors or You cannot be your own coach",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/coaches/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove the access of a coach to the records of the signed in athlete",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coach"
+                ],
+                "summary": "Revoke coach access",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "User ID of the coach",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coach access revoked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Coach not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/consents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The current versions of the terms of service and privacy policy with the versions the account accepted, and its choice about marketing emails. Reachable while documents are pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consent"
+                ],
+                "summary": "Get consents",
+                "responses": {
+                    "200": {
+                        "description": "Consents retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/consent.ConsentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no account",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Accept the current version of the terms of service, the privacy policy or both. Other endpoints answer 428 CONSENT_REQUIRED, with the versions to accept by document, until the current versions are accepted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consent"
+                ],
+                "summary": "Accept legal documents",
+                "parameters": [
+                    {
+                        "description": "Versions shown to the user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/consent.AcceptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Documents accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/consent.ConsentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no account",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Version is not the current one",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/consents/marketing": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Opt in or out of marketing emails, the time of the latest opt in and opt out are kept apart from the legal documents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consent"
+                ],
+                "summary": "Set marketing consent",
+                "parameters": [
+                    {
+                        "description": "Marketing email choice",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/consent.MarketingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Marketing consent updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/consent.MarketingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no account",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Every device of the user that is not revoked, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "List paired devices",
+                "responses": {
+                    "200": {
+                        "description": "Devices retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/device.DeviceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a watch or companion app install and return its device token and signing secret. They are shown once. The token never expires and only authorizes the session ingestion of this device until the device is revoked. The secret signs its batches and is never sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Pair a device",
+                "parameters": [
+                    {
+                        "description": "Device to pair",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.PairDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Device paired successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/device.PairDeviceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Device limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Unpair a device of the user, its token stops working immediately",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Revoke a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Device revoked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Device not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/{id}/sessions": {
+            "post": {
+                "security": [
+                    {
+                        "DeviceToken": []
+                    }
+                ],
+                "description": "Import a batch of up to 500 sessions recorded by the device, authenticated with the device token as bearer. The body is JSON, the same document as msgpack (application/msgpack) or the swimo.v1.ImportSessionsRequest protobuf message (application/x-protobuf). With replay protection enabled, the batch is signed: X-Swimo-Timestamp (unix seconds), X-Swimo-Nonce (16 to 128 characters, unique per batch) and X-Swimo-Signature, the hex HMAC-SHA256 keyed with the signing secret returned at pairing of timestamp, nonce, method, path and body joined by newlines.",
+                "consumes": [
+                    "application/json",
+                    "application/msgpack",
+                    "application/x-protobuf"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Upload device sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sessions recorded by the device",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingImportSessionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sessions imported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingImportSessionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or revoked device token, invalid or stale signature",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Token was issued for another device",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Request was already received",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Payload must be JSON, msgpack or protobuf",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Every piece of gear of the user with its lifetime usage, active gear first then newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "List equipment",
+                "responses": {
+                    "200": {
+                        "description": "Equipment retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/equipment.EquipmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a piece of gear of the user, ex: fins, paddles or a wetsuit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "Register equipment",
+                "parameters": [
+                    {
+                        "description": "Equipment to register",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/equipment.EquipmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Equipment registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/equipment.EquipmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Equipment limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sessions, distance and duration with each kind of gear and each piece of gear of the user during a calendar year, ex: the wetsuit sessions of the season. A session counts once per kind.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "Equipment usage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 2025,
+                        "description": "Season, the current year by default",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Equipment usage retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/equipment.EquipmentStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a piece of gear of the user with its lifetime usage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "Get equipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "Equipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Equipment retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/equipment.EquipmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Equipment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the details of a piece of gear of the user, retired gear keeps its usage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "Update equipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "Equipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Equipment details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/equipment.EquipmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Equipment updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/equipment.EquipmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Equipment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a piece of gear of the user and untag it from its sessions, retire it instead to keep its usage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "Delete equipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b\"",
+                        "description": "Equipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Equipment deleted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Equipment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queue up to 100 product events of the app. Events are attributed to the token, sampled, stripped of personal data and delivered in the background, an accepted event may still be dropped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Event"
+                ],
+                "summary": "Track analytics events",
+                "parameters": [
+                    {
+                        "description": "Analytics events",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/event.TrackEventsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Events accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/injuries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The injury and recovery log of the user, ongoing injuries first then latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injury"
+                ],
+                "summary": "List injuries",
+                "responses": {
+                    "200": {
+                        "description": "Injuries retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/injury.InjuryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add an injury to the injury and recovery log of the user, without a resolved date it is ongoing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injury"
+                ],
+                "summary": "Log injury",
+                "parameters": [
+                    {
+                        "description": "Injury to log",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/injury.InjuryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Injury logged successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/injury.InjuryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/injuries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get an injury of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injury"
+                ],
+                "summary": "Get injury",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e\"",
+                        "description": "Injury ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Injury retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/injury.InjuryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Injury not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace an injury of the user, send the resolved date once recovered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injury"
+                ],
+                "summary": "Update injury",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e\"",
+                        "description": "Injury ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Injury details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/injury.InjuryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Injury updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/injury.InjuryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Injury not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an injury of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injury"
+                ],
+                "summary": "Delete injury",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e\"",
+                        "description": "Injury ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Injury deleted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Injury not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{key}": {
+            "get": {
+                "description": "Public media (avatars, training thumbnails and videos) redirect to a short lived signed link of the storage. With the local storage driver, signed links are served here.",
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Download a stored file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"trainings/8c4a2d27-56e2-4ef3-8a6e-43b812345abc/video-1a2b3c4d.mp4\"",
+                        "description": "Object key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Signed link expiry, unix seconds",
+                        "name": "expires",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed link signature",
+                        "name": "signature",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to the signed link"
+                    },
+                    "403": {
+                        "description": "Invalid or expired link",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/check-in": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Check the signed in swimmer in at the pool of the code read from its QR, or else at the nearest pool whose geofence holds the location. Sessions finished in the next 4 hours, until check out or another check in, are swum at the pool.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pool"
+                ],
+                "summary": "Check in at a pool",
+                "parameters": [
+                    {
+                        "description": "Code of the QR or location",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pool.CheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checked in successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pool.CheckInResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found or not at a pool",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The pool the signed in swimmer is checked in at, until check out or expiry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pool"
+                ],
+                "summary": "Get the active check in",
+                "responses": {
+                    "200": {
+                        "description": "Check in retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pool.CheckInResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not checked in",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "End the active check in of the signed in swimmer, sessions finished later have no pool",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pool"
+                ],
+                "summary": "Check out",
+                "responses": {
+                    "200": {
+                        "description": "Checked out",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not checked in",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/mine": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The pools the signed in swimmer swam at, last swum first, with the sessions, distance and duration swum at each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pool"
+                ],
+                "summary": "List my pools",
+                "responses": {
+                    "200": {
+                        "description": "Pools retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/pool.MyPoolResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/{id}/occupancy": {
+            "get": {
+                "description": "The lanes of a managed pool as last published by its staff, with the swimmers in each and whether one can join. No token is needed so lobby screens can show it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pool"
+                ],
+                "summary": "Get lane occupancy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b\"",
+                        "description": "Pool ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Occupancy retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pool.OccupancyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found or lanes not published",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/{id}/occupancy/events": {
+            "get": {
+                "description": "Server-sent events of the lane board of a pool for live boards: an occupancy event with the board on connect, once published, then on every update. A comment is sent every 25 seconds while quiet. The stream ends after 15 minutes, EventSource reconnects on its own. No token is needed so lobby screens can show it.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Pool"
+                ],
+                "summary": "Watch lane occupancy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b\"",
+                        "description": "Pool ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "occupancy events",
+                        "schema": {
+                            "$ref": "#/definitions/pool.OccupancyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/races": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Virtual races, newest first by default, with the entry of the signed in swimmer in the races they registered to. Filter by status: upcoming, open (between the start and the end) or closed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "List races",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "enum": [
+                            "upcoming",
+                            "open",
+                            "closed"
+                        ],
+                        "description": "Race status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "minimum": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "minimum": 1,
+                        "maximum": 100,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "starts_at.desc",
+                        "enum": [
+                            "starts_at.asc",
+                            "starts_at.desc",
+                            "name.asc",
+                            "name.desc"
+                        ],
+                        "description": "Sort field and direction",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Races retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/race.RaceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "A virtual race with its entry and finisher counts, and the entry of the signed in swimmer when registered",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "Get a race",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Race retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/race.RaceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Race not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/certificate": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The finisher certificate of the signed in swimmer as an SVG image, with their time and rank",
+                "produces": [
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "Download a race certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificate",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Race or entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Race not finished",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/finishers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The finishers of a race ranked fastest first, earlier results first on a tie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "List race finishers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "minimum": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "minimum": 1,
+                        "maximum": 100,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Finishers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/race.FinisherResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Race not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/register": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enter the signed in swimmer in a race, possible until the race ends. Registering again keeps the entry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "Register to a race",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/race.RaceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Race not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Race registration closed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/result": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submit a recorded session as the result of the signed in swimmer, until 48 hours after the race ends. The session must start during the race, cover at least the race distance, be plausible and, pro-rated to the race distance, finish within the cutoff. A slower session than the current result is accepted but doesn't replace it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Race"
+                ],
+                "summary": "Submit a race result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d\"",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Session to submit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/race.ResultRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/race.RaceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Race, entry or session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Race submissions closed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors or session not qualifying",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh-token": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate new access token using refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh JWT token",
+                "parameters": [
+                    {
+                        "description": "Refresh token request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/auth.RefreshTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Sign up required, the guest was flagged for abuse",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-in": {
+            "post": {
+                "description": "Authenticate user with email and password, returns JWT tokens. A sign in from a device or country not seen before on the account emails an alert with a \"this wasn't me\" link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Sign in user",
+                "parameters": [
+                    {
+                        "description": "Sign in request with user credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.SignInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sign in successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/auth.SignInResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "423": {
+                        "description": "Your account has been locked",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-in-alerts/deny": {
+            "post": {
+                "description": "Report the sign in of an alert email as not made by the owner of the account, with the token of its link. The account is locked and every session revoked, access tokens already issued stay valid until they expire. A token works once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Deny sign in",
+                "parameters": [
+                    {
+                        "description": "Token of the alert link",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.DenySignInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account locked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Link is invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-in-guest": {
+            "post": {
+                "description": "Authenticate guest user without credentials, returns limited access tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Sign in guest",
+                "parameters": [
+                    {
+                        "description": "Guest sign in request with optional user agent",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.SignInGuestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Guest sign in successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/auth.SignInGuestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guest sign in disabled or sign up required, the client was flagged for abuse",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Guest session limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-out": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke user session and invalidate JWT tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Sign out user",
+                "responses": {
+                    "200": {
+                        "description": "Sign out successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-up": {
+            "post": {
+                "description": "Register a new user account with email, password, and profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Sign up new user",
+                "parameters": [
+                    {
+                        "description": "Sign up request with user details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.SignUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/hr-zones": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Time spent in each of the five heart rate zones over a rolling period, aggregated and per session. Only laps recorded with an average heart rate count. Zones are shares of the max heart rate set in the preferences, or 220 minus the age when unset. Injuries overlapping the period are listed so charts can show them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Heart rate zones",
+                "parameters": [
+                    {
+                        "enum": [
+                            "week",
+                            "month",
+                            "year"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Rolling period ending now",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Heart rate zones retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/stats.HeartRateZonesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Premium plan required",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors or max heart rate unknown",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/open-water": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sessions, distance, duration, wetsuit sessions and water temperatures of the open water sessions of a calendar year, in total and for each month in UTC. Injuries overlapping the year are listed so charts can show them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Open water season",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 2025,
+                        "description": "Season, the current year by default",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Open water stats retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/stats.OpenWaterStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Premium plan required",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/training-load": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Acute (7 day) and chronic (42 day) training load for each UTC day of the range ending today. The load of a session is its minutes times its perceived exertion (RPE), sessions without one count as moderate. Both loads are exponentially weighted moving averages, an acute:chronic ratio above the risk threshold flags a sharp ramp up. Injuries overlapping the range are listed so charts can show them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Training load",
+                "parameters": [
+                    {
+                        "maximum": 365,
+                        "minimum": 7,
+                        "type": "integer",
+                        "default": 42,
+                        "description": "Days of the range, today included",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training load retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/stats.TrainingLoadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Premium plan required",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/sessions": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sync a batch of sessions recorded offline, identified by a UUID generated on the device. A known clientId updates its session: trainingId and startedAt keep the server value, distance, duration and laps keep the value edited last by updatedAt. Results are keyed by clientId (by index when it is missing) with the status created, updated, unchanged or rejected, and the fields that kept the server value in conflicts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Sync offline training sessions",
+                "parameters": [
+                    {
+                        "description": "Training sessions sync request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingSyncSessionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training sessions synced successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingSyncSessionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Sessions are being synced by another request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of trainings with optional search, filters and sorting. With facets, the number of trainings per category and level is returned along the page, each count applying every filter but its own. A search without results is an empty page, not an error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Get trainings with pagination",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name.asc",
+                            "name.desc",
+                            "level.asc",
+                            "level.desc",
+                            "created_at.asc",
+                            "created_at.desc"
+                        ],
+                        "type": "string",
+                        "default": "created_at.desc",
+                        "description": "Sort field and direction",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term for training name and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "BREASTSTROKE",
+                        "description": "Category code to filter by",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "beginner",
+                        "description": "Level to filter by",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "category,level",
+                        "description": "Comma separated filters to count the trainings of by value, returned in meta.facets",
+                        "name": "facets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trainings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Search timed out",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new training with the provided details. Trainings created by admins are published right away, those of other accounts are submitted for review and only listed once approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Create a new training",
+                "parameters": [
+                    {
+                        "description": "Training creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Training created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests cannot submit trainings",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Training already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List up to 100 pending pairs of sessions of the user overlapping for at least half of the shorter one, ex: a manual entry and the watch import of the same swim. Newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "List possible duplicate sessions",
+                "responses": {
+                    "200": {
+                        "description": "Possible duplicates retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingDuplicateResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/duplicates/{id}/dismiss": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mark the pair as distinct sessions, both are kept and the pair is not flagged again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Dismiss a possible duplicate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a\"",
+                        "description": "Duplicate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duplicate dismissed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Duplicate not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/duplicates/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Keep one session of the pair, the one stored first unless keepId is sent, and delete the other. The kept session takes the laps, water conditions, client id and training of the deleted one where it has none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Merge duplicate sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a\"",
+                        "description": "Duplicate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Session to keep",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingMergeDuplicateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sessions merged",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Duplicate not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream every training session of the user, newest first. Send Accept: application/x-ndjson (or format=ndjson) for one session per line, otherwise the sessions are streamed as the data array.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Export training sessions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Stream format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training sessions exported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingSessionExportResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/export/file": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Write every training session of the user to an NDJSON file and return a link downloading it until expiresAt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Export training sessions to a file",
+                "responses": {
+                    "200": {
+                        "description": "Training sessions exported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingExportFileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import a batch of sessions recorded elsewhere (ex: a watch), with optional laps, in a single transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Import training sessions",
+                "parameters": [
+                    {
+                        "description": "Training sessions import request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingImportSessionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Training sessions imported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingImportSessionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "User not found or Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/last": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the most recent training session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Get user's last training session",
+                "responses": {
+                    "200": {
+                        "description": "Last training session retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No training sessions found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a session of the user with its laps and, for open water sessions, its water conditions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Get a training session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training session retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingSessionDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a session from the history, stats and exports of the user. Admins can restore it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Delete a training session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session deleted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.Message"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid session ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/{id}/conditions": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the water conditions of an open water session. With latitude and longitude, the water temperature, wave height and current speed left empty are filled from the weather at the start of the session when a provider is configured.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Update water conditions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Water conditions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingConditionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Water conditions updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingSessionDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors or session not open water",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/sessions/{id}/equipment": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the gear used in a session of the user with up to 10 pieces of their gear, an empty list clears it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Equipment"
+                ],
+                "summary": "Tag session equipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gear used",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/equipment.SessionEquipmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session equipment updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/equipment.EquipmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found or Equipment not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/submissions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List up to 100 trainings submitted by the user with their review status and the reason of a rejection. Newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "List my training submissions",
+                "responses": {
+                    "200": {
+                        "description": "Submissions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/training.TrainingReviewResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed training information by training ID. Trainings under review or rejected are only visible to their author and admins. With include, aggregates are embedded in the same response: completions is the number of sessions of the user on the training.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Get training by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "completions",
+                        "description": "Comma separated aggregates to embed",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid training ID or include",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/{id}/finish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Complete an ongoing training session with distance and duration metrics. Open water sessions may carry their water conditions. Sessions faster or slower than plausible for the training level, longer than 6 hours or not a whole number of pool lengths are rejected unless an admin sets override.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Finish a training session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Training finish session request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/training.TrainingFinishSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Training session finished successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests must sign up to record sessions",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found or Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors, implausible session or conditions on a session that is not open water",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trainings/{id}/media": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the thumbnail (JPEG, PNG or WebP) and/or the video (MP4, WebM or QuickTime) of a training with the files of a multipart form. Files are streamed to storage, large videos are uploaded in parts. With a version, sent before the files, a training edited since is refused with the current version. Only the author of the training and admins can replace its media.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Training"
+                ],
+                "summary": "Upload training media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c4a2d27-56e2-4ef3-8a6e-43b812345abc\"",
+                        "description": "Training ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Version of the training read, sent before the files",
+                        "name": "version",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Thumbnail image",
+                        "name": "thumbnail",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Video",
+                        "name": "video",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Training media updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/training.TrainingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the author of the training",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Training not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Training edited since the version read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors or file rejected by the malware scan",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Uploads disabled or malware scanner unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the avatar of the user with a JPEG, PNG or WebP image up to 5MB, sent as the avatar field of a multipart form",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upload avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Avatar image",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Avatar updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.AvatarResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors or file rejected by the malware scan",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Uploads disabled or malware scanner unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the time zone and the notifications the user receives",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "Preferences retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.PreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Set the IANA time zone of the user, used to send scheduled mail in the morning of the user, and opt in or out of the weekly digest. With a version, preferences edited since, ex: from another device, are refused with the current version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.PreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.PreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Guests have no profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Preferences edited since the version read",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "abuse.GuestFlagResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "fingerprint": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6e5d4c3b-2a19-4f08-9e7d-6c5b4a392817"
+                },
+                "occurrences": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "many_sessions",
+                        "implausible_session"
+                    ],
+                    "example": "many_sessions"
+                },
+                "throttled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "throttledUntil": {
+                    "type": "string",
+                    "example": "2025-09-22T07:30:00Z"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T08:10:00Z"
+                },
+                "userAgent": {
+                    "type": "string",
+                    "example": "okhttp/4.12.0"
+                }
+            }
+        },
+        "admin.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "admin.user_viewed"
+                },
+                "actorEmail": {
+                    "type": "string",
+                    "example": "admin@swimo.id"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "admin.ImpersonateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "readOnly": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Ticket #4821, stats page shows no sessions"
+                }
+            }
+        },
+        "admin.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "expiresIn": {
+                    "type": "integer",
+                    "example": 900000
+                },
+                "id": {
+                    "description": "audit event of the impersonation",
+                    "type": "string",
+                    "example": "3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a"
+                },
+                "readOnly": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "admin.UserDetailResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string",
+                    "example": "0f9e8d7c-6b5a-4f3e-2d1c-0b9a8f7e6d5c"
+                },
+                "activeSignIns": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "auditEvents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.AuditEventResponse"
+                    }
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "example": "2025-09-23T10:15:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "swimmer@swimo.id"
+                },
+                "lastActivityAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "locked": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dina Kusuma"
+                },
+                "organizationId": {
+                    "type": "string",
+                    "example": "7a6b5c4d-3e2f-4a1b-9c8d-7e6f5a4b3c2d"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "user"
+                },
+                "sessionsCount": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "admin.UserSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string",
+                    "example": "0f9e8d7c-6b5a-4f3e-2d1c-0b9a8f7e6d5c"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "example": "2025-09-23T10:15:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "swimmer@swimo.id"
+                },
+                "locked": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dina Kusuma"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "user"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "auth.DenySignInRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "swa_q5nM3v8YtR2kLp0wXe7uZa4bHc1dFg6jNs9oQi2rTv"
+                }
+            }
+        },
+        "auth.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
+                }
+            }
+        },
+        "auth.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expiresInMs": {
+                    "type": "integer",
+                    "example": 1799999
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "auth.SignInGuestRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "male"
+                },
+                "height": {
+                    "type": "number",
+                    "example": 180
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 75.5
+                }
+            }
+        },
+        "auth.SignInGuestResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "expiresIn": {
+                    "type": "integer",
+                    "example": 1799999
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "height": {
+                    "type": "number",
+                    "example": 180
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 75.5
+                }
+            }
+        },
+        "auth.SignInRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "SecurePassword123"
+                }
+            }
+        },
+        "auth.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "expiresIn": {
+                    "type": "integer",
+                    "example": 1799999
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "height": {
+                    "type": "number",
+                    "example": 180
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 75.5
+                }
+            }
+        },
+        "auth.SignUpRequest": {
+            "type": "object",
+            "required": [
+                "confirmPassword",
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "confirmPassword": {
+                    "type": "string",
+                    "example": "SecurePassword123"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "male"
+                },
+                "height": {
+                    "type": "number",
+                    "example": 180
+                },
+                "marketingEmails": {
+                    "description": "opt in to marketing emails, unchecked by default",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "SecurePassword123"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 75.5
+                }
+            }
+        },
+        "billing.BillingResponse": {
+            "type": "object",
+            "properties": {
+                "entitlements": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "premium"
+                    ]
+                },
+                "plan": {
+                    "type": "string",
+                    "enum": [
+                        "free",
+                        "premium"
+                    ],
+                    "example": "premium"
+                },
+                "subscription": {
+                    "description": "latest subscription, unset when never subscribed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/billing.SubscriptionResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "billing.CheckoutRequest": {
+            "type": "object",
+            "required": [
+                "plan"
+            ],
+            "properties": {
+                "plan": {
+                    "type": "string",
+                    "enum": [
+                        "premium"
+                    ],
+                    "example": "premium"
+                }
+            }
+        },
+        "billing.CheckoutResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "cs_test_a1b2c3d4e5f6"
+                },
+                "url": {
+                    "description": "where the user pays",
+                    "type": "string",
+                    "example": "https://checkout.stripe.com/c/pay/cs_test_a1b2c3d4e5f6"
+                }
+            }
+        },
+        "billing.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "smallest currency unit",
+                    "type": "integer",
+                    "example": 4900
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "idr"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0f1e2d3c-4b5a-4697-8877-665544332211"
+                },
+                "paidAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "refunded": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+                }
+            }
+        },
+        "billing.RefundRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "smallest currency unit",
+                    "type": "integer",
+                    "example": 4900
+                }
+            }
+        },
+        "billing.SubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "cancelAtPeriodEnd": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "currentPeriodEnd": {
+                    "type": "string",
+                    "example": "2025-10-21T07:30:00Z"
+                },
+                "plan": {
+                    "type": "string",
+                    "example": "premium"
+                },
+                "status": {
+                    "description": "as the payment provider reports it",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "coach.AssignRequest": {
+            "type": "object",
+            "required": [
+                "dueOn",
+                "trainingId"
+            ],
+            "properties": {
+                "dueOn": {
+                    "description": "UTC date, today or later",
+                    "type": "string",
+                    "example": "2025-09-28"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Negative split the last 200m"
+                },
+                "targetPace": {
+                    "description": "minutes/100m",
+                    "type": "number",
+                    "maximum": 60,
+                    "example": 2.1
+                },
+                "trainingId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                }
+            }
+        },
+        "coach.AssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "athlete": {
+                    "$ref": "#/definitions/coach.AssignmentUserResponse"
+                },
+                "coach": {
+                    "$ref": "#/definitions/coach.AssignmentUserResponse"
+                },
+                "completion": {
+                    "description": "unset until completed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/coach.CompletionResponse"
+                        }
+                    ]
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "dueOn": {
+                    "type": "string",
+                    "example": "2025-09-28"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4d2c6b1a-9e8f-4a7b-b6c5-d4e3f2a1b0c9"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Negative split the last 200m"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "overdue",
+                        "completed"
+                    ],
+                    "example": "completed"
+                },
+                "targetPace": {
+                    "type": "number",
+                    "example": 2.1
+                },
+                "trainingId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "trainingName": {
+                    "type": "string",
+                    "example": "Endurance 1500m"
+                }
+            }
+        },
+        "coach.AssignmentUserResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Dina Kusuma"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "coach.AthleteComplianceResponse": {
+            "type": "object",
+            "properties": {
+                "assigned": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "completed": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dina Kusuma"
+                },
+                "onTime": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "overdue": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "paceMet": {
+                    "description": "of those, swum at or under it",
+                    "type": "integer",
+                    "example": 3
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "rate": {
+                    "description": "percent completed on time of those no longer pending, unset without any",
+                    "type": "number",
+                    "example": 75
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                },
+                "withTargetPace": {
+                    "description": "completed with a target pace",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "coach.CompletionResponse": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string",
+                    "example": "2025-09-27T06:45:00Z"
+                },
+                "onTime": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "pace": {
+                    "type": "number",
+                    "example": 2.05
+                },
+                "paceMet": {
+                    "description": "unset without target pace",
+                    "type": "boolean",
+                    "example": true
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+                }
+            }
+        },
+        "coach.ComplianceResponse": {
+            "type": "object",
+            "properties": {
+                "athletes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/coach.AthleteComplianceResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-08-25"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-09-21"
+                }
+            }
+        },
+        "coach.GrantCoachRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "coach@swimo.id"
+                }
+            }
+        },
+        "coach.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "coach@swimo.id"
+                },
+                "grantedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dina Kusuma"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "consent.AcceptRequest": {
+            "type": "object",
+            "properties": {
+                "privacy": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "2026-10-01"
+                },
+                "terms": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "2026-10-01"
+                }
+            }
+        },
+        "consent.ConsentResponse": {
+            "type": "object",
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/consent.DocumentResponse"
+                    }
+                },
+                "marketing": {
+                    "$ref": "#/definitions/consent.MarketingResponse"
+                }
+            }
+        },
+        "consent.DocumentResponse": {
+            "type": "object",
+            "properties": {
+                "acceptedAt": {
+                    "type": "string",
+                    "example": "2026-04-02T07:30:00Z"
+                },
+                "acceptedVersion": {
+                    "type": "string",
+                    "example": "2026-04-01"
+                },
+                "currentVersion": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "document": {
+                    "type": "string",
+                    "enum": [
+                        "terms",
+                        "privacy"
+                    ],
+                    "example": "terms"
+                },
+                "pending": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "consent.MarketingRequest": {
+            "type": "object",
+            "required": [
+                "optedIn"
+            ],
+            "properties": {
+                "optedIn": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "consent.MarketingResponse": {
+            "type": "object",
+            "properties": {
+                "optedIn": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "optedInAt": {
+                    "type": "string",
+                    "example": "2026-04-02T07:30:00Z"
+                },
+                "optedOutAt": {
+                    "type": "string",
+                    "example": "2026-06-10T18:00:00Z"
+                }
+            }
+        },
+        "device.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                },
+                "lastSeenAt": {
+                    "type": "string",
+                    "example": "2025-09-22T06:10:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Apple Watch"
+                },
+                "platform": {
+                    "type": "string",
+                    "example": "watchos"
+                }
+            }
+        },
+        "device.PairDeviceRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "platform"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Apple Watch"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "watchos",
+                        "wearos",
+                        "garmin",
+                        "ios",
+                        "android"
+                    ],
+                    "example": "watchos"
+                }
+            }
+        },
+        "device.PairDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "$ref": "#/definitions/device.DeviceResponse"
+                },
+                "signingSecret": {
+                    "description": "keys the signature of batches, never sent",
+                    "type": "string",
+                    "example": "sws_Vb7Kp2Qx9Lm4Tn6Rw1Zc8Hd3Fj5Gs0Ya2Ue7Io4Pq"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "swd_q8G3n0Jx2yVt5Lk7Wm1Rb4Zc9Hs6Fd0Ep3Ua8Yi5Oo"
+                }
+            }
+        },
+        "equipment.EquipmentRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Arena"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "fins",
+                        "paddles",
+                        "wetsuit",
+                        "pull_buoy",
+                        "kickboard",
+                        "snorkel",
+                        "other"
+                    ],
+                    "example": "fins"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Blue training fins"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Short blade, size 40-41"
+                },
+                "retired": {
+                    "description": "Retired gear stays in the stats but is listed last",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "equipment.EquipmentResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Arena"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "fins"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Blue training fins"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Short blade, size 40-41"
+                },
+                "retired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "usage": {
+                    "description": "lifetime",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/equipment.UsageResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "equipment.EquipmentStatsResponse": {
+            "type": "object",
+            "properties": {
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/equipment.EquipmentUsageResponse"
+                    }
+                },
+                "kinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/equipment.KindUsageResponse"
+                    }
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
+        },
+        "equipment.EquipmentUsageResponse": {
+            "type": "object",
+            "properties": {
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 18000
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 21600
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "wetsuit"
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Summer wetsuit"
+                },
+                "retired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "equipment.KindUsageResponse": {
+            "type": "object",
+            "properties": {
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 18000
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 21600
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "wetsuit"
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "equipment.SessionEquipmentRequest": {
+            "type": "object",
+            "properties": {
+                "equipmentIds": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+                    ]
+                }
+            }
+        },
+        "equipment.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 18000
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 21600
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "event.TrackEventRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "anonymousId": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "6f1c2b9e-device"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "screen_viewed"
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "properties": {
+                    "type": "object"
+                }
+            }
+        },
+        "event.TrackEventsRequest": {
+            "type": "object",
+            "required": [
+                "events"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/event.TrackEventRequest"
+                    }
+                }
+            }
+        },
+        "injury.InjuryRequest": {
+            "type": "object",
+            "required": [
+                "injuredOn",
+                "severity",
+                "type"
+            ],
+            "properties": {
+                "injuredOn": {
+                    "description": "InjuredOn and ResolvedOn are dates, ex: 2025-09-21. Without ResolvedOn the injury is ongoing.",
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Pain on the recovery phase of freestyle"
+                },
+                "resolvedOn": {
+                    "type": "string",
+                    "example": "2025-09-21"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe"
+                    ],
+                    "example": "moderate"
+                },
+                "type": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Swimmer's shoulder"
+                }
+            }
+        },
+        "injury.InjuryResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-01T07:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e"
+                },
+                "injuredOn": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pain on the recovery phase of freestyle"
+                },
+                "ongoing": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "resolvedOn": {
+                    "type": "string",
+                    "example": "2025-09-21"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe"
+                    ],
+                    "example": "moderate"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Swimmer's shoulder"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                }
+            }
+        },
+        "pool.CheckInRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "pool_Zk3q9vX2mB7wT1aR"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": -6.2149
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 106.8016
+                }
+            }
+        },
+        "pool.CheckInResponse": {
+            "type": "object",
+            "properties": {
+                "checkedInAt": {
+                    "type": "string",
+                    "example": "2025-09-21T06:00:00Z"
+                },
+                "expiresAt": {
+                    "description": "sessions finished until then are swum at the pool",
+                    "type": "string",
+                    "example": "2025-09-21T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "qr",
+                        "geofence"
+                    ],
+                    "example": "qr"
+                },
+                "pool": {
+                    "$ref": "#/definitions/pool.PoolSummaryResponse"
+                }
+            }
+        },
+        "pool.LaneRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "capacity": {
+                    "description": "swimmers at most",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 6
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "Fast"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "closed",
+                        "reserved"
+                    ],
+                    "example": "open"
+                },
+                "swimmers": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 4
+                }
+            }
+        },
+        "pool.LaneResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "open and under capacity",
+                    "type": "boolean",
+                    "example": true
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Fast"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "closed",
+                        "reserved"
+                    ],
+                    "example": "open"
+                },
+                "swimmers": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T06:45:00Z"
+                }
+            }
+        },
+        "pool.LaneUpdateRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "closed",
+                        "reserved"
+                    ],
+                    "example": "open"
+                },
+                "swimmers": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 5
+                }
+            }
+        },
+        "pool.LanesRequest": {
+            "type": "object",
+            "required": [
+                "lanes"
+            ],
+            "properties": {
+                "lanes": {
+                    "description": "lanes[0] is lane 1",
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "$ref": "#/definitions/pool.LaneRequest"
+                    }
+                }
+            }
+        },
+        "pool.MyPoolResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Pintu Satu Senayan, Jakarta"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 48000
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 64800
+                },
+                "lastSwumAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "pool": {
+                    "$ref": "#/definitions/pool.PoolSummaryResponse"
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 24
+                }
+            }
+        },
+        "pool.OccupancyResponse": {
+            "type": "object",
+            "properties": {
+                "availableLanes": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "lanes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pool.LaneResponse"
+                    }
+                },
+                "pool": {
+                    "$ref": "#/definitions/pool.PoolSummaryResponse"
+                },
+                "swimmers": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "updatedAt": {
+                    "description": "last lane update",
+                    "type": "string",
+                    "example": "2025-09-21T06:45:00Z"
+                }
+            }
+        },
+        "pool.PoolRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "radiusMeters"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 300,
+                    "example": "Jl. Pintu Satu Senayan, Jakarta"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": -6.2146
+                },
+                "lengthMeters": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 10,
+                    "example": 50
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 106.8019
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Senayan Aquatic Center"
+                },
+                "radiusMeters": {
+                    "description": "geofence",
+                    "type": "integer",
+                    "maximum": 2000,
+                    "minimum": 20,
+                    "example": 150
+                }
+            }
+        },
+        "pool.PoolResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Pintu Satu Senayan, Jakarta"
+                },
+                "checkInCode": {
+                    "description": "to encode in the QR posted at the pool",
+                    "type": "string",
+                    "example": "pool_Zk3q9vX2mB7wT1aR"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-01T07:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": -6.2146
+                },
+                "lengthMeters": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8019
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Senayan Aquatic Center"
+                },
+                "radiusMeters": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-01T07:30:00Z"
+                }
+            }
+        },
+        "pool.PoolSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b"
+                },
+                "lengthMeters": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Senayan Aquatic Center"
+                }
+            }
+        },
+        "race.EntryResponse": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 6120
+                },
+                "finished": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "finishedAt": {
+                    "type": "string",
+                    "example": "2026-01-03T08:15:00Z"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "registeredAt": {
+                    "type": "string",
+                    "example": "2025-12-20T09:00:00Z"
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                }
+            }
+        },
+        "race.FinisherResponse": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 4210
+                },
+                "finishedAt": {
+                    "type": "string",
+                    "example": "2026-01-02T07:40:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dina"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "race.RaceRequest": {
+            "type": "object",
+            "required": [
+                "distanceMeters",
+                "endsAt",
+                "name",
+                "startsAt"
+            ],
+            "properties": {
+                "cutoffSeconds": {
+                    "description": "slowest accepted time",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 60,
+                    "example": 10800
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Swim 5 km anywhere during the first week of the year"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "maximum": 50000,
+                    "minimum": 25,
+                    "example": 5000
+                },
+                "endsAt": {
+                    "type": "string",
+                    "example": "2026-01-08T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "5k New Year Swim"
+                },
+                "startsAt": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                }
+            }
+        },
+        "race.RaceResponse": {
+            "type": "object",
+            "properties": {
+                "cutoffSeconds": {
+                    "type": "integer",
+                    "example": 10800
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Swim 5 km anywhere during the first week of the year"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 5000
+                },
+                "endsAt": {
+                    "type": "string",
+                    "example": "2026-01-08T00:00:00Z"
+                },
+                "entries": {
+                    "type": "integer",
+                    "example": 128
+                },
+                "entry": {
+                    "description": "of the signed in swimmer, when registered",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/race.EntryResponse"
+                        }
+                    ]
+                },
+                "finishers": {
+                    "type": "integer",
+                    "example": 57
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "5k New Year Swim"
+                },
+                "startsAt": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "upcoming",
+                        "open",
+                        "closed"
+                    ],
+                    "example": "open"
+                }
+            }
+        },
+        "race.ResultRequest": {
+            "type": "object",
+            "required": [
+                "sessionId"
+            ],
+            "properties": {
+                "sessionId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                }
+            }
+        },
+        "response.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "VALIDATION_FAILED"
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Validation errors"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "3f2a9c1e8b7d4c6a9e0f1b2c3d4e5f60"
+                }
+            }
+        },
+        "response.FacetValue": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Breaststroke"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "BREASTSTROKE"
+                }
+            }
+        },
+        "response.Facets": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "array",
+                "items": {
+                    "$ref": "#/definitions/response.FacetValue"
+                }
+            }
+        },
+        "response.Message": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Sign out successfully"
+                }
+            }
+        },
+        "response.Meta": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/response.Facets"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.Pagination"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "3f2a9c1e8b7d4c6a9e0f1b2c3d4e5f60"
+                }
+            }
+        },
+        "response.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "totalEstimated": {
+                    "description": "totals are approximate on large lists",
+                    "type": "boolean",
+                    "example": false
+                },
+                "totalItems": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "response.Success": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "meta": {
+                    "$ref": "#/definitions/response.Meta"
+                }
+            }
+        },
+        "stats.HeartRateSessionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "seconds": {
+                    "description": "time of the laps with a heart rate",
+                    "type": "integer",
+                    "example": 1800
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.HeartRateZoneTimeResponse"
+                    }
+                }
+            }
+        },
+        "stats.HeartRateZoneResponse": {
+            "type": "object",
+            "properties": {
+                "maxBpm": {
+                    "type": "integer",
+                    "example": 131
+                },
+                "minBpm": {
+                    "type": "integer",
+                    "example": 113
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Endurance"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 42.5
+                },
+                "seconds": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "zone": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "stats.HeartRateZoneTimeResponse": {
+            "type": "object",
+            "properties": {
+                "percent": {
+                    "type": "number",
+                    "example": 33.3
+                },
+                "seconds": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "zone": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "stats.HeartRateZonesResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-08-21T07:30:00Z"
+                },
+                "injuries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.InjuryPeriodResponse"
+                    }
+                },
+                "maxHeartRate": {
+                    "type": "integer",
+                    "example": 188
+                },
+                "maxHeartRateSource": {
+                    "type": "string",
+                    "enum": [
+                        "preferences",
+                        "age"
+                    ],
+                    "example": "age"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "month"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.HeartRateSessionResponse"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.HeartRateZoneResponse"
+                    }
+                }
+            }
+        },
+        "stats.InjuryPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e"
+                },
+                "injuredOn": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "resolvedOn": {
+                    "type": "string",
+                    "example": "2025-09-21"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe"
+                    ],
+                    "example": "moderate"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Swimmer's shoulder"
+                }
+            }
+        },
+        "stats.OpenWaterMonthResponse": {
+            "type": "object",
+            "properties": {
+                "avgWaterTemperatureC": {
+                    "type": "number",
+                    "example": 24.8
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 9000
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 12600
+                },
+                "maxWaterTemperatureC": {
                     "type": "number",
-                    "example": 180
+                    "example": 27
+                },
+                "minWaterTemperatureC": {
+                    "type": "number",
+                    "example": 21.5
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2025-07"
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "wetsuitSessions": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "stats.OpenWaterStatsResponse": {
+            "type": "object",
+            "properties": {
+                "avgWaterTemperatureC": {
+                    "type": "number",
+                    "example": 24.8
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 9000
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 12600
+                },
+                "injuries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.InjuryPeriodResponse"
+                    }
+                },
+                "maxWaterTemperatureC": {
+                    "type": "number",
+                    "example": 27
+                },
+                "minWaterTemperatureC": {
+                    "type": "number",
+                    "example": 21.5
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.OpenWaterMonthResponse"
+                    }
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "wetsuitSessions": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
+        },
+        "stats.TrainingLoadDayResponse": {
+            "type": "object",
+            "properties": {
+                "acute": {
+                    "type": "number",
+                    "example": 142.6
+                },
+                "chronic": {
+                    "type": "number",
+                    "example": 98.3
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-21"
+                },
+                "load": {
+                    "type": "number",
+                    "example": 270
+                },
+                "ratio": {
+                    "type": "number",
+                    "example": 1.45
+                },
+                "sessions": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "stats.TrainingLoadResponse": {
+            "type": "object",
+            "properties": {
+                "acute": {
+                    "type": "number",
+                    "example": 142.6
+                },
+                "atRisk": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "chronic": {
+                    "type": "number",
+                    "example": 98.3
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.TrainingLoadDayResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-08-11"
+                },
+                "injuries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.InjuryPeriodResponse"
+                    }
+                },
+                "ratio": {
+                    "description": "unset without chronic load",
+                    "type": "number",
+                    "example": 1.45
+                },
+                "riskThreshold": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-09-21"
+                }
+            }
+        },
+        "training.TrainingAggregatesResponse": {
+            "type": "object",
+            "properties": {
+                "completions": {
+                    "description": "sessions of the user on the training",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "training.TrainingApproveRequest": {
+            "type": "object",
+            "properties": {
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "training.TrainingConditionsRequest": {
+            "type": "object",
+            "properties": {
+                "currentNotes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Pulling north along the beach"
+                },
+                "currentSpeedKmh": {
+                    "type": "number",
+                    "maximum": 20,
+                    "minimum": 0,
+                    "example": 1.2
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": -8.7203
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 115.1689
+                },
+                "waterTemperatureC": {
+                    "type": "number",
+                    "maximum": 40,
+                    "minimum": -2,
+                    "example": 24.5
+                },
+                "waveHeightMeters": {
+                    "type": "number",
+                    "maximum": 20,
+                    "minimum": 0,
+                    "example": 0.6
+                },
+                "waveNotes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Choppy after the buoy"
+                },
+                "wetsuit": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "training.TrainingConditionsResponse": {
+            "type": "object",
+            "properties": {
+                "autoFilled": {
+                    "description": "a measurement comes from the weather provider",
+                    "type": "boolean",
+                    "example": true
+                },
+                "currentNotes": {
+                    "type": "string",
+                    "example": "Pulling north along the beach"
+                },
+                "currentSpeedKmh": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": -8.7203
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 115.1689
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T08:05:00Z"
+                },
+                "waterTemperatureC": {
+                    "type": "number",
+                    "example": 24.5
+                },
+                "waveHeightMeters": {
+                    "type": "number",
+                    "example": 0.6
+                },
+                "waveNotes": {
+                    "type": "string",
+                    "example": "Choppy after the buoy"
+                },
+                "wetsuit": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "training.TrainingDeletedSessionResponse": {
+            "type": "object",
+            "properties": {
+                "caloriesKcal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "conditions": {
+                    "$ref": "#/definitions/training.TrainingConditionsResponse"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "example": "2025-09-23T10:15:00Z"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 1800
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "laps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapResponse"
+                    }
+                },
+                "pace": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "rpe": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "trainingId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+                }
+            }
+        },
+        "training.TrainingDuplicateResponse": {
+            "type": "object",
+            "properties": {
+                "detectedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T09:00:00Z"
+                },
+                "duplicateOf": {
+                    "$ref": "#/definitions/training.TrainingSessionDetailResponse"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a"
+                },
+                "overlapSeconds": {
+                    "type": "integer",
+                    "example": 1740
+                },
+                "session": {
+                    "$ref": "#/definitions/training.TrainingSessionDetailResponse"
+                }
+            }
+        },
+        "training.TrainingExportFileResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:45:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://swimo-files.s3.amazonaws.com/exports/a1b2c3d4-e5f6-7890-1234-567890abcdef/sessions-20250921T073000Z.ndjson?X-Amz-Signature=..."
+                }
+            }
+        },
+        "training.TrainingFinishSessionRequest": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "$ref": "#/definitions/training.TrainingConditionsRequest"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "laps": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapRequest"
+                    }
+                },
+                "override": {
+                    "description": "records a session failing the plausibility checks, admins only",
+                    "type": "boolean",
+                    "example": false
+                },
+                "poolLengthMeters": {
+                    "description": "the distance must be a whole number of lengths",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 10,
+                    "example": 25
+                },
+                "rpe": {
+                    "description": "rate of perceived exertion",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 6
+                }
+            }
+        },
+        "training.TrainingImportSessionRequest": {
+            "type": "object",
+            "required": [
+                "startedAt",
+                "trainingId"
+            ],
+            "properties": {
+                "conditions": {
+                    "$ref": "#/definitions/training.TrainingConditionsRequest"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 1800
+                },
+                "laps": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapRequest"
+                    }
+                },
+                "rpe": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 6
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "trainingId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                }
+            }
+        },
+        "training.TrainingImportSessionsRequest": {
+            "type": "object",
+            "required": [
+                "sessions"
+            ],
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingImportSessionRequest"
+                    }
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "watch",
+                        "google_fit",
+                        "apple_health"
+                    ],
+                    "example": "watch"
+                }
+            }
+        },
+        "training.TrainingImportSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "possibleDuplicates": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingSessionResponse"
+                    }
+                }
+            }
+        },
+        "training.TrainingItemResponse": {
+            "type": "object",
+            "properties": {
+                "descriptions": {
+                    "type": "string",
+                    "example": "Short description about this training"
                 },
-                "weight": {
-                    "type": "number",
-                    "example": 75.5
+                "id": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "level": {
+                    "type": "string",
+                    "example": "beginner"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Breaststroke Basics"
+                },
+                "thumbnailUrl": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/thumbs/breaststroke.png"
                 }
             }
         },
-        "auth.SignInGuestResponse": {
+        "training.TrainingLapRequest": {
             "type": "object",
             "properties": {
-                "age": {
+                "avgHeartRate": {
+                    "type": "integer",
+                    "maximum": 250,
+                    "minimum": 30,
+                    "example": 142
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "durationSeconds": {
                     "type": "integer",
                     "example": 30
                 },
-                "expiresIn": {
+                "strokeCount": {
                     "type": "integer",
-                    "example": 1799999
+                    "minimum": 0,
+                    "example": 18
+                }
+            }
+        },
+        "training.TrainingLapResponse": {
+            "type": "object",
+            "properties": {
+                "avgHeartRate": {
+                    "type": "integer",
+                    "example": 142
                 },
-                "gender": {
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "strokeCount": {
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "training.TrainingMergeDuplicateRequest": {
+            "type": "object",
+            "properties": {
+                "keepId": {
                     "type": "string",
-                    "example": "male"
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                }
+            }
+        },
+        "training.TrainingRejectRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "The video does not match the description"
                 },
-                "height": {
-                    "type": "number",
-                    "example": 180
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "training.TrainingRequest": {
+            "type": "object",
+            "required": [
+                "categoryCode",
+                "content",
+                "descriptions",
+                "level",
+                "name",
+                "thumbnailUrl",
+                "time"
+            ],
+            "properties": {
+                "caloriesKcal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "categoryCode": {
+                    "type": "string",
+                    "example": "BREASTSTROKE"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "\u003cp\u003eHTML content here\u003c/p\u003e"
+                },
+                "descriptions": {
+                    "type": "string",
+                    "example": "Dasar gaya dada untuk pemula"
+                },
+                "level": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "beginner"
                 },
                 "name": {
                     "type": "string",
-                    "example": "John Doe"
+                    "maxLength": 100,
+                    "example": "Breaststroke Basics"
                 },
-                "refreshToken": {
+                "thumbnailUrl": {
                     "type": "string",
-                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
+                    "example": "https://cdn.example.com/thumbs/breaststroke.png"
                 },
-                "token": {
+                "time": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "10-15 min"
                 },
-                "weight": {
-                    "type": "number",
-                    "example": 75.5
+                "videoUrl": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/videos/breaststroke.mp4"
                 }
             }
         },
-        "auth.SignInRequest": {
+        "training.TrainingResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "aggregates": {
+                    "$ref": "#/definitions/training.TrainingAggregatesResponse"
+                },
+                "caloriesKcal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "categoryCode": {
                     "type": "string",
-                    "example": "john@example.com"
+                    "example": "BREASTSTROKE"
                 },
-                "password": {
+                "categoryName": {
                     "type": "string",
-                    "example": "SecurePassword123"
+                    "example": "Breaststroke"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "\u003cp\u003eHTML content here\u003c/p\u003e"
+                },
+                "descriptions": {
+                    "type": "string",
+                    "example": "Short description about this training"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "level": {
+                    "type": "string",
+                    "example": "beginner"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Breaststroke Basics"
+                },
+                "reviewReason": {
+                    "type": "string",
+                    "example": "The video does not match the description"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending_review",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                },
+                "thumbnailUrl": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/thumbs/breaststroke.png"
+                },
+                "timeLabel": {
+                    "type": "string",
+                    "example": "10-15 min"
+                },
+                "version": {
+                    "description": "sent back by edits, a training edited since is refused",
+                    "type": "integer",
+                    "example": 3
+                },
+                "videoUrl": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/videos/breaststroke.mp4"
                 }
             }
         },
-        "auth.SignInResponse": {
+        "training.TrainingReviewResponse": {
             "type": "object",
             "properties": {
-                "age": {
+                "authorId": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+                },
+                "categoryCode": {
+                    "type": "string",
+                    "example": "BREASTSTROKE"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "example": "2025-09-23T10:15:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "level": {
+                    "type": "string",
+                    "example": "beginner"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Breaststroke Basics"
+                },
+                "reviewReason": {
+                    "type": "string",
+                    "example": "The video does not match the description"
+                },
+                "reviewedAt": {
+                    "type": "string",
+                    "example": "2025-09-22T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending_review",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "pending_review"
+                },
+                "version": {
                     "type": "integer",
-                    "example": 30
+                    "example": 2
+                }
+            }
+        },
+        "training.TrainingSessionDetailResponse": {
+            "type": "object",
+            "properties": {
+                "caloriesKcal": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "email": {
+                "conditions": {
+                    "$ref": "#/definitions/training.TrainingConditionsResponse"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 1800
+                },
+                "id": {
                     "type": "string",
-                    "example": "john@example.com"
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
                 },
-                "expiresIn": {
+                "laps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapResponse"
+                    }
+                },
+                "pace": {
+                    "type": "number",
+                    "example": 1.2
+                },
+                "rpe": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "manual",
+                        "import",
+                        "watch",
+                        "google_fit",
+                        "apple_health",
+                        "sync"
+                    ],
+                    "example": "watch"
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "trainingId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+                }
+            }
+        },
+        "training.TrainingSessionExportResponse": {
+            "type": "object",
+            "properties": {
+                "caloriesKcal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "conditions": {
+                    "$ref": "#/definitions/training.TrainingConditionsResponse"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "durationSeconds": {
                     "type": "integer",
-                    "example": 1799999
+                    "example": 1800
                 },
-                "gender": {
+                "id": {
                     "type": "string",
-                    "example": "male"
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
                 },
-                "height": {
+                "laps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapResponse"
+                    }
+                },
+                "pace": {
                     "type": "number",
-                    "example": 180
+                    "example": 1.2
                 },
-                "name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "rpe": {
+                    "type": "integer",
+                    "example": 6
                 },
-                "refreshToken": {
+                "trainingId": {
                     "type": "string",
-                    "example": "3d3dc788634e05b7d1d5fac06834d3b6a9b62..."
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
                 },
-                "token": {
+                "userId": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "weight": {
-                    "type": "number",
-                    "example": 75.5
+                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
                 }
             }
         },
-        "auth.SignUpRequest": {
+        "training.TrainingSessionResponse": {
             "type": "object",
             "properties": {
-                "age": {
+                "caloriesKcal": {
                     "type": "integer",
-                    "example": 30
+                    "example": 120
                 },
-                "confirmPassword": {
-                    "type": "string",
-                    "example": "SecurePassword123"
+                "conditions": {
+                    "$ref": "#/definitions/training.TrainingConditionsResponse"
                 },
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
+                "distanceMeters": {
+                    "type": "integer",
+                    "example": 1500
                 },
-                "gender": {
+                "durationSeconds": {
+                    "type": "integer",
+                    "example": 1800
+                },
+                "id": {
                     "type": "string",
-                    "example": "male"
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
                 },
-                "height": {
+                "laps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapResponse"
+                    }
+                },
+                "pace": {
                     "type": "number",
-                    "example": 180
+                    "example": 1.2
                 },
-                "name": {
+                "poolId": {
+                    "description": "pool the swimmer was checked in at",
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b"
                 },
-                "password": {
+                "rpe": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "trainingId": {
                     "type": "string",
-                    "example": "SecurePassword123"
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
                 },
-                "weight": {
-                    "type": "number",
-                    "example": 75.5
+                "userId": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
                 }
             }
         },
-        "response.Error": {
+        "training.TrainingSyncResult": {
             "type": "object",
             "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "trainingId"
+                    ]
+                },
                 "errors": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "response.Message": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+                "session": {
+                    "$ref": "#/definitions/training.TrainingSessionResponse"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "unchanged",
+                        "rejected"
+                    ],
+                    "example": "updated"
                 }
             }
         },
-        "response.Pagination": {
+        "training.TrainingSyncSessionRequest": {
             "type": "object",
+            "required": [
+                "clientId",
+                "startedAt",
+                "trainingId",
+                "updatedAt"
+            ],
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "example": 10
+                "clientId": {
+                    "type": "string",
+                    "example": "0f8e7d6c-5b4a-4392-8a1b-2c3d4e5f6a7b"
                 },
-                "page": {
+                "distanceMeters": {
                     "type": "integer",
-                    "example": 1
+                    "example": 1500
                 },
-                "totalPages": {
+                "durationSeconds": {
                     "type": "integer",
-                    "example": 5
+                    "example": 1800
+                },
+                "laps": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingLapRequest"
+                    }
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T07:30:00Z"
+                },
+                "trainingId": {
+                    "type": "string",
+                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-09-21T08:05:00Z"
                 }
             }
         },
-        "response.Success": {
-            "type": "object",
-            "properties": {
-                "data": {}
-            }
-        },
-        "response.SuccessPagination": {
+        "training.TrainingSyncSessionsRequest": {
             "type": "object",
             "properties": {
-                "data": {},
-                "pagination": {
-                    "$ref": "#/definitions/response.Pagination"
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/training.TrainingSyncSessionRequest"
+                    }
                 }
             }
         },
-        "training.TrainingFinishSessionRequest": {
+        "training.TrainingSyncSessionsResponse": {
             "type": "object",
             "properties": {
-                "distanceMeters": {
+                "possibleDuplicates": {
                     "type": "integer",
-                    "example": 300
+                    "example": 0
                 },
-                "durationSeconds": {
-                    "type": "integer",
-                    "example": 50
+                "results": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/training.TrainingSyncResult"
+                    }
                 }
             }
         },
-        "training.TrainingItemResponse": {
+        "usage.AccountUsageResponse": {
             "type": "object",
             "properties": {
-                "descriptions": {
-                    "type": "string",
-                    "example": "Short description about this training"
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usage.UsageDayResponse"
+                    }
                 },
-                "id": {
+                "from": {
                     "type": "string",
-                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                    "example": "2025-08-23"
                 },
-                "level": {
-                    "type": "string",
-                    "example": "beginner"
+                "quota": {
+                    "$ref": "#/definitions/usage.QuotaResponse"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Breaststroke Basics"
+                "requests": {
+                    "type": "integer",
+                    "example": 5120
                 },
-                "thumbnailUrl": {
+                "to": {
                     "type": "string",
-                    "example": "https://cdn.example.com/thumbs/breaststroke.png"
+                    "example": "2025-09-21"
                 }
             }
         },
-        "training.TrainingRequest": {
+        "usage.QuotaResponse": {
             "type": "object",
             "properties": {
-                "caloriesKcal": {
+                "limit": {
                     "type": "integer",
-                    "example": 120
-                },
-                "categoryCode": {
-                    "type": "string",
-                    "example": "BREASTSTROKE"
+                    "example": 100000
                 },
-                "content": {
-                    "type": "string",
-                    "example": "\u003cp\u003eHTML content here\u003c/p\u003e"
+                "remaining": {
+                    "type": "integer",
+                    "example": 95800
                 },
-                "descriptions": {
+                "resetsAt": {
                     "type": "string",
-                    "example": "Dasar gaya dada untuk pemula"
+                    "example": "2025-10-01T00:00:00Z"
                 },
-                "level": {
+                "used": {
+                    "type": "integer",
+                    "example": 4200
+                }
+            }
+        },
+        "usage.UsageClientResponse": {
+            "type": "object",
+            "properties": {
+                "deviceId": {
                     "type": "string",
-                    "example": "beginner"
+                    "example": "3f0c9a52-7d4e-4b8a-9c1f-2e6d5a4b3c21"
                 },
-                "name": {
+                "kind": {
                     "type": "string",
-                    "example": "Breaststroke Basics"
+                    "enum": [
+                        "app",
+                        "device"
+                    ],
+                    "example": "device"
                 },
-                "thumbnailUrl": {
-                    "type": "string",
-                    "example": "https://cdn.example.com/thumbs/breaststroke.png"
+                "requests": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "usage.UsageDayResponse": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usage.UsageClientResponse"
+                    }
                 },
-                "time": {
+                "date": {
                     "type": "string",
-                    "example": "10-15 min"
+                    "example": "2025-09-21"
                 },
-                "videoUrl": {
+                "requests": {
+                    "type": "integer",
+                    "example": 180
+                }
+            }
+        },
+        "user.AvatarResponse": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
                     "type": "string",
-                    "example": "https://cdn.example.com/videos/breaststroke.mp4"
+                    "example": "https://api.swimo.id/api/v1/media/avatars/a1b2c3d4-e5f6-7890-1234-567890abcdef/3f2a9c1e8b7d4c6a.jpg"
                 }
             }
         },
-        "training.TrainingResponse": {
+        "user.PreferencesRequest": {
             "type": "object",
+            "required": [
+                "timezone"
+            ],
             "properties": {
-                "caloriesKcal": {
+                "maxHeartRate": {
                     "type": "integer",
-                    "example": 120
-                },
-                "categoryCode": {
-                    "type": "string",
-                    "example": "BREASTSTROKE"
-                },
-                "categoryName": {
-                    "type": "string",
-                    "example": "Breaststroke"
-                },
-                "content": {
-                    "type": "string",
-                    "example": "\u003cp\u003eHTML content here\u003c/p\u003e"
-                },
-                "descriptions": {
-                    "type": "string",
-                    "example": "Short description about this training"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
-                },
-                "level": {
-                    "type": "string",
-                    "example": "beginner"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Breaststroke Basics"
+                    "maximum": 230,
+                    "minimum": 100,
+                    "example": 188
                 },
-                "thumbnailUrl": {
+                "timezone": {
                     "type": "string",
-                    "example": "https://cdn.example.com/thumbs/breaststroke.png"
+                    "maxLength": 64,
+                    "example": "Asia/Jakarta"
                 },
-                "timeLabel": {
-                    "type": "string",
-                    "example": "10-15 min"
+                "version": {
+                    "description": "version read, preferences edited since are refused",
+                    "type": "integer",
+                    "example": 2
                 },
-                "videoUrl": {
-                    "type": "string",
-                    "example": "https://cdn.example.com/videos/breaststroke.mp4"
+                "weeklyDigest": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "training.TrainingSessionResponse": {
+        "user.PreferencesResponse": {
             "type": "object",
             "properties": {
-                "caloriesKcal": {
-                    "type": "integer",
-                    "example": 120
-                },
-                "distanceMeters": {
-                    "type": "integer",
-                    "example": 1500
-                },
-                "durationSeconds": {
+                "maxHeartRate": {
                     "type": "integer",
-                    "example": 1800
+                    "example": 188
                 },
-                "id": {
+                "timezone": {
                     "type": "string",
-                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
-                },
-                "pace": {
-                    "type": "number",
-                    "example": 1.2
+                    "example": "Asia/Jakarta"
                 },
-                "trainingId": {
-                    "type": "string",
-                    "example": "8c4a2d27-56e2-4ef3-8a6e-43b812345abc"
+                "version": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "userId": {
-                    "type": "string",
-                    "example": "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+                "weeklyDigest": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         }
@@ -1034,6 +10083,12 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "DeviceToken": {
+            "description": "Type \"Bearer\" followed by a space and the token returned when pairing the device.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     },
     "externalDocs": {
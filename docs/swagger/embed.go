@@ -0,0 +1,8 @@
+package swagger
+
+import _ "embed"
+
+// JSON is the generated swagger 2.0 document, embedded so the binary serves it from any directory
+//
+//go:embed swagger.json
+var JSON []byte
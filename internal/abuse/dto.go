@@ -0,0 +1,50 @@
+package abuse
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+)
+
+type FlagsQuery struct {
+	pagination.Params
+	Reason string `query:"reason"`
+}
+
+// flagSorts whitelists the sortable flagged guest list columns
+var flagSorts = pagination.SortSpec{
+	Columns: map[string]string{
+		"updated_at":  "updated_at",
+		"occurrences": "occurrences",
+	},
+	Default:    "updated_at.desc",
+	TieBreaker: "id",
+}
+
+type GuestFlagResponse struct {
+	ID             string         `json:"id" example:"6e5d4c3b-2a19-4f08-9e7d-6c5b4a392817"`
+	Fingerprint    string         `json:"fingerprint" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	Reason         string         `json:"reason" example:"many_sessions" enums:"many_sessions,implausible_session"`
+	Occurrences    int            `json:"occurrences" example:"3"`
+	UserAgent      *string        `json:"userAgent,omitempty" example:"okhttp/4.12.0"`
+	Details        map[string]any `json:"details,omitempty"`
+	ThrottledUntil time.Time      `json:"throttledUntil" example:"2025-09-22T07:30:00Z"`
+	Throttled      bool           `json:"throttled" example:"true"`
+	CreatedAt      time.Time      `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+	UpdatedAt      time.Time      `json:"updatedAt" example:"2025-09-21T08:10:00Z"`
+}
+
+func newGuestFlagResponse(f *GuestFlag, now time.Time) GuestFlagResponse {
+	return GuestFlagResponse{
+		ID:             f.ID,
+		Fingerprint:    f.Fingerprint,
+		Reason:         f.Reason,
+		Occurrences:    f.Occurrences,
+		UserAgent:      f.UserAgent,
+		Details:        f.Details,
+		ThrottledUntil: f.ThrottledUntil,
+		Throttled:      f.ThrottledUntil.After(now),
+		CreatedAt:      f.CreatedAt,
+		UpdatedAt:      f.UpdatedAt,
+	}
+}
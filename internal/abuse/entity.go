@@ -0,0 +1,39 @@
+package abuse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSignUpRequired = errors.New("sign up required")
+	ErrFlagNotFound   = errors.New("guest flag not found")
+)
+
+// Flag reasons
+const (
+	ReasonManySessions       = "many_sessions"       // too many guest sessions from one fingerprint
+	ReasonImplausibleSession = "implausible_session" // a guest reported a swim nobody can swim
+)
+
+// GuestFlag is a guest fingerprint caught by a heuristic. While open and throttled, the
+// fingerprint cannot sign in or refresh as a guest and has to sign up.
+type GuestFlag struct {
+	ID             string
+	Fingerprint    string
+	Reason         string
+	Occurrences    int
+	UserAgent      *string
+	Details        map[string]any
+	ThrottledUntil time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Fingerprint identifies the client of a guest session without storing its IP
+func Fingerprint(ip, userAgent string) string {
+	sum := sha256.Sum256([]byte(ip + "|" + userAgent))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,86 @@
+package abuse
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type AbuseHandler struct {
+	abuseUsecase AbuseUsecase
+}
+
+func NewAbuseHandler(abuseUsecase AbuseUsecase) *AbuseHandler {
+	return &AbuseHandler{abuseUsecase}
+}
+
+// ListFlags handles the flagged guests view
+// @Summary List flagged guests
+// @Description List the open flags of guest clients caught by the abuse heuristics: too many guest sessions from one fingerprint in an hour, or a reported swim nobody can swim. A throttled fingerprint must sign up until throttledUntil. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param reason query string false "Flag reason" Enums(many_sessions,implausible_session)
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Number of items per page" default(10) minimum(1) maximum(100)
+// @Param sort query string false "Sort field and direction" Enums(updated_at.asc,updated_at.desc,occurrences.asc,occurrences.desc) default(updated_at.desc)
+// @Success 200 {object} response.Success{data=[]GuestFlagResponse} "Flagged guests retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/guests/flagged [get]
+func (h *AbuseHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	params, verr := pagination.Parse(r.URL.Query(), pagination.Options{Sorts: flagSorts})
+	if verr != nil {
+		response.ValidationError(w, verr.Errors)
+		return
+	}
+
+	query := FlagsQuery{Params: params, Reason: r.URL.Query().Get("reason")}
+	switch query.Reason {
+	case "", ReasonManySessions, ReasonImplausibleSession:
+	default:
+		response.ValidationError(w, map[string]string{"reason": "Reason must be one of: many_sessions, implausible_session"})
+		return
+	}
+
+	flags, total, err := h.abuseUsecase.ListFlags(r.Context(), &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Paginated(w, http.StatusOK, flags, query.Response(total))
+}
+
+// ClearFlag handles lifting a guest flag
+// @Summary Clear a guest flag
+// @Description Close a flag raised by mistake, its fingerprint may sign in as a guest again unless another open flag still throttles it. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Flag ID" example("6e5d4c3b-2a19-4f08-9e7d-6c5b4a392817")
+// @Success 200 {object} response.Success{data=response.Message} "Guest flag cleared"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Guest flag not found"
+// @Failure 422 {object} response.Error "Invalid flag ID"
+// @Security ApiKeyAuth
+// @Router /admin/guests/flagged/{id} [delete]
+func (h *AbuseHandler) ClearFlag(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if err := h.abuseUsecase.ClearFlag(ctx, *claim.Aid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Guest flag cleared"})
+}
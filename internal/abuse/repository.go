@@ -0,0 +1,140 @@
+package abuse
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+type AbuseRepository interface {
+	// CountGuestSessions counts the guest sessions opened by the fingerprint since the time
+	CountGuestSessions(ctx context.Context, fingerprint string, since time.Time) (int, error)
+	// GetSessionFingerprint returns the fingerprint of a guest session, empty when unknown
+	GetSessionFingerprint(ctx context.Context, sessionID string) (string, error)
+	// IsThrottled reports whether an open flag of the fingerprint is throttled at now
+	IsThrottled(ctx context.Context, fingerprint string, now time.Time) (bool, error)
+	// Flag opens a flag in the tenant of ctx, or bumps the open one of the same fingerprint and reason
+	Flag(ctx context.Context, flag *GuestFlag) error
+	// ListFlags returns a page of the open flags of the tenant
+	ListFlags(ctx context.Context, query *FlagsQuery) ([]*GuestFlag, pagination.Total, error)
+	// Clear closes an open flag, ErrFlagNotFound when none
+	Clear(ctx context.Context, id, clearedBy string) error
+}
+
+type abuseRepository struct {
+	db database.DBTX
+}
+
+func NewAbuseRepositry(db database.DBTX) AbuseRepository {
+	return &abuseRepository{db}
+}
+
+func (r *abuseRepository) CountGuestSessions(ctx context.Context, fingerprint string, since time.Time) (count int, err error) {
+	const q = `
+		SELECT COUNT(*) FROM sessions
+		WHERE kind = 'guest'
+			AND fingerprint = $1
+			AND created_at >= $2`
+
+	err = r.db.QueryRow(ctx, q, fingerprint, since).Scan(&count)
+
+	return count, err
+}
+
+func (r *abuseRepository) GetSessionFingerprint(ctx context.Context, sessionID string) (fingerprint string, err error) {
+	const q = `SELECT COALESCE(fingerprint, '') FROM sessions WHERE id = $1 AND kind = 'guest'`
+
+	err = r.db.QueryRow(ctx, q, sessionID).Scan(&fingerprint)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return fingerprint, err
+}
+
+func (r *abuseRepository) IsThrottled(ctx context.Context, fingerprint string, now time.Time) (throttled bool, err error) {
+	// A client is one client whatever the tenant it signs in to
+	const q = `
+		SELECT EXISTS (
+			SELECT 1 FROM guest_flags
+			WHERE fingerprint = $1
+				AND cleared_at IS NULL
+				AND throttled_until > $2
+		)`
+
+	err = r.db.QueryRow(ctx, q, fingerprint, now).Scan(&throttled)
+
+	return throttled, err
+}
+
+func (r *abuseRepository) Flag(ctx context.Context, flag *GuestFlag) error {
+	const q = `
+		INSERT INTO guest_flags (organization_id, fingerprint, reason, user_agent, details, throttled_until)
+		VALUES ($1, $2, $3, $4, COALESCE($5, '{}'::jsonb), $6)
+		ON CONFLICT (fingerprint, reason) WHERE cleared_at IS NULL DO UPDATE SET
+			occurrences = guest_flags.occurrences + 1,
+			user_agent = COALESCE(EXCLUDED.user_agent, guest_flags.user_agent),
+			details = EXCLUDED.details,
+			throttled_until = GREATEST(guest_flags.throttled_until, EXCLUDED.throttled_until),
+			updated_at = now()
+		RETURNING id, occurrences, throttled_until, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, q, tenant.ID(ctx), flag.Fingerprint, flag.Reason, flag.UserAgent, flag.Details, flag.ThrottledUntil).
+		Scan(&flag.ID, &flag.Occurrences, &flag.ThrottledUntil, &flag.CreatedAt, &flag.UpdatedAt)
+}
+
+func (r *abuseRepository) ListFlags(ctx context.Context, query *FlagsQuery) ([]*GuestFlag, pagination.Total, error) {
+	var (
+		total pagination.Total
+		args  []any
+		baseQ = `
+		SELECT id, fingerprint, reason, occurrences, user_agent, details, throttled_until, created_at, updated_at
+		FROM guest_flags`
+	)
+
+	// Outside a tenant admins see every organization
+	whereQ := ` WHERE cleared_at IS NULL AND ($1::uuid IS NULL OR organization_id = $1)`
+	args = append(args, tenant.ID(ctx))
+
+	if query.Reason != "" {
+		whereQ += ` AND reason = $2`
+		args = append(args, query.Reason)
+	}
+
+	limitQ, limitArgs := query.LimitOffset(len(args) + 1)
+
+	flags, err := database.Select[GuestFlag](ctx, r.db, baseQ+whereQ+query.Sort.OrderBy()+limitQ, append(args, limitArgs...)...)
+	if err != nil {
+		return nil, total, err
+	}
+
+	total.Items, total.Estimated, err = database.Count(ctx, r.db, query.Count == pagination.CountEstimate, baseQ+whereQ, args...)
+	if err != nil {
+		return nil, total, err
+	}
+
+	return flags, total, nil
+}
+
+func (r *abuseRepository) Clear(ctx context.Context, id, clearedBy string) error {
+	const q = `
+		UPDATE guest_flags SET cleared_at = now(), cleared_by = $2, updated_at = now()
+		WHERE id = $1
+			AND cleared_at IS NULL
+			AND ($3::uuid IS NULL OR organization_id = $3)`
+
+	tag, err := r.db.Exec(ctx, q, id, clearedBy, tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrFlagNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package abuse
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the flagged guests endpoints, all of them require an admin account
+func (h *AbuseHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	admin := router.NewGroup(mux, mw.Admin)
+	admin.HandleFunc("GET /api/v1/admin/guests/flagged", h.ListFlags)
+	admin.HandleFunc("DELETE /api/v1/admin/guests/flagged/{id}", h.ClearFlag)
+}
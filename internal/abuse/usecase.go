@@ -0,0 +1,131 @@
+package abuse
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+)
+
+type AbuseUsecase interface {
+	// CheckGuestSignIn refuses a throttled fingerprint, and flags and throttles one opening
+	// more guest sessions in the last hour than configured
+	CheckGuestSignIn(ctx context.Context, fingerprint, userAgent string) error
+	// CheckGuestRefresh refuses refreshing a guest session of a throttled fingerprint
+	CheckGuestRefresh(ctx context.Context, fingerprint string) error
+	// ReportImplausibleSession flags and throttles the fingerprint of a guest session that
+	// reported a swim nobody can swim
+	ReportImplausibleSession(ctx context.Context, sessionID string, details map[string]any) error
+	ListFlags(ctx context.Context, query *FlagsQuery) ([]GuestFlagResponse, pagination.Total, error)
+	// ClearFlag closes an open flag, its fingerprint may sign in as a guest again
+	ClearFlag(ctx context.Context, actorAccountID, id string) error
+}
+
+type abuseUsecase struct {
+	cfg       *config.Store
+	abuseRepo AbuseRepository
+}
+
+func NewAbuseUsecase(cfg *config.Store, abuseRepo AbuseRepository) AbuseUsecase {
+	return &abuseUsecase{cfg, abuseRepo}
+}
+
+func (u *abuseUsecase) CheckGuestSignIn(ctx context.Context, fingerprint, userAgent string) error {
+	if err := u.CheckGuestRefresh(ctx, fingerprint); err != nil {
+		return err
+	}
+
+	// Guest settings are hot reloadable, read the current snapshot
+	limit := u.cfg.Load().Auth.GuestSessionsPerHour
+	if limit <= 0 || fingerprint == "" {
+		return nil
+	}
+
+	count, err := u.abuseRepo.CountGuestSessions(ctx, fingerprint, time.Now().UTC().Add(-time.Hour))
+	if err != nil {
+		// The heuristics must not lock guests out when the database hiccups
+		logger.FromContext(ctx).Warn("guest abuse: count sessions failed", "error", err)
+		return nil
+	}
+	if count < limit {
+		return nil
+	}
+
+	if err := u.flag(ctx, fingerprint, ReasonManySessions, &userAgent, map[string]any{"sessionsLastHour": count, "limit": limit}); err != nil {
+		return err
+	}
+
+	return ErrSignUpRequired
+}
+
+func (u *abuseUsecase) CheckGuestRefresh(ctx context.Context, fingerprint string) error {
+	if fingerprint == "" {
+		return nil
+	}
+
+	throttled, err := u.abuseRepo.IsThrottled(ctx, fingerprint, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Warn("guest abuse: throttle check failed", "error", err)
+		return nil
+	}
+	if throttled {
+		return ErrSignUpRequired
+	}
+
+	return nil
+}
+
+func (u *abuseUsecase) ReportImplausibleSession(ctx context.Context, sessionID string, details map[string]any) error {
+	fingerprint, err := u.abuseRepo.GetSessionFingerprint(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	// Sessions opened before fingerprints were recorded cannot be traced to a client
+	if fingerprint == "" {
+		return nil
+	}
+
+	return u.flag(ctx, fingerprint, ReasonImplausibleSession, nil, details)
+}
+
+func (u *abuseUsecase) ListFlags(ctx context.Context, query *FlagsQuery) ([]GuestFlagResponse, pagination.Total, error) {
+	flags, total, err := u.abuseRepo.ListFlags(ctx, query)
+	if err != nil {
+		return nil, total, err
+	}
+
+	now := time.Now().UTC()
+	res := make([]GuestFlagResponse, len(flags))
+	for i, flag := range flags {
+		res[i] = newGuestFlagResponse(flag, now)
+	}
+
+	return res, total, nil
+}
+
+func (u *abuseUsecase) ClearFlag(ctx context.Context, actorAccountID, id string) error {
+	if err := u.abuseRepo.Clear(ctx, id, actorAccountID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Guest flag cleared", "flag_id", id, "actor_account_id", actorAccountID)
+	return nil
+}
+
+func (u *abuseUsecase) flag(ctx context.Context, fingerprint, reason string, userAgent *string, details map[string]any) error {
+	flag := &GuestFlag{
+		Fingerprint:    fingerprint,
+		Reason:         reason,
+		UserAgent:      userAgent,
+		Details:        details,
+		ThrottledUntil: time.Now().UTC().Add(u.cfg.Load().Auth.GuestThrottle),
+	}
+	if err := u.abuseRepo.Flag(ctx, flag); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Warn("Guest flagged", "flag_id", flag.ID, "reason", reason, "occurrences", flag.Occurrences, "throttled_until", flag.ThrottledUntil)
+	return nil
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/internal/audit"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type UsersQuery struct {
+	pagination.Params
+	Search  string `query:"query"`
+	Deleted bool   `query:"deleted"` // lists the deleted users instead
+}
+
+// userSorts whitelists the sortable admin user list columns
+var userSorts = pagination.SortSpec{
+	Columns: map[string]string{
+		"email":      "a.email",
+		"name":       "u.name",
+		"created_at": "u.created_at",
+	},
+	Default:    "created_at.desc",
+	TieBreaker: "u.id",
+}
+
+type UserSummaryResponse struct {
+	UserID    string     `json:"userId" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	AccountID string     `json:"accountId" example:"0f9e8d7c-6b5a-4f3e-2d1c-0b9a8f7e6d5c"`
+	Email     string     `json:"email" example:"swimmer@swimo.id"`
+	Name      string     `json:"name" example:"Dina Kusuma"`
+	Role      string     `json:"role" example:"user" enums:"user,admin"`
+	Locked    bool       `json:"locked" example:"false"`
+	CreatedAt time.Time  `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty" example:"2025-09-23T10:15:00Z"`
+}
+
+type UserDetailResponse struct {
+	UserSummaryResponse
+	OrganizationID *string              `json:"organizationId,omitempty" example:"7a6b5c4d-3e2f-4a1b-9c8d-7e6f5a4b3c2d"`
+	SessionsCount  int                  `json:"sessionsCount" example:"42"`
+	ActiveSignIns  int                  `json:"activeSignIns" example:"2"`
+	LastActivityAt *time.Time           `json:"lastActivityAt,omitempty" example:"2025-09-21T07:30:00Z"`
+	AuditEvents    []AuditEventResponse `json:"auditEvents"`
+}
+
+type AuditEventResponse struct {
+	ID         string         `json:"id" example:"3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a"`
+	Action     string         `json:"action" example:"admin.user_viewed"`
+	ActorEmail *string        `json:"actorEmail,omitempty" example:"admin@swimo.id"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+}
+
+// ImpersonateRequest opens a support session as the user, read only unless readOnly is false
+type ImpersonateRequest struct {
+	Reason   string `json:"reason" validate:"required,max=500" example:"Ticket #4821, stats page shows no sessions"`
+	ReadOnly *bool  `json:"readOnly,omitempty" example:"true"`
+}
+
+type ImpersonationResponse struct {
+	ID        string `json:"id" example:"3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a"` // audit event of the impersonation
+	Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresIn int64  `json:"expiresIn" example:"900000"`
+	ReadOnly  bool   `json:"readOnly" example:"true"`
+}
+
+func (r *ImpersonateRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newUserSummaryResponse(u *UserSummary) UserSummaryResponse {
+	return UserSummaryResponse{
+		UserID:    u.UserID,
+		AccountID: u.AccountID,
+		Email:     u.Email,
+		Name:      u.Name,
+		Role:      u.Role,
+		Locked:    u.IsLocked,
+		CreatedAt: u.CreatedAt,
+		DeletedAt: u.DeletedAt,
+	}
+}
+
+func newAuditEventResponses(events []audit.Event) []AuditEventResponse {
+	res := make([]AuditEventResponse, len(events))
+	for i, e := range events {
+		res[i] = AuditEventResponse{ID: e.ID, Action: e.Action, ActorEmail: e.ActorEmail, Metadata: e.Metadata, CreatedAt: e.CreatedAt}
+	}
+	return res
+}
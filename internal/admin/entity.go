@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rizkyharahap/swimo/internal/audit"
+)
+
+var (
+	ErrImpersonateAdmin = errors.New("admins cannot be impersonated")
+	ErrDeleteAdmin      = errors.New("admins cannot be deleted")
+)
+
+// UserSummary is a user matching an admin search
+type UserSummary struct {
+	UserID    string
+	AccountID string
+	Email     string
+	Name      string
+	Role      string
+	IsLocked  bool
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// UserDetail is what support needs to answer about an account
+type UserDetail struct {
+	UserSummary
+	OrganizationID *string
+	SessionsCount  int        // training sessions recorded
+	ActiveSignIns  int        // sign in sessions neither revoked nor expired
+	LastActivityAt *time.Time // last sign in, refresh or recorded session
+	AuditEvents    []audit.Event
+}
@@ -0,0 +1,187 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type AdminHandler struct {
+	adminUsecase AdminUsecase
+}
+
+func NewAdminHandler(adminUsecase AdminUsecase) *AdminHandler {
+	return &AdminHandler{adminUsecase}
+}
+
+// SearchUsers handles the support user search
+// @Summary Search users
+// @Description Search the users of the organization by email or name, case insensitive and anywhere in the value. Without query every user is listed. With deleted=true the deleted users are searched instead. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param query query string false "Part of the email or name" example("dina")
+// @Param deleted query bool false "Search the deleted users" default(false)
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Number of items per page" default(10) minimum(1) maximum(100)
+// @Param sort query string false "Sort field and direction" Enums(email.asc,email.desc,name.asc,name.desc,created_at.asc,created_at.desc) default(created_at.desc)
+// @Success 200 {object} response.Success{data=[]UserSummaryResponse} "Users retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/users [get]
+func (h *AdminHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	params, verr := pagination.Parse(r.URL.Query(), pagination.Options{Sorts: userSorts})
+	if verr != nil {
+		response.ValidationError(w, verr.Errors)
+		return
+	}
+
+	query := UsersQuery{Params: params, Search: r.URL.Query().Get("query"), Deleted: r.URL.Query().Get("deleted") == "true"}
+
+	users, total, err := h.adminUsecase.SearchUsers(r.Context(), &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Paginated(w, http.StatusOK, users, query.Response(total))
+}
+
+// GetUser handles the support view of a user
+// @Summary Get a user
+// @Description Account status, recorded sessions, active sign ins, last activity and the 20 latest audit events of a user. The view itself is audited. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "User ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=UserDetailResponse} "User retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "User not found"
+// @Failure 422 {object} response.Error "Invalid user ID"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	res, err := h.adminUsecase.GetUser(ctx, *claim.Aid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Impersonate handles opening a support session as a user
+// @Summary Impersonate a user
+// @Description Mint a token acting as the user to reproduce a reported bug. It is read only unless readOnly is false, expires after JWT_IMPERSONATION_TTL_MIN minutes and has no refresh token. The impersonation and every request made with the token are recorded in the audit events of the user. Admins cannot be impersonated, the token is refused by gRPC. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Param request body ImpersonateRequest true "Reason of the impersonation"
+// @Success 201 {object} response.Success{data=ImpersonationResponse} "Impersonation token issued"
+// @Failure 403 {object} response.Error "Insufficient role or admins cannot be impersonated"
+// @Failure 404 {object} response.Error "User not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/impersonate [post]
+func (h *AdminHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req ImpersonateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	res, err := h.adminUsecase.Impersonate(ctx, *claim.Aid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// DeleteUser handles deleting a user
+// @Summary Delete a user
+// @Description Softly delete a user and its account: it can no longer sign in, its sign ins are revoked and it leaves the lists and stats. Access tokens already issued stay valid until they expire. Admins cannot be deleted. The deletion is audited and can be undone with restore. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "User ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=response.Message} "User deleted"
+// @Failure 403 {object} response.Error "Insufficient role or admins cannot be deleted"
+// @Failure 404 {object} response.Error "User not found"
+// @Failure 422 {object} response.Error "Invalid user ID"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if err := h.adminUsecase.DeleteUser(ctx, *claim.Aid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "User deleted"})
+}
+
+// RestoreUser handles restoring a deleted user
+// @Summary Restore a deleted user
+// @Description Restore a deleted user and its account, it can sign in again. The restore is audited. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "User ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=response.Message} "User restored"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Deleted user not found"
+// @Failure 422 {object} response.Error "Invalid user ID"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/restore [post]
+func (h *AdminHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if err := h.adminUsecase.RestoreUser(ctx, *claim.Aid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "User restored"})
+}
@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+type AdminRepository interface {
+	// SearchUsers returns a page of the users of the tenant whose email or name contains the search
+	SearchUsers(ctx context.Context, query *UsersQuery) ([]*UserSummary, pagination.Total, error)
+	// GetUserDetail returns a user of the tenant with its activity, deleted or not, user.ErrUserNotFound when none
+	GetUserDetail(ctx context.Context, userID string) (*UserDetail, error)
+	// DeleteUser softly deletes a user of the tenant with its account and revokes its sign ins,
+	// user.ErrUserNotFound when none
+	DeleteUser(ctx context.Context, userID string) error
+	// RestoreUser restores a deleted user of the tenant with its account, user.ErrUserNotFound when none
+	RestoreUser(ctx context.Context, userID string) error
+}
+
+type adminRepository struct {
+	db database.DBTX
+}
+
+func NewAdminRepositry(db database.DBTX) AdminRepository {
+	return &adminRepository{db}
+}
+
+func (r *adminRepository) SearchUsers(ctx context.Context, query *UsersQuery) ([]*UserSummary, pagination.Total, error) {
+	var (
+		total pagination.Total
+		args  []any
+		baseQ = `
+		SELECT u.id AS user_id, a.id AS account_id, a.email, u.name, a.role, a.is_locked, u.created_at, u.deleted_at
+		FROM users u
+		JOIN accounts a ON a.id = u.account_id`
+	)
+
+	// Outside a tenant admins search every organization
+	whereQ := ` WHERE ($1::uuid IS NULL OR a.organization_id = $1)`
+	args = append(args, tenant.ID(ctx))
+
+	if query.Deleted {
+		whereQ += ` AND ` + database.Deleted("u")
+	} else {
+		whereQ += ` AND ` + database.Live("u")
+	}
+
+	if query.Search != "" {
+		whereQ += ` AND (a.email ILIKE $2 OR u.name ILIKE $2)`
+		args = append(args, "%"+query.Search+"%")
+	}
+
+	limitQ, limitArgs := query.LimitOffset(len(args) + 1)
+
+	users, err := database.Select[UserSummary](ctx, r.db, baseQ+whereQ+query.Sort.OrderBy()+limitQ, append(args, limitArgs...)...)
+	if err != nil {
+		return nil, total, err
+	}
+
+	total.Items, total.Estimated, err = database.Count(ctx, r.db, query.Count == pagination.CountEstimate, baseQ+whereQ, args...)
+	if err != nil {
+		return nil, total, err
+	}
+
+	return users, total, nil
+}
+
+func (r *adminRepository) GetUserDetail(ctx context.Context, userID string) (*UserDetail, error) {
+	const q = `
+		SELECT
+			u.id AS user_id, a.id AS account_id, a.email, u.name, a.role, a.is_locked, u.created_at, u.deleted_at,
+			a.organization_id,
+			(SELECT count(*) FROM training_sessions ts WHERE ts.user_id = u.id AND ts.deleted_at IS NULL) AS sessions_count,
+			(SELECT count(*) FROM sessions s
+				WHERE s.account_id = a.id AND s.revoked_at IS NULL AND s.refresh_expires_at > now()) AS active_sign_ins,
+			GREATEST(
+				(SELECT max(s.created_at) FROM sessions s WHERE s.account_id = a.id),
+				(SELECT max(ts.created_at) FROM training_sessions ts WHERE ts.user_id = u.id AND ts.deleted_at IS NULL)
+			) AS last_activity_at
+		FROM users u
+		JOIN accounts a ON a.id = u.account_id
+		WHERE u.id = $1
+			AND ($2::uuid IS NULL OR a.organization_id = $2)`
+
+	d, err := database.Get[UserDetail](ctx, r.db, q, userID, tenant.ID(ctx))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (r *adminRepository) DeleteUser(ctx context.Context, userID string) error {
+	// The account goes with its user, its sign ins are revoked so refresh tokens stop working
+	const q = `
+		WITH u AS (
+			UPDATE users
+			SET deleted_at = now()
+			WHERE id = $1
+				AND deleted_at IS NULL
+				AND account_id IN (SELECT id FROM accounts WHERE $2::uuid IS NULL OR organization_id = $2)
+			RETURNING account_id, deleted_at
+		),
+		a AS (
+			UPDATE accounts
+			SET deleted_at = u.deleted_at
+			FROM u
+			WHERE accounts.id = u.account_id
+			RETURNING accounts.id
+		),
+		s AS (
+			UPDATE sessions
+			SET revoked_at = now()
+			FROM a
+			WHERE sessions.account_id = a.id
+				AND sessions.revoked_at IS NULL
+		)
+		SELECT count(*) FROM a`
+
+	return r.setUserDeleted(ctx, q, userID)
+}
+
+func (r *adminRepository) RestoreUser(ctx context.Context, userID string) error {
+	const q = `
+		WITH u AS (
+			UPDATE users
+			SET deleted_at = NULL
+			WHERE id = $1
+				AND deleted_at IS NOT NULL
+				AND account_id IN (SELECT id FROM accounts WHERE $2::uuid IS NULL OR organization_id = $2)
+			RETURNING account_id
+		),
+		a AS (
+			UPDATE accounts
+			SET deleted_at = NULL
+			FROM u
+			WHERE accounts.id = u.account_id
+			RETURNING accounts.id
+		)
+		SELECT count(*) FROM a`
+
+	return r.setUserDeleted(ctx, q, userID)
+}
+
+func (r *adminRepository) setUserDeleted(ctx context.Context, q, userID string) error {
+	var updated int
+	if err := r.db.QueryRow(ctx, q, userID, tenant.ID(ctx)).Scan(&updated); err != nil {
+		return err
+	}
+	if updated == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the support endpoints, all of them require an admin account
+func (h *AdminHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	admin := router.NewGroup(mux, mw.Admin)
+	admin.HandleFunc("GET /api/v1/admin/users", h.SearchUsers)
+	admin.HandleFunc("GET /api/v1/admin/users/{id}", h.GetUser)
+	admin.HandleFunc("POST /api/v1/admin/users/{id}/impersonate", h.Impersonate)
+	admin.HandleFunc("DELETE /api/v1/admin/users/{id}", h.DeleteUser)
+	admin.HandleFunc("POST /api/v1/admin/users/{id}/restore", h.RestoreUser)
+}
@@ -0,0 +1,196 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/internal/audit"
+	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+// maxAuditEvents caps the audit events shown with a user
+const maxAuditEvents = 20
+
+type AdminUsecase interface {
+	SearchUsers(ctx context.Context, query *UsersQuery) ([]UserSummaryResponse, pagination.Total, error)
+	// GetUser returns the detail of a user, the view is recorded in its audit events
+	GetUser(ctx context.Context, actorAccountID, userID string) (*UserDetailResponse, error)
+	// Impersonate mints a short lived token acting as the user, the impersonation is audited
+	Impersonate(ctx context.Context, actorAccountID, userID string, req *ImpersonateRequest) (*ImpersonationResponse, error)
+	// DeleteUser softly deletes a user and its account, signing it out everywhere. Audited.
+	DeleteUser(ctx context.Context, actorAccountID, userID string) error
+	// RestoreUser restores a deleted user and its account, it signs in again. Audited.
+	RestoreUser(ctx context.Context, actorAccountID, userID string) error
+	// RecordImpersonatedRequest audits a request made with an impersonation token
+	RecordImpersonatedRequest(ctx context.Context, claims *security.Claim, r *http.Request, status int)
+}
+
+type adminUsecase struct {
+	cfg       *config.Store
+	adminRepo AdminRepository
+	auditRepo audit.AuditRepository
+}
+
+func NewAdminUsecase(cfg *config.Store, adminRepo AdminRepository, auditRepo audit.AuditRepository) AdminUsecase {
+	return &adminUsecase{cfg, adminRepo, auditRepo}
+}
+
+func (u *adminUsecase) SearchUsers(ctx context.Context, query *UsersQuery) ([]UserSummaryResponse, pagination.Total, error) {
+	users, total, err := u.adminRepo.SearchUsers(ctx, query)
+	if err != nil {
+		return nil, total, err
+	}
+
+	res := make([]UserSummaryResponse, len(users))
+	for i, user := range users {
+		res[i] = newUserSummaryResponse(user)
+	}
+
+	return res, total, nil
+}
+
+func (u *adminUsecase) GetUser(ctx context.Context, actorAccountID, userID string) (*UserDetailResponse, error) {
+	detail, err := u.adminRepo.GetUserDetail(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := u.auditRepo.ListByTarget(ctx, detail.AccountID, maxAuditEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Support reads personal data, every view is kept. A failed record doesn't hide the account.
+	event := &audit.Event{ActorAccountID: &actorAccountID, TargetAccountID: &detail.AccountID, Action: audit.ActionUserViewed, Metadata: map[string]any{}}
+	if err := u.auditRepo.Record(ctx, event); err != nil {
+		logger.FromContext(ctx).Warn("admin user view: audit record failed", "user_id", userID, "error", err)
+	}
+
+	return &UserDetailResponse{
+		UserSummaryResponse: newUserSummaryResponse(&detail.UserSummary),
+		OrganizationID:      detail.OrganizationID,
+		SessionsCount:       detail.SessionsCount,
+		ActiveSignIns:       detail.ActiveSignIns,
+		LastActivityAt:      detail.LastActivityAt,
+		AuditEvents:         newAuditEventResponses(events),
+	}, nil
+}
+
+func (u *adminUsecase) Impersonate(ctx context.Context, actorAccountID, userID string, req *ImpersonateRequest) (*ImpersonationResponse, error) {
+	target, err := u.adminRepo.GetUserDetail(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// An impersonated admin would open the admin endpoints to another admin's name
+	if target.Role == security.RoleAdmin {
+		return nil, ErrImpersonateAdmin
+	}
+	if target.DeletedAt != nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	readOnly := req.ReadOnly == nil || *req.ReadOnly
+	auth := u.cfg.Load().Auth
+
+	// Recorded before the token exists, there is no impersonation without its audit event
+	event := &audit.Event{
+		ActorAccountID:  &actorAccountID,
+		TargetAccountID: &target.AccountID,
+		Action:          audit.ActionImpersonationStarted,
+		Metadata: map[string]any{
+			"reason":    req.Reason,
+			"readOnly":  readOnly,
+			"expiresAt": time.Now().Add(auth.ImpersonationTTL).UTC(),
+		},
+	}
+	if err := u.auditRepo.Record(ctx, event); err != nil {
+		return nil, err
+	}
+
+	// The session of the token is the audit event, every impersonated request points back to it
+	claims := security.Claim{
+		Sub:  event.ID,
+		Aid:  &target.AccountID,
+		Uid:  &target.UserID,
+		Org:  target.OrganizationID,
+		Kind: "user",
+		Role: target.Role,
+	}
+	token, exp, err := security.NewImpersonationToken(security.SigningKey{ID: auth.JWTKeyID, Secret: auth.JWTSecret}, auth.ImpersonationTTL, claims, actorAccountID, readOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("Impersonation started", "impersonation_id", event.ID, "user_id", userID, "read_only", readOnly)
+
+	return &ImpersonationResponse{
+		ID:        event.ID,
+		Token:     token,
+		ExpiresIn: time.Until(exp).Milliseconds(),
+		ReadOnly:  readOnly,
+	}, nil
+}
+
+func (u *adminUsecase) DeleteUser(ctx context.Context, actorAccountID, userID string) error {
+	target, err := u.adminRepo.GetUserDetail(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Admins are removed from the admin role first, the last admin can't lock everyone out
+	if target.Role == security.RoleAdmin {
+		return ErrDeleteAdmin
+	}
+
+	if err := u.adminRepo.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	u.recordUserAction(ctx, actorAccountID, target.AccountID, audit.ActionUserDeleted)
+	return nil
+}
+
+func (u *adminUsecase) RestoreUser(ctx context.Context, actorAccountID, userID string) error {
+	target, err := u.adminRepo.GetUserDetail(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.adminRepo.RestoreUser(ctx, userID); err != nil {
+		return err
+	}
+
+	u.recordUserAction(ctx, actorAccountID, target.AccountID, audit.ActionUserRestored)
+	return nil
+}
+
+// recordUserAction audits an action done on an account, a failed record doesn't undo it
+func (u *adminUsecase) recordUserAction(ctx context.Context, actorAccountID, targetAccountID, action string) {
+	event := &audit.Event{ActorAccountID: &actorAccountID, TargetAccountID: &targetAccountID, Action: action, Metadata: map[string]any{}}
+	if err := u.auditRepo.Record(ctx, event); err != nil {
+		logger.FromContext(ctx).Warn("admin user action: audit record failed", "action", action, "account_id", targetAccountID, "error", err)
+	}
+}
+
+func (u *adminUsecase) RecordImpersonatedRequest(ctx context.Context, claims *security.Claim, r *http.Request, status int) {
+	event := &audit.Event{
+		ActorAccountID:  claims.Imp,
+		TargetAccountID: claims.Aid,
+		Action:          audit.ActionImpersonatedRequest,
+		Metadata: map[string]any{
+			"impersonationId": claims.Sub,
+			"method":          r.Method,
+			"path":            r.URL.Path,
+			"status":          status,
+		},
+	}
+	if err := u.auditRepo.Record(ctx, event); err != nil {
+		logger.FromContext(ctx).Error("impersonated request: audit record failed", "impersonation_id", claims.Sub, "error", err)
+	}
+}
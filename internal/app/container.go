@@ -0,0 +1,770 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/abuse"
+	"github.com/rizkyharahap/swimo/internal/admin"
+	"github.com/rizkyharahap/swimo/internal/audit"
+	"github.com/rizkyharahap/swimo/internal/auth"
+	"github.com/rizkyharahap/swimo/internal/billing"
+	"github.com/rizkyharahap/swimo/internal/coach"
+	"github.com/rizkyharahap/swimo/internal/consent"
+	"github.com/rizkyharahap/swimo/internal/device"
+	"github.com/rizkyharahap/swimo/internal/digest"
+	"github.com/rizkyharahap/swimo/internal/equipment"
+	"github.com/rizkyharahap/swimo/internal/event"
+	"github.com/rizkyharahap/swimo/internal/health"
+	"github.com/rizkyharahap/swimo/internal/injury"
+	"github.com/rizkyharahap/swimo/internal/media"
+	"github.com/rizkyharahap/swimo/internal/organization"
+	"github.com/rizkyharahap/swimo/internal/pool"
+	"github.com/rizkyharahap/swimo/internal/race"
+	"github.com/rizkyharahap/swimo/internal/stats"
+	"github.com/rizkyharahap/swimo/internal/swagger"
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/internal/usage"
+	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/internal/warehouse"
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+	"github.com/rizkyharahap/swimo/pkg/broker"
+	"github.com/rizkyharahap/swimo/pkg/cache"
+	"github.com/rizkyharahap/swimo/pkg/crypto"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/mailer"
+	"github.com/rizkyharahap/swimo/pkg/metering"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+	"github.com/rizkyharahap/swimo/pkg/payment"
+	"github.com/rizkyharahap/swimo/pkg/pubsub"
+	"github.com/rizkyharahap/swimo/pkg/ratelimit"
+	"github.com/rizkyharahap/swimo/pkg/router"
+	"github.com/rizkyharahap/swimo/pkg/scanner"
+	"github.com/rizkyharahap/swimo/pkg/scheduler"
+	"github.com/rizkyharahap/swimo/pkg/secrets"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+	"github.com/rizkyharahap/swimo/pkg/weather"
+)
+
+// Container constructs and holds every application dependency.
+// Fields set through options before New builds the graph are kept as-is,
+// so tests and alternative environments can swap any implementation.
+type Container struct {
+	Config      *config.Config
+	ConfigStore *config.Store // hot reloadable snapshot of Config
+	Secrets     *secrets.Resolver
+	Log         *logger.Logger
+
+	// Infrastructure
+	DBManager      *database.Manager
+	DB             *database.Database
+	Migrator       *database.Migrator
+	Breaker        *database.Breaker
+	Redis          *redis.Client
+	Cache          cache.Cache
+	RateLimitStore ratelimit.Store
+	NonceStore     ratelimit.Store // nonces of signed requests, a nonce is allowed once per window
+	MeteringStore  metering.Store  // requests per account and client, nil when metering is disabled
+	PubSub         pubsub.Bus      // live updates pushed to the clients of every instance
+	Publisher      broker.Publisher
+	Tracker        analytics.Tracker
+	Mailer         mailer.Mailer
+	Weather        weather.Provider
+	Payments       payment.Provider // nil when billing is disabled
+	Storage        storage.Storage
+	Scheduler      *scheduler.Scheduler
+	Metrics        *metrics.Registry
+	AccessLog      io.Writer      // nil when the access log is disabled
+	Cipher         *crypto.Cipher // encrypts sensitive columns, nil stores them as plaintext
+
+	// Repositories
+	AuthRepo         auth.AuthRepository
+	UserRepo         user.UserRepository
+	TrainingRepo     training.TrainingRepository
+	OrganizationRepo organization.OrganizationRepository
+	WarehouseRepo    warehouse.WarehouseRepository
+	DigestRepo       digest.DigestRepository
+	StatsRepo        stats.StatsRepository
+	DeviceRepo       device.DeviceRepository
+	EquipmentRepo    equipment.EquipmentRepository
+	CoachRepo        coach.CoachRepository
+	InjuryRepo       injury.InjuryRepository
+	RaceRepo         race.RaceRepository
+	ConsentRepo      consent.ConsentRepository
+	AuditRepo        audit.AuditRepository
+	AdminRepo        admin.AdminRepository
+	AbuseRepo        abuse.AbuseRepository
+	PoolRepo         pool.PoolRepository
+	BillingRepo      billing.BillingRepository
+
+	// Usecases
+	AuthUsecase      auth.AuthUsecase
+	UserUsecase      user.UserUsecase
+	TrainingUsecase  training.TrainingUsecase
+	WarehouseUsecase warehouse.WarehouseUsecase
+	DigestUsecase    digest.DigestUsecase
+	StatsUsecase     stats.StatsUsecase
+	DeviceUsecase    device.DeviceUsecase
+	EquipmentUsecase equipment.EquipmentUsecase
+	CoachUsecase     coach.CoachUsecase
+	InjuryUsecase    injury.InjuryUsecase
+	RaceUsecase      race.RaceUsecase
+	ConsentUsecase   consent.ConsentUsecase
+	AdminUsecase     admin.AdminUsecase
+	AbuseUsecase     abuse.AbuseUsecase
+	UsageUsecase     usage.UsageUsecase
+	PoolUsecase      pool.PoolUsecase
+	BillingUsecase   billing.BillingUsecase
+
+	// Handlers
+	HealthHandler    *health.HealthHandler
+	SwaggerHandler   *swagger.SwaggerHandler
+	AuthHandler      *auth.AuthHandler
+	UserHandler      *user.UserHandler
+	TrainingHandler  *training.TrainingHandler
+	MediaHandler     *media.MediaHandler
+	EventHandler     *event.EventHandler
+	StatsHandler     *stats.StatsHandler
+	DeviceHandler    *device.DeviceHandler
+	EquipmentHandler *equipment.EquipmentHandler
+	CoachHandler     *coach.CoachHandler
+	InjuryHandler    *injury.InjuryHandler
+	RaceHandler      *race.RaceHandler
+	ConsentHandler   *consent.ConsentHandler
+	AdminHandler     *admin.AdminHandler
+	AbuseHandler     *abuse.AbuseHandler
+	UsageHandler     *usage.UsageHandler
+	PoolHandler      *pool.PoolHandler
+	BillingHandler   *billing.BillingHandler
+
+	closers []func() error
+
+	// started is closed once the database is connected and migrated, right away unless
+	// DB_LAZY_CONNECT is set. startErr is set before when that failed.
+	started  chan struct{}
+	startErr error
+}
+
+// Option overrides a dependency before the container builds the rest of the graph
+type Option func(*Container)
+
+// New builds the full dependency graph from config
+func New(ctx context.Context, cfg *config.Config, log *logger.Logger, opts ...Option) (*Container, error) {
+	c := &Container{Config: cfg, Log: log}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.Secrets == nil {
+		c.Secrets = secrets.NewResolver()
+	}
+	if c.ConfigStore == nil {
+		c.ConfigStore = config.NewStore(cfg, cfg.App.ConfigFile, c.Secrets.Resolve)
+	}
+
+	steps := []func(context.Context) error{
+		c.initInfrastructure,
+		c.initRepositories,
+		c.initUsecases,
+		c.initHandlers,
+		c.initJobs,
+	}
+
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Modules returns every module exposing HTTP routes
+func (c *Container) Modules() []router.Module {
+	return []router.Module{
+		c.HealthHandler,
+		c.SwaggerHandler,
+		c.AuthHandler,
+		c.UserHandler,
+		c.TrainingHandler,
+		c.MediaHandler,
+		c.EventHandler,
+		c.StatsHandler,
+		c.DeviceHandler,
+		c.EquipmentHandler,
+		c.CoachHandler,
+		c.InjuryHandler,
+		c.RaceHandler,
+		c.ConsentHandler,
+		c.AdminHandler,
+		c.AbuseHandler,
+		c.UsageHandler,
+		c.PoolHandler,
+		c.BillingHandler,
+	}
+}
+
+// Close releases every resource opened by the container in reverse order
+func (c *Container) Close() error {
+	var errs []error
+
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		if err := c.closers[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.closers = nil
+
+	return errors.Join(errs...)
+}
+
+// Ready reports whether the database is connected and migrated, with DB_LAZY_CONNECT the
+// server starts serving before
+func (c *Container) Ready() bool {
+	select {
+	case <-c.started:
+		return c.startErr == nil
+	default:
+		return false
+	}
+}
+
+// onClose registers a cleanup function run by Close
+func (c *Container) onClose(fn func() error) {
+	c.closers = append(c.closers, fn)
+}
+
+func (c *Container) initInfrastructure(ctx context.Context) error {
+	cfg := c.Config
+
+	if c.Metrics == nil {
+		c.Metrics = metrics.NewRegistry()
+	}
+
+	// Open the access log, a sink of its own next to the application logs
+	if c.AccessLog == nil && cfg.Log.Access.Enabled {
+		c.AccessLog = os.Stdout
+		if cfg.Log.Access.File != "" {
+			file, err := logger.NewRotatingFile(cfg.Log.Access.File, logger.RotationConfig{
+				MaxSizeMB:  cfg.Log.MaxSizeMB,
+				MaxAgeDays: cfg.Log.MaxAgeDays,
+				MaxBackups: cfg.Log.MaxBackups,
+				Compress:   cfg.Log.Compress,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to open access log: %w", err)
+			}
+
+			c.AccessLog = file
+			c.onClose(file.Close)
+		}
+	}
+
+	// Set up database connection
+	if c.DB == nil {
+		// Started first so it is stopped last, after the pool is closed
+		if cfg.Database.Embedded.Enabled {
+			server, err := database.StartEmbedded(&cfg.Database, c.Log)
+			if err != nil {
+				return err
+			}
+			c.onClose(server.Stop)
+		}
+
+		if c.DBManager == nil {
+			c.DBManager = database.NewManager(c.Log)
+			c.DBManager.RegisterMetrics(c.Metrics)
+		}
+
+		connect := c.DBManager.Connect
+		if cfg.Database.LazyConnect {
+			connect = c.DBManager.ConnectLazy
+		}
+
+		db, err := connect(ctx, "primary", &cfg.Database, &cfg.App)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+
+		c.DB = db
+		c.onClose(c.DBManager.CloseAll)
+	}
+
+	// Set up the circuit breaker shared by every repository
+	if c.Breaker == nil {
+		c.Breaker = database.NewBreaker(c.DB.Name, cfg.Database.BreakerThreshold, cfg.Database.BreakerCooldown, c.Log)
+
+		trips := c.Metrics.NewCounter("db_circuit_trips_total", "Times the database circuit breaker opened.", "database")
+		state := c.Metrics.NewGauge("db_circuit_state", "Database circuit breaker state: 0 closed, 1 half-open, 2 open.", "database")
+
+		c.Breaker.OnTrip = func() { trips.Inc(c.DB.Name) }
+		c.Metrics.OnCollect(func() { state.Set(float64(c.Breaker.State()), c.DB.Name) })
+	}
+
+	// Set up schema migrations, once the database answers when connecting lazily
+	c.started = make(chan struct{})
+	c.onClose(func() error {
+		select {
+		case <-c.started:
+			if c.Migrator != nil {
+				return c.Migrator.Close()
+			}
+		default:
+		}
+		return nil
+	})
+
+	if cfg.Database.LazyConnect {
+		go func() {
+			defer close(c.started)
+
+			if c.startErr = c.DB.Wait(context.Background()); c.startErr != nil {
+				return
+			}
+			if c.startErr = c.initMigrator(); c.startErr != nil {
+				c.Log.Error("Failed to start after connecting to the database", "error", c.startErr)
+			}
+		}()
+	} else {
+		c.startErr = c.initMigrator()
+		close(c.started)
+		if c.startErr != nil {
+			return c.startErr
+		}
+	}
+
+	// Set up redis connection, shared by rate limiter and cache
+	if c.Redis == nil && cfg.Redis.URL != "" {
+		client, err := database.ConnectRedis(ctx, &cfg.Redis)
+		if err != nil {
+			return fmt.Errorf("failed to connect to redis: %w", err)
+		}
+
+		c.Redis = client
+		c.onClose(client.Close)
+	}
+
+	// Initialize rate limit store
+	if c.RateLimitStore == nil && cfg.RateLimit.Enabled {
+		if cfg.RateLimit.Store == "redis" && c.Redis != nil {
+			c.RateLimitStore = ratelimit.NewRedisStore(c.Redis, "swimo:ratelimit:")
+		} else {
+			c.RateLimitStore = ratelimit.NewMemoryStore()
+		}
+	}
+
+	// Initialize nonce store of replay protection
+	if c.NonceStore == nil && cfg.Replay.Enabled {
+		if cfg.Replay.Store == "redis" && c.Redis != nil {
+			c.NonceStore = ratelimit.NewRedisStore(c.Redis, "swimo:replay:")
+		} else {
+			c.NonceStore = ratelimit.NewMemoryStore()
+		}
+	}
+
+	// Initialize metering store
+	if c.MeteringStore == nil && cfg.Metering.Enabled {
+		if cfg.Metering.Store == "redis" && c.Redis != nil {
+			c.MeteringStore = metering.NewRedisStore(c.Redis, "swimo:metering:", cfg.Metering.Retention)
+		} else {
+			c.MeteringStore = metering.NewMemoryStore(cfg.Metering.Retention)
+		}
+	}
+
+	// Live updates reach the clients connected to other instances through redis
+	if c.PubSub == nil {
+		if c.Redis != nil {
+			c.PubSub = pubsub.NewRedisBus(c.Redis, "swimo:pubsub:")
+		} else {
+			c.PubSub = pubsub.NewMemoryBus()
+		}
+	}
+
+	// Initialize cache
+	if c.Cache == nil {
+		appCache, err := cache.New(cfg.Cache, c.Redis)
+		if err != nil {
+			return fmt.Errorf("failed to initialize cache: %w", err)
+		}
+		c.Cache = appCache
+	}
+
+	// Initialize event publisher
+	if c.Publisher == nil {
+		publisher, err := broker.New(cfg.Broker, c.Log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize broker: %w", err)
+		}
+
+		c.Publisher = publisher
+		c.onClose(publisher.Close)
+	}
+
+	// Initialize analytics, writes bypass the breaker so a slow sink never opens it for requests
+	if c.Tracker == nil {
+		tracker, err := analytics.New(cfg.Analytics, c.DB.Pool, c.Log, c.Metrics)
+		if err != nil {
+			return fmt.Errorf("failed to initialize analytics: %w", err)
+		}
+
+		c.Tracker = tracker
+		c.onClose(tracker.Close)
+	}
+
+	// Initialize mail, nil when disabled
+	if c.Mailer == nil {
+		mail, err := mailer.New(cfg.Mailer, c.Log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize mailer: %w", err)
+		}
+
+		c.Mailer = mail
+	}
+
+	// Initialize the weather integration, nil when disabled
+	if c.Weather == nil {
+		provider, err := weather.New(cfg.Weather)
+		if err != nil {
+			return fmt.Errorf("failed to initialize weather provider: %w", err)
+		}
+
+		c.Weather = provider
+	}
+
+	// Initialize the payment provider, nil when billing is disabled
+	if c.Payments == nil {
+		provider, err := payment.New(cfg.Billing)
+		if err != nil {
+			return fmt.Errorf("failed to initialize payment provider: %w", err)
+		}
+
+		c.Payments = provider
+	}
+
+	// Initialize file storage
+	if c.Storage == nil {
+		files, err := storage.New(ctx, cfg.Storage, cfg.HTTP.BaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		fileScanner, err := scanner.New(cfg.Scanner)
+		if err != nil {
+			return fmt.Errorf("failed to initialize scanner: %w", err)
+		}
+
+		// User generated media is only accepted once scanned, dev may upload without a scanner
+		if fileScanner != nil || cfg.App.Env != "dev" {
+			files = storage.WithScanner(files, fileScanner, "avatars/", "trainings/")
+		}
+		c.Storage = files
+	}
+
+	// Set up encryption of sensitive columns
+	if c.Cipher == nil && cfg.Encryption.Keys != "" {
+		keys, err := crypto.ParseKeys(cfg.Encryption.Keys)
+		if err != nil {
+			return fmt.Errorf("invalid PII_ENCRYPTION_KEYS: %w", err)
+		}
+
+		cipher, err := crypto.New(cfg.Encryption.KeyID, keys)
+		if err != nil {
+			return fmt.Errorf("invalid PII_ENCRYPTION_KEYS: %w", err)
+		}
+		c.Cipher = cipher
+	}
+
+	return nil
+}
+
+// initMigrator sets up the migrator and applies pending migrations with DB_AUTO_MIGRATE,
+// the database must be connected
+func (c *Container) initMigrator() error {
+	if c.Migrator != nil {
+		return nil
+	}
+
+	migrator, err := database.NewMigrator(c.DB.Pool, c.Log)
+	if err != nil {
+		return err
+	}
+	c.Migrator = migrator
+
+	if c.Config.Database.AutoMigrate {
+		if err := migrator.Up(); err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Container) initRepositories(ctx context.Context) error {
+	if c.AuthRepo == nil {
+		c.AuthRepo = auth.NewAuthRepository(c.queryDB())
+	}
+	if c.UserRepo == nil {
+		c.UserRepo = user.NewUserRepositry(c.queryDB())
+	}
+	if c.TrainingRepo == nil {
+		c.TrainingRepo = training.NewTrainingRepositry(c.queryDB())
+	}
+	if c.OrganizationRepo == nil {
+		c.OrganizationRepo = organization.NewOrganizationRepositry(c.queryDB())
+	}
+	if c.WarehouseRepo == nil {
+		// Export scans run for minutes, they are not held to the per query deadline
+		c.WarehouseRepo = warehouse.NewWarehouseRepositry(c.DB.Pool)
+	}
+	if c.DigestRepo == nil {
+		c.DigestRepo = digest.NewDigestRepositry(c.queryDB())
+	}
+	if c.StatsRepo == nil {
+		c.StatsRepo = stats.NewStatsRepositry(c.queryDB())
+	}
+	if c.DeviceRepo == nil {
+		c.DeviceRepo = device.NewDeviceRepositry(c.queryDB(), c.Cipher)
+	}
+	if c.EquipmentRepo == nil {
+		c.EquipmentRepo = equipment.NewEquipmentRepositry(c.queryDB())
+	}
+	if c.CoachRepo == nil {
+		c.CoachRepo = coach.NewCoachRepositry(c.queryDB())
+	}
+	if c.InjuryRepo == nil {
+		c.InjuryRepo = injury.NewInjuryRepositry(c.queryDB(), c.Cipher)
+	}
+	if c.RaceRepo == nil {
+		c.RaceRepo = race.NewRaceRepositry(c.queryDB())
+	}
+	if c.ConsentRepo == nil {
+		c.ConsentRepo = consent.NewConsentRepositry(c.queryDB())
+	}
+	if c.AuditRepo == nil {
+		c.AuditRepo = audit.NewAuditRepositry(c.queryDB())
+	}
+	if c.AdminRepo == nil {
+		c.AdminRepo = admin.NewAdminRepositry(c.queryDB())
+	}
+	if c.AbuseRepo == nil {
+		c.AbuseRepo = abuse.NewAbuseRepositry(c.queryDB())
+	}
+	if c.PoolRepo == nil {
+		c.PoolRepo = pool.NewPoolRepositry(c.queryDB())
+	}
+	if c.BillingRepo == nil {
+		c.BillingRepo = billing.NewBillingRepositry(c.queryDB())
+	}
+
+	return nil
+}
+
+// queryDB returns the pool with the configured per query deadline and the circuit breaker applied
+func (c *Container) queryDB() database.DBTX {
+	return database.WithBreaker(database.WithQueryTimeout(c.DB.Pool, c.Config.Database.QueryTimeout), c.Breaker)
+}
+
+func (c *Container) initUsecases(ctx context.Context) error {
+	if c.AbuseUsecase == nil {
+		c.AbuseUsecase = abuse.NewAbuseUsecase(c.ConfigStore, c.AbuseRepo)
+	}
+	if c.AuthUsecase == nil {
+		c.AuthUsecase = auth.NewAuthUsecase(c.ConfigStore, c.DB.Pool, c.AuthRepo, c.UserRepo, c.ConsentRepo, c.Publisher, c.Tracker, c.AbuseUsecase, c.Mailer)
+	}
+	if c.UserUsecase == nil {
+		c.UserUsecase = user.NewUserUsecase(c.UserRepo, c.Storage, c.Config.HTTP.BaseURL)
+	}
+	if c.TrainingUsecase == nil {
+		lookups := c.Metrics.NewCounter("cache_lookups_total", "Cache lookups by cache and result: hit, miss or error.", "cache", "result")
+		c.TrainingUsecase = training.NewTrainingUsecase(c.DB.Pool, c.TrainingRepo, c.UserRepo, c.Publisher, c.Cache, c.Config.Cache.TrainingTTL, c.Config.Cache.TrainingCountTTL, lookups, c.Storage, c.Config.HTTP.BaseURL, c.Config.Storage.SignTTL, c.Tracker, c.Weather, c.AbuseUsecase)
+	}
+	if c.WarehouseUsecase == nil {
+		c.WarehouseUsecase = warehouse.NewWarehouseUsecase(c.Config.Warehouse, c.WarehouseRepo, c.Storage)
+	}
+	if c.DigestUsecase == nil {
+		c.DigestUsecase = digest.NewDigestUsecase(c.Config.Digest, c.DigestRepo, c.Mailer)
+	}
+	if c.StatsUsecase == nil {
+		c.StatsUsecase = stats.NewStatsUsecase(c.StatsRepo, c.Config.TrainingLoad, c.Publisher)
+	}
+	if c.DeviceUsecase == nil {
+		c.DeviceUsecase = device.NewDeviceUsecase(c.DeviceRepo, c.TrainingUsecase)
+	}
+	if c.EquipmentUsecase == nil {
+		c.EquipmentUsecase = equipment.NewEquipmentUsecase(c.DB.Pool, c.EquipmentRepo)
+	}
+	if c.CoachUsecase == nil {
+		c.CoachUsecase = coach.NewCoachUsecase(c.CoachRepo)
+	}
+	if c.InjuryUsecase == nil {
+		c.InjuryUsecase = injury.NewInjuryUsecase(c.InjuryRepo, c.CoachUsecase)
+	}
+	if c.RaceUsecase == nil {
+		c.RaceUsecase = race.NewRaceUsecase(c.RaceRepo)
+	}
+	if c.ConsentUsecase == nil {
+		c.ConsentUsecase = consent.NewConsentUsecase(c.ConfigStore, c.ConsentRepo, c.Cache)
+	}
+	if c.AdminUsecase == nil {
+		c.AdminUsecase = admin.NewAdminUsecase(c.ConfigStore, c.AdminRepo, c.AuditRepo)
+	}
+	if c.UsageUsecase == nil {
+		c.UsageUsecase = usage.NewUsageUsecase(c.ConfigStore, c.MeteringStore)
+	}
+	if c.PoolUsecase == nil {
+		c.PoolUsecase = pool.NewPoolUsecase(c.PoolRepo, c.PubSub)
+	}
+	if c.BillingUsecase == nil {
+		c.BillingUsecase = billing.NewBillingUsecase(c.DB.Pool, c.Config.Billing, c.BillingRepo, c.Payments)
+	}
+
+	return nil
+}
+
+func (c *Container) initHandlers(ctx context.Context) error {
+	if c.HealthHandler == nil {
+		c.HealthHandler = health.NewHealthHandler(c.Log, c.Config.Database.HealthTimeout, c.Config.Database.HealthVerbose)
+		c.HealthHandler.Register("database", func(ctx context.Context) error {
+			err := c.DB.Ping(ctx)
+			if errors.Is(err, database.ErrConnecting) {
+				return fmt.Errorf("%w: %w", health.ErrDegraded, err)
+			}
+			return err
+		})
+		c.HealthHandler.Register("database_circuit", func(ctx context.Context) error {
+			// Half-open reports ready so traffic can probe the database again
+			if c.Breaker.Open() {
+				return database.ErrCircuitOpen
+			}
+			return nil
+		})
+		c.HealthHandler.Register("migrations", func(ctx context.Context) error {
+			select {
+			case <-c.started:
+				if c.startErr != nil {
+					return c.startErr
+				}
+			default:
+				return fmt.Errorf("%w: waiting for the database", health.ErrDegraded)
+			}
+
+			status, err := c.Migrator.Status()
+			switch {
+			case err != nil:
+				return err
+			case status.Dirty:
+				return fmt.Errorf("schema version %d is dirty", status.Version)
+			case status.Pending > 0:
+				return fmt.Errorf("%d pending migrations", status.Pending)
+			}
+			return nil
+		})
+
+		if c.Redis != nil {
+			c.HealthHandler.Register("cache", func(ctx context.Context) error {
+				return c.Redis.Ping(ctx).Err()
+			})
+		}
+	}
+	if c.SwaggerHandler == nil {
+		swaggerHandler, err := swagger.NewSwaggerHandler(c.Config)
+		if err != nil {
+			return err
+		}
+		c.SwaggerHandler = swaggerHandler
+	}
+	if c.AuthHandler == nil {
+		c.AuthHandler = auth.NewAuthHandler(
+			c.AuthUsecase, c.Config.RateLimit.KeyHeader, c.Config.RateLimit.TrustedProxies, c.Config.Auth.CountryHeader,
+		)
+	}
+	if c.UserHandler == nil {
+		c.UserHandler = user.NewUserHandler(c.UserUsecase)
+	}
+	if c.TrainingHandler == nil {
+		c.TrainingHandler = training.NewTrainingHandler(c.TrainingUsecase)
+	}
+	if c.MediaHandler == nil {
+		c.MediaHandler = media.NewMediaHandler(c.Storage, c.Config.Storage.SignTTL)
+	}
+	if c.EventHandler == nil {
+		c.EventHandler = event.NewEventHandler(c.Tracker)
+	}
+	if c.StatsHandler == nil {
+		c.StatsHandler = stats.NewStatsHandler(c.StatsUsecase)
+	}
+	if c.DeviceHandler == nil {
+		c.DeviceHandler = device.NewDeviceHandler(c.DeviceUsecase)
+	}
+	if c.EquipmentHandler == nil {
+		c.EquipmentHandler = equipment.NewEquipmentHandler(c.EquipmentUsecase)
+	}
+	if c.CoachHandler == nil {
+		c.CoachHandler = coach.NewCoachHandler(c.CoachUsecase)
+	}
+	if c.InjuryHandler == nil {
+		c.InjuryHandler = injury.NewInjuryHandler(c.InjuryUsecase)
+	}
+	if c.RaceHandler == nil {
+		c.RaceHandler = race.NewRaceHandler(c.RaceUsecase)
+	}
+	if c.ConsentHandler == nil {
+		c.ConsentHandler = consent.NewConsentHandler(c.ConsentUsecase)
+	}
+	if c.AdminHandler == nil {
+		c.AdminHandler = admin.NewAdminHandler(c.AdminUsecase)
+	}
+	if c.AbuseHandler == nil {
+		c.AbuseHandler = abuse.NewAbuseHandler(c.AbuseUsecase)
+	}
+	if c.UsageHandler == nil {
+		c.UsageHandler = usage.NewUsageHandler(c.UsageUsecase)
+	}
+	if c.PoolHandler == nil {
+		c.PoolHandler = pool.NewPoolHandler(c.PoolUsecase)
+	}
+	if c.BillingHandler == nil {
+		c.BillingHandler = billing.NewBillingHandler(c.BillingUsecase)
+	}
+
+	return nil
+}
+
+func (c *Container) initJobs(ctx context.Context) error {
+	if c.Scheduler != nil {
+		return nil
+	}
+
+	cfg := c.Config.Scheduler
+	c.Scheduler = scheduler.New(c.Log)
+
+	if !cfg.Enabled {
+		return nil
+	}
+
+	purged := c.Metrics.NewCounter("sessions_purged_total", "Expired sessions purged by kind and action: deleted or archived.", "kind", "action")
+	if cfg.SessionPurge.Enabled {
+		c.Scheduler.Register(auth.NewSessionPurgeJob(cfg.SessionPurge, c.AuthRepo, purged))
+	}
+	if cfg.GuestPurge.Enabled {
+		c.Scheduler.Register(auth.NewGuestPurgeJob(cfg.GuestPurge, c.AuthRepo, purged))
+	}
+	if cfg.WarehouseExport.Enabled {
+		c.Scheduler.Register(warehouse.NewExportJob(cfg.WarehouseExport, c.WarehouseUsecase))
+	}
+	if cfg.WeeklyDigest.Enabled {
+		c.Scheduler.Register(digest.NewWeeklyDigestJob(cfg.WeeklyDigest, c.DigestUsecase))
+	}
+	if cfg.TrainingLoadAlerts.Enabled {
+		c.Scheduler.Register(stats.NewTrainingLoadAlertJob(cfg.TrainingLoadAlerts, c.StatsUsecase))
+	}
+
+	return nil
+}
@@ -0,0 +1,572 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database/seed"
+	"github.com/rizkyharahap/swimo/internal/app"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// placeholderID names a resource that doesn't exist, for path parameters nothing was found for
+const placeholderID = "00000000-0000-4000-8000-000000000000"
+
+// TestContract boots the server on a seeded database and calls every operation of the served
+// document: secured operations must reject a request without a token with 401, and every
+// response must have a documented status and match its schema. It needs a database, run it
+// with make contract or:
+//
+//	CONTRACT_TEST=1 go test ./internal/app -run TestContract
+//
+// An embedded Postgres is started unless DATABASE_URL or DB_HOST point to one, its content is
+// changed by the calls so don't point it to a database you care about.
+func TestContract(t *testing.T) {
+	if os.Getenv("CONTRACT_TEST") != "1" {
+		t.Skip("set CONTRACT_TEST=1 to run the contract tests against a database")
+	}
+
+	h := newHarness(t)
+
+	ops := h.operations()
+	if len(ops) == 0 {
+		t.Fatal("the served document has no operation")
+	}
+
+	// Nothing is changed by requests rejected for their missing token, they run first
+	for _, op := range ops {
+		if len(op.schemes) == 0 {
+			continue
+		}
+		t.Run("security/"+op.String(), func(t *testing.T) {
+			h.checkSecurity(t, op)
+		})
+	}
+
+	for _, op := range ops {
+		t.Run("response/"+op.String(), func(t *testing.T) {
+			h.checkResponse(t, op)
+		})
+	}
+}
+
+type harness struct {
+	doc      *openapi3.T
+	baseURL  string
+	basePath string
+
+	userToken   string
+	adminToken  string
+	deviceToken string
+
+	// ids of the resources created during the run, by collection path and by its last segment
+	created map[string]string
+}
+
+// contractOp is a documented operation and the security schemes it accepts
+type contractOp struct {
+	method   string
+	path     string
+	item     *openapi3.PathItem
+	op       *openapi3.Operation
+	schemes  []string
+	pathArgs []*openapi3.Parameter
+}
+
+func (o *contractOp) String() string {
+	return o.method + " " + o.path
+}
+
+func newHarness(t *testing.T) *harness {
+	ctx := context.Background()
+
+	defaults := map[string]string{
+		"JWT_SECRET":             "contract-test-secret-at-least-32-characters",
+		"STORAGE_LOCAL_DIR":      t.TempDir(),
+		"DB_EMBEDDED_PORT":       "5434",
+		"HTTP_VALIDATE_REQUESTS": "true",
+	}
+	if os.Getenv("DATABASE_URL") == "" && os.Getenv("DB_HOST") == "" {
+		defaults["DB_EMBEDDED"] = "true"
+	}
+	for key, value := range defaults {
+		if _, ok := os.LookupEnv(key); !ok {
+			t.Setenv(key, value)
+		}
+	}
+	// Pending legal documents would answer 428 to every call
+	t.Setenv("TERMS_VERSION", "")
+	t.Setenv("PRIVACY_VERSION", "")
+	t.Setenv("RATE_LIMIT_ENABLED", "false")
+	t.Setenv("SCHEDULER_ENABLED", "false")
+
+	cfg, err := config.Load(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("invalid configuration: %v", err)
+	}
+	cfg.Database.AutoMigrate = true
+
+	log := logger.New(logger.Config{Level: "error", Format: "text"})
+
+	container, err := app.New(ctx, cfg, log)
+	if err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	t.Cleanup(func() { container.Close() })
+
+	if err := seed.Run(ctx, container.DB.Pool, log, seed.DefaultOptions()); err != nil {
+		t.Fatalf("failed to seed: %v", err)
+	}
+
+	server := httptest.NewServer(container.Handler())
+	t.Cleanup(server.Close)
+
+	doc := container.SwaggerHandler.Document()
+	h := &harness{
+		doc:     doc,
+		baseURL: server.URL,
+		created: map[string]string{},
+	}
+	if len(doc.Servers) > 0 {
+		u, err := url.Parse(doc.Servers[0].URL)
+		if err != nil {
+			t.Fatalf("invalid server URL: %v", err)
+		}
+		h.basePath = strings.TrimSuffix(u.Path, "/")
+	}
+
+	opts := seed.DefaultOptions()
+	h.adminToken = h.signIn(t, opts.AdminEmail, opts.AdminPassword)
+	h.userToken = h.signIn(t, "budi@swimo.dev", opts.UserPassword)
+
+	return h
+}
+
+func (h *harness) signIn(t *testing.T, email, password string) string {
+	body, _ := json.Marshal(map[string]string{"email": email, "password": password})
+
+	res, err := http.Post(h.baseURL+h.basePath+"/sign-in", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("sign in %s: %v", email, err)
+	}
+	defer res.Body.Close()
+
+	var out struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil || res.StatusCode != http.StatusOK || out.Data.Token == "" {
+		t.Fatalf("sign in %s: status %d, %v", email, res.StatusCode, err)
+	}
+	return out.Data.Token
+}
+
+// operations lists the documented operations in the order they are called: reads before the
+// writes they would see, resources created before they are read by id, deletes and sign out last
+func (h *harness) operations() []*contractOp {
+	var ops []*contractOp
+	for p, item := range h.doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			o := &contractOp{method: method, path: p, item: item, op: op}
+
+			security := h.doc.Security
+			if op.Security != nil {
+				security = *op.Security
+			}
+			for _, requirement := range security {
+				for scheme := range requirement {
+					o.schemes = append(o.schemes, scheme)
+				}
+			}
+			sort.Strings(o.schemes)
+
+			for _, ref := range append(item.Parameters, op.Parameters...) {
+				if ref.Value != nil && ref.Value.In == openapi3.ParameterInPath {
+					o.pathArgs = append(o.pathArgs, ref.Value)
+				}
+			}
+			ops = append(ops, o)
+		}
+	}
+
+	rank := func(o *contractOp) int {
+		switch {
+		case strings.HasSuffix(o.path, "/sign-out"):
+			return 5
+		case o.method == http.MethodDelete:
+			return 4
+		case len(o.pathArgs) == 0 && o.method == http.MethodGet:
+			return 0
+		case len(o.pathArgs) == 0:
+			return 1
+		case o.method == http.MethodGet:
+			return 2
+		default:
+			return 3
+		}
+	}
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ri, rj := rank(ops[i]), rank(ops[j]); ri != rj {
+			return ri < rj
+		}
+		if ops[i].path != ops[j].path {
+			return ops[i].path < ops[j].path
+		}
+		return ops[i].method < ops[j].method
+	})
+
+	return ops
+}
+
+// checkSecurity sends op without credentials, it must be rejected before reaching the handler
+func (h *harness) checkSecurity(t *testing.T, op *contractOp) {
+	res, body := h.do(t, op, "")
+
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("documented with %v, got %d without a token: %s", op.schemes, res.StatusCode, body)
+	}
+
+	// 401 is answered by the auth middleware, most operations leave it out of their responses
+	if schema := h.doc.Components.Schemas["response.Error"]; schema != nil && schema.Value != nil {
+		var value any
+		if err := json.Unmarshal(body, &value); err != nil {
+			t.Fatalf("401 body is not JSON: %v", err)
+		}
+		if err := schema.Value.VisitJSON(value); err != nil {
+			t.Fatalf("401 body doesn't match response.Error: %v", err)
+		}
+	}
+}
+
+// checkResponse sends op as the caller it is meant for, the response must be documented
+func (h *harness) checkResponse(t *testing.T, op *contractOp) {
+	res, body := h.do(t, op, h.token(op))
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		t.Fatalf("got %d: %s", res.StatusCode, body)
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request: res.Request,
+			Route: &routers.Route{
+				Spec:      h.doc,
+				Path:      op.path,
+				PathItem:  op.item,
+				Method:    op.method,
+				Operation: op.op,
+			},
+		},
+		Status: res.StatusCode,
+		Header: res.Header,
+		Body:   io.NopCloser(bytes.NewReader(body)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+			// Files are checked by status only, swagger 2 gives their errors the file content type
+			ExcludeResponseBody: !producesJSON(op.op),
+		},
+	}
+	if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+		t.Fatalf("status %d doesn't match the document: %v\n%s", res.StatusCode, err, body)
+	}
+
+	h.remember(op, res.StatusCode, body)
+}
+
+// token returns the credentials op is called with: admins for the admin endpoints, the paired
+// device for device endpoints and the demo user otherwise
+func (h *harness) token(op *contractOp) string {
+	switch {
+	case len(op.schemes) == 0:
+		return ""
+	case strings.HasPrefix(op.path, "/admin/"):
+		return h.adminToken
+	case op.schemes[0] == "DeviceToken":
+		return h.deviceToken
+	default:
+		return h.userToken
+	}
+}
+
+// remember keeps the id of a created resource for the operations taking it in their path
+func (h *harness) remember(op *contractOp, status int, body []byte) {
+	if op.method != http.MethodPost || len(op.pathArgs) > 0 || status >= http.StatusMultipleChoices {
+		return
+	}
+
+	var out struct {
+		Data struct {
+			ID    string `json:"id"`
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(body, &out) != nil || out.Data.ID == "" {
+		return
+	}
+
+	h.created[op.path] = out.Data.ID
+	h.created[path.Base(op.path)] = out.Data.ID
+	if out.Data.Token != "" && path.Base(op.path) == "devices" {
+		h.deviceToken = out.Data.Token
+	}
+}
+
+// do sends op with values built from the document, authenticated with token when not empty
+func (h *harness) do(t *testing.T, op *contractOp, token string) (*http.Response, []byte) {
+	t.Helper()
+
+	p := op.path
+	for _, param := range op.pathArgs {
+		p = strings.Replace(p, "{"+param.Name+"}", url.PathEscape(h.pathValue(t, op, param)), 1)
+	}
+
+	query := url.Values{}
+	for _, ref := range op.op.Parameters {
+		param := ref.Value
+		if param == nil || param.In != openapi3.ParameterInQuery || !param.Required {
+			continue
+		}
+		query.Set(param.Name, fmt.Sprint(sample(param.Schema, 0)))
+	}
+
+	target := h.baseURL + h.basePath + p
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	body, contentType, err := requestBody(op.op)
+	if err != nil {
+		t.Fatalf("failed to build the request body: %v", err)
+	}
+
+	req, err := http.NewRequest(op.method, target, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		// Redirects are responses of their own, ex: media served from a signed URL
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read the response: %v", err)
+	}
+	return res, data
+}
+
+// pathValue returns an existing id for param: one created earlier in the run or the first
+// item listed by the collection, else the example of the parameter or a missing id
+func (h *harness) pathValue(t *testing.T, op *contractOp, param *openapi3.Parameter) string {
+	collection, _, _ := strings.Cut(op.path, "/{"+param.Name+"}")
+
+	if id := h.created[collection]; id != "" {
+		return id
+	}
+	if id := h.firstListed(t, collection, op); id != "" {
+		return id
+	}
+	if id := h.created[path.Base(collection)]; id != "" {
+		return id
+	}
+
+	if param.Example != nil {
+		return fmt.Sprint(param.Example)
+	}
+	if param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Example != nil {
+		return fmt.Sprint(param.Schema.Value.Example)
+	}
+	return placeholderID
+}
+
+// firstListed returns the id of the first item of the documented list at collection
+func (h *harness) firstListed(t *testing.T, collection string, op *contractOp) string {
+	item := h.doc.Paths.Value(collection)
+	if item == nil || item.Get == nil {
+		return ""
+	}
+
+	list := &contractOp{method: http.MethodGet, path: collection, item: item, op: item.Get, schemes: op.schemes}
+	res, body := h.do(t, list, h.token(op))
+	if res.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var out struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(body, &out) != nil || len(out.Data) == 0 {
+		return ""
+	}
+	return out.Data[0].ID
+}
+
+// requestBody builds the documented JSON or multipart body of op from its examples
+func requestBody(op *openapi3.Operation) (io.Reader, string, error) {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil, "", nil
+	}
+	content := op.RequestBody.Value.Content
+
+	if media := content.Get("application/json"); media != nil {
+		data, err := json.Marshal(sample(media.Schema, 0))
+		if err != nil {
+			return nil, "", err
+		}
+		return bytes.NewReader(data), "application/json", nil
+	}
+
+	if media := content.Get("multipart/form-data"); media != nil && media.Schema != nil && media.Schema.Value != nil {
+		var buf bytes.Buffer
+		form := multipart.NewWriter(&buf)
+		for _, name := range sortedKeys(media.Schema.Value.Properties) {
+			prop := media.Schema.Value.Properties[name]
+			if prop.Value != nil && prop.Value.Format == "binary" {
+				part, err := form.CreateFormFile(name, name+".png")
+				if err != nil {
+					return nil, "", err
+				}
+				part.Write([]byte("\x89PNG\r\n\x1a\n"))
+				continue
+			}
+			form.WriteField(name, fmt.Sprint(sample(prop, 0)))
+		}
+		if err := form.Close(); err != nil {
+			return nil, "", err
+		}
+		return &buf, form.FormDataContentType(), nil
+	}
+
+	return nil, "", nil
+}
+
+// sample returns a value valid for ref, its example when documented
+func sample(ref *openapi3.SchemaRef, depth int) any {
+	if ref == nil || ref.Value == nil || depth > 8 {
+		return nil
+	}
+	s := ref.Value
+
+	switch {
+	case s.Example != nil:
+		return s.Example
+	case s.Default != nil:
+		return s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	}
+
+	if len(s.AllOf) > 0 {
+		merged := map[string]any{}
+		for _, part := range s.AllOf {
+			if obj, ok := sample(part, depth+1).(map[string]any); ok {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+
+	switch {
+	case s.Type.Is(openapi3.TypeObject) || len(s.Properties) > 0:
+		obj := map[string]any{}
+		for name, prop := range s.Properties {
+			obj[name] = sample(prop, depth+1)
+		}
+		if len(s.Properties) == 0 && s.AdditionalProperties.Schema != nil {
+			obj["contract"] = sample(s.AdditionalProperties.Schema, depth+1)
+		}
+		return obj
+	case s.Type.Is(openapi3.TypeArray):
+		n := max(int(s.MinItems), 1)
+		items := make([]any, n)
+		for i := range items {
+			items[i] = sample(s.Items, depth+1)
+		}
+		return items
+	case s.Type.Is(openapi3.TypeInteger):
+		if s.Min != nil {
+			return int64(*s.Min)
+		}
+		return 1
+	case s.Type.Is(openapi3.TypeNumber):
+		if s.Min != nil {
+			return *s.Min
+		}
+		return 1
+	case s.Type.Is(openapi3.TypeBoolean):
+		return true
+	}
+
+	switch s.Format {
+	case "date-time":
+		return time.Now().UTC().Format(time.RFC3339)
+	case "date":
+		return time.Now().UTC().Format(time.DateOnly)
+	case "uuid":
+		return placeholderID
+	case "email":
+		return "contract@swimo.dev"
+	}
+	return strings.Repeat("x", max(int(s.MinLength), 8))
+}
+
+// producesJSON reports whether the successful responses of op are JSON
+func producesJSON(op *openapi3.Operation) bool {
+	for _, ref := range op.Responses.Map() {
+		if ref.Value == nil {
+			continue
+		}
+		for mime := range ref.Value.Content {
+			if strings.Contains(mime, "json") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
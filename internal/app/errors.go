@@ -0,0 +1,138 @@
+package app
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/abuse"
+	"github.com/rizkyharahap/swimo/internal/admin"
+	"github.com/rizkyharahap/swimo/internal/auth"
+	"github.com/rizkyharahap/swimo/internal/billing"
+	"github.com/rizkyharahap/swimo/internal/coach"
+	"github.com/rizkyharahap/swimo/internal/consent"
+	"github.com/rizkyharahap/swimo/internal/device"
+	"github.com/rizkyharahap/swimo/internal/equipment"
+	"github.com/rizkyharahap/swimo/internal/injury"
+	"github.com/rizkyharahap/swimo/internal/organization"
+	"github.com/rizkyharahap/swimo/internal/pool"
+	"github.com/rizkyharahap/swimo/internal/race"
+	"github.com/rizkyharahap/swimo/internal/stats"
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/internal/usage"
+	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/payment"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/scanner"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+)
+
+// errorCatalog is the single list of domain errors exposed to clients. Codes are part of the
+// API contract: mobile clients branch on them, so never rename one, add a new code instead.
+var errorCatalog = []response.CatalogEntry{
+	// Auth
+	{Err: auth.ErrAccountExists, Status: http.StatusConflict, Code: "ACCOUNT_EXISTS", Message: "Email already exists"},
+	{Err: auth.ErrUserExists, Status: http.StatusConflict, Code: "ACCOUNT_EXISTS", Message: "Email already exists"},
+	{Err: auth.ErrAlertTokenInvalid, Status: http.StatusNotFound, Code: "ALERT_TOKEN_INVALID", Message: "Link is invalid or expired"},
+	{Err: auth.ErrInvalidCreds, Status: http.StatusUnauthorized, Code: "INVALID_CREDENTIALS", Message: "Invalid email or password"},
+	{Err: auth.ErrLocked, Status: http.StatusForbidden, Code: "ACCOUNT_LOCKED", Message: "Your account has been locked"},
+	{Err: auth.ErrGuestDisabled, Status: http.StatusForbidden, Code: "GUEST_DISABLED", Message: "Guest sign in disabled"},
+	{Err: auth.ErrGuestLimited, Status: http.StatusTooManyRequests, Code: "GUEST_LIMIT_REACHED", Message: "Guest session limit reached"},
+	{Err: auth.ErrExpiredRefreshToken, Status: http.StatusUnauthorized, Code: "REFRESH_TOKEN_INVALID", Message: "Invalid or expired refresh token"},
+	{Err: abuse.ErrSignUpRequired, Status: http.StatusForbidden, Code: "SIGN_UP_REQUIRED", Message: "Sign up to continue"},
+
+	// User
+	{Err: user.ErrUserNotFound, Status: http.StatusNotFound, Code: "USER_NOT_FOUND", Message: "User not found"},
+	{Err: user.ErrUserExists, Status: http.StatusConflict, Code: "ACCOUNT_EXISTS", Message: "Email already exists"},
+	{Err: user.ErrGenderInvalid, Status: http.StatusUnprocessableEntity, Code: "GENDER_INVALID", Message: "Gender must be male or female"},
+	{Err: user.ErrAvatarType, Status: http.StatusUnsupportedMediaType, Code: "MEDIA_TYPE_UNSUPPORTED", Message: "Avatar must be a JPEG, PNG or WebP image"},
+
+	// Training
+	{Err: training.ErrTrainingNotFound, Status: http.StatusNotFound, Code: "TRAINING_NOT_FOUND", Message: "Training not found"},
+	{Err: training.ErrTrainingCategoryNotFound, Status: http.StatusNotFound, Code: "TRAINING_NOT_FOUND", Message: "Training not found"},
+	{Err: training.ErrGuestSubmission, Status: http.StatusForbidden, Code: "GUEST_SUBMISSION", Message: "Guests cannot submit trainings"},
+	{Err: training.ErrNotTrainingAuthor, Status: http.StatusForbidden, Code: "NOT_TRAINING_AUTHOR", Message: "Only the author of the training can change it"},
+	{Err: training.ErrTrainingNotPending, Status: http.StatusConflict, Code: "TRAINING_NOT_PENDING", Message: "Training is not pending review"},
+	{Err: training.ErrorTrainingExists, Status: http.StatusConflict, Code: "TRAINING_EXISTS", Message: "Training already exists"},
+	{Err: training.ErrMediaType, Status: http.StatusUnsupportedMediaType, Code: "MEDIA_TYPE_UNSUPPORTED", Message: "Thumbnail must be a JPEG, PNG or WebP image and video a MP4, WebM or QuickTime file"},
+	{Err: training.ErrTrainingSessionNotFound, Status: http.StatusNotFound, Code: "TRAINING_SESSION_NOT_FOUND", Message: "No training sessions found"},
+	{Err: training.ErrSessionNotFound, Status: http.StatusNotFound, Code: "SESSION_NOT_FOUND", Message: "Session not found"},
+	{Err: training.ErrNotOpenWater, Status: http.StatusUnprocessableEntity, Code: "SESSION_NOT_OPEN_WATER", Message: "Water conditions are only recorded on open water sessions"},
+	{Err: training.ErrSessionDuplicateNotFound, Status: http.StatusNotFound, Code: "SESSION_DUPLICATE_NOT_FOUND", Message: "Duplicate not found"},
+	{Err: training.ErrSessionNotInDuplicate, Status: http.StatusUnprocessableEntity, Code: "SESSION_NOT_IN_DUPLICATE", Message: "Keep id must be one of the sessions of the duplicate"},
+	{Err: training.ErrSyncInProgress, Status: http.StatusConflict, Code: "SYNC_IN_PROGRESS", Message: "Sessions are being synced by another request, retry"},
+
+	// Organization
+	{Err: organization.ErrOrganizationNotFound, Status: http.StatusNotFound, Code: "ORGANIZATION_NOT_FOUND", Message: "Organization not found"},
+
+	// Device
+	{Err: device.ErrDeviceNotFound, Status: http.StatusNotFound, Code: "DEVICE_NOT_FOUND", Message: "Device not found"},
+	{Err: device.ErrDeviceLimit, Status: http.StatusConflict, Code: "DEVICE_LIMIT_REACHED", Message: "Device limit reached, revoke a device to pair another"},
+	{Err: equipment.ErrEquipmentNotFound, Status: http.StatusNotFound, Code: "EQUIPMENT_NOT_FOUND", Message: "Equipment not found"},
+	{Err: equipment.ErrEquipmentLimit, Status: http.StatusConflict, Code: "EQUIPMENT_LIMIT_REACHED", Message: "Equipment limit reached, delete gear to add more"},
+	{Err: coach.ErrCoachNotFound, Status: http.StatusNotFound, Code: "COACH_NOT_FOUND", Message: "Coach not found"},
+	{Err: coach.ErrCoachSelf, Status: http.StatusUnprocessableEntity, Code: "COACH_SELF", Message: "You cannot be your own coach"},
+	{Err: coach.ErrCoachLimit, Status: http.StatusConflict, Code: "COACH_LIMIT_REACHED", Message: "Coach limit reached, revoke a coach to add another"},
+	{Err: coach.ErrAthleteNotFound, Status: http.StatusNotFound, Code: "ATHLETE_NOT_FOUND", Message: "Athlete not found"},
+	{Err: coach.ErrAssignmentNotFound, Status: http.StatusNotFound, Code: "ASSIGNMENT_NOT_FOUND", Message: "Assignment not found"},
+	{Err: coach.ErrAssignedTrainingNotFound, Status: http.StatusNotFound, Code: "TRAINING_NOT_FOUND", Message: "Training not found"},
+	{Err: coach.ErrAssignmentLimit, Status: http.StatusConflict, Code: "ASSIGNMENT_LIMIT_REACHED", Message: "Assignment limit reached, wait for some to come due"},
+	{Err: consent.ErrVersionOutdated, Status: http.StatusConflict, Code: "CONSENT_VERSION_OUTDATED", Message: "Version is not the current one, reload the document"},
+	{Err: injury.ErrInjuryNotFound, Status: http.StatusNotFound, Code: "INJURY_NOT_FOUND", Message: "Injury not found"},
+	{Err: race.ErrRaceNotFound, Status: http.StatusNotFound, Code: "RACE_NOT_FOUND", Message: "Race not found"},
+	{Err: race.ErrRaceHasEntries, Status: http.StatusConflict, Code: "RACE_HAS_ENTRIES", Message: "Swimmers registered to the race, it can no longer be deleted"},
+	{Err: race.ErrRegistrationClosed, Status: http.StatusConflict, Code: "RACE_REGISTRATION_CLOSED", Message: "The race has ended, registration is closed"},
+	{Err: race.ErrSubmissionClosed, Status: http.StatusConflict, Code: "RACE_SUBMISSION_CLOSED", Message: "Results are not accepted for this race now"},
+	{Err: race.ErrEntryNotFound, Status: http.StatusNotFound, Code: "RACE_ENTRY_NOT_FOUND", Message: "You are not registered to this race"},
+	{Err: race.ErrSessionNotFound, Status: http.StatusNotFound, Code: "RACE_SESSION_NOT_FOUND", Message: "Session not found"},
+	{Err: race.ErrNotFinished, Status: http.StatusConflict, Code: "RACE_NOT_FINISHED", Message: "Submit a qualifying session to get the certificate"},
+	{Err: pool.ErrPoolNotFound, Status: http.StatusNotFound, Code: "POOL_NOT_FOUND", Message: "Pool not found"},
+	{Err: pool.ErrNotAtPool, Status: http.StatusNotFound, Code: "NOT_AT_POOL", Message: "No pool around your location, scan the QR code of the pool"},
+	{Err: pool.ErrNotCheckedIn, Status: http.StatusNotFound, Code: "NOT_CHECKED_IN", Message: "You are not checked in at a pool"},
+	{Err: pool.ErrLaneNotFound, Status: http.StatusNotFound, Code: "LANE_NOT_FOUND", Message: "Lane not found, publish the lanes of the pool first"},
+	{Err: pool.ErrBoardNotPublished, Status: http.StatusNotFound, Code: "LANES_NOT_PUBLISHED", Message: "Lane occupancy is not published for this pool"},
+	{Err: device.ErrDeviceTokenInvalid, Status: http.StatusUnauthorized, Code: "DEVICE_TOKEN_INVALID", Message: "Invalid or revoked device token"},
+	{Err: device.ErrPayloadType, Status: http.StatusUnsupportedMediaType, Code: "MEDIA_TYPE_UNSUPPORTED", Message: "Payload must be JSON, msgpack or protobuf"},
+
+	// Stats
+	{Err: stats.ErrMaxHeartRateUnknown, Status: http.StatusUnprocessableEntity, Code: "MAX_HEART_RATE_UNKNOWN", Message: "Set your max heart rate or age to compute heart rate zones"},
+
+	// Admin
+	{Err: admin.ErrImpersonateAdmin, Status: http.StatusForbidden, Code: "IMPERSONATE_ADMIN", Message: "Admins cannot be impersonated"},
+	{Err: admin.ErrDeleteAdmin, Status: http.StatusForbidden, Code: "DELETE_ADMIN", Message: "Admins cannot be deleted"},
+	{Err: abuse.ErrFlagNotFound, Status: http.StatusNotFound, Code: "GUEST_FLAG_NOT_FOUND", Message: "Guest flag not found"},
+
+	// Billing
+	{Err: billing.ErrBillingDisabled, Status: http.StatusServiceUnavailable, Code: "BILLING_DISABLED", Message: "Billing is disabled"},
+	{Err: billing.ErrAlreadySubscribed, Status: http.StatusConflict, Code: "ALREADY_SUBSCRIBED", Message: "You are already subscribed to premium"},
+	{Err: billing.ErrPaymentNotFound, Status: http.StatusNotFound, Code: "PAYMENT_NOT_FOUND", Message: "Payment not found"},
+	{Err: billing.ErrFullyRefunded, Status: http.StatusConflict, Code: "PAYMENT_REFUNDED", Message: "Payment is already fully refunded"},
+	{Err: billing.ErrRefundTooLarge, Status: http.StatusConflict, Code: "REFUND_TOO_LARGE", Message: "Amount is more than left to refund"},
+	{Err: billing.ErrRefundKeyReused, Status: http.StatusConflict, Code: "IDEMPOTENCY_KEY_REUSED", Message: "Idempotency key is used by another refund"},
+	{Err: billing.ErrUnknownSubscription, Status: http.StatusConflict, Code: "SUBSCRIPTION_UNKNOWN", Message: "Event delivered before its subscription, retry later"},
+	{Err: payment.ErrInvalidSignature, Status: http.StatusBadRequest, Code: "INVALID_SIGNATURE", Message: "Invalid webhook signature"},
+	{Err: payment.ErrUnavailable, Status: http.StatusServiceUnavailable, Code: response.CodeUnavailable, Message: "Service temporarily unavailable"},
+
+	// Usage
+	{Err: usage.ErrMeteringDisabled, Status: http.StatusServiceUnavailable, Code: "METERING_DISABLED", Message: "Usage metering is disabled"},
+
+	// Storage
+	{Err: storage.ErrTooLarge, Status: http.StatusRequestEntityTooLarge, Code: response.CodePayloadTooLarge, Message: "File too large"},
+	{Err: storage.ErrUploadsDisabled, Status: http.StatusServiceUnavailable, Code: "UPLOADS_DISABLED", Message: "File uploads are disabled"},
+	{Err: scanner.ErrInfected, Status: http.StatusUnprocessableEntity, Code: "FILE_REJECTED", Message: "File rejected by the malware scan"},
+	{Err: scanner.ErrUnavailable, Status: http.StatusServiceUnavailable, Code: response.CodeUnavailable, Message: "Service temporarily unavailable"},
+
+	// Database
+	{Err: database.ErrQueryTimeout, Status: http.StatusServiceUnavailable, Code: "QUERY_TIMEOUT", Message: "The request took too long, try again or narrow the query"},
+	{Err: database.ErrCircuitOpen, Status: http.StatusServiceUnavailable, Code: response.CodeUnavailable, Message: "Service temporarily unavailable"},
+	{Err: database.ErrStaleVersion, Status: http.StatusConflict, Code: "STALE_VERSION", Message: "Modified since you read it, reload and try again"},
+}
+
+var registerErrors sync.Once
+
+// registerErrorCatalog makes the catalog available to response.Err and the gRPC error conversion
+func registerErrorCatalog() {
+	registerErrors.Do(func() {
+		response.RegisterErrors(errorCatalog...)
+	})
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/internal/auth"
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/pkg/grpcserver"
+)
+
+// GRPCServer builds the gRPC server exposing the same usecases as the HTTP handlers
+func (c *Container) GRPCServer() *grpcserver.Server {
+	// Domain errors are converted with the same catalog as HTTP responses
+	registerErrorCatalog()
+
+	// Proxies only count when the HTTP side is configured to read the forwarded header
+	proxies := 0
+	if c.Config.RateLimit.KeyHeader != "" {
+		proxies = c.Config.RateLimit.TrustedProxies
+	}
+
+	return grpcserver.NewServer(c.Config.GRPC, c.Log,
+		grpcserver.Options{
+			Keys: func() map[string]string {
+				return c.ConfigStore.Load().Auth.JWTKeys()
+			},
+			PublicMethods:  auth.PublicMethods,
+			RateLimitStore: c.RateLimitStore,
+			RateLimits: func() (int, time.Duration) {
+				rl := c.ConfigStore.Load().RateLimit
+				return rl.AuthMax, rl.AuthWindow
+			},
+			TrustedProxies: proxies,
+		},
+		auth.NewAuthGRPCServer(c.AuthUsecase),
+		training.NewTrainingGRPCServer(c.TrainingUsecase),
+	)
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/internal/billing"
+	"github.com/rizkyharahap/swimo/internal/consent"
+	"github.com/rizkyharahap/swimo/internal/health"
+	"github.com/rizkyharahap/swimo/internal/organization"
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/router"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+// Handler builds the HTTP handler with every module route and the global middlewares
+func (c *Container) Handler() http.Handler {
+	cfg := c.Config
+
+	// Domain errors rendered by response.Err
+	registerErrorCatalog()
+
+	// Create router
+	mux := http.NewServeMux()
+
+	// Setup routes
+	router.Register(mux, c.routeMiddlewares(), c.Modules()...)
+
+	if cfg.Metrics.Enabled {
+		mux.Handle("GET "+cfg.Metrics.Path, c.Metrics.Handler())
+	}
+
+	requestDuration := c.Metrics.NewHistogram(
+		"http_request_duration_seconds",
+		"HTTP request latency by route pattern.",
+		nil,
+		"method", "route", "status",
+	)
+
+	// Requests to a school subdomain are scoped to its organization
+	tenancy := func(next http.Handler) http.Handler { return next }
+	if cfg.Tenancy.Enabled {
+		tenancy = middleware.TenantMiddleware(middleware.TenantOptions{
+			BaseDomain: cfg.Tenancy.BaseDomain,
+			Lookup:     organization.NewSlugResolver(c.OrganizationRepo, c.Cache, cfg.Tenancy.CacheTTL),
+		})
+	}
+
+	// Probes and scrapes are polled every few seconds by machines, they skip the middlewares
+	// meant for browsers and API clients
+	probes := []string{health.LivePath, health.ReadyPath, cfg.Metrics.Path}
+
+	// Apply middlewares
+	return middleware.Chain(
+		middleware.RequestIDMiddleware,
+		middleware.AccessLogMiddleware(c.AccessLog, middleware.AccessLogOptions{
+			Format:         cfg.Log.Access.Format,
+			IPHeader:       cfg.RateLimit.KeyHeader,
+			TrustedProxies: cfg.RateLimit.TrustedProxies,
+		}),
+		middleware.LocaleMiddleware,
+		middleware.EncodingMiddleware,
+		middleware.ErrorHandler,
+		middleware.RecoverMiddleware(c.Log),
+		middleware.LoggingMiddleware(c.Log, middleware.LoggingOptions{
+			SlowThreshold: cfg.Log.SlowRequestThreshold,
+			Duration:      requestDuration,
+			SamplePaths:   cfg.Log.SamplePaths,
+		}),
+		middleware.Skip(middleware.DynamicCORSMiddleware(func() config.CORSConfig {
+			return c.ConfigStore.Load().CORS
+		}), probes...),
+		middleware.Skip(middleware.RateLimit(c.RateLimitStore, c.Log, middleware.RateLimitOptions{
+			Name:    "global",
+			KeyFunc: middleware.IPKey(cfg.RateLimit.KeyHeader, cfg.RateLimit.TrustedProxies),
+			Limits: func() (int, time.Duration) {
+				rl := c.ConfigStore.Load().RateLimit
+				return rl.Max, rl.Window
+			},
+		}), probes...),
+		tenancy,
+		middleware.Skip(middleware.CompressionMiddleware(cfg.Compression), probes...),
+		middleware.Skip(middleware.BodyLoggingMiddleware(cfg.Log.Body), probes...),
+	)(middleware.CaptureRoute(mux))
+}
+
+// routeMiddlewares builds the middleware chains shared by module routes
+func (c *Container) routeMiddlewares() router.Middlewares {
+	cfg := c.Config
+
+	// Public endpoints - no authentication required, limited per client IP
+	authRateLimit := middleware.RateLimit(c.RateLimitStore, c.Log, middleware.RateLimitOptions{
+		Name:    "auth",
+		KeyFunc: middleware.IPKey(cfg.RateLimit.KeyHeader, cfg.RateLimit.TrustedProxies),
+		Limits: func() (int, time.Duration) {
+			rl := c.ConfigStore.Load().RateLimit
+			return rl.AuthMax, rl.AuthWindow
+		},
+	})
+
+	// Protected endpoints - require authentication, limited per account with the quota of its kind
+	accountRateLimit := middleware.RateLimit(c.RateLimitStore, c.Log, middleware.RateLimitOptions{
+		Name:    "account",
+		KeyFunc: middleware.AccountKey,
+		LimitsFor: func(r *http.Request) (int, time.Duration) {
+			rl := c.ConfigStore.Load().RateLimit
+			if quota, ok := rl.KindQuotas[middleware.CallerKind(r)]; ok {
+				return quota.Max, quota.Window
+			}
+			return rl.AccountMax, rl.AccountWindow
+		},
+	})
+
+	// Stats and search cost far more than a read, guests get the tightest quota
+	expensiveRateLimit := middleware.RateLimit(c.RateLimitStore, c.Log, middleware.RateLimitOptions{
+		Name:    "expensive",
+		KeyFunc: middleware.AccountKey,
+		LimitsFor: func(r *http.Request) (int, time.Duration) {
+			quota := c.ConfigStore.Load().RateLimit.ExpensiveQuotas[middleware.CallerKind(r)]
+			return quota.Max, quota.Window
+		},
+	})
+
+	// Every request of an account is counted, device requests against its monthly quota
+	meter := middleware.Metering(c.MeteringStore, c.Log, middleware.MeteringOptions{
+		Quota: func() int { return c.ConfigStore.Load().Metering.MonthlyQuota },
+	})
+
+	// Requests are checked against the served document after auth and body limits
+	validate := func(next http.Handler) http.Handler { return next }
+	if cfg.HTTP.ValidateRequests {
+		openAPIValidation, err := middleware.OpenAPIValidation(c.SwaggerHandler.Document())
+		if err != nil {
+			c.Log.Error("OpenAPI request validation disabled", "error", err)
+		} else {
+			validate = openAPIValidation
+		}
+	}
+
+	// JWT keys are read per request so refreshed secrets and rotated keys apply without restart
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			middleware.AuthMiddleware(c.ConfigStore.Load().Auth.JWTKeys(), next).ServeHTTP(w, r)
+		})
+	}
+
+	// Requests of support impersonating a user are audited, read only tokens can't write
+	impersonation := middleware.ImpersonationMiddleware(c.AdminUsecase.RecordImpersonatedRequest)
+
+	// Accounts behind on the legal documents can only read and accept them, or sign out
+	consents := middleware.When(func(r *http.Request) bool {
+		return r.URL.Path != "/api/v1/sign-out" && !strings.HasPrefix(r.URL.Path, consent.Path)
+	}, middleware.ConsentMiddleware(c.ConsentUsecase.Pending))
+
+	// Fail fast while the database is connecting or known to be down
+	available := middleware.Chain(
+		middleware.ReadinessMiddleware(c.Ready, cfg.Database.ConnectRetryMax),
+		middleware.CircuitBreakerMiddleware(c.Breaker),
+	)
+
+	// Premium endpoints are open to every account while billing is disabled
+	premium := func(next http.Handler) http.Handler { return next }
+	if c.Payments != nil {
+		premium = middleware.EntitlementMiddleware(billing.EntitlementPremium, c.BillingUsecase.Entitled)
+	}
+
+	protected := middleware.Chain(
+		available,
+		auth,
+		impersonation,
+		consents,
+		accountRateLimit,
+		meter,
+		middleware.BodyLimit(int64(cfg.HTTP.BodyLimitBytes)),
+		validate,
+	)
+
+	return router.Middlewares{
+		Public: middleware.Chain(
+			available,
+			authRateLimit,
+			middleware.BodyLimit(int64(cfg.HTTP.AuthBodyLimitBytes)),
+			validate,
+		),
+		// Only the global limit per client IP applies, lobby screens share it with the swimmers
+		// on the pool network but not the tight sign in bucket
+		PublicRead: middleware.Chain(
+			available,
+			middleware.BodyLimit(int64(cfg.HTTP.AuthBodyLimitBytes)),
+			validate,
+		),
+		Protected: protected,
+		Expensive: middleware.Chain(
+			protected,
+			expensiveRateLimit,
+		),
+		Premium: middleware.Chain(
+			protected,
+			expensiveRateLimit,
+			premium,
+		),
+		Admin: middleware.Chain(
+			protected,
+			middleware.RequireRole(security.RoleAdmin),
+		),
+		// Multipart bodies are streamed to storage, they are not checked against the document
+		Upload: middleware.Chain(
+			available,
+			auth,
+			impersonation,
+			consents,
+			accountRateLimit,
+			meter,
+			middleware.BodyLimit(cfg.Storage.MaxUploadBytes),
+		),
+		// Binary batches from watches are not checked against the document. Devices sign
+		// batches with the secret shared at pairing, which is never sent, so a batch captured
+		// on the way can't be sent again or forged.
+		Device: middleware.Chain(
+			available,
+			middleware.DeviceAuthMiddleware(c.DeviceUsecase.Authenticate),
+			accountRateLimit,
+			meter,
+			middleware.BodyLimit(int64(cfg.HTTP.BodyLimitBytes)),
+			middleware.ReplayProtection(c.NonceStore, c.Log, middleware.ReplayOptions{
+				Window:  cfg.Replay.Window,
+				Secret:  middleware.DeviceSigningSecret,
+				KeyFunc: middleware.AccountKey,
+			}),
+		),
+		// Payloads are verified against their signature byte for byte, they are not checked
+		// against the document
+		Webhook: middleware.Chain(
+			available,
+			middleware.BodyLimit(int64(cfg.HTTP.BodyLimitBytes)),
+		),
+	}
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/auth"
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+	"github.com/rizkyharahap/swimo/pkg/broker"
+	"github.com/rizkyharahap/swimo/pkg/cache"
+	"github.com/rizkyharahap/swimo/pkg/crypto"
+	"github.com/rizkyharahap/swimo/pkg/mailer"
+	"github.com/rizkyharahap/swimo/pkg/metering"
+	"github.com/rizkyharahap/swimo/pkg/payment"
+	"github.com/rizkyharahap/swimo/pkg/pubsub"
+	"github.com/rizkyharahap/swimo/pkg/ratelimit"
+	"github.com/rizkyharahap/swimo/pkg/secrets"
+	"github.com/rizkyharahap/swimo/pkg/weather"
+)
+
+// WithSecretsResolver reuses the resolver that loaded the startup config,
+// so reloads and refreshes know which values are secret references
+func WithSecretsResolver(resolver *secrets.Resolver) Option {
+	return func(c *Container) { c.Secrets = resolver }
+}
+
+// WithDatabase uses an already connected database instead of connecting from config
+func WithDatabase(db *database.Database) Option {
+	return func(c *Container) { c.DB = db }
+}
+
+// WithCache overrides the cache driver selected in config
+func WithCache(cache cache.Cache) Option {
+	return func(c *Container) { c.Cache = cache }
+}
+
+// WithRateLimitStore overrides the rate limit store selected in config
+func WithRateLimitStore(store ratelimit.Store) Option {
+	return func(c *Container) { c.RateLimitStore = store }
+}
+
+// WithNonceStore overrides the replay protection nonce store selected in config
+func WithNonceStore(store ratelimit.Store) Option {
+	return func(c *Container) { c.NonceStore = store }
+}
+
+// WithMeteringStore overrides the metering store selected in config
+func WithMeteringStore(store metering.Store) Option {
+	return func(c *Container) { c.MeteringStore = store }
+}
+
+// WithPubSub overrides the bus of live updates, redis when configured
+func WithPubSub(bus pubsub.Bus) Option {
+	return func(c *Container) { c.PubSub = bus }
+}
+
+// WithCipher overrides the column encryption keys selected in config
+func WithCipher(cipher *crypto.Cipher) Option {
+	return func(c *Container) { c.Cipher = cipher }
+}
+
+// WithPublisher overrides the broker driver selected in config
+func WithPublisher(publisher broker.Publisher) Option {
+	return func(c *Container) { c.Publisher = publisher }
+}
+
+// WithTracker overrides the analytics sink selected in config
+func WithTracker(tracker analytics.Tracker) Option {
+	return func(c *Container) { c.Tracker = tracker }
+}
+
+// WithMailer overrides the mail driver selected in config
+func WithMailer(mail mailer.Mailer) Option {
+	return func(c *Container) { c.Mailer = mail }
+}
+
+// WithWeather overrides the weather provider selected in config
+func WithWeather(provider weather.Provider) Option {
+	return func(c *Container) { c.Weather = provider }
+}
+
+// WithPayments overrides the payment provider selected in config
+func WithPayments(provider payment.Provider) Option {
+	return func(c *Container) { c.Payments = provider }
+}
+
+// WithAuthRepository overrides the postgres auth repository
+func WithAuthRepository(repo auth.AuthRepository) Option {
+	return func(c *Container) { c.AuthRepo = repo }
+}
+
+// WithUserRepository overrides the postgres user repository
+func WithUserRepository(repo user.UserRepository) Option {
+	return func(c *Container) { c.UserRepo = repo }
+}
+
+// WithTrainingRepository overrides the postgres training repository
+func WithTrainingRepository(repo training.TrainingRepository) Option {
+	return func(c *Container) { c.TrainingRepo = repo }
+}
@@ -0,0 +1,23 @@
+package audit
+
+import "time"
+
+// Audited actions
+const (
+	ActionUserViewed           = "admin.user_viewed"
+	ActionImpersonationStarted = "admin.impersonation_started"
+	ActionImpersonatedRequest  = "admin.impersonated_request"
+	ActionUserDeleted          = "admin.user_deleted"
+	ActionUserRestored         = "admin.user_restored"
+)
+
+// Event records an action of an admin or support account on another account
+type Event struct {
+	ID              string
+	ActorAccountID  *string // nil once the actor account is deleted
+	ActorEmail      *string // only read by the lists
+	TargetAccountID *string
+	Action          string
+	Metadata        map[string]any
+	CreatedAt       time.Time
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+type AuditRepository interface {
+	// Record stores the event in the tenant of ctx
+	Record(ctx context.Context, event *Event) error
+	// ListByTarget returns the latest events on the account, newest first
+	ListByTarget(ctx context.Context, accountID string, limit int) ([]Event, error)
+}
+
+type auditRepository struct {
+	db database.DBTX
+}
+
+func NewAuditRepositry(db database.DBTX) AuditRepository {
+	return &auditRepository{db}
+}
+
+func (r *auditRepository) Record(ctx context.Context, event *Event) error {
+	const q = `
+		INSERT INTO audit_events (organization_id, actor_account_id, target_account_id, action, metadata)
+		VALUES ($1, $2, $3, $4, COALESCE($5, '{}'::jsonb))
+		RETURNING id, created_at`
+
+	return r.db.QueryRow(ctx, q, tenant.ID(ctx), event.ActorAccountID, event.TargetAccountID, event.Action, event.Metadata).
+		Scan(&event.ID, &event.CreatedAt)
+}
+
+func (r *auditRepository) ListByTarget(ctx context.Context, accountID string, limit int) ([]Event, error) {
+	const q = `
+		SELECT e.id, e.actor_account_id, a.email, e.target_account_id, e.action, e.metadata, e.created_at
+		FROM audit_events e
+		LEFT JOIN accounts a ON a.id = e.actor_account_id
+		WHERE e.target_account_id = $1
+		ORDER BY e.created_at DESC, e.id
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, q, accountID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.ActorAccountID, &e.ActorEmail, &e.TargetAccountID, &e.Action, &e.Metadata, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
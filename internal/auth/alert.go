@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	htmltemplate "html/template"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/mailer"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	alertTextTemplate = template.Must(template.ParseFS(templates, "templates/sign_in_alert.txt"))
+	alertHTMLTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/sign_in_alert.html"))
+)
+
+// alertTokenPrefix marks the tokens of the "this wasn't me" links
+const alertTokenPrefix = "swa_"
+
+// signInAlertMail is the data of the sign in alert templates
+type signInAlertMail struct {
+	Name       string
+	Time       time.Time
+	Device     string
+	IP         string
+	Country    string
+	NewDevice  bool
+	NewCountry bool
+	Link       string
+	ExpiresAt  time.Time
+}
+
+// alertSignIn records the device and country of a sign in and emails the owner of the account
+// when one of them is new. It never fails the sign in, errors are logged.
+func (uc *authUsecase) alertSignIn(ctx context.Context, auth *Auth, client Client) {
+	log := logger.FromContext(ctx)
+
+	// Recorded even with alerts disabled, turning them on doesn't alert every known device
+	history, err := uc.authRepo.RecordSignIn(ctx, auth.AccountID, client)
+	if err != nil {
+		log.Warn("sign in alert: record sign in failed", "account_id", auth.AccountID, "error", err)
+		return
+	}
+
+	cfg := uc.cfg.Load().Auth
+	reasons := alertReasons(history, client)
+	if len(reasons) == 0 || uc.mail == nil || cfg.SignInAlertURL == "" {
+		return
+	}
+
+	msg, err := uc.newSignInAlert(ctx, &cfg, auth, client, reasons)
+	if err != nil {
+		log.Warn("sign in alert: create failed", "account_id", auth.AccountID, "error", err)
+		return
+	}
+
+	// Sent in the background, the sign in doesn't wait on the mail relay
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := uc.mail.Send(ctx, *msg); err != nil {
+			log.Warn("sign in alert: not sent", "account_id", auth.AccountID, "error", err)
+		}
+	}()
+}
+
+// alertReasons lists why a sign in is suspicious. The first sign in of an account, or the first
+// since alerts exist, raises none, nor does a country unknown to the CDN.
+func alertReasons(history *SignInHistory, client Client) []string {
+	if !history.Known {
+		return nil
+	}
+
+	var reasons []string
+	if !history.KnownDevice {
+		reasons = append(reasons, AlertNewDevice)
+	}
+	if client.Country != "" && !history.KnownCountry {
+		reasons = append(reasons, AlertNewCountry)
+	}
+	return reasons
+}
+
+// newSignInAlert stores an alert and renders its email, the link carries a single use token
+func (uc *authUsecase) newSignInAlert(ctx context.Context, cfg *config.AuthConfig, auth *Auth, client Client, reasons []string) (*mailer.Message, error) {
+	token, err := security.NewOpaqueToken(alertTokenPrefix, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := &SignInAlert{
+		AccountID: auth.AccountID,
+		TokenHash: security.HashToken(token),
+		Client:    client,
+		Reasons:   reasons,
+		ExpiresAt: time.Now().Add(cfg.SignInAlertTTL),
+	}
+	if err := uc.authRepo.CreateSignInAlert(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	link, err := url.Parse(cfg.SignInAlertURL)
+	if err != nil {
+		return nil, err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	data := signInAlertMail{
+		Name:      auth.Name,
+		Time:      alert.CreatedAt.UTC(),
+		Device:    client.UserAgent,
+		IP:        client.IP,
+		Country:   client.Country,
+		Link:      link.String(),
+		ExpiresAt: alert.ExpiresAt.UTC(),
+	}
+	for _, reason := range reasons {
+		data.NewDevice = data.NewDevice || reason == AlertNewDevice
+		data.NewCountry = data.NewCountry || reason == AlertNewCountry
+	}
+	if data.Device == "" {
+		data.Device = "Unknown device"
+	}
+
+	var text, html bytes.Buffer
+	if err := alertTextTemplate.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := alertHTMLTemplate.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &mailer.Message{
+		To:      auth.Email,
+		Subject: "New sign in to your Swimo account",
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -1,27 +1,26 @@
 package auth
 
 import (
-	"strings"
-
 	"github.com/rizkyharahap/swimo/pkg/validator"
 )
 
 // SignUpRequest represents the sign up request data transfer object
 type SignUpRequest struct {
-	Name            string  `json:"name" example:"John Doe"`
-	Email           string  `json:"email" example:"john@example.com"`
-	Password        string  `json:"password" example:"SecurePassword123"`
-	ConfirmPassword string  `json:"confirmPassword" example:"SecurePassword123"`
-	Gender          string  `json:"gender" example:"male"`
-	Age             int16   `json:"age" example:"30"`
-	Height          float64 `json:"height" example:"180"`
-	Weight          float64 `json:"weight" example:"75.5"`
+	Name            string  `json:"name" validate:"required" example:"John Doe"`
+	Email           string  `json:"email" validate:"required,lower,email" example:"john@example.com"`
+	Password        string  `json:"password" validate:"required,min=8" example:"SecurePassword123"`
+	ConfirmPassword string  `json:"confirmPassword" validate:"required,eqfield=Password" example:"SecurePassword123"`
+	Gender          string  `json:"gender" validate:"oneof=male female" example:"male"`
+	Age             int16   `json:"age" validate:"gt=0" example:"30"`
+	Height          float64 `json:"height" validate:"gt=0" example:"180"`
+	Weight          float64 `json:"weight" validate:"gt=0" example:"75.5"`
+	MarketingEmails bool    `json:"marketingEmails" example:"false"` // opt in to marketing emails, unchecked by default
 }
 
 // SignInRequest represents the sign in request data transfer object
 type SignInRequest struct {
-	Email    string `json:"email" example:"john@example.com"`
-	Password string `json:"password" example:"SecurePassword123"`
+	Email    string `json:"email" validate:"required,lower,email" example:"john@example.com"`
+	Password string `json:"password" validate:"required,min=8" example:"SecurePassword123"`
 }
 
 // SignInResponse represents the sign in response data transfer object
@@ -38,10 +37,10 @@ type SignInResponse struct {
 }
 
 type SignInGuestRequest struct {
-	Gender string  `json:"gender" example:"male"`
-	Age    int16   `json:"age" example:"30"`
-	Height float64 `json:"height" example:"180"`
-	Weight float64 `json:"weight" example:"75.5"`
+	Gender string  `json:"gender" validate:"oneof=male female" example:"male"`
+	Age    int16   `json:"age" validate:"gt=0" example:"30"`
+	Height float64 `json:"height" validate:"gt=0" example:"180"`
+	Weight float64 `json:"weight" validate:"gt=0" example:"75.5"`
 }
 
 type SignInGuestResponse struct {
@@ -56,7 +55,7 @@ type SignInGuestResponse struct {
 }
 
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refreshToken" example:"3d3dc788634e05b7d1d5fac06834d3b6a9b62..."`
+	RefreshToken string `json:"refreshToken" validate:"required" example:"3d3dc788634e05b7d1d5fac06834d3b6a9b62..."`
 }
 
 type RefreshTokenResponse struct {
@@ -65,119 +64,33 @@ type RefreshTokenResponse struct {
 	ExpiresIn    int64  `json:"expiresInMs" example:"1799999"`
 }
 
-func trim(s string) string {
-	return strings.TrimSpace(s)
+// DenySignInRequest reports a sign in as not made by the owner of the account, with the token
+// of the "this wasn't me" link of its alert email
+type DenySignInRequest struct {
+	Token string `json:"token" validate:"required,max=128" example:"swa_q5nM3v8YtR2kLp0wXe7uZa4bHc1dFg6jNs9oQi2rTv"`
 }
 
 // Validate validates the sign in request
 func (r *SignInRequest) Validate() *validator.ValidationError {
-	errors := make(map[string]string)
-
-	r.Email = strings.ToLower(trim(r.Email))
-	if r.Email == "" {
-		errors["email"] = "Email is required"
-	} else if !validator.IsValidEmail(r.Email) {
-		errors["email"] = "Email is not a valid format"
-	}
-
-	r.Password = trim(r.Password)
-	if r.Password == "" {
-		errors["password"] = "Password is required"
-	} else if len(r.Password) < 8 {
-		errors["password"] = "Password must be at least 8 characters"
-	}
-
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
-	}
-
-	return nil
+	return validator.Struct(r)
 }
 
 // Validate validates the sign up request
 func (r *SignUpRequest) Validate() *validator.ValidationError {
-	errors := make(map[string]string)
-
-	r.Email = strings.ToLower(trim(r.Email))
-	if r.Email == "" {
-		errors["email"] = "Email is required"
-	} else if !validator.IsValidEmail(r.Email) {
-		errors["email"] = "Email is not a valid format"
-	}
-
-	r.Password = trim(r.Password)
-	if r.Password == "" {
-		errors["password"] = "Password is required"
-	} else if len(r.Password) < 8 {
-		errors["password"] = "Password must be at least 8 characters"
-	}
-
-	r.ConfirmPassword = trim(r.ConfirmPassword)
-	if r.ConfirmPassword == "" {
-		errors["confirmPassword"] = "Confirm password is required"
-	} else if r.Password != r.ConfirmPassword {
-		errors["confirmPassword"] = "Confirm passwords do not match"
-	}
-
-	r.Name = trim(r.Name)
-	if r.Name == "" {
-		errors["name"] = "Name is required"
-	}
-
-	if r.Weight <= 0 {
-		errors["weight"] = "Weight must be a positive number"
-	}
-
-	if r.Height <= 0 {
-		errors["height"] = "Height cannot be negative"
-	}
-
-	if r.Age <= 0 {
-		errors["age"] = "Age must be a positive number"
-	}
-
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
-	}
-
-	return nil
+	return validator.Struct(r)
 }
 
 // Validate validates the sign in guest request
 func (r *SignInGuestRequest) Validate() *validator.ValidationError {
-	errors := make(map[string]string)
-
-	if r.Weight <= 0 {
-		errors["weight"] = "Weight must be a positive number"
-	}
-
-	if r.Height <= 0 {
-		errors["height"] = "Height must be a positive number"
-	}
-
-	if r.Age <= 0 {
-		errors["age"] = "Age must be a positive number"
-	}
-
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
-	}
-
-	return nil
+	return validator.Struct(r)
 }
 
-// Validate validates the sign in guest request
+// Validate validates the refresh token request
 func (r *RefreshTokenRequest) Validate() *validator.ValidationError {
-	errors := make(map[string]string)
-
-	r.RefreshToken = trim(r.RefreshToken)
-	if r.RefreshToken == "" {
-		errors["refresh_token"] = "Refresh token is required"
-	}
-
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
-	}
+	return validator.Struct(r)
+}
 
-	return nil
+// Validate validates the deny sign in request
+func (r *DenySignInRequest) Validate() *validator.ValidationError {
+	return validator.Struct(r)
 }
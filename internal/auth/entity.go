@@ -11,30 +11,76 @@ import (
 )
 
 var (
-	ErrInvalidCreds = errors.New("invalid email or passwords")
+	ErrInvalidCreds      = errors.New("invalid email or passwords")
+	ErrAlertTokenInvalid = errors.New("sign in alert token invalid or expired")
 )
 
+// Reasons a sign in raises an alert
+const (
+	AlertNewDevice  = "new_device"
+	AlertNewCountry = "new_country"
+)
+
+// Client describes where a request comes from, IP and Country are empty when unknown
+type Client struct {
+	UserAgent string
+	IP        string
+	Country   string // ISO 3166 code set by the CDN, ex: ID
+}
+
+// SignInHistory tells whether the device and country of a sign in were seen before on the account
+type SignInHistory struct {
+	Known        bool // the account signed in before, its first sign in raises no alert
+	KnownDevice  bool
+	KnownCountry bool
+}
+
+// SignInAlert is the email sent for a sign in from a new device or country, TokenHash is the
+// digest of the token of its "this wasn't me" link
+type SignInAlert struct {
+	ID        string
+	AccountID string
+	TokenHash string
+	Client    Client
+	Reasons   []string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
 type Auth struct {
-	AccountID    string
-	Email        string
-	PasswordHash string
-	IsLocked     bool
-	Name         string
-	Gender       user.Gender
-	WeightKG     float64
-	HeightCM     float64
-	AgeYears     int16
+	AccountID      string
+	OrganizationID *string
+	Email          string
+	PasswordHash   string
+	IsLocked       bool
+	Name           string
+	Gender         user.Gender
+	WeightKG       float64
+	HeightCM       float64
+	AgeYears       int16
 }
 
 type Session struct {
 	ID               string
 	AccountID        *string
+	OrganizationID   *string
 	Kind             string
 	RefreshTokenHash string
 	ExpiresAt        time.Time
 	RefreshExpiresAt time.Time
 	UserAgent        string
+	Fingerprint      string // guests only, see abuse.Fingerprint
 	RevokedAt        *time.Time
+	// Sessions refreshed from one sign in form a family, sharing an absolute expiry. A nil
+	// FamilyID starts a new family, FamilyExpiresAt is nil on sessions older than families.
+	FamilyID        *string
+	FamilyExpiresAt *time.Time
+}
+
+// SignedUpEvent is the payload of the user.signed_up event
+type SignedUpEvent struct {
+	AccountID string `json:"accountId"`
+	UserID    string `json:"userId"`
 }
 
 type AccessToken struct {
@@ -51,7 +97,7 @@ func (u *Auth) ComparePassword(password string) error {
 	return nil
 }
 
-func NewSession(cfg *config.AuthConfig, userAgent string, accountId *string) (*Session, error) {
+func NewSession(cfg *config.AuthConfig, userAgent string, accountId, organizationId *string) (*Session, error) {
 	refreshToken, err := security.NewRefreshToken(32)
 	if err != nil {
 		return nil, err
@@ -60,12 +106,29 @@ func NewSession(cfg *config.AuthConfig, userAgent string, accountId *string) (*S
 	now := time.Now()
 	expiresAt := now.Add(cfg.JWTAccessTTL)
 	refreshExpiresAt := now.Add(cfg.JWTRefreshTTL)
+	familyExpiresAt := now.Add(cfg.SessionMaxAge)
 
 	return &Session{
 		AccountID:        accountId,
+		OrganizationID:   organizationId,
 		RefreshTokenHash: refreshToken,
 		ExpiresAt:        expiresAt,
 		RefreshExpiresAt: refreshExpiresAt,
 		UserAgent:        userAgent,
+		FamilyExpiresAt:  &familyExpiresAt,
 	}, nil
 }
+
+// ContinueFamily makes s the session refreshed from prev: it joins the family of prev and its
+// refresh window, slid from now, stops at the family expiry. Sessions older than families start
+// their lifetime at the first refresh.
+func (s *Session) ContinueFamily(prev *Session) {
+	s.FamilyID = prev.FamilyID
+	if prev.FamilyExpiresAt != nil {
+		s.FamilyExpiresAt = prev.FamilyExpiresAt
+	}
+
+	if s.FamilyExpiresAt.Before(s.RefreshExpiresAt) {
+		s.RefreshExpiresAt = *s.FamilyExpiresAt
+	}
+}
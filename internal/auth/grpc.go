@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rizkyharahap/swimo/internal/abuse"
+	"github.com/rizkyharahap/swimo/pkg/grpcserver"
+	"github.com/rizkyharahap/swimo/pkg/i18n"
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	swimov1 "github.com/rizkyharahap/swimo/proto/swimo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// PublicMethods are the auth RPCs callable without an access token
+var PublicMethods = []string{
+	swimov1.AuthService_SignUp_FullMethodName,
+	swimov1.AuthService_SignIn_FullMethodName,
+	swimov1.AuthService_SignInGuest_FullMethodName,
+	swimov1.AuthService_RefreshToken_FullMethodName,
+}
+
+// AuthGRPCServer serves the auth usecase over gRPC.
+// Errors are converted to statuses by the grpcserver interceptors.
+type AuthGRPCServer struct {
+	swimov1.UnimplementedAuthServiceServer
+
+	authUsecase AuthUsecase
+}
+
+func NewAuthGRPCServer(authUsecase AuthUsecase) *AuthGRPCServer {
+	return &AuthGRPCServer{authUsecase: authUsecase}
+}
+
+func (s *AuthGRPCServer) RegisterGRPC(server *grpc.Server) {
+	swimov1.RegisterAuthServiceServer(server, s)
+}
+
+func (s *AuthGRPCServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return swimov1.RegisterAuthServiceHandler(ctx, mux, conn)
+}
+
+func (s *AuthGRPCServer) SignUp(ctx context.Context, in *swimov1.SignUpRequest) (*swimov1.SignUpResponse, error) {
+	req := SignUpRequest{
+		Name:            in.GetName(),
+		Email:           in.GetEmail(),
+		Password:        in.GetPassword(),
+		ConfirmPassword: in.GetConfirmPassword(),
+		Gender:          in.GetGender(),
+		Age:             int16(in.GetAge()),
+		Height:          in.GetHeight(),
+		Weight:          in.GetWeight(),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authUsecase.SignUp(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return &swimov1.SignUpResponse{Message: i18n.Translate(i18n.FromContext(ctx), "User registered successfully")}, nil
+}
+
+func (s *AuthGRPCServer) SignIn(ctx context.Context, in *swimov1.SignInRequest) (*swimov1.SignInResponse, error) {
+	req := SignInRequest{Email: in.GetEmail(), Password: in.GetPassword()}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.authUsecase.SignIn(ctx, req, Client{UserAgent: userAgent(ctx), IP: grpcserver.ClientIP(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &swimov1.SignInResponse{
+		Name:         res.Name,
+		Email:        res.Email,
+		Gender:       res.Gender,
+		Age:          int32(res.Age),
+		Height:       res.Height,
+		Weight:       res.Weight,
+		Token:        res.Token,
+		RefreshToken: res.RefreshToken,
+		ExpiresIn:    res.ExpiresIn,
+	}, nil
+}
+
+func (s *AuthGRPCServer) SignInGuest(ctx context.Context, in *swimov1.SignInGuestRequest) (*swimov1.SignInGuestResponse, error) {
+	req := SignInGuestRequest{
+		Gender: in.GetGender(),
+		Age:    int16(in.GetAge()),
+		Height: in.GetHeight(),
+		Weight: in.GetWeight(),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.authUsecase.SignInGuest(ctx, req, userAgent(ctx), abuse.Fingerprint(grpcserver.ClientIP(ctx), userAgent(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &swimov1.SignInGuestResponse{
+		Name:         res.Name,
+		Gender:       res.Gender,
+		Age:          int32(res.Age),
+		Height:       res.Height,
+		Weight:       res.Weight,
+		Token:        res.Token,
+		RefreshToken: res.RefreshToken,
+		ExpiresIn:    res.ExpiresIn,
+	}, nil
+}
+
+func (s *AuthGRPCServer) SignOut(ctx context.Context, in *swimov1.SignOutRequest) (*swimov1.SignOutResponse, error) {
+	claim := middleware.AuthFromContext(ctx)
+
+	if err := s.authUsecase.SignOut(ctx, claim.Sub); err != nil {
+		return nil, err
+	}
+
+	return &swimov1.SignOutResponse{Message: i18n.Translate(i18n.FromContext(ctx), "Sign out successfully")}, nil
+}
+
+func (s *AuthGRPCServer) RefreshToken(ctx context.Context, in *swimov1.RefreshTokenRequest) (*swimov1.RefreshTokenResponse, error) {
+	req := RefreshTokenRequest{RefreshToken: in.GetRefreshToken()}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.authUsecase.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &swimov1.RefreshTokenResponse{
+		Token:        res.Token,
+		RefreshToken: res.RefreshToken,
+		ExpiresIn:    res.ExpiresIn,
+	}, nil
+}
+
+// userAgent returns the client user agent, as forwarded by the gateway or sent by the gRPC client
+func userAgent(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
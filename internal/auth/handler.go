@@ -2,19 +2,23 @@ package auth
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/rizkyharahap/swimo/internal/abuse"
 	"github.com/rizkyharahap/swimo/pkg/middleware"
 	"github.com/rizkyharahap/swimo/pkg/response"
 )
 
 type AuthHandler struct {
-	authUsecase AuthUsecase
+	authUsecase   AuthUsecase
+	ipHeader      string // header carrying the client IP behind a proxy, ex: X-Forwarded-For
+	proxies       int    // number of proxies appending to ipHeader, see middleware.ClientIP
+	countryHeader string // header carrying the client country set by the CDN, ex: CF-IPCountry
 }
 
-func NewAuthHandler(authUsecase AuthUsecase) *AuthHandler {
-	return &AuthHandler{authUsecase}
+func NewAuthHandler(authUsecase AuthUsecase, ipHeader string, proxies int, countryHeader string) *AuthHandler {
+	return &AuthHandler{authUsecase, ipHeader, proxies, countryHeader}
 }
 
 // SignUp handles user registration
@@ -24,16 +28,16 @@ func NewAuthHandler(authUsecase AuthUsecase) *AuthHandler {
 // @Accept json
 // @Produce json
 // @Param request body SignUpRequest true "Sign up request with user details"
-// @Success 201 {object} response.Message "User registered successfully"
-// @Failure 400 {object} response.Message "Invalid request body"
+// @Success 201 {object} response.Success{data=response.Message} "User registered successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
 // @Failure 422 {object} response.Error "Validation errors"
-// @Failure 409 {object} response.Message "Email already exists"
+// @Failure 409 {object} response.Error "Email already exists"
 // @Router /sign-up [post]
 func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req SignUpRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w)
+		response.DecodeError(w, err)
 		return
 	}
 
@@ -44,36 +48,31 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.authUsecase.SignUp(r.Context(), req); err != nil {
-		if errors.Is(err, ErrAccountExists) {
-			response.JSON(w, http.StatusConflict, response.Message{Message: "Email already exists"})
-			return
-		}
-
-		response.InternalError(w)
+		response.Err(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, response.Message{Message: "User registered successfully"})
+	response.OK(w, http.StatusCreated, response.Message{Message: "User registered successfully"})
 }
 
 // SignIn handles user sign in
 // @Summary Sign in user
-// @Description Authenticate user with email and password, returns JWT tokens
+// @Description Authenticate user with email and password, returns JWT tokens. A sign in from a device or country not seen before on the account emails an alert with a "this wasn't me" link.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body SignInRequest true "Sign in request with user credentials"
 // @Success 200 {object} response.Success{data=SignInResponse} "Sign in successful"
-// @Failure 400 {object} response.Message "Invalid request body"
-// @Failure 401 {object} response.Message "Invalid email or password"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 401 {object} response.Error "Invalid email or password"
 // @Failure 422 {object} response.Error "Validation errors"
-// @Failure 423 {object} response.Message "Your account has been locked"
+// @Failure 423 {object} response.Error "Your account has been locked"
 // @Router /sign-in [post]
 func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req SignInRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w)
+		response.DecodeError(w, err)
 		return
 	}
 
@@ -83,24 +82,13 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := h.authUsecase.SignIn(r.Context(), req, r.UserAgent())
+	data, err := h.authUsecase.SignIn(r.Context(), req, h.client(r))
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrInvalidCreds):
-			response.JSON(w, http.StatusUnauthorized, response.Message{Message: "Invalid email or password"})
-			return
-
-		case errors.Is(err, ErrLocked):
-			response.JSON(w, http.StatusForbidden, response.Message{Message: "Your account has been locked"})
-			return
-
-		default:
-			response.InternalError(w)
-			return
-		}
+		response.Err(w, err)
+		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Success{Data: data})
+	response.OK(w, http.StatusOK, data)
 }
 
 // SignIn handles guest sign in
@@ -111,17 +99,17 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param request body SignInGuestRequest true "Guest sign in request with optional user agent"
 // @Success 200 {object} response.Success{data=SignInGuestResponse} "Guest sign in successful"
-// @Failure 400 {object} response.Message "Invalid request body"
-// @Failure 403 {object} response.Message "Guest sign in disabled"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guest sign in disabled or sign up required, the client was flagged for abuse"
 // @Failure 422 {object} response.Error "Validation errors"
-// @Failure 429 {object} response.Message "Guest session limit reached"
+// @Failure 429 {object} response.Error "Guest session limit reached"
 // @Router /sign-in-guest [post]
 func (h *AuthHandler) SignInGuest(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req SignInGuestRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w)
+		response.DecodeError(w, err)
 		return
 	}
 
@@ -131,24 +119,15 @@ func (h *AuthHandler) SignInGuest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := h.authUsecase.SignInGuest(r.Context(), req, r.UserAgent())
-	if err != nil {
-		switch {
-		case errors.Is(err, ErrGuestDisabled):
-			response.JSON(w, http.StatusForbidden, response.Message{Message: "Guest sign in disabled"})
-			return
-
-		case errors.Is(err, ErrGuestLimited):
-			response.JSON(w, http.StatusTooManyRequests, response.Message{Message: "Guest session limit reached"})
-			return
+	fingerprint := abuse.Fingerprint(middleware.ClientIP(r, h.ipHeader, h.proxies), r.UserAgent())
 
-		default:
-			response.InternalError(w)
-			return
-		}
+	data, err := h.authUsecase.SignInGuest(r.Context(), req, r.UserAgent(), fingerprint)
+	if err != nil {
+		response.Err(w, err)
+		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Success{Data: data})
+	response.OK(w, http.StatusOK, data)
 }
 
 // SignOut handles user sign out
@@ -157,7 +136,7 @@ func (h *AuthHandler) SignInGuest(w http.ResponseWriter, r *http.Request) {
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Message "Sign out successfully"
+// @Success 200 {object} response.Success{data=response.Message} "Sign out successfully"
 // @Security ApiKeyAuth
 // @Router /sign-out [post]
 func (h *AuthHandler) SignOut(w http.ResponseWriter, r *http.Request) {
@@ -169,7 +148,7 @@ func (h *AuthHandler) SignOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Message{Message: "Sign out successfully"})
+	response.OK(w, http.StatusOK, response.Message{Message: "Sign out successfully"})
 }
 
 // RefreshToken handles JWT token refresh
@@ -180,13 +159,14 @@ func (h *AuthHandler) SignOut(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param request body auth.RefreshTokenRequest true "Refresh token request"
 // @Success 200 {object} response.Success{data=RefreshTokenResponse} "Token refreshed successfully"
-// @Failure 401 {object} response.Message "Invalid or expired refresh token"
+// @Failure 401 {object} response.Error "Invalid or expired refresh token"
+// @Failure 403 {object} response.Error "Sign up required, the guest was flagged for abuse"
 // @Security ApiKeyAuth
 // @Router /refresh-token [post]
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req RefreshTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w)
+		response.DecodeError(w, err)
 		return
 	}
 
@@ -198,14 +178,54 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 
 	data, err := h.authUsecase.RefreshToken(r.Context(), req.RefreshToken)
 	if err != nil {
-		if errors.Is(err, ErrExpiredRefreshToken) {
-			response.JSON(w, http.StatusUnauthorized, response.Message{Message: "Invalid or expired refresh token"})
-			return
-		}
+		response.Err(w, err)
+		return
+	}
 
-		response.InternalError(w)
+	response.OK(w, http.StatusOK, data)
+}
+
+// DenySignIn handles the "this wasn't me" link of a sign in alert
+// @Summary Deny sign in
+// @Description Report the sign in of an alert email as not made by the owner of the account, with the token of its link. The account is locked and every session revoked, access tokens already issued stay valid until they expire. A token works once.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body DenySignInRequest true "Token of the alert link"
+// @Success 200 {object} response.Success{data=response.Message} "Account locked"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 404 {object} response.Error "Link is invalid or expired"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Router /sign-in-alerts/deny [post]
+func (h *AuthHandler) DenySignIn(w http.ResponseWriter, r *http.Request) {
+	var req DenySignInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.Errors)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Success{Data: data})
+	if err := h.authUsecase.DenySignIn(r.Context(), req.Token); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Account locked and signed out everywhere, contact support to recover it"})
+}
+
+// client returns where the request comes from, the country is only known behind a CDN setting
+// it. XX is the code of the CDN for a country it could not tell.
+func (h *AuthHandler) client(r *http.Request) Client {
+	client := Client{UserAgent: r.UserAgent(), IP: middleware.ClientIP(r, h.ipHeader, h.proxies)}
+	if h.countryHeader != "" {
+		client.Country = strings.ToUpper(strings.TrimSpace(r.Header.Get(h.countryHeader)))
+	}
+	if client.Country == "XX" {
+		client.Country = ""
+	}
+	return client
 }
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+	"github.com/rizkyharahap/swimo/pkg/scheduler"
+)
+
+// purgeFunc purges one batch of expired sessions, see AuthRepository.DeleteExpiredSessions
+type purgeFunc func(ctx context.Context, before time.Time, limit int, archive bool) (int64, error)
+
+// NewSessionPurgeJob returns a job deleting user sessions revoked or expired longer than the retention window.
+// purged counts the rows by kind and action, deleted or archived.
+func NewSessionPurgeJob(cfg config.JobConfig, authRepo AuthRepository, purged *metrics.Counter) scheduler.Job {
+	return newPurgeJob("session_purge", "user", cfg, authRepo.DeleteExpiredSessions, purged)
+}
+
+// NewGuestPurgeJob returns a job deleting guest sessions revoked or expired longer than the retention window
+func NewGuestPurgeJob(cfg config.JobConfig, authRepo AuthRepository, purged *metrics.Counter) scheduler.Job {
+	return newPurgeJob("guest_purge", "guest", cfg, authRepo.DeleteExpiredGuestSessions, purged)
+}
+
+func newPurgeJob(name, kind string, cfg config.JobConfig, purge purgeFunc, purged *metrics.Counter) scheduler.Job {
+	action := "deleted"
+	if cfg.Archive {
+		action = "archived"
+	}
+
+	return scheduler.Job{
+		Name:     name,
+		Interval: cfg.Interval,
+		Jitter:   cfg.Jitter,
+		Run: func(ctx context.Context) error {
+			before := time.Now().Add(-cfg.Retention)
+
+			// One batch per statement until a short one, each batch commits on its own so a
+			// backlog never holds locks on sessions for the whole run
+			var total int64
+			for {
+				n, err := purge(ctx, before, cfg.BatchSize, cfg.Archive)
+				if err != nil {
+					return err
+				}
+
+				total += n
+				purged.Add(float64(n), kind, action)
+
+				if n < int64(cfg.BatchSize) || ctx.Err() != nil {
+					break
+				}
+			}
+
+			logger.FromContext(ctx).Info("Expired sessions purged", "kind", kind, action, total)
+			return nil
+		},
+	}
+}
@@ -7,7 +7,8 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
 )
 
 var (
@@ -17,41 +18,57 @@ var (
 
 type AuthRepository interface {
 	GetAuthByEmail(ctx context.Context, email string) (*Auth, error)
-	CreateAccount(ctx context.Context, tx pgx.Tx, email, passwordHash string) (id string, err error)
+	CreateAccount(ctx context.Context, email, passwordHash string) (id string, err error)
 	CreateUserSession(ctx context.Context, session *Session) (id string, err error)
 	CreateGuestSession(ctx context.Context, session *Session) (id string, err error)
 	CountRecentGuestByUsertAgent(ctx context.Context, userAgent string, since time.Time) (count int, err error)
 	GetSessionByRefreshToken(ctx context.Context, refreshToken string) (*Session, error)
+	// GetRoleByAccountId returns the role carried by the access tokens of the account
+	GetRoleByAccountId(ctx context.Context, accountId string) (string, error)
 	RevokeSessionById(ctx context.Context, sessionId string) error
 	RevokeSessionByAccountId(ctx context.Context, accountId string, userAgent string) error
+	// DeleteExpiredSessions deletes, or moves to sessions_archive with archive, at most limit
+	// user sessions revoked or expired before before
+	DeleteExpiredSessions(ctx context.Context, before time.Time, limit int, archive bool) (deleted int64, err error)
+	DeleteExpiredGuestSessions(ctx context.Context, before time.Time, limit int, archive bool) (deleted int64, err error)
+
+	// RecordSignIn stores the device and country of a sign in, returning what was known before it
+	RecordSignIn(ctx context.Context, accountId string, client Client) (*SignInHistory, error)
+	CreateSignInAlert(ctx context.Context, alert *SignInAlert) error
+	// DenySignInAlert marks the alert of the token denied, pgx.ErrNoRows when it is unknown,
+	// expired or already denied
+	DenySignInAlert(ctx context.Context, tokenHash string) (accountId string, err error)
+	LockAccount(ctx context.Context, accountId string) error
+	// RevokeAccountSessions revokes every open session of the account
+	RevokeAccountSessions(ctx context.Context, accountId string) (revoked int64, err error)
+
+	// WithTx returns a repository running every query in tx
+	WithTx(tx pgx.Tx) AuthRepository
 }
 
-type authRepository struct{ db *pgxpool.Pool }
+type authRepository struct{ db database.DBTX }
 
-func NewAuthRepository(db *pgxpool.Pool) AuthRepository { return &authRepository{db: db} }
+func NewAuthRepository(db database.DBTX) AuthRepository { return &authRepository{db: db} }
+
+func (r *authRepository) WithTx(tx pgx.Tx) AuthRepository {
+	return &authRepository{db: database.Rebind(r.db, tx)}
+}
 
 func (r *authRepository) GetAuthByEmail(ctx context.Context, email string) (*Auth, error) {
 	const q = `
 		SELECT
-		    a.id, a.email, a.password_hash, a.is_locked,
+		    a.id AS account_id, a.organization_id, a.email, a.password_hash, a.is_locked,
 			u.name, u.gender, u.weight_kg, u.height_cm, u.age_years
 		FROM accounts AS a
 		JOIN users AS u ON a.id = u.account_id
 		WHERE a.email = $1
+			AND ($2::uuid IS NULL OR a.organization_id = $2)
+			AND a.deleted_at IS NULL
 		LIMIT 1`
 
-	var auth Auth
-	if err := r.db.QueryRow(ctx, q, email).Scan(
-		&auth.AccountID,
-		&auth.Email,
-		&auth.PasswordHash,
-		&auth.IsLocked,
-		&auth.Name,
-		&auth.Gender,
-		&auth.WeightKG,
-		&auth.HeightCM,
-		&auth.AgeYears,
-	); err != nil {
+	// Outside a tenant any account can sign in, its organization is then carried by the token
+	auth, err := database.Get[Auth](ctx, r.db, q, email, tenant.ID(ctx))
+	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, ErrInvalidCreds
 		}
@@ -59,16 +76,16 @@ func (r *authRepository) GetAuthByEmail(ctx context.Context, email string) (*Aut
 		return nil, err
 	}
 
-	return &auth, nil
+	return auth, nil
 }
 
-func (r *authRepository) CreateAccount(ctx context.Context, tx pgx.Tx, email, passwordHash string) (id string, err error) {
+func (r *authRepository) CreateAccount(ctx context.Context, email, passwordHash string) (id string, err error) {
 	const q = `
-		INSERT INTO accounts (email, password_hash)
-		VALUES ($1, $2)
+		INSERT INTO accounts (email, password_hash, organization_id)
+		VALUES ($1, $2, $3)
 		RETURNING id`
 
-	if err = tx.QueryRow(ctx, q, email, passwordHash).Scan(&id); err != nil {
+	if err = r.db.QueryRow(ctx, q, email, passwordHash, tenant.ID(ctx)).Scan(&id); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
 			return "", ErrAccountExists
@@ -82,11 +99,11 @@ func (r *authRepository) CreateAccount(ctx context.Context, tx pgx.Tx, email, pa
 
 func (r *authRepository) CreateUserSession(ctx context.Context, session *Session) (id string, err error) {
 	const q = `
-		INSERT INTO sessions (account_id, kind, user_agent, expires_at, refresh_token_hash, refresh_expires_at)
-		VALUES ($1, 'user', $2, $3, $4, $5)
+		INSERT INTO sessions (account_id, organization_id, kind, user_agent, expires_at, refresh_token_hash, refresh_expires_at, family_id, family_expires_at)
+		VALUES ($1, $2, 'user', $3, $4, $5, $6, COALESCE($7::uuid, gen_random_uuid()), $8)
 		RETURNING id`
 
-	if err = r.db.QueryRow(ctx, q, &session.AccountID, &session.UserAgent, &session.ExpiresAt, &session.RefreshTokenHash, &session.RefreshExpiresAt).Scan(&id); err != nil {
+	if err = r.db.QueryRow(ctx, q, &session.AccountID, &session.OrganizationID, &session.UserAgent, &session.ExpiresAt, &session.RefreshTokenHash, &session.RefreshExpiresAt, session.FamilyID, session.FamilyExpiresAt).Scan(&id); err != nil {
 		return "", err
 	}
 
@@ -95,11 +112,11 @@ func (r *authRepository) CreateUserSession(ctx context.Context, session *Session
 
 func (r *authRepository) CreateGuestSession(ctx context.Context, session *Session) (id string, err error) {
 	const q = `
-		INSERT INTO SESSIONS (account_id, kind, user_agent, expires_at, refresh_token_hash, refresh_expires_at)
-		VALUES (NULL, 'guest', $1, $2, $3, $4)
+		INSERT INTO SESSIONS (account_id, organization_id, kind, user_agent, expires_at, refresh_token_hash, refresh_expires_at, fingerprint, family_id, family_expires_at)
+		VALUES (NULL, $1, 'guest', $2, $3, $4, $5, NULLIF($6, ''), COALESCE($7::uuid, gen_random_uuid()), $8)
 		RETURNING id`
 
-	if err = r.db.QueryRow(ctx, q, &session.UserAgent, &session.ExpiresAt, &session.RefreshTokenHash, &session.RefreshExpiresAt).Scan(&id); err != nil {
+	if err = r.db.QueryRow(ctx, q, &session.OrganizationID, &session.UserAgent, &session.ExpiresAt, &session.RefreshTokenHash, &session.RefreshExpiresAt, session.Fingerprint, session.FamilyID, session.FamilyExpiresAt).Scan(&id); err != nil {
 		return "", err
 	}
 
@@ -120,28 +137,27 @@ func (r *authRepository) CountRecentGuestByUsertAgent(ctx context.Context, userA
 
 func (r *authRepository) GetSessionByRefreshToken(ctx context.Context, refreshToken string) (*Session, error) {
 	const q = `
-		SELECT id, account_id, kind, user_agent, expires_at, revoked_at, refresh_token_hash, refresh_expires_at
+		SELECT id, account_id, organization_id, kind, user_agent, expires_at, revoked_at, refresh_token_hash, refresh_expires_at,
+			COALESCE(fingerprint, '') AS fingerprint, family_id, family_expires_at
 		FROM sessions
 		WHERE refresh_token_hash = $1
 			AND revoked_at IS NULL
 			AND refresh_expires_at > NOW()
+			AND ($2::uuid IS NULL OR organization_id = $2)
 		LIMIT 1`
 
-	var session Session
-	if err := r.db.QueryRow(ctx, q, refreshToken).Scan(
-		&session.ID,
-		&session.AccountID,
-		&session.Kind,
-		&session.UserAgent,
-		&session.ExpiresAt,
-		&session.RevokedAt,
-		&session.RefreshTokenHash,
-		&session.RefreshExpiresAt,
-	); err != nil {
-		return nil, err
+	return database.Get[Session](ctx, r.db, q, refreshToken, tenant.ID(ctx))
+}
+
+func (r *authRepository) GetRoleByAccountId(ctx context.Context, accountId string) (string, error) {
+	const q = `SELECT role FROM accounts WHERE id = $1`
+
+	var role string
+	if err := r.db.QueryRow(ctx, q, accountId).Scan(&role); err != nil {
+		return "", err
 	}
 
-	return &session, nil
+	return role, nil
 }
 
 func (r *authRepository) RevokeSessionById(ctx context.Context, sessionId string) error {
@@ -175,3 +191,130 @@ func (r *authRepository) RevokeSessionByAccountId(ctx context.Context, accountId
 
 	return nil
 }
+
+func (r *authRepository) DeleteExpiredSessions(ctx context.Context, before time.Time, limit int, archive bool) (deleted int64, err error) {
+	return r.purgeSessions(ctx, "user", before, limit, archive)
+}
+
+func (r *authRepository) DeleteExpiredGuestSessions(ctx context.Context, before time.Time, limit int, archive bool) (deleted int64, err error) {
+	return r.purgeSessions(ctx, "guest", before, limit, archive)
+}
+
+// purgeSessions deletes one batch of expired sessions of kind. SKIP LOCKED leaves sessions being
+// refreshed to the next run, and a small batch keeps the locks short on a busy table.
+func (r *authRepository) purgeSessions(ctx context.Context, kind string, before time.Time, limit int, archive bool) (int64, error) {
+	const deleteQ = `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE kind = $1
+				AND (revoked_at < $2 OR refresh_expires_at < $2)
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	const archiveQ = `
+		WITH purged AS (
+			DELETE FROM sessions
+			WHERE id IN (
+				SELECT id FROM sessions
+				WHERE kind = $1
+					AND (revoked_at < $2 OR refresh_expires_at < $2)
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		INSERT INTO sessions_archive (id, account_id, kind, created_at, data)
+		SELECT id, account_id, kind, created_at, to_jsonb(purged) - 'refresh_token_hash'
+		FROM purged`
+
+	q := deleteQ
+	if archive {
+		q = archiveQ
+	}
+
+	tag, err := r.db.Exec(ctx, q, kind, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *authRepository) RecordSignIn(ctx context.Context, accountId string, client Client) (*SignInHistory, error) {
+	// seen reads the snapshot before the upsert, the sign in being recorded is not part of it
+	const q = `
+		WITH seen AS (
+			SELECT
+				count(*) > 0 AS known,
+				coalesce(bool_or(user_agent = $2), false) AS known_device,
+				coalesce(bool_or(country = $3), false) AS known_country
+			FROM account_sign_ins
+			WHERE account_id = $1
+		), upsert AS (
+			INSERT INTO account_sign_ins (account_id, user_agent, country)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (account_id, user_agent, country) DO UPDATE SET last_seen_at = now()
+		)
+		SELECT known, known_device, known_country FROM seen`
+
+	var h SignInHistory
+	if err := r.db.QueryRow(ctx, q, accountId, client.UserAgent, client.Country).Scan(&h.Known, &h.KnownDevice, &h.KnownCountry); err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+func (r *authRepository) CreateSignInAlert(ctx context.Context, alert *SignInAlert) error {
+	const q = `
+		INSERT INTO sign_in_alerts (account_id, token_hash, user_agent, ip, country, reasons, expires_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		RETURNING id, created_at`
+
+	return r.db.QueryRow(ctx, q,
+		alert.AccountID,
+		alert.TokenHash,
+		alert.Client.UserAgent,
+		alert.Client.IP,
+		alert.Client.Country,
+		alert.Reasons,
+		alert.ExpiresAt,
+	).Scan(&alert.ID, &alert.CreatedAt)
+}
+
+func (r *authRepository) DenySignInAlert(ctx context.Context, tokenHash string) (accountId string, err error) {
+	const q = `
+		UPDATE sign_in_alerts
+		SET denied_at = now()
+		WHERE token_hash = $1
+			AND denied_at IS NULL
+			AND expires_at > now()
+		RETURNING account_id`
+
+	err = r.db.QueryRow(ctx, q, tokenHash).Scan(&accountId)
+	return accountId, err
+}
+
+func (r *authRepository) LockAccount(ctx context.Context, accountId string) error {
+	const q = `UPDATE accounts SET is_locked = true WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, q, accountId)
+	return err
+}
+
+func (r *authRepository) RevokeAccountSessions(ctx context.Context, accountId string) (revoked int64, err error) {
+	const q = `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE account_id = $1
+			AND revoked_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, q, accountId)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
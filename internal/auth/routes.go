@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the auth endpoints
+func (h *AuthHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/sign-up", mw.Public(http.HandlerFunc(h.SignUp)))
+	mux.Handle("POST /api/v1/sign-in", mw.Public(http.HandlerFunc(h.SignIn)))
+	mux.Handle("POST /api/v1/sign-in-guest", mw.Public(http.HandlerFunc(h.SignInGuest)))
+	mux.Handle("POST /api/v1/refresh-token", mw.Public(http.HandlerFunc(h.RefreshToken)))
+	mux.Handle("POST /api/v1/sign-in-alerts/deny", mw.Public(http.HandlerFunc(h.DenySignIn)))
+
+	mux.Handle("POST /api/v1/sign-out", mw.Protected(http.HandlerFunc(h.SignOut)))
+}
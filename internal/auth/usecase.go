@@ -9,9 +9,16 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/abuse"
+	"github.com/rizkyharahap/swimo/internal/consent"
 	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+	"github.com/rizkyharahap/swimo/pkg/broker"
 	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/mailer"
 	"github.com/rizkyharahap/swimo/pkg/security"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -24,22 +31,30 @@ var (
 
 type AuthUsecase interface {
 	SignUp(ctx context.Context, req SignUpRequest) error
-	SignIn(ctx context.Context, req SignInRequest, userAgent string) (*SignInResponse, error)
-	SignInGuest(ctx context.Context, req SignInGuestRequest, userAgent string) (*SignInGuestResponse, error)
+	// SignIn opens a session, a sign in from a new device or country of client emails an alert
+	SignIn(ctx context.Context, req SignInRequest, client Client) (*SignInResponse, error)
+	// SignInGuest opens a guest session, fingerprint identifies the client for the abuse heuristics
+	SignInGuest(ctx context.Context, req SignInGuestRequest, userAgent, fingerprint string) (*SignInGuestResponse, error)
 	SignOut(ctx context.Context, sessionId string) error
 	RefreshToken(ctx context.Context, refreshToken string) (*RefreshTokenResponse, error)
+	// DenySignIn locks the account of the sign in alert of token and revokes its sessions
+	DenySignIn(ctx context.Context, token string) error
 }
 
 type authUsecase struct {
-	cfg      *config.Config
-	log      *logger.Logger
-	pool     *pgxpool.Pool
-	authRepo AuthRepository
-	userRepo user.UserRepository
+	cfg         *config.Store
+	pool        *pgxpool.Pool
+	authRepo    AuthRepository
+	userRepo    user.UserRepository
+	consentRepo consent.ConsentRepository
+	publisher   broker.Publisher
+	tracker     analytics.Tracker
+	abuse       abuse.AbuseUsecase
+	mail        mailer.Mailer // nil disables the sign in alerts
 }
 
-func NewAuthUsecase(cfg *config.Config, log *logger.Logger, pool *pgxpool.Pool, authRepo AuthRepository, userRepo user.UserRepository) AuthUsecase {
-	return &authUsecase{cfg, log, pool, authRepo, userRepo}
+func NewAuthUsecase(cfg *config.Store, pool *pgxpool.Pool, authRepo AuthRepository, userRepo user.UserRepository, consentRepo consent.ConsentRepository, publisher broker.Publisher, tracker analytics.Tracker, abuseUsecase abuse.AbuseUsecase, mail mailer.Mailer) AuthUsecase {
+	return &authUsecase{cfg, pool, authRepo, userRepo, consentRepo, publisher, tracker, abuseUsecase, mail}
 }
 
 func (uc *authUsecase) SignUp(ctx context.Context, req SignUpRequest) error {
@@ -48,51 +63,76 @@ func (uc *authUsecase) SignUp(ctx context.Context, req SignUpRequest) error {
 		return err
 	}
 
-	// Transaction Start
-	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	// Create account
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
-	accountID, err := uc.authRepo.CreateAccount(ctx, tx, email, string(hash))
-	if err != nil {
-		uc.log.Warn("signup: create account failed, rolling back", "email", email, "error", err)
-		return err
-	}
-
 	gender, err := user.ParseGender(req.Gender)
 	if err != nil {
 		return err
 	}
 
-	// Create user profile
-	user := user.User{
-		AccountID: accountID,
-		Name:      req.Name,
-		Gender:    gender,
-		WeightKG:  req.Weight,
-		HeightCM:  req.Height,
-		AgeYears:  req.Age,
-	}
+	var (
+		accountID string
+		userID    string
+	)
 
-	_, err = uc.userRepo.CreateUser(ctx, tx, &user)
+	err = database.WithTx(ctx, uc.pool, func(tx pgx.Tx) error {
+		// Create account
+		accountID, err = uc.authRepo.WithTx(tx).CreateAccount(ctx, email, string(hash))
+		if err != nil {
+			logger.FromContext(ctx).Warn("signup: create account failed, rolling back", "email", email, "error", err)
+			return err
+		}
+
+		// Create user profile
+		user := user.User{
+			AccountID: accountID,
+			Name:      req.Name,
+			Gender:    gender,
+			WeightKG:  req.Weight,
+			HeightCM:  req.Height,
+			AgeYears:  req.Age,
+		}
+
+		if _, err := uc.userRepo.WithTx(tx).CreateUser(ctx, &user); err != nil {
+			return err
+		}
+
+		userID = user.ID
+
+		// Signing up accepts the documents shown on the form, their current versions
+		legal := uc.cfg.Load().Legal
+		consentRepo := uc.consentRepo.WithTx(tx)
+		for document, version := range map[string]string{consent.DocumentTerms: legal.TermsVersion, consent.DocumentPrivacy: legal.PrivacyVersion} {
+			if version == "" {
+				continue
+			}
+			if err := consentRepo.Accept(ctx, accountID, document, version, ""); err != nil {
+				return err
+			}
+		}
+
+		if req.MarketingEmails {
+			if _, err := consentRepo.SetMarketing(ctx, accountID, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return err // tx rollback by defer
+		return err
 	}
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return err
+	// Publish after commit so consumers never see a rolled back account
+	event := broker.NewEvent(broker.EventUserSignedUp, SignedUpEvent{AccountID: accountID, UserID: userID})
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		logger.FromContext(ctx).Warn("signup: publish event failed", "account_id", accountID, "error", err)
 	}
+	uc.tracker.Track(ctx, analytics.Event{Name: analytics.EventSignup, UserID: userID})
 
 	return nil
 }
 
-func (uc *authUsecase) SignIn(ctx context.Context, req SignInRequest, userAgent string) (*SignInResponse, error) {
+func (uc *authUsecase) SignIn(ctx context.Context, req SignInRequest, client Client) (*SignInResponse, error) {
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
 	auth, err := uc.authRepo.GetAuthByEmail(ctx, email)
@@ -109,18 +149,20 @@ func (uc *authUsecase) SignIn(ctx context.Context, req SignInRequest, userAgent
 	}
 
 	// revoke another session
-	if err := uc.authRepo.RevokeSessionByAccountId(ctx, auth.AccountID, userAgent); err != nil {
+	if err := uc.authRepo.RevokeSessionByAccountId(ctx, auth.AccountID, client.UserAgent); err != nil {
 		if err != pgx.ErrNoRows {
 			return nil, err
 		}
 	}
 
 	// create session with refresh token
-	accessToken, err := uc.createSessionToken(ctx, "user", userAgent, &auth.AccountID)
+	accessToken, err := uc.createSessionToken(ctx, "user", client.UserAgent, "", &auth.AccountID, auth.OrganizationID, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	uc.alertSignIn(ctx, auth, client)
+
 	return &SignInResponse{
 		Name:         auth.Name,
 		Email:        auth.Email,
@@ -133,21 +175,27 @@ func (uc *authUsecase) SignIn(ctx context.Context, req SignInRequest, userAgent
 	}, nil
 }
 
-func (uc *authUsecase) SignInGuest(ctx context.Context, req SignInGuestRequest, userAgent string) (*SignInGuestResponse, error) {
-	if !uc.cfg.Auth.GuestEnabled {
+func (uc *authUsecase) SignInGuest(ctx context.Context, req SignInGuestRequest, userAgent, fingerprint string) (*SignInGuestResponse, error) {
+	// Guest settings are hot reloadable, read the current snapshot
+	cfg := uc.cfg.Load()
+	if !cfg.Auth.GuestEnabled {
 		return nil, ErrGuestDisabled
 	}
 
-	if uc.cfg.Auth.GuestRatePerMinute > 0 {
+	if cfg.Auth.GuestRatePerMinute > 0 {
 		since := time.Now().UTC().Add(-1 * time.Minute)
 
 		count, err := uc.authRepo.CountRecentGuestByUsertAgent(ctx, userAgent, since)
-		if err == nil && count >= uc.cfg.Auth.GuestRatePerMinute {
+		if err == nil && count >= cfg.Auth.GuestRatePerMinute {
 			return nil, ErrGuestLimited
 		}
 	}
 
-	accessToken, err := uc.createSessionToken(ctx, "guest", userAgent, nil)
+	if err := uc.abuse.CheckGuestSignIn(ctx, fingerprint, userAgent); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := uc.createSessionToken(ctx, "guest", userAgent, fingerprint, nil, tenant.ID(ctx), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -182,12 +230,20 @@ func (uc *authUsecase) RefreshToken(ctx context.Context, refreshToken string) (*
 		return nil, err
 	}
 
+	// A flagged guest keeps its current token but cannot extend it
+	if session.Kind == "guest" {
+		if err := uc.abuse.CheckGuestRefresh(ctx, session.Fingerprint); err != nil {
+			return nil, err
+		}
+	}
+
 	err = uc.authRepo.RevokeSessionById(ctx, session.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	accessToken, err := uc.createSessionToken(ctx, session.Kind, session.UserAgent, session.AccountID)
+	// The new session continues the family, a refresh never outlives the first sign in by more than SESSION_MAX_AGE_DAYS
+	accessToken, err := uc.createSessionToken(ctx, session.Kind, session.UserAgent, session.Fingerprint, session.AccountID, session.OrganizationID, session)
 	if err != nil {
 		return nil, err
 	}
@@ -199,14 +255,55 @@ func (uc *authUsecase) RefreshToken(ctx context.Context, refreshToken string) (*
 	}, nil
 }
 
-func (uc *authUsecase) createSessionToken(ctx context.Context, kind, userAgent string, accountId *string) (*AccessToken, error) {
+func (uc *authUsecase) DenySignIn(ctx context.Context, token string) error {
+	var (
+		accountId string
+		revoked   int64
+	)
+
+	err := database.WithTx(ctx, uc.pool, func(tx pgx.Tx) error {
+		authRepo := uc.authRepo.WithTx(tx)
+
+		var err error
+		accountId, err = authRepo.DenySignInAlert(ctx, security.HashToken(token))
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrAlertTokenInvalid
+			}
+			return err
+		}
+
+		if err := authRepo.LockAccount(ctx, accountId); err != nil {
+			return err
+		}
+
+		// Access tokens already issued stay valid until they expire, JWT_ACCESS_TTL_MIN at most
+		revoked, err = authRepo.RevokeAccountSessions(ctx, accountId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Warn("Account locked from a sign in alert", "account_id", accountId, "sessions_revoked", revoked)
+	return nil
+}
+
+// createSessionToken creates a session and its tokens, refreshed from prev or a new family when prev is nil
+func (uc *authUsecase) createSessionToken(ctx context.Context, kind, userAgent, fingerprint string, accountId, organizationId *string, prev *Session) (*AccessToken, error) {
+	cfg := uc.cfg.Load()
+
 	// create session with refresh token
-	session, err := NewSession(&uc.cfg.Auth, userAgent, accountId)
+	session, err := NewSession(&cfg.Auth, userAgent, accountId, organizationId)
 	if err != nil {
 		return nil, err
 	}
+	session.Fingerprint = fingerprint
+	if prev != nil {
+		session.ContinueFamily(prev)
+	}
 
-	var sessionId string
+	var sessionId, role string
 	var userId *string
 	if kind == "guest" || accountId == nil {
 		sessionId, err = uc.authRepo.CreateGuestSession(ctx, session)
@@ -219,13 +316,19 @@ func (uc *authUsecase) createSessionToken(ctx context.Context, kind, userAgent s
 			return nil, err
 		}
 
+		// Read on every issue so a refresh picks up a promotion or demotion
+		role, err = uc.authRepo.GetRoleByAccountId(ctx, *accountId)
+		if err != nil {
+			return nil, err
+		}
+
 		sessionId, err = uc.authRepo.CreateUserSession(ctx, session)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	accessToken, exp, err := security.NewAccessToken(uc.cfg.Auth.JWTSecret, uc.cfg.Auth.JWTAccessTTL, sessionId, kind, accountId, userId)
+	accessToken, exp, err := security.NewAccessToken(security.SigningKey{ID: cfg.Auth.JWTKeyID, Secret: cfg.Auth.JWTSecret}, cfg.Auth.JWTAccessTTL, sessionId, kind, role, accountId, userId, organizationId)
 	if err != nil {
 		return nil, err
 	}
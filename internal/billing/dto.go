@@ -0,0 +1,87 @@
+package billing
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type CheckoutRequest struct {
+	Plan string `json:"plan" validate:"required,oneof=premium" example:"premium" enums:"premium"`
+}
+
+// RefundRequest gives back part of a payment, the rest of it when amount is unset
+type RefundRequest struct {
+	Amount *int64 `json:"amount,omitempty" validate:"gt=0" example:"4900"` // smallest currency unit
+}
+
+type CheckoutResponse struct {
+	ID  string `json:"id" example:"cs_test_a1b2c3d4e5f6"`
+	URL string `json:"url" example:"https://checkout.stripe.com/c/pay/cs_test_a1b2c3d4e5f6"` // where the user pays
+}
+
+type BillingResponse struct {
+	Plan         string                `json:"plan" example:"premium" enums:"free,premium"`
+	Entitlements []string              `json:"entitlements" example:"premium"`
+	Subscription *SubscriptionResponse `json:"subscription,omitempty"` // latest subscription, unset when never subscribed
+}
+
+type SubscriptionResponse struct {
+	Plan              string     `json:"plan" example:"premium"`
+	Status            string     `json:"status" example:"active"` // as the payment provider reports it
+	CurrentPeriodEnd  *time.Time `json:"currentPeriodEnd,omitempty" example:"2025-10-21T07:30:00Z"`
+	CancelAtPeriodEnd bool       `json:"cancelAtPeriodEnd" example:"false"`
+}
+
+type PaymentResponse struct {
+	ID       string    `json:"id" example:"0f1e2d3c-4b5a-4697-8877-665544332211"`
+	UserID   string    `json:"userId" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Amount   int64     `json:"amount" example:"4900"` // smallest currency unit
+	Refunded int64     `json:"refunded" example:"0"`
+	Currency string    `json:"currency" example:"idr"`
+	PaidAt   time.Time `json:"paidAt" example:"2025-09-21T07:30:00Z"`
+}
+
+func (r *CheckoutRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RefundRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newBillingResponse(sub *Subscription, now time.Time) BillingResponse {
+	res := BillingResponse{Plan: PlanFree, Entitlements: []string{}}
+	if sub == nil {
+		return res
+	}
+
+	if sub.Active(now) {
+		res.Plan = sub.Plan
+		res.Entitlements = append(res.Entitlements, EntitlementPremium)
+	}
+	res.Subscription = &SubscriptionResponse{
+		Plan:              sub.Plan,
+		Status:            sub.Status,
+		CurrentPeriodEnd:  sub.CurrentPeriodEnd,
+		CancelAtPeriodEnd: sub.CancelAtPeriodEnd,
+	}
+	return res
+}
+
+func newPaymentResponse(p *Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:       p.ID,
+		UserID:   p.UserID,
+		Amount:   p.Amount,
+		Refunded: p.Refunded,
+		Currency: p.Currency,
+		PaidAt:   p.PaidAt,
+	}
+}
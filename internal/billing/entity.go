@@ -0,0 +1,85 @@
+package billing
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrBillingDisabled   = errors.New("billing disabled")
+	ErrAlreadySubscribed = errors.New("already subscribed")
+	ErrPaymentNotFound   = errors.New("payment not found")
+	ErrFullyRefunded     = errors.New("payment fully refunded")
+	ErrRefundTooLarge    = errors.New("refund larger than payment")
+	ErrRefundKeyReused   = errors.New("idempotency key used by another refund")
+	// ErrUnknownSubscription is returned for an invoice event delivered before the events of its
+	// subscription, the provider delivers it again later
+	ErrUnknownSubscription = errors.New("unknown subscription")
+)
+
+// Plans and the entitlements they grant
+const (
+	PlanFree    = "free"
+	PlanPremium = "premium"
+
+	EntitlementPremium = "premium"
+)
+
+// Subscription is the premium plan of a user at the payment provider
+type Subscription struct {
+	ID                     string
+	UserID                 string
+	Plan                   string
+	ProviderCustomerID     string
+	ProviderSubscriptionID string
+	Status                 string // as the provider reports it
+	CurrentPeriodEnd       *time.Time
+	CancelAtPeriodEnd      bool
+	SyncedAt               time.Time // time of the last applied event
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// Active reports whether the subscription grants its plan at now. A past due subscription
+// keeps it while the provider retries the payment.
+func (s *Subscription) Active(now time.Time) bool {
+	switch s.Status {
+	case "active", "trialing", "past_due":
+		return s.CurrentPeriodEnd == nil || now.Before(*s.CurrentPeriodEnd)
+	default:
+		return false
+	}
+}
+
+// Statuses of a refund
+const (
+	RefundPending   = "pending"
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
+
+// Payment is an invoice paid for a subscription, amounts are in the smallest currency unit
+type Payment struct {
+	ID                      string
+	UserID                  string
+	SubscriptionID          *string
+	ProviderInvoiceID       string
+	ProviderPaymentIntentID *string
+	Amount                  int64
+	Refunded                int64
+	Currency                string
+	PaidAt                  time.Time
+	UpdatedAt               time.Time
+}
+
+// Refund is a refund of a payment asked by an admin, pending until the provider answers
+type Refund struct {
+	ID               string
+	PaymentID        string
+	Amount           int64
+	IdempotencyKey   *string
+	Status           string
+	ProviderRefundID *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
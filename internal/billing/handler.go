@@ -0,0 +1,227 @@
+package billing
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// maxIdempotencyKey is the longest idempotency key of a refund, the limit of the provider
+const maxIdempotencyKey = 255
+
+type BillingHandler struct {
+	billingUsecase BillingUsecase
+}
+
+func NewBillingHandler(billingUsecase BillingUsecase) *BillingHandler {
+	return &BillingHandler{billingUsecase}
+}
+
+// Checkout handles subscribing to a plan
+// @Summary Subscribe to premium
+// @Description Open a hosted checkout of the premium plan for the signed in user, the client sends them to its url. The plan is granted once the payment provider confirms the subscription.
+// @Tags Billing
+// @Accept json
+// @Produce json
+// @Param request body CheckoutRequest true "Plan to subscribe to"
+// @Success 201 {object} response.Success{data=CheckoutResponse} "Checkout created successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 409 {object} response.Error "Already subscribed"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Failure 503 {object} response.Error "Billing disabled or payment provider unavailable"
+// @Security ApiKeyAuth
+// @Router /billing/checkout [post]
+func (h *BillingHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req CheckoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.billingUsecase.Checkout(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// Get handles the plan of the signed in user
+// @Summary Get my plan
+// @Description The plan and entitlements of the signed in user with their latest subscription
+// @Tags Billing
+// @Produce json
+// @Success 200 {object} response.Success{data=BillingResponse} "Plan retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /billing [get]
+func (h *BillingHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	res, err := h.billingUsecase.Get(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// ListPayments handles the payments of the signed in user
+// @Summary List my payments
+// @Description The invoices paid by the signed in user, last paid first, with the amount refunded of each
+// @Tags Billing
+// @Produce json
+// @Success 200 {object} response.Success{data=[]PaymentResponse} "Payments retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /billing/payments [get]
+func (h *BillingHandler) ListPayments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	res, err := h.billingUsecase.ListPayments(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Webhook handles the events posted by the payment provider
+// @Summary Payment provider webhook
+// @Description Called by the payment provider with the events of checkouts, subscriptions, invoices and refunds, signed in the Stripe-Signature header. An event is applied once however often it's delivered.
+// @Tags Billing
+// @Accept json
+// @Produce json
+// @Param Stripe-Signature header string true "Signature of the payload" example("t=1700000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd")
+// @Success 200 {object} response.Success{data=response.Message} "Event received"
+// @Failure 400 {object} response.Error "Invalid signature"
+// @Failure 409 {object} response.Error "Event delivered before its subscription"
+// @Failure 413 {object} response.Error "Request body too large"
+// @Failure 503 {object} response.Error "Billing disabled"
+// @Router /billing/webhook [post]
+func (h *BillingHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := h.billingUsecase.HandleEvent(r.Context(), payload, r.Header.Get("Stripe-Signature")); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Event received"})
+}
+
+// ListUserPayments handles the payments of a user for admins
+// @Summary List the payments of a user
+// @Description The invoices paid by a user, last paid first, with the amount refunded of each. Admin only.
+// @Tags Billing
+// @Produce json
+// @Param id path string true "User ID" example("a1b2c3d4-e5f6-7890-1234-567890abcdef")
+// @Success 200 {object} response.Success{data=[]PaymentResponse} "Payments retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/payments [get]
+func (h *BillingHandler) ListUserPayments(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.billingUsecase.ListPayments(r.Context(), id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Refund handles refunding a payment
+// @Summary Refund a payment
+// @Description Give back an amount of a payment through the payment provider, the rest of it without amount. The subscription it paid is kept, cancel it at the provider to end it. Sent again with the same Idempotency-Key, ex: after a timeout, the refund asked first is made once. Admin only.
+// @Tags Billing
+// @Accept json
+// @Produce json
+// @Param id path string true "Payment ID" example("0f1e2d3c-4b5a-4697-8877-665544332211")
+// @Param Idempotency-Key header string false "Unique to the refund, up to 255 characters" example("b7e3c1a2-9f04-4d6e-8a51-2c7d9e0f1a3b")
+// @Param request body RefundRequest false "Amount to refund"
+// @Success 200 {object} response.Success{data=PaymentResponse} "Payment refunded successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Payment not found"
+// @Failure 409 {object} response.Error "Payment fully refunded, amount more than left or idempotency key used by another refund"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Failure 503 {object} response.Error "Billing disabled or payment provider unavailable"
+// @Security ApiKeyAuth
+// @Router /admin/payments/{id}/refund [post]
+func (h *BillingHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	key := r.Header.Get("Idempotency-Key")
+	if len(key) > maxIdempotencyKey {
+		response.ValidationError(w, map[string]string{"Idempotency-Key": "Idempotency key must be at most 255 characters"})
+		return
+	}
+
+	var req RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.billingUsecase.Refund(r.Context(), id, key, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
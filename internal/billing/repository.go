@@ -0,0 +1,266 @@
+package billing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+type BillingRepository interface {
+	// GetSubscription returns the latest subscription of the user, nil when they never subscribed
+	GetSubscription(ctx context.Context, userID string) (*Subscription, error)
+	// SyncSubscription creates or updates the subscription of its provider ID, unless an event
+	// newer than sub.SyncedAt was applied to it. Without user the subscription is only updated.
+	SyncSubscription(ctx context.Context, sub *Subscription) error
+	// RecordPayment stores a paid invoice of a subscription once, filling its user.
+	// ErrUnknownSubscription when the subscription isn't stored yet.
+	RecordPayment(ctx context.Context, subscriptionID string, payment *Payment) error
+	// RecordRefund raises the amount refunded of the payment of a payment intent to refunded,
+	// capped to its amount. Refunds reported again or out of order don't lower it.
+	RecordRefund(ctx context.Context, paymentIntentID string, refunded int64) error
+	// RecordEvent marks a webhook event applied, false when it already was
+	RecordEvent(ctx context.Context, id, eventType string) (bool, error)
+	// GetPaymentForUpdate returns a payment of the tenant and locks it until the transaction ends,
+	// ErrPaymentNotFound when none matches
+	GetPaymentForUpdate(ctx context.Context, id string) (*Payment, error)
+	// ListPayments returns the payments of the user in the tenant, last paid first
+	ListPayments(ctx context.Context, userID string) ([]*Payment, error)
+	// GetRefundByKey returns the refund of the payment asked with an idempotency key, nil when none
+	GetRefundByKey(ctx context.Context, paymentID, key string) (*Refund, error)
+	// PendingRefunds returns the amount of the refunds of the payment the provider hasn't answered
+	PendingRefunds(ctx context.Context, paymentID string) (int64, error)
+	// CreateRefund stores a pending refund, filling its ID
+	CreateRefund(ctx context.Context, refund *Refund) error
+	// FinishRefund records the answer of the provider to a refund, a succeeded refund stays so
+	FinishRefund(ctx context.Context, id, status string, providerRefundID *string) error
+	// ApplyRefunds raises the amount refunded of the payment to its succeeded refunds and returns it
+	ApplyRefunds(ctx context.Context, paymentID string) (*Payment, error)
+	// WithTx returns a repository running every query in tx
+	WithTx(tx pgx.Tx) BillingRepository
+}
+
+type billingRepository struct {
+	db database.DBTX
+}
+
+func NewBillingRepositry(db database.DBTX) BillingRepository {
+	return &billingRepository{db}
+}
+
+func (r *billingRepository) WithTx(tx pgx.Tx) BillingRepository {
+	return &billingRepository{db: database.Rebind(r.db, tx)}
+}
+
+const paymentColumns = `
+	p.id, p.user_id, p.subscription_id, p.provider_invoice_id, p.provider_payment_intent_id, p.amount,
+	p.refunded, p.currency, p.paid_at, p.updated_at`
+
+func (r *billingRepository) GetSubscription(ctx context.Context, userID string) (*Subscription, error) {
+	const q = `
+		SELECT id, user_id, plan, provider_customer_id, provider_subscription_id, status, current_period_end,
+			cancel_at_period_end, synced_at, created_at, updated_at
+		FROM subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	sub, err := database.Get[Subscription](ctx, r.db, q, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return sub, err
+}
+
+func (r *billingRepository) SyncSubscription(ctx context.Context, sub *Subscription) error {
+	// the events of a checkout and its subscription arrive in any order, a checkout knows neither
+	// the period end nor the customer of an update without it
+	const update = `
+		UPDATE subscriptions
+		SET status = $2,
+			current_period_end = COALESCE($3, current_period_end),
+			cancel_at_period_end = $4,
+			provider_customer_id = COALESCE(NULLIF($5, ''), provider_customer_id),
+			synced_at = $6,
+			updated_at = now()
+		WHERE provider_subscription_id = $1 AND synced_at <= $6`
+
+	const upsert = `
+		INSERT INTO subscriptions (user_id, plan, provider_customer_id, provider_subscription_id, status,
+			current_period_end, cancel_at_period_end, synced_at)
+		VALUES ($7, $8, $5, $1, $2, $3, $4, $6)
+		ON CONFLICT ON CONSTRAINT uq_subscriptions_provider DO UPDATE
+		SET status = excluded.status,
+			current_period_end = COALESCE(excluded.current_period_end, subscriptions.current_period_end),
+			cancel_at_period_end = excluded.cancel_at_period_end,
+			provider_customer_id = COALESCE(NULLIF(excluded.provider_customer_id, ''), subscriptions.provider_customer_id),
+			synced_at = excluded.synced_at,
+			updated_at = now()
+		WHERE subscriptions.synced_at <= excluded.synced_at`
+
+	args := []any{
+		sub.ProviderSubscriptionID,
+		sub.Status,
+		sub.CurrentPeriodEnd,
+		sub.CancelAtPeriodEnd,
+		sub.ProviderCustomerID,
+		sub.SyncedAt,
+	}
+
+	q := update
+	if sub.UserID != "" {
+		q = upsert
+		args = append(args, sub.UserID, sub.Plan)
+	}
+
+	_, err := r.db.Exec(ctx, q, args...)
+	return err
+}
+
+func (r *billingRepository) RecordPayment(ctx context.Context, subscriptionID string, payment *Payment) error {
+	const q = `
+		WITH s AS (
+			SELECT id, user_id FROM subscriptions WHERE provider_subscription_id = $1
+		), ins AS (
+			INSERT INTO payments (user_id, subscription_id, provider_invoice_id, provider_payment_intent_id,
+				amount, currency, paid_at)
+			SELECT s.user_id, s.id, $2, NULLIF($3, ''), $4, $5, $6 FROM s
+			ON CONFLICT ON CONSTRAINT uq_payments_provider DO NOTHING
+		)
+		SELECT user_id::text FROM s`
+
+	var paymentIntentID string
+	if payment.ProviderPaymentIntentID != nil {
+		paymentIntentID = *payment.ProviderPaymentIntentID
+	}
+
+	err := r.db.QueryRow(ctx, q,
+		subscriptionID,
+		payment.ProviderInvoiceID,
+		paymentIntentID,
+		payment.Amount,
+		payment.Currency,
+		payment.PaidAt,
+	).Scan(&payment.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUnknownSubscription
+	}
+	return err
+}
+
+func (r *billingRepository) RecordRefund(ctx context.Context, paymentIntentID string, refunded int64) error {
+	const q = `
+		UPDATE payments
+		SET refunded = GREATEST(refunded, LEAST($2, amount)), updated_at = now()
+		WHERE provider_payment_intent_id = $1`
+
+	_, err := r.db.Exec(ctx, q, paymentIntentID, refunded)
+	return err
+}
+
+func (r *billingRepository) RecordEvent(ctx context.Context, id, eventType string) (bool, error) {
+	const q = `
+		INSERT INTO billing_events (id, type)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO NOTHING`
+
+	tag, err := r.db.Exec(ctx, q, id, eventType)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *billingRepository) GetPaymentForUpdate(ctx context.Context, id string) (*Payment, error) {
+	// Payments belong to the tenant of the account that paid them
+	const q = `
+		SELECT ` + paymentColumns + `
+		FROM payments p
+		JOIN users u ON u.id = p.user_id
+		JOIN accounts a ON a.id = u.account_id
+		WHERE p.id = $1
+			AND ($2::uuid IS NULL OR a.organization_id = $2)
+		FOR UPDATE OF p`
+
+	payment, err := database.Get[Payment](ctx, r.db, q, id, tenant.ID(ctx))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, err
+}
+
+func (r *billingRepository) ListPayments(ctx context.Context, userID string) ([]*Payment, error) {
+	const q = `
+		SELECT ` + paymentColumns + `
+		FROM payments p
+		JOIN users u ON u.id = p.user_id
+		JOIN accounts a ON a.id = u.account_id
+		WHERE p.user_id = $1
+			AND ($2::uuid IS NULL OR a.organization_id = $2)
+		ORDER BY p.paid_at DESC, p.id`
+
+	return database.Select[Payment](ctx, r.db, q, userID, tenant.ID(ctx))
+}
+
+const refundColumns = `
+	id, payment_id, amount, idempotency_key, status, provider_refund_id, created_at, updated_at`
+
+func (r *billingRepository) GetRefundByKey(ctx context.Context, paymentID, key string) (*Refund, error) {
+	const q = `SELECT ` + refundColumns + ` FROM refunds WHERE payment_id = $1 AND idempotency_key = $2`
+
+	refund, err := database.Get[Refund](ctx, r.db, q, paymentID, key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return refund, err
+}
+
+func (r *billingRepository) PendingRefunds(ctx context.Context, paymentID string) (int64, error) {
+	const q = `SELECT COALESCE(sum(amount), 0) FROM refunds WHERE payment_id = $1 AND status = 'pending'`
+
+	var pending int64
+	err := r.db.QueryRow(ctx, q, paymentID).Scan(&pending)
+	return pending, err
+}
+
+func (r *billingRepository) CreateRefund(ctx context.Context, refund *Refund) error {
+	const q = `
+		INSERT INTO refunds (payment_id, amount, idempotency_key)
+		VALUES ($1, $2, $3)
+		RETURNING id, status, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, q, refund.PaymentID, refund.Amount, refund.IdempotencyKey).
+		Scan(&refund.ID, &refund.Status, &refund.CreatedAt, &refund.UpdatedAt)
+}
+
+func (r *billingRepository) FinishRefund(ctx context.Context, id, status string, providerRefundID *string) error {
+	// A duplicate request failing while the first one succeeds doesn't undo it
+	const q = `
+		UPDATE refunds
+		SET status = $2, provider_refund_id = COALESCE($3, provider_refund_id), updated_at = now()
+		WHERE id = $1 AND status <> 'succeeded'`
+
+	_, err := r.db.Exec(ctx, q, id, status, providerRefundID)
+	return err
+}
+
+func (r *billingRepository) ApplyRefunds(ctx context.Context, paymentID string) (*Payment, error) {
+	// Refunds made at the provider directly are only known from the charge.refunded event, the
+	// amount it reported is kept when larger
+	const q = `
+		UPDATE payments p
+		SET refunded = GREATEST(p.refunded, LEAST(p.amount, (
+				SELECT COALESCE(sum(amount), 0) FROM refunds WHERE payment_id = $1 AND status = 'succeeded'
+			))),
+			updated_at = now()
+		WHERE p.id = $1
+		RETURNING ` + paymentColumns
+
+	payment, err := database.Get[Payment](ctx, r.db, q, paymentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, err
+}
@@ -0,0 +1,18 @@
+package billing
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the plan and payments of users, the webhook of the payment provider and
+// refunds by admins
+func (h *BillingHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("GET /api/v1/billing", mw.Protected(http.HandlerFunc(h.Get)))
+	mux.Handle("POST /api/v1/billing/checkout", mw.Protected(http.HandlerFunc(h.Checkout)))
+	mux.Handle("GET /api/v1/billing/payments", mw.Protected(http.HandlerFunc(h.ListPayments)))
+	mux.Handle("POST /api/v1/billing/webhook", mw.Webhook(http.HandlerFunc(h.Webhook)))
+	mux.Handle("GET /api/v1/admin/users/{id}/payments", mw.Admin(http.HandlerFunc(h.ListUserPayments)))
+	mux.Handle("POST /api/v1/admin/payments/{id}/refund", mw.Admin(http.HandlerFunc(h.Refund)))
+}
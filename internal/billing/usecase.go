@@ -0,0 +1,285 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/payment"
+)
+
+type BillingUsecase interface {
+	// Checkout opens a checkout of the plan, ErrAlreadySubscribed when the user holds it
+	Checkout(ctx context.Context, userID string, req *CheckoutRequest) (*CheckoutResponse, error)
+	// Get returns the plan and entitlements of the user
+	Get(ctx context.Context, userID string) (*BillingResponse, error)
+	// ListPayments returns the payments of the user, last paid first
+	ListPayments(ctx context.Context, userID string) ([]PaymentResponse, error)
+	// Refund gives back part or the rest of a payment through the provider. Sent again with the
+	// same idempotency key, the refund asked first is made once.
+	Refund(ctx context.Context, paymentID, idempotencyKey string, req *RefundRequest) (*PaymentResponse, error)
+	// HandleEvent verifies a webhook payload and applies its event once, payment.ErrInvalidSignature
+	// when it's not signed by the provider
+	HandleEvent(ctx context.Context, payload []byte, signature string) error
+	// Entitled reports whether the user holds the entitlement now
+	Entitled(ctx context.Context, userID, entitlement string) (bool, error)
+}
+
+type billingUsecase struct {
+	pool        *pgxpool.Pool
+	cfg         config.BillingConfig
+	billingRepo BillingRepository
+	provider    payment.Provider // nil when billing is disabled
+}
+
+func NewBillingUsecase(pool *pgxpool.Pool, cfg config.BillingConfig, billingRepo BillingRepository, provider payment.Provider) BillingUsecase {
+	return &billingUsecase{pool, cfg, billingRepo, provider}
+}
+
+func (u *billingUsecase) Checkout(ctx context.Context, userID string, req *CheckoutRequest) (*CheckoutResponse, error) {
+	if u.provider == nil {
+		return nil, ErrBillingDisabled
+	}
+
+	sub, err := u.billingRepo.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := payment.CheckoutParams{
+		PriceID:    u.cfg.PremiumPriceID,
+		UserID:     userID,
+		SuccessURL: u.cfg.SuccessURL,
+		CancelURL:  u.cfg.CancelURL,
+	}
+	if sub != nil {
+		if sub.Active(time.Now()) {
+			return nil, ErrAlreadySubscribed
+		}
+		params.CustomerID = sub.ProviderCustomerID
+	}
+
+	checkout, err := u.provider.CreateCheckout(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckoutResponse{ID: checkout.ID, URL: checkout.URL}, nil
+}
+
+func (u *billingUsecase) Get(ctx context.Context, userID string) (*BillingResponse, error) {
+	sub, err := u.billingRepo.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newBillingResponse(sub, time.Now())
+	return &res, nil
+}
+
+func (u *billingUsecase) ListPayments(ctx context.Context, userID string) ([]PaymentResponse, error) {
+	payments, err := u.billingRepo.ListPayments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]PaymentResponse, len(payments))
+	for i, p := range payments {
+		res[i] = newPaymentResponse(p)
+	}
+	return res, nil
+}
+
+func (u *billingUsecase) Refund(ctx context.Context, paymentID, idempotencyKey string, req *RefundRequest) (*PaymentResponse, error) {
+	if u.provider == nil {
+		return nil, ErrBillingDisabled
+	}
+
+	// The refund is stored pending while the payment is locked, a concurrent refund waits and
+	// sees the amount it holds. The provider is called once the lock is released.
+	var p *Payment
+	var refund *Refund
+	err := database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.billingRepo.WithTx(tx)
+
+		var err error
+		if p, err = repo.GetPaymentForUpdate(ctx, paymentID); err != nil {
+			return err
+		}
+		if p.ProviderPaymentIntentID == nil {
+			return ErrFullyRefunded
+		}
+
+		// A retry of the admin sends the refund asked first again
+		if idempotencyKey != "" {
+			if refund, err = repo.GetRefundByKey(ctx, p.ID, idempotencyKey); err != nil {
+				return err
+			}
+			if refund != nil {
+				if req.Amount != nil && *req.Amount != refund.Amount {
+					return ErrRefundKeyReused
+				}
+				return nil
+			}
+		}
+
+		pending, err := repo.PendingRefunds(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+
+		left := p.Amount - p.Refunded - pending
+		if left <= 0 {
+			return ErrFullyRefunded
+		}
+		amount := left
+		if req.Amount != nil {
+			if *req.Amount > left {
+				return ErrRefundTooLarge
+			}
+			amount = *req.Amount
+		}
+
+		refund = &Refund{PaymentID: p.ID, Amount: amount}
+		if idempotencyKey != "" {
+			refund.IdempotencyKey = &idempotencyKey
+		}
+		return repo.CreateRefund(ctx, refund)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.Status == RefundSucceeded {
+		res := newPaymentResponse(p)
+		return &res, nil
+	}
+
+	// Keyed by the stored refund, the provider refunds once however often it's sent
+	result, err := u.provider.Refund(ctx, *p.ProviderPaymentIntentID, refund.Amount, "refund:"+refund.ID)
+
+	// Recorded even when the request was canceled, or the amount stays held
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		if ferr := u.billingRepo.FinishRefund(ctx, refund.ID, RefundFailed, nil); ferr != nil {
+			logger.FromContext(ctx).Error("failed to record a failed refund", "refund_id", refund.ID, "error", ferr)
+		}
+		return nil, err
+	}
+
+	// The charge.refunded event records it too, whichever comes first
+	err = database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.billingRepo.WithTx(tx)
+
+		if err := repo.FinishRefund(ctx, refund.ID, RefundSucceeded, &result.ID); err != nil {
+			return err
+		}
+
+		var err error
+		p, err = repo.ApplyRefunds(ctx, p.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newPaymentResponse(p)
+	return &res, nil
+}
+
+func (u *billingUsecase) HandleEvent(ctx context.Context, payload []byte, signature string) error {
+	if u.provider == nil {
+		return ErrBillingDisabled
+	}
+
+	event, err := u.provider.ParseEvent(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	// The event is marked applied with its changes, a failure leaves it to the next delivery
+	return database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.billingRepo.WithTx(tx)
+
+		applied, err := repo.RecordEvent(ctx, event.ID, event.Type)
+		if err != nil || !applied {
+			return err
+		}
+
+		return u.apply(ctx, repo, event)
+	})
+}
+
+func (u *billingUsecase) apply(ctx context.Context, repo BillingRepository, event *payment.Event) error {
+	obj := event.Object
+
+	switch event.Type {
+	case payment.EventCheckoutCompleted:
+		if obj.Subscription == "" || obj.ClientReferenceID == "" {
+			return nil
+		}
+		// A checkout only ties the subscription to the user, its status comes with the events of
+		// the subscription so any of them, before or after, wins over it
+		return repo.SyncSubscription(ctx, &Subscription{
+			UserID:                 obj.ClientReferenceID,
+			Plan:                   PlanPremium,
+			ProviderCustomerID:     obj.Customer,
+			ProviderSubscriptionID: obj.Subscription,
+			Status:                 "incomplete",
+		})
+
+	case payment.EventSubscriptionCreated, payment.EventSubscriptionUpdated, payment.EventSubscriptionDeleted:
+		sub := &Subscription{
+			UserID:                 obj.Metadata["userId"],
+			Plan:                   PlanPremium,
+			ProviderCustomerID:     obj.Customer,
+			ProviderSubscriptionID: obj.ID,
+			Status:                 obj.Status,
+			CancelAtPeriodEnd:      obj.CancelAtPeriodEnd,
+			SyncedAt:               event.Created,
+		}
+		if obj.CurrentPeriodEnd > 0 {
+			end := time.Unix(obj.CurrentPeriodEnd, 0).UTC()
+			sub.CurrentPeriodEnd = &end
+		}
+		return repo.SyncSubscription(ctx, sub)
+
+	case payment.EventInvoicePaid:
+		if obj.Subscription == "" {
+			return nil
+		}
+		p := &Payment{
+			ProviderInvoiceID: obj.ID,
+			Amount:            obj.AmountPaid,
+			Currency:          obj.Currency,
+			PaidAt:            event.Created,
+		}
+		if obj.PaymentIntent != "" {
+			p.ProviderPaymentIntentID = &obj.PaymentIntent
+		}
+		return repo.RecordPayment(ctx, obj.Subscription, p)
+
+	case payment.EventChargeRefunded:
+		if obj.PaymentIntent == "" {
+			return nil
+		}
+		return repo.RecordRefund(ctx, obj.PaymentIntent, obj.AmountRefunded)
+
+	default:
+		logger.FromContext(ctx).Debug("billing webhook: event ignored", "event_id", event.ID, "type", event.Type)
+		return nil
+	}
+}
+
+func (u *billingUsecase) Entitled(ctx context.Context, userID, entitlement string) (bool, error) {
+	sub, err := u.billingRepo.GetSubscription(ctx, userID)
+	if err != nil || sub == nil {
+		return false, err
+	}
+
+	return entitlement == EntitlementPremium && sub.Active(time.Now()), nil
+}
@@ -0,0 +1,153 @@
+package coach
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+const (
+	// maxUpcomingAssignments caps the assignments of a coach to an athlete not yet due
+	maxUpcomingAssignments = 50
+	// inboxDays is how far back the inbox of an athlete and the assignments seen by a coach go
+	inboxDays = 30
+	// maxAssignmentsListed caps the assignments of a list and of a compliance report
+	maxAssignmentsListed = 500
+)
+
+func (u *coachUsecase) Assign(ctx context.Context, coachID, athleteID string, req *AssignRequest) (*AssignmentResponse, error) {
+	if err := u.Authorize(ctx, coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	name, err := u.coachRepo.GetTrainingName(ctx, req.TrainingID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := u.coachRepo.CountUpcoming(ctx, coachID, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxUpcomingAssignments {
+		return nil, ErrAssignmentLimit
+	}
+
+	assignment := &Assignment{
+		CoachUserID:   coachID,
+		AthleteUserID: athleteID,
+		TrainingID:    req.TrainingID,
+		TrainingName:  name,
+		TargetPace:    req.TargetPace,
+		Notes:         req.Notes,
+	}
+	assignment.DueOn, _ = time.Parse(time.DateOnly, req.DueOn)
+
+	if err := u.coachRepo.CreateAssignment(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	res := newAssignmentResponse(assignment, time.Now())
+	return &res, nil
+}
+
+func (u *coachUsecase) Unassign(ctx context.Context, coachID, athleteID, id string) error {
+	if err := u.Authorize(ctx, coachID, athleteID); err != nil {
+		return err
+	}
+	return u.coachRepo.DeleteAssignment(ctx, coachID, athleteID, id)
+}
+
+func (u *coachUsecase) ListAssigned(ctx context.Context, athleteID string, query *AssignmentsQuery) ([]AssignmentResponse, error) {
+	return u.listAssignments(ctx, AssignmentFilter{AthleteID: athleteID}, query.Status)
+}
+
+func (u *coachUsecase) ListAssignmentsForCoach(ctx context.Context, coachID, athleteID string, query *AssignmentsQuery) ([]AssignmentResponse, error) {
+	if err := u.Authorize(ctx, coachID, athleteID); err != nil {
+		return nil, err
+	}
+	return u.listAssignments(ctx, AssignmentFilter{CoachID: coachID, AthleteID: athleteID}, query.Status)
+}
+
+// listAssignments lists the assignments due in the last inboxDays or later, of a status when set
+func (u *coachUsecase) listAssignments(ctx context.Context, filter AssignmentFilter, status string) ([]AssignmentResponse, error) {
+	now := time.Now()
+	from := dayOf(now).AddDate(0, 0, -inboxDays)
+	filter.DueFrom, filter.Limit = &from, maxAssignmentsListed
+
+	assignments, err := u.coachRepo.ListAssignments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]AssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		if status == "" || a.Status(now) == status {
+			res = append(res, newAssignmentResponse(a, now))
+		}
+	}
+	return res, nil
+}
+
+func (u *coachUsecase) GetCompliance(ctx context.Context, coachID string, query *ComplianceQuery) (*ComplianceResponse, error) {
+	athletes, err := u.coachRepo.ListAthletes(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	to := dayOf(now)
+	from := to.AddDate(0, 0, 1-query.Days)
+
+	assignments, err := u.coachRepo.ListAssignments(ctx, AssignmentFilter{CoachID: coachID, DueFrom: &from, DueTo: &to, Limit: maxAssignmentsListed})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ComplianceResponse{
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		Athletes: make([]AthleteComplianceResponse, len(athletes)),
+	}
+
+	byAthlete := make(map[string]*AthleteComplianceResponse, len(athletes))
+	for i, athlete := range athletes {
+		res.Athletes[i] = AthleteComplianceResponse{UserID: athlete.UserID, Name: athlete.Name}
+		byAthlete[athlete.UserID] = &res.Athletes[i]
+	}
+
+	for _, a := range assignments {
+		c := byAthlete[a.AthleteUserID]
+		if c == nil {
+			continue
+		}
+
+		c.Assigned++
+		switch a.Status(now) {
+		case AssignmentCompleted:
+			c.Completed++
+			if a.OnTime() {
+				c.OnTime++
+			}
+			if a.TargetPace != nil {
+				c.WithTargetPace++
+				if a.PaceMet() {
+					c.PaceMet++
+				}
+			}
+		case AssignmentOverdue:
+			c.Overdue++
+		default:
+			c.Pending++
+		}
+	}
+
+	for i := range res.Athletes {
+		if c := &res.Athletes[i]; c.Assigned > c.Pending {
+			rate := math.Round(float64(c.OnTime)/float64(c.Assigned-c.Pending)*1000) / 10
+			c.Rate = &rate
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,163 @@
+package coach
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type GrantCoachRequest struct {
+	Email string `json:"email" validate:"required,lower,email,max=254" example:"coach@swimo.id"`
+}
+
+type MemberResponse struct {
+	UserID    string    `json:"userId" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	Name      string    `json:"name" example:"Dina Kusuma"`
+	Email     string    `json:"email" example:"coach@swimo.id"`
+	GrantedAt time.Time `json:"grantedAt" example:"2025-09-21T07:30:00Z"`
+}
+
+type AssignRequest struct {
+	TrainingID string   `json:"trainingId" validate:"required,uuid" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	DueOn      string   `json:"dueOn" validate:"required,date" example:"2025-09-28"`       // UTC date, today or later
+	TargetPace *float64 `json:"targetPace,omitempty" validate:"gt=0,max=60" example:"2.1"` // minutes/100m
+	Notes      *string  `json:"notes,omitempty" validate:"max=1000" example:"Negative split the last 200m"`
+}
+
+type AssignmentsQuery struct {
+	Status string `query:"status" validate:"oneof=pending overdue completed"`
+}
+
+type ComplianceQuery struct {
+	Days int `query:"days" validate:"min=7,max=365"`
+}
+
+// AssignmentResponse is a training assigned by a coach, completed by the first session of the
+// athlete on it since it was assigned
+type AssignmentResponse struct {
+	ID           string                 `json:"id" example:"4d2c6b1a-9e8f-4a7b-b6c5-d4e3f2a1b0c9"`
+	Coach        AssignmentUserResponse `json:"coach"`
+	Athlete      AssignmentUserResponse `json:"athlete"`
+	TrainingID   string                 `json:"trainingId" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	TrainingName string                 `json:"trainingName" example:"Endurance 1500m"`
+	DueOn        string                 `json:"dueOn" example:"2025-09-28"`
+	TargetPace   *float64               `json:"targetPace,omitempty" example:"2.1"`
+	Notes        *string                `json:"notes,omitempty" example:"Negative split the last 200m"`
+	Status       string                 `json:"status" example:"completed" enums:"pending,overdue,completed"`
+	Completion   *CompletionResponse    `json:"completion,omitempty"` // unset until completed
+	CreatedAt    time.Time              `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+}
+
+type AssignmentUserResponse struct {
+	UserID string `json:"userId" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	Name   string `json:"name" example:"Dina Kusuma"`
+}
+
+// CompletionResponse is the session completing an assignment
+type CompletionResponse struct {
+	SessionID   string    `json:"sessionId" example:"9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"`
+	CompletedAt time.Time `json:"completedAt" example:"2025-09-27T06:45:00Z"`
+	Pace        float64   `json:"pace" example:"2.05"`
+	OnTime      bool      `json:"onTime" example:"true"`
+	PaceMet     *bool     `json:"paceMet,omitempty" example:"true"` // unset without target pace
+}
+
+// ComplianceResponse sums the assignments of the coach due in the range ending today, per athlete
+type ComplianceResponse struct {
+	From     string                      `json:"from" example:"2025-08-25"`
+	To       string                      `json:"to" example:"2025-09-21"`
+	Athletes []AthleteComplianceResponse `json:"athletes"`
+}
+
+// AthleteComplianceResponse counts the assignments due in the range. Assignments due today and
+// not done yet are pending, neither complied with nor overdue.
+type AthleteComplianceResponse struct {
+	UserID         string   `json:"userId" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	Name           string   `json:"name" example:"Dina Kusuma"`
+	Assigned       int      `json:"assigned" example:"8"`
+	Completed      int      `json:"completed" example:"7"`
+	OnTime         int      `json:"onTime" example:"6"`
+	Overdue        int      `json:"overdue" example:"1"`
+	Pending        int      `json:"pending" example:"0"`
+	WithTargetPace int      `json:"withTargetPace" example:"4"`  // completed with a target pace
+	PaceMet        int      `json:"paceMet" example:"3"`         // of those, swum at or under it
+	Rate           *float64 `json:"rate,omitempty" example:"75"` // percent completed on time of those no longer pending, unset without any
+}
+
+func (r *GrantCoachRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newMemberResponses(members []Member) []MemberResponse {
+	res := make([]MemberResponse, len(members))
+	for i, m := range members {
+		res[i] = MemberResponse{UserID: m.UserID, Name: m.Name, Email: m.Email, GrantedAt: m.GrantedAt}
+	}
+	return res
+}
+
+func (r *AssignRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	if r.DueOn < time.Now().UTC().Format(time.DateOnly) {
+		return &validator.ValidationError{Errors: map[string]string{"dueOn": "Due on must not be in the past"}}
+	}
+	return nil
+}
+
+func (q *AssignmentsQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *ComplianceQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newAssignmentResponse(a *Assignment, now time.Time) AssignmentResponse {
+	res := AssignmentResponse{
+		ID:           a.ID,
+		Coach:        AssignmentUserResponse{UserID: a.CoachUserID, Name: a.CoachName},
+		Athlete:      AssignmentUserResponse{UserID: a.AthleteUserID, Name: a.AthleteName},
+		TrainingID:   a.TrainingID,
+		TrainingName: a.TrainingName,
+		DueOn:        a.DueOn.Format(time.DateOnly),
+		TargetPace:   a.TargetPace,
+		Notes:        a.Notes,
+		Status:       a.Status(now),
+		CreatedAt:    a.CreatedAt,
+	}
+
+	if a.CompletedAt != nil {
+		res.Completion = &CompletionResponse{
+			SessionID:   *a.SessionID,
+			CompletedAt: *a.CompletedAt,
+			Pace:        *a.Pace,
+			OnTime:      a.OnTime(),
+		}
+		if a.TargetPace != nil {
+			met := a.PaceMet()
+			res.Completion.PaceMet = &met
+		}
+	}
+
+	return res
+}
+
+func newAssignmentResponses(assignments []*Assignment, now time.Time) []AssignmentResponse {
+	res := make([]AssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		res[i] = newAssignmentResponse(a, now)
+	}
+	return res
+}
@@ -0,0 +1,83 @@
+package coach
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCoachNotFound   = errors.New("coach not found")
+	ErrCoachSelf       = errors.New("cannot coach yourself")
+	ErrCoachLimit      = errors.New("coach limit reached")
+	ErrAthleteNotFound = errors.New("athlete not found")
+
+	ErrAssignmentNotFound       = errors.New("assignment not found")
+	ErrAssignmentLimit          = errors.New("assignment limit reached")
+	ErrAssignedTrainingNotFound = errors.New("assigned training not found")
+)
+
+// Assignment statuses, pending until due, then overdue until completed
+const (
+	AssignmentPending   = "pending"
+	AssignmentOverdue   = "overdue"
+	AssignmentCompleted = "completed"
+)
+
+// Member is the other side of a coaching access, the coach for the athlete or the athlete
+// for the coach. Athletes grant and revoke the access, coaches can only read.
+type Member struct {
+	UserID    string
+	Name      string
+	Email     string
+	GrantedAt time.Time
+}
+
+// Assignment is a training a coach assigns to an athlete, due on a UTC date. The first session
+// of the athlete on the training since it was assigned completes it.
+type Assignment struct {
+	ID            string
+	CoachUserID   string
+	CoachName     string
+	AthleteUserID string
+	AthleteName   string
+	TrainingID    string
+	TrainingName  string
+	DueOn         time.Time
+	TargetPace    *float64 // minutes/100m
+	Notes         *string
+	CreatedAt     time.Time
+
+	// Session completing the assignment, unset while none
+	SessionID   *string
+	CompletedAt *time.Time
+	Pace        *float64
+}
+
+// Status returns the status of the assignment on the UTC day of now
+func (a *Assignment) Status(now time.Time) string {
+	switch {
+	case a.CompletedAt != nil:
+		return AssignmentCompleted
+	case a.DueOn.Before(dayOf(now)):
+		return AssignmentOverdue
+	default:
+		return AssignmentPending
+	}
+}
+
+// OnTime reports whether the assignment was completed by the end of its due date
+func (a *Assignment) OnTime() bool {
+	return a.CompletedAt != nil && !dayOf(*a.CompletedAt).After(a.DueOn)
+}
+
+// PaceMet reports whether the completing session was at or under the target pace, false
+// without target
+func (a *Assignment) PaceMet() bool {
+	return a.TargetPace != nil && a.Pace != nil && *a.Pace <= *a.TargetPace
+}
+
+// dayOf truncates t to midnight UTC
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
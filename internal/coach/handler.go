@@ -0,0 +1,365 @@
+package coach
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type CoachHandler struct {
+	coachUsecase CoachUsecase
+}
+
+func NewCoachHandler(coachUsecase CoachUsecase) *CoachHandler {
+	return &CoachHandler{coachUsecase}
+}
+
+// Grant handles giving a coach access to the records of the signed in user
+// @Summary Grant coach access
+// @Description Give the user signed up with the email read access to the records of the signed in athlete, ex: injuries
+// @Tags Coach
+// @Accept json
+// @Produce json
+// @Param request body GrantCoachRequest true "Email of the coach"
+// @Success 201 {object} response.Success{data=MemberResponse} "Coach access granted successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Coach not found"
+// @Failure 409 {object} response.Error "Coach limit reached"
+// @Failure 422 {object} response.Error "Validation errors or You cannot be your own coach"
+// @Security ApiKeyAuth
+// @Router /coaches [post]
+func (h *CoachHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req GrantCoachRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.coachUsecase.Grant(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// ListCoaches handles listing the coaches of the signed in user
+// @Summary List coaches
+// @Description The coaches with access to the records of the signed in athlete, newest grant first
+// @Tags Coach
+// @Produce json
+// @Success 200 {object} response.Success{data=[]MemberResponse} "Coaches retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /coaches [get]
+func (h *CoachHandler) ListCoaches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	coaches, err := h.coachUsecase.ListCoaches(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, coaches)
+}
+
+// Revoke handles removing the access of a coach
+// @Summary Revoke coach access
+// @Description Remove the access of a coach to the records of the signed in athlete
+// @Tags Coach
+// @Produce json
+// @Param id path string true "User ID of the coach" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=response.Message} "Coach access revoked"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Coach not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /coaches/{id} [delete]
+func (h *CoachHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.coachUsecase.Revoke(ctx, *claim.Uid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Coach access revoked"})
+}
+
+// ListAthletes handles listing the athletes coached by the signed in user
+// @Summary List athletes
+// @Description The athletes who granted the signed in coach access to their records, by name
+// @Tags Coach
+// @Produce json
+// @Success 200 {object} response.Success{data=[]MemberResponse} "Athletes retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /athletes [get]
+func (h *CoachHandler) ListAthletes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	athletes, err := h.coachUsecase.ListAthletes(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, athletes)
+}
+
+// Assign handles assigning a training to an athlete of the signed in coach
+// @Summary Assign a training
+// @Description Assign an approved training to an athlete who granted the signed in coach access, due on a UTC date with an optional target pace. The first session of the athlete on the training since the assignment completes it, whatever the way it was recorded. A coach can have up to 50 assignments not yet due per athlete.
+// @Tags Coach
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID of the athlete" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Param request body AssignRequest true "Training, due date and target"
+// @Success 201 {object} response.Success{data=AssignmentResponse} "Training assigned successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Athlete or training not found"
+// @Failure 409 {object} response.Error "Assignment limit reached"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /athletes/{id}/assignments [post]
+func (h *CoachHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req AssignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.coachUsecase.Assign(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// ListAthleteAssignments handles listing the assignments of the signed in coach to an athlete
+// @Summary List athlete assignments
+// @Description The assignments of the signed in coach to an athlete due in the last 30 days or later, by due date
+// @Tags Coach
+// @Produce json
+// @Param id path string true "User ID of the athlete" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Param status query string false "Only the assignments in this status" Enums(pending,overdue,completed)
+// @Success 200 {object} response.Success{data=[]AssignmentResponse} "Assignments retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Athlete not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /athletes/{id}/assignments [get]
+func (h *CoachHandler) ListAthleteAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	query := AssignmentsQuery{Status: r.URL.Query().Get("status")}
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	assignments, err := h.coachUsecase.ListAssignmentsForCoach(ctx, *claim.Uid, id, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, assignments)
+}
+
+// Unassign handles removing an assignment of the signed in coach
+// @Summary Remove an assignment
+// @Description Remove an assignment of the signed in coach to an athlete, completed or not
+// @Tags Coach
+// @Produce json
+// @Param id path string true "User ID of the athlete" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Param assignmentId path string true "Assignment ID" example("4d2c6b1a-9e8f-4a7b-b6c5-d4e3f2a1b0c9")
+// @Success 200 {object} response.Success{data=response.Message} "Assignment removed"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Athlete or assignment not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /athletes/{id}/assignments/{assignmentId} [delete]
+func (h *CoachHandler) Unassign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id, assignmentID := r.PathValue("id"), r.PathValue("assignmentId")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+	if !validator.IsValidUUID(assignmentID) {
+		response.ValidationError(w, map[string]string{"assignmentId": "Assignment ID is not a valid ID"})
+		return
+	}
+
+	if err := h.coachUsecase.Unassign(ctx, *claim.Uid, id, assignmentID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Assignment removed"})
+}
+
+// GetCompliance handles the compliance report of the athletes of the signed in coach
+// @Summary Assignment compliance
+// @Description For each athlete of the signed in coach, the assignments due in the range ending today: completed, on time, overdue, pending while due today, and the completed ones swum at or under their target pace. The rate is the percent completed on time out of those no longer pending.
+// @Tags Coach
+// @Produce json
+// @Param days query int false "Days of the range, today included" minimum(7) maximum(365) default(28)
+// @Success 200 {object} response.Success{data=ComplianceResponse} "Compliance retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /athletes/compliance [get]
+func (h *CoachHandler) GetCompliance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	query := ComplianceQuery{Days: 28}
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			response.ValidationError(w, map[string]string{"days": "Days must be a number"})
+			return
+		}
+		query.Days = days
+	}
+
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.coachUsecase.GetCompliance(ctx, *claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// ListAssigned handles the assigned inbox of the signed in athlete
+// @Summary List assigned trainings
+// @Description The trainings assigned to the signed in athlete by their coaches, due in the last 30 days or later, by due date. A session on the training since the assignment completes it.
+// @Tags Coach
+// @Produce json
+// @Param status query string false "Only the assignments in this status" Enums(pending,overdue,completed)
+// @Success 200 {object} response.Success{data=[]AssignmentResponse} "Assignments retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /assignments [get]
+func (h *CoachHandler) ListAssigned(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	query := AssignmentsQuery{Status: r.URL.Query().Get("status")}
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	assignments, err := h.coachUsecase.ListAssigned(ctx, *claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, assignments)
+}
@@ -0,0 +1,260 @@
+package coach
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+type CoachRepository interface {
+	// GetUserByEmail returns the user signed up with the email in the tenant, ErrCoachNotFound when none
+	GetUserByEmail(ctx context.Context, email string) (*Member, error)
+	// CountCoaches returns the coaches with access to the athlete
+	CountCoaches(ctx context.Context, athleteID string) (int, error)
+	// Grant gives the coach access to the athlete, granting twice keeps the first grant
+	Grant(ctx context.Context, athleteID string, coach *Member) error
+	// Revoke removes the access of the coach, ErrCoachNotFound when it had none
+	Revoke(ctx context.Context, athleteID, coachID string) error
+	ListCoaches(ctx context.Context, athleteID string) ([]Member, error)
+	ListAthletes(ctx context.Context, coachID string) ([]Member, error)
+	HasAccess(ctx context.Context, coachID, athleteID string) (bool, error)
+
+	// GetTrainingName returns the name of an approved training of the tenant, ErrAssignedTrainingNotFound when none
+	GetTrainingName(ctx context.Context, trainingID string) (string, error)
+	// CountUpcoming returns the assignments of the coach to the athlete due today or later
+	CountUpcoming(ctx context.Context, coachID, athleteID string) (int, error)
+	// CreateAssignment stores the assignment and fills its id and the names of the coach and athlete
+	CreateAssignment(ctx context.Context, assignment *Assignment) error
+	// ListAssignments returns the assignments matching the filter by due date with the session
+	// completing them. Assignments of a coach who lost access are left out.
+	ListAssignments(ctx context.Context, filter AssignmentFilter) ([]*Assignment, error)
+	// DeleteAssignment removes an assignment of the coach to the athlete, ErrAssignmentNotFound when none
+	DeleteAssignment(ctx context.Context, coachID, athleteID, id string) error
+}
+
+// AssignmentFilter narrows ListAssignments, empty fields match every assignment
+type AssignmentFilter struct {
+	CoachID   string
+	AthleteID string
+	DueFrom   *time.Time
+	DueTo     *time.Time
+	Limit     int
+}
+
+type coachRepository struct {
+	db database.DBTX
+}
+
+func NewCoachRepositry(db database.DBTX) CoachRepository {
+	return &coachRepository{db}
+}
+
+func (r *coachRepository) GetUserByEmail(ctx context.Context, email string) (*Member, error) {
+	const q = `
+		SELECT u.id, u.name, a.email
+		FROM accounts a
+		JOIN users u ON u.account_id = a.id
+		WHERE a.email = $1
+			AND ($2::uuid IS NULL OR a.organization_id = $2)
+			AND u.deleted_at IS NULL
+		LIMIT 1`
+
+	var m Member
+	if err := r.db.QueryRow(ctx, q, email, tenant.ID(ctx)).Scan(&m.UserID, &m.Name, &m.Email); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCoachNotFound
+		}
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (r *coachRepository) CountCoaches(ctx context.Context, athleteID string) (int, error) {
+	const q = `SELECT count(*) FROM coach_athletes WHERE athlete_user_id = $1`
+
+	var count int
+	err := r.db.QueryRow(ctx, q, athleteID).Scan(&count)
+	return count, err
+}
+
+func (r *coachRepository) Grant(ctx context.Context, athleteID string, coach *Member) error {
+	// The no-op update returns the existing row on a second grant
+	const q = `
+		INSERT INTO coach_athletes (coach_user_id, athlete_user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (coach_user_id, athlete_user_id) DO UPDATE SET coach_user_id = EXCLUDED.coach_user_id
+		RETURNING created_at`
+
+	return r.db.QueryRow(ctx, q, coach.UserID, athleteID).Scan(&coach.GrantedAt)
+}
+
+func (r *coachRepository) Revoke(ctx context.Context, athleteID, coachID string) error {
+	const q = `DELETE FROM coach_athletes WHERE coach_user_id = $1 AND athlete_user_id = $2`
+
+	tag, err := r.db.Exec(ctx, q, coachID, athleteID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCoachNotFound
+	}
+
+	return nil
+}
+
+func (r *coachRepository) ListCoaches(ctx context.Context, athleteID string) ([]Member, error) {
+	const q = `
+		SELECT u.id, u.name, a.email, ca.created_at
+		FROM coach_athletes ca
+		JOIN users u ON u.id = ca.coach_user_id
+		JOIN accounts a ON a.id = u.account_id
+		WHERE ca.athlete_user_id = $1 AND u.deleted_at IS NULL
+		ORDER BY ca.created_at DESC`
+
+	return r.listMembers(ctx, q, athleteID)
+}
+
+func (r *coachRepository) ListAthletes(ctx context.Context, coachID string) ([]Member, error) {
+	const q = `
+		SELECT u.id, u.name, a.email, ca.created_at
+		FROM coach_athletes ca
+		JOIN users u ON u.id = ca.athlete_user_id
+		JOIN accounts a ON a.id = u.account_id
+		WHERE ca.coach_user_id = $1 AND u.deleted_at IS NULL
+		ORDER BY u.name`
+
+	return r.listMembers(ctx, q, coachID)
+}
+
+func (r *coachRepository) listMembers(ctx context.Context, q, userID string) ([]Member, error) {
+	rows, err := r.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []Member
+	for rows.Next() {
+		var m Member
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.GrantedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *coachRepository) HasAccess(ctx context.Context, coachID, athleteID string) (bool, error) {
+	const q = `SELECT EXISTS (SELECT 1 FROM coach_athletes WHERE coach_user_id = $1 AND athlete_user_id = $2)`
+
+	var ok bool
+	err := r.db.QueryRow(ctx, q, coachID, athleteID).Scan(&ok)
+	return ok, err
+}
+
+func (r *coachRepository) GetTrainingName(ctx context.Context, trainingID string) (string, error) {
+	const q = `
+		SELECT name
+		FROM trainings
+		WHERE id = $1
+			AND status = 'approved'
+			AND deleted_at IS NULL
+			AND (organization_id IS NULL OR organization_id = $2)`
+
+	var name string
+	if err := r.db.QueryRow(ctx, q, trainingID, tenant.ID(ctx)).Scan(&name); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrAssignedTrainingNotFound
+		}
+		return "", err
+	}
+
+	return name, nil
+}
+
+func (r *coachRepository) CountUpcoming(ctx context.Context, coachID, athleteID string) (int, error) {
+	const q = `
+		SELECT count(*)
+		FROM coach_assignments
+		WHERE coach_user_id = $1 AND athlete_user_id = $2 AND due_on >= (now() AT TIME ZONE 'UTC')::date`
+
+	var count int
+	err := r.db.QueryRow(ctx, q, coachID, athleteID).Scan(&count)
+	return count, err
+}
+
+func (r *coachRepository) CreateAssignment(ctx context.Context, assignment *Assignment) error {
+	const q = `
+		WITH ins AS (
+			INSERT INTO coach_assignments (coach_user_id, athlete_user_id, training_id, due_on, target_pace, notes)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at
+		)
+		SELECT ins.id, ins.created_at, cu.name, au.name
+		FROM ins
+		JOIN users cu ON cu.id = $1
+		JOIN users au ON au.id = $2`
+
+	return r.db.QueryRow(ctx, q,
+		assignment.CoachUserID,
+		assignment.AthleteUserID,
+		assignment.TrainingID,
+		assignment.DueOn,
+		assignment.TargetPace,
+		assignment.Notes,
+	).Scan(&assignment.ID, &assignment.CreatedAt, &assignment.CoachName, &assignment.AthleteName)
+}
+
+func (r *coachRepository) ListAssignments(ctx context.Context, filter AssignmentFilter) ([]*Assignment, error) {
+	// The first live session of the athlete on the training since the assignment completes it
+	const q = `
+		SELECT
+			ca.id, ca.coach_user_id, cu.name AS coach_name, ca.athlete_user_id, au.name AS athlete_name,
+			ca.training_id, t.name AS training_name, ca.due_on, ca.target_pace, ca.notes, ca.created_at,
+			s.id AS session_id, s.created_at AS completed_at, s.pace
+		FROM coach_assignments ca
+		JOIN coach_athletes access ON access.coach_user_id = ca.coach_user_id AND access.athlete_user_id = ca.athlete_user_id
+		JOIN users cu ON cu.id = ca.coach_user_id
+		JOIN users au ON au.id = ca.athlete_user_id
+		JOIN trainings t ON t.id = ca.training_id
+		LEFT JOIN LATERAL (
+			SELECT ts.id, ts.created_at, ts.pace
+			FROM training_sessions ts
+			WHERE ts.user_id = ca.athlete_user_id
+				AND ts.training_id = ca.training_id
+				AND ts.created_at >= ca.created_at
+				AND ts.deleted_at IS NULL
+			ORDER BY ts.created_at
+			LIMIT 1
+		) s ON true
+		WHERE ($1 = '' OR ca.coach_user_id = $1::uuid)
+			AND ($2 = '' OR ca.athlete_user_id = $2::uuid)
+			AND ($3::date IS NULL OR ca.due_on >= $3)
+			AND ($4::date IS NULL OR ca.due_on <= $4)
+			AND cu.deleted_at IS NULL
+			AND au.deleted_at IS NULL
+		ORDER BY ca.due_on, ca.created_at
+		LIMIT $5`
+
+	return database.Select[Assignment](ctx, r.db, q, filter.CoachID, filter.AthleteID, filter.DueFrom, filter.DueTo, filter.Limit)
+}
+
+func (r *coachRepository) DeleteAssignment(ctx context.Context, coachID, athleteID, id string) error {
+	const q = `DELETE FROM coach_assignments WHERE id = $1 AND coach_user_id = $2 AND athlete_user_id = $3`
+
+	tag, err := r.db.Exec(ctx, q, id, coachID, athleteID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAssignmentNotFound
+	}
+
+	return nil
+}
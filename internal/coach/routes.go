@@ -0,0 +1,21 @@
+package coach
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the coaching access endpoints, athletes manage their coaches, and the
+// assignments coaches give their athletes
+func (h *CoachHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/coaches", mw.Protected(http.HandlerFunc(h.Grant)))
+	mux.Handle("GET /api/v1/coaches", mw.Protected(http.HandlerFunc(h.ListCoaches)))
+	mux.Handle("DELETE /api/v1/coaches/{id}", mw.Protected(http.HandlerFunc(h.Revoke)))
+	mux.Handle("GET /api/v1/athletes", mw.Protected(http.HandlerFunc(h.ListAthletes)))
+	mux.Handle("GET /api/v1/athletes/compliance", mw.Expensive(http.HandlerFunc(h.GetCompliance)))
+	mux.Handle("POST /api/v1/athletes/{id}/assignments", mw.Protected(http.HandlerFunc(h.Assign)))
+	mux.Handle("GET /api/v1/athletes/{id}/assignments", mw.Protected(http.HandlerFunc(h.ListAthleteAssignments)))
+	mux.Handle("DELETE /api/v1/athletes/{id}/assignments/{assignmentId}", mw.Protected(http.HandlerFunc(h.Unassign)))
+	mux.Handle("GET /api/v1/assignments", mw.Protected(http.HandlerFunc(h.ListAssigned)))
+}
@@ -0,0 +1,92 @@
+package coach
+
+import "context"
+
+// maxCoaches caps the coaches with access to an athlete
+const maxCoaches = 20
+
+type CoachUsecase interface {
+	// Grant gives the user signed up with the email access to the records of the athlete
+	Grant(ctx context.Context, athleteID string, req *GrantCoachRequest) (*MemberResponse, error)
+	Revoke(ctx context.Context, athleteID, coachID string) error
+	ListCoaches(ctx context.Context, athleteID string) ([]MemberResponse, error)
+	ListAthletes(ctx context.Context, coachID string) ([]MemberResponse, error)
+	// Authorize returns ErrAthleteNotFound unless the coach has access to the athlete
+	Authorize(ctx context.Context, coachID, athleteID string) error
+
+	// Assign gives a training to an athlete of the coach, due on a date
+	Assign(ctx context.Context, coachID, athleteID string, req *AssignRequest) (*AssignmentResponse, error)
+	// Unassign removes an assignment of the coach, completed or not
+	Unassign(ctx context.Context, coachID, athleteID, id string) error
+	// ListAssigned returns the inbox of the athlete: assignments due in the last 30 days or
+	// later from every coach, by due date
+	ListAssigned(ctx context.Context, athleteID string, query *AssignmentsQuery) ([]AssignmentResponse, error)
+	// ListAssignmentsForCoach returns the assignments of the coach to the athlete like ListAssigned
+	ListAssignmentsForCoach(ctx context.Context, coachID, athleteID string, query *AssignmentsQuery) ([]AssignmentResponse, error)
+	// GetCompliance sums the assignments of the coach due in the days of the query per athlete
+	GetCompliance(ctx context.Context, coachID string, query *ComplianceQuery) (*ComplianceResponse, error)
+}
+
+type coachUsecase struct {
+	coachRepo CoachRepository
+}
+
+func NewCoachUsecase(coachRepo CoachRepository) CoachUsecase {
+	return &coachUsecase{coachRepo}
+}
+
+func (u *coachUsecase) Grant(ctx context.Context, athleteID string, req *GrantCoachRequest) (*MemberResponse, error) {
+	coach, err := u.coachRepo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if coach.UserID == athleteID {
+		return nil, ErrCoachSelf
+	}
+
+	count, err := u.coachRepo.CountCoaches(ctx, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCoaches {
+		return nil, ErrCoachLimit
+	}
+
+	if err := u.coachRepo.Grant(ctx, athleteID, coach); err != nil {
+		return nil, err
+	}
+
+	res := newMemberResponses([]Member{*coach})
+	return &res[0], nil
+}
+
+func (u *coachUsecase) Revoke(ctx context.Context, athleteID, coachID string) error {
+	return u.coachRepo.Revoke(ctx, athleteID, coachID)
+}
+
+func (u *coachUsecase) ListCoaches(ctx context.Context, athleteID string) ([]MemberResponse, error) {
+	coaches, err := u.coachRepo.ListCoaches(ctx, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	return newMemberResponses(coaches), nil
+}
+
+func (u *coachUsecase) ListAthletes(ctx context.Context, coachID string) ([]MemberResponse, error) {
+	athletes, err := u.coachRepo.ListAthletes(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+	return newMemberResponses(athletes), nil
+}
+
+func (u *coachUsecase) Authorize(ctx context.Context, coachID, athleteID string) error {
+	ok, err := u.coachRepo.HasAccess(ctx, coachID, athleteID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAthleteNotFound
+	}
+	return nil
+}
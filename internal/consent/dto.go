@@ -0,0 +1,96 @@
+package consent
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// AcceptRequest accepts the versions of the legal documents shown to the user, they must be
+// the current ones
+type AcceptRequest struct {
+	Terms   *string `json:"terms,omitempty" validate:"max=64" example:"2026-10-01"`
+	Privacy *string `json:"privacy,omitempty" validate:"max=64" example:"2026-10-01"`
+}
+
+type MarketingRequest struct {
+	OptedIn *bool `json:"optedIn" validate:"required" example:"true"`
+}
+
+type ConsentResponse struct {
+	Documents []DocumentResponse `json:"documents"`
+	Marketing MarketingResponse  `json:"marketing"`
+}
+
+// DocumentResponse is the acceptance of a legal document, pending until the current version is accepted
+type DocumentResponse struct {
+	Document        string     `json:"document" example:"terms" enums:"terms,privacy"`
+	CurrentVersion  string     `json:"currentVersion" example:"2026-10-01"`
+	AcceptedVersion *string    `json:"acceptedVersion,omitempty" example:"2026-04-01"`
+	AcceptedAt      *time.Time `json:"acceptedAt,omitempty" example:"2026-04-02T07:30:00Z"`
+	Pending         bool       `json:"pending" example:"true"`
+}
+
+// MarketingResponse is the choice about marketing emails, never opted in until the user chooses
+type MarketingResponse struct {
+	OptedIn    bool       `json:"optedIn" example:"true"`
+	OptedInAt  *time.Time `json:"optedInAt,omitempty" example:"2026-04-02T07:30:00Z"`
+	OptedOutAt *time.Time `json:"optedOutAt,omitempty" example:"2026-06-10T18:00:00Z"`
+}
+
+func (r *AcceptRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	if r.Terms == nil && r.Privacy == nil {
+		return &validator.ValidationError{Errors: map[string]string{"terms": "Accept the terms or the privacy policy"}}
+	}
+	return nil
+}
+
+// versions returns the versions accepted by the request, by document
+func (r *AcceptRequest) versions() map[string]string {
+	versions := make(map[string]string, 2)
+	if r.Terms != nil {
+		versions[DocumentTerms] = *r.Terms
+	}
+	if r.Privacy != nil {
+		versions[DocumentPrivacy] = *r.Privacy
+	}
+	return versions
+}
+
+func (r *MarketingRequest) Validate() error {
+	return validator.Struct(r)
+}
+
+func newConsentResponse(current map[string]string, accepted []Acceptance, marketing *MarketingConsent) *ConsentResponse {
+	res := &ConsentResponse{Documents: []DocumentResponse{}, Marketing: newMarketingResponse(marketing)}
+
+	for _, document := range []string{DocumentTerms, DocumentPrivacy} {
+		version, ok := current[document]
+		if !ok {
+			continue
+		}
+
+		doc := DocumentResponse{Document: document, CurrentVersion: version, Pending: true}
+		for _, a := range accepted {
+			if a.Document == document {
+				doc.AcceptedVersion = &a.Version
+				doc.AcceptedAt = &a.AcceptedAt
+				doc.Pending = a.Version != version
+			}
+		}
+		res.Documents = append(res.Documents, doc)
+	}
+
+	return res
+}
+
+func newMarketingResponse(m *MarketingConsent) MarketingResponse {
+	if m == nil {
+		return MarketingResponse{}
+	}
+	return MarketingResponse{OptedIn: m.OptedIn, OptedInAt: m.OptedInAt, OptedOutAt: m.OptedOutAt}
+}
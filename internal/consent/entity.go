@@ -0,0 +1,56 @@
+package consent
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+// Legal documents accounts accept a version of
+const (
+	DocumentTerms   = "terms"
+	DocumentPrivacy = "privacy"
+)
+
+var ErrVersionOutdated = errors.New("consent version is not the current one")
+
+// Acceptance is the latest version of a legal document accepted by an account
+type Acceptance struct {
+	Document   string
+	Version    string
+	AcceptedAt time.Time
+}
+
+// MarketingConsent is the choice of an account about marketing emails, the time of its latest
+// opt in and opt out are kept as proof
+type MarketingConsent struct {
+	OptedIn    bool
+	OptedInAt  *time.Time
+	OptedOutAt *time.Time
+	UpdatedAt  time.Time
+}
+
+// currentVersions returns the enforced version of each document, documents without a
+// configured version are left out
+func currentVersions(cfg config.LegalConfig) map[string]string {
+	versions := make(map[string]string, 2)
+	if cfg.TermsVersion != "" {
+		versions[DocumentTerms] = cfg.TermsVersion
+	}
+	if cfg.PrivacyVersion != "" {
+		versions[DocumentPrivacy] = cfg.PrivacyVersion
+	}
+	return versions
+}
+
+// pendingVersions returns the current versions missing from accepted, by document
+func pendingVersions(current, accepted map[string]string) map[string]string {
+	pending := make(map[string]string)
+	for document, version := range current {
+		if accepted[document] != version {
+			pending[document] = version
+		}
+	}
+	return pending
+}
@@ -0,0 +1,130 @@
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type ConsentHandler struct {
+	consentUsecase ConsentUsecase
+}
+
+func NewConsentHandler(consentUsecase ConsentUsecase) *ConsentHandler {
+	return &ConsentHandler{consentUsecase}
+}
+
+// Get handles the consents of the signed in account
+// @Summary Get consents
+// @Description The current versions of the terms of service and privacy policy with the versions the account accepted, and its choice about marketing emails. Reachable while documents are pending.
+// @Tags Consent
+// @Produce json
+// @Success 200 {object} response.Success{data=ConsentResponse} "Consents retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no account"
+// @Security ApiKeyAuth
+// @Router /consents [get]
+func (h *ConsentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Aid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no account")
+		return
+	}
+
+	res, err := h.consentUsecase.Get(ctx, *claim.Aid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Accept handles accepting the current legal documents
+// @Summary Accept legal documents
+// @Description Accept the current version of the terms of service, the privacy policy or both. Other endpoints answer 428 CONSENT_REQUIRED, with the versions to accept by document, until the current versions are accepted.
+// @Tags Consent
+// @Accept json
+// @Produce json
+// @Param request body AcceptRequest true "Versions shown to the user"
+// @Success 200 {object} response.Success{data=ConsentResponse} "Documents accepted"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no account"
+// @Failure 409 {object} response.Error "Version is not the current one"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /consents [post]
+func (h *ConsentHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Aid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no account")
+		return
+	}
+
+	var req AcceptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.consentUsecase.Accept(ctx, *claim.Aid, &req, r.UserAgent())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// SetMarketing handles opting in or out of marketing emails
+// @Summary Set marketing consent
+// @Description Opt in or out of marketing emails, the time of the latest opt in and opt out are kept apart from the legal documents
+// @Tags Consent
+// @Accept json
+// @Produce json
+// @Param request body MarketingRequest true "Marketing email choice"
+// @Success 200 {object} response.Success{data=MarketingResponse} "Marketing consent updated"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no account"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /consents/marketing [put]
+func (h *ConsentHandler) SetMarketing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Aid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no account")
+		return
+	}
+
+	var req MarketingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.consentUsecase.SetMarketing(ctx, *claim.Aid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
@@ -0,0 +1,105 @@
+package consent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+)
+
+type ConsentRepository interface {
+	// ListAccepted returns the latest version accepted of each document
+	ListAccepted(ctx context.Context, accountID string) ([]Acceptance, error)
+	// Accept records the acceptance of a version of a document, accepting it again keeps the first record
+	Accept(ctx context.Context, accountID, document, version, userAgent string) error
+	// GetMarketing returns nil when the account never made a choice
+	GetMarketing(ctx context.Context, accountID string) (*MarketingConsent, error)
+	// SetMarketing opts the account in or out of marketing emails, stamping the time of a change
+	SetMarketing(ctx context.Context, accountID string, optedIn bool) (*MarketingConsent, error)
+	// WithTx returns a repository running every query in tx
+	WithTx(tx pgx.Tx) ConsentRepository
+}
+
+type consentRepository struct {
+	db database.DBTX
+}
+
+func NewConsentRepositry(db database.DBTX) ConsentRepository {
+	return &consentRepository{db}
+}
+
+func (r *consentRepository) WithTx(tx pgx.Tx) ConsentRepository {
+	return &consentRepository{db: database.Rebind(r.db, tx)}
+}
+
+func (r *consentRepository) ListAccepted(ctx context.Context, accountID string) ([]Acceptance, error) {
+	const q = `
+		SELECT DISTINCT ON (document) document, version, accepted_at
+		FROM account_consents
+		WHERE account_id = $1
+		ORDER BY document, accepted_at DESC`
+
+	rows, err := r.db.Query(ctx, q, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accepted []Acceptance
+	for rows.Next() {
+		var a Acceptance
+		if err := rows.Scan(&a.Document, &a.Version, &a.AcceptedAt); err != nil {
+			return nil, err
+		}
+		accepted = append(accepted, a)
+	}
+
+	return accepted, rows.Err()
+}
+
+func (r *consentRepository) Accept(ctx context.Context, accountID, document, version, userAgent string) error {
+	const q = `
+		INSERT INTO account_consents (account_id, document, version, user_agent)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (account_id, document, version) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, q, accountID, document, version, userAgent)
+	return err
+}
+
+func (r *consentRepository) GetMarketing(ctx context.Context, accountID string) (*MarketingConsent, error) {
+	const q = `
+		SELECT opted_in, opted_in_at, opted_out_at, updated_at
+		FROM marketing_consents
+		WHERE account_id = $1`
+
+	var m MarketingConsent
+	err := r.db.QueryRow(ctx, q, accountID).Scan(&m.OptedIn, &m.OptedInAt, &m.OptedOutAt, &m.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *consentRepository) SetMarketing(ctx context.Context, accountID string, optedIn bool) (*MarketingConsent, error) {
+	// Timestamps only move when the choice changes, repeating it keeps the original proof
+	const q = `
+		INSERT INTO marketing_consents (account_id, opted_in, opted_in_at, opted_out_at)
+		VALUES ($1, $2::boolean, CASE WHEN $2::boolean THEN now() END, CASE WHEN NOT $2::boolean THEN now() END)
+		ON CONFLICT (account_id) DO UPDATE SET
+			opted_in     = EXCLUDED.opted_in,
+			opted_in_at  = CASE WHEN EXCLUDED.opted_in AND NOT marketing_consents.opted_in THEN now() ELSE marketing_consents.opted_in_at END,
+			opted_out_at = CASE WHEN NOT EXCLUDED.opted_in AND marketing_consents.opted_in THEN now() ELSE marketing_consents.opted_out_at END,
+			updated_at   = now()
+		RETURNING opted_in, opted_in_at, opted_out_at, updated_at`
+
+	var m MarketingConsent
+	if err := r.db.QueryRow(ctx, q, accountID, optedIn).Scan(&m.OptedIn, &m.OptedInAt, &m.OptedOutAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
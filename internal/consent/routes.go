@@ -0,0 +1,17 @@
+package consent
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Path is the prefix of the consent endpoints, they stay reachable while consents are pending
+const Path = "/api/v1/consents"
+
+// Routes registers the acceptance of the legal documents and the marketing email opt in
+func (h *ConsentHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("GET "+Path, mw.Protected(http.HandlerFunc(h.Get)))
+	mux.Handle("POST "+Path, mw.Protected(http.HandlerFunc(h.Accept)))
+	mux.Handle("PUT "+Path+"/marketing", mw.Protected(http.HandlerFunc(h.SetMarketing)))
+}
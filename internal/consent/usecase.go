@@ -0,0 +1,115 @@
+package consent
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/cache"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// cacheKeyAccepted caches the versions accepted by an account once nothing is pending, an
+// account with pending documents is always read from the database so an acceptance made
+// through another instance is seen at once
+const (
+	cacheKeyAccepted = "consent:accepted:"
+	cacheTTL         = 10 * time.Minute
+)
+
+type ConsentUsecase interface {
+	Get(ctx context.Context, accountID string) (*ConsentResponse, error)
+	// Accept records the acceptance of the versions of the request, ErrVersionOutdated when one is not current
+	Accept(ctx context.Context, accountID string, req *AcceptRequest, userAgent string) (*ConsentResponse, error)
+	SetMarketing(ctx context.Context, accountID string, req *MarketingRequest) (*MarketingResponse, error)
+	// Pending returns the current versions the account has yet to accept, by document
+	Pending(ctx context.Context, accountID string) (map[string]string, error)
+}
+
+type consentUsecase struct {
+	cfg         *config.Store
+	consentRepo ConsentRepository
+	cache       cache.Cache
+}
+
+func NewConsentUsecase(cfg *config.Store, consentRepo ConsentRepository, cache cache.Cache) ConsentUsecase {
+	return &consentUsecase{cfg, consentRepo, cache}
+}
+
+func (u *consentUsecase) Get(ctx context.Context, accountID string) (*ConsentResponse, error) {
+	accepted, err := u.consentRepo.ListAccepted(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	marketing, err := u.consentRepo.GetMarketing(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConsentResponse(currentVersions(u.cfg.Load().Legal), accepted, marketing), nil
+}
+
+func (u *consentUsecase) Accept(ctx context.Context, accountID string, req *AcceptRequest, userAgent string) (*ConsentResponse, error) {
+	current := currentVersions(u.cfg.Load().Legal)
+
+	// A client showing an older text must fetch the current one, nothing is recorded
+	versions := req.versions()
+	for document, version := range versions {
+		if current[document] != version {
+			return nil, ErrVersionOutdated
+		}
+	}
+
+	for document, version := range versions {
+		if err := u.consentRepo.Accept(ctx, accountID, document, version, userAgent); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.Get(ctx, accountID)
+}
+
+func (u *consentUsecase) SetMarketing(ctx context.Context, accountID string, req *MarketingRequest) (*MarketingResponse, error) {
+	marketing, err := u.consentRepo.SetMarketing(ctx, accountID, *req.OptedIn)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newMarketingResponse(marketing)
+	return &res, nil
+}
+
+func (u *consentUsecase) Pending(ctx context.Context, accountID string) (map[string]string, error) {
+	current := currentVersions(u.cfg.Load().Legal)
+	if len(current) == 0 {
+		return nil, nil
+	}
+
+	key := cacheKeyAccepted + accountID
+
+	var cached map[string]string
+	if found, err := u.cache.Get(ctx, key, &cached); err != nil {
+		logger.FromContext(ctx).Warn("consent cache get failed", "key", key, "error", err)
+	} else if found && len(pendingVersions(current, cached)) == 0 {
+		return nil, nil
+	}
+
+	accepted, err := u.consentRepo.ListAccepted(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(accepted))
+	for _, a := range accepted {
+		versions[a.Document] = a.Version
+	}
+
+	pending := pendingVersions(current, versions)
+	if len(pending) == 0 {
+		if err := u.cache.Set(ctx, key, versions, cacheTTL); err != nil {
+			logger.FromContext(ctx).Warn("consent cache set failed", "key", key, "error", err)
+		}
+	}
+	return pending, nil
+}
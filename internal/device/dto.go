@@ -0,0 +1,45 @@
+package device
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type PairDeviceRequest struct {
+	Name     string `json:"name" validate:"required,max=64" example:"Apple Watch"`
+	Platform string `json:"platform" validate:"required,lower,oneof=watchos wearos garmin ios android" example:"watchos"`
+}
+
+type DeviceResponse struct {
+	ID         string     `json:"id" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	Name       string     `json:"name" example:"Apple Watch"`
+	Platform   string     `json:"platform" example:"watchos"`
+	CreatedAt  time.Time  `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty" example:"2025-09-22T06:10:00Z"`
+}
+
+// PairDeviceResponse returns the device token and signing secret, they are shown once. Only the
+// hash of the token is stored, the secret is stored encrypted to check signatures.
+type PairDeviceResponse struct {
+	Device        DeviceResponse `json:"device"`
+	Token         string         `json:"token" example:"swd_q8G3n0Jx2yVt5Lk7Wm1Rb4Zc9Hs6Fd0Ep3Ua8Yi5Oo"`
+	SigningSecret string         `json:"signingSecret" example:"sws_Vb7Kp2Qx9Lm4Tn6Rw1Zc8Hd3Fj5Gs0Ya2Ue7Io4Pq"` // keys the signature of batches, never sent
+}
+
+func (r *PairDeviceRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newDeviceResponse(d *Device) DeviceResponse {
+	return DeviceResponse{
+		ID:         d.ID,
+		Name:       d.Name,
+		Platform:   d.Platform,
+		CreatedAt:  d.CreatedAt,
+		LastSeenAt: d.LastSeenAt,
+	}
+}
@@ -0,0 +1,35 @@
+package device
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrDeviceNotFound     = errors.New("device not found")
+	ErrDeviceLimit        = errors.New("device limit reached")
+	ErrDeviceTokenInvalid = errors.New("invalid device token")
+	ErrPayloadType        = errors.New("unsupported payload type")
+)
+
+// Device is a watch or app install paired to a user, it authenticates with its own token and
+// signs its batches with its signing secret
+type Device struct {
+	ID            string
+	UserID        string
+	Name          string
+	Platform      string
+	TokenHash     string
+	SigningSecret string
+	CreatedAt     time.Time
+	LastSeenAt    *time.Time
+}
+
+// DeviceOwner is the account a device token acts for
+type DeviceOwner struct {
+	DeviceID       string
+	AccountID      string
+	UserID         string
+	OrganizationID *string
+	SigningSecret  *string // nil for devices paired before signing secrets
+}
@@ -0,0 +1,221 @@
+package device
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+	swimov1 "github.com/rizkyharahap/swimo/proto/swimo/v1"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+type DeviceHandler struct {
+	deviceUsecase DeviceUsecase
+}
+
+func NewDeviceHandler(deviceUsecase DeviceUsecase) *DeviceHandler {
+	return &DeviceHandler{deviceUsecase}
+}
+
+// Pair handles pairing a watch or app install to the signed in user
+// @Summary Pair a device
+// @Description Register a watch or companion app install and return its device token and signing secret. They are shown once. The token never expires and only authorizes the session ingestion of this device until the device is revoked. The secret signs its batches and is never sent.
+// @Tags Device
+// @Accept json
+// @Produce json
+// @Param request body PairDeviceRequest true "Device to pair"
+// @Success 201 {object} response.Success{data=PairDeviceResponse} "Device paired successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 409 {object} response.Error "Device limit reached"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /devices [post]
+func (h *DeviceHandler) Pair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req PairDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.deviceUsecase.Pair(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// List handles listing the paired devices of the signed in user
+// @Summary List paired devices
+// @Description Every device of the user that is not revoked, newest first
+// @Tags Device
+// @Produce json
+// @Success 200 {object} response.Success{data=[]DeviceResponse} "Devices retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /devices [get]
+func (h *DeviceHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	devices, err := h.deviceUsecase.List(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, devices)
+}
+
+// Revoke handles unpairing a device
+// @Summary Revoke a device
+// @Description Unpair a device of the user, its token stops working immediately
+// @Tags Device
+// @Produce json
+// @Param id path string true "Device ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=response.Message} "Device revoked"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Device not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /devices/{id} [delete]
+func (h *DeviceHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.deviceUsecase.Revoke(ctx, *claim.Uid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Device revoked"})
+}
+
+// IngestSessions handles the sessions uploaded by a paired device
+// @Summary Upload device sessions
+// @Description Import a batch of up to 500 sessions recorded by the device, authenticated with the device token as bearer. The body is JSON, the same document as msgpack (application/msgpack) or the swimo.v1.ImportSessionsRequest protobuf message (application/x-protobuf). With replay protection enabled, the batch is signed: X-Swimo-Timestamp (unix seconds), X-Swimo-Nonce (16 to 128 characters, unique per batch) and X-Swimo-Signature, the hex HMAC-SHA256 keyed with the signing secret returned at pairing of timestamp, nonce, method, path and body joined by newlines.
+// @Tags Device
+// @Accept json
+// @Accept application/msgpack
+// @Accept application/x-protobuf
+// @Produce json
+// @Param id path string true "Device ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Param request body training.TrainingImportSessionsRequest true "Sessions recorded by the device"
+// @Success 201 {object} response.Success{data=training.TrainingImportSessionsResponse} "Sessions imported successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 401 {object} response.Error "Invalid or revoked device token, invalid or stale signature"
+// @Failure 403 {object} response.Error "Token was issued for another device"
+// @Failure 404 {object} response.Error "Training not found"
+// @Failure 409 {object} response.Error "Request was already received"
+// @Failure 413 {object} response.Error "Request body too large"
+// @Failure 415 {object} response.Error "Payload must be JSON, msgpack or protobuf"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security DeviceToken
+// @Router /devices/{id}/sessions [post]
+func (h *DeviceHandler) IngestSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Kind != KindDevice || claim.Sub != r.PathValue("id") {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Token was issued for another device")
+		return
+	}
+
+	req, err := decodeSessions(r)
+	if err != nil {
+		if err == ErrPayloadType {
+			response.Err(w, err)
+			return
+		}
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.deviceUsecase.IngestSessions(ctx, claim, req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// decodeSessions reads the batch by Content-Type, watches send the compact binary encodings
+func decodeSessions(r *http.Request) (*training.TrainingImportSessionsRequest, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "", response.ContentTypeJSON:
+		var req training.TrainingImportSessionsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, err
+		}
+		return &req, nil
+
+	case response.ContentTypeMsgPack, "application/x-msgpack", "application/vnd.msgpack":
+		var req training.TrainingImportSessionsRequest
+		dec := msgpack.NewDecoder(r.Body)
+		dec.SetCustomStructTag("json") // same keys as the JSON body
+		if err := dec.Decode(&req); err != nil {
+			return nil, err
+		}
+		return &req, nil
+
+	case response.ContentTypeProtobuf, "application/protobuf", "application/vnd.google.protobuf":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		var msg swimov1.ImportSessionsRequest
+		if err := proto.Unmarshal(body, &msg); err != nil {
+			return nil, err
+		}
+		return training.NewImportSessionsRequest(&msg), nil
+
+	default:
+		return nil, ErrPayloadType
+	}
+}
@@ -0,0 +1,128 @@
+package device
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/crypto"
+)
+
+type DeviceRepository interface {
+	Create(ctx context.Context, device *Device) error
+	// CountActive returns the devices of the user not revoked
+	CountActive(ctx context.Context, userID string) (int, error)
+	ListByUser(ctx context.Context, userID string) ([]Device, error)
+	// Revoke invalidates the token of a device of the user, ErrDeviceNotFound when none matches
+	Revoke(ctx context.Context, userID, id string) error
+	// GetOwnerByTokenHash returns the owner of an active device whose account is not locked, with
+	// the signing secret of the device
+	GetOwnerByTokenHash(ctx context.Context, tokenHash string) (*DeviceOwner, error)
+	Touch(ctx context.Context, id string) error
+}
+
+// deviceRepository encrypts the signing secrets with cipher, bound to the owner of the device
+type deviceRepository struct {
+	db     database.DBTX
+	cipher *crypto.Cipher
+}
+
+func NewDeviceRepositry(db database.DBTX, cipher *crypto.Cipher) DeviceRepository {
+	return &deviceRepository{db, cipher}
+}
+
+func (r *deviceRepository) Create(ctx context.Context, device *Device) error {
+	const q = `
+		INSERT INTO devices (user_id, name, platform, token_hash, signing_secret)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	secret, err := r.cipher.Encrypt(device.SigningSecret, device.UserID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRow(ctx, q, device.UserID, device.Name, device.Platform, device.TokenHash, secret).Scan(&device.ID, &device.CreatedAt)
+}
+
+func (r *deviceRepository) CountActive(ctx context.Context, userID string) (int, error) {
+	const q = `SELECT count(*) FROM devices WHERE user_id = $1 AND revoked_at IS NULL`
+
+	var count int
+	err := r.db.QueryRow(ctx, q, userID).Scan(&count)
+	return count, err
+}
+
+func (r *deviceRepository) ListByUser(ctx context.Context, userID string) ([]Device, error) {
+	const q = `
+		SELECT id, user_id, name, platform, created_at, last_seen_at
+		FROM devices
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []Device
+	for rows.Next() {
+		var d Device
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Name, &d.Platform, &d.CreatedAt, &d.LastSeenAt); err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+
+	return devices, rows.Err()
+}
+
+func (r *deviceRepository) Revoke(ctx context.Context, userID, id string) error {
+	const q = `
+		UPDATE devices
+		SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, q, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (r *deviceRepository) GetOwnerByTokenHash(ctx context.Context, tokenHash string) (*DeviceOwner, error) {
+	const q = `
+		SELECT d.id, a.id, u.id, a.organization_id, d.signing_secret
+		FROM devices d
+		JOIN users u ON u.id = d.user_id
+		JOIN accounts a ON a.id = u.account_id
+		WHERE d.token_hash = $1 AND d.revoked_at IS NULL AND NOT a.is_locked AND a.deleted_at IS NULL`
+
+	var owner DeviceOwner
+	err := r.db.QueryRow(ctx, q, tokenHash).Scan(&owner.DeviceID, &owner.AccountID, &owner.UserID, &owner.OrganizationID, &owner.SigningSecret)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeviceTokenInvalid
+		}
+		return nil, err
+	}
+
+	if owner.SigningSecret, err = r.cipher.DecryptPtr(owner.SigningSecret, owner.UserID); err != nil {
+		return nil, err
+	}
+
+	return &owner, nil
+}
+
+func (r *deviceRepository) Touch(ctx context.Context, id string) error {
+	const q = `UPDATE devices SET last_seen_at = now() WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, q, id)
+	return err
+}
@@ -0,0 +1,15 @@
+package device
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the pairing endpoints and the ingestion endpoint called by devices
+func (h *DeviceHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/devices", mw.Protected(http.HandlerFunc(h.Pair)))
+	mux.Handle("GET /api/v1/devices", mw.Protected(http.HandlerFunc(h.List)))
+	mux.Handle("DELETE /api/v1/devices/{id}", mw.Protected(http.HandlerFunc(h.Revoke)))
+	mux.Handle("POST /api/v1/devices/{id}/sessions", mw.Device(http.HandlerFunc(h.IngestSessions)))
+}
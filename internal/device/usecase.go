@@ -0,0 +1,131 @@
+package device
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+const (
+	// KindDevice is the claim kind of requests authenticated with a device token
+	KindDevice = "device"
+
+	// tokenPrefix tells device tokens apart from access tokens in logs and secret scanners
+	tokenPrefix = "swd_"
+	// signingSecretPrefix tells signing secrets apart from device tokens
+	signingSecretPrefix = "sws_"
+
+	// maxDevices caps the paired devices of a user, old ones are revoked to pair more
+	maxDevices = 10
+)
+
+type DeviceUsecase interface {
+	Pair(ctx context.Context, userID string, req *PairDeviceRequest) (*PairDeviceResponse, error)
+	List(ctx context.Context, userID string) ([]DeviceResponse, error)
+	Revoke(ctx context.Context, userID, id string) error
+	// Authenticate resolves a device token to claims acting for the device owner and the signing
+	// secret of the device, nil for devices paired before signing secrets
+	Authenticate(ctx context.Context, token string) (*security.Claim, []byte, error)
+	// IngestSessions imports the sessions recorded by the device of the claims
+	IngestSessions(ctx context.Context, claim *security.Claim, req *training.TrainingImportSessionsRequest) (*training.TrainingImportSessionsResponse, error)
+}
+
+type deviceUsecase struct {
+	deviceRepo      DeviceRepository
+	trainingUsecase training.TrainingUsecase
+}
+
+func NewDeviceUsecase(deviceRepo DeviceRepository, trainingUsecase training.TrainingUsecase) DeviceUsecase {
+	return &deviceUsecase{deviceRepo, trainingUsecase}
+}
+
+func (u *deviceUsecase) Pair(ctx context.Context, userID string, req *PairDeviceRequest) (*PairDeviceResponse, error) {
+	count, err := u.deviceRepo.CountActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxDevices {
+		return nil, ErrDeviceLimit
+	}
+
+	token, err := security.NewOpaqueToken(tokenPrefix, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signing secret never travels with a request, a captured batch can't be signed again
+	secret, err := security.NewOpaqueToken(signingSecretPrefix, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	device := Device{
+		UserID:        userID,
+		Name:          req.Name,
+		Platform:      req.Platform,
+		TokenHash:     security.HashToken(token),
+		SigningSecret: secret,
+	}
+	if err := u.deviceRepo.Create(ctx, &device); err != nil {
+		return nil, err
+	}
+
+	return &PairDeviceResponse{Device: newDeviceResponse(&device), Token: token, SigningSecret: secret}, nil
+}
+
+func (u *deviceUsecase) List(ctx context.Context, userID string) ([]DeviceResponse, error) {
+	devices, err := u.deviceRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]DeviceResponse, len(devices))
+	for i := range devices {
+		res[i] = newDeviceResponse(&devices[i])
+	}
+	return res, nil
+}
+
+func (u *deviceUsecase) Revoke(ctx context.Context, userID, id string) error {
+	return u.deviceRepo.Revoke(ctx, userID, id)
+}
+
+func (u *deviceUsecase) Authenticate(ctx context.Context, token string) (*security.Claim, []byte, error) {
+	owner, err := u.deviceRepo.GetOwnerByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var secret []byte
+	if owner.SigningSecret != nil {
+		secret = []byte(*owner.SigningSecret)
+	}
+
+	return &security.Claim{
+		Sub:  owner.DeviceID,
+		Aid:  &owner.AccountID,
+		Uid:  &owner.UserID,
+		Org:  owner.OrganizationID,
+		Kind: KindDevice,
+	}, secret, nil
+}
+
+func (u *deviceUsecase) IngestSessions(ctx context.Context, claim *security.Claim, req *training.TrainingImportSessionsRequest) (*training.TrainingImportSessionsResponse, error) {
+	if req.Source == "" {
+		req.Source = training.SourceWatch
+	}
+
+	res, err := u.trainingUsecase.ImportSessions(ctx, *claim.Uid, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Last seen only helps the user spot stale devices, a failed update doesn't fail the upload
+	if err := u.deviceRepo.Touch(ctx, claim.Sub); err != nil {
+		logger.FromContext(ctx).Warn("Device last seen not updated", "device_id", claim.Sub, "error", err)
+	}
+
+	return res, nil
+}
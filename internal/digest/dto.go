@@ -0,0 +1,69 @@
+package digest
+
+import (
+	"fmt"
+	"time"
+)
+
+// Digest is the content of one weekly summary email
+type Digest struct {
+	Name         string
+	From         time.Time // local Monday of the summarized week
+	To           time.Time // local Sunday of the summarized week
+	Week         WeekStats
+	PreviousWeek WeekStats
+	Records      []string
+	Streak       int  // consecutive active weeks up to the summarized one
+	StreakLost   bool // the week broke a streak of the weeks before
+}
+
+// Empty reports whether there is nothing worth sending, users inactive for a while are not mailed
+func (d *Digest) Empty() bool {
+	return d.Week.Sessions == 0 && d.PreviousWeek.Sessions == 0 && !d.StreakLost
+}
+
+func (d *Digest) Distance() string {
+	return formatDistance(d.Week.DistanceMeters)
+}
+
+func (d *Digest) Duration() string {
+	return formatDuration(d.Week.DurationSeconds)
+}
+
+// DistanceChange compares the distance with the week before, ex: +12%, empty without a baseline
+func (d *Digest) DistanceChange() string {
+	if d.PreviousWeek.DistanceMeters == 0 {
+		return ""
+	}
+
+	change := float64(d.Week.DistanceMeters-d.PreviousWeek.DistanceMeters) / float64(d.PreviousWeek.DistanceMeters) * 100
+	return fmt.Sprintf("%+.0f%%", change)
+}
+
+// SessionsChange compares the session count with the week before, ex: +2
+func (d *Digest) SessionsChange() string {
+	return fmt.Sprintf("%+d", d.Week.Sessions-d.PreviousWeek.Sessions)
+}
+
+// formatDistance renders meters, switching to kilometers from 1000m, ex: 800 m, 2.4 km
+func formatDistance(meters int64) string {
+	if meters < 1000 {
+		return fmt.Sprintf("%d m", meters)
+	}
+	return fmt.Sprintf("%.1f km", float64(meters)/1000)
+}
+
+// formatDuration renders seconds as hours and minutes, ex: 45m, 1h 20m
+func formatDuration(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatPace renders minutes per 100m, ex: 2:05 /100m
+func formatPace(pace float64) string {
+	seconds := int(pace*60 + 0.5)
+	return fmt.Sprintf("%d:%02d /100m", seconds/60, seconds%60)
+}
@@ -0,0 +1,27 @@
+package digest
+
+import "time"
+
+// Recipient is a user due for the digest of the past week
+type Recipient struct {
+	UserID    string
+	Email     string
+	Name      string
+	Timezone  string
+	WeekStart time.Time // start of the current local week, the digest covers the week before
+}
+
+// WeekStats totals the sessions of one week
+type WeekStats struct {
+	Sessions        int
+	DistanceMeters  int64
+	DurationSeconds int64
+}
+
+// Records holds the personal bests before a week and within it, nil without sessions
+type Records struct {
+	LongestBefore *int
+	LongestWeek   *int
+	PaceBefore    *float64 // minutes per 100m, lower is better
+	PaceWeek      *float64
+}
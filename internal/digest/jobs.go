@@ -0,0 +1,30 @@
+package digest
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/scheduler"
+)
+
+// NewWeeklyDigestJob returns a job sending the weekly digest. Users are due from the send
+// hour of their local Monday, a short interval spreads sending as time zones reach it.
+func NewWeeklyDigestJob(cfg config.JobConfig, digestUsecase DigestUsecase) scheduler.Job {
+	return scheduler.Job{
+		Name:     "weekly_digest",
+		Interval: cfg.Interval,
+		Jitter:   cfg.Jitter,
+		Run: func(ctx context.Context) error {
+			sent, err := digestUsecase.SendDue(ctx)
+			if err != nil {
+				return err
+			}
+
+			if sent > 0 {
+				logger.FromContext(ctx).Info("Weekly digest sent", "users", sent)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package digest
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/database"
+)
+
+type DigestRepository interface {
+	// ListDue returns users opted in whose local time passed sendHour on Monday
+	// and who have not been handled this local week
+	ListDue(ctx context.Context, sendHour, limit int) ([]Recipient, error)
+	// Claim marks the digest of the week as handled, false when another instance took it
+	Claim(ctx context.Context, userID string, weekStart time.Time) (bool, error)
+	// Unclaim makes the user due again, after a failed send
+	Unclaim(ctx context.Context, userID string) error
+	GetWeekStats(ctx context.Context, userID string, from, to time.Time) (*WeekStats, error)
+	GetRecords(ctx context.Context, userID string, from, to time.Time) (*Records, error)
+	// GetActiveWeeks returns the local Mondays of the weeks with a session before the given time, newest first
+	GetActiveWeeks(ctx context.Context, userID, timezone string, before time.Time, limit int) ([]time.Time, error)
+}
+
+type digestRepository struct {
+	db database.DBTX
+}
+
+func NewDigestRepositry(db database.DBTX) DigestRepository {
+	return &digestRepository{db}
+}
+
+func (r *digestRepository) ListDue(ctx context.Context, sendHour, limit int) ([]Recipient, error) {
+	// Zones unknown to the database are skipped rather than failing the whole batch
+	const q = `
+		WITH users_local AS (
+			SELECT u.id, a.email, u.name, u.timezone, u.last_digest_at,
+				now() AT TIME ZONE u.timezone AS local_now,
+				date_trunc('week', now() AT TIME ZONE u.timezone) AS local_week
+			FROM users u
+			JOIN accounts a ON a.id = u.account_id
+			JOIN pg_timezone_names tz ON tz.name = u.timezone
+			WHERE u.weekly_digest AND NOT a.is_locked AND u.deleted_at IS NULL
+		)
+		SELECT id, email, name, timezone, local_week AT TIME ZONE timezone
+		FROM users_local
+		WHERE local_now >= local_week + make_interval(hours => $1)
+			AND (last_digest_at IS NULL OR last_digest_at < local_week AT TIME ZONE timezone)
+		ORDER BY id
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, q, sendHour, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []Recipient
+	for rows.Next() {
+		var rcpt Recipient
+		if err := rows.Scan(&rcpt.UserID, &rcpt.Email, &rcpt.Name, &rcpt.Timezone, &rcpt.WeekStart); err != nil {
+			return nil, err
+		}
+		res = append(res, rcpt)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *digestRepository) Claim(ctx context.Context, userID string, weekStart time.Time) (bool, error) {
+	const q = `
+		UPDATE users
+		SET last_digest_at = now()
+		WHERE id = $1 AND (last_digest_at IS NULL OR last_digest_at < $2)`
+
+	tag, err := r.db.Exec(ctx, q, userID, weekStart)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *digestRepository) Unclaim(ctx context.Context, userID string) error {
+	const q = `UPDATE users SET last_digest_at = NULL WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, q, userID)
+	return err
+}
+
+func (r *digestRepository) GetWeekStats(ctx context.Context, userID string, from, to time.Time) (*WeekStats, error) {
+	const q = `
+		SELECT count(*), COALESCE(sum(distance_meters), 0), COALESCE(sum(duration_seconds), 0)
+		FROM training_sessions
+		WHERE user_id = $1 AND created_at >= $2 AND created_at < $3 AND deleted_at IS NULL`
+
+	var stats WeekStats
+	if err := r.db.QueryRow(ctx, q, userID, from, to).Scan(&stats.Sessions, &stats.DistanceMeters, &stats.DurationSeconds); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func (r *digestRepository) GetRecords(ctx context.Context, userID string, from, to time.Time) (*Records, error) {
+	// Paces of very short sessions are noise, only sessions of 100m or more count
+	const q = `
+		SELECT
+			max(distance_meters) FILTER (WHERE created_at < $2),
+			max(distance_meters) FILTER (WHERE created_at >= $2),
+			min(pace) FILTER (WHERE created_at < $2 AND distance_meters >= 100),
+			min(pace) FILTER (WHERE created_at >= $2 AND distance_meters >= 100)
+		FROM training_sessions
+		WHERE user_id = $1 AND created_at < $3 AND deleted_at IS NULL`
+
+	var records Records
+	if err := r.db.QueryRow(ctx, q, userID, from, to).Scan(
+		&records.LongestBefore,
+		&records.LongestWeek,
+		&records.PaceBefore,
+		&records.PaceWeek,
+	); err != nil {
+		return nil, err
+	}
+
+	return &records, nil
+}
+
+func (r *digestRepository) GetActiveWeeks(ctx context.Context, userID, timezone string, before time.Time, limit int) ([]time.Time, error) {
+	const q = `
+		SELECT DISTINCT date_trunc('week', created_at AT TIME ZONE $2)::date AS week
+		FROM training_sessions
+		WHERE user_id = $1 AND created_at < $3 AND deleted_at IS NULL
+		ORDER BY week DESC
+		LIMIT $4`
+
+	rows, err := r.db.Query(ctx, q, userID, timezone, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weeks []time.Time
+	for rows.Next() {
+		var week time.Time
+		if err := rows.Scan(&week); err != nil {
+			return nil, err
+		}
+		weeks = append(weeks, week)
+	}
+
+	return weeks, rows.Err()
+}
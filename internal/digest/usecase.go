@@ -0,0 +1,184 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/mailer"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	textTemplate = template.Must(template.ParseFS(templates, "templates/digest.txt"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/digest.html"))
+)
+
+// streakLookback bounds the weeks read to compute a streak, about two years
+const streakLookback = 105
+
+type DigestUsecase interface {
+	// SendDue sends the digest of the past week to every user whose local send time has passed
+	SendDue(ctx context.Context) (int, error)
+	// Compose builds the digest of the week before weekStart
+	Compose(ctx context.Context, rcpt *Recipient) (*Digest, error)
+}
+
+type digestUsecase struct {
+	cfg        config.DigestConfig
+	digestRepo DigestRepository
+	mail       mailer.Mailer
+}
+
+func NewDigestUsecase(cfg config.DigestConfig, digestRepo DigestRepository, mail mailer.Mailer) DigestUsecase {
+	return &digestUsecase{cfg, digestRepo, mail}
+}
+
+func (u *digestUsecase) SendDue(ctx context.Context) (int, error) {
+	log := logger.FromContext(ctx)
+
+	recipients, err := u.digestRepo.ListDue(ctx, u.cfg.SendHour, u.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range recipients {
+		rcpt := &recipients[i]
+
+		// Claimed before sending, two instances never mail the same user twice
+		claimed, err := u.digestRepo.Claim(ctx, rcpt.UserID, rcpt.WeekStart)
+		if err != nil {
+			return sent, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := u.send(ctx, rcpt); err != nil {
+			log.Warn("Weekly digest not sent", "user_id", rcpt.UserID, "error", err)
+
+			// Due again on the next run
+			if err := u.digestRepo.Unclaim(ctx, rcpt.UserID); err != nil {
+				return sent, err
+			}
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (u *digestUsecase) send(ctx context.Context, rcpt *Recipient) error {
+	digest, err := u.Compose(ctx, rcpt)
+	if err != nil {
+		return err
+	}
+	if digest.Empty() {
+		return nil
+	}
+
+	var text, html bytes.Buffer
+	if err := textTemplate.Execute(&text, digest); err != nil {
+		return err
+	}
+	if err := htmlTemplate.Execute(&html, digest); err != nil {
+		return err
+	}
+
+	return u.mail.Send(ctx, mailer.Message{
+		To:      rcpt.Email,
+		Subject: fmt.Sprintf("Your swim week, %s - %s", digest.From.Format("Jan 2"), digest.To.Format("Jan 2")),
+		Text:    text.String(),
+		HTML:    html.String(),
+	})
+}
+
+func (u *digestUsecase) Compose(ctx context.Context, rcpt *Recipient) (*Digest, error) {
+	loc, err := time.LoadLocation(rcpt.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Weeks are stepped by calendar days, a DST change makes them 167 or 169 hours long
+	to := rcpt.WeekStart.In(loc)
+	from := to.AddDate(0, 0, -7)
+	previous := to.AddDate(0, 0, -14)
+
+	digest := &Digest{Name: rcpt.Name, From: from, To: to.AddDate(0, 0, -1)}
+
+	week, err := u.digestRepo.GetWeekStats(ctx, rcpt.UserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	digest.Week = *week
+
+	previousWeek, err := u.digestRepo.GetWeekStats(ctx, rcpt.UserID, previous, from)
+	if err != nil {
+		return nil, err
+	}
+	digest.PreviousWeek = *previousWeek
+
+	records, err := u.digestRepo.GetRecords(ctx, rcpt.UserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	digest.Records = newRecords(records)
+
+	weeks, err := u.digestRepo.GetActiveWeeks(ctx, rcpt.UserID, rcpt.Timezone, to, streakLookback)
+	if err != nil {
+		return nil, err
+	}
+	digest.Streak, digest.StreakLost = streak(weeks, from)
+
+	return digest, nil
+}
+
+// newRecords lists the bests beaten during the week, first sessions ever set no record
+func newRecords(r *Records) []string {
+	var res []string
+	if r.LongestBefore != nil && r.LongestWeek != nil && *r.LongestWeek > *r.LongestBefore {
+		res = append(res, "longest swim, "+formatDistance(int64(*r.LongestWeek)))
+	}
+	if r.PaceBefore != nil && r.PaceWeek != nil && *r.PaceWeek < *r.PaceBefore {
+		res = append(res, "best pace, "+formatPace(*r.PaceWeek))
+	}
+	return res
+}
+
+// streak counts the consecutive active weeks ending with the week starting at from, weeks are
+// the local Mondays newest first. lost reports a streak broken by an inactive week.
+func streak(weeks []time.Time, from time.Time) (count int, lost bool) {
+	week := dateOf(from)
+	for _, active := range weeks {
+		active = dateOf(active)
+		if active.After(week) {
+			continue
+		}
+		if !active.Equal(week) {
+			break
+		}
+
+		count++
+		week = week.AddDate(0, 0, -7)
+	}
+
+	if count == 0 && len(weeks) > 0 {
+		lost = dateOf(weeks[0]).Equal(dateOf(from).AddDate(0, 0, -7))
+	}
+	return count, lost
+}
+
+// dateOf drops the time and zone, weeks are compared as calendar dates
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,130 @@
+package equipment
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Gear kinds
+const (
+	KindFins      = "fins"
+	KindPaddles   = "paddles"
+	KindWetsuit   = "wetsuit"
+	KindPullBuoy  = "pull_buoy"
+	KindKickboard = "kickboard"
+	KindSnorkel   = "snorkel"
+	KindOther     = "other"
+)
+
+type EquipmentRequest struct {
+	Name  string  `json:"name" validate:"required,max=64" example:"Blue training fins"`
+	Kind  string  `json:"kind" validate:"required,lower,oneof=fins paddles wetsuit pull_buoy kickboard snorkel other" example:"fins"`
+	Brand *string `json:"brand,omitempty" validate:"max=64" example:"Arena"`
+	Notes *string `json:"notes,omitempty" validate:"max=500" example:"Short blade, size 40-41"`
+	// Retired gear stays in the stats but is listed last
+	Retired bool `json:"retired" example:"false"`
+}
+
+// SessionEquipmentRequest replaces the gear used in a session, an empty list clears it
+type SessionEquipmentRequest struct {
+	EquipmentIDs []string `json:"equipmentIds" validate:"max=10" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+}
+
+type EquipmentStatsQuery struct {
+	Year int `query:"year" validate:"min=2000,max=2100"`
+}
+
+type EquipmentResponse struct {
+	ID        string        `json:"id" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	Name      string        `json:"name" example:"Blue training fins"`
+	Kind      string        `json:"kind" example:"fins"`
+	Brand     *string       `json:"brand,omitempty" example:"Arena"`
+	Notes     *string       `json:"notes,omitempty" example:"Short blade, size 40-41"`
+	Retired   bool          `json:"retired" example:"false"`
+	CreatedAt time.Time     `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+	UpdatedAt time.Time     `json:"updatedAt" example:"2025-09-21T07:30:00Z"`
+	Usage     UsageResponse `json:"usage"` // lifetime
+}
+
+type UsageResponse struct {
+	Sessions        int        `json:"sessions" example:"12"`
+	DistanceMeters  int64      `json:"distanceMeters" example:"18000"`
+	DurationSeconds int64      `json:"durationSeconds" example:"21600"`
+	LastUsedAt      *time.Time `json:"lastUsedAt,omitempty" example:"2025-09-21T07:30:00Z"`
+}
+
+// EquipmentStatsResponse sums the sessions of a season, the calendar year, per kind and per
+// piece of gear. Gear unused during the season is left out.
+type EquipmentStatsResponse struct {
+	Year      int                      `json:"year" example:"2025"`
+	Kinds     []KindUsageResponse      `json:"kinds"`
+	Equipment []EquipmentUsageResponse `json:"equipment"`
+}
+
+type KindUsageResponse struct {
+	Kind string `json:"kind" example:"wetsuit"`
+	UsageResponse
+}
+
+type EquipmentUsageResponse struct {
+	ID      string `json:"id" example:"5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"`
+	Name    string `json:"name" example:"Summer wetsuit"`
+	Kind    string `json:"kind" example:"wetsuit"`
+	Retired bool   `json:"retired" example:"false"`
+	UsageResponse
+}
+
+func (r *EquipmentRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *SessionEquipmentRequest) Validate() error {
+	err := validator.Struct(r)
+	if err == nil {
+		err = &validator.ValidationError{Errors: make(map[string]string)}
+	}
+
+	if _, ok := err.Errors["equipmentIds"]; !ok {
+		for i, id := range r.EquipmentIDs {
+			if !validator.IsValidUUID(id) {
+				err.Errors[fmt.Sprintf("equipmentIds[%d]", i)] = "ID is not a valid ID"
+			}
+		}
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+
+	// Sending the same gear twice tags it once
+	slices.Sort(r.EquipmentIDs)
+	r.EquipmentIDs = slices.Compact(r.EquipmentIDs)
+	return nil
+}
+
+func (q *EquipmentStatsQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newEquipmentResponse(e *Equipment) EquipmentResponse {
+	return EquipmentResponse{
+		ID:        e.ID,
+		Name:      e.Name,
+		Kind:      e.Kind,
+		Brand:     e.Brand,
+		Notes:     e.Notes,
+		Retired:   e.RetiredAt != nil,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		Usage:     UsageResponse(e.Usage),
+	}
+}
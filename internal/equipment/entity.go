@@ -0,0 +1,39 @@
+package equipment
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEquipmentNotFound = errors.New("equipment not found")
+	ErrEquipmentLimit    = errors.New("equipment limit reached")
+)
+
+// Equipment is a piece of gear of a user, ex: fins or a wetsuit
+type Equipment struct {
+	ID        string
+	UserID    string
+	Name      string
+	Kind      string
+	Brand     *string
+	Notes     *string
+	RetiredAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Usage
+}
+
+// Usage sums the sessions a piece of gear, or a kind of gear, was used in
+type Usage struct {
+	Sessions        int
+	DistanceMeters  int64
+	DurationSeconds int64
+	LastUsedAt      *time.Time
+}
+
+// KindUsage is the usage of every piece of gear of a kind, a session counts once per kind
+type KindUsage struct {
+	Kind string
+	Usage
+}
@@ -0,0 +1,305 @@
+package equipment
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type EquipmentHandler struct {
+	equipmentUsecase EquipmentUsecase
+}
+
+func NewEquipmentHandler(equipmentUsecase EquipmentUsecase) *EquipmentHandler {
+	return &EquipmentHandler{equipmentUsecase}
+}
+
+// Create handles registering gear
+// @Summary Register equipment
+// @Description Register a piece of gear of the user, ex: fins, paddles or a wetsuit
+// @Tags Equipment
+// @Accept json
+// @Produce json
+// @Param request body EquipmentRequest true "Equipment to register"
+// @Success 201 {object} response.Success{data=EquipmentResponse} "Equipment registered successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 409 {object} response.Error "Equipment limit reached"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /equipment [post]
+func (h *EquipmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.equipmentUsecase.Create(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// List handles listing the gear of the signed in user
+// @Summary List equipment
+// @Description Every piece of gear of the user with its lifetime usage, active gear first then newest first
+// @Tags Equipment
+// @Produce json
+// @Success 200 {object} response.Success{data=[]EquipmentResponse} "Equipment retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /equipment [get]
+func (h *EquipmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	equipment, err := h.equipmentUsecase.List(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, equipment)
+}
+
+// GetById handles the detail of a piece of gear
+// @Summary Get equipment
+// @Description Get a piece of gear of the user with its lifetime usage
+// @Tags Equipment
+// @Produce json
+// @Param id path string true "Equipment ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=EquipmentResponse} "Equipment retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Equipment not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /equipment/{id} [get]
+func (h *EquipmentHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.equipmentUsecase.GetById(ctx, *claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Update handles editing a piece of gear
+// @Summary Update equipment
+// @Description Replace the details of a piece of gear of the user, retired gear keeps its usage
+// @Tags Equipment
+// @Accept json
+// @Produce json
+// @Param id path string true "Equipment ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Param request body EquipmentRequest true "Equipment details"
+// @Success 200 {object} response.Success{data=EquipmentResponse} "Equipment updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Equipment not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /equipment/{id} [put]
+func (h *EquipmentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.equipmentUsecase.Update(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Delete handles removing a piece of gear
+// @Summary Delete equipment
+// @Description Delete a piece of gear of the user and untag it from its sessions, retire it instead to keep its usage
+// @Tags Equipment
+// @Produce json
+// @Param id path string true "Equipment ID" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=response.Message} "Equipment deleted"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Equipment not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /equipment/{id} [delete]
+func (h *EquipmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.equipmentUsecase.Delete(ctx, *claim.Uid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Equipment deleted"})
+}
+
+// SetSessionEquipment handles tagging a session with the gear used
+// @Summary Tag session equipment
+// @Description Replace the gear used in a session of the user with up to 10 pieces of their gear, an empty list clears it
+// @Tags Equipment
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Param request body SessionEquipmentRequest true "Gear used"
+// @Success 200 {object} response.Success{data=[]EquipmentResponse} "Session equipment updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Session not found or Equipment not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/{id}/equipment [put]
+func (h *EquipmentHandler) SetSessionEquipment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req SessionEquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.equipmentUsecase.SetSessionEquipment(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// GetStats handles the usage of the gear during a season
+// @Summary Equipment usage
+// @Description Sessions, distance and duration with each kind of gear and each piece of gear of the user during a calendar year, ex: the wetsuit sessions of the season. A session counts once per kind.
+// @Tags Equipment
+// @Produce json
+// @Param year query int false "Season, the current year by default" example(2025)
+// @Success 200 {object} response.Success{data=EquipmentStatsResponse} "Equipment usage retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /equipment/stats [get]
+func (h *EquipmentHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	query := EquipmentStatsQuery{Year: time.Now().UTC().Year()}
+	if raw := r.URL.Query().Get("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			response.ValidationError(w, map[string]string{"year": "Year must be a number"})
+			return
+		}
+		query.Year = year
+	}
+
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.equipmentUsecase.GetStats(ctx, *claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
@@ -0,0 +1,235 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/training"
+)
+
+type EquipmentRepository interface {
+	Create(ctx context.Context, equipment *Equipment) error
+	CountByUser(ctx context.Context, userID string) (int, error)
+	// ListByUser returns the gear of the user with its lifetime usage, active gear first
+	ListByUser(ctx context.Context, userID string) ([]*Equipment, error)
+	// GetById returns gear of the user with its lifetime usage
+	GetById(ctx context.Context, userID, id string) (*Equipment, error)
+	Update(ctx context.Context, equipment *Equipment) error
+	// Delete removes gear of the user, its session tags go with it
+	Delete(ctx context.Context, userID, id string) error
+	// SetSessionEquipment replaces the gear tagged on a session of the user and returns it
+	SetSessionEquipment(ctx context.Context, userID, sessionID string, ids []string) ([]*Equipment, error)
+	// GetUsage sums the sessions created in [from, to) per piece of gear of the user
+	GetUsage(ctx context.Context, userID string, from, to time.Time) ([]*Equipment, error)
+	// GetKindUsage sums the sessions created in [from, to) per kind of gear of the user
+	GetKindUsage(ctx context.Context, userID string, from, to time.Time) ([]*KindUsage, error)
+
+	// WithTx returns a repository running every query in tx
+	WithTx(tx pgx.Tx) EquipmentRepository
+}
+
+type equipmentRepository struct {
+	db database.DBTX
+}
+
+func NewEquipmentRepositry(db database.DBTX) EquipmentRepository {
+	return &equipmentRepository{db}
+}
+
+func (r *equipmentRepository) WithTx(tx pgx.Tx) EquipmentRepository {
+	return &equipmentRepository{db: database.Rebind(r.db, tx)}
+}
+
+func (r *equipmentRepository) Create(ctx context.Context, equipment *Equipment) error {
+	const q = `
+		INSERT INTO equipment (user_id, name, kind, brand, notes, retired_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, q,
+		equipment.UserID,
+		equipment.Name,
+		equipment.Kind,
+		equipment.Brand,
+		equipment.Notes,
+		equipment.RetiredAt,
+	).Scan(&equipment.ID, &equipment.CreatedAt, &equipment.UpdatedAt)
+}
+
+func (r *equipmentRepository) CountByUser(ctx context.Context, userID string) (int, error) {
+	const q = `SELECT count(*) FROM equipment WHERE user_id = $1`
+
+	var count int
+	err := r.db.QueryRow(ctx, q, userID).Scan(&count)
+	return count, err
+}
+
+// equipmentColumns selects gear with its lifetime usage
+const equipmentColumns = `
+	e.id, e.user_id, e.name, e.kind, e.brand, e.notes, e.retired_at, e.created_at, e.updated_at,
+	u.sessions, u.distance_meters, u.duration_seconds, u.last_used_at`
+
+// equipmentUsage joins the lifetime usage of the gear aliased e
+const equipmentUsage = `
+	CROSS JOIN LATERAL (
+		SELECT
+			count(ts.id) AS sessions,
+			COALESCE(sum(ts.distance_meters), 0) AS distance_meters,
+			COALESCE(sum(ts.duration_seconds), 0) AS duration_seconds,
+			max(ts.created_at) AS last_used_at
+		FROM training_session_equipment se
+		JOIN training_sessions ts ON ts.id = se.session_id
+		WHERE se.equipment_id = e.id AND ts.deleted_at IS NULL
+	) u`
+
+func (r *equipmentRepository) ListByUser(ctx context.Context, userID string) ([]*Equipment, error) {
+	const q = `
+		SELECT ` + equipmentColumns + `
+		FROM equipment e
+		` + equipmentUsage + `
+		WHERE e.user_id = $1
+		ORDER BY e.retired_at IS NOT NULL, e.created_at DESC`
+
+	return database.Select[Equipment](ctx, r.db, q, userID)
+}
+
+func (r *equipmentRepository) GetById(ctx context.Context, userID, id string) (*Equipment, error) {
+	const q = `
+		SELECT ` + equipmentColumns + `
+		FROM equipment e
+		` + equipmentUsage + `
+		WHERE e.id = $1 AND e.user_id = $2`
+
+	e, err := database.Get[Equipment](ctx, r.db, q, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEquipmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (r *equipmentRepository) Update(ctx context.Context, equipment *Equipment) error {
+	const q = `
+		UPDATE equipment
+		SET name = $3, kind = $4, brand = $5, notes = $6, retired_at = $7, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(ctx, q,
+		equipment.ID,
+		equipment.UserID,
+		equipment.Name,
+		equipment.Kind,
+		equipment.Brand,
+		equipment.Notes,
+		equipment.RetiredAt,
+	).Scan(&equipment.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEquipmentNotFound
+	}
+
+	return err
+}
+
+func (r *equipmentRepository) Delete(ctx context.Context, userID, id string) error {
+	const q = `DELETE FROM equipment WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, q, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEquipmentNotFound
+	}
+
+	return nil
+}
+
+func (r *equipmentRepository) SetSessionEquipment(ctx context.Context, userID, sessionID string, ids []string) ([]*Equipment, error) {
+	// Locked so concurrent replacements of the same session apply one after the other
+	const sessionQ = `SELECT id FROM training_sessions WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL FOR UPDATE`
+
+	if err := r.db.QueryRow(ctx, sessionQ, sessionID, userID).Scan(&sessionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, training.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	const ownedQ = `SELECT count(*) FROM equipment WHERE id = ANY($1::uuid[]) AND user_id = $2`
+
+	var owned int
+	if err := r.db.QueryRow(ctx, ownedQ, ids, userID).Scan(&owned); err != nil {
+		return nil, err
+	}
+	if owned != len(ids) {
+		return nil, ErrEquipmentNotFound
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM training_session_equipment WHERE session_id = $1`, sessionID); err != nil {
+		return nil, err
+	}
+
+	const tagQ = `
+		INSERT INTO training_session_equipment (session_id, equipment_id)
+		SELECT $1, unnest($2::uuid[])`
+
+	if _, err := r.db.Exec(ctx, tagQ, sessionID, ids); err != nil {
+		return nil, err
+	}
+
+	const listQ = `
+		SELECT ` + equipmentColumns + `
+		FROM training_session_equipment se
+		JOIN equipment e ON e.id = se.equipment_id
+		` + equipmentUsage + `
+		WHERE se.session_id = $1
+		ORDER BY e.name`
+
+	return database.Select[Equipment](ctx, r.db, listQ, sessionID)
+}
+
+func (r *equipmentRepository) GetUsage(ctx context.Context, userID string, from, to time.Time) ([]*Equipment, error) {
+	const q = `
+		SELECT
+			e.id, e.name, e.kind, e.retired_at,
+			count(*) AS sessions, sum(ts.distance_meters) AS distance_meters,
+			sum(ts.duration_seconds) AS duration_seconds, max(ts.created_at) AS last_used_at
+		FROM equipment e
+		JOIN training_session_equipment se ON se.equipment_id = e.id
+		JOIN training_sessions ts ON ts.id = se.session_id
+		WHERE e.user_id = $1
+			AND ts.created_at >= $2 AND ts.created_at < $3
+			AND ts.deleted_at IS NULL
+		GROUP BY e.id
+		ORDER BY count(*) DESC, e.name`
+
+	return database.Select[Equipment](ctx, r.db, q, userID, from, to)
+}
+
+func (r *equipmentRepository) GetKindUsage(ctx context.Context, userID string, from, to time.Time) ([]*KindUsage, error) {
+	// Distinct per kind, a session with two pairs of fins is one fins session
+	const q = `
+		SELECT
+			kind, count(*) AS sessions, sum(distance_meters) AS distance_meters,
+			sum(duration_seconds) AS duration_seconds, max(created_at) AS last_used_at
+		FROM (
+			SELECT DISTINCT e.kind, ts.id, ts.distance_meters, ts.duration_seconds, ts.created_at
+			FROM equipment e
+			JOIN training_session_equipment se ON se.equipment_id = e.id
+			JOIN training_sessions ts ON ts.id = se.session_id
+			WHERE e.user_id = $1
+				AND ts.created_at >= $2 AND ts.created_at < $3
+				AND ts.deleted_at IS NULL
+		) s
+		GROUP BY kind
+		ORDER BY kind`
+
+	return database.Select[KindUsage](ctx, r.db, q, userID, from, to)
+}
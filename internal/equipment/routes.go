@@ -0,0 +1,18 @@
+package equipment
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the gear endpoints and the tagging of sessions with gear
+func (h *EquipmentHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/equipment", mw.Protected(http.HandlerFunc(h.Create)))
+	mux.Handle("GET /api/v1/equipment", mw.Protected(http.HandlerFunc(h.List)))
+	mux.Handle("GET /api/v1/equipment/stats", mw.Protected(http.HandlerFunc(h.GetStats)))
+	mux.Handle("GET /api/v1/equipment/{id}", mw.Protected(http.HandlerFunc(h.GetById)))
+	mux.Handle("PUT /api/v1/equipment/{id}", mw.Protected(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /api/v1/equipment/{id}", mw.Protected(http.HandlerFunc(h.Delete)))
+	mux.Handle("PUT /api/v1/trainings/sessions/{id}/equipment", mw.Protected(http.HandlerFunc(h.SetSessionEquipment)))
+}
@@ -0,0 +1,172 @@
+package equipment
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
+)
+
+// maxEquipment caps the gear of a user, retired gear included
+const maxEquipment = 100
+
+type EquipmentUsecase interface {
+	Create(ctx context.Context, userID string, req *EquipmentRequest) (*EquipmentResponse, error)
+	List(ctx context.Context, userID string) ([]EquipmentResponse, error)
+	GetById(ctx context.Context, userID, id string) (*EquipmentResponse, error)
+	Update(ctx context.Context, userID, id string, req *EquipmentRequest) (*EquipmentResponse, error)
+	Delete(ctx context.Context, userID, id string) error
+	// SetSessionEquipment replaces the gear tagged on a session of the user
+	SetSessionEquipment(ctx context.Context, userID, sessionID string, req *SessionEquipmentRequest) ([]EquipmentResponse, error)
+	// GetStats sums the usage of the gear of the user during a season
+	GetStats(ctx context.Context, userID string, query *EquipmentStatsQuery) (*EquipmentStatsResponse, error)
+}
+
+type equipmentUsecase struct {
+	pool          *pgxpool.Pool
+	equipmentRepo EquipmentRepository
+}
+
+func NewEquipmentUsecase(pool *pgxpool.Pool, equipmentRepo EquipmentRepository) EquipmentUsecase {
+	return &equipmentUsecase{pool, equipmentRepo}
+}
+
+func (u *equipmentUsecase) Create(ctx context.Context, userID string, req *EquipmentRequest) (*EquipmentResponse, error) {
+	count, err := u.equipmentRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxEquipment {
+		return nil, ErrEquipmentLimit
+	}
+
+	equipment := Equipment{
+		UserID:    userID,
+		Name:      req.Name,
+		Kind:      req.Kind,
+		Brand:     req.Brand,
+		Notes:     req.Notes,
+		RetiredAt: retiredAt(req.Retired, nil),
+	}
+	if err := u.equipmentRepo.Create(ctx, &equipment); err != nil {
+		return nil, err
+	}
+
+	res := newEquipmentResponse(&equipment)
+	return &res, nil
+}
+
+func (u *equipmentUsecase) List(ctx context.Context, userID string) ([]EquipmentResponse, error) {
+	equipment, err := u.equipmentRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]EquipmentResponse, len(equipment))
+	for i := range equipment {
+		res[i] = newEquipmentResponse(equipment[i])
+	}
+	return res, nil
+}
+
+func (u *equipmentUsecase) GetById(ctx context.Context, userID, id string) (*EquipmentResponse, error) {
+	equipment, err := u.equipmentRepo.GetById(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newEquipmentResponse(equipment)
+	return &res, nil
+}
+
+func (u *equipmentUsecase) Update(ctx context.Context, userID, id string, req *EquipmentRequest) (*EquipmentResponse, error) {
+	equipment, err := u.equipmentRepo.GetById(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	equipment.Name = req.Name
+	equipment.Kind = req.Kind
+	equipment.Brand = req.Brand
+	equipment.Notes = req.Notes
+	equipment.RetiredAt = retiredAt(req.Retired, equipment.RetiredAt)
+
+	if err := u.equipmentRepo.Update(ctx, equipment); err != nil {
+		return nil, err
+	}
+
+	res := newEquipmentResponse(equipment)
+	return &res, nil
+}
+
+func (u *equipmentUsecase) Delete(ctx context.Context, userID, id string) error {
+	return u.equipmentRepo.Delete(ctx, userID, id)
+}
+
+func (u *equipmentUsecase) SetSessionEquipment(ctx context.Context, userID, sessionID string, req *SessionEquipmentRequest) ([]EquipmentResponse, error) {
+	var equipment []*Equipment
+	err := database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		var err error
+		equipment, err = u.equipmentRepo.WithTx(tx).SetSessionEquipment(ctx, userID, sessionID, req.EquipmentIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]EquipmentResponse, len(equipment))
+	for i := range equipment {
+		res[i] = newEquipmentResponse(equipment[i])
+	}
+	return res, nil
+}
+
+func (u *equipmentUsecase) GetStats(ctx context.Context, userID string, query *EquipmentStatsQuery) (*EquipmentStatsResponse, error) {
+	from := time.Date(query.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	kinds, err := u.equipmentRepo.GetKindUsage(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	equipment, err := u.equipmentRepo.GetUsage(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &EquipmentStatsResponse{
+		Year:      query.Year,
+		Kinds:     make([]KindUsageResponse, len(kinds)),
+		Equipment: make([]EquipmentUsageResponse, len(equipment)),
+	}
+	for i, k := range kinds {
+		res.Kinds[i] = KindUsageResponse{Kind: k.Kind, UsageResponse: UsageResponse(k.Usage)}
+	}
+	for i, e := range equipment {
+		res.Equipment[i] = EquipmentUsageResponse{
+			ID:            e.ID,
+			Name:          e.Name,
+			Kind:          e.Kind,
+			Retired:       e.RetiredAt != nil,
+			UsageResponse: UsageResponse(e.Usage),
+		}
+	}
+
+	return res, nil
+}
+
+// retiredAt keeps the retirement time of gear still retired, sets it when newly retired
+func retiredAt(retired bool, current *time.Time) *time.Time {
+	if !retired {
+		return nil
+	}
+	if current != nil {
+		return current
+	}
+
+	now := time.Now()
+	return &now
+}
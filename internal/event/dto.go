@@ -0,0 +1,101 @@
+package event
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+const (
+	maxProperties     = 30
+	maxPropertyLength = 256
+)
+
+var eventNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// serverEvents can only be emitted by the server, clients would skew the product metrics
+var serverEvents = []string{analytics.EventSignup, analytics.EventSessionFinished, analytics.EventTrainingViewed}
+
+type TrackEventsRequest struct {
+	Events []TrackEventRequest `json:"events" validate:"required,max=100"`
+}
+
+type TrackEventRequest struct {
+	Name        string         `json:"name" validate:"required,max=64" example:"screen_viewed"`
+	AnonymousID string         `json:"anonymousId" validate:"max=64" example:"6f1c2b9e-device"`
+	OccurredAt  *time.Time     `json:"occurredAt" example:"2025-09-21T07:30:00Z"`
+	Properties  map[string]any `json:"properties" swaggertype:"object"`
+}
+
+func (r *TrackEventsRequest) Validate() error {
+	err := validator.Struct(r)
+	if err == nil {
+		err = &validator.ValidationError{Errors: make(map[string]string)}
+	}
+
+	// Tags can't express the name format or the property values, they are checked here
+	for i, e := range r.Events {
+		field := fmt.Sprintf("events[%d]", i)
+
+		if _, ok := err.Errors[field+".name"]; !ok && e.Name != "" {
+			switch {
+			case !eventNamePattern.MatchString(e.Name):
+				err.Errors[field+".name"] = "Event name must contain lowercase letters, digits and underscores only"
+			case slices.Contains(serverEvents, e.Name):
+				err.Errors[field+".name"] = "Event name is reserved for server events"
+			}
+		}
+
+		if e.OccurredAt != nil && e.OccurredAt.After(time.Now().Add(time.Minute)) {
+			err.Errors[field+".occurredAt"] = "Occurred at must not be in the future"
+		}
+
+		if msg := validateProperties(e.Properties); msg != "" {
+			err.Errors[field+".properties"] = msg
+		}
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
+	return nil
+}
+
+// validateProperties allows a small flat object of short scalar values
+func validateProperties(props map[string]any) string {
+	if len(props) > maxProperties {
+		return fmt.Sprintf("Properties must not exceed %d items", maxProperties)
+	}
+
+	for _, value := range props {
+		switch v := value.(type) {
+		case nil, bool, float64:
+		case string:
+			if utf8.RuneCountInString(v) > maxPropertyLength {
+				return fmt.Sprintf("Property values must not exceed %d characters", maxPropertyLength)
+			}
+		default:
+			return "Property values must be strings, numbers or booleans"
+		}
+	}
+	return ""
+}
+
+// newEvent maps a client event, its identity is filled by the tracker from the token
+func newEvent(req TrackEventRequest) analytics.Event {
+	e := analytics.Event{
+		Name:        req.Name,
+		Source:      analytics.SourceClient,
+		AnonymousID: req.AnonymousID,
+		Properties:  req.Properties,
+	}
+	if req.OccurredAt != nil {
+		e.OccurredAt = req.OccurredAt.UTC()
+	}
+	return e
+}
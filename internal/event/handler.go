@@ -0,0 +1,50 @@
+package event
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type EventHandler struct {
+	tracker analytics.Tracker
+}
+
+func NewEventHandler(tracker analytics.Tracker) *EventHandler {
+	return &EventHandler{tracker}
+}
+
+// Track handles product analytics events sent by the apps
+// @Summary Track analytics events
+// @Description Queue up to 100 product events of the app. Events are attributed to the token, sampled, stripped of personal data and delivered in the background, an accepted event may still be dropped.
+// @Tags Event
+// @Accept json
+// @Produce json
+// @Param request body TrackEventsRequest true "Analytics events"
+// @Success 202 {object} response.Success{data=response.Message} "Events accepted"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /events [post]
+func (h *EventHandler) Track(w http.ResponseWriter, r *http.Request) {
+	var req TrackEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	ctx := r.Context()
+	for _, e := range req.Events {
+		h.tracker.Track(ctx, newEvent(e))
+	}
+
+	response.OK(w, http.StatusAccepted, response.Message{Message: "Events accepted"})
+}
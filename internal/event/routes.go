@@ -0,0 +1,12 @@
+package event
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the analytics ingestion endpoint, guests send events with their guest token
+func (h *EventHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/events", mw.Protected(http.HandlerFunc(h.Track)))
+}
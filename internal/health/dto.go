@@ -0,0 +1,19 @@
+package health
+
+import "time"
+
+// HealthResponse represents the body of the liveness and readiness endpoints
+type HealthResponse struct {
+	Status       string                        `json:"status" example:"healthy"`
+	Timestamp    time.Time                     `json:"timestamp" example:"2025-09-21T14:36:31Z"`
+	Service      string                        `json:"service" example:"swimo-api"`
+	Uptime       string                        `json:"uptime,omitempty" example:"3h12m5s"`
+	Dependencies map[string]DependencyResponse `json:"dependencies,omitempty"`
+}
+
+// DependencyResponse represents the state of a single dependency check
+type DependencyResponse struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latencyMs" example:"1.25"`
+	Error     string  `json:"error,omitempty" example:"context deadline exceeded"`
+}
@@ -1,48 +1,158 @@
 package health
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
+	"sync"
 	"time"
 
-	"github.com/rizkyharahap/swimo/database"
 	"github.com/rizkyharahap/swimo/pkg/logger"
 	"github.com/rizkyharahap/swimo/pkg/response"
 )
 
+const serviceName = "swimo-api"
+
+// Checker reports whether a dependency required to serve traffic is available
+type Checker func(ctx context.Context) error
+
+// ErrDegraded is wrapped by checkers of a dependency still starting up, the service reports
+// degraded rather than unhealthy while no other dependency is down
+var ErrDegraded = errors.New("degraded")
+
+type namedChecker struct {
+	name  string
+	check Checker
+}
+
 type HealthHandler struct {
-	log *logger.Logger
-	db  *database.Database
+	log       *logger.Logger
+	timeout   time.Duration
+	verbose   bool // allow ?verbose=true to expose error details
+	startedAt time.Time
+	mu        sync.RWMutex
+	checkers  []namedChecker
 }
 
-func NewHealthHandler(log *logger.Logger, db *database.Database) *HealthHandler {
-	return &HealthHandler{log, db}
+func NewHealthHandler(log *logger.Logger, timeout time.Duration, verbose bool) *HealthHandler {
+	return &HealthHandler{log: log, timeout: timeout, verbose: verbose, startedAt: time.Now()}
 }
 
-func (h *HealthHandler) Check(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// Register adds a dependency checker evaluated by the readiness endpoint
+func (h *HealthHandler) Register(name string, check Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
-	if h.db == nil {
-		resp := fmt.Sprintf(`{"status":"unhealthy","timestamp":"%s","service":"swimo-api","database":"unconnected"}`,
-			time.Now().UTC().Format(time.RFC3339))
-		h.log.Error("Health check failed: database unconnected", "response", resp)
+	h.checkers = append(h.checkers, namedChecker{name: name, check: check})
+}
 
-		response.JSON(w, http.StatusServiceUnavailable, response.Message{Message: "Database unconnected"})
-		return
+// Live handles the liveness probe, it only reports that the process is up and serving
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	resp := HealthResponse{
+		Status:    "alive",
+		Timestamp: time.Now().UTC(),
+		Service:   serviceName,
+	}
+
+	if h.isVerbose(r) {
+		resp.Uptime = time.Since(h.startedAt).Round(time.Second).String()
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// Ready handles the readiness probe, it fails when any registered dependency is unavailable
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	verbose := h.isVerbose(r)
+	dependencies, healthy, degraded := h.runChecks(r.Context(), verbose)
+
+	resp := HealthResponse{
+		Status:       "healthy",
+		Timestamp:    time.Now().UTC(),
+		Service:      serviceName,
+		Dependencies: dependencies,
+	}
+
+	if verbose {
+		resp.Uptime = time.Since(h.startedAt).Round(time.Second).String()
 	}
 
-	if err := h.db.Pool.Ping(ctx); err != nil {
-		resp := fmt.Sprintf(`{"status":"unhealthy ping","timestamp":"%s","service":"swimo-api","database":"disconnected"}`,
-			time.Now().UTC().Format(time.RFC3339))
-		h.log.Error("Health check failed: ping error", "response", resp)
+	if !healthy {
+		resp.Status = "unhealthy"
+		h.log.Error("Readiness check failed", "dependencies", dependencies)
 
-		response.JSON(w, http.StatusServiceUnavailable, response.Message{Message: "Database ping failed"})
+		response.JSON(w, http.StatusServiceUnavailable, resp)
 		return
 	}
 
-	resp := fmt.Sprintf(`{"status":"healthy","timestamp":"%s","service":"swimo-api","database":"connected"}`,
-		time.Now().UTC().Format(time.RFC3339))
-	h.log.Info("Health check OK", "response", resp)
+	// Still not ready for traffic, though nothing needs a look
+	if degraded {
+		resp.Status = "degraded"
+		response.JSON(w, http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// isVerbose reports whether the caller asked for, and is allowed, detailed output
+func (h *HealthHandler) isVerbose(r *http.Request) bool {
+	return h.verbose && r.URL.Query().Get("verbose") == "true"
+}
+
+// runChecks runs every checker concurrently, measuring the latency of each
+func (h *HealthHandler) runChecks(ctx context.Context, verbose bool) (map[string]DependencyResponse, bool, bool) {
+	h.mu.RLock()
+	checkers := h.checkers
+	h.mu.RUnlock()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		healthy  = true
+		degraded bool
+		results  = make(map[string]DependencyResponse, len(checkers))
+	)
+
+	for _, c := range checkers {
+		wg.Add(1)
+		go func(c namedChecker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.check(checkCtx)
+
+			result := DependencyResponse{
+				Status:    "up",
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+
+			isDegraded := errors.Is(err, ErrDegraded)
+			switch {
+			case isDegraded:
+				result.Status = "degraded"
+			case err != nil:
+				h.log.Warn("Dependency check failed", "dependency", c.name, "error", err)
+				result.Status = "down"
+			}
+			if err != nil && verbose {
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[c.name] = result
+			if isDegraded {
+				degraded = true
+			} else if err != nil {
+				healthy = false
+			}
+			mu.Unlock()
+		}(c)
+	}
 
-	w.WriteHeader(http.StatusOK)
+	wg.Wait()
+	return results, healthy, degraded
 }
@@ -0,0 +1,19 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Paths of the probes, polled by load balancers and orchestrators
+const (
+	LivePath  = "/api/v1/healthz"
+	ReadyPath = "/api/v1/readyz"
+)
+
+// Routes registers the liveness (process up) and readiness (dependencies available) probes
+func (h *HealthHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.HandleFunc("GET "+LivePath, h.Live)
+	mux.HandleFunc("GET "+ReadyPath, h.Ready)
+}
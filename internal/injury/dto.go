@@ -0,0 +1,89 @@
+package injury
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Injury severities
+const (
+	SeverityMild     = "mild"
+	SeverityModerate = "moderate"
+	SeveritySevere   = "severe"
+)
+
+type InjuryRequest struct {
+	Type     string  `json:"type" validate:"required,max=64" example:"Swimmer's shoulder"`
+	Severity string  `json:"severity" validate:"required,lower,oneof=mild moderate severe" example:"moderate"`
+	Notes    *string `json:"notes,omitempty" validate:"max=1000" example:"Pain on the recovery phase of freestyle"`
+	// InjuredOn and ResolvedOn are dates, ex: 2025-09-21. Without ResolvedOn the injury is ongoing.
+	InjuredOn  string  `json:"injuredOn" validate:"required,date" example:"2025-09-01"`
+	ResolvedOn *string `json:"resolvedOn,omitempty" validate:"date" example:"2025-09-21"`
+}
+
+type InjuryResponse struct {
+	ID         string    `json:"id" example:"0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e"`
+	Type       string    `json:"type" example:"Swimmer's shoulder"`
+	Severity   string    `json:"severity" example:"moderate" enums:"mild,moderate,severe"`
+	Notes      *string   `json:"notes,omitempty" example:"Pain on the recovery phase of freestyle"`
+	InjuredOn  string    `json:"injuredOn" example:"2025-09-01"`
+	ResolvedOn *string   `json:"resolvedOn,omitempty" example:"2025-09-21"`
+	Ongoing    bool      `json:"ongoing" example:"false"`
+	CreatedAt  time.Time `json:"createdAt" example:"2025-09-01T07:30:00Z"`
+	UpdatedAt  time.Time `json:"updatedAt" example:"2025-09-21T07:30:00Z"`
+}
+
+func (r *InjuryRequest) Validate() error {
+	err := validator.Struct(r)
+	if err != nil {
+		return err
+	}
+
+	if r.ResolvedOn != nil && *r.ResolvedOn < r.InjuredOn {
+		return &validator.ValidationError{Errors: map[string]string{"resolvedOn": "Resolved on must not be before injured on"}}
+	}
+	return nil
+}
+
+// newInjury maps a validated request, dates are in UTC
+func newInjury(userID string, req *InjuryRequest) *Injury {
+	injury := &Injury{
+		UserID:   userID,
+		Type:     req.Type,
+		Severity: req.Severity,
+		Notes:    req.Notes,
+	}
+	injury.InjuredOn, _ = time.Parse(time.DateOnly, req.InjuredOn)
+	if req.ResolvedOn != nil {
+		resolved, _ := time.Parse(time.DateOnly, *req.ResolvedOn)
+		injury.ResolvedOn = &resolved
+	}
+	return injury
+}
+
+func newInjuryResponse(i *Injury) InjuryResponse {
+	res := InjuryResponse{
+		ID:        i.ID,
+		Type:      i.Type,
+		Severity:  i.Severity,
+		Notes:     i.Notes,
+		InjuredOn: i.InjuredOn.Format(time.DateOnly),
+		Ongoing:   i.ResolvedOn == nil,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+	if i.ResolvedOn != nil {
+		resolved := i.ResolvedOn.Format(time.DateOnly)
+		res.ResolvedOn = &resolved
+	}
+	return res
+}
+
+func newInjuryResponses(injuries []*Injury) []InjuryResponse {
+	res := make([]InjuryResponse, len(injuries))
+	for i, injury := range injuries {
+		res[i] = newInjuryResponse(injury)
+	}
+	return res
+}
@@ -0,0 +1,21 @@
+package injury
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInjuryNotFound = errors.New("injury not found")
+
+// Injury is an entry of the injury and recovery log of a user, ongoing until resolved
+type Injury struct {
+	ID         string
+	UserID     string
+	Type       string
+	Severity   string
+	Notes      *string
+	InjuredOn  time.Time
+	ResolvedOn *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,244 @@
+package injury
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type InjuryHandler struct {
+	injuryUsecase InjuryUsecase
+}
+
+func NewInjuryHandler(injuryUsecase InjuryUsecase) *InjuryHandler {
+	return &InjuryHandler{injuryUsecase}
+}
+
+// Create handles logging an injury
+// @Summary Log injury
+// @Description Add an injury to the injury and recovery log of the user, without a resolved date it is ongoing
+// @Tags Injury
+// @Accept json
+// @Produce json
+// @Param request body InjuryRequest true "Injury to log"
+// @Success 201 {object} response.Success{data=InjuryResponse} "Injury logged successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /injuries [post]
+func (h *InjuryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req InjuryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.injuryUsecase.Create(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// List handles the injury log of the signed in user
+// @Summary List injuries
+// @Description The injury and recovery log of the user, ongoing injuries first then latest first
+// @Tags Injury
+// @Produce json
+// @Success 200 {object} response.Success{data=[]InjuryResponse} "Injuries retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /injuries [get]
+func (h *InjuryHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	injuries, err := h.injuryUsecase.List(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, injuries)
+}
+
+// GetById handles the detail of an injury
+// @Summary Get injury
+// @Description Get an injury of the user
+// @Tags Injury
+// @Produce json
+// @Param id path string true "Injury ID" example("0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e")
+// @Success 200 {object} response.Success{data=InjuryResponse} "Injury retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Injury not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /injuries/{id} [get]
+func (h *InjuryHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.injuryUsecase.GetById(ctx, *claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Update handles editing an injury, ex: setting the date it resolved
+// @Summary Update injury
+// @Description Replace an injury of the user, send the resolved date once recovered
+// @Tags Injury
+// @Accept json
+// @Produce json
+// @Param id path string true "Injury ID" example("0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e")
+// @Param request body InjuryRequest true "Injury details"
+// @Success 200 {object} response.Success{data=InjuryResponse} "Injury updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Injury not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /injuries/{id} [put]
+func (h *InjuryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req InjuryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.injuryUsecase.Update(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Delete handles removing an injury from the log
+// @Summary Delete injury
+// @Description Delete an injury of the user
+// @Tags Injury
+// @Produce json
+// @Param id path string true "Injury ID" example("0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e")
+// @Success 200 {object} response.Success{data=response.Message} "Injury deleted"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Injury not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /injuries/{id} [delete]
+func (h *InjuryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.injuryUsecase.Delete(ctx, *claim.Uid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Injury deleted"})
+}
+
+// ListForCoach handles the injury log of an athlete seen by their coach
+// @Summary List athlete injuries
+// @Description The injury and recovery log of an athlete who granted the signed in coach access
+// @Tags Injury
+// @Produce json
+// @Param id path string true "User ID of the athlete" example("5b1f8a3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b")
+// @Success 200 {object} response.Success{data=[]InjuryResponse} "Injuries retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Athlete not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /athletes/{id}/injuries [get]
+func (h *InjuryHandler) ListForCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	injuries, err := h.injuryUsecase.ListForCoach(ctx, *claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, injuries)
+}
@@ -0,0 +1,148 @@
+package injury
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/crypto"
+)
+
+type InjuryRepository interface {
+	Create(ctx context.Context, injury *Injury) error
+	// ListByUser returns the injuries of the user, ongoing ones first then latest first
+	ListByUser(ctx context.Context, userID string) ([]*Injury, error)
+	GetById(ctx context.Context, userID, id string) (*Injury, error)
+	// Update replaces an injury of the user, ErrInjuryNotFound when none matches
+	Update(ctx context.Context, injury *Injury) error
+	Delete(ctx context.Context, userID, id string) error
+}
+
+// injuryRepository encrypts the notes, medical details, with cipher. They are bound to the
+// user so notes copied to the injury of someone else can't be read.
+type injuryRepository struct {
+	db     database.DBTX
+	cipher *crypto.Cipher
+}
+
+func NewInjuryRepositry(db database.DBTX, cipher *crypto.Cipher) InjuryRepository {
+	return &injuryRepository{db, cipher}
+}
+
+func (r *injuryRepository) Create(ctx context.Context, injury *Injury) error {
+	const q = `
+		INSERT INTO injuries (user_id, type, severity, notes, injured_on, resolved_on)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	notes, err := r.cipher.EncryptPtr(injury.Notes, injury.UserID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRow(ctx, q,
+		injury.UserID,
+		injury.Type,
+		injury.Severity,
+		notes,
+		injury.InjuredOn,
+		injury.ResolvedOn,
+	).Scan(&injury.ID, &injury.CreatedAt, &injury.UpdatedAt)
+}
+
+const injuryColumns = `id, user_id, type, severity, notes, injured_on, resolved_on, created_at, updated_at`
+
+func (r *injuryRepository) ListByUser(ctx context.Context, userID string) ([]*Injury, error) {
+	const q = `
+		SELECT ` + injuryColumns + `
+		FROM injuries
+		WHERE user_id = $1
+		ORDER BY resolved_on IS NOT NULL, injured_on DESC, created_at DESC`
+
+	injuries, err := database.Select[Injury](ctx, r.db, q, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, injury := range injuries {
+		if err := r.decryptNotes(injury); err != nil {
+			return nil, err
+		}
+	}
+
+	return injuries, nil
+}
+
+func (r *injuryRepository) GetById(ctx context.Context, userID, id string) (*Injury, error) {
+	const q = `
+		SELECT ` + injuryColumns + `
+		FROM injuries
+		WHERE id = $1 AND user_id = $2`
+
+	injury, err := database.Get[Injury](ctx, r.db, q, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInjuryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.decryptNotes(injury); err != nil {
+		return nil, err
+	}
+
+	return injury, nil
+}
+
+func (r *injuryRepository) Update(ctx context.Context, injury *Injury) error {
+	const q = `
+		UPDATE injuries
+		SET type = $3, severity = $4, notes = $5, injured_on = $6, resolved_on = $7, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING created_at, updated_at`
+
+	notes, err := r.cipher.EncryptPtr(injury.Notes, injury.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(ctx, q,
+		injury.ID,
+		injury.UserID,
+		injury.Type,
+		injury.Severity,
+		notes,
+		injury.InjuredOn,
+		injury.ResolvedOn,
+	).Scan(&injury.CreatedAt, &injury.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInjuryNotFound
+	}
+	return err
+}
+
+func (r *injuryRepository) Delete(ctx context.Context, userID, id string) error {
+	const q = `DELETE FROM injuries WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, q, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInjuryNotFound
+	}
+
+	return nil
+}
+
+// decryptNotes replaces the notes read from the database with their plain text
+func (r *injuryRepository) decryptNotes(injury *Injury) error {
+	notes, err := r.cipher.DecryptPtr(injury.Notes, injury.UserID)
+	if err != nil {
+		return err
+	}
+
+	injury.Notes = notes
+	return nil
+}
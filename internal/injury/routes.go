@@ -0,0 +1,17 @@
+package injury
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the injury log endpoints and its read only view for coaches
+func (h *InjuryHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/injuries", mw.Protected(http.HandlerFunc(h.Create)))
+	mux.Handle("GET /api/v1/injuries", mw.Protected(http.HandlerFunc(h.List)))
+	mux.Handle("GET /api/v1/injuries/{id}", mw.Protected(http.HandlerFunc(h.GetById)))
+	mux.Handle("PUT /api/v1/injuries/{id}", mw.Protected(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /api/v1/injuries/{id}", mw.Protected(http.HandlerFunc(h.Delete)))
+	mux.Handle("GET /api/v1/athletes/{id}/injuries", mw.Protected(http.HandlerFunc(h.ListForCoach)))
+}
@@ -0,0 +1,76 @@
+package injury
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/internal/coach"
+)
+
+type InjuryUsecase interface {
+	Create(ctx context.Context, userID string, req *InjuryRequest) (*InjuryResponse, error)
+	List(ctx context.Context, userID string) ([]InjuryResponse, error)
+	GetById(ctx context.Context, userID, id string) (*InjuryResponse, error)
+	Update(ctx context.Context, userID, id string, req *InjuryRequest) (*InjuryResponse, error)
+	Delete(ctx context.Context, userID, id string) error
+	// ListForCoach returns the injuries of an athlete who granted the coach access
+	ListForCoach(ctx context.Context, coachID, athleteID string) ([]InjuryResponse, error)
+}
+
+type injuryUsecase struct {
+	injuryRepo   InjuryRepository
+	coachUsecase coach.CoachUsecase
+}
+
+func NewInjuryUsecase(injuryRepo InjuryRepository, coachUsecase coach.CoachUsecase) InjuryUsecase {
+	return &injuryUsecase{injuryRepo, coachUsecase}
+}
+
+func (u *injuryUsecase) Create(ctx context.Context, userID string, req *InjuryRequest) (*InjuryResponse, error) {
+	injury := newInjury(userID, req)
+	if err := u.injuryRepo.Create(ctx, injury); err != nil {
+		return nil, err
+	}
+
+	res := newInjuryResponse(injury)
+	return &res, nil
+}
+
+func (u *injuryUsecase) List(ctx context.Context, userID string) ([]InjuryResponse, error) {
+	injuries, err := u.injuryRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return newInjuryResponses(injuries), nil
+}
+
+func (u *injuryUsecase) GetById(ctx context.Context, userID, id string) (*InjuryResponse, error) {
+	injury, err := u.injuryRepo.GetById(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newInjuryResponse(injury)
+	return &res, nil
+}
+
+func (u *injuryUsecase) Update(ctx context.Context, userID, id string, req *InjuryRequest) (*InjuryResponse, error) {
+	injury := newInjury(userID, req)
+	injury.ID = id
+	if err := u.injuryRepo.Update(ctx, injury); err != nil {
+		return nil, err
+	}
+
+	res := newInjuryResponse(injury)
+	return &res, nil
+}
+
+func (u *injuryUsecase) Delete(ctx context.Context, userID, id string) error {
+	return u.injuryRepo.Delete(ctx, userID, id)
+}
+
+func (u *injuryUsecase) ListForCoach(ctx context.Context, coachID, athleteID string) ([]InjuryResponse, error) {
+	if err := u.coachUsecase.Authorize(ctx, coachID, athleteID); err != nil {
+		return nil, err
+	}
+	return u.List(ctx, athleteID)
+}
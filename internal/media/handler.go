@@ -0,0 +1,111 @@
+package media
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+)
+
+// publicPrefixes are the keys anyone can download, the rest (ex: exports) is only
+// reachable through the signed links handed to their owner
+var publicPrefixes = []string{"avatars/", "trainings/"}
+
+type MediaHandler struct {
+	storage storage.Storage
+	signTTL time.Duration
+}
+
+func NewMediaHandler(storage storage.Storage, signTTL time.Duration) *MediaHandler {
+	return &MediaHandler{storage, signTTL}
+}
+
+// Download handles media links
+// @Summary Download a stored file
+// @Description Public media (avatars, training thumbnails and videos) redirect to a short lived signed link of the storage. With the local storage driver, signed links are served here.
+// @Tags Media
+// @Param key path string true "Object key" example("trainings/8c4a2d27-56e2-4ef3-8a6e-43b812345abc/video-1a2b3c4d.mp4")
+// @Param expires query int false "Signed link expiry, unix seconds"
+// @Param signature query string false "Signed link signature"
+// @Success 200 {file} file "File content"
+// @Success 302 "Redirect to the signed link"
+// @Failure 403 {object} response.Error "Invalid or expired link"
+// @Failure 404 {object} response.Error "File not found"
+// @Router /media/{key} [get]
+func (h *MediaHandler) Download(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	key, err := storage.CleanKey(r.PathValue("key"))
+	if err != nil {
+		response.Fail(w, http.StatusNotFound, response.CodeNotFound, "File not found")
+		return
+	}
+
+	query := r.URL.Query()
+	if local, ok := h.storage.(*storage.Local); ok && query.Has("signature") {
+		if err := local.Verify(key, query.Get("expires"), query.Get("signature")); err != nil {
+			response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Invalid or expired link")
+			return
+		}
+
+		h.serve(w, r, key)
+		return
+	}
+
+	if !isPublic(key) {
+		response.Fail(w, http.StatusNotFound, response.CodeNotFound, "File not found")
+		return
+	}
+
+	link, err := h.storage.SignURL(ctx, key, h.signTTL)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to sign media link", "key", key, "error", err)
+		response.InternalError(w)
+		return
+	}
+
+	// Clients may reuse the redirect while the signed link is still valid
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(h.signTTL.Seconds()/2)))
+	http.Redirect(w, r, link, http.StatusFound)
+}
+
+// serve writes a local file, with range requests so videos can be streamed
+func (h *MediaHandler) serve(w http.ResponseWriter, r *http.Request, key string) {
+	body, object, err := h.storage.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			response.Fail(w, http.StatusNotFound, response.CodeNotFound, "File not found")
+			return
+		}
+		logger.FromContext(r.Context()).Error("Failed to open media", "key", key, "error", err)
+		response.InternalError(w)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", object.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(h.signTTL.Seconds())))
+
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", time.Time{}, seeker)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.FormatInt(object.Size, 10))
+	io.Copy(w, body)
+}
+
+func isPublic(key string) bool {
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
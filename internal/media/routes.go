@@ -0,0 +1,14 @@
+package media
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+)
+
+// Routes registers the media download. Links are embedded in images and players which
+// send no token, so the route is neither authenticated nor limited like the auth routes.
+func (h *MediaHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.HandleFunc("GET "+storage.MediaPath+"{key...}", h.Download)
+}
@@ -0,0 +1,201 @@
+package ops
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength matches the sign up validation
+const minPasswordLength = 8
+
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrAccountExists   = errors.New("account already exists")
+)
+
+// AccountOptions identifies the account of CreateAdmin and ResetPassword.
+// An empty Password is replaced by a generated one, returned to the caller.
+type AccountOptions struct {
+	Email    string
+	Name     string
+	Password string
+}
+
+// CreateAdmin creates an admin account with its profile, returning the password in use
+func CreateAdmin(ctx context.Context, cfg *config.Config, log *logger.Logger, opts AccountOptions) (string, error) {
+	email, password, err := checkAccount(opts)
+	if err != nil {
+		return "", err
+	}
+	if opts.Name == "" {
+		opts.Name = "Swimo Admin"
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	db, closeDB, err := connect(ctx, cfg, log)
+	if err != nil {
+		return "", err
+	}
+	defer closeDB()
+
+	err = database.WithTx(ctx, db.Pool, func(tx pgx.Tx) error {
+		var accountID string
+		err := tx.QueryRow(ctx, `
+			INSERT INTO accounts (email, password_hash, role)
+			VALUES ($1, $2, 'admin')
+			ON CONFLICT (email) DO NOTHING
+			RETURNING id`,
+			email, string(hash),
+		).Scan(&accountID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAccountExists
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO users (account_id, name, gender) VALUES ($1, $2, 0)`, accountID, opts.Name)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Info("Admin account created", "email", email)
+	return password, nil
+}
+
+// ResetPassword replaces the password of an account and revokes its sessions,
+// returning the password in use
+func ResetPassword(ctx context.Context, cfg *config.Config, log *logger.Logger, opts AccountOptions) (string, error) {
+	email, password, err := checkAccount(opts)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	db, closeDB, err := connect(ctx, cfg, log)
+	if err != nil {
+		return "", err
+	}
+	defer closeDB()
+
+	var revoked int64
+	err = database.WithTx(ctx, db.Pool, func(tx pgx.Tx) error {
+		var accountID string
+		err := tx.QueryRow(ctx, `
+			UPDATE accounts
+			SET password_hash = $2, updated_at = NOW()
+			WHERE email = $1
+			RETURNING id`,
+			email, string(hash),
+		).Scan(&accountID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		// Sessions signed in with the old password must not outlive it
+		revoked, err = revokeSessions(ctx, tx, accountID)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Info("Password reset", "email", email, "revoked_sessions", revoked)
+	return password, nil
+}
+
+// RevokeSessions revokes the active sessions of an account, or of every account when email is empty
+func RevokeSessions(ctx context.Context, cfg *config.Config, log *logger.Logger, email string) (int64, error) {
+	db, closeDB, err := connect(ctx, cfg, log)
+	if err != nil {
+		return 0, err
+	}
+	defer closeDB()
+
+	if email == "" {
+		tag, err := db.Pool.Exec(ctx, `UPDATE sessions SET revoked_at = NOW() WHERE revoked_at IS NULL`)
+		if err != nil {
+			return 0, err
+		}
+
+		log.Info("Sessions revoked", "scope", "all", "revoked", tag.RowsAffected())
+		return tag.RowsAffected(), nil
+	}
+
+	var accountID string
+	err = db.Pool.QueryRow(ctx, `SELECT id FROM accounts WHERE email = $1`, strings.ToLower(strings.TrimSpace(email))).Scan(&accountID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrAccountNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := revokeSessions(ctx, db.Pool, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info("Sessions revoked", "email", email, "revoked", revoked)
+	return revoked, nil
+}
+
+func revokeSessions(ctx context.Context, db database.DBTX, accountID string) (int64, error) {
+	tag, err := db.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE account_id = $1
+			AND revoked_at IS NULL`,
+		accountID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// checkAccount normalizes the email and fills a generated password when none is given
+func checkAccount(opts AccountOptions) (email, password string, err error) {
+	email = strings.ToLower(strings.TrimSpace(opts.Email))
+	if !validator.IsValidEmail(email) {
+		return "", "", fmt.Errorf("invalid email %q", opts.Email)
+	}
+
+	password = opts.Password
+	if password == "" {
+		b := make([]byte, 18)
+		if _, err := rand.Read(b); err != nil {
+			return "", "", err
+		}
+		password = base64.RawURLEncoding.EncodeToString(b)
+	}
+	if len(password) < minPasswordLength {
+		return "", "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	return email, password, nil
+}
@@ -0,0 +1,62 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database/seed"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+const fixturesUsage = "usage: fixtures [clean] [--users <n>] [--sessions <n>] [--days <n>] [--seed <n>] [--force]"
+
+// Fixtures handles `fixtures [--users n] [--sessions n] [--days n] [--seed n] [--force]`, replacing
+// the synthetic accounts of load tests with a new data set, and `fixtures clean [--force]` deleting
+// them. Like seed it runs in dev, staging needs --force and production is always refused.
+// The accounts sign in as user00001@loadtest.swimo.dev with FIXTURE_PASSWORD, swimo-load by default.
+func Fixtures(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	clean := len(args) > 0 && args[0] == "clean"
+	if clean {
+		args = args[1:]
+	}
+
+	opts := seed.DefaultFixtureOptions()
+	if password := os.Getenv("FIXTURE_PASSWORD"); password != "" {
+		opts.Password = password
+	}
+
+	var force bool
+	fs := flag.NewFlagSet("fixtures", flag.ContinueOnError)
+	fs.IntVar(&opts.Users, "users", opts.Users, "synthetic accounts to create")
+	fs.IntVar(&opts.Sessions, "sessions", opts.Sessions, "training sessions spread over the accounts")
+	fs.IntVar(&opts.Days, "days", opts.Days, "sessions happened within the last days")
+	fs.Uint64Var(&opts.Seed, "seed", opts.Seed, "random seed, the same seed generates the same data set")
+	fs.BoolVar(&force, "force", false, "allow a staging environment")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New(fixturesUsage)
+	}
+
+	if err := checkSeedEnv(cfg, force); err != nil {
+		return err
+	}
+
+	db, closeDB, err := connectMigrated(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if clean {
+		_, err = seed.CleanFixtures(ctx, db.Pool, log)
+		return err
+	}
+
+	_, err = seed.Fixtures(ctx, db.Pool, log, opts)
+	return err
+}
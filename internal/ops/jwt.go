@@ -0,0 +1,78 @@
+package ops
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+// jwtSecretBytes is the entropy of generated secrets, 64 characters once encoded
+const jwtSecretBytes = 48
+
+// JWTRotation is the JWT configuration after a key rotation
+type JWTRotation struct {
+	KeyID        string // JWT_KEY_ID
+	Secret       string // JWT_SECRET
+	PreviousKeys string // JWT_PREVIOUS_KEYS, the replaced key first
+}
+
+// RotateJWTSecret generates a new JWT key, named after the current time, and moves the current
+// key of auth to the previous keys so tokens it signed stay valid. Drop it from
+// JWT_PREVIOUS_KEYS once JWT_ACCESS_TTL_MIN passed to retire it. When path is set the secret is
+// written there, ex: the file of a file provider secret, so a running API picks it up on its
+// next secrets refresh.
+func RotateJWTSecret(auth *config.AuthConfig, path string) (*JWTRotation, error) {
+	b := make([]byte, jwtSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	rotation := &JWTRotation{
+		KeyID:        time.Now().UTC().Format("k20060102150405"),
+		Secret:       base64.RawURLEncoding.EncodeToString(b),
+		PreviousKeys: auth.JWTPreviousKeys,
+	}
+
+	// A key without kid is written alone, it verifies the tokens signed before key ids
+	if auth.JWTSecret != "" {
+		current := auth.JWTSecret
+		if auth.JWTKeyID != "" {
+			current = auth.JWTKeyID + "=" + current
+		}
+		rotation.PreviousKeys = strings.Trim(current+","+rotation.PreviousKeys, ",")
+	}
+
+	if path == "" {
+		return rotation, nil
+	}
+
+	// Write then rename so readers never see a partial secret
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".jwt-secret-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(rotation.Secret); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return rotation, nil
+}
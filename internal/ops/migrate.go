@@ -0,0 +1,82 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+const migrateUsage = "usage: migrate <up|down [n]|steps <n>|force <version>|status>"
+
+// Migrate handles the `migrate` command using the embedded migrations
+func Migrate(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, closeDB, err := connect(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	migrator, err := database.NewMigrator(db.Pool, log)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			if n, err = strconv.Atoi(args[1]); err != nil || n <= 0 {
+				return errors.New(migrateUsage)
+			}
+		}
+		err = migrator.Steps(-n)
+	case "steps":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		n, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return errors.New(migrateUsage)
+		}
+		err = migrator.Steps(n)
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return errors.New(migrateUsage)
+		}
+		err = migrator.Force(version)
+	case "status":
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	log.Info("Migration status",
+		"version", status.Version,
+		"latest", status.Latest,
+		"pending", status.Pending,
+		"dirty", status.Dirty,
+	)
+	return nil
+}
@@ -0,0 +1,26 @@
+// Package ops implements operator tasks shared by the swimo binary and swimoctl:
+// migrations, seeds, account maintenance and secret rotation. Tasks talk to the
+// database directly so they work without the API running.
+package ops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// connect opens the primary database, the returned function closes it
+func connect(ctx context.Context, cfg *config.Config, log *logger.Logger) (*database.Database, func(), error) {
+	dbManager := database.NewManager(log)
+
+	db, err := dbManager.Connect(ctx, "primary", &cfg.Database, &cfg.App)
+	if err != nil {
+		dbManager.CloseAll()
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return db, func() { dbManager.CloseAll() }, nil
+}
@@ -0,0 +1,77 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/database/seed"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// Seed handles `seed [--force]`, applying pending migrations then populating demo data.
+// Runs in dev, staging needs --force and production is always refused.
+func Seed(ctx context.Context, cfg *config.Config, log *logger.Logger, args []string) error {
+	force := len(args) > 0 && args[0] == "--force"
+	if err := checkSeedEnv(cfg, force); err != nil {
+		return err
+	}
+
+	db, closeDB, err := connectMigrated(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	opts := seed.DefaultOptions()
+	if password := os.Getenv("SEED_ADMIN_PASSWORD"); password != "" {
+		opts.AdminPassword = password
+	}
+	if password := os.Getenv("SEED_USER_PASSWORD"); password != "" {
+		opts.UserPassword = password
+	}
+
+	if err := seed.Run(ctx, db.Pool, log, opts); err != nil {
+		return err
+	}
+
+	log.Info("Seed completed", "admin", opts.AdminEmail)
+	return nil
+}
+
+// checkSeedEnv allows generated data in dev, in staging with force and never in prod
+func checkSeedEnv(cfg *config.Config, force bool) error {
+	switch {
+	case cfg.App.Env == "prod":
+		return errors.New("refusing to seed a prod environment")
+	case cfg.App.Env != "dev" && !force:
+		return fmt.Errorf("refusing to seed %s environment without --force", cfg.App.Env)
+	}
+	return nil
+}
+
+// connectMigrated opens the primary database and applies pending migrations, seed rows
+// depend on the latest schema (ex: accounts.role)
+func connectMigrated(ctx context.Context, cfg *config.Config, log *logger.Logger) (*database.Database, func(), error) {
+	db, closeDB, err := connect(ctx, cfg, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator, err := database.NewMigrator(db.Pool, log)
+	if err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil {
+		closeDB()
+		return nil, nil, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return db, closeDB, nil
+}
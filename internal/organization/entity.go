@@ -0,0 +1,11 @@
+package organization
+
+import "time"
+
+// Organization is a swim school hosted on the deployment, addressed by its subdomain
+type Organization struct {
+	ID        string
+	Slug      string
+	Name      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,39 @@
+package organization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+)
+
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+type OrganizationRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*Organization, error)
+}
+
+type organizationRepository struct{ db database.DBTX }
+
+func NewOrganizationRepositry(db database.DBTX) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*Organization, error) {
+	const q = `
+		SELECT id, slug, name, created_at
+		FROM organizations
+		WHERE slug = $1
+		LIMIT 1`
+
+	var org Organization
+	if err := r.db.QueryRow(ctx, q, slug).Scan(&org.ID, &org.Slug, &org.Name, &org.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return &org, nil
+}
@@ -0,0 +1,33 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/cache"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+const cacheKeySlug = "organization:slug:"
+
+// NewSlugResolver returns the organization id lookup used by the tenant middleware.
+// Slugs rarely change and are resolved on every request, so ids are cached for ttl.
+func NewSlugResolver(repo OrganizationRepository, c cache.Cache, ttl time.Duration) func(ctx context.Context, slug string) (string, error) {
+	return func(ctx context.Context, slug string) (string, error) {
+		var id string
+		if found, err := c.Get(ctx, cacheKeySlug+slug, &id); err == nil && found {
+			return id, nil
+		}
+
+		org, err := repo.GetBySlug(ctx, slug)
+		if err != nil {
+			return "", err
+		}
+
+		if err := c.Set(ctx, cacheKeySlug+slug, org.ID, ttl); err != nil {
+			logger.FromContext(ctx).Warn("organization cache set failed", "slug", slug, "error", err)
+		}
+
+		return org.ID, nil
+	}
+}
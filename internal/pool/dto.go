@@ -0,0 +1,223 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// PoolRequest creates or replaces a pool, admins only
+type PoolRequest struct {
+	Name         string  `json:"name" validate:"required,max=100" example:"Senayan Aquatic Center"`
+	Address      *string `json:"address,omitempty" validate:"max=300" example:"Jl. Pintu Satu Senayan, Jakarta"`
+	Latitude     float64 `json:"latitude" validate:"min=-90,max=90" example:"-6.2146"`
+	Longitude    float64 `json:"longitude" validate:"min=-180,max=180" example:"106.8019"`
+	RadiusMeters int     `json:"radiusMeters" validate:"required,min=20,max=2000" example:"150"` // geofence
+	LengthMeters *int    `json:"lengthMeters,omitempty" validate:"min=10,max=100" example:"50"`
+}
+
+// CheckInRequest checks in with the code of the QR posted at the pool, or else the location
+// of the swimmer within the geofence of a pool
+type CheckInRequest struct {
+	Code      *string  `json:"code,omitempty" validate:"max=64" example:"pool_Zk3q9vX2mB7wT1aR"`
+	Latitude  *float64 `json:"latitude,omitempty" validate:"min=-90,max=90" example:"-6.2149"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"min=-180,max=180" example:"106.8016"`
+}
+
+// LanesRequest publishes the lane board of a pool, replacing the previous one, staff only
+type LanesRequest struct {
+	Lanes []LaneRequest `json:"lanes" validate:"required,max=30"` // lanes[0] is lane 1
+}
+
+type LaneRequest struct {
+	Status   string  `json:"status" validate:"required,oneof=open closed reserved" example:"open" enums:"open,closed,reserved"`
+	Swimmers int     `json:"swimmers" validate:"min=0,max=100" example:"4"`
+	Capacity *int    `json:"capacity,omitempty" validate:"min=1,max=100" example:"6"` // swimmers at most
+	Label    *string `json:"label,omitempty" validate:"max=40" example:"Fast"`
+}
+
+// LaneUpdateRequest updates one lane as swimmers come and go, staff only
+type LaneUpdateRequest struct {
+	Status   string `json:"status" validate:"required,oneof=open closed reserved" example:"open" enums:"open,closed,reserved"`
+	Swimmers int    `json:"swimmers" validate:"min=0,max=100" example:"5"`
+}
+
+type PoolResponse struct {
+	ID           string    `json:"id" example:"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b"`
+	Name         string    `json:"name" example:"Senayan Aquatic Center"`
+	Address      *string   `json:"address,omitempty" example:"Jl. Pintu Satu Senayan, Jakarta"`
+	Latitude     float64   `json:"latitude" example:"-6.2146"`
+	Longitude    float64   `json:"longitude" example:"106.8019"`
+	RadiusMeters int       `json:"radiusMeters" example:"150"`
+	LengthMeters *int      `json:"lengthMeters,omitempty" example:"50"`
+	CheckInCode  string    `json:"checkInCode" example:"pool_Zk3q9vX2mB7wT1aR"` // to encode in the QR posted at the pool
+	CreatedAt    time.Time `json:"createdAt" example:"2025-09-01T07:30:00Z"`
+	UpdatedAt    time.Time `json:"updatedAt" example:"2025-09-01T07:30:00Z"`
+}
+
+type CheckInResponse struct {
+	ID          string              `json:"id" example:"1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"`
+	Pool        PoolSummaryResponse `json:"pool"`
+	Method      string              `json:"method" example:"qr" enums:"qr,geofence"`
+	CheckedInAt time.Time           `json:"checkedInAt" example:"2025-09-21T06:00:00Z"`
+	ExpiresAt   time.Time           `json:"expiresAt" example:"2025-09-21T10:00:00Z"` // sessions finished until then are swum at the pool
+}
+
+type PoolSummaryResponse struct {
+	ID           string `json:"id" example:"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b"`
+	Name         string `json:"name" example:"Senayan Aquatic Center"`
+	LengthMeters *int   `json:"lengthMeters,omitempty" example:"50"`
+}
+
+// MyPoolResponse is a pool the swimmer swam at with the sum of their sessions there
+type MyPoolResponse struct {
+	Pool            PoolSummaryResponse `json:"pool"`
+	Address         *string             `json:"address,omitempty" example:"Jl. Pintu Satu Senayan, Jakarta"`
+	Sessions        int                 `json:"sessions" example:"24"`
+	DistanceMeters  int                 `json:"distanceMeters" example:"48000"`
+	DurationSeconds int                 `json:"durationSeconds" example:"64800"`
+	LastSwumAt      time.Time           `json:"lastSwumAt" example:"2025-09-21T07:30:00Z"`
+}
+
+// OccupancyResponse is the lane board of a pool, also sent by the occupancy event stream
+type OccupancyResponse struct {
+	Pool           PoolSummaryResponse `json:"pool"`
+	Lanes          []LaneResponse      `json:"lanes"`
+	AvailableLanes int                 `json:"availableLanes" example:"3"`
+	Swimmers       int                 `json:"swimmers" example:"17"`
+	UpdatedAt      time.Time           `json:"updatedAt" example:"2025-09-21T06:45:00Z"` // last lane update
+}
+
+type LaneResponse struct {
+	Number    int       `json:"number" example:"1"`
+	Status    string    `json:"status" example:"open" enums:"open,closed,reserved"`
+	Swimmers  int       `json:"swimmers" example:"4"`
+	Capacity  *int      `json:"capacity,omitempty" example:"6"`
+	Label     *string   `json:"label,omitempty" example:"Fast"`
+	Available bool      `json:"available" example:"true"` // open and under capacity
+	UpdatedAt time.Time `json:"updatedAt" example:"2025-09-21T06:45:00Z"`
+}
+
+func (r *PoolRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *CheckInRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	if r.Code == nil && (r.Latitude == nil || r.Longitude == nil) {
+		return &validator.ValidationError{Errors: map[string]string{"code": "Code or latitude and longitude are required"}}
+	}
+	return nil
+}
+
+func (r *LanesRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *LaneUpdateRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newPool(req *PoolRequest) *Pool {
+	return &Pool{
+		Name:         req.Name,
+		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		RadiusMeters: req.RadiusMeters,
+		LengthMeters: req.LengthMeters,
+	}
+}
+
+func newPoolResponse(p *Pool) PoolResponse {
+	return PoolResponse{
+		ID:           p.ID,
+		Name:         p.Name,
+		Address:      p.Address,
+		Latitude:     p.Latitude,
+		Longitude:    p.Longitude,
+		RadiusMeters: p.RadiusMeters,
+		LengthMeters: p.LengthMeters,
+		CheckInCode:  p.CheckInCode,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+func newCheckInResponse(c *CheckIn) CheckInResponse {
+	return CheckInResponse{
+		ID:          c.ID,
+		Pool:        PoolSummaryResponse{ID: c.PoolID, Name: c.PoolName, LengthMeters: c.PoolLengthMeters},
+		Method:      c.Method,
+		CheckedInAt: c.CheckedInAt,
+		ExpiresAt:   c.ExpiresAt,
+	}
+}
+
+func newMyPoolResponses(stats []*PoolStats) []MyPoolResponse {
+	res := make([]MyPoolResponse, len(stats))
+	for i, s := range stats {
+		res[i] = MyPoolResponse{
+			Pool:            PoolSummaryResponse{ID: s.ID, Name: s.Name, LengthMeters: s.LengthMeters},
+			Address:         s.Address,
+			Sessions:        s.Sessions,
+			DistanceMeters:  s.DistanceMeters,
+			DurationSeconds: s.DurationSeconds,
+			LastSwumAt:      s.LastSwumAt,
+		}
+	}
+	return res
+}
+
+func newLanes(req *LanesRequest) []*Lane {
+	lanes := make([]*Lane, len(req.Lanes))
+	for i, l := range req.Lanes {
+		lanes[i] = &Lane{
+			Number:   i + 1,
+			Status:   l.Status,
+			Swimmers: l.Swimmers,
+			Capacity: l.Capacity,
+			Label:    l.Label,
+		}
+	}
+	return lanes
+}
+
+func newOccupancyResponse(p *Pool, lanes []*Lane) OccupancyResponse {
+	res := OccupancyResponse{
+		Pool:  PoolSummaryResponse{ID: p.ID, Name: p.Name, LengthMeters: p.LengthMeters},
+		Lanes: make([]LaneResponse, len(lanes)),
+	}
+
+	for i, l := range lanes {
+		res.Lanes[i] = LaneResponse{
+			Number:    l.Number,
+			Status:    l.Status,
+			Swimmers:  l.Swimmers,
+			Capacity:  l.Capacity,
+			Label:     l.Label,
+			Available: l.Available(),
+			UpdatedAt: l.UpdatedAt,
+		}
+		if l.Available() {
+			res.AvailableLanes++
+		}
+		res.Swimmers += l.Swimmers
+		if l.UpdatedAt.After(res.UpdatedAt) {
+			res.UpdatedAt = l.UpdatedAt
+		}
+	}
+	return res
+}
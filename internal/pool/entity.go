@@ -0,0 +1,81 @@
+package pool
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPoolNotFound = errors.New("pool not found")
+	ErrNotAtPool    = errors.New("not within a pool geofence")
+	ErrNotCheckedIn = errors.New("not checked in")
+	ErrLaneNotFound = errors.New("lane not found")
+	// ErrBoardNotPublished is returned for the occupancy of a pool whose staff didn't publish lanes
+	ErrBoardNotPublished = errors.New("lane board not published")
+)
+
+// Check in methods
+const (
+	MethodQR       = "qr"
+	MethodGeofence = "geofence"
+)
+
+// Lane statuses
+const (
+	LaneOpen     = "open"
+	LaneClosed   = "closed"
+	LaneReserved = "reserved"
+)
+
+// Pool is a pool swimmers check in at with the code of its QR or within RadiusMeters of its
+// location
+type Pool struct {
+	ID           string
+	Name         string
+	Address      *string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters int
+	LengthMeters *int
+	CheckInCode  string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CheckIn is the presence of a swimmer at a pool, active until checked out or expired
+type CheckIn struct {
+	ID       string
+	UserID   string
+	PoolID   string
+	PoolName string
+	// PoolLengthMeters is the length of the pool, nil when unknown
+	PoolLengthMeters *int
+	Method           string
+	CheckedInAt      time.Time
+	ExpiresAt        time.Time
+	CheckedOutAt     *time.Time
+}
+
+// PoolStats sums the sessions of a swimmer at a pool
+type PoolStats struct {
+	Pool
+	Sessions        int
+	DistanceMeters  int
+	DurationSeconds int
+	LastSwumAt      time.Time
+}
+
+// Lane is a lane of the board of a managed pool, as published by its staff
+type Lane struct {
+	Number    int
+	Status    string
+	Swimmers  int
+	Capacity  *int // swimmers at most, nil when not set
+	Label     *string
+	UpdatedAt time.Time
+}
+
+// Available reports whether a swimmer can join the lane
+func (l *Lane) Available() bool {
+	return l.Status == LaneOpen && (l.Capacity == nil || l.Swimmers < *l.Capacity)
+}
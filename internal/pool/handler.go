@@ -0,0 +1,424 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+const (
+	// occupancyStreamTTL ends occupancy streams so lobby boards reconnect, to another instance
+	// after a deploy, and shutdown doesn't wait on them longer
+	occupancyStreamTTL = 15 * time.Minute
+	// occupancyPingEvery keeps the stream of a quiet board from being closed by proxies
+	occupancyPingEvery = 25 * time.Second
+	// occupancyRetry is how long EventSource waits before reconnecting
+	occupancyRetry = 3 * time.Second
+)
+
+type PoolHandler struct {
+	poolUsecase PoolUsecase
+}
+
+func NewPoolHandler(poolUsecase PoolUsecase) *PoolHandler {
+	return &PoolHandler{poolUsecase}
+}
+
+// CheckIn handles checking in at a pool
+// @Summary Check in at a pool
+// @Description Check the signed in swimmer in at the pool of the code read from its QR, or else at the nearest pool whose geofence holds the location. Sessions finished in the next 4 hours, until check out or another check in, are swum at the pool.
+// @Tags Pool
+// @Accept json
+// @Produce json
+// @Param request body CheckInRequest true "Code of the QR or location"
+// @Success 201 {object} response.Success{data=CheckInResponse} "Checked in successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Pool not found or not at a pool"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /pools/check-in [post]
+func (h *PoolHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.poolUsecase.CheckIn(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// GetCheckIn handles the active check in of the signed in swimmer
+// @Summary Get the active check in
+// @Description The pool the signed in swimmer is checked in at, until check out or expiry
+// @Tags Pool
+// @Produce json
+// @Success 200 {object} response.Success{data=CheckInResponse} "Check in retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Not checked in"
+// @Security ApiKeyAuth
+// @Router /pools/check-in [get]
+func (h *PoolHandler) GetCheckIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	res, err := h.poolUsecase.GetCheckIn(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// CheckOut handles checking out of the pool
+// @Summary Check out
+// @Description End the active check in of the signed in swimmer, sessions finished later have no pool
+// @Tags Pool
+// @Produce json
+// @Success 200 {object} response.Success{data=response.Message} "Checked out"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Not checked in"
+// @Security ApiKeyAuth
+// @Router /pools/check-in [delete]
+func (h *PoolHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	if err := h.poolUsecase.CheckOut(ctx, *claim.Uid); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Checked out"})
+}
+
+// ListMine handles the pools the signed in swimmer swims at
+// @Summary List my pools
+// @Description The pools the signed in swimmer swam at, last swum first, with the sessions, distance and duration swum at each
+// @Tags Pool
+// @Produce json
+// @Success 200 {object} response.Success{data=[]MyPoolResponse} "Pools retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /pools/mine [get]
+func (h *PoolHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	pools, err := h.poolUsecase.ListMine(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, pools)
+}
+
+// List handles listing the pools for admins
+// @Summary List pools
+// @Description The pools of the organization and the shared ones by name, with the check in code of their QR. Admin only.
+// @Tags Pool
+// @Produce json
+// @Success 200 {object} response.Success{data=[]PoolResponse} "Pools retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Security ApiKeyAuth
+// @Router /admin/pools [get]
+func (h *PoolHandler) List(w http.ResponseWriter, r *http.Request) {
+	pools, err := h.poolUsecase.List(r.Context())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, pools)
+}
+
+// Create handles creating a pool
+// @Summary Create a pool
+// @Description Create a pool of the organization with a new check in code to post as a QR at the pool. Swimmers within radiusMeters of the location can check in without it. Admin only.
+// @Tags Pool
+// @Accept json
+// @Produce json
+// @Param request body PoolRequest true "Pool to create"
+// @Success 201 {object} response.Success{data=PoolResponse} "Pool created successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/pools [post]
+func (h *PoolHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req PoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.poolUsecase.Create(r.Context(), &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// Update handles editing a pool
+// @Summary Update a pool
+// @Description Replace the details of a pool of the organization, its check in code is kept. Admin only.
+// @Tags Pool
+// @Accept json
+// @Produce json
+// @Param id path string true "Pool ID" example("6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b")
+// @Param request body PoolRequest true "Pool details"
+// @Success 200 {object} response.Success{data=PoolResponse} "Pool updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Pool not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/pools/{id} [put]
+func (h *PoolHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req PoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.poolUsecase.Update(r.Context(), id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// GetOccupancy handles the lane board of a pool
+// @Summary Get lane occupancy
+// @Description The lanes of a managed pool as last published by its staff, with the swimmers in each and whether one can join. No token is needed so lobby screens can show it.
+// @Tags Pool
+// @Produce json
+// @Param id path string true "Pool ID" example("6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b")
+// @Success 200 {object} response.Success{data=OccupancyResponse} "Occupancy retrieved successfully"
+// @Failure 404 {object} response.Error "Pool not found or lanes not published"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Router /pools/{id}/occupancy [get]
+func (h *PoolHandler) GetOccupancy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.poolUsecase.GetOccupancy(r.Context(), id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// WatchOccupancy handles the live lane board of a pool
+// @Summary Watch lane occupancy
+// @Description Server-sent events of the lane board of a pool for live boards: an occupancy event with the board on connect, once published, then on every update. A comment is sent every 25 seconds while quiet. The stream ends after 15 minutes, EventSource reconnects on its own. No token is needed so lobby screens can show it.
+// @Tags Pool
+// @Produce text/event-stream
+// @Param id path string true "Pool ID" example("6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b")
+// @Success 200 {object} OccupancyResponse "occupancy events"
+// @Failure 404 {object} response.Error "Pool not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Router /pools/{id}/occupancy/events [get]
+func (h *PoolHandler) WatchOccupancy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), occupancyStreamTTL)
+	defer cancel()
+
+	board, updates, err := h.poolUsecase.WatchOccupancy(ctx, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	// Failures past this point end the stream, the client reconnects
+	stream, err := response.NewEventStream(w, occupancyRetry, occupancyPingEvery)
+	if err != nil {
+		return
+	}
+	if board != nil {
+		if err := stream.Send("occupancy", board); err != nil {
+			return
+		}
+	}
+
+	ping := time.NewTicker(occupancyPingEvery)
+	defer ping.Stop()
+
+	for {
+		select {
+		case payload, ok := <-updates:
+			if !ok {
+				return
+			}
+			err = stream.SendRaw("occupancy", payload)
+		case <-ping.C:
+			err = stream.Ping()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// PublishLanes handles publishing the lane board of a pool
+// @Summary Publish lanes
+// @Description Replace the lane board of a pool of the organization, lanes[0] being lane 1, and push it to the live boards. Admin only.
+// @Tags Pool
+// @Accept json
+// @Produce json
+// @Param id path string true "Pool ID" example("6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b")
+// @Param request body LanesRequest true "Lanes of the pool"
+// @Success 200 {object} response.Success{data=OccupancyResponse} "Lanes published successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Pool not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/pools/{id}/lanes [put]
+func (h *PoolHandler) PublishLanes(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req LanesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.poolUsecase.PublishLanes(r.Context(), id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// UpdateLane handles updating a lane of the board of a pool
+// @Summary Update a lane
+// @Description Set the status and swimmers of a published lane of a pool of the organization as swimmers come and go, and push the board to the live boards. Admin only.
+// @Tags Pool
+// @Accept json
+// @Produce json
+// @Param id path string true "Pool ID" example("6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b")
+// @Param number path integer true "Lane number, from 1" example(3)
+// @Param request body LaneUpdateRequest true "Lane status and swimmers"
+// @Success 200 {object} response.Success{data=OccupancyResponse} "Lane updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Pool or lane not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/pools/{id}/lanes/{number} [put]
+func (h *PoolHandler) UpdateLane(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 {
+		response.ValidationError(w, map[string]string{"number": "Number must be a lane number"})
+		return
+	}
+
+	var req LaneUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.poolUsecase.UpdateLane(r.Context(), id, number, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
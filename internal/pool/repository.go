@@ -0,0 +1,288 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+type PoolRepository interface {
+	// Create stores a pool of the tenant
+	Create(ctx context.Context, pool *Pool) error
+	// Update replaces the details of a pool of the tenant, its code is kept. ErrPoolNotFound when none matches.
+	Update(ctx context.Context, pool *Pool) error
+	// List returns the pools of the tenant and the shared ones, by name
+	List(ctx context.Context) ([]*Pool, error)
+	// GetByCode returns the pool of a check in code, ErrPoolNotFound when none matches
+	GetByCode(ctx context.Context, code string) (*Pool, error)
+	// GetNearest returns the nearest pool whose geofence holds the location, ErrNotAtPool when none
+	GetNearest(ctx context.Context, latitude, longitude float64) (*Pool, error)
+	// CheckIn closes the active check in of the user and opens checkIn, filling its id and times
+	CheckIn(ctx context.Context, checkIn *CheckIn, ttl time.Duration) error
+	// GetActiveCheckIn returns the check in of the user not checked out nor expired, ErrNotCheckedIn when none
+	GetActiveCheckIn(ctx context.Context, userID string) (*CheckIn, error)
+	// CheckOut closes the active check in of the user, ErrNotCheckedIn when none
+	CheckOut(ctx context.Context, userID string) error
+	// ListStats returns the pools the user swam at with the sum of their sessions, last swum first
+	ListStats(ctx context.Context, userID string) ([]*PoolStats, error)
+	// Get returns a pool of the tenant or a shared one, ErrPoolNotFound when none matches
+	Get(ctx context.Context, id string) (*Pool, error)
+	// ReplaceLanes replaces the lane board of a pool of the tenant, lanes[i] being lane i+1.
+	// ErrPoolNotFound when none matches.
+	ReplaceLanes(ctx context.Context, poolID string, lanes []*Lane) error
+	// UpdateLane sets the status and swimmers of a lane of a pool of the tenant, filling the
+	// rest of lane. ErrLaneNotFound when the pool or the lane doesn't exist.
+	UpdateLane(ctx context.Context, poolID string, lane *Lane) error
+	// ListLanes returns the lane board of a pool by number, nil when not published
+	ListLanes(ctx context.Context, poolID string) ([]*Lane, error)
+}
+
+type poolRepository struct {
+	db database.DBTX
+}
+
+func NewPoolRepositry(db database.DBTX) PoolRepository {
+	return &poolRepository{db}
+}
+
+const poolColumns = `
+	p.id, p.name, p.address, p.latitude, p.longitude, p.radius_meters, p.length_meters,
+	p.check_in_code, p.created_at, p.updated_at`
+
+func (r *poolRepository) Create(ctx context.Context, pool *Pool) error {
+	const q = `
+		INSERT INTO pools (organization_id, name, address, latitude, longitude, radius_meters, length_meters, check_in_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, q,
+		tenant.ID(ctx),
+		pool.Name,
+		pool.Address,
+		pool.Latitude,
+		pool.Longitude,
+		pool.RadiusMeters,
+		pool.LengthMeters,
+		pool.CheckInCode,
+	).Scan(&pool.ID, &pool.CreatedAt, &pool.UpdatedAt)
+}
+
+func (r *poolRepository) Update(ctx context.Context, pool *Pool) error {
+	const q = `
+		UPDATE pools
+		SET name = $2, address = $3, latitude = $4, longitude = $5, radius_meters = $6, length_meters = $7,
+			updated_at = now()
+		WHERE id = $1 AND organization_id IS NOT DISTINCT FROM $8
+		RETURNING check_in_code, created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, q,
+		pool.ID,
+		pool.Name,
+		pool.Address,
+		pool.Latitude,
+		pool.Longitude,
+		pool.RadiusMeters,
+		pool.LengthMeters,
+		tenant.ID(ctx),
+	).Scan(&pool.CheckInCode, &pool.CreatedAt, &pool.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPoolNotFound
+	}
+	return err
+}
+
+func (r *poolRepository) List(ctx context.Context) ([]*Pool, error) {
+	const q = `
+		SELECT ` + poolColumns + `
+		FROM pools p
+		WHERE p.organization_id IS NULL OR p.organization_id = $1
+		ORDER BY p.name, p.id`
+
+	return database.Select[Pool](ctx, r.db, q, tenant.ID(ctx))
+}
+
+func (r *poolRepository) GetByCode(ctx context.Context, code string) (*Pool, error) {
+	const q = `
+		SELECT ` + poolColumns + `
+		FROM pools p
+		WHERE p.check_in_code = $1 AND (p.organization_id IS NULL OR p.organization_id = $2)`
+
+	pool, err := database.Get[Pool](ctx, r.db, q, code, tenant.ID(ctx))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPoolNotFound
+	}
+	return pool, err
+}
+
+func (r *poolRepository) GetNearest(ctx context.Context, latitude, longitude float64) (*Pool, error) {
+	// Haversine distance in meters, pools are few per tenant so they are scanned
+	const q = `
+		SELECT ` + poolColumns + `
+		FROM pools p
+		CROSS JOIN LATERAL (
+			SELECT 2 * 6371000 * asin(sqrt(
+				power(sin(radians(p.latitude - $1) / 2), 2) +
+				cos(radians($1)) * cos(radians(p.latitude)) * power(sin(radians(p.longitude - $2) / 2), 2)
+			)) AS meters
+		) d
+		WHERE (p.organization_id IS NULL OR p.organization_id = $3) AND d.meters <= p.radius_meters
+		ORDER BY d.meters
+		LIMIT 1`
+
+	pool, err := database.Get[Pool](ctx, r.db, q, latitude, longitude, tenant.ID(ctx))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotAtPool
+	}
+	return pool, err
+}
+
+func (r *poolRepository) CheckIn(ctx context.Context, checkIn *CheckIn, ttl time.Duration) error {
+	const q = `
+		WITH closed AS (
+			UPDATE pool_check_ins
+			SET checked_out_at = now()
+			WHERE user_id = $1 AND checked_out_at IS NULL AND expires_at > now()
+		)
+		INSERT INTO pool_check_ins (user_id, pool_id, method, expires_at)
+		VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+		RETURNING id, checked_in_at, expires_at`
+
+	return r.db.QueryRow(ctx, q,
+		checkIn.UserID,
+		checkIn.PoolID,
+		checkIn.Method,
+		ttl.Seconds(),
+	).Scan(&checkIn.ID, &checkIn.CheckedInAt, &checkIn.ExpiresAt)
+}
+
+func (r *poolRepository) GetActiveCheckIn(ctx context.Context, userID string) (*CheckIn, error) {
+	const q = `
+		SELECT c.id, c.user_id, c.pool_id, p.name AS pool_name, p.length_meters AS pool_length_meters,
+			c.method, c.checked_in_at, c.expires_at, c.checked_out_at
+		FROM pool_check_ins c
+		JOIN pools p ON p.id = c.pool_id
+		WHERE c.user_id = $1 AND c.checked_out_at IS NULL AND c.expires_at > now()
+		ORDER BY c.checked_in_at DESC
+		LIMIT 1`
+
+	checkIn, err := database.Get[CheckIn](ctx, r.db, q, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotCheckedIn
+	}
+	return checkIn, err
+}
+
+func (r *poolRepository) CheckOut(ctx context.Context, userID string) error {
+	const q = `
+		UPDATE pool_check_ins
+		SET checked_out_at = now()
+		WHERE user_id = $1 AND checked_out_at IS NULL AND expires_at > now()`
+
+	tag, err := r.db.Exec(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotCheckedIn
+	}
+
+	return nil
+}
+
+func (r *poolRepository) ListStats(ctx context.Context, userID string) ([]*PoolStats, error) {
+	const q = `
+		SELECT ` + poolColumns + `,
+			s.sessions, s.distance_meters, s.duration_seconds, s.last_swum_at
+		FROM (
+			SELECT pool_id, count(*) AS sessions, sum(distance_meters) AS distance_meters,
+				sum(duration_seconds) AS duration_seconds, max(created_at) AS last_swum_at
+			FROM training_sessions
+			WHERE user_id = $1 AND pool_id IS NOT NULL AND deleted_at IS NULL
+			GROUP BY pool_id
+		) s
+		JOIN pools p ON p.id = s.pool_id
+		ORDER BY s.last_swum_at DESC`
+
+	return database.Select[PoolStats](ctx, r.db, q, userID)
+}
+
+func (r *poolRepository) Get(ctx context.Context, id string) (*Pool, error) {
+	const q = `
+		SELECT ` + poolColumns + `
+		FROM pools p
+		WHERE p.id = $1 AND (p.organization_id IS NULL OR p.organization_id = $2)`
+
+	pool, err := database.Get[Pool](ctx, r.db, q, id, tenant.ID(ctx))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPoolNotFound
+	}
+	return pool, err
+}
+
+func (r *poolRepository) ReplaceLanes(ctx context.Context, poolID string, lanes []*Lane) error {
+	// Lanes past the new board are dropped, the others upserted so both never touch the same row
+	const q = `
+		WITH pool AS (
+			SELECT id FROM pools WHERE id = $1 AND organization_id IS NOT DISTINCT FROM $2
+		), dropped AS (
+			DELETE FROM pool_lanes
+			WHERE pool_id IN (SELECT id FROM pool) AND number > cardinality($3::text[])
+		), upserted AS (
+			INSERT INTO pool_lanes (pool_id, number, status, swimmers, capacity, label)
+			SELECT pool.id, l.number, l.status, l.swimmers, l.capacity, l.label
+			FROM pool, unnest($3::text[], $4::int[], $5::int[], $6::text[]) WITH ORDINALITY
+				AS l(status, swimmers, capacity, label, number)
+			ON CONFLICT (pool_id, number) DO UPDATE
+			SET status = excluded.status, swimmers = excluded.swimmers, capacity = excluded.capacity,
+				label = excluded.label, updated_at = now()
+		)
+		SELECT count(*) FROM pool`
+
+	statuses := make([]string, len(lanes))
+	swimmers := make([]int, len(lanes))
+	capacities := make([]*int, len(lanes))
+	labels := make([]*string, len(lanes))
+	for i, lane := range lanes {
+		statuses[i], swimmers[i], capacities[i], labels[i] = lane.Status, lane.Swimmers, lane.Capacity, lane.Label
+	}
+
+	var found int
+	if err := r.db.QueryRow(ctx, q, poolID, tenant.ID(ctx), statuses, swimmers, capacities, labels).Scan(&found); err != nil {
+		return err
+	}
+	if found == 0 {
+		return ErrPoolNotFound
+	}
+
+	return nil
+}
+
+func (r *poolRepository) UpdateLane(ctx context.Context, poolID string, lane *Lane) error {
+	const q = `
+		UPDATE pool_lanes l
+		SET status = $3, swimmers = $4, updated_at = now()
+		FROM pools p
+		WHERE l.pool_id = $1 AND l.number = $2 AND p.id = l.pool_id AND p.organization_id IS NOT DISTINCT FROM $5
+		RETURNING l.capacity, l.label, l.updated_at`
+
+	err := r.db.QueryRow(ctx, q, poolID, lane.Number, lane.Status, lane.Swimmers, tenant.ID(ctx)).
+		Scan(&lane.Capacity, &lane.Label, &lane.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrLaneNotFound
+	}
+	return err
+}
+
+func (r *poolRepository) ListLanes(ctx context.Context, poolID string) ([]*Lane, error) {
+	const q = `
+		SELECT number, status, swimmers, capacity, label, updated_at
+		FROM pool_lanes
+		WHERE pool_id = $1
+		ORDER BY number`
+
+	return database.Select[Lane](ctx, r.db, q, poolID)
+}
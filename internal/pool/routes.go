@@ -0,0 +1,22 @@
+package pool
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the pool check in and occupancy endpoints and the management of pools by admins
+func (h *PoolHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("POST /api/v1/pools/check-in", mw.Protected(http.HandlerFunc(h.CheckIn)))
+	mux.Handle("GET /api/v1/pools/check-in", mw.Protected(http.HandlerFunc(h.GetCheckIn)))
+	mux.Handle("DELETE /api/v1/pools/check-in", mw.Protected(http.HandlerFunc(h.CheckOut)))
+	mux.Handle("GET /api/v1/pools/mine", mw.Protected(http.HandlerFunc(h.ListMine)))
+	mux.Handle("GET /api/v1/pools/{id}/occupancy", mw.PublicRead(http.HandlerFunc(h.GetOccupancy)))
+	mux.Handle("GET /api/v1/pools/{id}/occupancy/events", mw.PublicRead(http.HandlerFunc(h.WatchOccupancy)))
+	mux.Handle("GET /api/v1/admin/pools", mw.Admin(http.HandlerFunc(h.List)))
+	mux.Handle("POST /api/v1/admin/pools", mw.Admin(http.HandlerFunc(h.Create)))
+	mux.Handle("PUT /api/v1/admin/pools/{id}", mw.Admin(http.HandlerFunc(h.Update)))
+	mux.Handle("PUT /api/v1/admin/pools/{id}/lanes", mw.Admin(http.HandlerFunc(h.PublishLanes)))
+	mux.Handle("PUT /api/v1/admin/pools/{id}/lanes/{number}", mw.Admin(http.HandlerFunc(h.UpdateLane)))
+}
@@ -0,0 +1,213 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/pubsub"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+// checkInTTL is how long a check in lasts without check out, longer than any pool session
+const checkInTTL = 4 * time.Hour
+
+type PoolUsecase interface {
+	Create(ctx context.Context, req *PoolRequest) (*PoolResponse, error)
+	Update(ctx context.Context, id string, req *PoolRequest) (*PoolResponse, error)
+	List(ctx context.Context) ([]PoolResponse, error)
+	// CheckIn checks the user in at the pool of the code, or else the pool whose geofence holds
+	// the location. It replaces the active check in.
+	CheckIn(ctx context.Context, userID string, req *CheckInRequest) (*CheckInResponse, error)
+	GetCheckIn(ctx context.Context, userID string) (*CheckInResponse, error)
+	CheckOut(ctx context.Context, userID string) error
+	// ListMine returns the pools the user swam at, last swum first
+	ListMine(ctx context.Context, userID string) ([]MyPoolResponse, error)
+	// PublishLanes replaces the lane board of a pool and notifies its watchers
+	PublishLanes(ctx context.Context, poolID string, req *LanesRequest) (*OccupancyResponse, error)
+	// UpdateLane updates a lane of the board of a pool and notifies its watchers
+	UpdateLane(ctx context.Context, poolID string, number int, req *LaneUpdateRequest) (*OccupancyResponse, error)
+	GetOccupancy(ctx context.Context, poolID string) (*OccupancyResponse, error)
+	// WatchOccupancy returns the lane board of a pool, nil when not published, and the boards
+	// encoded as JSON on every update until ctx is done
+	WatchOccupancy(ctx context.Context, poolID string) (*OccupancyResponse, <-chan []byte, error)
+}
+
+type poolUsecase struct {
+	poolRepo PoolRepository
+	bus      pubsub.Bus
+}
+
+func NewPoolUsecase(poolRepo PoolRepository, bus pubsub.Bus) PoolUsecase {
+	return &poolUsecase{poolRepo, bus}
+}
+
+func (u *poolUsecase) Create(ctx context.Context, req *PoolRequest) (*PoolResponse, error) {
+	code, err := security.NewOpaqueToken("pool_", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := newPool(req)
+	pool.CheckInCode = code
+	if err := u.poolRepo.Create(ctx, pool); err != nil {
+		return nil, err
+	}
+
+	res := newPoolResponse(pool)
+	return &res, nil
+}
+
+func (u *poolUsecase) Update(ctx context.Context, id string, req *PoolRequest) (*PoolResponse, error) {
+	pool := newPool(req)
+	pool.ID = id
+	if err := u.poolRepo.Update(ctx, pool); err != nil {
+		return nil, err
+	}
+
+	res := newPoolResponse(pool)
+	return &res, nil
+}
+
+func (u *poolUsecase) List(ctx context.Context) ([]PoolResponse, error) {
+	pools, err := u.poolRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]PoolResponse, len(pools))
+	for i, pool := range pools {
+		res[i] = newPoolResponse(pool)
+	}
+	return res, nil
+}
+
+func (u *poolUsecase) CheckIn(ctx context.Context, userID string, req *CheckInRequest) (*CheckInResponse, error) {
+	var (
+		pool   *Pool
+		method string
+		err    error
+	)
+	if req.Code != nil {
+		pool, err = u.poolRepo.GetByCode(ctx, *req.Code)
+		method = MethodQR
+	} else {
+		pool, err = u.poolRepo.GetNearest(ctx, *req.Latitude, *req.Longitude)
+		method = MethodGeofence
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	checkIn := &CheckIn{
+		UserID:           userID,
+		PoolID:           pool.ID,
+		PoolName:         pool.Name,
+		PoolLengthMeters: pool.LengthMeters,
+		Method:           method,
+	}
+	if err := u.poolRepo.CheckIn(ctx, checkIn, checkInTTL); err != nil {
+		return nil, err
+	}
+
+	res := newCheckInResponse(checkIn)
+	return &res, nil
+}
+
+func (u *poolUsecase) GetCheckIn(ctx context.Context, userID string) (*CheckInResponse, error) {
+	checkIn, err := u.poolRepo.GetActiveCheckIn(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newCheckInResponse(checkIn)
+	return &res, nil
+}
+
+func (u *poolUsecase) CheckOut(ctx context.Context, userID string) error {
+	return u.poolRepo.CheckOut(ctx, userID)
+}
+
+func (u *poolUsecase) ListMine(ctx context.Context, userID string) ([]MyPoolResponse, error) {
+	stats, err := u.poolRepo.ListStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return newMyPoolResponses(stats), nil
+}
+
+// occupancyChannel is the bus channel of the lane board updates of a pool
+func occupancyChannel(poolID string) string {
+	return "pool:" + poolID + ":occupancy"
+}
+
+func (u *poolUsecase) PublishLanes(ctx context.Context, poolID string, req *LanesRequest) (*OccupancyResponse, error) {
+	if err := u.poolRepo.ReplaceLanes(ctx, poolID, newLanes(req)); err != nil {
+		return nil, err
+	}
+	return u.notify(ctx, poolID)
+}
+
+func (u *poolUsecase) UpdateLane(ctx context.Context, poolID string, number int, req *LaneUpdateRequest) (*OccupancyResponse, error) {
+	lane := &Lane{Number: number, Status: req.Status, Swimmers: req.Swimmers}
+	if err := u.poolRepo.UpdateLane(ctx, poolID, lane); err != nil {
+		return nil, err
+	}
+	return u.notify(ctx, poolID)
+}
+
+// notify sends the updated board of a pool to its watchers, who keep the previous one when it fails
+func (u *poolUsecase) notify(ctx context.Context, poolID string) (*OccupancyResponse, error) {
+	res, err := u.GetOccupancy(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.bus.Publish(ctx, occupancyChannel(poolID), payload); err != nil {
+		logger.FromContext(ctx).Warn("publish lanes: notify watchers failed", "pool_id", poolID, "error", err)
+	}
+
+	return res, nil
+}
+
+func (u *poolUsecase) GetOccupancy(ctx context.Context, poolID string) (*OccupancyResponse, error) {
+	pool, err := u.poolRepo.Get(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	lanes, err := u.poolRepo.ListLanes(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lanes) == 0 {
+		return nil, ErrBoardNotPublished
+	}
+
+	res := newOccupancyResponse(pool, lanes)
+	return &res, nil
+}
+
+func (u *poolUsecase) WatchOccupancy(ctx context.Context, poolID string) (*OccupancyResponse, <-chan []byte, error) {
+	// Subscribed first so an update published while the board is read isn't missed
+	updates, err := u.bus.Subscribe(ctx, occupancyChannel(poolID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := u.GetOccupancy(ctx, poolID)
+	if errors.Is(err, ErrBoardNotPublished) {
+		return nil, updates, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, updates, nil
+}
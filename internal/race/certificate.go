@@ -0,0 +1,78 @@
+package race
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var certificateTemplate = template.Must(template.ParseFS(templates, "templates/certificate.svg"))
+
+// certificate is the data of the certificate template
+type certificate struct {
+	Name      string
+	Race      string
+	Distance  string
+	Time      string
+	Rank      string
+	Finishers int
+	Date      string
+}
+
+// renderCertificate renders the certificate of a finished entry, an SVG image printable as is
+func renderCertificate(race *Race, entry *Entry) ([]byte, error) {
+	data := certificate{
+		Name:      entry.Name,
+		Race:      race.Name,
+		Distance:  formatDistance(race.DistanceMeters),
+		Time:      formatDuration(*entry.DurationSeconds),
+		Rank:      ordinal(*entry.Rank),
+		Finishers: race.Finishers,
+		Date:      entry.FinishedAt.UTC().Format("2 January 2006"),
+	}
+
+	var buf bytes.Buffer
+	if err := certificateTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatDistance writes whole kilometers in km, ex: 5 km, other distances in meters
+func formatDistance(meters int) string {
+	if meters >= 1000 && meters%1000 == 0 {
+		return fmt.Sprintf("%d km", meters/1000)
+	}
+	return fmt.Sprintf("%d m", meters)
+}
+
+// formatDuration writes a race time, ex: 1:42:05 or 28:30
+func formatDuration(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// ordinal writes a rank, ex: 1st, 12th, 23rd
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,139 @@
+package race
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Race statuses, computed from the window
+const (
+	StatusUpcoming = "upcoming"
+	StatusOpen     = "open"
+	StatusClosed   = "closed"
+)
+
+// RaceRequest creates or replaces a race, admins only
+type RaceRequest struct {
+	Name           string    `json:"name" validate:"required,max=100" example:"5k New Year Swim"`
+	Description    *string   `json:"description,omitempty" validate:"max=2000" example:"Swim 5 km anywhere during the first week of the year"`
+	DistanceMeters int       `json:"distanceMeters" validate:"required,min=25,max=50000" example:"5000"`
+	CutoffSeconds  *int      `json:"cutoffSeconds,omitempty" validate:"min=60,max=86400" example:"10800"` // slowest accepted time
+	StartsAt       time.Time `json:"startsAt" validate:"required" example:"2026-01-01T00:00:00Z"`
+	EndsAt         time.Time `json:"endsAt" validate:"required" example:"2026-01-08T00:00:00Z"`
+}
+
+// ResultRequest submits a session of the swimmer as their race result
+type ResultRequest struct {
+	SessionID string `json:"sessionId" validate:"required" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+}
+
+type RacesQuery struct {
+	pagination.Params
+	Status string `query:"status"`
+}
+
+// raceSorts whitelists the sortable race list columns
+var raceSorts = pagination.SortSpec{
+	Columns: map[string]string{
+		"starts_at": "r.starts_at",
+		"name":      "r.name",
+	},
+	Default:    "starts_at.desc",
+	TieBreaker: "r.id",
+}
+
+type RaceResponse struct {
+	ID             string         `json:"id" example:"3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d"`
+	Name           string         `json:"name" example:"5k New Year Swim"`
+	Description    *string        `json:"description,omitempty" example:"Swim 5 km anywhere during the first week of the year"`
+	DistanceMeters int            `json:"distanceMeters" example:"5000"`
+	CutoffSeconds  *int           `json:"cutoffSeconds,omitempty" example:"10800"`
+	StartsAt       time.Time      `json:"startsAt" example:"2026-01-01T00:00:00Z"`
+	EndsAt         time.Time      `json:"endsAt" example:"2026-01-08T00:00:00Z"`
+	Status         string         `json:"status" example:"open" enums:"upcoming,open,closed"`
+	Entries        int            `json:"entries" example:"128"`
+	Finishers      int            `json:"finishers" example:"57"`
+	Entry          *EntryResponse `json:"entry,omitempty"` // of the signed in swimmer, when registered
+}
+
+type EntryResponse struct {
+	RegisteredAt    time.Time  `json:"registeredAt" example:"2025-12-20T09:00:00Z"`
+	Finished        bool       `json:"finished" example:"true"`
+	SessionID       *string    `json:"sessionId,omitempty" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	DurationSeconds *int       `json:"durationSeconds,omitempty" example:"6120"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty" example:"2026-01-03T08:15:00Z"`
+	Rank            *int       `json:"rank,omitempty" example:"12"`
+}
+
+type FinisherResponse struct {
+	Rank            int       `json:"rank" example:"1"`
+	Name            string    `json:"name" example:"Dina"`
+	DurationSeconds int       `json:"durationSeconds" example:"4210"`
+	FinishedAt      time.Time `json:"finishedAt" example:"2026-01-02T07:40:00Z"`
+}
+
+func (r *RaceRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	if !r.EndsAt.After(r.StartsAt) {
+		return &validator.ValidationError{Errors: map[string]string{"endsAt": "Ends at must be after starts at"}}
+	}
+	return nil
+}
+
+func (r *ResultRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	if !validator.IsValidUUID(r.SessionID) {
+		return &validator.ValidationError{Errors: map[string]string{"sessionId": "ID is not a valid ID"}}
+	}
+	return nil
+}
+
+// status of a race at now
+func status(race *Race, now time.Time) string {
+	switch {
+	case now.Before(race.StartsAt):
+		return StatusUpcoming
+	case now.Before(race.EndsAt):
+		return StatusOpen
+	default:
+		return StatusClosed
+	}
+}
+
+func newRaceResponse(race *Race, entry *Entry, now time.Time) RaceResponse {
+	res := RaceResponse{
+		ID:             race.ID,
+		Name:           race.Name,
+		Description:    race.Description,
+		DistanceMeters: race.DistanceMeters,
+		CutoffSeconds:  race.CutoffSeconds,
+		StartsAt:       race.StartsAt,
+		EndsAt:         race.EndsAt,
+		Status:         status(race, now),
+		Entries:        race.Entries,
+		Finishers:      race.Finishers,
+	}
+	if entry != nil {
+		res.Entry = newEntryResponse(entry)
+	}
+	return res
+}
+
+func newEntryResponse(entry *Entry) *EntryResponse {
+	return &EntryResponse{
+		RegisteredAt:    entry.RegisteredAt,
+		Finished:        entry.FinishedAt != nil,
+		SessionID:       entry.SessionID,
+		DurationSeconds: entry.DurationSeconds,
+		FinishedAt:      entry.FinishedAt,
+		Rank:            entry.Rank,
+	}
+}
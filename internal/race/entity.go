@@ -0,0 +1,62 @@
+package race
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrRaceNotFound       = errors.New("race not found")
+	ErrRaceHasEntries     = errors.New("race has entries")
+	ErrRegistrationClosed = errors.New("race registration closed")
+	ErrSubmissionClosed   = errors.New("race submissions closed")
+	ErrEntryNotFound      = errors.New("race entry not found")
+	ErrSessionNotFound    = errors.New("race session not found")
+	ErrNotFinished        = errors.New("race not finished")
+)
+
+// Race is a virtual race event, swum anywhere between StartsAt and EndsAt
+type Race struct {
+	ID             string
+	Name           string
+	Description    *string
+	DistanceMeters int
+	CutoffSeconds  *int // slowest accepted time, nil for none
+	StartsAt       time.Time
+	EndsAt         time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Entries        int // registered swimmers
+	Finishers      int
+}
+
+// Entry is the registration of a swimmer to a race and its result once finished
+type Entry struct {
+	ID              string
+	RaceID          string
+	UserID          string
+	Name            string // of the swimmer, only read by GetEntry
+	SessionID       *string
+	DurationSeconds *int // pro-rated to the race distance
+	FinishedAt      *time.Time
+	RegisteredAt    time.Time
+	Rank            *int // among the finishers, fastest first
+}
+
+// Finisher is an entry of the finisher list
+type Finisher struct {
+	Rank            int
+	UserID          string
+	Name            string
+	DurationSeconds int
+	FinishedAt      time.Time
+}
+
+// Session is a training session of a swimmer submitted as a race result
+type Session struct {
+	ID              string
+	UserID          string
+	DistanceMeters  int
+	DurationSeconds int
+	StartedAt       time.Time
+}
@@ -0,0 +1,358 @@
+package race
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// finisherSorts has a single order, the finisher list is always fastest first
+var finisherSorts = pagination.SortSpec{}
+
+type RaceHandler struct {
+	raceUsecase RaceUsecase
+}
+
+func NewRaceHandler(raceUsecase RaceUsecase) *RaceHandler {
+	return &RaceHandler{raceUsecase}
+}
+
+// List handles listing the races
+// @Summary List races
+// @Description Virtual races, newest first by default, with the entry of the signed in swimmer in the races they registered to. Filter by status: upcoming, open (between the start and the end) or closed.
+// @Tags Race
+// @Produce json
+// @Param status query string false "Race status" Enums(upcoming,open,closed)
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Number of items per page" default(10) minimum(1) maximum(100)
+// @Param sort query string false "Sort field and direction" Enums(starts_at.asc,starts_at.desc,name.asc,name.desc) default(starts_at.desc)
+// @Success 200 {object} response.Success{data=[]RaceResponse} "Races retrieved successfully"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /races [get]
+func (h *RaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	params, verr := pagination.Parse(r.URL.Query(), pagination.Options{Sorts: raceSorts})
+	if verr != nil {
+		response.ValidationError(w, verr.Errors)
+		return
+	}
+
+	query := RacesQuery{Params: params, Status: r.URL.Query().Get("status")}
+	if query.Status != "" && !slices.Contains([]string{StatusUpcoming, StatusOpen, StatusClosed}, query.Status) {
+		response.ValidationError(w, map[string]string{"status": "Status must be one of: upcoming, open, closed"})
+		return
+	}
+
+	races, total, err := h.raceUsecase.List(ctx, claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Paginated(w, http.StatusOK, races, query.Response(total))
+}
+
+// GetById handles the detail of a race
+// @Summary Get a race
+// @Description A virtual race with its entry and finisher counts, and the entry of the signed in swimmer when registered
+// @Tags Race
+// @Produce json
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Success 200 {object} response.Success{data=RaceResponse} "Race retrieved successfully"
+// @Failure 404 {object} response.Error "Race not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /races/{id} [get]
+func (h *RaceHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.raceUsecase.GetById(ctx, claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Register handles entering a race
+// @Summary Register to a race
+// @Description Enter the signed in swimmer in a race, possible until the race ends. Registering again keeps the entry.
+// @Tags Race
+// @Produce json
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Success 200 {object} response.Success{data=RaceResponse} "Registered successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Race not found"
+// @Failure 409 {object} response.Error "Race registration closed"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /races/{id}/register [post]
+func (h *RaceHandler) Register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.raceUsecase.Register(ctx, *claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// SubmitResult handles submitting a session as a race result
+// @Summary Submit a race result
+// @Description Submit a recorded session as the result of the signed in swimmer, until 48 hours after the race ends. The session must start during the race, cover at least the race distance, be plausible and, pro-rated to the race distance, finish within the cutoff. A slower session than the current result is accepted but doesn't replace it.
+// @Tags Race
+// @Accept json
+// @Produce json
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Param request body ResultRequest true "Session to submit"
+// @Success 200 {object} response.Success{data=RaceResponse} "Result submitted successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Race, entry or session not found"
+// @Failure 409 {object} response.Error "Race submissions closed"
+// @Failure 422 {object} response.Error "Validation errors or session not qualifying"
+// @Security ApiKeyAuth
+// @Router /races/{id}/result [post]
+func (h *RaceHandler) SubmitResult(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req ResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.raceUsecase.SubmitResult(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// ListFinishers handles the finisher list of a race
+// @Summary List race finishers
+// @Description The finishers of a race ranked fastest first, earlier results first on a tie
+// @Tags Race
+// @Produce json
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Number of items per page" default(10) minimum(1) maximum(100)
+// @Success 200 {object} response.Success{data=[]FinisherResponse} "Finishers retrieved successfully"
+// @Failure 404 {object} response.Error "Race not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /races/{id}/finishers [get]
+func (h *RaceHandler) ListFinishers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	params, verr := pagination.Parse(r.URL.Query(), pagination.Options{Sorts: finisherSorts})
+	if verr != nil {
+		response.ValidationError(w, verr.Errors)
+		return
+	}
+
+	finishers, total, err := h.raceUsecase.ListFinishers(r.Context(), id, params)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Paginated(w, http.StatusOK, finishers, params.Response(total))
+}
+
+// Certificate handles downloading the finisher certificate
+// @Summary Download a race certificate
+// @Description The finisher certificate of the signed in swimmer as an SVG image, with their time and rank
+// @Tags Race
+// @Produce image/svg+xml
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Success 200 {file} file "Certificate"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Race or entry not found"
+// @Failure 409 {object} response.Error "Race not finished"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /races/{id}/certificate [get]
+func (h *RaceHandler) Certificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	svg, err := h.raceUsecase.Certificate(ctx, *claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Content-Disposition", `attachment; filename="race-certificate.svg"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(svg)
+}
+
+// Create handles creating a race
+// @Summary Create a race
+// @Description Create a virtual race, swum anywhere between startsAt and endsAt. Admin only.
+// @Tags Race
+// @Accept json
+// @Produce json
+// @Param request body RaceRequest true "Race to create"
+// @Success 201 {object} response.Success{data=RaceResponse} "Race created successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/races [post]
+func (h *RaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req RaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.raceUsecase.Create(r.Context(), &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// Update handles editing a race
+// @Summary Update a race
+// @Description Replace the details of a race, results already accepted are kept. Admin only.
+// @Tags Race
+// @Accept json
+// @Produce json
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Param request body RaceRequest true "Race details"
+// @Success 200 {object} response.Success{data=RaceResponse} "Race updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Race not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/races/{id} [put]
+func (h *RaceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req RaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.raceUsecase.Update(r.Context(), id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// Delete handles deleting a race
+// @Summary Delete a race
+// @Description Delete a race nobody registered to yet. Admin only.
+// @Tags Race
+// @Produce json
+// @Param id path string true "Race ID" example("3d9f1c2b-7a4e-4b6d-9c8a-1e2f3a4b5c6d")
+// @Success 200 {object} response.Success{data=response.Message} "Race deleted"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Race not found"
+// @Failure 409 {object} response.Error "Race has entries"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/races/{id} [delete]
+func (h *RaceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.raceUsecase.Delete(r.Context(), id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Race deleted"})
+}
@@ -0,0 +1,345 @@
+package race
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+)
+
+type RaceRepository interface {
+	Create(ctx context.Context, race *Race) error
+	// Update replaces the details of a race, ErrRaceNotFound when it doesn't exist
+	Update(ctx context.Context, race *Race) error
+	// Delete removes a race without entries, ErrRaceHasEntries once swimmers registered
+	Delete(ctx context.Context, id string) error
+	// GetById returns a race with its counts, and the entry of userID when registered
+	GetById(ctx context.Context, id string, userID *string) (*Race, *Entry, error)
+	// List returns a page of races with their counts and the entries of userID
+	List(ctx context.Context, query *RacesQuery, userID *string) ([]*Race, map[string]*Entry, pagination.Total, error)
+	// Register creates the entry of the user, or returns the existing one
+	Register(ctx context.Context, raceID, userID string) (*Entry, error)
+	// GetEntry returns the entry of the user with its rank and the name of the swimmer,
+	// ErrEntryNotFound when not registered
+	GetEntry(ctx context.Context, raceID, userID string) (*Entry, error)
+	// SetResult records the result of an entry unless it already has a faster one
+	SetResult(ctx context.Context, entryID, sessionID string, durationSeconds int) error
+	// GetSession returns a training session of the user, ErrSessionNotFound for another user's
+	GetSession(ctx context.Context, userID, sessionID string) (*Session, error)
+	// ListFinishers returns a page of the finishers of a race, fastest first
+	ListFinishers(ctx context.Context, raceID string, params pagination.Params) ([]*Finisher, pagination.Total, error)
+}
+
+type raceRepository struct {
+	db database.DBTX
+}
+
+func NewRaceRepositry(db database.DBTX) RaceRepository {
+	return &raceRepository{db}
+}
+
+func (r *raceRepository) Create(ctx context.Context, race *Race) error {
+	const q = `
+		INSERT INTO races (name, description, distance_meters, cutoff_seconds, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, q,
+		race.Name,
+		race.Description,
+		race.DistanceMeters,
+		race.CutoffSeconds,
+		race.StartsAt,
+		race.EndsAt,
+	).Scan(&race.ID, &race.CreatedAt, &race.UpdatedAt)
+}
+
+func (r *raceRepository) Update(ctx context.Context, race *Race) error {
+	const q = `
+		UPDATE races
+		SET name = $2, description = $3, distance_meters = $4, cutoff_seconds = $5,
+			starts_at = $6, ends_at = $7, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(ctx, q,
+		race.ID,
+		race.Name,
+		race.Description,
+		race.DistanceMeters,
+		race.CutoffSeconds,
+		race.StartsAt,
+		race.EndsAt,
+	).Scan(&race.CreatedAt, &race.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrRaceNotFound
+	}
+	return err
+}
+
+func (r *raceRepository) Delete(ctx context.Context, id string) error {
+	const q = `
+		WITH deleted AS (
+			DELETE FROM races
+			WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM race_entries WHERE race_id = $1)
+			RETURNING id
+		)
+		SELECT
+			EXISTS (SELECT 1 FROM deleted),
+			EXISTS (SELECT 1 FROM races WHERE id = $1)`
+
+	var deleted, exists bool
+	if err := r.db.QueryRow(ctx, q, id).Scan(&deleted, &exists); err != nil {
+		return err
+	}
+
+	switch {
+	case deleted:
+		return nil
+	case exists:
+		return ErrRaceHasEntries
+	default:
+		return ErrRaceNotFound
+	}
+}
+
+// raceColumns selects a race aliased r with its counts and the entry aliased e, scanned by
+// scanRace. The rank of a finished entry counts the faster finishers, earlier results first on
+// a tie.
+const raceColumns = `
+	r.id, r.name, r.description, r.distance_meters, r.cutoff_seconds, r.starts_at, r.ends_at,
+	r.created_at, r.updated_at, c.entries, c.finishers,
+	e.id, e.session_id, e.duration_seconds, e.finished_at, e.registered_at,
+	CASE WHEN e.finished_at IS NOT NULL THEN (
+		SELECT count(*) + 1
+		FROM race_entries o
+		WHERE o.race_id = e.race_id AND o.finished_at IS NOT NULL
+			AND (o.duration_seconds, o.finished_at) < (e.duration_seconds, e.finished_at)
+	) END`
+
+// raceCounts joins the entry counts of the race aliased r
+const raceCounts = `
+	CROSS JOIN LATERAL (
+		SELECT count(*) AS entries, count(finished_at) AS finishers
+		FROM race_entries
+		WHERE race_id = r.id
+	) c`
+
+func scanRace(row pgx.Row) (*Race, *Entry, error) {
+	var (
+		race         Race
+		entry        Entry
+		entryID      *string
+		registeredAt *time.Time
+	)
+	if err := row.Scan(
+		&race.ID,
+		&race.Name,
+		&race.Description,
+		&race.DistanceMeters,
+		&race.CutoffSeconds,
+		&race.StartsAt,
+		&race.EndsAt,
+		&race.CreatedAt,
+		&race.UpdatedAt,
+		&race.Entries,
+		&race.Finishers,
+		&entryID,
+		&entry.SessionID,
+		&entry.DurationSeconds,
+		&entry.FinishedAt,
+		&registeredAt,
+		&entry.Rank,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	if entryID == nil {
+		return &race, nil, nil
+	}
+
+	entry.ID = *entryID
+	entry.RaceID = race.ID
+	entry.RegisteredAt = *registeredAt
+	return &race, &entry, nil
+}
+
+func (r *raceRepository) GetById(ctx context.Context, id string, userID *string) (*Race, *Entry, error) {
+	q := `
+		SELECT ` + raceColumns + `
+		FROM races r` + raceCounts + `
+		LEFT JOIN race_entries e ON e.race_id = r.id AND e.user_id = $2
+		WHERE r.id = $1`
+
+	race, entry, err := scanRace(r.db.QueryRow(ctx, q, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrRaceNotFound
+		}
+		return nil, nil, err
+	}
+
+	if entry != nil {
+		entry.UserID = *userID
+	}
+	return race, entry, nil
+}
+
+func (r *raceRepository) List(ctx context.Context, query *RacesQuery, userID *string) ([]*Race, map[string]*Entry, pagination.Total, error) {
+	var total pagination.Total
+
+	// $1 is the user of the entries, the filters start at $2
+	whereQ := ` WHERE true`
+	args := []any{userID}
+	switch query.Status {
+	case StatusUpcoming:
+		whereQ += ` AND r.starts_at > now()`
+	case StatusOpen:
+		whereQ += ` AND r.starts_at <= now() AND r.ends_at > now()`
+	case StatusClosed:
+		whereQ += ` AND r.ends_at <= now()`
+	}
+
+	limitQ, limitArgs := query.LimitOffset(len(args) + 1)
+	q := `
+		SELECT ` + raceColumns + `
+		FROM races r` + raceCounts + `
+		LEFT JOIN race_entries e ON e.race_id = r.id AND e.user_id = $1` + whereQ + query.Sort.OrderBy() + limitQ
+
+	rows, err := r.db.Query(ctx, q, append(args, limitArgs...)...)
+	if err != nil {
+		return nil, nil, total, err
+	}
+	defer rows.Close()
+
+	races := make([]*Race, 0, query.Limit)
+	entries := make(map[string]*Entry)
+	for rows.Next() {
+		race, entry, err := scanRace(rows)
+		if err != nil {
+			return nil, nil, total, err
+		}
+
+		races = append(races, race)
+		if entry != nil {
+			entry.UserID = *userID
+			entries[race.ID] = entry
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, total, err
+	}
+
+	total.Items, total.Estimated, err = database.Count(ctx, r.db, query.Count == pagination.CountEstimate, `SELECT 1 FROM races r`+whereQ)
+	if err != nil {
+		return nil, nil, total, err
+	}
+
+	return races, entries, total, nil
+}
+
+func (r *raceRepository) Register(ctx context.Context, raceID, userID string) (*Entry, error) {
+	// The insert and the existing entry can't both return a row
+	const q = `
+		WITH ins AS (
+			INSERT INTO race_entries (race_id, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (race_id, user_id) DO NOTHING
+			RETURNING id, registered_at
+		)
+		SELECT id, registered_at FROM ins
+		UNION ALL
+		SELECT id, registered_at FROM race_entries WHERE race_id = $1 AND user_id = $2
+		LIMIT 1`
+
+	entry := Entry{RaceID: raceID, UserID: userID}
+	if err := r.db.QueryRow(ctx, q, raceID, userID).Scan(&entry.ID, &entry.RegisteredAt); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *raceRepository) GetEntry(ctx context.Context, raceID, userID string) (*Entry, error) {
+	const q = `
+		SELECT
+			e.id, u.name, e.session_id, e.duration_seconds, e.finished_at, e.registered_at,
+			CASE WHEN e.finished_at IS NOT NULL THEN (
+				SELECT count(*) + 1
+				FROM race_entries o
+				WHERE o.race_id = e.race_id AND o.finished_at IS NOT NULL
+					AND (o.duration_seconds, o.finished_at) < (e.duration_seconds, e.finished_at)
+			) END AS rank
+		FROM race_entries e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.race_id = $1 AND e.user_id = $2`
+
+	entry, err := database.Get[Entry](ctx, r.db, q, raceID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	entry.RaceID, entry.UserID = raceID, userID
+	return entry, nil
+}
+
+func (r *raceRepository) SetResult(ctx context.Context, entryID, sessionID string, durationSeconds int) error {
+	const q = `
+		UPDATE race_entries
+		SET session_id = $2, duration_seconds = $3, finished_at = now()
+		WHERE id = $1 AND (duration_seconds IS NULL OR duration_seconds > $3)`
+
+	_, err := r.db.Exec(ctx, q, entryID, sessionID, durationSeconds)
+	return err
+}
+
+func (r *raceRepository) GetSession(ctx context.Context, userID, sessionID string) (*Session, error) {
+	const q = `
+		SELECT id, user_id, distance_meters, duration_seconds, created_at AS started_at
+		FROM training_sessions
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+
+	s, err := database.Get[Session](ctx, r.db, q, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *raceRepository) ListFinishers(ctx context.Context, raceID string, params pagination.Params) ([]*Finisher, pagination.Total, error) {
+	var total pagination.Total
+
+	const fromQ = `
+		FROM race_entries e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.race_id = $1 AND e.finished_at IS NOT NULL AND u.deleted_at IS NULL`
+
+	// The rank is numbered over every finisher before the page is cut
+	limitQ, limitArgs := params.LimitOffset(2)
+	q := `
+		SELECT
+			row_number() OVER (ORDER BY e.duration_seconds, e.finished_at, e.id) AS rank,
+			e.user_id, u.name, e.duration_seconds, e.finished_at` + fromQ + `
+		ORDER BY e.duration_seconds, e.finished_at, e.id` + limitQ
+
+	finishers, err := database.Select[Finisher](ctx, r.db, q, append([]any{raceID}, limitArgs...)...)
+	if err != nil {
+		return nil, total, err
+	}
+
+	total.Items, total.Estimated, err = database.Count(ctx, r.db, params.Count == pagination.CountEstimate, `SELECT 1`+fromQ, raceID)
+	if err != nil {
+		return nil, total, err
+	}
+
+	return finishers, total, nil
+}
@@ -0,0 +1,20 @@
+package race
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the virtual race endpoints, races are managed by admins
+func (h *RaceHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("GET /api/v1/races", mw.Protected(http.HandlerFunc(h.List)))
+	mux.Handle("GET /api/v1/races/{id}", mw.Protected(http.HandlerFunc(h.GetById)))
+	mux.Handle("POST /api/v1/races/{id}/register", mw.Protected(http.HandlerFunc(h.Register)))
+	mux.Handle("POST /api/v1/races/{id}/result", mw.Protected(http.HandlerFunc(h.SubmitResult)))
+	mux.Handle("GET /api/v1/races/{id}/finishers", mw.Protected(http.HandlerFunc(h.ListFinishers)))
+	mux.Handle("GET /api/v1/races/{id}/certificate", mw.Protected(http.HandlerFunc(h.Certificate)))
+	mux.Handle("POST /api/v1/admin/races", mw.Admin(http.HandlerFunc(h.Create)))
+	mux.Handle("PUT /api/v1/admin/races/{id}", mw.Admin(http.HandlerFunc(h.Update)))
+	mux.Handle("DELETE /api/v1/admin/races/{id}", mw.Admin(http.HandlerFunc(h.Delete)))
+}
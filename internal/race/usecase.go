@@ -0,0 +1,211 @@
+package race
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rizkyharahap/swimo/internal/training"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// submitGrace is how long after the end of a race sessions swum during it are still accepted,
+// for sessions synced late
+const submitGrace = 48 * time.Hour
+
+type RaceUsecase interface {
+	Create(ctx context.Context, req *RaceRequest) (*RaceResponse, error)
+	Update(ctx context.Context, id string, req *RaceRequest) (*RaceResponse, error)
+	Delete(ctx context.Context, id string) error
+	// List returns a page of races, with the entry of userID when registered
+	List(ctx context.Context, userID *string, query *RacesQuery) ([]RaceResponse, pagination.Total, error)
+	GetById(ctx context.Context, userID *string, id string) (*RaceResponse, error)
+	// Register enters the user in a race until it ends, registering twice keeps the entry
+	Register(ctx context.Context, userID, id string) (*RaceResponse, error)
+	// SubmitResult validates a session of the user against the race and records it as the
+	// result, unless the entry already has a faster one
+	SubmitResult(ctx context.Context, userID, id string, req *ResultRequest) (*RaceResponse, error)
+	ListFinishers(ctx context.Context, id string, params pagination.Params) ([]FinisherResponse, pagination.Total, error)
+	// Certificate renders the finisher certificate of the user as an SVG image
+	Certificate(ctx context.Context, userID, id string) ([]byte, error)
+}
+
+type raceUsecase struct {
+	raceRepo RaceRepository
+}
+
+func NewRaceUsecase(raceRepo RaceRepository) RaceUsecase {
+	return &raceUsecase{raceRepo}
+}
+
+func (u *raceUsecase) Create(ctx context.Context, req *RaceRequest) (*RaceResponse, error) {
+	race := newRace(req)
+	if err := u.raceRepo.Create(ctx, race); err != nil {
+		return nil, err
+	}
+
+	res := newRaceResponse(race, nil, time.Now())
+	return &res, nil
+}
+
+func (u *raceUsecase) Update(ctx context.Context, id string, req *RaceRequest) (*RaceResponse, error) {
+	race := newRace(req)
+	race.ID = id
+	if err := u.raceRepo.Update(ctx, race); err != nil {
+		return nil, err
+	}
+
+	// Read back for the counts
+	return u.GetById(ctx, nil, id)
+}
+
+func (u *raceUsecase) Delete(ctx context.Context, id string) error {
+	return u.raceRepo.Delete(ctx, id)
+}
+
+func (u *raceUsecase) List(ctx context.Context, userID *string, query *RacesQuery) ([]RaceResponse, pagination.Total, error) {
+	races, entries, total, err := u.raceRepo.List(ctx, query, userID)
+	if err != nil {
+		return nil, total, err
+	}
+
+	now := time.Now()
+	res := make([]RaceResponse, len(races))
+	for i, race := range races {
+		res[i] = newRaceResponse(race, entries[race.ID], now)
+	}
+	return res, total, nil
+}
+
+func (u *raceUsecase) GetById(ctx context.Context, userID *string, id string) (*RaceResponse, error) {
+	race, entry, err := u.raceRepo.GetById(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newRaceResponse(race, entry, time.Now())
+	return &res, nil
+}
+
+func (u *raceUsecase) Register(ctx context.Context, userID, id string) (*RaceResponse, error) {
+	race, _, err := u.raceRepo.GetById(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status(race, time.Now()) == StatusClosed {
+		return nil, ErrRegistrationClosed
+	}
+
+	if _, err := u.raceRepo.Register(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return u.GetById(ctx, &userID, id)
+}
+
+func (u *raceUsecase) SubmitResult(ctx context.Context, userID, id string, req *ResultRequest) (*RaceResponse, error) {
+	race, entry, err := u.raceRepo.GetById(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrEntryNotFound
+	}
+
+	now := time.Now()
+	if now.Before(race.StartsAt) || now.After(race.EndsAt.Add(submitGrace)) {
+		return nil, ErrSubmissionClosed
+	}
+
+	session, err := u.raceRepo.GetSession(ctx, userID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	durationSeconds, reason := checkSession(race, session)
+	if reason != "" {
+		return nil, &validator.ValidationError{Errors: map[string]string{"sessionId": reason}}
+	}
+
+	if err := u.raceRepo.SetResult(ctx, entry.ID, session.ID, durationSeconds); err != nil {
+		return nil, err
+	}
+
+	return u.GetById(ctx, &userID, id)
+}
+
+func (u *raceUsecase) ListFinishers(ctx context.Context, id string, params pagination.Params) ([]FinisherResponse, pagination.Total, error) {
+	// A race without finishers is an empty page, an unknown race is not found
+	if _, _, err := u.raceRepo.GetById(ctx, id, nil); err != nil {
+		return nil, pagination.Total{}, err
+	}
+
+	finishers, total, err := u.raceRepo.ListFinishers(ctx, id, params)
+	if err != nil {
+		return nil, total, err
+	}
+
+	res := make([]FinisherResponse, len(finishers))
+	for i, f := range finishers {
+		res[i] = FinisherResponse{
+			Rank:            f.Rank,
+			Name:            f.Name,
+			DurationSeconds: f.DurationSeconds,
+			FinishedAt:      f.FinishedAt,
+		}
+	}
+	return res, total, nil
+}
+
+func (u *raceUsecase) Certificate(ctx context.Context, userID, id string) ([]byte, error) {
+	race, _, err := u.raceRepo.GetById(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := u.raceRepo.GetEntry(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.FinishedAt == nil {
+		return nil, ErrNotFinished
+	}
+
+	return renderCertificate(race, entry)
+}
+
+// checkSession validates a session against a race and returns the result it is worth, the
+// duration pro-rated to the race distance for a longer swim, or why it doesn't qualify
+func checkSession(race *Race, session *Session) (int, string) {
+	if session.StartedAt.Before(race.StartsAt) || !session.StartedAt.Before(race.EndsAt) {
+		return 0, "Session must be swum during the race"
+	}
+	if session.DistanceMeters < race.DistanceMeters {
+		return 0, "Session is shorter than the race distance"
+	}
+
+	// Same bounds as the recording of a session without an override, with the fastest level
+	if training.CheckPlausibility("", session.DistanceMeters, session.DurationSeconds, nil) != nil {
+		return 0, "Session is not a plausible swim"
+	}
+
+	durationSeconds := int(math.Round(float64(session.DurationSeconds) * float64(race.DistanceMeters) / float64(session.DistanceMeters)))
+	if race.CutoffSeconds != nil && durationSeconds > *race.CutoffSeconds {
+		return 0, "Time is over the race cutoff"
+	}
+
+	return durationSeconds, ""
+}
+
+func newRace(req *RaceRequest) *Race {
+	return &Race{
+		Name:           req.Name,
+		Description:    req.Description,
+		DistanceMeters: req.DistanceMeters,
+		CutoffSeconds:  req.CutoffSeconds,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+	}
+}
@@ -0,0 +1,145 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Stats periods, rolling windows ending now
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// Sources of the max heart rate
+const (
+	MaxHeartRatePreferences = "preferences"
+	MaxHeartRateAge         = "age"
+)
+
+type HeartRateZonesQuery struct {
+	Period string `query:"period" validate:"oneof=week month year"`
+}
+
+type HeartRateZonesResponse struct {
+	Period             string                     `json:"period" example:"month"`
+	From               time.Time                  `json:"from" example:"2025-08-21T07:30:00Z"`
+	To                 time.Time                  `json:"to" example:"2025-09-21T07:30:00Z"`
+	MaxHeartRate       int                        `json:"maxHeartRate" example:"188"`
+	MaxHeartRateSource string                     `json:"maxHeartRateSource" example:"age" enums:"preferences,age"`
+	Zones              []HeartRateZoneResponse    `json:"zones"`
+	Sessions           []HeartRateSessionResponse `json:"sessions"`
+	Injuries           []InjuryPeriodResponse     `json:"injuries"`
+}
+
+// HeartRateZoneResponse is the time spent in one zone over the period
+type HeartRateZoneResponse struct {
+	Zone    int     `json:"zone" example:"2"`
+	Name    string  `json:"name" example:"Endurance"`
+	MinBpm  int     `json:"minBpm" example:"113"`
+	MaxBpm  int     `json:"maxBpm" example:"131"`
+	Seconds int     `json:"seconds" example:"5400"`
+	Percent float64 `json:"percent" example:"42.5"`
+}
+
+type HeartRateSessionResponse struct {
+	ID        string                      `json:"id" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	CreatedAt time.Time                   `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+	Seconds   int                         `json:"seconds" example:"1800"` // time of the laps with a heart rate
+	Zones     []HeartRateZoneTimeResponse `json:"zones"`
+}
+
+// HeartRateZoneTimeResponse is the time spent in one zone during a session
+type HeartRateZoneTimeResponse struct {
+	Zone    int     `json:"zone" example:"2"`
+	Seconds int     `json:"seconds" example:"600"`
+	Percent float64 `json:"percent" example:"33.3"`
+}
+
+type TrainingLoadQuery struct {
+	Days int `query:"days" validate:"min=7,max=365"`
+}
+
+// TrainingLoadResponse is the acute (7 day) and chronic (42 day) training load of the user, the
+// exponentially weighted daily load of minutes × RPE, with one point per UTC day of the range
+type TrainingLoadResponse struct {
+	From          string                    `json:"from" example:"2025-08-11"`
+	To            string                    `json:"to" example:"2025-09-21"`
+	Acute         float64                   `json:"acute" example:"142.6"`
+	Chronic       float64                   `json:"chronic" example:"98.3"`
+	Ratio         *float64                  `json:"ratio,omitempty" example:"1.45"` // unset without chronic load
+	RiskThreshold float64                   `json:"riskThreshold" example:"1.5"`
+	AtRisk        bool                      `json:"atRisk" example:"false"`
+	Days          []TrainingLoadDayResponse `json:"days"`
+	Injuries      []InjuryPeriodResponse    `json:"injuries"`
+}
+
+type TrainingLoadDayResponse struct {
+	Date     string   `json:"date" example:"2025-09-21"`
+	Sessions int      `json:"sessions" example:"1"`
+	Load     float64  `json:"load" example:"270"`
+	Acute    float64  `json:"acute" example:"142.6"`
+	Chronic  float64  `json:"chronic" example:"98.3"`
+	Ratio    *float64 `json:"ratio,omitempty" example:"1.45"`
+}
+
+// InjuryPeriodResponse is an injury overlapping the period of a stats response, so charts can
+// shade the days it lasted. Ongoing injuries have no resolved date.
+type InjuryPeriodResponse struct {
+	ID         string  `json:"id" example:"0f6b7c1e-3a2d-4b5c-8d9e-1f2a3b4c5d6e"`
+	Type       string  `json:"type" example:"Swimmer's shoulder"`
+	Severity   string  `json:"severity" example:"moderate" enums:"mild,moderate,severe"`
+	InjuredOn  string  `json:"injuredOn" example:"2025-09-01"`
+	ResolvedOn *string `json:"resolvedOn,omitempty" example:"2025-09-21"`
+}
+
+type OpenWaterStatsQuery struct {
+	Year int `query:"year" validate:"min=2000,max=2100"`
+}
+
+// OpenWaterStatsResponse sums the open water sessions of a season, the calendar year, in total
+// and per month. Water temperatures only count sessions where it was recorded.
+type OpenWaterStatsResponse struct {
+	Year int `json:"year" example:"2025"`
+	OpenWaterTotalsResponse
+	Months   []OpenWaterMonthResponse `json:"months"`
+	Injuries []InjuryPeriodResponse   `json:"injuries"`
+}
+
+type OpenWaterMonthResponse struct {
+	Month string `json:"month" example:"2025-07"`
+	OpenWaterTotalsResponse
+}
+
+type OpenWaterTotalsResponse struct {
+	Sessions             int      `json:"sessions" example:"6"`
+	DistanceMeters       int64    `json:"distanceMeters" example:"9000"`
+	DurationSeconds      int64    `json:"durationSeconds" example:"12600"`
+	WetsuitSessions      int      `json:"wetsuitSessions" example:"2"`
+	AvgWaterTemperatureC *float64 `json:"avgWaterTemperatureC,omitempty" example:"24.8"`
+	MinWaterTemperatureC *float64 `json:"minWaterTemperatureC,omitempty" example:"21.5"`
+	MaxWaterTemperatureC *float64 `json:"maxWaterTemperatureC,omitempty" example:"27"`
+}
+
+func (q *OpenWaterStatsQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *HeartRateZonesQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *TrainingLoadQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
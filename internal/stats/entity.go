@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrMaxHeartRateUnknown = errors.New("max heart rate unknown")
+
+// HeartRateProfile holds what sets the heart rate zones of a user
+type HeartRateProfile struct {
+	MaxHeartRate *int // bpm, set in the preferences
+	AgeYears     *int
+}
+
+// HeartRateLap is a lap recorded with the average heart rate of the device
+type HeartRateLap struct {
+	SessionID       string
+	CreatedAt       time.Time
+	DurationSeconds int
+	AvgHeartRate    int
+}
+
+// OpenWaterMonth sums the open water sessions of a user started in one month
+type OpenWaterMonth struct {
+	Month               time.Time
+	Sessions            int
+	DistanceMeters      int64
+	DurationSeconds     int64
+	WetsuitSessions     int
+	TemperatureReadings int // sessions with a water temperature
+	TemperatureSumC     float64
+	MinTemperatureC     *float64
+	MaxTemperatureC     *float64
+}
+
+// InjuryPeriod is an injury of the injury log drawn over a stats period, ongoing until resolved
+type InjuryPeriod struct {
+	ID         string
+	Type       string
+	Severity   string
+	InjuredOn  time.Time
+	ResolvedOn *time.Time
+}
+
+// DailyLoad sums the training load of the sessions of a user created on one UTC day
+type DailyLoad struct {
+	Day      time.Time
+	Sessions int
+	Load     float64 // minutes × RPE
+}
@@ -0,0 +1,150 @@
+package stats
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type StatsHandler struct {
+	statsUsecase StatsUsecase
+}
+
+func NewStatsHandler(statsUsecase StatsUsecase) *StatsHandler {
+	return &StatsHandler{statsUsecase}
+}
+
+// GetHeartRateZones handles the time in heart rate zones of the user
+// @Summary Heart rate zones
+// @Description Time spent in each of the five heart rate zones over a rolling period, aggregated and per session. Only laps recorded with an average heart rate count. Zones are shares of the max heart rate set in the preferences, or 220 minus the age when unset. Injuries overlapping the period are listed so charts can show them.
+// @Tags Stats
+// @Produce json
+// @Param period query string false "Rolling period ending now" Enums(week,month,year) default(month)
+// @Success 200 {object} response.Success{data=HeartRateZonesResponse} "Heart rate zones retrieved successfully"
+// @Failure 402 {object} response.Error "Premium plan required"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "User not found"
+// @Failure 422 {object} response.Error "Validation errors or max heart rate unknown"
+// @Security ApiKeyAuth
+// @Router /stats/hr-zones [get]
+func (h *StatsHandler) GetHeartRateZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	query := HeartRateZonesQuery{Period: r.URL.Query().Get("period")}
+	if query.Period == "" {
+		query.Period = PeriodMonth
+	}
+
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	zones, err := h.statsUsecase.GetHeartRateZones(ctx, *claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, zones)
+}
+
+// GetOpenWaterStats handles the open water season of the user
+// @Summary Open water season
+// @Description Sessions, distance, duration, wetsuit sessions and water temperatures of the open water sessions of a calendar year, in total and for each month in UTC. Injuries overlapping the year are listed so charts can show them.
+// @Tags Stats
+// @Produce json
+// @Param year query int false "Season, the current year by default" example(2025)
+// @Success 200 {object} response.Success{data=OpenWaterStatsResponse} "Open water stats retrieved successfully"
+// @Failure 402 {object} response.Error "Premium plan required"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /stats/open-water [get]
+func (h *StatsHandler) GetOpenWaterStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	query := OpenWaterStatsQuery{Year: time.Now().UTC().Year()}
+	if raw := r.URL.Query().Get("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			response.ValidationError(w, map[string]string{"year": "Year must be a number"})
+			return
+		}
+		query.Year = year
+	}
+
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	stats, err := h.statsUsecase.GetOpenWaterStats(ctx, *claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, stats)
+}
+
+// GetTrainingLoad handles the acute and chronic training load of the user
+// @Summary Training load
+// @Description Acute (7 day) and chronic (42 day) training load for each UTC day of the range ending today. The load of a session is its minutes times its perceived exertion (RPE), sessions without one count as moderate. Both loads are exponentially weighted moving averages, an acute:chronic ratio above the risk threshold flags a sharp ramp up. Injuries overlapping the range are listed so charts can show them.
+// @Tags Stats
+// @Produce json
+// @Param days query int false "Days of the range, today included" minimum(7) maximum(365) default(42)
+// @Success 200 {object} response.Success{data=TrainingLoadResponse} "Training load retrieved successfully"
+// @Failure 402 {object} response.Error "Premium plan required"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /stats/training-load [get]
+func (h *StatsHandler) GetTrainingLoad(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	query := TrainingLoadQuery{Days: 42}
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			response.ValidationError(w, map[string]string{"days": "Days must be a number"})
+			return
+		}
+		query.Days = days
+	}
+
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	load, err := h.statsUsecase.GetTrainingLoad(ctx, *claim.Uid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, load)
+}
@@ -0,0 +1,30 @@
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+// injuryOverlay returns the injuries of the user overlapping [from, to)
+func (u *statsUsecase) injuryOverlay(ctx context.Context, userID string, from, to time.Time) ([]InjuryPeriodResponse, error) {
+	periods, err := u.statsRepo.ListInjuryPeriods(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]InjuryPeriodResponse, len(periods))
+	for i, p := range periods {
+		res[i] = InjuryPeriodResponse{
+			ID:        p.ID,
+			Type:      p.Type,
+			Severity:  p.Severity,
+			InjuredOn: p.InjuredOn.Format(time.DateOnly),
+		}
+		if p.ResolvedOn != nil {
+			resolved := p.ResolvedOn.Format(time.DateOnly)
+			res[i].ResolvedOn = &resolved
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,30 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/scheduler"
+)
+
+// NewTrainingLoadAlertJob returns a job checking the training load of the users who swam since
+// their last check and notifying those whose acute:chronic ratio went above the risk threshold
+func NewTrainingLoadAlertJob(cfg config.JobConfig, statsUsecase StatsUsecase) scheduler.Job {
+	return scheduler.Job{
+		Name:     "training_load_alerts",
+		Interval: cfg.Interval,
+		Jitter:   cfg.Jitter,
+		Run: func(ctx context.Context) error {
+			sent, err := statsUsecase.SendLoadAlerts(ctx)
+			if err != nil {
+				return err
+			}
+
+			if sent > 0 {
+				logger.FromContext(ctx).Info("Training load alerts published", "users", sent)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// GetOpenWaterStats sums the open water sessions of the year, every month is listed even without sessions
+func (u *statsUsecase) GetOpenWaterStats(ctx context.Context, userID string, query *OpenWaterStatsQuery) (*OpenWaterStatsResponse, error) {
+	from := time.Date(query.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	months, err := u.statsRepo.ListOpenWaterMonths(ctx, userID, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	byMonth := make(map[time.Month]*OpenWaterMonth, len(months))
+	for _, m := range months {
+		byMonth[m.Month.UTC().Month()] = m
+	}
+
+	res := &OpenWaterStatsResponse{Year: query.Year, Months: make([]OpenWaterMonthResponse, 0, 12)}
+	var season OpenWaterMonth
+	for month := time.January; month <= time.December; month++ {
+		m, ok := byMonth[month]
+		if !ok {
+			m = &OpenWaterMonth{}
+		}
+
+		res.Months = append(res.Months, OpenWaterMonthResponse{
+			Month:                   time.Date(query.Year, month, 1, 0, 0, 0, 0, time.UTC).Format("2006-01"),
+			OpenWaterTotalsResponse: newOpenWaterTotals(m),
+		})
+		addOpenWaterMonth(&season, m)
+	}
+	res.OpenWaterTotalsResponse = newOpenWaterTotals(&season)
+
+	if res.Injuries, err = u.injuryOverlay(ctx, userID, from, from.AddDate(1, 0, 0)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// addOpenWaterMonth adds the sums of m to total
+func addOpenWaterMonth(total, m *OpenWaterMonth) {
+	total.Sessions += m.Sessions
+	total.DistanceMeters += m.DistanceMeters
+	total.DurationSeconds += m.DurationSeconds
+	total.WetsuitSessions += m.WetsuitSessions
+	total.TemperatureReadings += m.TemperatureReadings
+	total.TemperatureSumC += m.TemperatureSumC
+
+	if m.MinTemperatureC != nil && (total.MinTemperatureC == nil || *m.MinTemperatureC < *total.MinTemperatureC) {
+		total.MinTemperatureC = m.MinTemperatureC
+	}
+	if m.MaxTemperatureC != nil && (total.MaxTemperatureC == nil || *m.MaxTemperatureC > *total.MaxTemperatureC) {
+		total.MaxTemperatureC = m.MaxTemperatureC
+	}
+}
+
+func newOpenWaterTotals(m *OpenWaterMonth) OpenWaterTotalsResponse {
+	res := OpenWaterTotalsResponse{
+		Sessions:             m.Sessions,
+		DistanceMeters:       m.DistanceMeters,
+		DurationSeconds:      m.DurationSeconds,
+		WetsuitSessions:      m.WetsuitSessions,
+		MinWaterTemperatureC: m.MinTemperatureC,
+		MaxWaterTemperatureC: m.MaxTemperatureC,
+	}
+
+	if m.TemperatureReadings > 0 {
+		avg := math.Round(m.TemperatureSumC/float64(m.TemperatureReadings)*10) / 10
+		res.AvgWaterTemperatureC = &avg
+	}
+
+	return res
+}
@@ -0,0 +1,219 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/user"
+)
+
+type StatsRepository interface {
+	GetHeartRateProfile(ctx context.Context, userID string) (*HeartRateProfile, error)
+	// ListHeartRateLaps returns the laps with a heart rate of the sessions created in [from, to),
+	// newest session first and laps in recorded order
+	ListHeartRateLaps(ctx context.Context, userID string, from, to time.Time) ([]HeartRateLap, error)
+	// ListOpenWaterMonths sums the open water sessions created in [from, to) per UTC month,
+	// months without sessions are left out
+	ListOpenWaterMonths(ctx context.Context, userID string, from, to time.Time) ([]*OpenWaterMonth, error)
+	// ListDailyLoads sums the training load of the sessions created in [from, to) per UTC day,
+	// sessions without a perceived exertion count with defaultRPE. Days without sessions are left out.
+	ListDailyLoads(ctx context.Context, userID string, from, to time.Time, defaultRPE int) ([]DailyLoad, error)
+	// ListLoadCheckDue returns up to limit users with a session created in the last day since
+	// their training load was last checked
+	ListLoadCheckDue(ctx context.Context, limit int) ([]string, error)
+	MarkLoadChecked(ctx context.Context, userID string) error
+	// ClaimLoadAlert marks the user alerted unless they were within the cooldown
+	ClaimLoadAlert(ctx context.Context, userID string, cooldown time.Duration) (bool, error)
+	// ListInjuryPeriods returns the injuries of the user overlapping [from, to), earliest first
+	ListInjuryPeriods(ctx context.Context, userID string, from, to time.Time) ([]InjuryPeriod, error)
+}
+
+type statsRepository struct {
+	db database.DBTX
+}
+
+func NewStatsRepositry(db database.DBTX) StatsRepository {
+	return &statsRepository{db}
+}
+
+func (r *statsRepository) GetHeartRateProfile(ctx context.Context, userID string) (*HeartRateProfile, error) {
+	const q = `
+		SELECT max_heart_rate, age_years
+		FROM users
+		WHERE id = $1`
+
+	var profile HeartRateProfile
+	if err := r.db.QueryRow(ctx, q, userID).Scan(&profile.MaxHeartRate, &profile.AgeYears); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *statsRepository) ListHeartRateLaps(ctx context.Context, userID string, from, to time.Time) ([]HeartRateLap, error) {
+	const q = `
+		SELECT ts.id, ts.created_at, l.duration_seconds, l.avg_heart_rate
+		FROM training_sessions ts
+		JOIN training_session_laps l ON l.session_id = ts.id
+		WHERE ts.user_id = $1
+			AND ts.created_at >= $2 AND ts.created_at < $3
+			AND ts.deleted_at IS NULL
+			AND l.avg_heart_rate IS NOT NULL
+		ORDER BY ts.created_at DESC, ts.id, l.lap_number`
+
+	rows, err := r.db.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var laps []HeartRateLap
+	for rows.Next() {
+		var lap HeartRateLap
+		if err := rows.Scan(&lap.SessionID, &lap.CreatedAt, &lap.DurationSeconds, &lap.AvgHeartRate); err != nil {
+			return nil, err
+		}
+		laps = append(laps, lap)
+	}
+
+	return laps, rows.Err()
+}
+
+func (r *statsRepository) ListOpenWaterMonths(ctx context.Context, userID string, from, to time.Time) ([]*OpenWaterMonth, error) {
+	const q = `
+		SELECT
+			date_trunc('month', ts.created_at, 'UTC') AS month,
+			count(*) AS sessions,
+			sum(ts.distance_meters) AS distance_meters,
+			sum(ts.duration_seconds) AS duration_seconds,
+			count(*) FILTER (WHERE c.wetsuit) AS wetsuit_sessions,
+			count(c.water_temperature_c) AS temperature_readings,
+			COALESCE(sum(c.water_temperature_c), 0) AS temperature_sum_c,
+			min(c.water_temperature_c) AS min_temperature_c,
+			max(c.water_temperature_c) AS max_temperature_c
+		FROM training_sessions ts
+		JOIN trainings t ON t.id = ts.training_id
+		JOIN training_categories tc ON tc.id = t.category_id AND tc.code = 'OPEN_WATER'
+		LEFT JOIN training_session_conditions c ON c.session_id = ts.id
+		WHERE ts.user_id = $1
+			AND ts.created_at >= $2 AND ts.created_at < $3
+			AND ts.deleted_at IS NULL
+		GROUP BY month
+		ORDER BY month`
+
+	return database.Select[OpenWaterMonth](ctx, r.db, q, userID, from, to)
+}
+
+func (r *statsRepository) ListInjuryPeriods(ctx context.Context, userID string, from, to time.Time) ([]InjuryPeriod, error) {
+	const q = `
+		SELECT id, type, severity, injured_on, resolved_on
+		FROM injuries
+		WHERE user_id = $1
+			AND injured_on < $3
+			AND (resolved_on IS NULL OR resolved_on >= $2::date)
+		ORDER BY injured_on, created_at`
+
+	rows, err := r.db.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []InjuryPeriod
+	for rows.Next() {
+		var p InjuryPeriod
+		if err := rows.Scan(&p.ID, &p.Type, &p.Severity, &p.InjuredOn, &p.ResolvedOn); err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+
+	return periods, rows.Err()
+}
+
+func (r *statsRepository) ListDailyLoads(ctx context.Context, userID string, from, to time.Time, defaultRPE int) ([]DailyLoad, error) {
+	const q = `
+		SELECT
+			date_trunc('day', ts.created_at AT TIME ZONE 'UTC') AS day,
+			count(*),
+			sum(ts.duration_seconds / 60.0 * COALESCE(ts.rpe, $4))::float8
+		FROM training_sessions ts
+		WHERE ts.user_id = $1
+			AND ts.created_at >= $2 AND ts.created_at < $3
+			AND ts.deleted_at IS NULL
+		GROUP BY day
+		ORDER BY day`
+
+	rows, err := r.db.Query(ctx, q, userID, from, to, defaultRPE)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []DailyLoad
+	for rows.Next() {
+		var d DailyLoad
+		if err := rows.Scan(&d.Day, &d.Sessions, &d.Load); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+
+	return days, rows.Err()
+}
+
+func (r *statsRepository) ListLoadCheckDue(ctx context.Context, limit int) ([]string, error) {
+	const q = `
+		SELECT u.id
+		FROM users u
+		WHERE u.deleted_at IS NULL
+			AND EXISTS (
+				SELECT 1 FROM training_sessions ts
+				WHERE ts.user_id = u.id
+					AND ts.created_at > GREATEST(u.load_checked_at, now() - interval '1 day')
+					AND ts.deleted_at IS NULL
+			)
+		ORDER BY u.load_checked_at NULLS FIRST
+		LIMIT $1`
+
+	rows, err := r.db.Query(ctx, q, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *statsRepository) MarkLoadChecked(ctx context.Context, userID string) error {
+	_, err := r.db.Exec(ctx, `UPDATE users SET load_checked_at = now() WHERE id = $1`, userID)
+	return err
+}
+
+func (r *statsRepository) ClaimLoadAlert(ctx context.Context, userID string, cooldown time.Duration) (bool, error) {
+	const q = `
+		UPDATE users
+		SET load_alerted_at = now()
+		WHERE id = $1 AND (load_alerted_at IS NULL OR load_alerted_at < now() - $2::interval)`
+
+	tag, err := r.db.Exec(ctx, q, userID, cooldown)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
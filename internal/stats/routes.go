@@ -0,0 +1,15 @@
+package stats
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the training statistics endpoints, they have the expensive quota and belong
+// to the premium plan
+func (h *StatsHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("GET /api/v1/stats/hr-zones", mw.Premium(http.HandlerFunc(h.GetHeartRateZones)))
+	mux.Handle("GET /api/v1/stats/open-water", mw.Premium(http.HandlerFunc(h.GetOpenWaterStats)))
+	mux.Handle("GET /api/v1/stats/training-load", mw.Premium(http.HandlerFunc(h.GetTrainingLoad)))
+}
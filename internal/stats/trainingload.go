@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/broker"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+const (
+	// acuteDays and chronicDays are the spans of the exponentially weighted loads
+	acuteDays   = 7
+	chronicDays = 42
+
+	// loadWarmup is how many days before the range are read so the chronic load has settled
+	loadWarmup = 2 * chronicDays
+
+	// loadCheckBatch caps the users checked for a training load alert per run
+	loadCheckBatch = 500
+)
+
+// TrainingLoadAlert is the payload of the training_load.high event
+type TrainingLoadAlert struct {
+	UserID        string  `json:"userId"`
+	Acute         float64 `json:"acute"`
+	Chronic       float64 `json:"chronic"`
+	Ratio         float64 `json:"ratio"`
+	RiskThreshold float64 `json:"riskThreshold"`
+}
+
+// GetTrainingLoad returns the daily training load of the last days, today included
+func (u *statsUsecase) GetTrainingLoad(ctx context.Context, userID string, query *TrainingLoadQuery) (*TrainingLoadResponse, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, 1-query.Days)
+
+	days, err := u.trainingLoad(ctx, userID, from, today)
+	if err != nil {
+		return nil, err
+	}
+
+	last := days[len(days)-1]
+	res := &TrainingLoadResponse{
+		From:          from.Format(time.DateOnly),
+		To:            today.Format(time.DateOnly),
+		Acute:         last.Acute,
+		Chronic:       last.Chronic,
+		Ratio:         last.Ratio,
+		RiskThreshold: u.loadCfg.RiskRatio,
+		AtRisk:        u.atRisk(&last),
+		Days:          days,
+	}
+
+	if res.Injuries, err = u.injuryOverlay(ctx, userID, from, today.AddDate(0, 0, 1)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SendLoadAlerts checks the users who swam since their last check and publishes an alert for
+// those above the risk threshold, at most once per cooldown
+func (u *statsUsecase) SendLoadAlerts(ctx context.Context) (int, error) {
+	log := logger.FromContext(ctx)
+
+	userIDs, err := u.statsRepo.ListLoadCheckDue(ctx, loadCheckBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	sent := 0
+	for _, userID := range userIDs {
+		days, err := u.trainingLoad(ctx, userID, today, today)
+		if err != nil {
+			return sent, err
+		}
+
+		if day := &days[0]; u.atRisk(day) {
+			claimed, err := u.statsRepo.ClaimLoadAlert(ctx, userID, u.loadCfg.AlertCooldown)
+			if err != nil {
+				return sent, err
+			}
+
+			if claimed {
+				alert := TrainingLoadAlert{
+					UserID:        userID,
+					Acute:         day.Acute,
+					Chronic:       day.Chronic,
+					Ratio:         *day.Ratio,
+					RiskThreshold: u.loadCfg.RiskRatio,
+				}
+				if err := u.publisher.Publish(ctx, broker.NewEvent(broker.EventTrainingLoadHigh, alert)); err != nil {
+					log.Warn("Training load alert not published", "user_id", userID, "error", err)
+				} else {
+					sent++
+				}
+			}
+		}
+
+		if err := u.statsRepo.MarkLoadChecked(ctx, userID); err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
+
+// trainingLoad returns one point per UTC day of [from, to]. The loads are exponentially weighted
+// moving averages with a decay of 2 / (span + 1), seeded with zero loadWarmup days before from.
+func (u *statsUsecase) trainingLoad(ctx context.Context, userID string, from, to time.Time) ([]TrainingLoadDayResponse, error) {
+	start := from.AddDate(0, 0, -loadWarmup)
+
+	loads, err := u.statsRepo.ListDailyLoads(ctx, userID, start, to.AddDate(0, 0, 1), u.loadCfg.DefaultRPE)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[time.Time]*DailyLoad, len(loads))
+	for i := range loads {
+		byDay[loads[i].Day.UTC()] = &loads[i]
+	}
+
+	acuteDecay := 2.0 / (acuteDays + 1)
+	chronicDecay := 2.0 / (chronicDays + 1)
+
+	var days []TrainingLoadDayResponse
+	var acute, chronic float64
+	for day := start; !day.After(to); day = day.AddDate(0, 0, 1) {
+		var load DailyLoad
+		if l, ok := byDay[day]; ok {
+			load = *l
+		}
+
+		acute += (load.Load - acute) * acuteDecay
+		chronic += (load.Load - chronic) * chronicDecay
+
+		if day.Before(from) {
+			continue
+		}
+
+		point := TrainingLoadDayResponse{
+			Date:     day.Format(time.DateOnly),
+			Sessions: load.Sessions,
+			Load:     round1(load.Load),
+			Acute:    round1(acute),
+			Chronic:  round1(chronic),
+		}
+		if point.Chronic > 0 {
+			ratio := math.Round(acute/chronic*100) / 100
+			point.Ratio = &ratio
+		}
+		days = append(days, point)
+	}
+
+	return days, nil
+}
+
+// atRisk reports whether the ratio of a day is above the threshold. Below the minimal chronic
+// load any session makes the ratio spike, new or returning swimmers are not alerted.
+func (u *statsUsecase) atRisk(day *TrainingLoadDayResponse) bool {
+	return day.Ratio != nil && *day.Ratio > u.loadCfg.RiskRatio && day.Chronic >= u.loadCfg.MinChronic
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,160 @@
+package stats
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/broker"
+)
+
+// heartRateZones are the five zones as a share of the max heart rate. Zone 1 also takes
+// laps below its lower bound and zone 5 the laps above the max.
+var heartRateZones = []struct {
+	name     string
+	min, max float64
+}{
+	{"Recovery", 0.5, 0.6},
+	{"Endurance", 0.6, 0.7},
+	{"Tempo", 0.7, 0.8},
+	{"Threshold", 0.8, 0.9},
+	{"Maximum", 0.9, 1},
+}
+
+type StatsUsecase interface {
+	GetHeartRateZones(ctx context.Context, userID string, query *HeartRateZonesQuery) (*HeartRateZonesResponse, error)
+	GetOpenWaterStats(ctx context.Context, userID string, query *OpenWaterStatsQuery) (*OpenWaterStatsResponse, error)
+	GetTrainingLoad(ctx context.Context, userID string, query *TrainingLoadQuery) (*TrainingLoadResponse, error)
+	// SendLoadAlerts publishes a training_load.high event for the users newly above the risk threshold
+	SendLoadAlerts(ctx context.Context) (int, error)
+}
+
+type statsUsecase struct {
+	statsRepo StatsRepository
+	loadCfg   config.TrainingLoadConfig
+	publisher broker.Publisher
+}
+
+func NewStatsUsecase(statsRepo StatsRepository, loadCfg config.TrainingLoadConfig, publisher broker.Publisher) StatsUsecase {
+	return &statsUsecase{statsRepo, loadCfg, publisher}
+}
+
+func (u *statsUsecase) GetHeartRateZones(ctx context.Context, userID string, query *HeartRateZonesQuery) (*HeartRateZonesResponse, error) {
+	profile, err := u.statsRepo.GetHeartRateProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &HeartRateZonesResponse{Period: query.Period, To: time.Now().UTC()}
+	res.From = periodStart(query.Period, res.To)
+
+	switch {
+	case profile.MaxHeartRate != nil:
+		res.MaxHeartRate, res.MaxHeartRateSource = *profile.MaxHeartRate, MaxHeartRatePreferences
+	case profile.AgeYears != nil && *profile.AgeYears > 0:
+		res.MaxHeartRate, res.MaxHeartRateSource = 220-*profile.AgeYears, MaxHeartRateAge
+	default:
+		return nil, ErrMaxHeartRateUnknown
+	}
+
+	laps, err := u.statsRepo.ListHeartRateLaps(ctx, userID, res.From, res.To)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := zoneBounds(res.MaxHeartRate)
+	totals := make([]int, len(heartRateZones))
+	total := 0
+
+	res.Sessions = []HeartRateSessionResponse{}
+	var times []int
+	for i, lap := range laps {
+		if i == 0 || lap.SessionID != laps[i-1].SessionID {
+			if i > 0 {
+				closeSession(&res.Sessions[len(res.Sessions)-1], times)
+			}
+			res.Sessions = append(res.Sessions, HeartRateSessionResponse{ID: lap.SessionID, CreatedAt: lap.CreatedAt})
+			times = make([]int, len(heartRateZones))
+		}
+
+		zone := zoneOf(bounds, lap.AvgHeartRate)
+		times[zone] += lap.DurationSeconds
+		totals[zone] += lap.DurationSeconds
+		total += lap.DurationSeconds
+	}
+	if len(res.Sessions) > 0 {
+		closeSession(&res.Sessions[len(res.Sessions)-1], times)
+	}
+
+	res.Zones = make([]HeartRateZoneResponse, len(heartRateZones))
+	for i, zone := range heartRateZones {
+		res.Zones[i] = HeartRateZoneResponse{
+			Zone:    i + 1,
+			Name:    zone.name,
+			MinBpm:  bounds[i],
+			MaxBpm:  bounds[i+1] - 1,
+			Seconds: totals[i],
+			Percent: percent(totals[i], total),
+		}
+	}
+	res.Zones[0].MinBpm = 0
+	res.Zones[len(res.Zones)-1].MaxBpm = res.MaxHeartRate
+
+	if res.Injuries, err = u.injuryOverlay(ctx, userID, res.From, res.To); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// periodStart returns the start of the rolling period ending at now
+func periodStart(period string, now time.Time) time.Time {
+	switch period {
+	case PeriodWeek:
+		return now.AddDate(0, 0, -7)
+	case PeriodYear:
+		return now.AddDate(-1, 0, 0)
+	default:
+		return now.AddDate(0, -1, 0)
+	}
+}
+
+// zoneBounds returns the lower bpm of every zone followed by the bpm just above zone 5
+func zoneBounds(maxHeartRate int) []int {
+	bounds := make([]int, 0, len(heartRateZones)+1)
+	for _, zone := range heartRateZones {
+		bounds = append(bounds, int(math.Round(zone.min*float64(maxHeartRate))))
+	}
+	return append(bounds, maxHeartRate+1)
+}
+
+// zoneOf returns the zone index of a heart rate
+func zoneOf(bounds []int, bpm int) int {
+	for i := len(heartRateZones) - 1; i > 0; i-- {
+		if bpm >= bounds[i] {
+			return i
+		}
+	}
+	return 0
+}
+
+// closeSession fills the zones of a session from the seconds spent in each
+func closeSession(s *HeartRateSessionResponse, times []int) {
+	for _, seconds := range times {
+		s.Seconds += seconds
+	}
+
+	s.Zones = make([]HeartRateZoneTimeResponse, len(times))
+	for i, seconds := range times {
+		s.Zones[i] = HeartRateZoneTimeResponse{Zone: i + 1, Seconds: seconds, Percent: percent(seconds, s.Seconds)}
+	}
+}
+
+// percent returns part of total as a percentage with one decimal
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
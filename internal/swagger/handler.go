@@ -1,31 +1,95 @@
 package swagger
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
-	"strings"
+	"net/url"
 
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/rizkyharahap/swimo/config"
 	"github.com/rizkyharahap/swimo/docs/swagger"
-	_ "github.com/rizkyharahap/swimo/docs/swagger"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// Paths of the generated documents
+const (
+	openAPIPath = "/swagger/openapi.json"
+	legacyPath  = "/swagger/doc.json"
+)
+
+// legacySunset is announced on the swagger 2.0 document, clients must move to openapi.json by then
+const legacySunset = "Thu, 01 Apr 2027 00:00:00 GMT"
+
 type SwaggerHandler struct {
 	cfg     *config.Config
 	Handler http.Handler
+
+	// Documents are built once at startup from the embedded spec
+	legacy  []byte // swagger 2.0
+	openAPI []byte // OpenAPI 3.0
+	doc     *openapi3.T
 }
 
-func NewSwaggerHandler(cfg *config.Config) *SwaggerHandler {
-	urlParts := strings.SplitN(cfg.HTTP.BaseURL, "://", 2)
+func NewSwaggerHandler(cfg *config.Config) (*SwaggerHandler, error) {
+	var v2 openapi2.T
+	if err := json.Unmarshal(swagger.JSON, &v2); err != nil {
+		return nil, fmt.Errorf("failed to parse the embedded swagger document: %w", err)
+	}
 
-	if len(urlParts) == 2 {
-		swagger.SwaggerInfo.Host = urlParts[1]
-		swagger.SwaggerInfo.Schemes = []string{urlParts[0]}
+	// BaseURL format is checked by config.Validate
+	if baseURL, err := url.Parse(cfg.HTTP.BaseURL); err == nil && baseURL.Host != "" {
+		v2.Host = baseURL.Host
+		v2.Schemes = []string{baseURL.Scheme}
 	} else {
 		// Fallback to default values
-		swagger.SwaggerInfo.Host = "localhost:8080"
-		swagger.SwaggerInfo.Schemes = []string{"http"}
+		v2.Host = "localhost:8080"
+		v2.Schemes = []string{"http"}
+	}
+
+	legacy, err := json.Marshal(&v2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Servers are derived from the host, schemes and base path
+	v3, err := openapi2conv.ToV3(&v2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the OpenAPI 3 document: %w", err)
 	}
 
-	return &SwaggerHandler{cfg: cfg, Handler: httpSwagger.Handler()}
+	openAPI, err := json.Marshal(v3)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SwaggerHandler{
+		cfg:     cfg,
+		Handler: httpSwagger.Handler(httpSwagger.URL(openAPIPath)),
+		legacy:  legacy,
+		openAPI: openAPI,
+		doc:     v3,
+	}, nil
+}
+
+// Document returns the served OpenAPI 3.0 document, ex: to validate requests against it
+func (h *SwaggerHandler) Document() *openapi3.T {
+	return h.doc
+}
+
+// OpenAPI serves the OpenAPI 3.0 document
+func (h *SwaggerHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(h.openAPI)
+}
+
+// Legacy serves the swagger 2.0 document, kept for older clients until the sunset date
+func (h *SwaggerHandler) Legacy(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Deprecation", "true")
+	w.Header().Set("Sunset", legacySunset)
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", openAPIPath))
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(h.legacy)
 }
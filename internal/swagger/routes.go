@@ -0,0 +1,29 @@
+package swagger
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the swagger UI and documents according to SWAGGER_MODE
+func (h *SwaggerHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	var protect func(http.Handler) http.Handler
+
+	switch h.cfg.Swagger.Mode {
+	case "disabled":
+		return
+	case "basic":
+		protect = middleware.BasicAuthMiddleware("Swimo API docs", h.cfg.Swagger.User, h.cfg.Swagger.Password)
+	case "jwt":
+		protect = mw.Protected
+	default:
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	docs := router.NewGroup(mux, protect)
+	docs.HandleFunc("GET "+openAPIPath, h.OpenAPI)
+	docs.HandleFunc("GET "+legacyPath, h.Legacy)
+	docs.Handle("/swagger/", h.Handler)
+}
@@ -0,0 +1,90 @@
+package training
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// GetSession returns a session of the user with its laps and conditions
+func (u *trainingUsecase) GetSession(ctx context.Context, userId, id string) (*TrainingSessionDetailResponse, error) {
+	trainingSession, err := u.trainingRepo.GetSessionById(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newTrainingSessionDetailResponse(trainingSession)
+	return &res, nil
+}
+
+// UpdateConditions replaces the water conditions of an open water session of the user
+func (u *trainingUsecase) UpdateConditions(ctx context.Context, userId, id string, req *TrainingConditionsRequest) (*TrainingSessionDetailResponse, error) {
+	trainingSession, err := u.trainingRepo.GetSessionById(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if trainingSession.CategoryCode != CategoryOpenWater {
+		return nil, ErrNotOpenWater
+	}
+
+	conditions := newSessionConditions(req)
+	conditions.SessionID = trainingSession.ID
+	u.fillConditions(ctx, conditions, *trainingSession.StartedAt)
+
+	if err := u.trainingRepo.SaveConditions(ctx, []*SessionConditions{conditions}); err != nil {
+		return nil, err
+	}
+	trainingSession.Conditions = conditions
+
+	res := newTrainingSessionDetailResponse(trainingSession)
+	return &res, nil
+}
+
+// fillConditions fills the measurements left empty from the weather at the location and start of
+// the session. The session is saved with what the swimmer sent when the provider fails, false is
+// returned then.
+func (u *trainingUsecase) fillConditions(ctx context.Context, c *SessionConditions, startedAt time.Time) bool {
+	if u.weather == nil || c == nil || c.Latitude == nil || c.Longitude == nil {
+		return true
+	}
+	if c.WaterTemperatureC != nil && c.WaveHeightMeters != nil && c.CurrentSpeedKmh != nil {
+		return true
+	}
+
+	w, err := u.weather.Marine(ctx, *c.Latitude, *c.Longitude, startedAt)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Water conditions not filled", "error", err)
+		return false
+	}
+
+	if c.WaterTemperatureC == nil && w.WaterTemperatureC != nil {
+		c.WaterTemperatureC = w.WaterTemperatureC
+		c.AutoFilled = true
+	}
+	if c.WaveHeightMeters == nil && w.WaveHeightMeters != nil {
+		c.WaveHeightMeters = w.WaveHeightMeters
+		c.AutoFilled = true
+	}
+	if c.CurrentSpeedKmh == nil && w.CurrentSpeedKmh != nil {
+		c.CurrentSpeedKmh = w.CurrentSpeedKmh
+		c.AutoFilled = true
+	}
+
+	return true
+}
+
+// skipFill replaces fillConditions once the provider failed during a batch
+func skipFill(context.Context, *SessionConditions, time.Time) bool { return true }
+
+// sessionConditions returns the conditions of the stored sessions, keyed by their session
+func sessionConditions(trainingSessions ...*TrainingSession) []*SessionConditions {
+	var conditions []*SessionConditions
+	for _, s := range trainingSessions {
+		if s.Conditions != nil && s.ID != "" {
+			s.Conditions.SessionID = s.ID
+			conditions = append(conditions, s.Conditions)
+		}
+	}
+	return conditions
+}
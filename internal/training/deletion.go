@@ -0,0 +1,80 @@
+package training
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// maxDeleted caps the deleted trainings or sessions listed at once
+const maxDeleted = 100
+
+// DeleteTraining removes a training from the catalog and the moderation lists, its sessions
+// keep pointing to it
+func (u *trainingUsecase) DeleteTraining(ctx context.Context, id string) error {
+	if err := u.trainingRepo.DeleteTraining(ctx, id); err != nil {
+		return err
+	}
+
+	u.invalidateTraining(ctx, id)
+	return nil
+}
+
+// RestoreTraining puts a deleted training back with the status it had
+func (u *trainingUsecase) RestoreTraining(ctx context.Context, id string) error {
+	if err := u.trainingRepo.RestoreTraining(ctx, id); err != nil {
+		return err
+	}
+
+	u.invalidateTraining(ctx, id)
+	return nil
+}
+
+// ListDeletedTrainings returns the deleted trainings of the tenant, last deleted first
+func (u *trainingUsecase) ListDeletedTrainings(ctx context.Context) ([]TrainingReviewResponse, error) {
+	trainings, err := u.trainingRepo.ListDeleted(ctx, maxDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingReviewResponses(trainings), nil
+}
+
+// DeleteSession removes a session from the history of the user, stats and exports skip it
+func (u *trainingUsecase) DeleteSession(ctx context.Context, userId, id string) error {
+	return u.trainingRepo.DeleteSession(ctx, userId, id)
+}
+
+// RestoreSession puts a deleted session back in the history of its user
+func (u *trainingUsecase) RestoreSession(ctx context.Context, id string) error {
+	return u.trainingRepo.RestoreSession(ctx, id)
+}
+
+// ListDeletedSessions returns the deleted sessions of a user, last deleted first
+func (u *trainingUsecase) ListDeletedSessions(ctx context.Context, userId string) ([]TrainingDeletedSessionResponse, error) {
+	trainingSessions, err := u.trainingRepo.ListDeletedSessions(ctx, userId, maxDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]TrainingDeletedSessionResponse, 0, len(trainingSessions))
+	for _, s := range trainingSessions {
+		res = append(res, TrainingDeletedSessionResponse{
+			TrainingSessionExportResponse: TrainingSessionExportResponse{
+				TrainingSessionResponse: *newTrainingSessionResponse(s),
+				CreatedAt:               s.CreatedAt,
+			},
+			DeletedAt: *s.DeletedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// invalidateTraining drops the cached training and lists after it left or came back to the catalog
+func (u *trainingUsecase) invalidateTraining(ctx context.Context, id string) {
+	if err := u.cache.Delete(ctx, scopedKey(ctx, cacheKeyTraining)+id); err != nil {
+		logger.FromContext(ctx).Warn("training cache invalidation failed", "error", err)
+	}
+	u.invalidateList(ctx)
+}
@@ -1,21 +1,26 @@
 package training
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/rizkyharahap/swimo/pkg/pagination"
 	"github.com/rizkyharahap/swimo/pkg/validator"
 )
 
 type TrainingRequest struct {
-	CategoryCode string `json:"categoryCode" example:"BREASTSTROKE"`
-	Level        string `json:"level" example:"beginner"`
-	Name         string `json:"name" example:"Breaststroke Basics"`
-	Descriptions string `json:"descriptions" example:"Dasar gaya dada untuk pemula"`
-	TimeLabel    string `json:"time" example:"10-15 min"`
-	CaloriesKcal int    `json:"caloriesKcal" example:"120"`
-	ThumbnailURL string `json:"thumbnailUrl" example:"https://cdn.example.com/thumbs/breaststroke.png"`
-	VideoURL     string `json:"videoUrl" example:"https://cdn.example.com/videos/breaststroke.mp4"`
-	Content      string `json:"content" example:"<p>HTML content here</p>"`
+	CategoryCode string `json:"categoryCode" validate:"required" example:"BREASTSTROKE"`
+	Level        string `json:"level" validate:"required,max=50" example:"beginner"`
+	Name         string `json:"name" validate:"required,max=100" example:"Breaststroke Basics"`
+	Descriptions string `json:"descriptions" validate:"required" example:"Dasar gaya dada untuk pemula"`
+	TimeLabel    string `json:"time" validate:"required" example:"10-15 min"`
+	CaloriesKcal int    `json:"caloriesKcal" validate:"gt=0" example:"120"`
+	ThumbnailURL string `json:"thumbnailUrl" validate:"required,url" example:"https://cdn.example.com/thumbs/breaststroke.png"`
+	VideoURL     string `json:"videoUrl" validate:"url" example:"https://cdn.example.com/videos/breaststroke.mp4"`
+	Content      string `json:"content" validate:"required" example:"<p>HTML content here</p>"`
 }
 
 type TrainingResponse struct {
@@ -30,6 +35,43 @@ type TrainingResponse struct {
 	ThumbnailURL string  `json:"thumbnailUrl" example:"https://cdn.example.com/thumbs/breaststroke.png"`
 	VideoURL     *string `json:"videoUrl" example:"https://cdn.example.com/videos/breaststroke.mp4"`
 	ContentHTML  string  `json:"content" example:"<p>HTML content here</p>"`
+	Status       string  `json:"status" example:"approved" enums:"pending_review,approved,rejected"`
+	ReviewReason *string `json:"reviewReason,omitempty" example:"The video does not match the description"`
+	Version      int     `json:"version" example:"3"` // sent back by edits, a training edited since is refused
+
+	Aggregates *TrainingAggregatesResponse `json:"aggregates,omitempty"`
+}
+
+// TrainingAggregatesResponse are the counts embedded in a training, only those asked for with include
+type TrainingAggregatesResponse struct {
+	Completions *int `json:"completions,omitempty" example:"3"` // sessions of the user on the training
+}
+
+// TrainingReviewResponse is a submitted training with its review, listed to its author and
+// in the moderation queue
+type TrainingReviewResponse struct {
+	ID           string     `json:"id" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	CategoryCode string     `json:"categoryCode" example:"BREASTSTROKE"`
+	Level        string     `json:"level" example:"beginner"`
+	Name         string     `json:"name" example:"Breaststroke Basics"`
+	Status       string     `json:"status" example:"pending_review" enums:"pending_review,approved,rejected"`
+	AuthorID     *string    `json:"authorId,omitempty" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	ReviewReason *string    `json:"reviewReason,omitempty" example:"The video does not match the description"`
+	ReviewedAt   *time.Time `json:"reviewedAt,omitempty" example:"2025-09-22T09:00:00Z"`
+	Version      int        `json:"version" example:"2"`
+	CreatedAt    time.Time  `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty" example:"2025-09-23T10:15:00Z"`
+}
+
+// TrainingApproveRequest optionally pins the version of the training reviewed
+type TrainingApproveRequest struct {
+	Version *int `json:"version,omitempty" validate:"gt=0" example:"1"`
+}
+
+// TrainingRejectRequest gives the author the reason of a rejection
+type TrainingRejectRequest struct {
+	Reason  string `json:"reason" validate:"required,max=500" example:"The video does not match the description"`
+	Version *int   `json:"version,omitempty" validate:"gt=0" example:"1"`
 }
 
 type TrainingSessionResponse struct {
@@ -40,6 +82,44 @@ type TrainingSessionResponse struct {
 	DurationSeconds int     `json:"durationSeconds" example:"1800"`
 	Pace            float64 `json:"pace" example:"1.2"`
 	CaloriesKcal    int     `json:"caloriesKcal" example:"120"`
+	RPE             *int    `json:"rpe,omitempty" example:"6"`
+	PoolID          *string `json:"poolId,omitempty" example:"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b"` // pool the swimmer was checked in at
+
+	Laps       []TrainingLapResponse       `json:"laps,omitempty"`
+	Conditions *TrainingConditionsResponse `json:"conditions,omitempty"`
+}
+
+type TrainingConditionsResponse struct {
+	WaterTemperatureC *float64  `json:"waterTemperatureC,omitempty" example:"24.5"`
+	WaveHeightMeters  *float64  `json:"waveHeightMeters,omitempty" example:"0.6"`
+	CurrentSpeedKmh   *float64  `json:"currentSpeedKmh,omitempty" example:"1.2"`
+	WaveNotes         *string   `json:"waveNotes,omitempty" example:"Choppy after the buoy"`
+	CurrentNotes      *string   `json:"currentNotes,omitempty" example:"Pulling north along the beach"`
+	Wetsuit           bool      `json:"wetsuit" example:"true"`
+	Latitude          *float64  `json:"latitude,omitempty" example:"-8.7203"`
+	Longitude         *float64  `json:"longitude,omitempty" example:"115.1689"`
+	AutoFilled        bool      `json:"autoFilled" example:"true"` // a measurement comes from the weather provider
+	UpdatedAt         time.Time `json:"updatedAt" example:"2025-09-21T08:05:00Z"`
+}
+
+// TrainingSessionExportResponse is one line of a session export
+type TrainingSessionExportResponse struct {
+	TrainingSessionResponse
+	CreatedAt time.Time `json:"createdAt" example:"2025-09-21T07:30:00Z"`
+}
+
+// TrainingDeletedSessionResponse is a deleted session, listed for admins to restore
+type TrainingDeletedSessionResponse struct {
+	TrainingSessionExportResponse
+	DeletedAt time.Time `json:"deletedAt" example:"2025-09-23T10:15:00Z"`
+}
+
+type TrainingLapResponse struct {
+	Number          int  `json:"number" example:"1"`
+	DistanceMeters  int  `json:"distanceMeters" example:"25"`
+	DurationSeconds int  `json:"durationSeconds" example:"30"`
+	StrokeCount     *int `json:"strokeCount,omitempty" example:"18"`
+	AvgHeartRate    *int `json:"avgHeartRate,omitempty" example:"142"`
 }
 
 type TrainingItemResponse struct {
@@ -51,124 +131,396 @@ type TrainingItemResponse struct {
 }
 
 type TrainingsQuery struct {
-	Page   int    `query:"page" validate:"min=1"`
-	Limit  int    `query:"limit" validate:"min=1,max=100"`
-	Sort   string `query:"sort" validate:"oneof=name.asc name.desc level.asc level.desc created_at.asc created_at.desc"`
-	Search string `query:"search"`
+	pagination.Params
+	Search   string   `query:"search"`
+	Category string   `query:"category"` // category code, ex: BREASTSTROKE
+	Level    string   `query:"level"`
+	Facets   []string `query:"facets"` // filters to count the trainings of, see trainingFacets
 }
 
+// Facets of the training list, the filters counted alongside a page with facets=category,level
+const (
+	FacetCategory = "category"
+	FacetLevel    = "level"
+)
+
+var trainingFacets = []string{FacetCategory, FacetLevel}
+
+// Aggregates embedded in a training detail with include=completions
+const (
+	IncludeCompletions = "completions"
+)
+
+var trainingIncludes = []string{IncludeCompletions}
+
+// parseOptions reads a comma separated list of the allowed values without duplicates, ex:
+// category,level. name is the parameter in the error.
+func parseOptions(raw, name string, allowed []string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var options []string
+	for _, option := range strings.Split(raw, ",") {
+		option = strings.TrimSpace(option)
+		if !slices.Contains(allowed, option) {
+			return nil, errors.New(name + " must be one of: " + strings.Join(allowed, ", "))
+		}
+		if !slices.Contains(options, option) {
+			options = append(options, option)
+		}
+	}
+	return options, nil
+}
+
+// trainingSorts whitelists the sortable training list columns
+var trainingSorts = pagination.SortSpec{
+	Columns: map[string]string{
+		"name":       "name",
+		"level":      "level",
+		"created_at": "created_at",
+	},
+	Default:    "created_at.desc",
+	TieBreaker: "id",
+}
+
+// Laps and imported sessions are capped to keep a single request within one body and transaction
 type TrainingFinishSessionRequest struct {
-	DistanceMeters  int `json:"distanceMeters" example:"300"`
-	DurationSeconds int `json:"durationSeconds" example:"50"`
+	DistanceMeters   int                        `json:"distanceMeters" validate:"gt=0" example:"300"`
+	DurationSeconds  int                        `json:"durationSeconds" validate:"gt=0" example:"50"`
+	RPE              *int                       `json:"rpe,omitempty" validate:"min=1,max=10" example:"6"` // rate of perceived exertion
+	Laps             []TrainingLapRequest       `json:"laps,omitempty" validate:"max=1000"`
+	Conditions       *TrainingConditionsRequest `json:"conditions,omitempty"`
+	PoolLengthMeters *int                       `json:"poolLengthMeters,omitempty" validate:"min=10,max=100" example:"25"` // the distance must be a whole number of lengths
+	Override         bool                       `json:"override,omitempty" example:"false"`                                // records a session failing the plausibility checks, admins only
 }
 
-func trim(s string) string {
-	return strings.TrimSpace(s)
+// TrainingConditionsRequest records the water conditions of an open water session. With a
+// location, the measurements left empty are filled from the weather at the start of the session.
+type TrainingConditionsRequest struct {
+	WaterTemperatureC *float64 `json:"waterTemperatureC,omitempty" validate:"min=-2,max=40" example:"24.5"`
+	WaveHeightMeters  *float64 `json:"waveHeightMeters,omitempty" validate:"min=0,max=20" example:"0.6"`
+	CurrentSpeedKmh   *float64 `json:"currentSpeedKmh,omitempty" validate:"min=0,max=20" example:"1.2"`
+	WaveNotes         *string  `json:"waveNotes,omitempty" validate:"max=500" example:"Choppy after the buoy"`
+	CurrentNotes      *string  `json:"currentNotes,omitempty" validate:"max=500" example:"Pulling north along the beach"`
+	Wetsuit           bool     `json:"wetsuit" example:"true"`
+	Latitude          *float64 `json:"latitude,omitempty" validate:"min=-90,max=90" example:"-8.7203"`
+	Longitude         *float64 `json:"longitude,omitempty" validate:"min=-180,max=180" example:"115.1689"`
 }
 
-func (q *TrainingsQuery) Validate() *validator.ValidationError {
-	errors := make(map[string]string)
+type TrainingLapRequest struct {
+	DistanceMeters  int  `json:"distanceMeters" validate:"gt=0" example:"25"`
+	DurationSeconds int  `json:"durationSeconds" validate:"gt=0" example:"30"`
+	StrokeCount     *int `json:"strokeCount,omitempty" validate:"min=0" example:"18"`
+	AvgHeartRate    *int `json:"avgHeartRate,omitempty" validate:"min=30,max=250" example:"142"`
+}
 
-	if q.Page < 1 {
-		errors["page"] = "Page must be at least 1"
-	}
+type TrainingImportSessionRequest struct {
+	TrainingID      string               `json:"trainingId" validate:"required,uuid" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	StartedAt       time.Time            `json:"startedAt" validate:"required" example:"2025-09-21T07:30:00Z"`
+	DistanceMeters  int                  `json:"distanceMeters" validate:"gt=0" example:"1500"`
+	DurationSeconds int                  `json:"durationSeconds" validate:"gt=0" example:"1800"`
+	RPE             *int                 `json:"rpe,omitempty" validate:"min=1,max=10" example:"6"`
+	Laps            []TrainingLapRequest `json:"laps,omitempty" validate:"max=1000"`
+
+	Conditions *TrainingConditionsRequest `json:"conditions,omitempty"`
+}
+
+type TrainingImportSessionsRequest struct {
+	Sessions []TrainingImportSessionRequest `json:"sessions" validate:"required,max=500"`
+	Source   string                         `json:"source,omitempty" validate:"oneof=watch google_fit apple_health" example:"watch"`
+}
+
+// TrainingSyncSessionsRequest carries sessions recorded offline. Items are validated one by one,
+// an invalid item is rejected in its result without failing the batch.
+type TrainingSyncSessionsRequest struct {
+	Sessions []TrainingSyncSessionRequest `json:"sessions"`
+}
+
+type TrainingSyncSessionRequest struct {
+	ClientID        string               `json:"clientId" validate:"required,uuid" example:"0f8e7d6c-5b4a-4392-8a1b-2c3d4e5f6a7b"`
+	TrainingID      string               `json:"trainingId" validate:"required,uuid" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+	StartedAt       time.Time            `json:"startedAt" validate:"required" example:"2025-09-21T07:30:00Z"`
+	UpdatedAt       time.Time            `json:"updatedAt" validate:"required" example:"2025-09-21T08:05:00Z"`
+	DistanceMeters  int                  `json:"distanceMeters" validate:"gt=0" example:"1500"`
+	DurationSeconds int                  `json:"durationSeconds" validate:"gt=0" example:"1800"`
+	Laps            []TrainingLapRequest `json:"laps,omitempty" validate:"max=1000"`
+}
+
+// TrainingSyncSessionsResponse maps every client id of the batch to its result
+type TrainingSyncSessionsResponse struct {
+	Results            map[string]TrainingSyncResult `json:"results"`
+	PossibleDuplicates int                           `json:"possibleDuplicates" example:"0"`
+}
+
+// TrainingSyncResult is the outcome of one synced session. Session is the state kept by the
+// server, Conflicts lists the fields where it differs from the item sent.
+type TrainingSyncResult struct {
+	Status    string                   `json:"status" example:"updated" enums:"created,updated,unchanged,rejected"`
+	Session   *TrainingSessionResponse `json:"session,omitempty"`
+	Conflicts []string                 `json:"conflicts,omitempty" example:"trainingId"`
+	Errors    map[string]string        `json:"errors,omitempty"`
+}
+
+// TrainingExportFileResponse links to a session export stored as NDJSON
+type TrainingExportFileResponse struct {
+	URL       string    `json:"url" example:"https://swimo-files.s3.amazonaws.com/exports/a1b2c3d4-e5f6-7890-1234-567890abcdef/sessions-20250921T073000Z.ndjson?X-Amz-Signature=..."`
+	ExpiresAt time.Time `json:"expiresAt" example:"2025-09-21T07:45:00Z"`
+}
+
+type TrainingImportSessionsResponse struct {
+	Imported           int                       `json:"imported" example:"2"`
+	Sessions           []TrainingSessionResponse `json:"sessions"`
+	PossibleDuplicates int                       `json:"possibleDuplicates" example:"1"`
+}
+
+// TrainingDuplicateResponse pairs a session with an overlapping one stored before it, both
+// likely recording the same swim
+type TrainingDuplicateResponse struct {
+	ID             string                        `json:"id" example:"3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a"`
+	OverlapSeconds int                           `json:"overlapSeconds" example:"1740"`
+	DetectedAt     time.Time                     `json:"detectedAt" example:"2025-09-21T09:00:00Z"`
+	Session        TrainingSessionDetailResponse `json:"session"`
+	DuplicateOf    TrainingSessionDetailResponse `json:"duplicateOf"`
+}
+
+// TrainingSessionDetailResponse is a session with where and when it was recorded
+type TrainingSessionDetailResponse struct {
+	TrainingSessionResponse
+	Source    string    `json:"source" example:"watch" enums:"manual,import,watch,google_fit,apple_health,sync"`
+	StartedAt time.Time `json:"startedAt" example:"2025-09-21T07:30:00Z"`
+}
 
-	if q.Limit < 1 {
-		errors["limit"] = "Limit must be at least 1"
-	} else if q.Limit > 100 {
-		errors["limit"] = "Limit must not exceed 100"
+// TrainingMergeDuplicateRequest picks the session kept by a merge, the one stored first by default
+type TrainingMergeDuplicateRequest struct {
+	KeepID string `json:"keepId,omitempty" validate:"uuid" example:"8c4a2d27-56e2-4ef3-8a6e-43b812345abc"`
+}
+
+func (r *TrainingRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
 	}
+	return nil
+}
 
-	validSorts := map[string]bool{
-		"name.asc": true, "name.desc": true,
-		"level.asc": true, "level.desc": true,
-		"created_at.asc": true, "created_at.desc": true,
+func (r *TrainingFinishSessionRequest) Validate() error {
+	err := validator.Struct(r)
+	if err == nil {
+		err = &validator.ValidationError{Errors: make(map[string]string)}
 	}
-	if q.Sort != "" && !validSorts[q.Sort] {
-		errors["sort"] = "Sort must be one of: name.asc, name.desc, level.asc, level.desc, created_at.asc, created_at.desc"
+
+	validateLocation(r.Conditions, "conditions", err.Errors)
+
+	if len(err.Errors) > 0 {
+		return err
 	}
+	return nil
+}
 
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
+func (r *TrainingConditionsRequest) Validate() error {
+	err := validator.Struct(r)
+	if err == nil {
+		err = &validator.ValidationError{Errors: make(map[string]string)}
 	}
 
+	validateLocation(r, "", err.Errors)
+
+	if len(err.Errors) > 0 {
+		return err
+	}
 	return nil
 }
 
-func (r *TrainingRequest) Validate() error {
-	errors := make(map[string]string)
+// validateLocation requires the coordinates of the conditions as a pair
+func validateLocation(c *TrainingConditionsRequest, prefix string, errors map[string]string) {
+	if c == nil || (c.Latitude == nil) == (c.Longitude == nil) {
+		return
+	}
 
-	r.CategoryCode = trim(r.CategoryCode)
-	if r.CategoryCode == "" {
-		errors["categoryCode"] = "CategoryCode is required"
+	field := "longitude"
+	if c.Latitude == nil {
+		field = "latitude"
+	}
+	if prefix != "" {
+		field = prefix + "." + field
 	}
 
-	r.Level = trim(r.Level)
-	if r.Level == "" {
-		errors["level"] = "Level is required"
-	} else if len(r.Level) > 50 {
-		errors["level"] = "Level must not exceed 50 characters"
+	if _, ok := errors[field]; !ok {
+		errors[field] = "Latitude and longitude must be sent together"
 	}
+}
 
-	r.Name = trim(r.Name)
-	if r.Name == "" {
-		errors["name"] = "Name is required"
-	} else if len(r.Name) > 100 {
-		errors["name"] = "Name must not exceed 100 characters"
+func (r *TrainingImportSessionsRequest) Validate() error {
+	err := validator.Struct(r)
+	if err == nil {
+		err = &validator.ValidationError{Errors: make(map[string]string)}
 	}
 
-	r.Descriptions = trim(r.Descriptions)
-	if r.Descriptions == "" {
-		errors["descriptions"] = "Descriptions is required"
+	// Tags can't express a moving bound, start times are checked against the clock here
+	for i, s := range r.Sessions {
+		field := fmt.Sprintf("sessions[%d].startedAt", i)
+		if _, ok := err.Errors[field]; !ok && s.StartedAt.After(time.Now().Add(time.Minute)) {
+			err.Errors[field] = "Started at must not be in the future"
+		}
+
+		validateLocation(s.Conditions, fmt.Sprintf("sessions[%d].conditions", i), err.Errors)
 	}
 
-	r.TimeLabel = trim(r.TimeLabel)
-	if r.TimeLabel == "" {
-		errors["timeLabel"] = "TimeLabel is required"
+	if len(err.Errors) > 0 {
+		return err
 	}
+	return nil
+}
 
-	if r.CaloriesKcal <= 0 {
-		errors["caloriesKcal"] = "CaloriesKcal must be a positive integer"
+func (r *TrainingApproveRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
 	}
+	return nil
+}
 
-	r.ThumbnailURL = trim(r.ThumbnailURL)
-	if r.ThumbnailURL == "" {
-		errors["thumbnailUrl"] = "ThumbnailURL is required"
-	} else if !validator.IsValidURL(r.ThumbnailURL) {
-		errors["thumbnailUrl"] = "ThumbnailURL is not a valid URL"
+func (r *TrainingRejectRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
 	}
+	return nil
+}
 
-	r.VideoURL = trim(r.VideoURL)
-	if r.VideoURL != "" && !validator.IsValidURL(r.VideoURL) {
-		errors["videoUrl"] = "VideoURL is not a valid URL"
+func (r *TrainingMergeDuplicateRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
 	}
+	return nil
+}
 
-	r.Content = trim(r.Content)
-	if r.Content == "" {
-		errors["content"] = "Content is required"
+func (r *TrainingSyncSessionsRequest) Validate() error {
+	switch {
+	case len(r.Sessions) == 0:
+		return &validator.ValidationError{Errors: map[string]string{"sessions": "Sessions is required"}}
+	case len(r.Sessions) > maxSyncSessions:
+		return &validator.ValidationError{Errors: map[string]string{"sessions": fmt.Sprintf("Sessions must not exceed %d items", maxSyncSessions)}}
 	}
+	return nil
+}
 
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
+func (r *TrainingSyncSessionRequest) Validate() error {
+	err := validator.Struct(r)
+	if err == nil {
+		err = &validator.ValidationError{Errors: make(map[string]string)}
 	}
 
+	if _, ok := err.Errors["startedAt"]; !ok && r.StartedAt.After(time.Now().Add(time.Minute)) {
+		err.Errors["startedAt"] = "Started at must not be in the future"
+	}
+
+	if len(err.Errors) > 0 {
+		return err
+	}
 	return nil
 }
 
-func (r *TrainingFinishSessionRequest) Validate() error {
-	errors := make(map[string]string)
+// newTrainingLaps numbers laps in the order they were recorded
+func newTrainingLaps(laps []TrainingLapRequest) []TrainingLap {
+	if len(laps) == 0 {
+		return nil
+	}
 
-	if r.DistanceMeters <= 0 {
-		errors["distanceMeters"] = "DistanceMeteres must be a positive integer"
+	res := make([]TrainingLap, len(laps))
+	for i, lap := range laps {
+		res[i] = TrainingLap{
+			Number:          i + 1,
+			DistanceMeters:  lap.DistanceMeters,
+			DurationSeconds: lap.DurationSeconds,
+			StrokeCount:     lap.StrokeCount,
+			AvgHeartRate:    lap.AvgHeartRate,
+		}
 	}
+	return res
+}
 
-	if r.DurationSeconds <= 0 {
-		errors["timeLabel"] = "TimeLabel must be a positive integer"
+func newTrainingSessionResponse(s *TrainingSession) *TrainingSessionResponse {
+	res := &TrainingSessionResponse{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		TrainingID:      s.TrainingID,
+		DistanceMeters:  s.DistanceMeters,
+		DurationSeconds: s.DurationSeconds,
+		Pace:            s.Pace,
+		CaloriesKcal:    s.CaloriesKcal,
+		RPE:             s.RPE,
+		PoolID:          s.PoolID,
 	}
 
-	if len(errors) > 0 {
-		return &validator.ValidationError{Errors: errors}
+	for _, lap := range s.Laps {
+		res.Laps = append(res.Laps, TrainingLapResponse(lap))
 	}
 
-	return nil
+	if c := s.Conditions; c != nil {
+		res.Conditions = &TrainingConditionsResponse{
+			WaterTemperatureC: c.WaterTemperatureC,
+			WaveHeightMeters:  c.WaveHeightMeters,
+			CurrentSpeedKmh:   c.CurrentSpeedKmh,
+			WaveNotes:         c.WaveNotes,
+			CurrentNotes:      c.CurrentNotes,
+			Wetsuit:           c.Wetsuit,
+			Latitude:          c.Latitude,
+			Longitude:         c.Longitude,
+			AutoFilled:        c.AutoFilled,
+			UpdatedAt:         c.UpdatedAt,
+		}
+	}
+
+	return res
+}
+
+// newSessionConditions copies the conditions sent by the swimmer, nil when none were sent
+func newSessionConditions(req *TrainingConditionsRequest) *SessionConditions {
+	if req == nil {
+		return nil
+	}
+
+	return &SessionConditions{
+		WaterTemperatureC: req.WaterTemperatureC,
+		WaveHeightMeters:  req.WaveHeightMeters,
+		CurrentSpeedKmh:   req.CurrentSpeedKmh,
+		WaveNotes:         req.WaveNotes,
+		CurrentNotes:      req.CurrentNotes,
+		Wetsuit:           req.Wetsuit,
+		Latitude:          req.Latitude,
+		Longitude:         req.Longitude,
+	}
+}
+
+func newTrainingReviewResponse(t *Training) TrainingReviewResponse {
+	return TrainingReviewResponse{
+		ID:           t.ID,
+		CategoryCode: t.CategoryCode,
+		Level:        t.Level,
+		Name:         t.Name,
+		Status:       t.Status,
+		AuthorID:     t.AuthorUserID,
+		ReviewReason: t.ReviewReason,
+		ReviewedAt:   t.ReviewedAt,
+		Version:      t.Version,
+		CreatedAt:    t.CreatedAt,
+		DeletedAt:    t.DeletedAt,
+	}
+}
+
+func newTrainingDuplicateResponse(d *SessionDuplicate) TrainingDuplicateResponse {
+	return TrainingDuplicateResponse{
+		ID:             d.ID,
+		OverlapSeconds: d.OverlapSeconds,
+		DetectedAt:     d.DetectedAt,
+		Session:        newTrainingSessionDetailResponse(&d.Session),
+		DuplicateOf:    newTrainingSessionDetailResponse(&d.DuplicateOf),
+	}
+}
+
+func newTrainingSessionDetailResponse(s *TrainingSession) TrainingSessionDetailResponse {
+	return TrainingSessionDetailResponse{
+		TrainingSessionResponse: *newTrainingSessionResponse(s),
+		Source:                  s.Source,
+		StartedAt:               *s.StartedAt,
+	}
 }
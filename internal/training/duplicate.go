@@ -0,0 +1,66 @@
+package training
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+)
+
+// maxDuplicates caps the pending duplicates listed at once, merging or dismissing reveals the rest
+const maxDuplicates = 100
+
+// ListDuplicates returns the pairs of sessions of the user that likely record the same swim
+func (u *trainingUsecase) ListDuplicates(ctx context.Context, userId string) ([]TrainingDuplicateResponse, error) {
+	duplicates, err := u.trainingRepo.ListDuplicates(ctx, userId, maxDuplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]TrainingDuplicateResponse, 0, len(duplicates))
+	for _, d := range duplicates {
+		res = append(res, newTrainingDuplicateResponse(d))
+	}
+
+	return res, nil
+}
+
+// MergeDuplicate keeps one session of the pair and deletes the other, the kept session takes the
+// laps, conditions, client id and training of the deleted one where it has none
+func (u *trainingUsecase) MergeDuplicate(ctx context.Context, userId, id string, req *TrainingMergeDuplicateRequest) error {
+	return database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.trainingRepo.WithTx(tx)
+
+		d, err := repo.GetDuplicate(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+
+		keep, drop := &d.DuplicateOf, &d.Session
+		switch req.KeepID {
+		case "", keep.ID:
+		case drop.ID:
+			keep, drop = drop, keep
+		default:
+			return ErrSessionNotInDuplicate
+		}
+
+		return repo.MergeSessions(ctx, keep, drop)
+	})
+}
+
+// DismissDuplicate marks the pair as distinct sessions, it is not flagged again
+func (u *trainingUsecase) DismissDuplicate(ctx context.Context, userId, id string) error {
+	return u.trainingRepo.DismissDuplicate(ctx, userId, id)
+}
+
+// sessionIDs returns the IDs of the stored sessions
+func sessionIDs(trainingSessions []*TrainingSession) []string {
+	ids := make([]string, 0, len(trainingSessions))
+	for _, s := range trainingSessions {
+		if s.ID != "" {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
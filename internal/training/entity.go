@@ -3,12 +3,56 @@ package training
 import (
 	"errors"
 	"math"
+	"time"
 )
 
 var (
 	ErrInvalidCreds = errors.New("invalid email or passwords")
+	ErrMediaType    = errors.New("unsupported training media type")
+
+	ErrSessionNotFound          = errors.New("training session not found")
+	ErrNotOpenWater             = errors.New("conditions on a session that is not open water")
+	ErrSessionDuplicateNotFound = errors.New("session duplicate not found")
+	ErrSessionNotInDuplicate    = errors.New("session is not part of the duplicate")
+	ErrGuestSubmission          = errors.New("guests cannot submit trainings")
+	ErrTrainingNotPending       = errors.New("training is not pending review")
+	ErrNotTrainingAuthor        = errors.New("training changed by someone else than its author")
+)
+
+// CategoryOpenWater is the code of the training category recording water conditions
+const CategoryOpenWater = "OPEN_WATER"
+
+// Review statuses of a training, only approved trainings are listed in the catalog
+const (
+	StatusPendingReview = "pending_review"
+	StatusApproved      = "approved"
+	StatusRejected      = "rejected"
 )
 
+// Sources a session is recorded from
+const (
+	SourceManual      = "manual" // finished in the app, created at is the end of the session
+	SourceImport      = "import"
+	SourceWatch       = "watch"
+	SourceGoogleFit   = "google_fit"
+	SourceAppleHealth = "apple_health"
+	SourceSync        = "sync"
+)
+
+// mediaTypes maps the accepted content types of each training media field to their file extension
+var mediaTypes = map[string]map[string]string{
+	"thumbnail": {"image/jpeg": ".jpg", "image/png": ".png", "image/webp": ".webp"},
+	"video":     {"video/mp4": ".mp4", "video/webm": ".webm", "video/quicktime": ".mov"},
+}
+
+// FacetCount is the number of listed trainings with a value of a facet, Label is empty when the
+// value reads as is, ex: a level
+type FacetCount struct {
+	Value string
+	Label string
+	Count int
+}
+
 type TrainingCategory struct {
 	ID          string
 	Code        string
@@ -29,6 +73,25 @@ type Training struct {
 	ThumbnailURL string
 	VideoURL     *string
 	ContentHTML  string
+
+	Status       string
+	AuthorUserID *string // user who submitted the training, nil for the seeded catalog
+	ReviewReason *string // why the training was rejected
+	ReviewedAt   *time.Time
+	Version      int // bumped by every edit, see database.StaleVersionError
+	CreatedAt    time.Time
+	DeletedAt    *time.Time // only read by the moderation lists
+
+	Completions *int // sessions of a user on the training, only when asked for
+}
+
+// TrainingReviewedEvent is the payload of the training.reviewed event, sent to the author
+type TrainingReviewedEvent struct {
+	TrainingID string  `json:"trainingId"`
+	AuthorID   string  `json:"authorId"`
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Reason     *string `json:"reason,omitempty"`
 }
 
 type TrainingSession struct {
@@ -39,6 +102,52 @@ type TrainingSession struct {
 	DurationSeconds int
 	Pace            float64
 	CaloriesKcal    int
+	RPE             *int       // rate of perceived exertion, 1 to 10, weighs the training load
+	StartedAt       *time.Time // set for imported sessions, nil means now
+	CreatedAt       time.Time
+	Source          string // set for imported and synced sessions, the database defaults to manual
+	CategoryCode    string // code of the training category, only read by GetSessionById
+	Laps            []TrainingLap
+	Conditions      *SessionConditions // open water sessions only
+	PoolID          *string            // pool the swimmer was checked in at when the session finished, not read by lists
+
+	ClientID        *string    // generated by the app for sessions recorded offline
+	ClientUpdatedAt *time.Time // last edit on the device, orders latest wins fields
+	DeletedAt       *time.Time // only read by ListDeletedSessions
+}
+
+type TrainingLap struct {
+	Number          int
+	DistanceMeters  int
+	DurationSeconds int
+	StrokeCount     *int
+	AvgHeartRate    *int // bpm
+}
+
+// SessionConditions are the water conditions of an open water session. Measurements the
+// swimmer left empty are filled from the weather provider when the location is known.
+type SessionConditions struct {
+	SessionID         string
+	WaterTemperatureC *float64
+	WaveHeightMeters  *float64
+	CurrentSpeedKmh   *float64
+	WaveNotes         *string
+	CurrentNotes      *string
+	Wetsuit           bool
+	Latitude          *float64
+	Longitude         *float64
+	AutoFilled        bool // at least one measurement comes from the weather provider
+	UpdatedAt         time.Time
+}
+
+// SessionDuplicate pairs a session with one of the user stored before it and overlapping it in
+// time, likely the same swim recorded from two sources
+type SessionDuplicate struct {
+	ID             string
+	Session        TrainingSession
+	DuplicateOf    TrainingSession
+	OverlapSeconds int
+	DetectedAt     time.Time
 }
 
 type TrainingItem struct {
@@ -51,20 +160,37 @@ type TrainingItem struct {
 }
 
 func NewTrainingSession(userID string, trainingID string, distanceMeters int, durationSeconds int, bmr float64, met float32) *TrainingSession {
-	durationSecondsFloat := float64(durationSeconds)
-	paceMinPer100m := (durationSecondsFloat / float64(distanceMeters)) * (100.0 / 60.0)
-	durationHours := durationSecondsFloat / 3600.0
+	durationHours := float64(durationSeconds) / 3600.0
 
 	return &TrainingSession{
 		UserID:          userID,
 		TrainingID:      trainingID,
 		DistanceMeters:  distanceMeters,
 		DurationSeconds: durationSeconds,
-		Pace:            paceMinPer100m,
+		Pace:            calculatePace(distanceMeters, durationSeconds),
 		CaloriesKcal:    calculateCalories(bmr, float64(met), durationHours),
 	}
 }
 
+// Bounds no swimmer exceeds, the 100m freestyle world record is about 2.1 m/s
+const (
+	maxSwimSpeedMps   = 2.5
+	maxSessionMeters  = 50_000
+	maxSessionSeconds = 24 * 60 * 60
+)
+
+// ImpossibleSwim reports whether nobody can swim the distance in the duration
+func ImpossibleSwim(distanceMeters int, durationSeconds int) bool {
+	return distanceMeters > maxSessionMeters ||
+		durationSeconds > maxSessionSeconds ||
+		float64(distanceMeters)/float64(durationSeconds) > maxSwimSpeedMps
+}
+
+// calculatePace returns the pace in minutes per 100m
+func calculatePace(distanceMeters int, durationSeconds int) float64 {
+	return (float64(durationSeconds) / float64(distanceMeters)) * (100.0 / 60.0)
+}
+
 func calculateCalories(bmr float64, met float64, durationHours float64) int {
 	bmrPerHour := bmr / 24.0
 	calories := met * bmrPerHour * durationHours
@@ -0,0 +1,284 @@
+package training
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+	swimov1 "github.com/rizkyharahap/swimo/proto/swimo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TrainingGRPCServer serves the training usecase over gRPC.
+// Errors are converted to statuses by the grpcserver interceptors.
+type TrainingGRPCServer struct {
+	swimov1.UnimplementedTrainingServiceServer
+
+	trainingUseCase TrainingUsecase
+}
+
+func NewTrainingGRPCServer(trainingUseCase TrainingUsecase) *TrainingGRPCServer {
+	return &TrainingGRPCServer{trainingUseCase: trainingUseCase}
+}
+
+func (s *TrainingGRPCServer) RegisterGRPC(server *grpc.Server) {
+	swimov1.RegisterTrainingServiceServer(server, s)
+}
+
+func (s *TrainingGRPCServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return swimov1.RegisterTrainingServiceHandler(ctx, mux, conn)
+}
+
+func (s *TrainingGRPCServer) GetTraining(ctx context.Context, in *swimov1.GetTrainingRequest) (*swimov1.Training, error) {
+	if !validator.IsValidUUID(in.GetId()) {
+		return nil, &validator.ValidationError{Errors: map[string]string{"id": "ID is not a valid ID"}}
+	}
+
+	training, err := s.trainingUseCase.GetById(ctx, middleware.AuthFromContext(ctx), in.GetId(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingProto(training), nil
+}
+
+func (s *TrainingGRPCServer) ListTrainings(ctx context.Context, in *swimov1.ListTrainingsRequest) (*swimov1.ListTrainingsResponse, error) {
+	// Same parsing and limits as the REST query string
+	values := url.Values{}
+	if in.GetPage() != 0 {
+		values.Set("page", strconv.Itoa(int(in.GetPage())))
+	}
+	if in.GetLimit() != 0 {
+		values.Set("limit", strconv.Itoa(int(in.GetLimit())))
+	}
+	values.Set("sort", in.GetSort())
+
+	params, verr := pagination.Parse(values, pagination.Options{Sorts: trainingSorts, Count: pagination.CountEstimate})
+	if verr != nil {
+		return nil, verr
+	}
+
+	query := TrainingsQuery{Params: params, Search: in.GetSearch()}
+
+	items, total, err := s.trainingUseCase.GetTrainings(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := query.Response(total)
+	res := &swimov1.ListTrainingsResponse{
+		Pagination: &swimov1.Pagination{
+			Page:           int32(page.Page),
+			Limit:          int32(page.Limit),
+			TotalPages:     int32(page.TotalPages),
+			TotalItems:     int64(page.TotalItems),
+			TotalEstimated: page.TotalEstimated,
+		},
+	}
+	for _, item := range items {
+		res.Trainings = append(res.Trainings, &swimov1.TrainingItem{
+			Id:           item.ID,
+			Level:        item.Level,
+			Name:         item.Name,
+			Descriptions: item.Descriptions,
+			ThumbnailUrl: item.ThumbnailURL,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *TrainingGRPCServer) CreateTraining(ctx context.Context, in *swimov1.CreateTrainingRequest) (*swimov1.Training, error) {
+	req := TrainingRequest{
+		CategoryCode: in.GetCategoryCode(),
+		Level:        in.GetLevel(),
+		Name:         in.GetName(),
+		Descriptions: in.GetDescriptions(),
+		TimeLabel:    in.GetTimeLabel(),
+		CaloriesKcal: int(in.GetCaloriesKcal()),
+		ThumbnailURL: in.GetThumbnailUrl(),
+		VideoURL:     in.GetVideoUrl(),
+		Content:      in.GetContent(),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	training, err := s.trainingUseCase.CreateTraining(ctx, middleware.AuthFromContext(ctx), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingProto(training), nil
+}
+
+func (s *TrainingGRPCServer) GetLastSession(ctx context.Context, in *swimov1.GetLastSessionRequest) (*swimov1.TrainingSession, error) {
+	claim := middleware.AuthFromContext(ctx)
+
+	session, err := s.trainingUseCase.GetLastSession(ctx, *claim.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingSessionProto(session), nil
+}
+
+func (s *TrainingGRPCServer) FinishSession(ctx context.Context, in *swimov1.FinishSessionRequest) (*swimov1.TrainingSession, error) {
+	if !validator.IsValidUUID(in.GetId()) {
+		return nil, &validator.ValidationError{Errors: map[string]string{"id": "ID is not a valid ID"}}
+	}
+
+	req := TrainingFinishSessionRequest{
+		DistanceMeters:  int(in.GetDistanceMeters()),
+		DurationSeconds: int(in.GetDurationSeconds()),
+		Laps:            newTrainingLapRequests(in.GetLaps()),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	claim := middleware.AuthFromContext(ctx)
+
+	session, err := s.trainingUseCase.FinishSession(ctx, claim, in.GetId(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingSessionProto(session), nil
+}
+
+func (s *TrainingGRPCServer) ImportSessions(ctx context.Context, in *swimov1.ImportSessionsRequest) (*swimov1.ImportSessionsResponse, error) {
+	req := NewImportSessionsRequest(in)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	claim := middleware.AuthFromContext(ctx)
+
+	imported, err := s.trainingUseCase.ImportSessions(ctx, *claim.Uid, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &swimov1.ImportSessionsResponse{Imported: int32(imported.Imported)}
+	for i := range imported.Sessions {
+		res.Sessions = append(res.Sessions, newTrainingSessionProto(&imported.Sessions[i]))
+	}
+
+	return res, nil
+}
+
+func (s *TrainingGRPCServer) ExportSessions(in *swimov1.ExportSessionsRequest, stream grpc.ServerStreamingServer[swimov1.TrainingSession]) error {
+	ctx := stream.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	return s.trainingUseCase.ExportSessions(ctx, *claim.Uid, func(session *TrainingSessionExportResponse) error {
+		msg := newTrainingSessionProto(&session.TrainingSessionResponse)
+		msg.CreatedAt = timestamppb.New(session.CreatedAt)
+		return stream.Send(msg)
+	})
+}
+
+// NewImportSessionsRequest converts an import message, also sent as binary payload by devices
+func NewImportSessionsRequest(in *swimov1.ImportSessionsRequest) *TrainingImportSessionsRequest {
+	var req TrainingImportSessionsRequest
+	for _, session := range in.GetSessions() {
+		var startedAt time.Time
+		if session.GetStartedAt() != nil {
+			startedAt = session.GetStartedAt().AsTime()
+		}
+
+		req.Sessions = append(req.Sessions, TrainingImportSessionRequest{
+			TrainingID:      session.GetTrainingId(),
+			StartedAt:       startedAt,
+			DistanceMeters:  int(session.GetDistanceMeters()),
+			DurationSeconds: int(session.GetDurationSeconds()),
+			Laps:            newTrainingLapRequests(session.GetLaps()),
+		})
+	}
+	return &req
+}
+
+func newTrainingLapRequests(laps []*swimov1.TrainingLapInput) []TrainingLapRequest {
+	var res []TrainingLapRequest
+	for _, lap := range laps {
+		req := TrainingLapRequest{
+			DistanceMeters:  int(lap.GetDistanceMeters()),
+			DurationSeconds: int(lap.GetDurationSeconds()),
+		}
+		if lap.StrokeCount != nil {
+			strokeCount := int(lap.GetStrokeCount())
+			req.StrokeCount = &strokeCount
+		}
+		if lap.AvgHeartRate != nil {
+			avgHeartRate := int(lap.GetAvgHeartRate())
+			req.AvgHeartRate = &avgHeartRate
+		}
+		res = append(res, req)
+	}
+	return res
+}
+
+func newTrainingProto(t *TrainingResponse) *swimov1.Training {
+	return &swimov1.Training{
+		Id:           t.ID,
+		CategoryCode: t.CategoryCode,
+		CategoryName: t.CategoryName,
+		Level:        t.Level,
+		Name:         t.Name,
+		Descriptions: t.Descriptions,
+		TimeLabel:    t.TimeLabel,
+		CaloriesKcal: int32(t.CaloriesKcal),
+		ThumbnailUrl: t.ThumbnailURL,
+		VideoUrl:     t.VideoURL,
+		Content:      t.ContentHTML,
+	}
+}
+
+func newTrainingSessionProto(s *TrainingSessionResponse) *swimov1.TrainingSession {
+	res := &swimov1.TrainingSession{
+		Id:              s.ID,
+		UserId:          s.UserID,
+		TrainingId:      s.TrainingID,
+		DistanceMeters:  int32(s.DistanceMeters),
+		DurationSeconds: int32(s.DurationSeconds),
+		Pace:            s.Pace,
+		CaloriesKcal:    int32(s.CaloriesKcal),
+	}
+
+	for _, lap := range s.Laps {
+		msg := &swimov1.TrainingLap{
+			Number:          int32(lap.Number),
+			DistanceMeters:  int32(lap.DistanceMeters),
+			DurationSeconds: int32(lap.DurationSeconds),
+		}
+		if lap.StrokeCount != nil {
+			strokeCount := int32(*lap.StrokeCount)
+			msg.StrokeCount = &strokeCount
+		}
+		if lap.AvgHeartRate != nil {
+			avgHeartRate := int32(*lap.AvgHeartRate)
+			msg.AvgHeartRate = &avgHeartRate
+		}
+		res.Laps = append(res.Laps, msg)
+	}
+
+	return res
+}
+
+// Proto returns the protobuf representation sent to clients accepting application/x-protobuf
+func (t *TrainingResponse) Proto() proto.Message {
+	return newTrainingProto(t)
+}
+
+// Proto returns the protobuf representation sent to clients accepting application/x-protobuf
+func (s *TrainingSessionResponse) Proto() proto.Message {
+	return newTrainingSessionProto(s)
+}
@@ -2,11 +2,15 @@ package training
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
-	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/logger"
 	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
 	"github.com/rizkyharahap/swimo/pkg/response"
 	"github.com/rizkyharahap/swimo/pkg/validator"
 )
@@ -21,35 +25,43 @@ func NewTrainingHandler(trainingUseCase TrainingUsecase) *TrainingHandler {
 
 // GetById handles getting training by ID
 // @Summary Get training by ID
-// @Description Retrieve detailed training information by training ID
+// @Description Retrieve detailed training information by training ID. Trainings under review or rejected are only visible to their author and admins. With include, aggregates are embedded in the same response: completions is the number of sessions of the user on the training.
 // @Tags Training
 // @Accept json
 // @Produce json
 // @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Param include query string false "Comma separated aggregates to embed" example(completions)
 // @Success 200 {object} response.Success{data=TrainingResponse} "Training retrieved successfully"
-// @Failure 404 {object} response.Message "Training not found"
+// @Failure 404 {object} response.Error "Training not found"
+// @Failure 422 {object} response.Error "Invalid training ID or include"
 // @Security ApiKeyAuth
 // @Router /trainings/{id} [get]
 func (h *TrainingHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
 
-	training, err := h.trainingUseCase.GetById(r.Context(), id)
+	include, err := parseOptions(r.URL.Query().Get("include"), "Include", trainingIncludes)
 	if err != nil {
-		if err == ErrTrainingNotFound {
-			response.JSON(w, http.StatusNotFound, response.Message{Message: "Training not found"})
-			return
-		}
+		response.ValidationError(w, map[string]string{"include": err.Error()})
+		return
+	}
 
-		response.InternalError(w)
+	ctx := r.Context()
+	training, err := h.trainingUseCase.GetById(ctx, middleware.AuthFromContext(ctx), id, include)
+	if err != nil {
+		response.Err(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Success{Data: training})
+	response.OK(w, http.StatusOK, training)
 }
 
 // GetTrainings handles getting paginated list of trainings
 // @Summary Get trainings with pagination
-// @Description Retrieve a paginated list of trainings with optional search and sorting
+// @Description Retrieve a paginated list of trainings with optional search, filters and sorting. With facets, the number of trainings per category and level is returned along the page, each count applying every filter but its own. A search without results is an empty page, not an error.
 // @Tags Training
 // @Accept json
 // @Produce json
@@ -57,88 +69,78 @@ func (h *TrainingHandler) GetById(w http.ResponseWriter, r *http.Request) {
 // @Param limit query int false "Number of items per page" default(10) minimum(1) maximum(100)
 // @Param sort query string false "Sort field and direction" Enums(name.asc,name.desc,level.asc,level.desc,created_at.asc,created_at.desc) default(created_at.desc)
 // @Param search query string false "Search term for training name and description"
-// @Success 200 {object} response.SuccessPagination{data=[]TrainingItemResponse} "Trainings retrieved successfully"
-// @Failure 404 {object} response.SuccessPagination{data=[]TrainingItemResponse} "Training not found"
+// @Param category query string false "Category code to filter by" example(BREASTSTROKE)
+// @Param level query string false "Level to filter by" example(beginner)
+// @Param facets query string false "Comma separated filters to count the trainings of by value, returned in meta.facets" example(category,level)
+// @Success 200 {object} response.Success{data=[]TrainingItemResponse} "Trainings retrieved successfully"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Failure 503 {object} response.Error "Search timed out"
 // @Security ApiKeyAuth
 // @Router /trainings [get]
 func (h *TrainingHandler) GetTrainings(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Parse query parameters with default values
-	query := TrainingsQuery{
-		Page:  1,
-		Limit: 10,
-		Sort:  "created_at.desc",
+	// Search pages are viewed far more than they change, an estimated total is enough for large catalogs
+	params, verr := pagination.Parse(r.URL.Query(), pagination.Options{Sorts: trainingSorts, Count: pagination.CountEstimate})
+	if verr != nil {
+		response.ValidationError(w, verr.Errors)
+		return
 	}
 
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil {
-			query.Page = page
-		}
+	query := TrainingsQuery{
+		Params:   params,
+		Search:   r.URL.Query().Get("search"),
+		Category: r.URL.Query().Get("category"),
+		Level:    r.URL.Query().Get("level"),
 	}
 
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil {
-			query.Limit = limit
-		}
+	facets, err := parseOptions(r.URL.Query().Get("facets"), "Facets", trainingFacets)
+	if err != nil {
+		response.ValidationError(w, map[string]string{"facets": err.Error()})
+		return
 	}
+	query.Facets = facets
 
-	if sort := r.URL.Query().Get("sort"); sort != "" {
-		query.Sort = sort
+	// Get paginated trainings from usecase
+	trainingItems, total, err := h.trainingUseCase.GetTrainings(ctx, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
 	}
 
-	query.Search = r.URL.Query().Get("search")
-
-	if err := query.Validate(); err != nil {
-		response.ValidationError(w, err.Errors)
+	if len(query.Facets) == 0 {
+		response.Paginated(w, http.StatusOK, trainingItems, query.Response(total))
 		return
 	}
 
-	// Get paginated trainings from usecase
-	trainingItems, totalPages, err := h.trainingUseCase.GetTrainings(ctx, &query)
-	if err != nil {
-		if err == ErrTrainingNotFound {
-			response.JSON(w, http.StatusNotFound, response.SuccessPagination{
-				Data: trainingItems,
-				Pagination: response.Pagination{
-					Page:       query.Page,
-					Limit:      query.Limit,
-					TotalPages: totalPages,
-				},
-			})
-			return
-		}
-
-		response.InternalError(w)
+	// Counted on an empty page too, they show which filter to loosen
+	counts, err := h.trainingUseCase.GetTrainingFacets(ctx, &query)
+	if err != nil {
+		response.Err(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, response.SuccessPagination{
-		Data: trainingItems,
-		Pagination: response.Pagination{
-			Page:       query.Page,
-			Limit:      query.Limit,
-			TotalPages: totalPages,
-		},
-	})
+	response.Faceted(w, http.StatusOK, trainingItems, query.Response(total), counts)
 }
 
 // CreateTraining handles creating a new training
 // @Summary Create a new training
-// @Description Create a new training with the provided details
+// @Description Create a new training with the provided details. Trainings created by admins are published right away, those of other accounts are submitted for review and only listed once approved.
 // @Tags Training
 // @Accept json
 // @Produce json
 // @Param request body TrainingRequest true "Training creation request"
 // @Success 201 {object} response.Success{data=TrainingResponse} "Training created successfully"
-// @Failure 409 {object} response.Message "Training already exists"
+// @Failure 403 {object} response.Error "Guests cannot submit trainings"
+// @Failure 409 {object} response.Error "Training already exists"
 // @Failure 422 {object} response.Error "Validation errors"
 // @Security ApiKeyAuth
 // @Router /trainings [post]
 func (h *TrainingHandler) CreateTraining(w http.ResponseWriter, r *http.Request) {
 	var req TrainingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w)
+		response.DecodeError(w, err)
 		return
 	}
 
@@ -147,17 +149,14 @@ func (h *TrainingHandler) CreateTraining(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	training, err := h.trainingUseCase.CreateTraining(r.Context(), &req)
+	ctx := r.Context()
+	training, err := h.trainingUseCase.CreateTraining(ctx, middleware.AuthFromContext(ctx), &req)
 	if err != nil {
-		if err == ErrorTrainingExists {
-			response.JSON(w, http.StatusConflict, response.Message{Message: "Training already exists"})
-			return
-		}
-		response.InternalError(w)
+		response.Err(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, response.Success{Data: training})
+	response.OK(w, http.StatusCreated, training)
 }
 
 // GetLastTraining handles getting user's last training session
@@ -167,7 +166,7 @@ func (h *TrainingHandler) CreateTraining(w http.ResponseWriter, r *http.Request)
 // @Accept json
 // @Produce json
 // @Success 200 {object} response.Success{data=TrainingSessionResponse} "Last training session retrieved successfully"
-// @Failure 404 {object} response.Message "No training sessions found"
+// @Failure 404 {object} response.Error "No training sessions found"
 // @Security ApiKeyAuth
 // @Router /trainings/sessions/last [get]
 func (h *TrainingHandler) GetLastSession(w http.ResponseWriter, r *http.Request) {
@@ -176,35 +175,37 @@ func (h *TrainingHandler) GetLastSession(w http.ResponseWriter, r *http.Request)
 
 	trainingSession, err := h.trainingUseCase.GetLastSession(ctx, *claim.Uid)
 	if err != nil {
-		if err == ErrTrainingSessionNotFound {
-			response.JSON(w, http.StatusNotFound, response.Message{Message: "No training sessions found"})
-			return
-		}
-
-		response.InternalError(w)
+		response.Err(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, response.Success{Data: trainingSession})
+	response.OK(w, http.StatusOK, trainingSession)
 }
 
 // FinishSession handles finishing a training session
 // @Summary Finish a training session
-// @Description Complete an ongoing training session with distance and duration metrics
+// @Description Complete an ongoing training session with distance and duration metrics. Open water sessions may carry their water conditions. Sessions faster or slower than plausible for the training level, longer than 6 hours or not a whole number of pool lengths are rejected unless an admin sets override.
 // @Tags Training
 // @Accept json
 // @Produce json
 // @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
 // @Param request body TrainingFinishSessionRequest true "Training finish session request"
 // @Success 201 {object} response.Success{data=TrainingSessionResponse} "Training session finished successfully"
+// @Failure 403 {object} response.Error "Guests must sign up to record sessions"
 // @Failure 404 {object} response.Error "User not found or Training not found"
-// @Failure 422 {object} response.Error "Validation errors"
+// @Failure 422 {object} response.Error "Validation errors, implausible session or conditions on a session that is not open water"
 // @Security ApiKeyAuth
 // @Router /trainings/{id}/finish [post]
 func (h *TrainingHandler) FinishSession(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
 	var req TrainingFinishSessionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w)
+		response.DecodeError(w, err)
 		return
 	}
 
@@ -215,23 +216,744 @@ func (h *TrainingHandler) FinishSession(w http.ResponseWriter, r *http.Request)
 
 	ctx := r.Context()
 	claim := middleware.AuthFromContext(ctx)
+
+	training, err := h.trainingUseCase.FinishSession(ctx, claim, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, training)
+}
+
+// ImportSessions handles importing sessions recorded on another device
+// @Summary Import training sessions
+// @Description Import a batch of sessions recorded elsewhere (ex: a watch), with optional laps, in a single transaction
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Param request body TrainingImportSessionsRequest true "Training sessions import request"
+// @Success 201 {object} response.Success{data=TrainingImportSessionsResponse} "Training sessions imported successfully"
+// @Failure 404 {object} response.Error "User not found or Training not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/import [post]
+func (h *TrainingHandler) ImportSessions(w http.ResponseWriter, r *http.Request) {
+	var req TrainingImportSessionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	res, err := h.trainingUseCase.ImportSessions(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusCreated, res)
+}
+
+// SyncSessions handles syncing sessions recorded offline
+// @Summary Sync offline training sessions
+// @Description Sync a batch of sessions recorded offline, identified by a UUID generated on the device. A known clientId updates its session: trainingId and startedAt keep the server value, distance, duration and laps keep the value edited last by updatedAt. Results are keyed by clientId (by index when it is missing) with the status created, updated, unchanged or rejected, and the fields that kept the server value in conflicts.
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Param request body TrainingSyncSessionsRequest true "Training sessions sync request"
+// @Success 200 {object} response.Success{data=TrainingSyncSessionsResponse} "Training sessions synced successfully"
+// @Failure 404 {object} response.Error "User not found"
+// @Failure 409 {object} response.Error "Sessions are being synced by another request"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /sync/sessions [post]
+func (h *TrainingHandler) SyncSessions(w http.ResponseWriter, r *http.Request) {
+	var req TrainingSyncSessionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	// Items are validated one by one by the usecase, a bad item does not reject the batch
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	res, err := h.trainingUseCase.SyncSessions(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// GetSession handles the detail of a session
+// @Summary Get a training session
+// @Description Get a session of the user with its laps and, for open water sessions, its water conditions
+// @Tags Training
+// @Produce json
+// @Param id path string true "Session ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Success 200 {object} response.Success{data=TrainingSessionDetailResponse} "Training session retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Session not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/{id} [get]
+func (h *TrainingHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.trainingUseCase.GetSession(ctx, *claim.Uid, id)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// UpdateConditions handles recording the water conditions of a session
+// @Summary Update water conditions
+// @Description Replace the water conditions of an open water session. With latitude and longitude, the water temperature, wave height and current speed left empty are filled from the weather at the start of the session when a provider is configured.
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Param request body TrainingConditionsRequest true "Water conditions"
+// @Success 200 {object} response.Success{data=TrainingSessionDetailResponse} "Water conditions updated successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Session not found"
+// @Failure 422 {object} response.Error "Validation errors or session not open water"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/{id}/conditions [put]
+func (h *TrainingHandler) UpdateConditions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req TrainingConditionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.trainingUseCase.UpdateConditions(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// ListDuplicates handles listing the sessions possibly recorded twice
+// @Summary List possible duplicate sessions
+// @Description List up to 100 pending pairs of sessions of the user overlapping for at least half of the shorter one, ex: a manual entry and the watch import of the same swim. Newest first.
+// @Tags Training
+// @Produce json
+// @Success 200 {object} response.Success{data=[]TrainingDuplicateResponse} "Possible duplicates retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/duplicates [get]
+func (h *TrainingHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	res, err := h.trainingUseCase.ListDuplicates(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// MergeDuplicate handles merging a pair of duplicate sessions
+// @Summary Merge duplicate sessions
+// @Description Keep one session of the pair, the one stored first unless keepId is sent, and delete the other. The kept session takes the laps, water conditions, client id and training of the deleted one where it has none.
+// @Tags Training
+// @Accept json
+// @Produce json
+// @Param id path string true "Duplicate ID" example("3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a")
+// @Param request body TrainingMergeDuplicateRequest false "Session to keep"
+// @Success 200 {object} response.Success{data=response.Message} "Sessions merged"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Duplicate not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/duplicates/{id}/merge [post]
+func (h *TrainingHandler) MergeDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	// The body is optional, without it the session stored first is kept
+	var req TrainingMergeDuplicateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	if err := h.trainingUseCase.MergeDuplicate(ctx, *claim.Uid, id, &req); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Sessions merged"})
+}
+
+// DismissDuplicate handles keeping both sessions of a pair
+// @Summary Dismiss a possible duplicate
+// @Description Mark the pair as distinct sessions, both are kept and the pair is not flagged again
+// @Tags Training
+// @Produce json
+// @Param id path string true "Duplicate ID" example("3d2c1b0a-9f8e-4d7c-6b5a-4f3e2d1c0b9a")
+// @Success 200 {object} response.Success{data=response.Message} "Duplicate dismissed"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Duplicate not found"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/duplicates/{id}/dismiss [post]
+func (h *TrainingHandler) DismissDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.trainingUseCase.DismissDuplicate(ctx, *claim.Uid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Duplicate dismissed"})
+}
+
+// ExportSessions handles streaming the full session history of the user
+// @Summary Export training sessions
+// @Description Stream every training session of the user, newest first. Send Accept: application/x-ndjson (or format=ndjson) for one session per line, otherwise the sessions are streamed as the data array.
+// @Tags Training
+// @Produce json
+// @Produce application/x-ndjson
+// @Param format query string false "Stream format" Enums(json,ndjson)
+// @Success 200 {object} response.Success{data=[]TrainingSessionExportResponse} "Training sessions exported successfully"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/export [get]
+func (h *TrainingHandler) ExportSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	stream := response.NewStream(w, r)
+	err := h.trainingUseCase.ExportSessions(ctx, *claim.Uid, func(s *TrainingSessionExportResponse) error {
+		return stream.Write(s)
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("Training sessions export failed", "error", err, "exported", stream.Count())
+		stream.Fail(http.StatusInternalServerError, response.CodeInternal, "Internal server error")
+		return
+	}
+
+	stream.Close()
+}
+
+// ExportSessionsFile handles exporting the session history to a downloadable file
+// @Summary Export training sessions to a file
+// @Description Write every training session of the user to an NDJSON file and return a link downloading it until expiresAt
+// @Tags Training
+// @Produce json
+// @Success 200 {object} response.Success{data=TrainingExportFileResponse} "Training sessions exported successfully"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/export/file [post]
+func (h *TrainingHandler) ExportSessionsFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	export, err := h.trainingUseCase.ExportSessionsFile(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, export)
+}
+
+// UploadMedia handles uploading the thumbnail and video of a training
+// @Summary Upload training media
+// @Description Replace the thumbnail (JPEG, PNG or WebP) and/or the video (MP4, WebM or QuickTime) of a training with the files of a multipart form. Files are streamed to storage, large videos are uploaded in parts. With a version, sent before the files, a training edited since is refused with the current version. Only the author of the training and admins can replace its media.
+// @Tags Training
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Param version formData integer false "Version of the training read, sent before the files" example(3)
+// @Param thumbnail formData file false "Thumbnail image"
+// @Param video formData file false "Video"
+// @Success 200 {object} response.Success{data=TrainingResponse} "Training media updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Not the author of the training"
+// @Failure 404 {object} response.Error "Training not found"
+// @Failure 409 {object} response.Error "Training edited since the version read"
+// @Failure 413 {object} response.Error "File too large"
+// @Failure 415 {object} response.Error "Unsupported media type"
+// @Failure 422 {object} response.Error "Validation errors or file rejected by the malware scan"
+// @Failure 503 {object} response.Error "Uploads disabled or malware scanner unavailable"
+// @Security ApiKeyAuth
+// @Router /trainings/{id}/media [put]
+func (h *TrainingHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
 	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
 
-	training, err := h.trainingUseCase.FinishSession(r.Context(), *claim.Uid, id, &req)
+	reader, err := r.MultipartReader()
 	if err != nil {
-		if err == user.ErrUserNotFound {
-			response.JSON(w, http.StatusNotFound, "User not found")
+		response.BadRequest(w)
+		return
+	}
+
+	// Parts are streamed to storage one after the other as they arrive
+	var version *int
+	uploaded := 0
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			response.DecodeError(w, err)
 			return
 		}
 
-		if err == ErrTrainingCategoryNotFound {
-			response.JSON(w, http.StatusNotFound, "Training not found")
+		field := part.FormName()
+		if field == "version" {
+			if version, err = readVersion(part); err != nil {
+				response.ValidationError(w, map[string]string{"version": "Version must be a positive number"})
+				return
+			}
+			continue
+		}
+		if field != "thumbnail" && field != "video" {
+			continue
+		}
+
+		// Each file bumps the version, the next one expects it
+		current, err := h.trainingUseCase.UploadMedia(ctx, claim, id, field, part, part.Header.Get("Content-Type"), version)
+		if err != nil {
+			response.Err(w, err)
 			return
 		}
+		if version != nil {
+			version = &current
+		}
+		uploaded++
+	}
+
+	if uploaded == 0 {
+		response.ValidationError(w, map[string]string{"thumbnail": "Thumbnail or video is required"})
+		return
+	}
+
+	training, err := h.trainingUseCase.GetById(ctx, claim, id, nil)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, training)
+}
 
-		response.InternalError(w)
+// readVersion parses the version field of a multipart form
+func readVersion(part io.Reader) (*int, error) {
+	value, err := io.ReadAll(io.LimitReader(part, 16))
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := strconv.Atoi(strings.TrimSpace(string(value)))
+	if err != nil || version <= 0 {
+		return nil, errors.New("invalid version")
+	}
+	return &version, nil
+}
+
+// ListSubmissions handles listing the trainings submitted by the user
+// @Summary List my training submissions
+// @Description List up to 100 trainings submitted by the user with their review status and the reason of a rejection. Newest first.
+// @Tags Training
+// @Produce json
+// @Success 200 {object} response.Success{data=[]TrainingReviewResponse} "Submissions retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Security ApiKeyAuth
+// @Router /trainings/submissions [get]
+func (h *TrainingHandler) ListSubmissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	res, err := h.trainingUseCase.ListSubmissions(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// ListReviews handles the moderation queue
+// @Summary List trainings by review status
+// @Description List up to 100 trainings of the organization in a review status, the pending ones by default. Oldest first. Admin only.
+// @Tags Moderation
+// @Produce json
+// @Param status query string false "Review status" Enums(pending_review,approved,rejected) default(pending_review)
+// @Success 200 {object} response.Success{data=[]TrainingReviewResponse} "Trainings retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Invalid status"
+// @Security ApiKeyAuth
+// @Router /admin/trainings [get]
+func (h *TrainingHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = StatusPendingReview
+	case StatusPendingReview, StatusApproved, StatusRejected:
+	default:
+		response.ValidationError(w, map[string]string{"status": "Status must be one of: pending_review, approved, rejected"})
+		return
+	}
+
+	res, err := h.trainingUseCase.ListReviews(r.Context(), status)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// ApproveTraining handles publishing a submitted training
+// @Summary Approve a training
+// @Description Publish a training pending review to the catalog, its author is notified. With a version, a training edited since is refused with the current version. Admin only.
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Param request body TrainingApproveRequest false "Version of the training reviewed"
+// @Success 200 {object} response.Success{data=TrainingReviewResponse} "Training approved"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Training not found"
+// @Failure 409 {object} response.Error "Training is not pending review or edited since the version read"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/trainings/{id}/approve [post]
+func (h *TrainingHandler) ApproveTraining(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	// The body is optional, approving without one skips the version check
+	var req TrainingApproveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	res, err := h.trainingUseCase.ApproveTraining(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// RejectTraining handles turning down a submitted training
+// @Summary Reject a training
+// @Description Keep a training pending review out of the catalog, its author is notified with the reason. With a version, a training edited since is refused with the current version. Admin only.
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Param request body TrainingRejectRequest true "Reason of the rejection"
+// @Success 200 {object} response.Success{data=TrainingReviewResponse} "Training rejected"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Training not found"
+// @Failure 409 {object} response.Error "Training is not pending review or edited since the version read"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /admin/trainings/{id}/reject [post]
+func (h *TrainingHandler) RejectTraining(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	var req TrainingRejectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	res, err := h.trainingUseCase.RejectTraining(ctx, *claim.Uid, id, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// DeleteSession handles removing a session from the history of the user
+// @Summary Delete a training session
+// @Description Remove a session from the history, stats and exports of the user. Admins can restore it.
+// @Tags Training
+// @Produce json
+// @Param id path string true "Session ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Success 200 {object} response.Success{data=response.Message} "Session deleted"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "Session not found"
+// @Failure 422 {object} response.Error "Invalid session ID"
+// @Security ApiKeyAuth
+// @Router /trainings/sessions/{id} [delete]
+func (h *TrainingHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.trainingUseCase.DeleteSession(ctx, *claim.Uid, id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Session deleted"})
+}
+
+// DeleteTraining handles removing a training from the catalog
+// @Summary Delete a training
+// @Description Remove a training of the organization from the catalog and the moderation lists, sessions recorded on it are kept. Trainings of the shared catalog can only be deleted outside an organization. Admin only.
+// @Tags Moderation
+// @Produce json
+// @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Success 200 {object} response.Success{data=response.Message} "Training deleted"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Training not found"
+// @Failure 422 {object} response.Error "Invalid training ID"
+// @Security ApiKeyAuth
+// @Router /admin/trainings/{id} [delete]
+func (h *TrainingHandler) DeleteTraining(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.trainingUseCase.DeleteTraining(r.Context(), id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Training deleted"})
+}
+
+// RestoreTraining handles putting a deleted training back
+// @Summary Restore a deleted training
+// @Description Put a deleted training back in the catalog with the review status it had. Admin only.
+// @Tags Moderation
+// @Produce json
+// @Param id path string true "Training ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Success 200 {object} response.Success{data=response.Message} "Training restored"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Deleted training not found"
+// @Failure 422 {object} response.Error "Invalid training ID"
+// @Security ApiKeyAuth
+// @Router /admin/trainings/{id}/restore [post]
+func (h *TrainingHandler) RestoreTraining(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.trainingUseCase.RestoreTraining(r.Context(), id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Training restored"})
+}
+
+// ListDeletedTrainings handles listing the deleted trainings
+// @Summary List deleted trainings
+// @Description List up to 100 deleted trainings of the organization, last deleted first. Admin only.
+// @Tags Moderation
+// @Produce json
+// @Success 200 {object} response.Success{data=[]TrainingReviewResponse} "Deleted trainings retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Security ApiKeyAuth
+// @Router /admin/trainings/deleted [get]
+func (h *TrainingHandler) ListDeletedTrainings(w http.ResponseWriter, r *http.Request) {
+	res, err := h.trainingUseCase.ListDeletedTrainings(r.Context())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
+
+// RestoreSession handles putting a deleted session back
+// @Summary Restore a deleted training session
+// @Description Put a deleted session back in the history of its user. Admin only.
+// @Tags Moderation
+// @Produce json
+// @Param id path string true "Session ID" example("8c4a2d27-56e2-4ef3-8a6e-43b812345abc")
+// @Success 200 {object} response.Success{data=response.Message} "Session restored"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 404 {object} response.Error "Deleted session not found"
+// @Failure 422 {object} response.Error "Invalid session ID"
+// @Security ApiKeyAuth
+// @Router /admin/trainings/sessions/{id}/restore [post]
+func (h *TrainingHandler) RestoreSession(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	if err := h.trainingUseCase.RestoreSession(r.Context(), id); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, response.Message{Message: "Session restored"})
+}
+
+// ListDeletedSessions handles listing the deleted sessions of a user
+// @Summary List deleted training sessions of a user
+// @Description List up to 100 deleted sessions of a user, last deleted first. Admin only.
+// @Tags Moderation
+// @Produce json
+// @Param id path string true "User ID" example("a1b2c3d4-e5f6-7890-1234-567890abcdef")
+// @Success 200 {object} response.Success{data=[]TrainingDeletedSessionResponse} "Deleted sessions retrieved successfully"
+// @Failure 403 {object} response.Error "Insufficient role"
+// @Failure 422 {object} response.Error "Invalid user ID"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/sessions/deleted [get]
+func (h *TrainingHandler) ListDeletedSessions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validator.IsValidUUID(id) {
+		response.ValidationError(w, map[string]string{"id": "ID is not a valid ID"})
+		return
+	}
+
+	res, err := h.trainingUseCase.ListDeletedSessions(r.Context(), id)
+	if err != nil {
+		response.Err(w, err)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, response.Success{Data: training})
+	response.OK(w, http.StatusOK, res)
 }
@@ -0,0 +1,105 @@
+package training
+
+import (
+	"context"
+
+	"github.com/rizkyharahap/swimo/pkg/broker"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+// maxReviews caps the trainings listed at once, reviewing the queue reveals the rest
+const maxReviews = 100
+
+// canView reports whether the training is visible to the caller, trainings under review or
+// rejected are only shown to their author and to admins
+func canView(claim *security.Claim, training *Training) bool {
+	if training.Status == StatusApproved || (claim != nil && claim.IsAdmin()) {
+		return true
+	}
+	return claim != nil && claim.Uid != nil && training.AuthorUserID != nil && *claim.Uid == *training.AuthorUserID
+}
+
+// canEdit reports whether the caller may change a training written by authorID, only its
+// author and admins may, the seeded catalog has no author
+func canEdit(claim *security.Claim, authorID *string) bool {
+	if claim != nil && claim.IsAdmin() {
+		return true
+	}
+	return claim != nil && claim.Uid != nil && authorID != nil && *claim.Uid == *authorID
+}
+
+// ListSubmissions returns the trainings submitted by the user with their review
+func (u *trainingUsecase) ListSubmissions(ctx context.Context, userId string) ([]TrainingReviewResponse, error) {
+	trainings, err := u.trainingRepo.ListByAuthor(ctx, userId, maxReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingReviewResponses(trainings), nil
+}
+
+// ListReviews returns the trainings of the tenant in the review status, oldest first
+func (u *trainingUsecase) ListReviews(ctx context.Context, status string) ([]TrainingReviewResponse, error) {
+	trainings, err := u.trainingRepo.ListByStatus(ctx, status, maxReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTrainingReviewResponses(trainings), nil
+}
+
+// ApproveTraining publishes a pending training to the catalog
+func (u *trainingUsecase) ApproveTraining(ctx context.Context, reviewerId, id string, req *TrainingApproveRequest) (*TrainingReviewResponse, error) {
+	return u.review(ctx, reviewerId, id, StatusApproved, nil, req.Version)
+}
+
+// RejectTraining keeps a pending training out of the catalog, the author sees the reason
+func (u *trainingUsecase) RejectTraining(ctx context.Context, reviewerId, id string, req *TrainingRejectRequest) (*TrainingReviewResponse, error) {
+	return u.review(ctx, reviewerId, id, StatusRejected, &req.Reason, req.Version)
+}
+
+// review decides on a pending training, with a version only when no other admin edited it since
+func (u *trainingUsecase) review(ctx context.Context, reviewerId, id, status string, reason *string, version *int) (*TrainingReviewResponse, error) {
+	training, err := u.trainingRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if training == nil {
+		return nil, ErrTrainingNotFound
+	}
+
+	training.Status = status
+	training.ReviewReason = reason
+	if err := u.trainingRepo.Review(ctx, training, reviewerId, version); err != nil {
+		return nil, err
+	}
+
+	if status == StatusApproved {
+		u.invalidateList(ctx)
+	}
+
+	if training.AuthorUserID != nil {
+		event := TrainingReviewedEvent{
+			TrainingID: training.ID,
+			AuthorID:   *training.AuthorUserID,
+			Name:       training.Name,
+			Status:     status,
+			Reason:     reason,
+		}
+		if err := u.publisher.Publish(ctx, broker.NewEvent(broker.EventTrainingReviewed, event)); err != nil {
+			logger.FromContext(ctx).Warn("review training: publish event failed", "training_id", training.ID, "error", err)
+		}
+	}
+
+	res := newTrainingReviewResponse(training)
+	return &res, nil
+}
+
+func newTrainingReviewResponses(trainings []*Training) []TrainingReviewResponse {
+	res := make([]TrainingReviewResponse, 0, len(trainings))
+	for _, t := range trainings {
+		res = append(res, newTrainingReviewResponse(t))
+	}
+	return res
+}
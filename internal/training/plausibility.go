@@ -0,0 +1,55 @@
+package training
+
+// paceBounds are the plausible paces of a level, in seconds per 100m
+type paceBounds struct {
+	fastest float64
+	slowest float64
+}
+
+// levelPaces leave room above what swimmers of each level reach over a sprint, the 100m
+// freestyle world record is about 47s. Slower than 10 minutes per 100m is standing in the pool.
+var levelPaces = map[string]paceBounds{
+	"beginner":     {fastest: 75, slowest: 600},
+	"intermediate": {fastest: 60, slowest: 600},
+	"advanced":     {fastest: 48, slowest: 600},
+}
+
+// defaultPaces applies to levels missing from levelPaces, submitted trainings may use any level
+var defaultPaces = levelPaces["advanced"]
+
+// maxPlausibleSeconds is the longest session recorded without an admin override, longer swims
+// are races or crossings an admin records on behalf of the swimmer
+const maxPlausibleSeconds = 6 * 60 * 60
+
+// CheckPlausibility returns the reasons nobody of the training level swims the session, keyed
+// by field, or nil for a plausible session. With a pool length the distance must be a whole
+// number of lengths.
+func CheckPlausibility(level string, distanceMeters, durationSeconds int, poolLengthMeters *int) map[string]string {
+	errors := make(map[string]string)
+
+	if distanceMeters > maxSessionMeters {
+		errors["distanceMeters"] = "Distance is longer than a plausible session"
+	} else if poolLengthMeters != nil && distanceMeters%*poolLengthMeters != 0 {
+		errors["distanceMeters"] = "Distance must be a multiple of the pool length"
+	}
+
+	bounds, ok := levelPaces[level]
+	if !ok {
+		bounds = defaultPaces
+	}
+
+	pace := float64(durationSeconds) / float64(distanceMeters) * 100
+	switch {
+	case durationSeconds > maxPlausibleSeconds:
+		errors["durationSeconds"] = "Duration is longer than a plausible session"
+	case pace < bounds.fastest:
+		errors["durationSeconds"] = "Pace is faster than plausible for the training level"
+	case pace > bounds.slowest:
+		errors["durationSeconds"] = "Pace is slower than plausible for the training level"
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+	return errors
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
 )
 
 var (
@@ -18,15 +22,83 @@ var (
 type TrainingRepository interface {
 	GetTrainingCategoryByTrainingId(ctx context.Context, code string) (*TrainingCategory, error)
 	GetById(ctx context.Context, id string) (*Training, error)
-	GetList(ctx context.Context, query *TrainingsQuery) ([]*TrainingItem, int, error)
+	// GetWithAggregates is GetById with the aggregates of include joined in, completions counts
+	// the sessions of userID on the training
+	GetWithAggregates(ctx context.Context, id string, userID *string, include []string) (*Training, error)
+	GetList(ctx context.Context, query *TrainingsQuery) ([]*TrainingItem, error)
+	// CountList returns the number of trainings of the list, estimated on large lists when the
+	// query allows it
+	CountList(ctx context.Context, query *TrainingsQuery) (pagination.Total, error)
+	// GetFacets counts the trainings of the list by value of each facet of the query, every
+	// filter applied except the facet's own
+	GetFacets(ctx context.Context, query *TrainingsQuery) (map[string][]FacetCount, error)
 	Create(ctx context.Context, training *Training) (*Training, error)
 	GetLastSessionByUserId(ctx context.Context, userID string) (*TrainingSession, error)
+	StreamSessionsByUserId(ctx context.Context, userID string, fn func(*TrainingSession) error) error
 	FinishSession(ctx context.Context, trainingSession *TrainingSession) (*TrainingSession, error)
+	ImportSessions(ctx context.Context, trainingSessions []*TrainingSession) error
+	CreateLaps(ctx context.Context, trainingSessions ...*TrainingSession) error
+	// GetSessionsByClientIds locks the synced sessions of the user with the given client ids, laps included
+	GetSessionsByClientIds(ctx context.Context, userID string, clientIDs []string) ([]*TrainingSession, error)
+	// CreateSyncedSessions inserts sessions recorded offline, a client id synced concurrently is
+	// left without ID
+	CreateSyncedSessions(ctx context.Context, trainingSessions []*TrainingSession) error
+	// UpdateSyncedSession replaces the measurements of a synced session and its laps
+	UpdateSyncedSession(ctx context.Context, trainingSession *TrainingSession) error
+	// GetSessionById returns a session of the user with its category, laps and conditions
+	GetSessionById(ctx context.Context, userID, id string) (*TrainingSession, error)
+	// SaveConditions creates or replaces the conditions of the sessions
+	SaveConditions(ctx context.Context, conditions []*SessionConditions) error
+	// FlagDuplicates records the other sessions of the same user overlapping the given ones for
+	// at least half of the shorter session, returns how many pairs were recorded
+	FlagDuplicates(ctx context.Context, ids []string) (int, error)
+	// ListDuplicates returns the pending duplicates of the user, newest first
+	ListDuplicates(ctx context.Context, userID string, limit int) ([]*SessionDuplicate, error)
+	// GetDuplicate locks a pending duplicate of the user
+	GetDuplicate(ctx context.Context, userID, id string) (*SessionDuplicate, error)
+	// MergeSessions deletes drop after moving its laps, conditions, client id and training to keep
+	// where keep has none
+	MergeSessions(ctx context.Context, keep, drop *TrainingSession) error
+	// DismissDuplicate marks a pending duplicate of the user as distinct sessions
+	DismissDuplicate(ctx context.Context, userID, id string) error
+	// ListByStatus returns the trainings of the tenant in a review status, oldest first
+	ListByStatus(ctx context.Context, status string, limit int) ([]*Training, error)
+	// ListByAuthor returns the trainings submitted by the user, newest first
+	ListByAuthor(ctx context.Context, userID string, limit int) ([]*Training, error)
+	// Review records the decision on a pending training and bumps its version, ErrTrainingNotPending
+	// when it was already reviewed. With a version, a training edited since is a StaleVersionError.
+	Review(ctx context.Context, training *Training, reviewerID string, version *int) error
+	// UpdateMedia replaces the non nil media links of a training owned by the tenant, like Review
+	// it checks and bumps the version and returns the new one
+	UpdateMedia(ctx context.Context, id string, thumbnailURL, videoURL *string, version *int) (int, error)
+	// CheckMediaVersion returns the author of the training, ErrTrainingNotFound when no training
+	// owned by the tenant matches, a StaleVersionError when it's not at version, so media is
+	// checked before it's stored
+	CheckMediaVersion(ctx context.Context, id string, version *int) (authorID *string, err error)
+	// DeleteTraining softly deletes a training owned by the tenant, ErrTrainingNotFound when none
+	DeleteTraining(ctx context.Context, id string) error
+	// RestoreTraining restores a deleted training owned by the tenant, ErrTrainingNotFound when none
+	RestoreTraining(ctx context.Context, id string) error
+	// ListDeleted returns the deleted trainings of the tenant, last deleted first
+	ListDeleted(ctx context.Context, limit int) ([]*Training, error)
+	// DeleteSession softly deletes a session of the user, ErrSessionNotFound when none
+	DeleteSession(ctx context.Context, userID, id string) error
+	// RestoreSession restores a deleted session recorded in the tenant, ErrSessionNotFound when none
+	RestoreSession(ctx context.Context, id string) error
+	// ListDeletedSessions returns the deleted sessions of the user recorded in the tenant, last deleted first
+	ListDeletedSessions(ctx context.Context, userID string, limit int) ([]*TrainingSession, error)
+
+	// WithTx returns a repository running every query in tx
+	WithTx(tx pgx.Tx) TrainingRepository
 }
 
-type trainingRepository struct{ db *pgxpool.Pool }
+type trainingRepository struct{ db database.DBTX }
 
-func NewTrainingRepositry(db *pgxpool.Pool) TrainingRepository { return &trainingRepository{db: db} }
+func NewTrainingRepositry(db database.DBTX) TrainingRepository { return &trainingRepository{db: db} }
+
+func (r *trainingRepository) WithTx(tx pgx.Tx) TrainingRepository {
+	return &trainingRepository{db: database.Rebind(r.db, tx)}
+}
 
 func (r *trainingRepository) GetTrainingCategoryByTrainingId(ctx context.Context, trainingId string) (*TrainingCategory, error) {
 	const q = `
@@ -35,50 +107,58 @@ func (r *trainingRepository) GetTrainingCategoryByTrainingId(ctx context.Context
 		FROM training_categories tc
 		JOIN trainings t ON t.category_id = tc.id
 		WHERE t.id = $1
+			AND (t.organization_id IS NULL OR t.organization_id = $2)
+			AND t.deleted_at IS NULL
 		LIMIT 1
 	`
-	var category TrainingCategory
-	err := r.db.QueryRow(ctx, q, trainingId).Scan(
-		&category.ID,
-		&category.Code,
-		&category.Name,
-		&category.MET,
-	)
+	category, err := database.Get[TrainingCategory](ctx, r.db, q, trainingId, tenant.ID(ctx))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrTrainingCategoryNotFound
 		}
 		return nil, err
 	}
-	return &category, nil
+	return category, nil
 }
 
 func (r *trainingRepository) GetById(ctx context.Context, id string) (*Training, error) {
-	const q = `
+	return r.GetWithAggregates(ctx, id, nil, nil)
+}
+
+func (r *trainingRepository) GetWithAggregates(ctx context.Context, id string, userID *string, include []string) (*Training, error) {
+	// Aggregates are joined in the same statement, one round trip whatever is included
+	completionsQ, completionsJoin := `NULL::int AS completions`, ``
+	if slices.Contains(include, IncludeCompletions) {
+		completionsQ = `completions.count AS completions`
+		completionsJoin = `
+		LEFT JOIN LATERAL (
+			SELECT count(*)::int AS count
+			FROM training_sessions s
+			WHERE s.training_id = t.id AND s.user_id = $3 AND s.deleted_at IS NULL
+		) completions ON true`
+	}
+
+	q := `
 		SELECT
-			t.id, tc.code, tc.name,
+			t.id, tc.code AS category_code, tc.name AS category_name,
 			t.level, t.name, t.descriptions, t.time_label,
-			t.calories_kcal, t.thumbnail_url, t.video_url, t.content_html
+			t.calories_kcal, t.thumbnail_url, t.video_url, t.content_html,
+			t.status, t.author_user_id, t.review_reason, t.version, t.created_at,
+			` + completionsQ + `
 		FROM trainings t
-		LEFT JOIN training_categories tc ON t.category_id = tc.id
+		LEFT JOIN training_categories tc ON t.category_id = tc.id` + completionsJoin + `
 		WHERE t.id = $1
+			AND (t.organization_id IS NULL OR t.organization_id = $2)
+			AND t.deleted_at IS NULL
 		LIMIT 1
 	`
 
-	var training Training
-	err := r.db.QueryRow(ctx, q, id).Scan(
-		&training.ID,
-		&training.CategoryCode,
-		&training.CategoryName,
-		&training.Level,
-		&training.Name,
-		&training.Descriptions,
-		&training.TimeLabel,
-		&training.CaloriesKcal,
-		&training.ThumbnailURL,
-		&training.VideoURL,
-		&training.ContentHTML,
-	)
+	args := []any{id, tenant.ID(ctx)}
+	if completionsJoin != "" {
+		args = append(args, userID)
+	}
+
+	training, err := database.Get[Training](ctx, r.db, q, args...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -86,91 +166,107 @@ func (r *trainingRepository) GetById(ctx context.Context, id string) (*Training,
 		return nil, err
 	}
 
-	return &training, nil
+	return training, nil
 }
 
-func (r *trainingRepository) GetList(ctx context.Context, query *TrainingsQuery) ([]*TrainingItem, int, error) {
-	var (
-		whereQ string
-		args   []any
-		baseQ  = `
+func (r *trainingRepository) GetList(ctx context.Context, query *TrainingsQuery) ([]*TrainingItem, error) {
+	const baseQ = `
 		SELECT
 			id, level, name, descriptions, time_label, thumbnail_url
-		FROM trainings
+		FROM trainings t
 	`
-		countQ = `SELECT COUNT(*) FROM trainings`
-	)
+
+	whereQ, args := listFilter(ctx, query, "")
+
+	// Order by (whitelisted) and pagination
+	limitQ, limitArgs := query.LimitOffset(len(args) + 1)
+	finalQ := baseQ + whereQ + query.Sort.OrderBy() + limitQ
+
+	return database.Select[TrainingItem](ctx, r.db, finalQ, append(args, limitArgs...)...)
+}
+
+func (r *trainingRepository) CountList(ctx context.Context, query *TrainingsQuery) (pagination.Total, error) {
+	var total pagination.Total
+
+	whereQ, args := listFilter(ctx, query, "")
+
+	var err error
+	total.Items, total.Estimated, err = database.Count(ctx, r.db, query.Count == pagination.CountEstimate, `SELECT 1 FROM trainings t`+whereQ, args...)
+	return total, err
+}
+
+// listFilter returns the WHERE clause of the training list and its args, without the filter
+// named skip so a facet counts every value of its own filter
+func listFilter(ctx context.Context, query *TrainingsQuery, skip string) (string, []any) {
+	// Shared catalog plus the approved trainings of the tenant
+	whereQ := ` WHERE (t.organization_id IS NULL OR t.organization_id = $1) AND t.status = 'approved' AND ` + database.Live("t")
+	args := []any{tenant.ID(ctx)}
 
 	// Filter (search)
 	if query.Search != "" {
-		whereQ = ` WHERE (name ILIKE $1 OR descriptions ILIKE $1 OR level ILIKE $1)`
 		args = append(args, "%"+query.Search+"%")
+		whereQ += fmt.Sprintf(` AND (t.name ILIKE $%[1]d OR t.descriptions ILIKE $%[1]d OR t.level ILIKE $%[1]d)`, len(args))
 	}
-
-	// Order by
-	orderMap := map[string]string{
-		"name.asc":        " ORDER BY name ASC",
-		"name.desc":       " ORDER BY name DESC",
-		"level.asc":       " ORDER BY level ASC",
-		"level.desc":      " ORDER BY level DESC",
-		"created_at.asc":  " ORDER BY created_at ASC",
-		"created_at.desc": " ORDER BY created_at DESC",
+	if query.Category != "" && skip != FacetCategory {
+		args = append(args, query.Category)
+		whereQ += fmt.Sprintf(` AND t.category_id = (SELECT id FROM training_categories WHERE code = $%d)`, len(args))
 	}
-	orderByQ := orderMap[query.Sort]
-	if orderByQ == "" {
-		orderByQ = " ORDER BY created_at DESC"
+	if query.Level != "" && skip != FacetLevel {
+		args = append(args, query.Level)
+		whereQ += fmt.Sprintf(` AND t.level = $%d`, len(args))
 	}
 
-	// Pagination
-	offset := (query.Page - 1) * query.Limit
-	finalQ := fmt.Sprintf("%s%s%s LIMIT $%d OFFSET $%d",
-		baseQ, whereQ, orderByQ,
-		len(args)+1, len(args)+2,
-	)
-
-	rows, err := r.db.Query(ctx, finalQ, append(args, query.Limit, offset)...)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer rows.Close()
+	return whereQ, args
+}
 
-	trainings := make([]*TrainingItem, 0, query.Limit)
-	for rows.Next() {
-		var t TrainingItem
-		if err := rows.Scan(
-			&t.ID,
-			&t.Level,
-			&t.Name,
-			&t.Descriptions,
-			&t.TimeLabel,
-			&t.ThumbnailURL,
-		); err != nil {
-			return nil, 0, err
+func (r *trainingRepository) GetFacets(ctx context.Context, query *TrainingsQuery) (map[string][]FacetCount, error) {
+	facets := make(map[string][]FacetCount, len(query.Facets))
+
+	// One grouped query per facet, each ignores its own filter so the other values stay selectable
+	for _, facet := range query.Facets {
+		whereQ, args := listFilter(ctx, query, facet)
+
+		var q string
+		switch facet {
+		case FacetCategory:
+			q = `
+			SELECT c.code, c.name, count(*)
+			FROM trainings t
+			JOIN training_categories c ON c.id = t.category_id` + whereQ + `
+			GROUP BY c.code, c.name
+			ORDER BY count(*) DESC, c.name`
+		case FacetLevel:
+			q = `
+			SELECT t.level, '', count(*)
+			FROM trainings t` + whereQ + `
+			GROUP BY t.level
+			ORDER BY count(*) DESC, t.level`
+		default:
+			continue
 		}
 
-		trainings = append(trainings, &t)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, 0, err
-	}
-
-	if len(trainings) == 0 {
-		return nil, 0, nil
-	}
-
-	var total int
-	if len(args) > 0 {
-		err = r.db.QueryRow(ctx, countQ+whereQ, args...).Scan(&total)
-	} else {
-		err = r.db.QueryRow(ctx, countQ).Scan(&total)
-	}
+		rows, err := r.db.Query(ctx, q, args...)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, 0, err
+		var counts []FacetCount
+		for rows.Next() {
+			var c FacetCount
+			if err := rows.Scan(&c.Value, &c.Label, &c.Count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			counts = append(counts, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		facets[facet] = counts
 	}
 
-	return trainings, total, nil
+	return facets, nil
 }
 
 func (r *trainingRepository) Create(ctx context.Context, training *Training) (*Training, error) {
@@ -184,19 +280,20 @@ func (r *trainingRepository) Create(ctx context.Context, training *Training) (*T
 		ins AS (
 				INSERT INTO trainings (
 					category_id, level, name, descriptions, time_label,
-					calories_kcal, thumbnail_url, video_url, content_html
+					calories_kcal, thumbnail_url, video_url, content_html, organization_id,
+					status, author_user_id
 				)
 				SELECT
-					cat.id, $2, $3, $4, $5, $6, $7, $8, $9
+					cat.id, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 				FROM cat
 				RETURNING
-					id, category_id, level, name, descriptions,
-					time_label, calories_kcal, thumbnail_url, video_url, content_html
+					id, category_id, level, name, descriptions, time_label, calories_kcal, thumbnail_url,
+					video_url, content_html, status, author_user_id, version, created_at
 		)
 		SELECT
 				ins.id,
-				cat.code,
-				cat.name,
+				cat.code AS category_code,
+				cat.name AS category_name,
 				ins.level,
 				ins.name,
 				ins.descriptions,
@@ -204,35 +301,29 @@ func (r *trainingRepository) Create(ctx context.Context, training *Training) (*T
 				ins.calories_kcal,
 				ins.thumbnail_url,
 				ins.video_url,
-				ins.content_html
+				ins.content_html,
+				ins.status,
+				ins.author_user_id,
+				ins.version,
+				ins.created_at
 		FROM ins
 		JOIN cat ON ins.category_id = cat.id;
 		`
 
-	err := r.db.QueryRow(ctx, q,
+	created, err := database.Get[Training](ctx, r.db, q,
 		training.CategoryCode,
 		training.Level,
 		training.Name,
 		training.Descriptions,
-		training.VideoURL,
+		training.TimeLabel,
 		training.CaloriesKcal,
 		training.ThumbnailURL,
 		training.VideoURL,
 		training.ContentHTML,
-	).Scan(
-		&training.ID,
-		&training.CategoryCode,
-		&training.CategoryName,
-		&training.Level,
-		&training.Name,
-		&training.Descriptions,
-		&training.TimeLabel,
-		&training.CaloriesKcal,
-		&training.ThumbnailURL,
-		&training.VideoURL,
-		&training.ContentHTML,
+		tenant.ID(ctx),
+		training.Status,
+		training.AuthorUserID,
 	)
-
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
@@ -242,7 +333,98 @@ func (r *trainingRepository) Create(ctx context.Context, training *Training) (*T
 		return nil, err
 	}
 
-	return training, nil
+	return created, nil
+}
+
+// reviewColumns are the columns of a training read by the moderation lists
+const reviewColumns = `
+	t.id, tc.code AS category_code, t.level, t.name, t.status, t.author_user_id,
+	t.review_reason, t.reviewed_at, t.version, t.created_at, t.deleted_at`
+
+func (r *trainingRepository) ListByStatus(ctx context.Context, status string, limit int) ([]*Training, error) {
+	const q = `
+		SELECT ` + reviewColumns + `
+		FROM trainings t
+		JOIN training_categories tc ON tc.id = t.category_id
+		WHERE t.status = $1
+			AND t.organization_id IS NOT DISTINCT FROM $2
+			AND t.deleted_at IS NULL
+		ORDER BY t.created_at, t.id
+		LIMIT $3`
+
+	return database.Select[Training](ctx, r.db, q, status, tenant.ID(ctx), limit)
+}
+
+func (r *trainingRepository) ListByAuthor(ctx context.Context, userID string, limit int) ([]*Training, error) {
+	const q = `
+		SELECT ` + reviewColumns + `
+		FROM trainings t
+		JOIN training_categories tc ON tc.id = t.category_id
+		WHERE t.author_user_id = $1
+			AND t.deleted_at IS NULL
+		ORDER BY t.created_at DESC, t.id
+		LIMIT $2`
+
+	return database.Select[Training](ctx, r.db, q, userID, limit)
+}
+
+func (r *trainingRepository) ListDeleted(ctx context.Context, limit int) ([]*Training, error) {
+	const q = `
+		SELECT ` + reviewColumns + `
+		FROM trainings t
+		JOIN training_categories tc ON tc.id = t.category_id
+		WHERE t.deleted_at IS NOT NULL
+			AND t.organization_id IS NOT DISTINCT FROM $1
+		ORDER BY t.deleted_at DESC, t.id
+		LIMIT $2`
+
+	return database.Select[Training](ctx, r.db, q, tenant.ID(ctx), limit)
+}
+
+func (r *trainingRepository) Review(ctx context.Context, training *Training, reviewerID string, version *int) error {
+	// Checked on the row, two admins deciding at once don't both notify the author
+	const q = `
+		UPDATE trainings
+		SET status = $2, review_reason = $3, reviewed_by = $4, reviewed_at = NOW(), updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+			AND status = 'pending_review'
+			AND organization_id IS NOT DISTINCT FROM $5
+			AND deleted_at IS NULL
+			AND ($6::int IS NULL OR version = $6)
+		RETURNING reviewed_at, version`
+
+	err := r.db.QueryRow(ctx, q, training.ID, training.Status, training.ReviewReason, reviewerID, tenant.ID(ctx), version).
+		Scan(&training.ReviewedAt, &training.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r.updateConflict(ctx, training.ID, version, ErrTrainingNotPending)
+	}
+
+	return err
+}
+
+// updateConflict explains why an update of the training matched no row: missing, edited since
+// the expected version, or else err
+func (r *trainingRepository) updateConflict(ctx context.Context, id string, expected *int, err error) error {
+	const q = `
+		SELECT version
+		FROM trainings
+		WHERE id = $1
+			AND organization_id IS NOT DISTINCT FROM $2
+			AND deleted_at IS NULL`
+
+	var current int
+	if qerr := r.db.QueryRow(ctx, q, id, tenant.ID(ctx)).Scan(&current); qerr != nil {
+		if errors.Is(qerr, pgx.ErrNoRows) {
+			return ErrTrainingNotFound
+		}
+		return qerr
+	}
+
+	if stale := database.CheckVersion(expected, current); stale != nil {
+		return stale
+	}
+	return err
 }
 
 func (r *trainingRepository) GetLastSessionByUserId(ctx context.Context, userID string) (*TrainingSession, error) {
@@ -251,19 +433,12 @@ func (r *trainingRepository) GetLastSessionByUserId(ctx context.Context, userID
 			id, user_id, training_id, distance_meters, duration_seconds, pace, calories_kcal
 		FROM training_sessions
 		WHERE user_id = $1
+			AND organization_id IS NOT DISTINCT FROM $2
+			AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT 1`
 
-	var trainingSession TrainingSession
-	err := r.db.QueryRow(ctx, q, userID).Scan(
-		&trainingSession.ID,
-		&trainingSession.UserID,
-		&trainingSession.TrainingID,
-		&trainingSession.DistanceMeters,
-		&trainingSession.DurationSeconds,
-		&trainingSession.Pace,
-		&trainingSession.CaloriesKcal,
-	)
+	trainingSession, err := database.Get[TrainingSession](ctx, r.db, q, userID, tenant.ID(ctx))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -271,15 +446,30 @@ func (r *trainingRepository) GetLastSessionByUserId(ctx context.Context, userID
 		return nil, err
 	}
 
-	return &trainingSession, nil
+	return trainingSession, nil
 }
 
-func (r *trainingRepository) FinishSession(ctx context.Context, trainingSession *TrainingSession) (*TrainingSession, error) {
+// StreamSessionsByUserId calls fn for every session of the user, newest first, as rows arrive.
+// fn must not keep the session.
+func (r *trainingRepository) StreamSessionsByUserId(ctx context.Context, userID string, fn func(*TrainingSession) error) error {
 	const q = `
+		SELECT
+			id, user_id, training_id, distance_meters, duration_seconds, pace, calories_kcal, created_at
+		FROM training_sessions
+		WHERE user_id = $1
+			AND organization_id IS NOT DISTINCT FROM $2
+			AND deleted_at IS NULL
+		ORDER BY created_at DESC, id`
+
+	return database.Each(ctx, r.db, q, []any{userID, tenant.ID(ctx)}, fn)
+}
+
+func (r *trainingRepository) FinishSession(ctx context.Context, trainingSession *TrainingSession) (*TrainingSession, error) {
+	q := `
 		INSERT INTO training_sessions
-			(user_id, training_id, distance_meters, duration_seconds, pace, calories_kcal)
-			VALUES ($1, $2, $3, $4, $5, $6)
-			RETURNING id, pace`
+			(user_id, training_id, distance_meters, duration_seconds, pace, calories_kcal, organization_id, rpe, pool_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, ` + checkedInPool("now()") + `)
+			RETURNING id, pace, pool_id`
 
 	if err := r.db.QueryRow(ctx, q,
 		trainingSession.UserID,
@@ -288,9 +478,531 @@ func (r *trainingRepository) FinishSession(ctx context.Context, trainingSession
 		trainingSession.DurationSeconds,
 		trainingSession.Pace,
 		trainingSession.CaloriesKcal,
-	).Scan(&trainingSession.ID, &trainingSession.Pace); err != nil {
+		tenant.ID(ctx),
+		trainingSession.RPE,
+	).Scan(&trainingSession.ID, &trainingSession.Pace, &trainingSession.PoolID); err != nil {
 		return nil, err
 	}
 
 	return trainingSession, nil
 }
+
+// ImportSessions inserts every session in a single round trip, filling their IDs
+func (r *trainingRepository) ImportSessions(ctx context.Context, trainingSessions []*TrainingSession) error {
+	q := `
+		INSERT INTO training_sessions
+			(user_id, training_id, distance_meters, duration_seconds, pace, calories_kcal, created_at, organization_id, source, rpe, pool_id)
+			VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, now()), $8, $9, $10, ` + checkedInPool(sessionEnd) + `)
+			RETURNING id, pace, pool_id`
+
+	organizationId := tenant.ID(ctx)
+
+	return database.QueryBatch(ctx, r.db, q, trainingSessions,
+		func(s *TrainingSession) []any {
+			return []any{s.UserID, s.TrainingID, s.DistanceMeters, s.DurationSeconds, s.Pace, s.CaloriesKcal, s.StartedAt, organizationId, s.Source, s.RPE}
+		},
+		func(s *TrainingSession, row pgx.Row) error {
+			return row.Scan(&s.ID, &s.Pace, &s.PoolID)
+		},
+	)
+}
+
+// CreateLaps copies the laps of every given session with one COPY
+func (r *trainingRepository) CreateLaps(ctx context.Context, trainingSessions ...*TrainingSession) error {
+	type sessionLap struct {
+		sessionID string
+		lap       TrainingLap
+	}
+
+	var laps []sessionLap
+	for _, s := range trainingSessions {
+		for _, lap := range s.Laps {
+			laps = append(laps, sessionLap{sessionID: s.ID, lap: lap})
+		}
+	}
+
+	_, err := database.CopyRows(ctx, r.db, "training_session_laps",
+		[]string{"session_id", "lap_number", "distance_meters", "duration_seconds", "stroke_count", "avg_heart_rate"},
+		laps,
+		func(l sessionLap) []any {
+			return []any{l.sessionID, l.lap.Number, l.lap.DistanceMeters, l.lap.DurationSeconds, l.lap.StrokeCount, l.lap.AvgHeartRate}
+		},
+	)
+	return err
+}
+
+func (r *trainingRepository) UpdateMedia(ctx context.Context, id string, thumbnailURL, videoURL *string, version *int) (int, error) {
+	const q = `
+		UPDATE trainings
+		SET thumbnail_url = COALESCE($2, thumbnail_url),
+			video_url = COALESCE($3, video_url),
+			version = version + 1,
+			updated_at = now()
+		WHERE id = $1
+			AND organization_id IS NOT DISTINCT FROM $4
+			AND deleted_at IS NULL
+			AND ($5::int IS NULL OR version = $5)
+		RETURNING version`
+
+	// The shared catalog is read only for tenants, it is reported as missing
+	var current int
+	err := r.db.QueryRow(ctx, q, id, thumbnailURL, videoURL, tenant.ID(ctx), version).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, r.updateConflict(ctx, id, version, ErrTrainingNotFound)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return current, nil
+}
+
+func (r *trainingRepository) CheckMediaVersion(ctx context.Context, id string, version *int) (*string, error) {
+	const q = `
+		SELECT version, author_user_id
+		FROM trainings
+		WHERE id = $1
+			AND organization_id IS NOT DISTINCT FROM $2
+			AND deleted_at IS NULL`
+
+	var current int
+	var authorID *string
+	err := r.db.QueryRow(ctx, q, id, tenant.ID(ctx)).Scan(&current, &authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTrainingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return authorID, database.CheckVersion(version, current)
+}
+
+func (r *trainingRepository) GetSessionsByClientIds(ctx context.Context, userID string, clientIDs []string) ([]*TrainingSession, error) {
+	// Deleted sessions are matched too, syncing one again updates it and leaves it deleted
+	const q = `
+		SELECT
+			id, user_id, COALESCE(training_id::text, '') AS training_id, distance_meters, duration_seconds, pace,
+			calories_kcal, created_at, client_id, client_updated_at
+		FROM training_sessions
+		WHERE user_id = $1 AND client_id = ANY($2::uuid[])
+		FOR UPDATE`
+
+	trainingSessions, err := database.Select[TrainingSession](ctx, r.db, q, userID, clientIDs)
+	if err != nil || len(trainingSessions) == 0 {
+		return nil, err
+	}
+
+	byID := make(map[string]*TrainingSession, len(trainingSessions))
+	for _, s := range trainingSessions {
+		byID[s.ID] = s
+	}
+
+	if err := r.loadLaps(ctx, byID); err != nil {
+		return nil, err
+	}
+
+	return trainingSessions, nil
+}
+
+// loadLaps appends their laps, in recorded order, to the sessions keyed by ID
+func (r *trainingRepository) loadLaps(ctx context.Context, byID map[string]*TrainingSession) error {
+	const q = `
+		SELECT session_id, lap_number AS number, distance_meters, duration_seconds, stroke_count, avg_heart_rate
+		FROM training_session_laps
+		WHERE session_id = ANY($1::uuid[])
+		ORDER BY session_id, lap_number`
+
+	type sessionLap struct {
+		SessionID string
+		TrainingLap
+	}
+
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	laps, err := database.Select[sessionLap](ctx, r.db, q, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range laps {
+		byID[l.SessionID].Laps = append(byID[l.SessionID].Laps, l.TrainingLap)
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) CreateSyncedSessions(ctx context.Context, trainingSessions []*TrainingSession) error {
+	q := `
+		INSERT INTO training_sessions
+			(user_id, training_id, distance_meters, duration_seconds, pace, calories_kcal, created_at, organization_id,
+			client_id, client_updated_at, source, pool_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, ` + checkedInPool(sessionEnd) + `)
+			ON CONFLICT (user_id, client_id) WHERE client_id IS NOT NULL DO NOTHING
+			RETURNING id, pace, pool_id`
+
+	organizationId := tenant.ID(ctx)
+
+	return database.QueryBatch(ctx, r.db, q, trainingSessions,
+		func(s *TrainingSession) []any {
+			return []any{s.UserID, s.TrainingID, s.DistanceMeters, s.DurationSeconds, s.Pace, s.CaloriesKcal, s.StartedAt, organizationId,
+				s.ClientID, s.ClientUpdatedAt, s.Source}
+		},
+		func(s *TrainingSession, row pgx.Row) error {
+			return row.Scan(&s.ID, &s.Pace, &s.PoolID)
+		},
+	)
+}
+
+func (r *trainingRepository) UpdateSyncedSession(ctx context.Context, trainingSession *TrainingSession) error {
+	const q = `
+		UPDATE training_sessions
+		SET distance_meters = $2, duration_seconds = $3, pace = $4, calories_kcal = $5, client_updated_at = $6
+		WHERE id = $1
+		RETURNING pace`
+
+	if err := r.db.QueryRow(ctx, q,
+		trainingSession.ID,
+		trainingSession.DistanceMeters,
+		trainingSession.DurationSeconds,
+		trainingSession.Pace,
+		trainingSession.CaloriesKcal,
+		trainingSession.ClientUpdatedAt,
+	).Scan(&trainingSession.Pace); err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM training_session_laps WHERE session_id = $1`, trainingSession.ID); err != nil {
+		return err
+	}
+	return r.CreateLaps(ctx, trainingSession)
+}
+
+func (r *trainingRepository) FlagDuplicates(ctx context.Context, ids []string) (int, error) {
+	// Sessions finished in the app are stored at their end, the others at their start. A session
+	// of the same batch is only compared with the ones before it, so a pair is recorded once.
+	const q = `
+		WITH spans AS NOT MATERIALIZED (
+			SELECT
+				id, user_id, created_at, duration_seconds,
+				CASE WHEN source = 'manual' THEN created_at - make_interval(secs => duration_seconds) ELSE created_at END AS started_at
+			FROM training_sessions
+			WHERE deleted_at IS NULL
+		)
+		INSERT INTO training_session_duplicates (session_id, duplicate_of_id, overlap_seconds)
+		SELECT n.id, e.id, o.seconds
+		FROM spans n
+		JOIN spans e ON e.user_id = n.user_id
+			AND e.id <> n.id
+			AND e.created_at BETWEEN n.created_at - interval '1 day' AND n.created_at + interval '1 day'
+		CROSS JOIN LATERAL (
+			SELECT EXTRACT(EPOCH FROM
+				LEAST(n.started_at + make_interval(secs => n.duration_seconds), e.started_at + make_interval(secs => e.duration_seconds))
+				- GREATEST(n.started_at, e.started_at))::int AS seconds
+		) o
+		WHERE n.id = ANY($1::uuid[])
+			AND NOT (e.id = ANY($1::uuid[]) AND (e.started_at, e.id) > (n.started_at, n.id))
+			AND o.seconds * 2 >= LEAST(n.duration_seconds, e.duration_seconds)
+			AND NOT EXISTS (
+				SELECT 1 FROM training_session_duplicates x WHERE x.session_id = e.id AND x.duplicate_of_id = n.id
+			)
+		ON CONFLICT DO NOTHING`
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	tag, err := r.db.Exec(ctx, q, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// duplicateColumns selects a duplicate with both of its sessions, scanned by scanDuplicate
+const duplicateColumns = `
+	d.id, d.overlap_seconds, d.detected_at,
+	s.id, s.user_id, COALESCE(s.training_id::text, ''), s.distance_meters, s.duration_seconds, s.pace, s.calories_kcal,
+	s.created_at, s.source, s.client_id,
+	CASE WHEN s.source = 'manual' THEN s.created_at - make_interval(secs => s.duration_seconds) ELSE s.created_at END,
+	e.id, e.user_id, COALESCE(e.training_id::text, ''), e.distance_meters, e.duration_seconds, e.pace, e.calories_kcal,
+	e.created_at, e.source, e.client_id,
+	CASE WHEN e.source = 'manual' THEN e.created_at - make_interval(secs => e.duration_seconds) ELSE e.created_at END`
+
+// sessionEnd is when a session inserted with its start in $7 and its duration in $4 finished
+const sessionEnd = "COALESCE($7::timestamptz + $4::int * interval '1 second', now())"
+
+// checkedInPool selects the pool the user of $1 was checked in at when the sql expression at was
+func checkedInPool(at string) string {
+	return `(SELECT pool_id FROM pool_check_ins
+		WHERE user_id = $1 AND checked_in_at <= ` + at + ` AND ` + at + ` < COALESCE(checked_out_at, expires_at)
+		ORDER BY checked_in_at DESC LIMIT 1)`
+}
+
+func scanDuplicate(row pgx.Row) (*SessionDuplicate, error) {
+	var d SessionDuplicate
+	if err := row.Scan(
+		&d.ID, &d.OverlapSeconds, &d.DetectedAt,
+		&d.Session.ID, &d.Session.UserID, &d.Session.TrainingID, &d.Session.DistanceMeters, &d.Session.DurationSeconds,
+		&d.Session.Pace, &d.Session.CaloriesKcal, &d.Session.CreatedAt, &d.Session.Source, &d.Session.ClientID, &d.Session.StartedAt,
+		&d.DuplicateOf.ID, &d.DuplicateOf.UserID, &d.DuplicateOf.TrainingID, &d.DuplicateOf.DistanceMeters, &d.DuplicateOf.DurationSeconds,
+		&d.DuplicateOf.Pace, &d.DuplicateOf.CaloriesKcal, &d.DuplicateOf.CreatedAt, &d.DuplicateOf.Source, &d.DuplicateOf.ClientID, &d.DuplicateOf.StartedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *trainingRepository) ListDuplicates(ctx context.Context, userID string, limit int) ([]*SessionDuplicate, error) {
+	const q = `
+		SELECT ` + duplicateColumns + `
+		FROM training_session_duplicates d
+		JOIN training_sessions s ON s.id = d.session_id
+		JOIN training_sessions e ON e.id = d.duplicate_of_id
+		WHERE s.user_id = $1 AND d.dismissed_at IS NULL
+			AND s.deleted_at IS NULL AND e.deleted_at IS NULL
+		ORDER BY d.detected_at DESC, d.id
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, q, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var duplicates []*SessionDuplicate
+	for rows.Next() {
+		d, err := scanDuplicate(rows)
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, d)
+	}
+
+	return duplicates, rows.Err()
+}
+
+func (r *trainingRepository) GetDuplicate(ctx context.Context, userID, id string) (*SessionDuplicate, error) {
+	const q = `
+		SELECT ` + duplicateColumns + `
+		FROM training_session_duplicates d
+		JOIN training_sessions s ON s.id = d.session_id
+		JOIN training_sessions e ON e.id = d.duplicate_of_id
+		WHERE d.id = $1 AND s.user_id = $2 AND d.dismissed_at IS NULL
+			AND s.deleted_at IS NULL AND e.deleted_at IS NULL
+		FOR UPDATE OF d`
+
+	d, err := scanDuplicate(r.db.QueryRow(ctx, q, id, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSessionDuplicateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (r *trainingRepository) MergeSessions(ctx context.Context, keep, drop *TrainingSession) error {
+	// Laps are moved whole, mixing the laps of two recordings would not add up
+	const lapsQ = `
+		UPDATE training_session_laps SET session_id = $1
+		WHERE session_id = $2
+			AND NOT EXISTS (SELECT 1 FROM training_session_laps WHERE session_id = $1)`
+
+	if _, err := r.db.Exec(ctx, lapsQ, keep.ID, drop.ID); err != nil {
+		return err
+	}
+
+	const conditionsQ = `
+		UPDATE training_session_conditions SET session_id = $1
+		WHERE session_id = $2
+			AND NOT EXISTS (SELECT 1 FROM training_session_conditions WHERE session_id = $1)`
+
+	if _, err := r.db.Exec(ctx, conditionsQ, keep.ID, drop.ID); err != nil {
+		return err
+	}
+
+	// Deleted first, the client id is unique per user
+	const deleteQ = `DELETE FROM training_sessions WHERE id = $1 RETURNING training_id, client_id, client_updated_at`
+
+	var trainingID, clientID *string
+	var clientUpdatedAt *time.Time
+	if err := r.db.QueryRow(ctx, deleteQ, drop.ID).Scan(&trainingID, &clientID, &clientUpdatedAt); err != nil {
+		return err
+	}
+
+	// The client id follows the kept session so syncing the dropped one again updates it
+	const keepQ = `
+		UPDATE training_sessions
+		SET training_id = COALESCE(training_id, $2),
+			client_id = COALESCE(client_id, $3),
+			client_updated_at = CASE WHEN client_id IS NULL THEN $4 ELSE client_updated_at END
+		WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, keepQ, keep.ID, trainingID, clientID, clientUpdatedAt)
+	return err
+}
+
+func (r *trainingRepository) DismissDuplicate(ctx context.Context, userID, id string) error {
+	const q = `
+		UPDATE training_session_duplicates d
+		SET dismissed_at = now()
+		FROM training_sessions s
+		WHERE d.id = $1 AND s.id = d.session_id AND s.user_id = $2 AND d.dismissed_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, q, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSessionDuplicateNotFound
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) GetSessionById(ctx context.Context, userID, id string) (*TrainingSession, error) {
+	const q = `
+		SELECT
+			ts.id, ts.user_id, COALESCE(ts.training_id::text, '') AS training_id, ts.distance_meters, ts.duration_seconds,
+			ts.pace, ts.calories_kcal, ts.rpe, ts.created_at, ts.source, ts.pool_id,
+			CASE WHEN ts.source = 'manual' THEN ts.created_at - make_interval(secs => ts.duration_seconds) ELSE ts.created_at END AS started_at,
+			COALESCE(tc.code, '') AS category_code,
+			c.session_id IS NOT NULL AS has_conditions, c.water_temperature_c, c.wave_height_m AS wave_height_meters,
+			c.current_speed_kmh, c.wave_notes, c.current_notes, COALESCE(c.wetsuit, false) AS wetsuit, c.latitude, c.longitude,
+			COALESCE(c.auto_filled, false) AS auto_filled, c.updated_at AS conditions_updated_at
+		FROM training_sessions ts
+		LEFT JOIN trainings t ON t.id = ts.training_id
+		LEFT JOIN training_categories tc ON tc.id = t.category_id
+		LEFT JOIN training_session_conditions c ON c.session_id = ts.id
+		WHERE ts.id = $1 AND ts.user_id = $2 AND ts.deleted_at IS NULL`
+
+	// The conditions are outer joined, their columns are NULL for a session without conditions
+	type sessionRow struct {
+		TrainingSession
+		SessionConditions
+		HasConditions       bool
+		ConditionsUpdatedAt *time.Time
+	}
+
+	row, err := database.Get[sessionRow](ctx, r.db, q, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	s := row.TrainingSession
+	if row.HasConditions {
+		c := row.SessionConditions
+		c.SessionID = s.ID
+		c.UpdatedAt = *row.ConditionsUpdatedAt
+		s.Conditions = &c
+	}
+
+	if err := r.loadLaps(ctx, map[string]*TrainingSession{s.ID: &s}); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (r *trainingRepository) SaveConditions(ctx context.Context, conditions []*SessionConditions) error {
+	const q = `
+		INSERT INTO training_session_conditions
+			(session_id, water_temperature_c, wave_height_m, current_speed_kmh, wave_notes, current_notes, wetsuit,
+			latitude, longitude, auto_filled)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (session_id) DO UPDATE SET
+				water_temperature_c = EXCLUDED.water_temperature_c,
+				wave_height_m = EXCLUDED.wave_height_m,
+				current_speed_kmh = EXCLUDED.current_speed_kmh,
+				wave_notes = EXCLUDED.wave_notes,
+				current_notes = EXCLUDED.current_notes,
+				wetsuit = EXCLUDED.wetsuit,
+				latitude = EXCLUDED.latitude,
+				longitude = EXCLUDED.longitude,
+				auto_filled = EXCLUDED.auto_filled,
+				updated_at = now()
+			RETURNING updated_at`
+
+	return database.QueryBatch(ctx, r.db, q, conditions,
+		func(c *SessionConditions) []any {
+			return []any{c.SessionID, c.WaterTemperatureC, c.WaveHeightMeters, c.CurrentSpeedKmh, c.WaveNotes, c.CurrentNotes, c.Wetsuit,
+				c.Latitude, c.Longitude, c.AutoFilled}
+		},
+		func(c *SessionConditions, row pgx.Row) error {
+			return row.Scan(&c.UpdatedAt)
+		},
+	)
+}
+
+func (r *trainingRepository) DeleteTraining(ctx context.Context, id string) error {
+	// Like media, the shared catalog is read only for tenants
+	deleted, err := database.SoftDelete(ctx, r.db, "trainings", id, "organization_id IS NOT DISTINCT FROM $2", tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTrainingNotFound
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) RestoreTraining(ctx context.Context, id string) error {
+	restored, err := database.Restore(ctx, r.db, "trainings", id, "organization_id IS NOT DISTINCT FROM $2", tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return ErrTrainingNotFound
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) DeleteSession(ctx context.Context, userID, id string) error {
+	deleted, err := database.SoftDelete(ctx, r.db, "training_sessions", id, "user_id = $2", userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) RestoreSession(ctx context.Context, id string) error {
+	restored, err := database.Restore(ctx, r.db, "training_sessions", id, "organization_id IS NOT DISTINCT FROM $2", tenant.ID(ctx))
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *trainingRepository) ListDeletedSessions(ctx context.Context, userID string, limit int) ([]*TrainingSession, error) {
+	const q = `
+		SELECT
+			id, user_id, COALESCE(training_id::text, '') AS training_id, distance_meters, duration_seconds, pace,
+			calories_kcal, rpe, created_at, deleted_at
+		FROM training_sessions
+		WHERE user_id = $1
+			AND organization_id IS NOT DISTINCT FROM $2
+			AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id
+		LIMIT $3`
+
+	return database.Select[TrainingSession](ctx, r.db, q, userID, tenant.ID(ctx), limit)
+}
@@ -0,0 +1,37 @@
+package training
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the training endpoints, all of them require authentication and the
+// moderation ones an admin account
+func (h *TrainingHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("GET /api/v1/trainings/{id}", mw.Protected(http.HandlerFunc(h.GetById)))
+	mux.Handle("GET /api/v1/trainings", mw.Expensive(http.HandlerFunc(h.GetTrainings)))
+	mux.Handle("POST /api/v1/trainings", mw.Protected(http.HandlerFunc(h.CreateTraining)))
+	mux.Handle("GET /api/v1/trainings/sessions/last", mw.Protected(http.HandlerFunc(h.GetLastSession)))
+	mux.Handle("GET /api/v1/trainings/sessions/export", mw.Protected(http.HandlerFunc(h.ExportSessions)))
+	mux.Handle("POST /api/v1/trainings/sessions/import", mw.Protected(http.HandlerFunc(h.ImportSessions)))
+	mux.Handle("GET /api/v1/trainings/sessions/{id}", mw.Protected(http.HandlerFunc(h.GetSession)))
+	mux.Handle("DELETE /api/v1/trainings/sessions/{id}", mw.Protected(http.HandlerFunc(h.DeleteSession)))
+	mux.Handle("PUT /api/v1/trainings/sessions/{id}/conditions", mw.Protected(http.HandlerFunc(h.UpdateConditions)))
+	mux.Handle("GET /api/v1/trainings/sessions/duplicates", mw.Protected(http.HandlerFunc(h.ListDuplicates)))
+	mux.Handle("POST /api/v1/trainings/sessions/duplicates/{id}/merge", mw.Protected(http.HandlerFunc(h.MergeDuplicate)))
+	mux.Handle("POST /api/v1/trainings/sessions/duplicates/{id}/dismiss", mw.Protected(http.HandlerFunc(h.DismissDuplicate)))
+	mux.Handle("POST /api/v1/sync/sessions", mw.Protected(http.HandlerFunc(h.SyncSessions)))
+	mux.Handle("POST /api/v1/trainings/sessions/export/file", mw.Protected(http.HandlerFunc(h.ExportSessionsFile)))
+	mux.Handle("POST /api/v1/trainings/{id}/finish", mw.Protected(http.HandlerFunc(h.FinishSession)))
+	mux.Handle("PUT /api/v1/trainings/{id}/media", mw.Upload(http.HandlerFunc(h.UploadMedia)))
+	mux.Handle("GET /api/v1/trainings/submissions", mw.Protected(http.HandlerFunc(h.ListSubmissions)))
+	mux.Handle("GET /api/v1/admin/trainings", mw.Admin(http.HandlerFunc(h.ListReviews)))
+	mux.Handle("POST /api/v1/admin/trainings/{id}/approve", mw.Admin(http.HandlerFunc(h.ApproveTraining)))
+	mux.Handle("POST /api/v1/admin/trainings/{id}/reject", mw.Admin(http.HandlerFunc(h.RejectTraining)))
+	mux.Handle("GET /api/v1/admin/trainings/deleted", mw.Admin(http.HandlerFunc(h.ListDeletedTrainings)))
+	mux.Handle("DELETE /api/v1/admin/trainings/{id}", mw.Admin(http.HandlerFunc(h.DeleteTraining)))
+	mux.Handle("POST /api/v1/admin/trainings/{id}/restore", mw.Admin(http.HandlerFunc(h.RestoreTraining)))
+	mux.Handle("POST /api/v1/admin/trainings/sessions/{id}/restore", mw.Admin(http.HandlerFunc(h.RestoreSession)))
+	mux.Handle("GET /api/v1/admin/users/{id}/sessions/deleted", mw.Admin(http.HandlerFunc(h.ListDeletedSessions)))
+}
@@ -0,0 +1,216 @@
+package training
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Sync statuses of a session
+const (
+	SyncCreated   = "created"
+	SyncUpdated   = "updated"
+	SyncUnchanged = "unchanged"
+	SyncRejected  = "rejected"
+)
+
+// maxSyncSessions caps a sync batch to keep it within one body and transaction
+const maxSyncSessions = 500
+
+// ErrSyncInProgress is returned when another request created a session of the batch meanwhile
+var ErrSyncInProgress = errors.New("sync in progress")
+
+// SyncSessions stores sessions recorded offline, deduplicated by the client id generated on the
+// device. Syncing the same batch again is a no-op, so the app can retry after a lost response.
+func (u *trainingUsecase) SyncSessions(ctx context.Context, userId string, req *TrainingSyncSessionsRequest) (*TrainingSyncSessionsResponse, error) {
+	res := &TrainingSyncSessionsResponse{Results: make(map[string]TrainingSyncResult, len(req.Sessions))}
+
+	// A client id sent twice keeps its latest edit
+	items := make(map[string]*TrainingSyncSessionRequest, len(req.Sessions))
+	for i := range req.Sessions {
+		item := &req.Sessions[i]
+		if err := item.Validate(); err != nil {
+			res.Results[syncKey(item, i)] = TrainingSyncResult{Status: SyncRejected, Errors: err.(*validator.ValidationError).Errors}
+			continue
+		}
+		if prev, ok := items[item.ClientID]; ok && !item.UpdatedAt.After(prev.UpdatedAt) {
+			continue
+		}
+		items[item.ClientID] = item
+	}
+	if len(items) == 0 {
+		return res, nil
+	}
+
+	user, err := u.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	bmr := user.GetBMR()
+
+	clientIDs := make([]string, 0, len(items))
+	for clientID := range items {
+		clientIDs = append(clientIDs, clientID)
+	}
+	slices.Sort(clientIDs)
+
+	results := make(map[string]TrainingSyncResult, len(items))
+	var duplicates int
+	err = database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.trainingRepo.WithTx(tx)
+
+		existing, err := repo.GetSessionsByClientIds(ctx, userId, clientIDs)
+		if err != nil {
+			return err
+		}
+
+		stored := make(map[string]*TrainingSession, len(existing))
+		for _, s := range existing {
+			stored[*s.ClientID] = s
+		}
+
+		mets := make(map[string]float32)
+		var created, updated []*TrainingSession
+
+		for _, clientID := range clientIDs {
+			item := items[clientID]
+
+			if s, ok := stored[clientID]; ok {
+				changed, conflicts := mergeSyncedSession(s, item)
+				status := SyncUnchanged
+				if changed {
+					if err := repo.UpdateSyncedSession(ctx, s); err != nil {
+						return err
+					}
+					updated = append(updated, s)
+					status = SyncUpdated
+				}
+				results[clientID] = TrainingSyncResult{Status: status, Session: newTrainingSessionResponse(s), Conflicts: conflicts}
+				continue
+			}
+
+			met, ok := mets[item.TrainingID]
+			if !ok {
+				trainingCategory, err := u.trainingRepo.GetTrainingCategoryByTrainingId(ctx, item.TrainingID)
+				if errors.Is(err, ErrTrainingCategoryNotFound) || errors.Is(err, ErrTrainingNotFound) {
+					results[clientID] = TrainingSyncResult{Status: SyncRejected, Errors: map[string]string{"trainingId": "Training not found"}}
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				met = trainingCategory.MET
+				mets[item.TrainingID] = met
+			}
+
+			s := NewTrainingSession(userId, item.TrainingID, item.DistanceMeters, item.DurationSeconds, bmr, met)
+			s.StartedAt = &item.StartedAt
+			s.ClientID = &item.ClientID
+			s.ClientUpdatedAt = &item.UpdatedAt
+			s.Source = SourceSync
+			s.Laps = newTrainingLaps(item.Laps)
+			created = append(created, s)
+		}
+
+		if err := repo.CreateSyncedSessions(ctx, created); err != nil {
+			return err
+		}
+		for _, s := range created {
+			// Left without ID when a concurrent sync inserted the client id first
+			if s.ID == "" {
+				return ErrSyncInProgress
+			}
+			results[*s.ClientID] = TrainingSyncResult{Status: SyncCreated, Session: newTrainingSessionResponse(s)}
+		}
+
+		if err := repo.CreateLaps(ctx, created...); err != nil {
+			return err
+		}
+
+		// An updated duration can make a session overlap another one
+		duplicates, err = repo.FlagDuplicates(ctx, sessionIDs(append(created, updated...)))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.PossibleDuplicates = duplicates
+
+	for clientID, result := range results {
+		res.Results[clientID] = result
+	}
+	return res, nil
+}
+
+// mergeSyncedSession applies a synced item to the stored session field by field. The training and
+// start time identify the session, the server wins on them. Distance, duration and laps are latest
+// wins by the edit time on the device. Returns whether the session changed and the fields where
+// the item lost.
+func mergeSyncedSession(s *TrainingSession, item *TrainingSyncSessionRequest) (changed bool, conflicts []string) {
+	if s.TrainingID != item.TrainingID {
+		conflicts = append(conflicts, "trainingId")
+	}
+	if !s.CreatedAt.Equal(item.StartedAt.Truncate(time.Microsecond)) {
+		conflicts = append(conflicts, "startedAt")
+	}
+
+	laps := newTrainingLaps(item.Laps)
+
+	var differs []string
+	if s.DistanceMeters != item.DistanceMeters {
+		differs = append(differs, "distanceMeters")
+	}
+	if s.DurationSeconds != item.DurationSeconds {
+		differs = append(differs, "durationSeconds")
+	}
+	if !slices.EqualFunc(s.Laps, laps, equalLap) {
+		differs = append(differs, "laps")
+	}
+	if len(differs) == 0 {
+		return false, conflicts
+	}
+
+	if s.ClientUpdatedAt != nil && !item.UpdatedAt.After(*s.ClientUpdatedAt) {
+		return false, append(conflicts, differs...)
+	}
+
+	// Calories scale with the duration, the training and body of the recorded session are kept
+	if s.DurationSeconds > 0 {
+		s.CaloriesKcal = int(math.Round(float64(s.CaloriesKcal) * float64(item.DurationSeconds) / float64(s.DurationSeconds)))
+	}
+	s.DistanceMeters = item.DistanceMeters
+	s.DurationSeconds = item.DurationSeconds
+	s.Pace = calculatePace(item.DistanceMeters, item.DurationSeconds)
+	s.Laps = laps
+	s.ClientUpdatedAt = &item.UpdatedAt
+
+	return true, conflicts
+}
+
+func equalLap(a, b TrainingLap) bool {
+	return a.Number == b.Number &&
+		a.DistanceMeters == b.DistanceMeters &&
+		a.DurationSeconds == b.DurationSeconds &&
+		equalInt(a.StrokeCount, b.StrokeCount) &&
+		equalInt(a.AvgHeartRate, b.AvgHeartRate)
+}
+
+func equalInt(a, b *int) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+// syncKey keys the result of an item, by index when it has no client id
+func syncKey(item *TrainingSyncSessionRequest, i int) string {
+	if item.ClientID != "" {
+		return item.ClientID
+	}
+	return strconv.Itoa(i)
+}
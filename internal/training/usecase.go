@@ -2,9 +2,32 @@ package training
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/internal/abuse"
 	"github.com/rizkyharahap/swimo/internal/user"
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+	"github.com/rizkyharahap/swimo/pkg/broker"
+	"github.com/rizkyharahap/swimo/pkg/cache"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+	"github.com/rizkyharahap/swimo/pkg/pagination"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/security"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+	"github.com/rizkyharahap/swimo/pkg/weather"
 )
 
 var (
@@ -13,33 +36,110 @@ var (
 )
 
 type TrainingUsecase interface {
-	GetById(ctx context.Context, id string) (*TrainingResponse, error)
-	GetTrainings(ctx context.Context, query *TrainingsQuery) (trainingItems []TrainingItemResponse, totalPages int, err error)
-	CreateTraining(ctx context.Context, req *TrainingRequest) (*TrainingResponse, error)
+	// GetById returns an approved training, or one under review to its author and admins, with
+	// the aggregates of include embedded
+	GetById(ctx context.Context, claim *security.Claim, id string, include []string) (*TrainingResponse, error)
+	// GetTrainings returns a page of the catalog, empty rather than ErrTrainingNotFound without results
+	GetTrainings(ctx context.Context, query *TrainingsQuery) (trainingItems []TrainingItemResponse, total pagination.Total, err error)
+	// GetTrainingFacets counts the trainings of the list by value of the facets of the query
+	GetTrainingFacets(ctx context.Context, query *TrainingsQuery) (response.Facets, error)
+	// CreateTraining adds a training to the catalog, trainings of non admins wait for review
+	CreateTraining(ctx context.Context, claim *security.Claim, req *TrainingRequest) (*TrainingResponse, error)
+	ListSubmissions(ctx context.Context, userId string) ([]TrainingReviewResponse, error)
+	ListReviews(ctx context.Context, status string) ([]TrainingReviewResponse, error)
+	ApproveTraining(ctx context.Context, reviewerId, id string, req *TrainingApproveRequest) (*TrainingReviewResponse, error)
+	RejectTraining(ctx context.Context, reviewerId, id string, req *TrainingRejectRequest) (*TrainingReviewResponse, error)
+	// DeleteTraining removes a training from the catalog until it is restored
+	DeleteTraining(ctx context.Context, id string) error
+	RestoreTraining(ctx context.Context, id string) error
+	ListDeletedTrainings(ctx context.Context) ([]TrainingReviewResponse, error)
+	// DeleteSession removes a session of the user from their history until an admin restores it
+	DeleteSession(ctx context.Context, userId, id string) error
+	RestoreSession(ctx context.Context, id string) error
+	ListDeletedSessions(ctx context.Context, userId string) ([]TrainingDeletedSessionResponse, error)
 	GetLastSession(ctx context.Context, userId string) (*TrainingSessionResponse, error)
-	FinishSession(ctx context.Context, userId string, trainingId string, req *TrainingFinishSessionRequest) (*TrainingSessionResponse, error)
+	// FinishSession records the session of the user, guests keep no history and must sign up
+	FinishSession(ctx context.Context, claim *security.Claim, trainingId string, req *TrainingFinishSessionRequest) (*TrainingSessionResponse, error)
+	ImportSessions(ctx context.Context, userId string, req *TrainingImportSessionsRequest) (*TrainingImportSessionsResponse, error)
+	SyncSessions(ctx context.Context, userId string, req *TrainingSyncSessionsRequest) (*TrainingSyncSessionsResponse, error)
+	ListDuplicates(ctx context.Context, userId string) ([]TrainingDuplicateResponse, error)
+	MergeDuplicate(ctx context.Context, userId, id string, req *TrainingMergeDuplicateRequest) error
+	DismissDuplicate(ctx context.Context, userId, id string) error
+	GetSession(ctx context.Context, userId, id string) (*TrainingSessionDetailResponse, error)
+	UpdateConditions(ctx context.Context, userId, id string, req *TrainingConditionsRequest) (*TrainingSessionDetailResponse, error)
+	ExportSessions(ctx context.Context, userId string, fn func(*TrainingSessionExportResponse) error) error
+	ExportSessionsFile(ctx context.Context, userId string) (*TrainingExportFileResponse, error)
+	// UploadMedia stores a thumbnail or video of a training and returns its version, only its
+	// author and admins may change it
+	UploadMedia(ctx context.Context, claim *security.Claim, id, field string, file io.Reader, contentType string, version *int) (int, error)
 }
 
+// Cache keys for the training catalog
+const (
+	cacheKeyTraining     = "training:id:"
+	cacheKeyTrainingList = "training:list:"
+)
+
+// Names of the training caches, the cache label of the lookup metrics
+const (
+	cacheTraining       = "training"
+	cacheTrainingList   = "training_list"
+	cacheTrainingCount  = "training_count"
+	cacheTrainingFacets = "training_facets"
+)
+
 type trainingUsecase struct {
+	pool         *pgxpool.Pool
 	trainingRepo TrainingRepository
 	userRepo     user.UserRepository
+	publisher    broker.Publisher
+	cache        cache.Cache
+	cacheTTL     time.Duration
+	countTTL     time.Duration
+	lookups      *metrics.Counter
+	files        storage.Storage
+	baseURL      string
+	signTTL      time.Duration
+	tracker      analytics.Tracker
+	weather      weather.Provider
+	abuse        abuse.AbuseUsecase
+}
+
+// trainingListCache is the cached result of a training list page
+type trainingListCache struct {
+	Items []TrainingItemResponse `json:"items"`
+	Total pagination.Total       `json:"total"`
 }
 
-func NewTrainingUsecase(trainingRepo TrainingRepository, userRepo user.UserRepository) TrainingUsecase {
-	return &trainingUsecase{trainingRepo, userRepo}
+func NewTrainingUsecase(pool *pgxpool.Pool, trainingRepo TrainingRepository, userRepo user.UserRepository, publisher broker.Publisher, cache cache.Cache, cacheTTL, countTTL time.Duration, lookups *metrics.Counter, files storage.Storage, baseURL string, signTTL time.Duration, tracker analytics.Tracker, weather weather.Provider, abuseUsecase abuse.AbuseUsecase) TrainingUsecase {
+	return &trainingUsecase{pool, trainingRepo, userRepo, publisher, cache, cacheTTL, countTTL, lookups, files, baseURL, signTTL, tracker, weather, abuseUsecase}
 }
 
-func (u *trainingUsecase) GetById(ctx context.Context, id string) (*TrainingResponse, error) {
-	training, err := u.trainingRepo.GetById(ctx, id)
+func (u *trainingUsecase) GetById(ctx context.Context, claim *security.Claim, id string, include []string) (*TrainingResponse, error) {
+	// Only approved trainings are cached, they are visible to everyone. Aggregates are per user,
+	// with any the training is read along with them rather than in a second query.
+	var cached TrainingResponse
+	cacheKey := scopedKey(ctx, cacheKeyTraining) + id
+	if len(include) == 0 && u.cacheGet(ctx, cacheTraining, cacheKey, &cached) {
+		u.trackView(ctx, &cached)
+		return &cached, nil
+	}
+
+	var userID *string
+	if claim != nil {
+		userID = claim.Uid
+	}
+
+	training, err := u.trainingRepo.GetWithAggregates(ctx, id, userID, include)
 	if err != nil {
 		return nil, err
 	}
 
-	if training == nil {
+	if training == nil || !canView(claim, training) {
 		return nil, ErrTrainingNotFound
 	}
 
-	return &TrainingResponse{
+	res := &TrainingResponse{
 		ID:           training.ID,
 		Level:        training.Level,
 		Name:         training.Name,
@@ -51,7 +151,30 @@ func (u *trainingUsecase) GetById(ctx context.Context, id string) (*TrainingResp
 		ContentHTML:  training.ContentHTML,
 		CategoryCode: training.CategoryCode,
 		CategoryName: *training.CategoryName,
-	}, nil
+		Status:       training.Status,
+		ReviewReason: training.ReviewReason,
+		Version:      training.Version,
+	}
+
+	if training.Status == StatusApproved {
+		u.cacheSet(ctx, cacheKey, res, u.cacheTTL)
+		u.trackView(ctx, res)
+	}
+
+	if len(include) > 0 {
+		res.Aggregates = &TrainingAggregatesResponse{Completions: training.Completions}
+	}
+
+	return res, nil
+}
+
+// trackView records a training_viewed event, cached reads count as views too
+func (u *trainingUsecase) trackView(ctx context.Context, res *TrainingResponse) {
+	u.tracker.Track(ctx, analytics.Event{Name: analytics.EventTrainingViewed, Properties: map[string]any{
+		"trainingId":   res.ID,
+		"categoryCode": res.CategoryCode,
+		"level":        res.Level,
+	}})
 }
 
 func (uc *trainingUsecase) GetLastSession(ctx context.Context, userId string) (*TrainingSessionResponse, error) {
@@ -64,19 +187,120 @@ func (uc *trainingUsecase) GetLastSession(ctx context.Context, userId string) (*
 		return nil, ErrTrainingSessionNotFound
 	}
 
-	return (*TrainingSessionResponse)(training), nil
+	return newTrainingSessionResponse(training), nil
+}
+
+// ExportSessions streams every session of the user to fn without loading the history in memory
+func (uc *trainingUsecase) ExportSessions(ctx context.Context, userId string, fn func(*TrainingSessionExportResponse) error) error {
+	return uc.trainingRepo.StreamSessionsByUserId(ctx, userId, func(s *TrainingSession) error {
+		return fn(&TrainingSessionExportResponse{
+			TrainingSessionResponse: *newTrainingSessionResponse(s),
+			CreatedAt:               s.CreatedAt,
+		})
+	})
 }
 
-func (u *trainingUsecase) GetTrainings(ctx context.Context, query *TrainingsQuery) (trainingItems []TrainingItemResponse, totalPages int, err error) {
-	trainings, total, err := u.trainingRepo.GetList(ctx, query)
+// ExportSessionsFile writes the session export to storage as NDJSON and returns a signed
+// link to it, for clients that download in the background. Exports are kept under
+// exports/, old ones are expected to be expired by a bucket lifecycle rule.
+func (uc *trainingUsecase) ExportSessionsFile(ctx context.Context, userId string) (*TrainingExportFileResponse, error) {
+	key := fmt.Sprintf("exports/%s/sessions-%s.ndjson", userId, time.Now().UTC().Format("20060102T150405Z"))
+
+	// Rows are encoded while the previous ones upload, the history never sits in memory
+	pr, pw := io.Pipe()
+	go func() {
+		enc := json.NewEncoder(pw)
+		pw.CloseWithError(uc.ExportSessions(ctx, userId, func(s *TrainingSessionExportResponse) error {
+			return enc.Encode(s)
+		}))
+	}()
+
+	if err := uc.files.Put(ctx, key, pr, "application/x-ndjson"); err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+
+	url, err := uc.files.SignURL(ctx, key, uc.signTTL)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	if len(trainings) == 0 {
-		return nil, 0, ErrTrainingNotFound
+	return &TrainingExportFileResponse{URL: url, ExpiresAt: time.Now().Add(uc.signTTL).UTC()}, nil
+}
+
+// UploadMedia stores a thumbnail or video of the training and points the training to it, with a
+// version only when the training was not edited since. Returns the new version of the training.
+func (u *trainingUsecase) UploadMedia(ctx context.Context, claim *security.Claim, id, field string, file io.Reader, contentType string, version *int) (int, error) {
+	ext, ok := mediaTypes[field][contentType]
+	if !ok {
+		return 0, ErrMediaType
 	}
 
+	// A missing, stale or foreign training is reported before the file is streamed, an edit
+	// racing the upload is still caught by UpdateMedia
+	authorID, err := u.trainingRepo.CheckMediaVersion(ctx, id, version)
+	if err != nil {
+		return 0, err
+	}
+	if !canEdit(claim, authorID) {
+		return 0, ErrNotTrainingAuthor
+	}
+
+	suffix := make([]byte, 8)
+	rand.Read(suffix)
+	key := fmt.Sprintf("trainings/%s/%s-%s%s", id, field, hex.EncodeToString(suffix), ext)
+
+	if err := u.files.Put(ctx, key, file, contentType); err != nil {
+		return 0, err
+	}
+
+	url := storage.MediaURL(u.baseURL, key)
+
+	var thumbnailURL, videoURL *string
+	if field == "thumbnail" {
+		thumbnailURL = &url
+	} else {
+		videoURL = &url
+	}
+
+	current, err := u.trainingRepo.UpdateMedia(ctx, id, thumbnailURL, videoURL, version)
+	if err != nil {
+		// Deleted even when the request was canceled, or the object is left behind
+		if err := u.files.Delete(context.WithoutCancel(ctx), key); err != nil {
+			logger.FromContext(ctx).Warn("failed to delete orphan training media", "key", key, "error", err)
+		}
+		return 0, err
+	}
+
+	u.invalidateTraining(ctx, id)
+
+	return current, nil
+}
+
+func (u *trainingUsecase) GetTrainings(ctx context.Context, query *TrainingsQuery) (trainingItems []TrainingItemResponse, total pagination.Total, err error) {
+	cacheKey := fmt.Sprintf("%s%d:%d:%s:%s:%s:%s", scopedKey(ctx, cacheKeyTrainingList), query.Page, query.Limit, query.Sort.String(), query.Category, query.Level, query.Search)
+
+	var cached trainingListCache
+	if u.cacheGet(ctx, cacheTrainingList, cacheKey, &cached) {
+		return cached.Items, cached.Total, nil
+	}
+
+	trainings, err := u.trainingRepo.GetList(ctx, query)
+	if err != nil {
+		return nil, total, err
+	}
+
+	// An empty first page has nothing to count, past the last page the totals still tell the
+	// client where the list ends
+	if len(trainings) > 0 || query.Page > 1 {
+		total, err = u.countTrainings(ctx, query)
+		if err != nil {
+			return nil, total, err
+		}
+	}
+
+	// An empty page is a list without items, never an error
+	trainingItems = make([]TrainingItemResponse, 0, len(trainings))
 	for _, training := range trainings {
 		trainingItems = append(trainingItems, TrainingItemResponse{
 			ID:           training.ID,
@@ -87,15 +311,70 @@ func (u *trainingUsecase) GetTrainings(ctx context.Context, query *TrainingsQuer
 		})
 	}
 
-	totalPages = 0
-	if total > 0 {
-		totalPages = (total + query.Limit - 1) / query.Limit
+	u.cacheSet(ctx, cacheKey, trainingListCache{Items: trainingItems, Total: total}, u.cacheTTL)
+
+	return trainingItems, total, nil
+}
+
+// countTrainings returns the total of the list, cached by filters rather than by page so every
+// page of a search shares one count. Kept for a short while only, a catalog change invalidates
+// it with the pages but the estimate it may hold drifts with the table statistics.
+func (u *trainingUsecase) countTrainings(ctx context.Context, query *TrainingsQuery) (pagination.Total, error) {
+	cacheKey := fmt.Sprintf("%scount:%d:%s:%s:%s", scopedKey(ctx, cacheKeyTrainingList), query.Count, query.Category, query.Level, query.Search)
+
+	var total pagination.Total
+	if u.cacheGet(ctx, cacheTrainingCount, cacheKey, &total) {
+		return total, nil
 	}
 
-	return trainingItems, totalPages, nil
+	total, err := u.trainingRepo.CountList(ctx, query)
+	if err != nil {
+		return total, err
+	}
+
+	u.cacheSet(ctx, cacheKey, total, u.countTTL)
+
+	return total, nil
 }
 
-func (u *trainingUsecase) CreateTraining(ctx context.Context, req *TrainingRequest) (*TrainingResponse, error) {
+func (u *trainingUsecase) GetTrainingFacets(ctx context.Context, query *TrainingsQuery) (response.Facets, error) {
+	// Under the list prefix, so anything invalidating the pages invalidates the counts
+	cacheKey := fmt.Sprintf("%sfacets:%s:%s:%s:%s", scopedKey(ctx, cacheKeyTrainingList), strings.Join(query.Facets, ","), query.Category, query.Level, query.Search)
+
+	var facets response.Facets
+	if u.cacheGet(ctx, cacheTrainingFacets, cacheKey, &facets) {
+		return facets, nil
+	}
+
+	counts, err := u.trainingRepo.GetFacets(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	facets = make(response.Facets, len(counts))
+	for facet, values := range counts {
+		// Empty rather than missing, a requested facet is always present
+		facets[facet] = make([]response.FacetValue, 0, len(values))
+		for _, v := range values {
+			facets[facet] = append(facets[facet], response.FacetValue{Value: v.Value, Label: v.Label, Count: v.Count})
+		}
+	}
+
+	u.cacheSet(ctx, cacheKey, facets, u.cacheTTL)
+
+	return facets, nil
+}
+
+func (u *trainingUsecase) CreateTraining(ctx context.Context, claim *security.Claim, req *TrainingRequest) (*TrainingResponse, error) {
+	if claim.Uid == nil {
+		return nil, ErrGuestSubmission
+	}
+
+	status := StatusPendingReview
+	if claim.IsAdmin() {
+		status = StatusApproved
+	}
+
 	training, err := u.trainingRepo.Create(ctx, &Training{
 		CategoryCode: req.CategoryCode,
 		Level:        req.Level,
@@ -106,11 +385,18 @@ func (u *trainingUsecase) CreateTraining(ctx context.Context, req *TrainingReque
 		ThumbnailURL: req.ThumbnailURL,
 		VideoURL:     &req.VideoURL,
 		ContentHTML:  req.Content,
+		Status:       status,
+		AuthorUserID: claim.Uid,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// New trainings change every list page, drop them all
+	if status == StatusApproved {
+		u.invalidateList(ctx)
+	}
+
 	return &TrainingResponse{
 		ID:           training.ID,
 		Level:        training.Level,
@@ -123,10 +409,24 @@ func (u *trainingUsecase) CreateTraining(ctx context.Context, req *TrainingReque
 		ContentHTML:  training.ContentHTML,
 		CategoryCode: training.CategoryCode,
 		CategoryName: *training.CategoryName,
+		Status:       training.Status,
+		Version:      training.Version,
 	}, nil
 }
 
-func (u *trainingUsecase) FinishSession(ctx context.Context, userId string, trainingId string, req *TrainingFinishSessionRequest) (*TrainingSessionResponse, error) {
+func (u *trainingUsecase) FinishSession(ctx context.Context, claim *security.Claim, trainingId string, req *TrainingFinishSessionRequest) (*TrainingSessionResponse, error) {
+	if claim.Uid == nil {
+		// Nothing is stored for guests, an impossible swim still gives a scripted client away
+		if ImpossibleSwim(req.DistanceMeters, req.DurationSeconds) {
+			details := map[string]any{"trainingId": trainingId, "distanceMeters": req.DistanceMeters, "durationSeconds": req.DurationSeconds}
+			if err := u.abuse.ReportImplausibleSession(ctx, claim.Sub, details); err != nil {
+				logger.FromContext(ctx).Warn("finish session: report implausible guest session failed", "session_id", claim.Sub, "error", err)
+			}
+		}
+		return nil, abuse.ErrSignUpRequired
+	}
+	userId := *claim.Uid
+
 	user, err := u.userRepo.GetUserById(ctx, userId)
 	if err != nil {
 		return nil, err
@@ -137,13 +437,199 @@ func (u *trainingUsecase) FinishSession(ctx context.Context, userId string, trai
 		return nil, err
 	}
 
+	if req.Conditions != nil && trainingCategory.Code != CategoryOpenWater {
+		return nil, ErrNotOpenWater
+	}
+
+	if err := u.checkPlausibility(ctx, claim, trainingId, req); err != nil {
+		return nil, err
+	}
+
 	bmr := user.GetBMR()
 	trainingSession := NewTrainingSession(userId, trainingId, req.DistanceMeters, req.DurationSeconds, bmr, trainingCategory.MET)
+	trainingSession.RPE = req.RPE
+	trainingSession.Laps = newTrainingLaps(req.Laps)
+	trainingSession.Conditions = newSessionConditions(req.Conditions)
+	u.fillConditions(ctx, trainingSession.Conditions, time.Now().Add(-time.Duration(req.DurationSeconds)*time.Second))
+
+	err = database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.trainingRepo.WithTx(tx)
+
+		if _, err := repo.FinishSession(ctx, trainingSession); err != nil {
+			return err
+		}
+		if err := repo.CreateLaps(ctx, trainingSession); err != nil {
+			return err
+		}
+		if err := repo.SaveConditions(ctx, sessionConditions(trainingSession)); err != nil {
+			return err
+		}
+
+		// The same swim may already be imported from a watch
+		_, err := repo.FlagDuplicates(ctx, []string{trainingSession.ID})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newTrainingSessionResponse(trainingSession)
+
+	if err := u.publisher.Publish(ctx, broker.NewEvent(broker.EventSessionFinished, res)); err != nil {
+		logger.FromContext(ctx).Warn("finish session: publish event failed", "session_id", res.ID, "error", err)
+	}
+	u.tracker.Track(ctx, analytics.Event{Name: analytics.EventSessionFinished, UserID: userId, Properties: map[string]any{
+		"trainingId":      trainingId,
+		"distanceMeters":  res.DistanceMeters,
+		"durationSeconds": res.DurationSeconds,
+		"laps":            len(res.Laps),
+	}})
+
+	return res, nil
+}
+
+// checkPlausibility rejects a session nobody of the training level swims, so typos and scripted
+// clients stay out of stats and leaderboards. Admins may override it for exceptional swims.
+func (u *trainingUsecase) checkPlausibility(ctx context.Context, claim *security.Claim, trainingId string, req *TrainingFinishSessionRequest) error {
+	if req.Override {
+		if !claim.IsAdmin() {
+			return &validator.ValidationError{Errors: map[string]string{"override": "Only admins can override the plausibility checks"}}
+		}
+		return nil
+	}
+
+	training, err := u.trainingRepo.GetById(ctx, trainingId)
+	if err != nil {
+		return err
+	}
+	if training == nil {
+		return ErrTrainingNotFound
+	}
+
+	if reasons := CheckPlausibility(training.Level, req.DistanceMeters, req.DurationSeconds, req.PoolLengthMeters); reasons != nil {
+		return &validator.ValidationError{Errors: reasons}
+	}
+	return nil
+}
 
-	finishedSession, err := u.trainingRepo.FinishSession(ctx, trainingSession)
+// ImportSessions stores sessions recorded elsewhere (ex: a watch) with their laps,
+// using one batch for the sessions and one COPY for all laps
+func (u *trainingUsecase) ImportSessions(ctx context.Context, userId string, req *TrainingImportSessionsRequest) (*TrainingImportSessionsResponse, error) {
+	user, err := u.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	bmr := user.GetBMR()
+
+	source := req.Source
+	if source == "" {
+		source = SourceImport
+	}
+
+	// Imports usually repeat a handful of trainings, look each category up once
+	categories := make(map[string]*TrainingCategory)
+	trainingSessions := make([]*TrainingSession, 0, len(req.Sessions))
+
+	// A provider failing once is likely down, the remaining sessions keep what was sent
+	fill := u.fillConditions
+
+	for _, s := range req.Sessions {
+		trainingCategory, ok := categories[s.TrainingID]
+		if !ok {
+			trainingCategory, err = u.trainingRepo.GetTrainingCategoryByTrainingId(ctx, s.TrainingID)
+			if err != nil {
+				return nil, err
+			}
+			categories[s.TrainingID] = trainingCategory
+		}
+
+		if s.Conditions != nil && trainingCategory.Code != CategoryOpenWater {
+			return nil, ErrNotOpenWater
+		}
+
+		trainingSession := NewTrainingSession(userId, s.TrainingID, s.DistanceMeters, s.DurationSeconds, bmr, trainingCategory.MET)
+		trainingSession.StartedAt = &s.StartedAt
+		trainingSession.Source = source
+		trainingSession.RPE = s.RPE
+		trainingSession.Laps = newTrainingLaps(s.Laps)
+		trainingSession.Conditions = newSessionConditions(s.Conditions)
+		if !fill(ctx, trainingSession.Conditions, s.StartedAt) {
+			fill = skipFill
+		}
+
+		trainingSessions = append(trainingSessions, trainingSession)
+	}
+
+	var duplicates int
+	err = database.WithTx(ctx, u.pool, func(tx pgx.Tx) error {
+		repo := u.trainingRepo.WithTx(tx)
+
+		if err := repo.ImportSessions(ctx, trainingSessions); err != nil {
+			return err
+		}
+		if err := repo.CreateLaps(ctx, trainingSessions...); err != nil {
+			return err
+		}
+		if err := repo.SaveConditions(ctx, sessionConditions(trainingSessions...)); err != nil {
+			return err
+		}
+
+		duplicates, err = repo.FlagDuplicates(ctx, sessionIDs(trainingSessions))
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return (*TrainingSessionResponse)(finishedSession), nil
+	res := &TrainingImportSessionsResponse{
+		Imported:           len(trainingSessions),
+		Sessions:           make([]TrainingSessionResponse, 0, len(trainingSessions)),
+		PossibleDuplicates: duplicates,
+	}
+	for _, s := range trainingSessions {
+		res.Sessions = append(res.Sessions, *newTrainingSessionResponse(s))
+	}
+
+	return res, nil
+}
+
+// cacheGet reads a cached value of the named cache, treating cache errors as misses. Lookups
+// are counted by cache and result: hit, miss or error.
+func (u *trainingUsecase) cacheGet(ctx context.Context, name, key string, dest any) bool {
+	found, err := u.cache.Get(ctx, key, dest)
+	if err != nil {
+		u.lookups.Inc(name, "error")
+		logger.FromContext(ctx).Warn("training cache get failed", "key", key, "error", err)
+		return false
+	}
+
+	if found {
+		u.lookups.Inc(name, "hit")
+	} else {
+		u.lookups.Inc(name, "miss")
+	}
+	return found
+}
+
+// cacheSet stores a value for ttl, cache errors never fail the request
+func (u *trainingUsecase) cacheSet(ctx context.Context, key string, value any, ttl time.Duration) {
+	if err := u.cache.Set(ctx, key, value, ttl); err != nil {
+		logger.FromContext(ctx).Warn("training cache set failed", "key", key, "error", err)
+	}
+}
+
+// scopedKey adds the tenant to a cache key prefix, every tenant sees a different catalog
+func scopedKey(ctx context.Context, prefix string) string {
+	if id := tenant.FromContext(ctx); id != "" {
+		return prefix + id + ":"
+	}
+	return prefix
+}
+
+// invalidateList removes every cached training list page, of every tenant
+func (u *trainingUsecase) invalidateList(ctx context.Context) {
+	if err := u.cache.DeletePrefix(ctx, cacheKeyTrainingList); err != nil {
+		logger.FromContext(ctx).Warn("training cache invalidation failed", "error", err)
+	}
 }
@@ -0,0 +1,57 @@
+package usage
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Kinds of clients requests are counted for
+const (
+	ClientApp    = "app"
+	ClientDevice = "device"
+)
+
+type UsageQuery struct {
+	Days int `query:"days" validate:"min=1,max=90"`
+}
+
+// AccountUsageResponse counts the requests of the account on each UTC day of the range, with the
+// monthly quota of its devices
+type AccountUsageResponse struct {
+	From     string             `json:"from" example:"2025-08-23"`
+	To       string             `json:"to" example:"2025-09-21"`
+	Requests int64              `json:"requests" example:"5120"`
+	Days     []UsageDayResponse `json:"days"`
+	Quota    QuotaResponse      `json:"quota"`
+}
+
+// UsageDayResponse is a day with requests, days without any are left out
+type UsageDayResponse struct {
+	Date     string                `json:"date" example:"2025-09-21"`
+	Requests int64                 `json:"requests" example:"180"`
+	Clients  []UsageClientResponse `json:"clients"`
+}
+
+// UsageClientResponse is the requests of the app or of one paired device on a day
+type UsageClientResponse struct {
+	Kind     string  `json:"kind" example:"device" enums:"app,device"`
+	DeviceID *string `json:"deviceId,omitempty" example:"3f0c9a52-7d4e-4b8a-9c1f-2e6d5a4b3c21"`
+	Requests int64   `json:"requests" example:"120"`
+}
+
+// QuotaResponse is the use of the device quota in the current UTC month, without a quota
+// only the requests used are set
+type QuotaResponse struct {
+	Limit     *int      `json:"limit,omitempty" example:"100000"`
+	Used      int64     `json:"used" example:"4200"`
+	Remaining *int64    `json:"remaining,omitempty" example:"95800"`
+	ResetsAt  time.Time `json:"resetsAt" example:"2025-10-01T00:00:00Z"`
+}
+
+func (q *UsageQuery) Validate() error {
+	if err := validator.Struct(q); err != nil {
+		return err
+	}
+	return nil
+}
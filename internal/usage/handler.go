@@ -0,0 +1,63 @@
+package usage
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type UsageHandler struct {
+	usageUsecase UsageUsecase
+}
+
+func NewUsageHandler(usageUsecase UsageUsecase) *UsageHandler {
+	return &UsageHandler{usageUsecase}
+}
+
+// Get handles the API usage of the signed in account
+// @Summary Get API usage
+// @Description Requests of the account on each UTC day of the range ending today, by client: the app, signed in with an access token, and each paired device calling with its token. Device requests count against the monthly quota of the account, once it is used up they answer 429 QUOTA_EXCEEDED with Quota-Limit, Quota-Remaining and Quota-Reset headers until the next UTC month.
+// @Tags Usage
+// @Produce json
+// @Param days query int false "Days of the range, today included" minimum(1) maximum(90) default(30)
+// @Success 200 {object} response.Success{data=AccountUsageResponse} "Usage retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no account"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Failure 503 {object} response.Error "Usage metering is disabled"
+// @Security ApiKeyAuth
+// @Router /account/usage [get]
+func (h *UsageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Aid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no account")
+		return
+	}
+
+	query := UsageQuery{Days: 30}
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			response.ValidationError(w, map[string]string{"days": "Days must be a number"})
+			return
+		}
+		query.Days = days
+	}
+
+	if err := query.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	res, err := h.usageUsecase.Get(ctx, *claim.Aid, &query)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, res)
+}
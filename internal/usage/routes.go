@@ -0,0 +1,12 @@
+package usage
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the API usage endpoint
+func (h *UsageHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("GET /api/v1/account/usage", mw.Protected(http.HandlerFunc(h.Get)))
+}
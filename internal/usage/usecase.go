@@ -0,0 +1,84 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/metering"
+)
+
+var ErrMeteringDisabled = errors.New("metering disabled")
+
+type UsageUsecase interface {
+	// Get returns the requests of the account on the last days of the query, today included,
+	// ErrMeteringDisabled without a metering store
+	Get(ctx context.Context, accountID string, query *UsageQuery) (*AccountUsageResponse, error)
+}
+
+type usageUsecase struct {
+	cfg   *config.Store
+	store metering.Store
+}
+
+func NewUsageUsecase(cfg *config.Store, store metering.Store) UsageUsecase {
+	return &usageUsecase{cfg, store}
+}
+
+func (u *usageUsecase) Get(ctx context.Context, accountID string, query *UsageQuery) (*AccountUsageResponse, error) {
+	if u.store == nil {
+		return nil, ErrMeteringDisabled
+	}
+
+	now := time.Now().UTC()
+	from := now.AddDate(0, 0, 1-query.Days)
+
+	days, err := u.store.Days(ctx, accountID, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := u.store.Month(ctx, accountID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AccountUsageResponse{
+		From:  from.Format(time.DateOnly),
+		To:    now.Format(time.DateOnly),
+		Days:  make([]UsageDayResponse, 0, len(days)),
+		Quota: QuotaResponse{Used: used, ResetsAt: metering.MonthEnd(now)},
+	}
+
+	for _, day := range days {
+		dayRes := UsageDayResponse{Date: day.Date.Format(time.DateOnly)}
+
+		// Sorted keys list the app first, then devices by id
+		for _, client := range slices.Sorted(maps.Keys(day.Requests)) {
+			requests := day.Requests[client]
+			dayRes.Requests += requests
+			dayRes.Clients = append(dayRes.Clients, newClientResponse(client, requests))
+		}
+
+		res.Requests += dayRes.Requests
+		res.Days = append(res.Days, dayRes)
+	}
+
+	if quota := u.cfg.Load().Metering.MonthlyQuota; quota > 0 {
+		remaining := max(int64(quota)-used, 0)
+		res.Quota.Limit, res.Quota.Remaining = &quota, &remaining
+	}
+
+	return res, nil
+}
+
+func newClientResponse(client string, requests int64) UsageClientResponse {
+	if id, ok := strings.CutPrefix(client, metering.DeviceClient("")); ok {
+		return UsageClientResponse{Kind: ClientDevice, DeviceID: &id, Requests: requests}
+	}
+	return UsageClientResponse{Kind: ClientApp, Requests: requests}
+}
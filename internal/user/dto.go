@@ -0,0 +1,41 @@
+package user
+
+import (
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type AvatarResponse struct {
+	AvatarURL string `json:"avatarUrl" example:"https://api.swimo.id/api/v1/media/avatars/a1b2c3d4-e5f6-7890-1234-567890abcdef/3f2a9c1e8b7d4c6a.jpg"`
+}
+
+type PreferencesRequest struct {
+	Timezone     string `json:"timezone" validate:"required,max=64" example:"Asia/Jakarta"`
+	WeeklyDigest bool   `json:"weeklyDigest" example:"true"`
+	MaxHeartRate *int   `json:"maxHeartRate,omitempty" validate:"min=100,max=230" example:"188"`
+	Version      *int   `json:"version,omitempty" validate:"gt=0" example:"2"` // version read, preferences edited since are refused
+}
+
+type PreferencesResponse struct {
+	Timezone     string `json:"timezone" example:"Asia/Jakarta"`
+	WeeklyDigest bool   `json:"weeklyDigest" example:"true"`
+	MaxHeartRate *int   `json:"maxHeartRate,omitempty" example:"188"`
+	Version      int    `json:"version" example:"2"`
+}
+
+func (r *PreferencesRequest) Validate() error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	// Tags can't check the time zone database, Local would silently mean the server zone
+	if _, err := time.LoadLocation(r.Timezone); err != nil || r.Timezone == "Local" {
+		return &validator.ValidationError{Errors: map[string]string{"timezone": "Timezone is not a valid IANA time zone"}}
+	}
+	return nil
+}
+
+func newPreferencesResponse(p *Preferences) *PreferencesResponse {
+	return &PreferencesResponse{Timezone: p.Timezone, WeeklyDigest: p.WeeklyDigest, MaxHeartRate: p.MaxHeartRate, Version: p.Version}
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 )
 
-var ErrGenderInvalid = errors.New("invalid gender")
+var (
+	ErrGenderInvalid = errors.New("invalid gender")
+	ErrAvatarType    = errors.New("unsupported avatar type")
+)
 
 type Gender uint8
 
@@ -43,6 +46,15 @@ type User struct {
 	WeightKG  float64
 	HeightCM  float64
 	AgeYears  int16
+	AvatarKey *string
+}
+
+// Preferences controls the notifications sent to the user and their training zones
+type Preferences struct {
+	Timezone     string // IANA name, ex: Asia/Jakarta
+	WeeklyDigest bool
+	MaxHeartRate *int // bpm, nil derives it from the age
+	Version      int  // bumped by every edit of the preferences
 }
 
 func (u *User) GetBMR() float64 {
@@ -56,3 +68,10 @@ func (u *User) GetBMR() float64 {
 
 	return bmr
 }
+
+// avatarTypes maps the accepted avatar content types to their file extension
+var avatarTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
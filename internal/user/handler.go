@@ -0,0 +1,148 @@
+package user
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+type UserHandler struct {
+	userUsecase UserUsecase
+}
+
+func NewUserHandler(userUsecase UserUsecase) *UserHandler {
+	return &UserHandler{userUsecase}
+}
+
+// UpdateAvatar handles uploading the avatar of the signed in user
+// @Summary Upload avatar
+// @Description Replace the avatar of the user with a JPEG, PNG or WebP image up to 5MB, sent as the avatar field of a multipart form
+// @Tags User
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Avatar image"
+// @Success 200 {object} response.Success{data=AvatarResponse} "Avatar updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 413 {object} response.Error "File too large"
+// @Failure 422 {object} response.Error "Validation errors or file rejected by the malware scan"
+// @Failure 503 {object} response.Error "Uploads disabled or malware scanner unavailable"
+// @Security ApiKeyAuth
+// @Router /users/me/avatar [put]
+func (h *UserHandler) UpdateAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		response.BadRequest(w)
+		return
+	}
+
+	// Parts are streamed to storage as they arrive, the first avatar part wins
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			response.DecodeError(w, err)
+			return
+		}
+
+		if part.FormName() != "avatar" {
+			continue
+		}
+
+		avatar, err := h.userUsecase.UpdateAvatar(ctx, *claim.Uid, part, part.Header.Get("Content-Type"))
+		if err != nil {
+			response.Err(w, err)
+			return
+		}
+
+		response.OK(w, http.StatusOK, avatar)
+		return
+	}
+
+	response.ValidationError(w, map[string]string{"avatar": "Avatar is required"})
+}
+
+// GetPreferences handles reading the notification preferences of the signed in user
+// @Summary Get notification preferences
+// @Description Get the time zone and the notifications the user receives
+// @Tags User
+// @Produce json
+// @Success 200 {object} response.Success{data=PreferencesResponse} "Preferences retrieved successfully"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "User not found"
+// @Security ApiKeyAuth
+// @Router /users/me/preferences [get]
+func (h *UserHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	prefs, err := h.userUsecase.GetPreferences(ctx, *claim.Uid)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles replacing the notification preferences of the signed in user
+// @Summary Update notification preferences
+// @Description Set the IANA time zone of the user, used to send scheduled mail in the morning of the user, and opt in or out of the weekly digest. With a version, preferences edited since, ex: from another device, are refused with the current version.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body PreferencesRequest true "Notification preferences"
+// @Success 200 {object} response.Success{data=PreferencesResponse} "Preferences updated successfully"
+// @Failure 400 {object} response.Error "Invalid request body"
+// @Failure 403 {object} response.Error "Guests have no profile"
+// @Failure 404 {object} response.Error "User not found"
+// @Failure 409 {object} response.Error "Preferences edited since the version read"
+// @Failure 422 {object} response.Error "Validation errors"
+// @Security ApiKeyAuth
+// @Router /users/me/preferences [put]
+func (h *UserHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claim := middleware.AuthFromContext(ctx)
+
+	if claim.Uid == nil {
+		response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Guests have no profile")
+		return
+	}
+
+	var req PreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.DecodeError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.ValidationError(w, err.(*validator.ValidationError).Errors)
+		return
+	}
+
+	prefs, err := h.userUsecase.UpdatePreferences(ctx, *claim.Uid, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.OK(w, http.StatusOK, prefs)
+}
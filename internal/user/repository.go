@@ -6,7 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkyharahap/swimo/database"
 )
 
 var (
@@ -17,18 +17,31 @@ var (
 type UserRepository interface {
 	GetIdByAccountId(ctx context.Context, accountId string) (*string, error)
 	GetUserById(ctx context.Context, id string) (*User, error)
-	CreateUser(ctx context.Context, tx pgx.Tx, user *User) (*User, error)
+	CreateUser(ctx context.Context, user *User) (*User, error)
+	// UpdateAvatar sets the avatar of the user and returns the replaced one
+	UpdateAvatar(ctx context.Context, id, avatarKey string) (previous *string, err error)
+	GetPreferences(ctx context.Context, id string) (*Preferences, error)
+	// UpdatePreferences replaces the preferences and bumps their version, with a version only when
+	// it is still current, else a database.StaleVersionError
+	UpdatePreferences(ctx context.Context, id string, prefs *Preferences, version *int) error
+
+	// WithTx returns a repository running every query in tx
+	WithTx(tx pgx.Tx) UserRepository
 }
 
-type userRepository struct{ db *pgxpool.Pool }
+type userRepository struct{ db database.DBTX }
 
-func NewUserRepositry(db *pgxpool.Pool) UserRepository { return &userRepository{db: db} }
+func NewUserRepositry(db database.DBTX) UserRepository { return &userRepository{db: db} }
+
+func (r *userRepository) WithTx(tx pgx.Tx) UserRepository {
+	return &userRepository{db: database.Rebind(r.db, tx)}
+}
 
 func (r *userRepository) GetIdByAccountId(ctx context.Context, accountId string) (id *string, err error) {
 	const q = `
 		SELECT id
 		FROM users
-		WHERE account_id = $1
+		WHERE account_id = $1 AND deleted_at IS NULL
 		LIMIT 1
 	`
 
@@ -43,7 +56,7 @@ func (r *userRepository) GetUserById(ctx context.Context, id string) (*User, err
 	const q = `
 		SELECT id, name, weight_kg, height_cm, age_years, gender
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 		LIMIT 1
 	`
 
@@ -59,13 +72,13 @@ func (r *userRepository) GetUserById(ctx context.Context, id string) (*User, err
 	return &user, nil
 }
 
-func (r *userRepository) CreateUser(ctx context.Context, tx pgx.Tx, user *User) (*User, error) {
+func (r *userRepository) CreateUser(ctx context.Context, user *User) (*User, error) {
 	const q = `
 		INSERT INTO users (account_id, name, gender, weight_kg, height_cm, age_years)
 		VALUES ($1,$2,$3,$4,$5,$6)
 		RETURNING id`
 
-	if err := tx.QueryRow(ctx, q,
+	if err := r.db.QueryRow(ctx, q,
 		&user.AccountID,
 		&user.Name,
 		&user.Gender,
@@ -83,3 +96,63 @@ func (r *userRepository) CreateUser(ctx context.Context, tx pgx.Tx, user *User)
 
 	return user, nil
 }
+
+func (r *userRepository) UpdateAvatar(ctx context.Context, id, avatarKey string) (previous *string, err error) {
+	const q = `
+		UPDATE users AS u
+		SET avatar_key = $2, updated_at = now()
+		FROM (SELECT id, avatar_key FROM users WHERE id = $1 FOR UPDATE) AS old
+		WHERE u.id = old.id
+		RETURNING old.avatar_key`
+
+	if err := r.db.QueryRow(ctx, q, id, avatarKey).Scan(&previous); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+func (r *userRepository) GetPreferences(ctx context.Context, id string) (*Preferences, error) {
+	const q = `
+		SELECT timezone, weekly_digest, max_heart_rate, version
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	var prefs Preferences
+	if err := r.db.QueryRow(ctx, q, id).Scan(&prefs.Timezone, &prefs.WeeklyDigest, &prefs.MaxHeartRate, &prefs.Version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+func (r *userRepository) UpdatePreferences(ctx context.Context, id string, prefs *Preferences, version *int) error {
+	const q = `
+		UPDATE users
+		SET timezone = $2, weekly_digest = $3, max_heart_rate = $4, version = version + 1, updated_at = now()
+		WHERE id = $1
+			AND ($5::int IS NULL OR version = $5)
+		RETURNING version`
+
+	err := r.db.QueryRow(ctx, q, id, prefs.Timezone, prefs.WeeklyDigest, prefs.MaxHeartRate, version).Scan(&prefs.Version)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	// No row updated: the user is missing or was edited since the version read
+	const current = `SELECT version FROM users WHERE id = $1`
+	if err := r.db.QueryRow(ctx, current, id).Scan(&prefs.Version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	return database.CheckVersion(version, prefs.Version)
+}
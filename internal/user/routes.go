@@ -0,0 +1,14 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/router"
+)
+
+// Routes registers the profile endpoints
+func (h *UserHandler) Routes(mux *http.ServeMux, mw router.Middlewares) {
+	mux.Handle("PUT /api/v1/users/me/avatar", mw.Upload(http.HandlerFunc(h.UpdateAvatar)))
+	mux.Handle("GET /api/v1/users/me/preferences", mw.Protected(http.HandlerFunc(h.GetPreferences)))
+	mux.Handle("PUT /api/v1/users/me/preferences", mw.Protected(http.HandlerFunc(h.UpdatePreferences)))
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+)
+
+// maxAvatarBytes caps avatars, far below the upload limit meant for videos
+const maxAvatarBytes = 5 << 20
+
+type UserUsecase interface {
+	UpdateAvatar(ctx context.Context, userId string, file io.Reader, contentType string) (*AvatarResponse, error)
+	GetPreferences(ctx context.Context, userId string) (*PreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userId string, req *PreferencesRequest) (*PreferencesResponse, error)
+}
+
+type userUsecase struct {
+	userRepo UserRepository
+	files    storage.Storage
+	baseURL  string
+}
+
+func NewUserUsecase(userRepo UserRepository, files storage.Storage, baseURL string) UserUsecase {
+	return &userUsecase{userRepo, files, baseURL}
+}
+
+func (uc *userUsecase) UpdateAvatar(ctx context.Context, userId string, file io.Reader, contentType string) (*AvatarResponse, error) {
+	ext, ok := avatarTypes[contentType]
+	if !ok {
+		return nil, ErrAvatarType
+	}
+
+	// A new key per upload, so cached links of the previous avatar never serve the new one
+	suffix := make([]byte, 8)
+	rand.Read(suffix)
+	key := "avatars/" + userId + "/" + hex.EncodeToString(suffix) + ext
+
+	if err := uc.files.Put(ctx, key, storage.LimitReader(file, maxAvatarBytes), contentType); err != nil {
+		return nil, err
+	}
+
+	previous, err := uc.userRepo.UpdateAvatar(ctx, userId, key)
+	if err != nil {
+		uc.deleteFile(ctx, key)
+		return nil, err
+	}
+
+	if previous != nil {
+		uc.deleteFile(ctx, *previous)
+	}
+
+	return &AvatarResponse{AvatarURL: storage.MediaURL(uc.baseURL, key)}, nil
+}
+
+func (uc *userUsecase) GetPreferences(ctx context.Context, userId string) (*PreferencesResponse, error) {
+	prefs, err := uc.userRepo.GetPreferences(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPreferencesResponse(prefs), nil
+}
+
+func (uc *userUsecase) UpdatePreferences(ctx context.Context, userId string, req *PreferencesRequest) (*PreferencesResponse, error) {
+	prefs := Preferences{Timezone: req.Timezone, WeeklyDigest: req.WeeklyDigest, MaxHeartRate: req.MaxHeartRate}
+	if err := uc.userRepo.UpdatePreferences(ctx, userId, &prefs, req.Version); err != nil {
+		return nil, err
+	}
+
+	return newPreferencesResponse(&prefs), nil
+}
+
+// deleteFile removes a file no longer referenced, failures only leave an orphan behind
+func (uc *userUsecase) deleteFile(ctx context.Context, key string) {
+	if err := uc.files.Delete(ctx, key); err != nil {
+		logger.FromContext(ctx).Warn("failed to delete replaced file", "key", key, "error", err)
+	}
+}
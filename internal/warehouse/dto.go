@@ -0,0 +1,130 @@
+package warehouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/analytics"
+)
+
+// Exported datasets, one file per dataset and day
+const (
+	datasetSessions   = "sessions"
+	datasetSignups    = "signups"
+	datasetEngagement = "engagement"
+)
+
+// SessionRecord is one row of the sessions dataset. Ids of people are pseudonymized,
+// the same key as analytics is used so the datasets join with the event stream.
+type SessionRecord struct {
+	SessionKey      string    `parquet:"session_key"`
+	UserKey         string    `parquet:"user_key"`
+	TrainingID      *string   `parquet:"training_id,optional"`
+	CategoryCode    *string   `parquet:"category_code,optional"`
+	OrganizationID  *string   `parquet:"organization_id,optional"`
+	DistanceMeters  int32     `parquet:"distance_meters"`
+	DurationSeconds int32     `parquet:"duration_seconds"`
+	Pace            float64   `parquet:"pace"`
+	CaloriesKcal    int32     `parquet:"calories_kcal"`
+	Laps            int32     `parquet:"laps"`
+	CreatedAt       time.Time `parquet:"created_at,timestamp"`
+}
+
+// SignupRecord is one row of the signups dataset. Age is bucketed and the time
+// truncated to the hour, so a row can't be matched to a person by its profile.
+type SignupRecord struct {
+	UserKey        string    `parquet:"user_key"`
+	OrganizationID *string   `parquet:"organization_id,optional"`
+	Gender         string    `parquet:"gender"`
+	AgeBand        *string   `parquet:"age_band,optional"`
+	SignedUpHour   time.Time `parquet:"signed_up_hour,timestamp"`
+}
+
+// EngagementRecord is one row of the engagement dataset, Date is the partition day, ex: 2025-09-21
+type EngagementRecord struct {
+	Date            string  `parquet:"date"`
+	OrganizationID  *string `parquet:"organization_id,optional"`
+	ActiveUsers     int64   `parquet:"active_users"`
+	Sessions        int64   `parquet:"sessions"`
+	DistanceMeters  int64   `parquet:"distance_meters"`
+	DurationSeconds int64   `parquet:"duration_seconds"`
+	Signups         int64   `parquet:"signups"`
+	GuestSessions   int64   `parquet:"guest_sessions"`
+	Events          int64   `parquet:"events"`
+	TrainingViews   int64   `parquet:"training_views"`
+}
+
+// Manifest is written after every dataset of a day, its presence marks the day exported
+type Manifest struct {
+	Date       string                     `json:"date"`
+	Format     string                     `json:"format"`
+	Datasets   map[string]ManifestDataset `json:"datasets"`
+	ExportedAt time.Time                  `json:"exportedAt"`
+}
+
+type ManifestDataset struct {
+	Key  string `json:"key"`
+	Rows int64  `json:"rows"`
+}
+
+func newSessionRecord(s *Session, key []byte) SessionRecord {
+	return SessionRecord{
+		SessionKey:      analytics.Pseudonymize(key, s.ID),
+		UserKey:         analytics.Pseudonymize(key, s.UserID),
+		TrainingID:      s.TrainingID,
+		CategoryCode:    s.CategoryCode,
+		OrganizationID:  s.OrganizationID,
+		DistanceMeters:  int32(s.DistanceMeters),
+		DurationSeconds: int32(s.DurationSeconds),
+		Pace:            s.Pace,
+		CaloriesKcal:    int32(s.CaloriesKcal),
+		Laps:            int32(s.Laps),
+		CreatedAt:       s.CreatedAt.UTC(),
+	}
+}
+
+func newSignupRecord(s *Signup, key []byte) SignupRecord {
+	return SignupRecord{
+		UserKey:        analytics.Pseudonymize(key, s.UserID),
+		OrganizationID: s.OrganizationID,
+		Gender:         s.Gender,
+		AgeBand:        ageBand(s.AgeYears),
+		SignedUpHour:   s.CreatedAt.UTC().Truncate(time.Hour),
+	}
+}
+
+func newEngagementRecord(day time.Time, e *Engagement) EngagementRecord {
+	return EngagementRecord{
+		Date:            day.Format(time.DateOnly),
+		OrganizationID:  e.OrganizationID,
+		ActiveUsers:     e.ActiveUsers,
+		Sessions:        e.Sessions,
+		DistanceMeters:  e.DistanceMeters,
+		DurationSeconds: e.DurationSeconds,
+		Signups:         e.Signups,
+		GuestSessions:   e.GuestSessions,
+		Events:          e.Events,
+		TrainingViews:   e.TrainingViews,
+	}
+}
+
+// ageBand buckets an age by decade from 18, ex: 25 -> 25-34, minors are one band
+func ageBand(age *int) *string {
+	if age == nil {
+		return nil
+	}
+
+	var band string
+	switch a := *age; {
+	case a < 18:
+		band = "<18"
+	case a < 25:
+		band = "18-24"
+	case a >= 65:
+		band = "65+"
+	default:
+		low := 25 + (a-25)/10*10
+		band = fmt.Sprintf("%d-%d", low, low+9)
+	}
+	return &band
+}
@@ -0,0 +1,41 @@
+package warehouse
+
+import "time"
+
+// Session is a training session as stored, before anonymization
+type Session struct {
+	ID              string
+	UserID          string
+	TrainingID      *string
+	CategoryCode    *string
+	OrganizationID  *string
+	DistanceMeters  int
+	DurationSeconds int
+	Pace            float64
+	CaloriesKcal    int
+	Laps            int
+	CreatedAt       time.Time
+}
+
+// Signup is an account created on the day with its profile, before anonymization
+type Signup struct {
+	UserID         string
+	OrganizationID *string
+	Gender         string
+	AgeYears       *int
+	CreatedAt      time.Time
+}
+
+// Engagement aggregates the activity of one organization over a day,
+// the default tenant has no organization
+type Engagement struct {
+	OrganizationID  *string
+	ActiveUsers     int64
+	Sessions        int64
+	DistanceMeters  int64
+	DurationSeconds int64
+	Signups         int64
+	GuestSessions   int64
+	Events          int64
+	TrainingViews   int64
+}
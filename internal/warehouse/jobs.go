@@ -0,0 +1,32 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/scheduler"
+)
+
+// NewExportJob returns a job exporting the finished days missing from the warehouse.
+// It runs hourly rather than at midnight, so a day missed during downtime or a failed
+// run is picked up on the next tick.
+func NewExportJob(cfg config.JobConfig, warehouseUsecase WarehouseUsecase) scheduler.Job {
+	return scheduler.Job{
+		Name:     "warehouse_export",
+		Interval: cfg.Interval,
+		Jitter:   cfg.Jitter,
+		Run: func(ctx context.Context) error {
+			exported, err := warehouseUsecase.ExportMissing(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if exported > 0 {
+				logger.FromContext(ctx).Info("Warehouse export finished", "days", exported)
+			}
+			return nil
+		},
+	}
+}
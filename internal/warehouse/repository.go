@@ -0,0 +1,108 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizkyharahap/swimo/database"
+)
+
+// WarehouseRepository reads one day of activity across every organization, for the export
+type WarehouseRepository interface {
+	StreamSessions(ctx context.Context, from, to time.Time, fn func(*Session) error) error
+	StreamSignups(ctx context.Context, from, to time.Time, fn func(*Signup) error) error
+	GetEngagement(ctx context.Context, from, to time.Time) ([]*Engagement, error)
+}
+
+type warehouseRepository struct {
+	db database.DBTX
+}
+
+func NewWarehouseRepositry(db database.DBTX) WarehouseRepository {
+	return &warehouseRepository{db}
+}
+
+func (r *warehouseRepository) StreamSessions(ctx context.Context, from, to time.Time, fn func(*Session) error) error {
+	const q = `
+		SELECT
+			ts.id, ts.user_id, ts.training_id, tc.code AS category_code, ts.organization_id,
+			ts.distance_meters, ts.duration_seconds, ts.pace, ts.calories_kcal,
+			(SELECT count(*) FROM training_session_laps l WHERE l.session_id = ts.id) AS laps,
+			ts.created_at
+		FROM training_sessions ts
+		LEFT JOIN trainings t ON t.id = ts.training_id
+		LEFT JOIN training_categories tc ON tc.id = t.category_id
+		WHERE ts.created_at >= $1 AND ts.created_at < $2 AND ts.deleted_at IS NULL
+		ORDER BY ts.created_at, ts.id`
+
+	return database.Each(ctx, r.db, q, []any{from, to}, fn)
+}
+
+func (r *warehouseRepository) StreamSignups(ctx context.Context, from, to time.Time, fn func(*Signup) error) error {
+	const q = `
+		SELECT
+			u.id AS user_id, a.organization_id,
+			CASE u.gender WHEN 0 THEN 'male' ELSE 'female' END AS gender,
+			u.age_years, a.created_at
+		FROM accounts a
+		JOIN users u ON u.account_id = a.id
+		WHERE a.created_at >= $1 AND a.created_at < $2
+		ORDER BY a.created_at, u.id`
+
+	return database.Each(ctx, r.db, q, []any{from, to}, fn)
+}
+
+func (r *warehouseRepository) GetEngagement(ctx context.Context, from, to time.Time) ([]*Engagement, error) {
+	// One row per organization with any activity, UNION treats the default tenant (NULL) as one group
+	const q = `
+		WITH s AS (
+			SELECT organization_id,
+				count(DISTINCT user_id) AS active_users,
+				count(*) AS sessions,
+				sum(distance_meters) AS distance_meters,
+				sum(duration_seconds) AS duration_seconds
+			FROM training_sessions
+			WHERE created_at >= $1 AND created_at < $2 AND deleted_at IS NULL
+			GROUP BY organization_id
+		), a AS (
+			SELECT organization_id, count(*) AS signups
+			FROM accounts
+			WHERE created_at >= $1 AND created_at < $2
+			GROUP BY organization_id
+		), g AS (
+			SELECT organization_id, count(*) AS guest_sessions
+			FROM sessions
+			WHERE kind = 'guest' AND created_at >= $1 AND created_at < $2
+			GROUP BY organization_id
+		), e AS (
+			SELECT organization_id,
+				count(*) AS events,
+				count(*) FILTER (WHERE name = 'training_viewed') AS training_views
+			FROM analytics_events
+			WHERE occurred_at >= $1 AND occurred_at < $2
+			GROUP BY organization_id
+		), o AS (
+			SELECT organization_id FROM s
+			UNION SELECT organization_id FROM a
+			UNION SELECT organization_id FROM g
+			UNION SELECT organization_id FROM e
+		)
+		SELECT
+			o.organization_id,
+			COALESCE(s.active_users, 0) AS active_users,
+			COALESCE(s.sessions, 0) AS sessions,
+			COALESCE(s.distance_meters, 0) AS distance_meters,
+			COALESCE(s.duration_seconds, 0) AS duration_seconds,
+			COALESCE(a.signups, 0) AS signups,
+			COALESCE(g.guest_sessions, 0) AS guest_sessions,
+			COALESCE(e.events, 0) AS events,
+			COALESCE(e.training_views, 0) AS training_views
+		FROM o
+		LEFT JOIN s ON s.organization_id IS NOT DISTINCT FROM o.organization_id
+		LEFT JOIN a ON a.organization_id IS NOT DISTINCT FROM o.organization_id
+		LEFT JOIN g ON g.organization_id IS NOT DISTINCT FROM o.organization_id
+		LEFT JOIN e ON e.organization_id IS NOT DISTINCT FROM o.organization_id
+		ORDER BY o.organization_id NULLS FIRST`
+
+	return database.Select[Engagement](ctx, r.db, q, from, to)
+}
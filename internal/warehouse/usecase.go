@@ -0,0 +1,185 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/storage"
+	"github.com/rizkyharahap/swimo/pkg/warehouse"
+)
+
+type WarehouseUsecase interface {
+	// ExportDay writes every dataset of the UTC day and then its manifest
+	ExportDay(ctx context.Context, day time.Time) (*Manifest, error)
+	// ExportMissing exports the finished days of the lookback window without a manifest
+	ExportMissing(ctx context.Context, now time.Time) (int, error)
+}
+
+type warehouseUsecase struct {
+	cfg           config.WarehouseConfig
+	warehouseRepo WarehouseRepository
+	files         storage.Storage
+	key           []byte
+}
+
+func NewWarehouseUsecase(cfg config.WarehouseConfig, warehouseRepo WarehouseRepository, files storage.Storage) WarehouseUsecase {
+	return &warehouseUsecase{cfg, warehouseRepo, files, []byte(cfg.IDSalt)}
+}
+
+func (u *warehouseUsecase) ExportMissing(ctx context.Context, now time.Time) (int, error) {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	exported := 0
+	for i := u.cfg.Lookback; i >= 1; i-- {
+		day := today.AddDate(0, 0, -i)
+
+		done, err := u.exported(ctx, day)
+		if err != nil {
+			return exported, err
+		}
+		if done {
+			continue
+		}
+
+		manifest, err := u.ExportDay(ctx, day)
+		if err != nil {
+			return exported, fmt.Errorf("export %s: %w", day.Format(time.DateOnly), err)
+		}
+
+		logger.FromContext(ctx).Info("Warehouse day exported", "date", manifest.Date, "datasets", manifest.Datasets)
+		exported++
+	}
+
+	return exported, nil
+}
+
+func (u *warehouseUsecase) ExportDay(ctx context.Context, day time.Time) (*Manifest, error) {
+	from := day.UTC().Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, 1)
+
+	manifest := &Manifest{
+		Date:     from.Format(time.DateOnly),
+		Format:   u.cfg.Format,
+		Datasets: make(map[string]ManifestDataset, 3),
+	}
+
+	// Datasets are rewritten whole, a day interrupted halfway is exported again on the next run
+	sessionsKey := u.datasetKey(datasetSessions, from)
+	rows, err := exportTable(ctx, u.files, sessionsKey, u.cfg.Format, func(w warehouse.TableWriter[SessionRecord]) error {
+		return u.warehouseRepo.StreamSessions(ctx, from, to, func(s *Session) error {
+			return w.Write(newSessionRecord(s, u.key))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest.Datasets[datasetSessions] = ManifestDataset{Key: sessionsKey, Rows: rows}
+
+	signupsKey := u.datasetKey(datasetSignups, from)
+	rows, err = exportTable(ctx, u.files, signupsKey, u.cfg.Format, func(w warehouse.TableWriter[SignupRecord]) error {
+		return u.warehouseRepo.StreamSignups(ctx, from, to, func(s *Signup) error {
+			return w.Write(newSignupRecord(s, u.key))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest.Datasets[datasetSignups] = ManifestDataset{Key: signupsKey, Rows: rows}
+
+	engagement, err := u.warehouseRepo.GetEngagement(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	engagementKey := u.datasetKey(datasetEngagement, from)
+	rows, err = exportTable(ctx, u.files, engagementKey, u.cfg.Format, func(w warehouse.TableWriter[EngagementRecord]) error {
+		for _, e := range engagement {
+			if err := w.Write(newEngagementRecord(from, e)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest.Datasets[datasetEngagement] = ManifestDataset{Key: engagementKey, Rows: rows}
+
+	manifest.ExportedAt = time.Now().UTC()
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.files.Put(ctx, u.manifestKey(from), bytes.NewReader(body), "application/json"); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// exported reports whether the manifest of the day exists
+func (u *warehouseUsecase) exported(ctx context.Context, day time.Time) (bool, error) {
+	r, _, err := u.files.Get(ctx, u.manifestKey(day))
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	r.Close()
+	return true, nil
+}
+
+// datasetKey partitions files Hive style, ex: warehouse/sessions/dt=2025-09-21/part-00000.parquet
+func (u *warehouseUsecase) datasetKey(dataset string, day time.Time) string {
+	return fmt.Sprintf("%s/%s/dt=%s/part-00000%s", u.cfg.Prefix, dataset, day.Format(time.DateOnly), warehouse.Extension(u.cfg.Format))
+}
+
+func (u *warehouseUsecase) manifestKey(day time.Time) string {
+	return fmt.Sprintf("%s/_manifests/dt=%s.json", u.cfg.Prefix, day.Format(time.DateOnly))
+}
+
+// exportTable streams the rows written by fill to a file in storage and returns how many were written
+func exportTable[T any](ctx context.Context, files storage.Storage, key, format string, fill func(warehouse.TableWriter[T]) error) (int64, error) {
+	var rows int64
+
+	// Rows are encoded while the previous ones upload, a day never sits in memory
+	pr, pw := io.Pipe()
+	go func() {
+		w, err := warehouse.NewTableWriter[T](pw, format)
+		if err == nil {
+			err = fill(countingWriter[T]{w, &rows})
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	if err := files.Put(ctx, key, pr, warehouse.ContentType(format)); err != nil {
+		pr.CloseWithError(err)
+		return 0, err
+	}
+
+	return rows, nil
+}
+
+// countingWriter counts the rows passed to the table writer
+type countingWriter[T any] struct {
+	warehouse.TableWriter[T]
+	rows *int64
+}
+
+func (c countingWriter[T]) Write(rows ...T) error {
+	*c.rows += int64(len(rows))
+	return c.TableWriter.Write(rows...)
+}
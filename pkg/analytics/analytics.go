@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/database"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+)
+
+// Product events emitted by the server, clients may not send these names
+const (
+	EventSignup          = "signup"
+	EventSessionFinished = "session_finished"
+	EventTrainingViewed  = "training_viewed"
+)
+
+// Event sources
+const (
+	SourceClient = "client"
+	SourceServer = "server"
+)
+
+// Event is one product analytics event. Identity fields left empty are filled from the
+// request context by Track, before the privacy rules are applied.
+type Event struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Source         string         `json:"source"`
+	UserID         string         `json:"userId,omitempty"`
+	AnonymousID    string         `json:"anonymousId,omitempty"`
+	SessionID      string         `json:"sessionId,omitempty"`
+	OrganizationID string         `json:"organizationId,omitempty"`
+	Properties     map[string]any `json:"properties,omitempty"`
+	OccurredAt     time.Time      `json:"occurredAt"`
+	ReceivedAt     time.Time      `json:"receivedAt"`
+}
+
+// Tracker accepts events without blocking the caller, delivery is best effort
+type Tracker interface {
+	Track(ctx context.Context, event Event)
+	Close() error
+}
+
+// Sink stores a batch of events, ex: a table, a topic or a collector
+type Sink interface {
+	Write(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// New creates a tracker writing to the sink selected in config, db is used by the postgres sink
+func New(cfg config.AnalyticsConfig, db database.DBTX, log *logger.Logger, reg *metrics.Registry) (Tracker, error) {
+	var sink Sink
+	switch cfg.Sink {
+	case "postgres":
+		sink = NewPostgresSink(db)
+	case "kafka":
+		sink = NewKafkaSink(cfg.URL, cfg.Topic, cfg.Timeout)
+	case "http":
+		sink = NewHTTPSink(cfg.URL, cfg.Token, cfg.Timeout)
+	case "", "none":
+		return noopTracker{}, nil
+	default:
+		return nil, fmt.Errorf("unknown analytics sink %q", cfg.Sink)
+	}
+
+	log.Info("Analytics configured", "sink", cfg.Sink, "sample_rate", cfg.SampleRate)
+	return NewBufferedTracker(sink, cfg, log, reg), nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// noopTracker discards every event, used when no sink is configured
+type noopTracker struct{}
+
+func (noopTracker) Track(ctx context.Context, event Event) {}
+
+func (noopTracker) Close() error { return nil }
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink produces events to a single topic, keyed by identity so the events of one
+// user stay ordered within a partition
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaSink(brokers, topic string, timeout time.Duration) *KafkaSink {
+	return &KafkaSink{writer: &kafka.Writer{
+		Addr:                   kafka.TCP(strings.Split(brokers, ",")...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireOne,
+		WriteTimeout:           timeout,
+		AllowAutoTopicCreation: true,
+	}}
+}
+
+func (s *KafkaSink) Write(ctx context.Context, events []Event) error {
+	messages := make([]kafka.Message, len(events))
+	for i, e := range events {
+		value, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", e.Name, err)
+		}
+
+		key := e.UserID
+		if key == "" {
+			key = e.AnonymousID
+		}
+		messages[i] = kafka.Message{Key: []byte(key), Value: value}
+	}
+
+	if err := s.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to produce analytics events: %w", err)
+	}
+	return nil
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,45 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rizkyharahap/swimo/database"
+)
+
+// PostgresSink copies events into the analytics_events table
+type PostgresSink struct {
+	db database.DBTX
+}
+
+func NewPostgresSink(db database.DBTX) *PostgresSink {
+	return &PostgresSink{db: db}
+}
+
+var analyticsColumns = []string{
+	"id", "name", "source", "user_id", "anonymous_id", "session_id",
+	"organization_id", "properties", "occurred_at", "received_at",
+}
+
+func (s *PostgresSink) Write(ctx context.Context, events []Event) error {
+	_, err := database.CopyRows(ctx, s.db, "analytics_events", analyticsColumns, events, func(e Event) []any {
+		// Properties only hold JSON values, they were decoded from JSON or built by the server
+		props, _ := json.Marshal(e.Properties)
+
+		return []any{
+			e.ID, e.Name, e.Source, nullable(e.UserID), nullable(e.AnonymousID), nullable(e.SessionID),
+			nullable(e.OrganizationID), props, e.OccurredAt, e.ReceivedAt,
+		}
+	})
+	return err
+}
+
+// Close does nothing, the pool is owned by the container
+func (s *PostgresSink) Close() error { return nil }
+
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,100 @@
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces property values that look like personal data
+const redacted = "[redacted]"
+
+var emailPattern = regexp.MustCompile(`[^@\s]+@[^@\s]+\.[^@\s]+`)
+
+// privacy applies sampling and the PII rules before an event leaves the process
+type privacy struct {
+	sampleRate float64
+	salt       []byte
+	piiFields  map[string]bool
+}
+
+func newPrivacy(sampleRate float64, salt string, piiFields []string) *privacy {
+	p := &privacy{sampleRate: sampleRate, piiFields: make(map[string]bool, len(piiFields))}
+	if salt != "" {
+		p.salt = []byte(salt)
+	}
+	for _, field := range piiFields {
+		p.piiFields[normalizeField(field)] = true
+	}
+	return p
+}
+
+// sampled reports whether a client event is kept. The decision is made per identity rather
+// than per event, so a sampled user keeps their whole journey. Server events are always kept.
+func (p *privacy) sampled(event *Event) bool {
+	if event.Source == SourceServer || p.sampleRate >= 1 {
+		return true
+	}
+	if p.sampleRate <= 0 {
+		return false
+	}
+
+	key := event.UserID
+	if key == "" {
+		key = event.AnonymousID
+	}
+	if key == "" {
+		key = event.SessionID
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return float64(binary.BigEndian.Uint64(sum[:8]))/float64(^uint64(0)) < p.sampleRate
+}
+
+// scrub pseudonymizes the identity fields and strips personal data from the properties
+func (p *privacy) scrub(event *Event) {
+	event.UserID = p.pseudonymize(event.UserID)
+	event.AnonymousID = p.pseudonymize(event.AnonymousID)
+	event.SessionID = p.pseudonymize(event.SessionID)
+
+	// Copied so the map of the caller is never modified
+	props := make(map[string]any, len(event.Properties))
+	for key, value := range event.Properties {
+		if p.piiFields[normalizeField(key)] {
+			continue
+		}
+		if s, ok := value.(string); ok && emailPattern.MatchString(s) {
+			value = redacted
+		}
+		props[key] = value
+	}
+	event.Properties = props
+}
+
+// pseudonymize replaces an id by its keyed hash when a salt is configured
+func (p *privacy) pseudonymize(id string) string {
+	if p.salt == nil {
+		return id
+	}
+	return Pseudonymize(p.salt, id)
+}
+
+// Pseudonymize replaces an id by its keyed hash, ids stay joinable across events and
+// exports sharing the key without being traceable to an account by whoever reads them
+func Pseudonymize(key []byte, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// normalizeField matches property names regardless of case and separators, ex: E-Mail, e_mail
+func normalizeField(name string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,161 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+// BufferedTracker queues events in memory and writes them to the sink in batches from a
+// single goroutine. Track never blocks, events are dropped when the buffer is full or the
+// sink fails, analytics must not slow down or break a request.
+type BufferedTracker struct {
+	sink      Sink
+	privacy   *privacy
+	events    chan Event
+	batchSize int
+	interval  time.Duration
+	timeout   time.Duration
+	log       *logger.Logger
+	outcomes  *metrics.Counter
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func NewBufferedTracker(sink Sink, cfg config.AnalyticsConfig, log *logger.Logger, reg *metrics.Registry) *BufferedTracker {
+	t := &BufferedTracker{
+		sink:      sink,
+		privacy:   newPrivacy(cfg.SampleRate, cfg.IDSalt, cfg.PIIFields),
+		events:    make(chan Event, cfg.BufferSize),
+		batchSize: cfg.BatchSize,
+		interval:  cfg.FlushInterval,
+		timeout:   cfg.Timeout,
+		log:       log,
+		outcomes:  reg.NewCounter("analytics_events_total", "Analytics events by outcome: written, sampled_out, dropped or failed.", "outcome"),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go t.run()
+	return t
+}
+
+// Track fills the identity of the caller from ctx, applies sampling and the PII rules
+// and queues the event
+func (t *BufferedTracker) Track(ctx context.Context, event Event) {
+	now := time.Now().UTC()
+
+	if event.ID == "" {
+		event.ID = newID()
+	}
+	if event.Source == "" {
+		event.Source = SourceServer
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+	event.ReceivedAt = now
+
+	if claims := middleware.AuthFromContext(ctx); claims != nil {
+		if event.UserID == "" && claims.Uid != nil {
+			event.UserID = *claims.Uid
+		}
+		if event.SessionID == "" {
+			event.SessionID = claims.Sub
+		}
+	}
+	// Guests have no user, their session identifies them until they sign up
+	if event.UserID == "" && event.AnonymousID == "" {
+		event.AnonymousID = event.SessionID
+	}
+	if event.OrganizationID == "" {
+		event.OrganizationID = tenant.FromContext(ctx)
+	}
+
+	if !t.privacy.sampled(&event) {
+		t.outcomes.Inc("sampled_out")
+		return
+	}
+	t.privacy.scrub(&event)
+
+	select {
+	case <-t.stop:
+		t.outcomes.Inc("dropped")
+	case t.events <- event:
+	default:
+		t.outcomes.Inc("dropped")
+	}
+}
+
+// Close flushes the queued events and closes the sink
+func (t *BufferedTracker) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.stop)
+		<-t.done
+		t.closeErr = t.sink.Close()
+	})
+	return t.closeErr
+}
+
+func (t *BufferedTracker) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, t.batchSize)
+	for {
+		select {
+		case event := <-t.events:
+			batch = append(batch, event)
+			if len(batch) >= t.batchSize {
+				batch = t.flush(batch)
+			}
+		case <-ticker.C:
+			batch = t.flush(batch)
+		case <-t.stop:
+			// Drain what was queued before Close, later events are dropped by Track
+			for {
+				select {
+				case event := <-t.events:
+					batch = append(batch, event)
+					if len(batch) >= t.batchSize {
+						batch = t.flush(batch)
+					}
+				default:
+					t.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes the batch to the sink and returns it emptied for reuse
+func (t *BufferedTracker) flush(batch []Event) []Event {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+
+	if err := t.sink.Write(ctx, batch); err != nil {
+		t.outcomes.Add(float64(len(batch)), "failed")
+		t.log.Warn("Analytics batch dropped", "events", len(batch), "error", err)
+	} else {
+		t.outcomes.Add(float64(len(batch)), "written")
+	}
+
+	clear(batch)
+	return batch[:0]
+}
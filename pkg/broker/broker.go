@@ -0,0 +1,84 @@
+package broker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// Domain event types published by the application
+const (
+	EventUserSignedUp    = "user.signed_up"
+	EventSessionFinished = "session.finished"
+	// EventTrainingLoadHigh tells notification consumers that the acute:chronic training
+	// load ratio of a user went above the risk threshold
+	EventTrainingLoadHigh = "training_load.high"
+	// EventTrainingReviewed tells the author of a submitted training that it was approved or rejected
+	EventTrainingReviewed = "training.reviewed"
+)
+
+// Event represents a domain event envelope sent to the broker
+type Event struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+// Publisher publishes domain events to a message broker
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+// NewEvent creates an event with a random id and the current timestamp
+func NewEvent(eventType string, data any) Event {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return Event{
+		ID:         hex.EncodeToString(b),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// New creates a publisher for the driver selected in config
+func New(cfg config.BrokerConfig, log *logger.Logger) (Publisher, error) {
+	switch cfg.Driver {
+	case "nats":
+		return newNATSPublisher(cfg, log)
+	case "kafka":
+		return newKafkaPublisher(cfg, log), nil
+	case "", "noop":
+		return noopPublisher{}, nil
+	default:
+		return nil, fmt.Errorf("unknown broker driver %q", cfg.Driver)
+	}
+}
+
+// subject returns the topic/subject name for an event type
+func subject(prefix, eventType string) string {
+	if prefix == "" {
+		return eventType
+	}
+	return prefix + "." + eventType
+}
+
+func encode(event Event) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// noopPublisher discards every event, used when no broker is configured
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(ctx context.Context, event Event) error { return nil }
+
+func (noopPublisher) Close() error { return nil }
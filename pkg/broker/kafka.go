@@ -0,0 +1,51 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaPublisher publishes events to Kafka, one topic per event type
+type kafkaPublisher struct {
+	writer *kafka.Writer
+	prefix string
+}
+
+func newKafkaPublisher(cfg config.BrokerConfig, log *logger.Logger) Publisher {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(strings.Split(cfg.URL, ",")...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		WriteTimeout:           cfg.Timeout,
+		AllowAutoTopicCreation: true,
+	}
+
+	log.Info("Broker configured", "driver", "kafka", "brokers", cfg.URL)
+	return &kafkaPublisher{writer: writer, prefix: cfg.Prefix}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := encode(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: subject(p.prefix, event.Type),
+		Key:   []byte(event.ID),
+		Value: data,
+	}); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
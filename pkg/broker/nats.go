@@ -0,0 +1,65 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// natsPublisher publishes events to a NATS JetStream stream
+type natsPublisher struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+	log    *logger.Logger
+}
+
+func newNATSPublisher(cfg config.BrokerConfig, log *logger.Logger) (Publisher, error) {
+	conn, err := nats.Connect(cfg.URL, nats.Name(cfg.ClientID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	// Ensure the stream capturing all domain events exists
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     cfg.Stream,
+		Subjects: []string{subject(cfg.Prefix, ">")},
+	}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream stream: %w", err)
+	}
+
+	log.Info("Broker connected", "driver", "nats", "stream", cfg.Stream)
+	return &natsPublisher{conn: conn, js: js, prefix: cfg.Prefix, log: log}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := encode(event)
+	if err != nil {
+		return err
+	}
+
+	// Msg ID lets JetStream drop duplicates on publisher retries
+	if _, err := p.js.Publish(ctx, subject(p.prefix, event.Type), data, jetstream.WithMsgID(event.ID)); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rizkyharahap/swimo/config"
+)
+
+var ErrRedisRequired = errors.New("redis cache driver requires a redis client")
+
+// Cache stores JSON encoded values by key with a TTL
+type Cache interface {
+	// Get decodes the value stored at key into dest, reporting whether it was found
+	Get(ctx context.Context, key string, dest any) (bool, error)
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// New creates a cache for the driver selected in config
+func New(cfg config.CacheConfig, client *redis.Client) (Cache, error) {
+	switch cfg.Driver {
+	case "redis":
+		if client == nil {
+			return nil, ErrRedisRequired
+		}
+		return NewRedisCache(client, cfg.Prefix), nil
+	case "", "memory":
+		return NewMemoryCache(), nil
+	case "none":
+		return noopCache{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache driver %q", cfg.Driver)
+	}
+}
+
+// noopCache never stores anything, every Get is a miss
+type noopCache struct{}
+
+func (noopCache) Get(ctx context.Context, key string, dest any) (bool, error) { return false, nil }
+
+func (noopCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	return nil
+}
+
+func (noopCache) Delete(ctx context.Context, keys ...string) error { return nil }
+
+func (noopCache) DeletePrefix(ctx context.Context, prefix string) error { return nil }
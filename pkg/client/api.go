@@ -0,0 +1,2744 @@
+// Code generated by genclient from the Swimo API 1.0 document. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AcceptRequest is consent.AcceptRequest
+type AcceptRequest struct {
+	Privacy *string `json:"privacy,omitempty"`
+	Terms   *string `json:"terms,omitempty"`
+}
+
+// AccountUsageResponse is usage.AccountUsageResponse
+type AccountUsageResponse struct {
+	Days     []UsageDayResponse `json:"days,omitempty"`
+	From     string             `json:"from,omitempty"`
+	Quota    *QuotaResponse     `json:"quota,omitempty"`
+	Requests int                `json:"requests,omitempty"`
+	To       string             `json:"to,omitempty"`
+}
+
+// AssignRequest is coach.AssignRequest
+type AssignRequest struct {
+	// UTC date, today or later
+	DueOn string  `json:"dueOn"`
+	Notes *string `json:"notes,omitempty"`
+	// minutes/100m
+	TargetPace *float64 `json:"targetPace,omitempty"`
+	TrainingID string   `json:"trainingId"`
+}
+
+// AssignmentResponse is coach.AssignmentResponse
+type AssignmentResponse struct {
+	Athlete *AssignmentUserResponse `json:"athlete,omitempty"`
+	Coach   *AssignmentUserResponse `json:"coach,omitempty"`
+	// unset until completed
+	Completion *CompletionResponse `json:"completion,omitempty"`
+	CreatedAt  string              `json:"createdAt,omitempty"`
+	DueOn      string              `json:"dueOn,omitempty"`
+	ID         string              `json:"id,omitempty"`
+	Notes      string              `json:"notes,omitempty"`
+	// One of: pending, overdue, completed
+	Status       string  `json:"status,omitempty"`
+	TargetPace   float64 `json:"targetPace,omitempty"`
+	TrainingID   string  `json:"trainingId,omitempty"`
+	TrainingName string  `json:"trainingName,omitempty"`
+}
+
+// AssignmentUserResponse is coach.AssignmentUserResponse
+type AssignmentUserResponse struct {
+	Name   string `json:"name,omitempty"`
+	UserID string `json:"userId,omitempty"`
+}
+
+// AthleteComplianceResponse is coach.AthleteComplianceResponse
+type AthleteComplianceResponse struct {
+	Assigned  int    `json:"assigned,omitempty"`
+	Completed int    `json:"completed,omitempty"`
+	Name      string `json:"name,omitempty"`
+	OnTime    int    `json:"onTime,omitempty"`
+	Overdue   int    `json:"overdue,omitempty"`
+	// of those, swum at or under it
+	PaceMet int `json:"paceMet,omitempty"`
+	Pending int `json:"pending,omitempty"`
+	// percent completed on time of those no longer pending, unset without any
+	Rate   float64 `json:"rate,omitempty"`
+	UserID string  `json:"userId,omitempty"`
+	// completed with a target pace
+	WithTargetPace int `json:"withTargetPace,omitempty"`
+}
+
+// AuditEventResponse is admin.AuditEventResponse
+type AuditEventResponse struct {
+	Action     string         `json:"action,omitempty"`
+	ActorEmail string         `json:"actorEmail,omitempty"`
+	CreatedAt  string         `json:"createdAt,omitempty"`
+	ID         string         `json:"id,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+}
+
+// AvatarResponse is user.AvatarResponse
+type AvatarResponse struct {
+	AvatarURL string `json:"avatarUrl,omitempty"`
+}
+
+// BillingResponse is billing.BillingResponse
+type BillingResponse struct {
+	Entitlements []string `json:"entitlements,omitempty"`
+	// One of: free, premium
+	Plan string `json:"plan,omitempty"`
+	// latest subscription, unset when never subscribed
+	Subscription *SubscriptionResponse `json:"subscription,omitempty"`
+}
+
+// CheckInRequest is pool.CheckInRequest
+type CheckInRequest struct {
+	Code      *string  `json:"code,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// CheckInResponse is pool.CheckInResponse
+type CheckInResponse struct {
+	CheckedInAt string `json:"checkedInAt,omitempty"`
+	// sessions finished until then are swum at the pool
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	ID        string `json:"id,omitempty"`
+	// One of: qr, geofence
+	Method string               `json:"method,omitempty"`
+	Pool   *PoolSummaryResponse `json:"pool,omitempty"`
+}
+
+// CheckoutRequest is billing.CheckoutRequest
+type CheckoutRequest struct {
+	// One of: premium
+	Plan string `json:"plan"`
+}
+
+// CheckoutResponse is billing.CheckoutResponse
+type CheckoutResponse struct {
+	ID string `json:"id,omitempty"`
+	// where the user pays
+	URL string `json:"url,omitempty"`
+}
+
+// CompletionResponse is coach.CompletionResponse
+type CompletionResponse struct {
+	CompletedAt string  `json:"completedAt,omitempty"`
+	OnTime      bool    `json:"onTime,omitempty"`
+	Pace        float64 `json:"pace,omitempty"`
+	// unset without target pace
+	PaceMet   bool   `json:"paceMet,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+}
+
+// ComplianceResponse is coach.ComplianceResponse
+type ComplianceResponse struct {
+	Athletes []AthleteComplianceResponse `json:"athletes,omitempty"`
+	From     string                      `json:"from,omitempty"`
+	To       string                      `json:"to,omitempty"`
+}
+
+// ConsentResponse is consent.ConsentResponse
+type ConsentResponse struct {
+	Documents []DocumentResponse `json:"documents,omitempty"`
+	Marketing *MarketingResponse `json:"marketing,omitempty"`
+}
+
+// DenySignInRequest is auth.DenySignInRequest
+type DenySignInRequest struct {
+	Token string `json:"token"`
+}
+
+// DeviceResponse is device.DeviceResponse
+type DeviceResponse struct {
+	CreatedAt  string `json:"createdAt,omitempty"`
+	ID         string `json:"id,omitempty"`
+	LastSeenAt string `json:"lastSeenAt,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Platform   string `json:"platform,omitempty"`
+}
+
+// DocumentResponse is consent.DocumentResponse
+type DocumentResponse struct {
+	AcceptedAt      string `json:"acceptedAt,omitempty"`
+	AcceptedVersion string `json:"acceptedVersion,omitempty"`
+	CurrentVersion  string `json:"currentVersion,omitempty"`
+	// One of: terms, privacy
+	Document string `json:"document,omitempty"`
+	Pending  bool   `json:"pending,omitempty"`
+}
+
+// EntryResponse is race.EntryResponse
+type EntryResponse struct {
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	Finished        bool   `json:"finished,omitempty"`
+	FinishedAt      string `json:"finishedAt,omitempty"`
+	Rank            int    `json:"rank,omitempty"`
+	RegisteredAt    string `json:"registeredAt,omitempty"`
+	SessionID       string `json:"sessionId,omitempty"`
+}
+
+// EquipmentRequest is equipment.EquipmentRequest
+type EquipmentRequest struct {
+	Brand *string `json:"brand,omitempty"`
+	// One of: fins, paddles, wetsuit, pull_buoy, kickboard, snorkel, other
+	Kind  string  `json:"kind"`
+	Name  string  `json:"name"`
+	Notes *string `json:"notes,omitempty"`
+	// Retired gear stays in the stats but is listed last
+	Retired *bool `json:"retired,omitempty"`
+}
+
+// EquipmentResponse is equipment.EquipmentResponse
+type EquipmentResponse struct {
+	Brand     string `json:"brand,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+	Retired   bool   `json:"retired,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+	// lifetime
+	Usage *UsageResponse `json:"usage,omitempty"`
+}
+
+// EquipmentStatsResponse is equipment.EquipmentStatsResponse
+type EquipmentStatsResponse struct {
+	Equipment []EquipmentUsageResponse `json:"equipment,omitempty"`
+	Kinds     []KindUsageResponse      `json:"kinds,omitempty"`
+	Year      int                      `json:"year,omitempty"`
+}
+
+// EquipmentUsageResponse is equipment.EquipmentUsageResponse
+type EquipmentUsageResponse struct {
+	DistanceMeters  int    `json:"distanceMeters,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	ID              string `json:"id,omitempty"`
+	Kind            string `json:"kind,omitempty"`
+	LastUsedAt      string `json:"lastUsedAt,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Retired         bool   `json:"retired,omitempty"`
+	Sessions        int    `json:"sessions,omitempty"`
+}
+
+// FacetValue is response.FacetValue
+type FacetValue struct {
+	Count int    `json:"count,omitempty"`
+	Label string `json:"label,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Facets is response.Facets
+type Facets map[string][]FacetValue
+
+// FinisherResponse is race.FinisherResponse
+type FinisherResponse struct {
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	FinishedAt      string `json:"finishedAt,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Rank            int    `json:"rank,omitempty"`
+}
+
+// GrantCoachRequest is coach.GrantCoachRequest
+type GrantCoachRequest struct {
+	Email string `json:"email"`
+}
+
+// GuestFlagResponse is abuse.GuestFlagResponse
+type GuestFlagResponse struct {
+	CreatedAt   string         `json:"createdAt,omitempty"`
+	Details     map[string]any `json:"details,omitempty"`
+	Fingerprint string         `json:"fingerprint,omitempty"`
+	ID          string         `json:"id,omitempty"`
+	Occurrences int            `json:"occurrences,omitempty"`
+	// One of: many_sessions, implausible_session
+	Reason         string `json:"reason,omitempty"`
+	Throttled      bool   `json:"throttled,omitempty"`
+	ThrottledUntil string `json:"throttledUntil,omitempty"`
+	UpdatedAt      string `json:"updatedAt,omitempty"`
+	UserAgent      string `json:"userAgent,omitempty"`
+}
+
+// HeartRateSessionResponse is stats.HeartRateSessionResponse
+type HeartRateSessionResponse struct {
+	CreatedAt string `json:"createdAt,omitempty"`
+	ID        string `json:"id,omitempty"`
+	// time of the laps with a heart rate
+	Seconds int                         `json:"seconds,omitempty"`
+	Zones   []HeartRateZoneTimeResponse `json:"zones,omitempty"`
+}
+
+// HeartRateZoneResponse is stats.HeartRateZoneResponse
+type HeartRateZoneResponse struct {
+	MaxBpm  int     `json:"maxBpm,omitempty"`
+	MinBpm  int     `json:"minBpm,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Percent float64 `json:"percent,omitempty"`
+	Seconds int     `json:"seconds,omitempty"`
+	Zone    int     `json:"zone,omitempty"`
+}
+
+// HeartRateZoneTimeResponse is stats.HeartRateZoneTimeResponse
+type HeartRateZoneTimeResponse struct {
+	Percent float64 `json:"percent,omitempty"`
+	Seconds int     `json:"seconds,omitempty"`
+	Zone    int     `json:"zone,omitempty"`
+}
+
+// HeartRateZonesResponse is stats.HeartRateZonesResponse
+type HeartRateZonesResponse struct {
+	From         string                 `json:"from,omitempty"`
+	Injuries     []InjuryPeriodResponse `json:"injuries,omitempty"`
+	MaxHeartRate int                    `json:"maxHeartRate,omitempty"`
+	// One of: preferences, age
+	MaxHeartRateSource string                     `json:"maxHeartRateSource,omitempty"`
+	Period             string                     `json:"period,omitempty"`
+	Sessions           []HeartRateSessionResponse `json:"sessions,omitempty"`
+	To                 string                     `json:"to,omitempty"`
+	Zones              []HeartRateZoneResponse    `json:"zones,omitempty"`
+}
+
+// ImpersonateRequest is admin.ImpersonateRequest
+type ImpersonateRequest struct {
+	ReadOnly *bool  `json:"readOnly,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// ImpersonationResponse is admin.ImpersonationResponse
+type ImpersonationResponse struct {
+	ExpiresIn int `json:"expiresIn,omitempty"`
+	// audit event of the impersonation
+	ID       string `json:"id,omitempty"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// InjuryPeriodResponse is stats.InjuryPeriodResponse
+type InjuryPeriodResponse struct {
+	ID         string `json:"id,omitempty"`
+	InjuredOn  string `json:"injuredOn,omitempty"`
+	ResolvedOn string `json:"resolvedOn,omitempty"`
+	// One of: mild, moderate, severe
+	Severity string `json:"severity,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// InjuryRequest is injury.InjuryRequest
+type InjuryRequest struct {
+	// InjuredOn and ResolvedOn are dates, ex: 2025-09-21. Without ResolvedOn the injury is ongoing.
+	InjuredOn  string  `json:"injuredOn"`
+	Notes      *string `json:"notes,omitempty"`
+	ResolvedOn *string `json:"resolvedOn,omitempty"`
+	// One of: mild, moderate, severe
+	Severity string `json:"severity"`
+	Type     string `json:"type"`
+}
+
+// InjuryResponse is injury.InjuryResponse
+type InjuryResponse struct {
+	CreatedAt  string `json:"createdAt,omitempty"`
+	ID         string `json:"id,omitempty"`
+	InjuredOn  string `json:"injuredOn,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+	Ongoing    bool   `json:"ongoing,omitempty"`
+	ResolvedOn string `json:"resolvedOn,omitempty"`
+	// One of: mild, moderate, severe
+	Severity  string `json:"severity,omitempty"`
+	Type      string `json:"type,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// KindUsageResponse is equipment.KindUsageResponse
+type KindUsageResponse struct {
+	DistanceMeters  int    `json:"distanceMeters,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	Kind            string `json:"kind,omitempty"`
+	LastUsedAt      string `json:"lastUsedAt,omitempty"`
+	Sessions        int    `json:"sessions,omitempty"`
+}
+
+// LaneRequest is pool.LaneRequest
+type LaneRequest struct {
+	// swimmers at most
+	Capacity *int    `json:"capacity,omitempty"`
+	Label    *string `json:"label,omitempty"`
+	// One of: open, closed, reserved
+	Status   string `json:"status"`
+	Swimmers *int   `json:"swimmers,omitempty"`
+}
+
+// LaneResponse is pool.LaneResponse
+type LaneResponse struct {
+	// open and under capacity
+	Available bool   `json:"available,omitempty"`
+	Capacity  int    `json:"capacity,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Number    int    `json:"number,omitempty"`
+	// One of: open, closed, reserved
+	Status    string `json:"status,omitempty"`
+	Swimmers  int    `json:"swimmers,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// LaneUpdateRequest is pool.LaneUpdateRequest
+type LaneUpdateRequest struct {
+	// One of: open, closed, reserved
+	Status   string `json:"status"`
+	Swimmers *int   `json:"swimmers,omitempty"`
+}
+
+// LanesRequest is pool.LanesRequest
+type LanesRequest struct {
+	// lanes[0] is lane 1
+	Lanes []LaneRequest `json:"lanes"`
+}
+
+// MarketingRequest is consent.MarketingRequest
+type MarketingRequest struct {
+	OptedIn bool `json:"optedIn"`
+}
+
+// MarketingResponse is consent.MarketingResponse
+type MarketingResponse struct {
+	OptedIn    bool   `json:"optedIn,omitempty"`
+	OptedInAt  string `json:"optedInAt,omitempty"`
+	OptedOutAt string `json:"optedOutAt,omitempty"`
+}
+
+// MemberResponse is coach.MemberResponse
+type MemberResponse struct {
+	Email     string `json:"email,omitempty"`
+	GrantedAt string `json:"grantedAt,omitempty"`
+	Name      string `json:"name,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+}
+
+// Message is response.Message
+type Message struct {
+	Message string `json:"message,omitempty"`
+}
+
+// Meta is response.Meta
+type Meta struct {
+	Facets     Facets      `json:"facets,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+	RequestID  string      `json:"requestId,omitempty"`
+}
+
+// MyPoolResponse is pool.MyPoolResponse
+type MyPoolResponse struct {
+	Address         string               `json:"address,omitempty"`
+	DistanceMeters  int                  `json:"distanceMeters,omitempty"`
+	DurationSeconds int                  `json:"durationSeconds,omitempty"`
+	LastSwumAt      string               `json:"lastSwumAt,omitempty"`
+	Pool            *PoolSummaryResponse `json:"pool,omitempty"`
+	Sessions        int                  `json:"sessions,omitempty"`
+}
+
+// OccupancyResponse is pool.OccupancyResponse
+type OccupancyResponse struct {
+	AvailableLanes int                  `json:"availableLanes,omitempty"`
+	Lanes          []LaneResponse       `json:"lanes,omitempty"`
+	Pool           *PoolSummaryResponse `json:"pool,omitempty"`
+	Swimmers       int                  `json:"swimmers,omitempty"`
+	// last lane update
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// OpenWaterMonthResponse is stats.OpenWaterMonthResponse
+type OpenWaterMonthResponse struct {
+	AvgWaterTemperatureC float64 `json:"avgWaterTemperatureC,omitempty"`
+	DistanceMeters       int     `json:"distanceMeters,omitempty"`
+	DurationSeconds      int     `json:"durationSeconds,omitempty"`
+	MaxWaterTemperatureC float64 `json:"maxWaterTemperatureC,omitempty"`
+	MinWaterTemperatureC float64 `json:"minWaterTemperatureC,omitempty"`
+	Month                string  `json:"month,omitempty"`
+	Sessions             int     `json:"sessions,omitempty"`
+	WetsuitSessions      int     `json:"wetsuitSessions,omitempty"`
+}
+
+// OpenWaterStatsResponse is stats.OpenWaterStatsResponse
+type OpenWaterStatsResponse struct {
+	AvgWaterTemperatureC float64                  `json:"avgWaterTemperatureC,omitempty"`
+	DistanceMeters       int                      `json:"distanceMeters,omitempty"`
+	DurationSeconds      int                      `json:"durationSeconds,omitempty"`
+	Injuries             []InjuryPeriodResponse   `json:"injuries,omitempty"`
+	MaxWaterTemperatureC float64                  `json:"maxWaterTemperatureC,omitempty"`
+	MinWaterTemperatureC float64                  `json:"minWaterTemperatureC,omitempty"`
+	Months               []OpenWaterMonthResponse `json:"months,omitempty"`
+	Sessions             int                      `json:"sessions,omitempty"`
+	WetsuitSessions      int                      `json:"wetsuitSessions,omitempty"`
+	Year                 int                      `json:"year,omitempty"`
+}
+
+// Pagination is response.Pagination
+type Pagination struct {
+	Limit int `json:"limit,omitempty"`
+	Page  int `json:"page,omitempty"`
+	// totals are approximate on large lists
+	TotalEstimated bool `json:"totalEstimated,omitempty"`
+	TotalItems     int  `json:"totalItems,omitempty"`
+	TotalPages     int  `json:"totalPages,omitempty"`
+}
+
+// PairDeviceRequest is device.PairDeviceRequest
+type PairDeviceRequest struct {
+	Name string `json:"name"`
+	// One of: watchos, wearos, garmin, ios, android
+	Platform string `json:"platform"`
+}
+
+// PairDeviceResponse is device.PairDeviceResponse
+type PairDeviceResponse struct {
+	Device *DeviceResponse `json:"device,omitempty"`
+	// keys the signature of batches, never sent
+	SigningSecret string `json:"signingSecret,omitempty"`
+	Token         string `json:"token,omitempty"`
+}
+
+// PaymentResponse is billing.PaymentResponse
+type PaymentResponse struct {
+	// smallest currency unit
+	Amount   int    `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	ID       string `json:"id,omitempty"`
+	PaidAt   string `json:"paidAt,omitempty"`
+	Refunded int    `json:"refunded,omitempty"`
+	UserID   string `json:"userId,omitempty"`
+}
+
+// PoolRequest is pool.PoolRequest
+type PoolRequest struct {
+	Address      *string  `json:"address,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	LengthMeters *int     `json:"lengthMeters,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	Name         string   `json:"name"`
+	// geofence
+	RadiusMeters int `json:"radiusMeters"`
+}
+
+// PoolResponse is pool.PoolResponse
+type PoolResponse struct {
+	Address string `json:"address,omitempty"`
+	// to encode in the QR posted at the pool
+	CheckInCode  string  `json:"checkInCode,omitempty"`
+	CreatedAt    string  `json:"createdAt,omitempty"`
+	ID           string  `json:"id,omitempty"`
+	Latitude     float64 `json:"latitude,omitempty"`
+	LengthMeters int     `json:"lengthMeters,omitempty"`
+	Longitude    float64 `json:"longitude,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	RadiusMeters int     `json:"radiusMeters,omitempty"`
+	UpdatedAt    string  `json:"updatedAt,omitempty"`
+}
+
+// PoolSummaryResponse is pool.PoolSummaryResponse
+type PoolSummaryResponse struct {
+	ID           string `json:"id,omitempty"`
+	LengthMeters int    `json:"lengthMeters,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+// PreferencesRequest is user.PreferencesRequest
+type PreferencesRequest struct {
+	MaxHeartRate *int   `json:"maxHeartRate,omitempty"`
+	Timezone     string `json:"timezone"`
+	// version read, preferences edited since are refused
+	Version      *int  `json:"version,omitempty"`
+	WeeklyDigest *bool `json:"weeklyDigest,omitempty"`
+}
+
+// PreferencesResponse is user.PreferencesResponse
+type PreferencesResponse struct {
+	MaxHeartRate int    `json:"maxHeartRate,omitempty"`
+	Timezone     string `json:"timezone,omitempty"`
+	Version      int    `json:"version,omitempty"`
+	WeeklyDigest bool   `json:"weeklyDigest,omitempty"`
+}
+
+// QuotaResponse is usage.QuotaResponse
+type QuotaResponse struct {
+	Limit     int    `json:"limit,omitempty"`
+	Remaining int    `json:"remaining,omitempty"`
+	ResetsAt  string `json:"resetsAt,omitempty"`
+	Used      int    `json:"used,omitempty"`
+}
+
+// RaceRequest is race.RaceRequest
+type RaceRequest struct {
+	// slowest accepted time
+	CutoffSeconds  *int    `json:"cutoffSeconds,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	DistanceMeters int     `json:"distanceMeters"`
+	EndsAt         string  `json:"endsAt"`
+	Name           string  `json:"name"`
+	StartsAt       string  `json:"startsAt"`
+}
+
+// RaceResponse is race.RaceResponse
+type RaceResponse struct {
+	CutoffSeconds  int    `json:"cutoffSeconds,omitempty"`
+	Description    string `json:"description,omitempty"`
+	DistanceMeters int    `json:"distanceMeters,omitempty"`
+	EndsAt         string `json:"endsAt,omitempty"`
+	Entries        int    `json:"entries,omitempty"`
+	// of the signed in swimmer, when registered
+	Entry     *EntryResponse `json:"entry,omitempty"`
+	Finishers int            `json:"finishers,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	StartsAt  string         `json:"startsAt,omitempty"`
+	// One of: upcoming, open, closed
+	Status string `json:"status,omitempty"`
+}
+
+// RefreshTokenRequest is auth.RefreshTokenRequest
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RefreshTokenResponse is auth.RefreshTokenResponse
+type RefreshTokenResponse struct {
+	ExpiresInMs  int    `json:"expiresInMs,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	Token        string `json:"token,omitempty"`
+}
+
+// RefundRequest is billing.RefundRequest
+type RefundRequest struct {
+	// smallest currency unit
+	Amount *int `json:"amount,omitempty"`
+}
+
+// ResultRequest is race.ResultRequest
+type ResultRequest struct {
+	SessionID string `json:"sessionId"`
+}
+
+// SessionEquipmentRequest is equipment.SessionEquipmentRequest
+type SessionEquipmentRequest struct {
+	EquipmentIDs []string `json:"equipmentIds,omitempty"`
+}
+
+// SignInGuestRequest is auth.SignInGuestRequest
+type SignInGuestRequest struct {
+	Age *int `json:"age,omitempty"`
+	// One of: male, female
+	Gender *string  `json:"gender,omitempty"`
+	Height *float64 `json:"height,omitempty"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// SignInGuestResponse is auth.SignInGuestResponse
+type SignInGuestResponse struct {
+	Age          int     `json:"age,omitempty"`
+	ExpiresIn    int     `json:"expiresIn,omitempty"`
+	Gender       string  `json:"gender,omitempty"`
+	Height       float64 `json:"height,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	RefreshToken string  `json:"refreshToken,omitempty"`
+	Token        string  `json:"token,omitempty"`
+	Weight       float64 `json:"weight,omitempty"`
+}
+
+// SignInRequest is auth.SignInRequest
+type SignInRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// SignInResponse is auth.SignInResponse
+type SignInResponse struct {
+	Age          int     `json:"age,omitempty"`
+	Email        string  `json:"email,omitempty"`
+	ExpiresIn    int     `json:"expiresIn,omitempty"`
+	Gender       string  `json:"gender,omitempty"`
+	Height       float64 `json:"height,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	RefreshToken string  `json:"refreshToken,omitempty"`
+	Token        string  `json:"token,omitempty"`
+	Weight       float64 `json:"weight,omitempty"`
+}
+
+// SignUpRequest is auth.SignUpRequest
+type SignUpRequest struct {
+	Age             *int   `json:"age,omitempty"`
+	ConfirmPassword string `json:"confirmPassword"`
+	Email           string `json:"email"`
+	// One of: male, female
+	Gender *string  `json:"gender,omitempty"`
+	Height *float64 `json:"height,omitempty"`
+	// opt in to marketing emails, unchecked by default
+	MarketingEmails *bool    `json:"marketingEmails,omitempty"`
+	Name            string   `json:"name"`
+	Password        string   `json:"password"`
+	Weight          *float64 `json:"weight,omitempty"`
+}
+
+// SubscriptionResponse is billing.SubscriptionResponse
+type SubscriptionResponse struct {
+	CancelAtPeriodEnd bool   `json:"cancelAtPeriodEnd,omitempty"`
+	CurrentPeriodEnd  string `json:"currentPeriodEnd,omitempty"`
+	Plan              string `json:"plan,omitempty"`
+	// as the payment provider reports it
+	Status string `json:"status,omitempty"`
+}
+
+// TrackEventRequest is event.TrackEventRequest
+type TrackEventRequest struct {
+	AnonymousID *string `json:"anonymousId,omitempty"`
+	Name        string  `json:"name"`
+	OccurredAt  *string `json:"occurredAt,omitempty"`
+	Properties  any     `json:"properties,omitempty"`
+}
+
+// TrackEventsRequest is event.TrackEventsRequest
+type TrackEventsRequest struct {
+	Events []TrackEventRequest `json:"events"`
+}
+
+// TrainingAggregatesResponse is training.TrainingAggregatesResponse
+type TrainingAggregatesResponse struct {
+	// sessions of the user on the training
+	Completions int `json:"completions,omitempty"`
+}
+
+// TrainingApproveRequest is training.TrainingApproveRequest
+type TrainingApproveRequest struct {
+	Version *int `json:"version,omitempty"`
+}
+
+// TrainingConditionsRequest is training.TrainingConditionsRequest
+type TrainingConditionsRequest struct {
+	CurrentNotes      *string  `json:"currentNotes,omitempty"`
+	CurrentSpeedKmh   *float64 `json:"currentSpeedKmh,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty"`
+	Longitude         *float64 `json:"longitude,omitempty"`
+	WaterTemperatureC *float64 `json:"waterTemperatureC,omitempty"`
+	WaveHeightMeters  *float64 `json:"waveHeightMeters,omitempty"`
+	WaveNotes         *string  `json:"waveNotes,omitempty"`
+	Wetsuit           *bool    `json:"wetsuit,omitempty"`
+}
+
+// TrainingConditionsResponse is training.TrainingConditionsResponse
+type TrainingConditionsResponse struct {
+	// a measurement comes from the weather provider
+	AutoFilled        bool    `json:"autoFilled,omitempty"`
+	CurrentNotes      string  `json:"currentNotes,omitempty"`
+	CurrentSpeedKmh   float64 `json:"currentSpeedKmh,omitempty"`
+	Latitude          float64 `json:"latitude,omitempty"`
+	Longitude         float64 `json:"longitude,omitempty"`
+	UpdatedAt         string  `json:"updatedAt,omitempty"`
+	WaterTemperatureC float64 `json:"waterTemperatureC,omitempty"`
+	WaveHeightMeters  float64 `json:"waveHeightMeters,omitempty"`
+	WaveNotes         string  `json:"waveNotes,omitempty"`
+	Wetsuit           bool    `json:"wetsuit,omitempty"`
+}
+
+// TrainingDeletedSessionResponse is training.TrainingDeletedSessionResponse
+type TrainingDeletedSessionResponse struct {
+	CaloriesKcal    int                         `json:"caloriesKcal,omitempty"`
+	Conditions      *TrainingConditionsResponse `json:"conditions,omitempty"`
+	CreatedAt       string                      `json:"createdAt,omitempty"`
+	DeletedAt       string                      `json:"deletedAt,omitempty"`
+	DistanceMeters  int                         `json:"distanceMeters,omitempty"`
+	DurationSeconds int                         `json:"durationSeconds,omitempty"`
+	ID              string                      `json:"id,omitempty"`
+	Laps            []TrainingLapResponse       `json:"laps,omitempty"`
+	Pace            float64                     `json:"pace,omitempty"`
+	Rpe             int                         `json:"rpe,omitempty"`
+	TrainingID      string                      `json:"trainingId,omitempty"`
+	UserID          string                      `json:"userId,omitempty"`
+}
+
+// TrainingDuplicateResponse is training.TrainingDuplicateResponse
+type TrainingDuplicateResponse struct {
+	DetectedAt     string                         `json:"detectedAt,omitempty"`
+	DuplicateOf    *TrainingSessionDetailResponse `json:"duplicateOf,omitempty"`
+	ID             string                         `json:"id,omitempty"`
+	OverlapSeconds int                            `json:"overlapSeconds,omitempty"`
+	Session        *TrainingSessionDetailResponse `json:"session,omitempty"`
+}
+
+// TrainingExportFileResponse is training.TrainingExportFileResponse
+type TrainingExportFileResponse struct {
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// TrainingFinishSessionRequest is training.TrainingFinishSessionRequest
+type TrainingFinishSessionRequest struct {
+	Conditions      *TrainingConditionsRequest `json:"conditions,omitempty"`
+	DistanceMeters  *int                       `json:"distanceMeters,omitempty"`
+	DurationSeconds *int                       `json:"durationSeconds,omitempty"`
+	Laps            []TrainingLapRequest       `json:"laps,omitempty"`
+	// records a session failing the plausibility checks, admins only
+	Override *bool `json:"override,omitempty"`
+	// the distance must be a whole number of lengths
+	PoolLengthMeters *int `json:"poolLengthMeters,omitempty"`
+	// rate of perceived exertion
+	Rpe *int `json:"rpe,omitempty"`
+}
+
+// TrainingImportSessionRequest is training.TrainingImportSessionRequest
+type TrainingImportSessionRequest struct {
+	Conditions      *TrainingConditionsRequest `json:"conditions,omitempty"`
+	DistanceMeters  *int                       `json:"distanceMeters,omitempty"`
+	DurationSeconds *int                       `json:"durationSeconds,omitempty"`
+	Laps            []TrainingLapRequest       `json:"laps,omitempty"`
+	Rpe             *int                       `json:"rpe,omitempty"`
+	StartedAt       string                     `json:"startedAt"`
+	TrainingID      string                     `json:"trainingId"`
+}
+
+// TrainingImportSessionsRequest is training.TrainingImportSessionsRequest
+type TrainingImportSessionsRequest struct {
+	Sessions []TrainingImportSessionRequest `json:"sessions"`
+	// One of: watch, google_fit, apple_health
+	Source *string `json:"source,omitempty"`
+}
+
+// TrainingImportSessionsResponse is training.TrainingImportSessionsResponse
+type TrainingImportSessionsResponse struct {
+	Imported           int                       `json:"imported,omitempty"`
+	PossibleDuplicates int                       `json:"possibleDuplicates,omitempty"`
+	Sessions           []TrainingSessionResponse `json:"sessions,omitempty"`
+}
+
+// TrainingItemResponse is training.TrainingItemResponse
+type TrainingItemResponse struct {
+	Descriptions string `json:"descriptions,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Level        string `json:"level,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+}
+
+// TrainingLapRequest is training.TrainingLapRequest
+type TrainingLapRequest struct {
+	AvgHeartRate    *int `json:"avgHeartRate,omitempty"`
+	DistanceMeters  *int `json:"distanceMeters,omitempty"`
+	DurationSeconds *int `json:"durationSeconds,omitempty"`
+	StrokeCount     *int `json:"strokeCount,omitempty"`
+}
+
+// TrainingLapResponse is training.TrainingLapResponse
+type TrainingLapResponse struct {
+	AvgHeartRate    int `json:"avgHeartRate,omitempty"`
+	DistanceMeters  int `json:"distanceMeters,omitempty"`
+	DurationSeconds int `json:"durationSeconds,omitempty"`
+	Number          int `json:"number,omitempty"`
+	StrokeCount     int `json:"strokeCount,omitempty"`
+}
+
+// TrainingLoadDayResponse is stats.TrainingLoadDayResponse
+type TrainingLoadDayResponse struct {
+	Acute    float64 `json:"acute,omitempty"`
+	Chronic  float64 `json:"chronic,omitempty"`
+	Date     string  `json:"date,omitempty"`
+	Load     float64 `json:"load,omitempty"`
+	Ratio    float64 `json:"ratio,omitempty"`
+	Sessions int     `json:"sessions,omitempty"`
+}
+
+// TrainingLoadResponse is stats.TrainingLoadResponse
+type TrainingLoadResponse struct {
+	Acute    float64                   `json:"acute,omitempty"`
+	AtRisk   bool                      `json:"atRisk,omitempty"`
+	Chronic  float64                   `json:"chronic,omitempty"`
+	Days     []TrainingLoadDayResponse `json:"days,omitempty"`
+	From     string                    `json:"from,omitempty"`
+	Injuries []InjuryPeriodResponse    `json:"injuries,omitempty"`
+	// unset without chronic load
+	Ratio         float64 `json:"ratio,omitempty"`
+	RiskThreshold float64 `json:"riskThreshold,omitempty"`
+	To            string  `json:"to,omitempty"`
+}
+
+// TrainingMergeDuplicateRequest is training.TrainingMergeDuplicateRequest
+type TrainingMergeDuplicateRequest struct {
+	KeepID *string `json:"keepId,omitempty"`
+}
+
+// TrainingRejectRequest is training.TrainingRejectRequest
+type TrainingRejectRequest struct {
+	Reason  string `json:"reason"`
+	Version *int   `json:"version,omitempty"`
+}
+
+// TrainingRequest is training.TrainingRequest
+type TrainingRequest struct {
+	CaloriesKcal *int    `json:"caloriesKcal,omitempty"`
+	CategoryCode string  `json:"categoryCode"`
+	Content      string  `json:"content"`
+	Descriptions string  `json:"descriptions"`
+	Level        string  `json:"level"`
+	Name         string  `json:"name"`
+	ThumbnailURL string  `json:"thumbnailUrl"`
+	Time         string  `json:"time"`
+	VideoURL     *string `json:"videoUrl,omitempty"`
+}
+
+// TrainingResponse is training.TrainingResponse
+type TrainingResponse struct {
+	Aggregates   *TrainingAggregatesResponse `json:"aggregates,omitempty"`
+	CaloriesKcal int                         `json:"caloriesKcal,omitempty"`
+	CategoryCode string                      `json:"categoryCode,omitempty"`
+	CategoryName string                      `json:"categoryName,omitempty"`
+	Content      string                      `json:"content,omitempty"`
+	Descriptions string                      `json:"descriptions,omitempty"`
+	ID           string                      `json:"id,omitempty"`
+	Level        string                      `json:"level,omitempty"`
+	Name         string                      `json:"name,omitempty"`
+	ReviewReason string                      `json:"reviewReason,omitempty"`
+	// One of: pending_review, approved, rejected
+	Status       string `json:"status,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+	TimeLabel    string `json:"timeLabel,omitempty"`
+	// sent back by edits, a training edited since is refused
+	Version  int    `json:"version,omitempty"`
+	VideoURL string `json:"videoUrl,omitempty"`
+}
+
+// TrainingReviewResponse is training.TrainingReviewResponse
+type TrainingReviewResponse struct {
+	AuthorID     string `json:"authorId,omitempty"`
+	CategoryCode string `json:"categoryCode,omitempty"`
+	CreatedAt    string `json:"createdAt,omitempty"`
+	DeletedAt    string `json:"deletedAt,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Level        string `json:"level,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ReviewReason string `json:"reviewReason,omitempty"`
+	ReviewedAt   string `json:"reviewedAt,omitempty"`
+	// One of: pending_review, approved, rejected
+	Status  string `json:"status,omitempty"`
+	Version int    `json:"version,omitempty"`
+}
+
+// TrainingSessionDetailResponse is training.TrainingSessionDetailResponse
+type TrainingSessionDetailResponse struct {
+	CaloriesKcal    int                         `json:"caloriesKcal,omitempty"`
+	Conditions      *TrainingConditionsResponse `json:"conditions,omitempty"`
+	DistanceMeters  int                         `json:"distanceMeters,omitempty"`
+	DurationSeconds int                         `json:"durationSeconds,omitempty"`
+	ID              string                      `json:"id,omitempty"`
+	Laps            []TrainingLapResponse       `json:"laps,omitempty"`
+	Pace            float64                     `json:"pace,omitempty"`
+	Rpe             int                         `json:"rpe,omitempty"`
+	// One of: manual, import, watch, google_fit, apple_health, sync
+	Source     string `json:"source,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	TrainingID string `json:"trainingId,omitempty"`
+	UserID     string `json:"userId,omitempty"`
+}
+
+// TrainingSessionExportResponse is training.TrainingSessionExportResponse
+type TrainingSessionExportResponse struct {
+	CaloriesKcal    int                         `json:"caloriesKcal,omitempty"`
+	Conditions      *TrainingConditionsResponse `json:"conditions,omitempty"`
+	CreatedAt       string                      `json:"createdAt,omitempty"`
+	DistanceMeters  int                         `json:"distanceMeters,omitempty"`
+	DurationSeconds int                         `json:"durationSeconds,omitempty"`
+	ID              string                      `json:"id,omitempty"`
+	Laps            []TrainingLapResponse       `json:"laps,omitempty"`
+	Pace            float64                     `json:"pace,omitempty"`
+	Rpe             int                         `json:"rpe,omitempty"`
+	TrainingID      string                      `json:"trainingId,omitempty"`
+	UserID          string                      `json:"userId,omitempty"`
+}
+
+// TrainingSessionResponse is training.TrainingSessionResponse
+type TrainingSessionResponse struct {
+	CaloriesKcal    int                         `json:"caloriesKcal,omitempty"`
+	Conditions      *TrainingConditionsResponse `json:"conditions,omitempty"`
+	DistanceMeters  int                         `json:"distanceMeters,omitempty"`
+	DurationSeconds int                         `json:"durationSeconds,omitempty"`
+	ID              string                      `json:"id,omitempty"`
+	Laps            []TrainingLapResponse       `json:"laps,omitempty"`
+	Pace            float64                     `json:"pace,omitempty"`
+	// pool the swimmer was checked in at
+	PoolID     string `json:"poolId,omitempty"`
+	Rpe        int    `json:"rpe,omitempty"`
+	TrainingID string `json:"trainingId,omitempty"`
+	UserID     string `json:"userId,omitempty"`
+}
+
+// TrainingSyncResult is training.TrainingSyncResult
+type TrainingSyncResult struct {
+	Conflicts []string                 `json:"conflicts,omitempty"`
+	Errors    map[string]string        `json:"errors,omitempty"`
+	Session   *TrainingSessionResponse `json:"session,omitempty"`
+	// One of: created, updated, unchanged, rejected
+	Status string `json:"status,omitempty"`
+}
+
+// TrainingSyncSessionRequest is training.TrainingSyncSessionRequest
+type TrainingSyncSessionRequest struct {
+	ClientID        string               `json:"clientId"`
+	DistanceMeters  *int                 `json:"distanceMeters,omitempty"`
+	DurationSeconds *int                 `json:"durationSeconds,omitempty"`
+	Laps            []TrainingLapRequest `json:"laps,omitempty"`
+	StartedAt       string               `json:"startedAt"`
+	TrainingID      string               `json:"trainingId"`
+	UpdatedAt       string               `json:"updatedAt"`
+}
+
+// TrainingSyncSessionsRequest is training.TrainingSyncSessionsRequest
+type TrainingSyncSessionsRequest struct {
+	Sessions []TrainingSyncSessionRequest `json:"sessions,omitempty"`
+}
+
+// TrainingSyncSessionsResponse is training.TrainingSyncSessionsResponse
+type TrainingSyncSessionsResponse struct {
+	PossibleDuplicates int                           `json:"possibleDuplicates,omitempty"`
+	Results            map[string]TrainingSyncResult `json:"results,omitempty"`
+}
+
+// UsageClientResponse is usage.UsageClientResponse
+type UsageClientResponse struct {
+	DeviceID string `json:"deviceId,omitempty"`
+	// One of: app, device
+	Kind     string `json:"kind,omitempty"`
+	Requests int    `json:"requests,omitempty"`
+}
+
+// UsageDayResponse is usage.UsageDayResponse
+type UsageDayResponse struct {
+	Clients  []UsageClientResponse `json:"clients,omitempty"`
+	Date     string                `json:"date,omitempty"`
+	Requests int                   `json:"requests,omitempty"`
+}
+
+// UsageResponse is equipment.UsageResponse
+type UsageResponse struct {
+	DistanceMeters  int    `json:"distanceMeters,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	LastUsedAt      string `json:"lastUsedAt,omitempty"`
+	Sessions        int    `json:"sessions,omitempty"`
+}
+
+// UserDetailResponse is admin.UserDetailResponse
+type UserDetailResponse struct {
+	AccountID      string               `json:"accountId,omitempty"`
+	ActiveSignIns  int                  `json:"activeSignIns,omitempty"`
+	AuditEvents    []AuditEventResponse `json:"auditEvents,omitempty"`
+	CreatedAt      string               `json:"createdAt,omitempty"`
+	DeletedAt      string               `json:"deletedAt,omitempty"`
+	Email          string               `json:"email,omitempty"`
+	LastActivityAt string               `json:"lastActivityAt,omitempty"`
+	Locked         bool                 `json:"locked,omitempty"`
+	Name           string               `json:"name,omitempty"`
+	OrganizationID string               `json:"organizationId,omitempty"`
+	// One of: user, admin
+	Role          string `json:"role,omitempty"`
+	SessionsCount int    `json:"sessionsCount,omitempty"`
+	UserID        string `json:"userId,omitempty"`
+}
+
+// UserSummaryResponse is admin.UserSummaryResponse
+type UserSummaryResponse struct {
+	AccountID string `json:"accountId,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	DeletedAt string `json:"deletedAt,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Locked    bool   `json:"locked,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// One of: user, admin
+	Role   string `json:"role,omitempty"`
+	UserID string `json:"userId,omitempty"`
+}
+
+// GetAPIUsageParams are the query parameters of GetAPIUsage
+type GetAPIUsageParams struct {
+	// Days of the range, today included
+	Days *int
+}
+
+func (p *GetAPIUsageParams) values() url.Values {
+	q := url.Values{}
+	if p.Days != nil {
+		q.Set("days", strconv.Itoa(*p.Days))
+	}
+	return q
+}
+
+// GetAPIUsage calls GET /account/usage: Get API usage
+//
+// Requests of the account on each UTC day of the range ending today, by client: the app, signed in
+// with an access token, and each paired device calling with its token. Device requests count
+// against the monthly quota of the account, once it is used up they answer 429 QUOTA_EXCEEDED with
+// Quota-Limit, Quota-Remaining and Quota-Reset headers until the next UTC month.
+func (c *Client) GetAPIUsage(ctx context.Context, params *GetAPIUsageParams) (*AccountUsageResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data AccountUsageResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/account/usage", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListFlaggedGuestsParams are the query parameters of ListFlaggedGuests
+type ListFlaggedGuestsParams struct {
+	// Flag reason, one of: many_sessions, implausible_session
+	Reason *string
+	// Page number
+	Page *int
+	// Number of items per page
+	Limit *int
+	// Sort field and direction, one of: updated_at.asc, updated_at.desc, occurrences.asc,
+	// occurrences.desc
+	Sort *string
+}
+
+func (p *ListFlaggedGuestsParams) values() url.Values {
+	q := url.Values{}
+	if p.Reason != nil {
+		q.Set("reason", *p.Reason)
+	}
+	if p.Page != nil {
+		q.Set("page", strconv.Itoa(*p.Page))
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Sort != nil {
+		q.Set("sort", *p.Sort)
+	}
+	return q
+}
+
+// ListFlaggedGuests calls GET /admin/guests/flagged: List flagged guests
+//
+// List the open flags of guest clients caught by the abuse heuristics: too many guest sessions
+// from one fingerprint in an hour, or a reported swim nobody can swim. A throttled fingerprint
+// must sign up until throttledUntil. Admin only.
+func (c *Client) ListFlaggedGuests(ctx context.Context, params *ListFlaggedGuestsParams) ([]GuestFlagResponse, *Meta, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []GuestFlagResponse
+	raw, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/guests/flagged", query: query, auth: authUser}, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := decodeMeta(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, meta, nil
+}
+
+// ClearGuestFlag calls DELETE /admin/guests/flagged/{id}: Clear a guest flag
+//
+// Close a flag raised by mistake, its fingerprint may sign in as a guest again unless another open
+// flag still throttles it. Admin only.
+func (c *Client) ClearGuestFlag(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/admin/guests/flagged/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RefundPayment calls POST /admin/payments/{id}/refund: Refund a payment
+//
+// Give back an amount of a payment through the payment provider, the rest of it without amount.
+// The subscription it paid is kept, cancel it at the provider to end it. Sent again with the same
+// Idempotency-Key, ex: after a timeout, the refund asked first is made once. Admin only.
+func (c *Client) RefundPayment(ctx context.Context, id string, body *RefundRequest) (*PaymentResponse, error) {
+	var data PaymentResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/payments/" + url.PathEscape(id) + "/refund", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListPools calls GET /admin/pools: List pools
+//
+// The pools of the organization and the shared ones by name, with the check in code of their QR.
+// Admin only.
+func (c *Client) ListPools(ctx context.Context) ([]PoolResponse, error) {
+	var data []PoolResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/pools", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreatePool calls POST /admin/pools: Create a pool
+//
+// Create a pool of the organization with a new check in code to post as a QR at the pool. Swimmers
+// within radiusMeters of the location can check in without it. Admin only.
+func (c *Client) CreatePool(ctx context.Context, body *PoolRequest) (*PoolResponse, error) {
+	var data PoolResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/pools", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdatePool calls PUT /admin/pools/{id}: Update a pool
+//
+// Replace the details of a pool of the organization, its check in code is kept. Admin only.
+func (c *Client) UpdatePool(ctx context.Context, id string, body *PoolRequest) (*PoolResponse, error) {
+	var data PoolResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/admin/pools/" + url.PathEscape(id), body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// PublishLanes calls PUT /admin/pools/{id}/lanes: Publish lanes
+//
+// Replace the lane board of a pool of the organization, lanes[0] being lane 1, and push it to the
+// live boards. Admin only.
+func (c *Client) PublishLanes(ctx context.Context, id string, body *LanesRequest) (*OccupancyResponse, error) {
+	var data OccupancyResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/admin/pools/" + url.PathEscape(id) + "/lanes", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateLane calls PUT /admin/pools/{id}/lanes/{number}: Update a lane
+//
+// Set the status and swimmers of a published lane of a pool of the organization as swimmers come
+// and go, and push the board to the live boards. Admin only.
+func (c *Client) UpdateLane(ctx context.Context, id string, number string, body *LaneUpdateRequest) (*OccupancyResponse, error) {
+	var data OccupancyResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/admin/pools/" + url.PathEscape(id) + "/lanes/" + url.PathEscape(number), body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateRace calls POST /admin/races: Create a race
+//
+// Create a virtual race, swum anywhere between startsAt and endsAt. Admin only.
+func (c *Client) CreateRace(ctx context.Context, body *RaceRequest) (*RaceResponse, error) {
+	var data RaceResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/races", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateRace calls PUT /admin/races/{id}: Update a race
+//
+// Replace the details of a race, results already accepted are kept. Admin only.
+func (c *Client) UpdateRace(ctx context.Context, id string, body *RaceRequest) (*RaceResponse, error) {
+	var data RaceResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/admin/races/" + url.PathEscape(id), body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteRace calls DELETE /admin/races/{id}: Delete a race
+//
+// Delete a race nobody registered to yet. Admin only.
+func (c *Client) DeleteRace(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/admin/races/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListTrainingsByReviewStatusParams are the query parameters of ListTrainingsByReviewStatus
+type ListTrainingsByReviewStatusParams struct {
+	// Review status, one of: pending_review, approved, rejected
+	Status *string
+}
+
+func (p *ListTrainingsByReviewStatusParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != nil {
+		q.Set("status", *p.Status)
+	}
+	return q
+}
+
+// ListTrainingsByReviewStatus calls GET /admin/trainings: List trainings by review status
+//
+// List up to 100 trainings of the organization in a review status, the pending ones by default.
+// Oldest first. Admin only.
+func (c *Client) ListTrainingsByReviewStatus(ctx context.Context, params *ListTrainingsByReviewStatusParams) ([]TrainingReviewResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []TrainingReviewResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/trainings", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListDeletedTrainings calls GET /admin/trainings/deleted: List deleted trainings
+//
+// List up to 100 deleted trainings of the organization, last deleted first. Admin only.
+func (c *Client) ListDeletedTrainings(ctx context.Context) ([]TrainingReviewResponse, error) {
+	var data []TrainingReviewResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/trainings/deleted", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RestoreDeletedTrainingSession calls POST /admin/trainings/sessions/{id}/restore: Restore a
+// deleted training session
+//
+// Put a deleted session back in the history of its user. Admin only.
+func (c *Client) RestoreDeletedTrainingSession(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/trainings/sessions/" + url.PathEscape(id) + "/restore", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteTraining calls DELETE /admin/trainings/{id}: Delete a training
+//
+// Remove a training of the organization from the catalog and the moderation lists, sessions
+// recorded on it are kept. Trainings of the shared catalog can only be deleted outside an
+// organization. Admin only.
+func (c *Client) DeleteTraining(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/admin/trainings/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ApproveTraining calls POST /admin/trainings/{id}/approve: Approve a training
+//
+// Publish a training pending review to the catalog, its author is notified. With a version, a
+// training edited since is refused with the current version. Admin only.
+func (c *Client) ApproveTraining(ctx context.Context, id string, body *TrainingApproveRequest) (*TrainingReviewResponse, error) {
+	var data TrainingReviewResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/trainings/" + url.PathEscape(id) + "/approve", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RejectTraining calls POST /admin/trainings/{id}/reject: Reject a training
+//
+// Keep a training pending review out of the catalog, its author is notified with the reason. With
+// a version, a training edited since is refused with the current version. Admin only.
+func (c *Client) RejectTraining(ctx context.Context, id string, body *TrainingRejectRequest) (*TrainingReviewResponse, error) {
+	var data TrainingReviewResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/trainings/" + url.PathEscape(id) + "/reject", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RestoreDeletedTraining calls POST /admin/trainings/{id}/restore: Restore a deleted training
+//
+// Put a deleted training back in the catalog with the review status it had. Admin only.
+func (c *Client) RestoreDeletedTraining(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/trainings/" + url.PathEscape(id) + "/restore", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SearchUsersParams are the query parameters of SearchUsers
+type SearchUsersParams struct {
+	// Part of the email or name
+	Query *string
+	// Search the deleted users
+	Deleted *bool
+	// Page number
+	Page *int
+	// Number of items per page
+	Limit *int
+	// Sort field and direction, one of: email.asc, email.desc, name.asc, name.desc, created_at.asc,
+	// created_at.desc
+	Sort *string
+}
+
+func (p *SearchUsersParams) values() url.Values {
+	q := url.Values{}
+	if p.Query != nil {
+		q.Set("query", *p.Query)
+	}
+	if p.Deleted != nil {
+		q.Set("deleted", strconv.FormatBool(*p.Deleted))
+	}
+	if p.Page != nil {
+		q.Set("page", strconv.Itoa(*p.Page))
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Sort != nil {
+		q.Set("sort", *p.Sort)
+	}
+	return q
+}
+
+// SearchUsers calls GET /admin/users: Search users
+//
+// Search the users of the organization by email or name, case insensitive and anywhere in the
+// value. Without query every user is listed. With deleted=true the deleted users are searched
+// instead. Admin only.
+func (c *Client) SearchUsers(ctx context.Context, params *SearchUsersParams) ([]UserSummaryResponse, *Meta, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []UserSummaryResponse
+	raw, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/users", query: query, auth: authUser}, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := decodeMeta(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, meta, nil
+}
+
+// GetUser calls GET /admin/users/{id}: Get a user
+//
+// Account status, recorded sessions, active sign ins, last activity and the 20 latest audit events
+// of a user. The view itself is audited. Admin only.
+func (c *Client) GetUser(ctx context.Context, id string) (*UserDetailResponse, error) {
+	var data UserDetailResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/users/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteUser calls DELETE /admin/users/{id}: Delete a user
+//
+// Softly delete a user and its account: it can no longer sign in, its sign ins are revoked and it
+// leaves the lists and stats. Access tokens already issued stay valid until they expire. Admins
+// cannot be deleted. The deletion is audited and can be undone with restore. Admin only.
+func (c *Client) DeleteUser(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/admin/users/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ImpersonateUser calls POST /admin/users/{id}/impersonate: Impersonate a user
+//
+// Mint a token acting as the user to reproduce a reported bug. It is read only unless readOnly is
+// false, expires after JWT_IMPERSONATION_TTL_MIN minutes and has no refresh token. The
+// impersonation and every request made with the token are recorded in the audit events of the
+// user. Admins cannot be impersonated, the token is refused by gRPC. Admin only.
+func (c *Client) ImpersonateUser(ctx context.Context, id string, body *ImpersonateRequest) (*ImpersonationResponse, error) {
+	var data ImpersonationResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/users/" + url.PathEscape(id) + "/impersonate", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListPaymentsOfUser calls GET /admin/users/{id}/payments: List the payments of a user
+//
+// The invoices paid by a user, last paid first, with the amount refunded of each. Admin only.
+func (c *Client) ListPaymentsOfUser(ctx context.Context, id string) ([]PaymentResponse, error) {
+	var data []PaymentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/users/" + url.PathEscape(id) + "/payments", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RestoreDeletedUser calls POST /admin/users/{id}/restore: Restore a deleted user
+//
+// Restore a deleted user and its account, it can sign in again. The restore is audited. Admin
+// only.
+func (c *Client) RestoreDeletedUser(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/admin/users/" + url.PathEscape(id) + "/restore", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListDeletedTrainingSessionsOfUser calls GET /admin/users/{id}/sessions/deleted: List deleted
+// training sessions of a user
+//
+// List up to 100 deleted sessions of a user, last deleted first. Admin only.
+func (c *Client) ListDeletedTrainingSessionsOfUser(ctx context.Context, id string) ([]TrainingDeletedSessionResponse, error) {
+	var data []TrainingDeletedSessionResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/admin/users/" + url.PathEscape(id) + "/sessions/deleted", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAssignedTrainingsParams are the query parameters of ListAssignedTrainings
+type ListAssignedTrainingsParams struct {
+	// Only the assignments in this status, one of: pending, overdue, completed
+	Status *string
+}
+
+func (p *ListAssignedTrainingsParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != nil {
+		q.Set("status", *p.Status)
+	}
+	return q
+}
+
+// ListAssignedTrainings calls GET /assignments: List assigned trainings
+//
+// The trainings assigned to the signed in athlete by their coaches, due in the last 30 days or
+// later, by due date. A session on the training since the assignment completes it.
+func (c *Client) ListAssignedTrainings(ctx context.Context, params *ListAssignedTrainingsParams) ([]AssignmentResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []AssignmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/assignments", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAthletes calls GET /athletes: List athletes
+//
+// The athletes who granted the signed in coach access to their records, by name
+func (c *Client) ListAthletes(ctx context.Context) ([]MemberResponse, error) {
+	var data []MemberResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/athletes", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// AssignmentComplianceParams are the query parameters of AssignmentCompliance
+type AssignmentComplianceParams struct {
+	// Days of the range, today included
+	Days *int
+}
+
+func (p *AssignmentComplianceParams) values() url.Values {
+	q := url.Values{}
+	if p.Days != nil {
+		q.Set("days", strconv.Itoa(*p.Days))
+	}
+	return q
+}
+
+// AssignmentCompliance calls GET /athletes/compliance: Assignment compliance
+//
+// For each athlete of the signed in coach, the assignments due in the range ending today:
+// completed, on time, overdue, pending while due today, and the completed ones swum at or under
+// their target pace. The rate is the percent completed on time out of those no longer pending.
+func (c *Client) AssignmentCompliance(ctx context.Context, params *AssignmentComplianceParams) (*ComplianceResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data ComplianceResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/athletes/compliance", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListAthleteAssignmentsParams are the query parameters of ListAthleteAssignments
+type ListAthleteAssignmentsParams struct {
+	// Only the assignments in this status, one of: pending, overdue, completed
+	Status *string
+}
+
+func (p *ListAthleteAssignmentsParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != nil {
+		q.Set("status", *p.Status)
+	}
+	return q
+}
+
+// ListAthleteAssignments calls GET /athletes/{id}/assignments: List athlete assignments
+//
+// The assignments of the signed in coach to an athlete due in the last 30 days or later, by due
+// date
+func (c *Client) ListAthleteAssignments(ctx context.Context, id string, params *ListAthleteAssignmentsParams) ([]AssignmentResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []AssignmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/athletes/" + url.PathEscape(id) + "/assignments", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// AssignTraining calls POST /athletes/{id}/assignments: Assign a training
+//
+// Assign an approved training to an athlete who granted the signed in coach access, due on a UTC
+// date with an optional target pace. The first session of the athlete on the training since the
+// assignment completes it, whatever the way it was recorded. A coach can have up to 50 assignments
+// not yet due per athlete.
+func (c *Client) AssignTraining(ctx context.Context, id string, body *AssignRequest) (*AssignmentResponse, error) {
+	var data AssignmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/athletes/" + url.PathEscape(id) + "/assignments", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RemoveAssignment calls DELETE /athletes/{id}/assignments/{assignmentId}: Remove an assignment
+//
+// Remove an assignment of the signed in coach to an athlete, completed or not
+func (c *Client) RemoveAssignment(ctx context.Context, id string, assignmentID string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/athletes/" + url.PathEscape(id) + "/assignments/" + url.PathEscape(assignmentID), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListAthleteInjuries calls GET /athletes/{id}/injuries: List athlete injuries
+//
+// The injury and recovery log of an athlete who granted the signed in coach access
+func (c *Client) ListAthleteInjuries(ctx context.Context, id string) ([]InjuryResponse, error) {
+	var data []InjuryResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/athletes/" + url.PathEscape(id) + "/injuries", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetMyPlan calls GET /billing: Get my plan
+//
+// The plan and entitlements of the signed in user with their latest subscription
+func (c *Client) GetMyPlan(ctx context.Context) (*BillingResponse, error) {
+	var data BillingResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/billing", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SubscribeToPremium calls POST /billing/checkout: Subscribe to premium
+//
+// Open a hosted checkout of the premium plan for the signed in user, the client sends them to its
+// url. The plan is granted once the payment provider confirms the subscription.
+func (c *Client) SubscribeToPremium(ctx context.Context, body *CheckoutRequest) (*CheckoutResponse, error) {
+	var data CheckoutResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/billing/checkout", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListMyPayments calls GET /billing/payments: List my payments
+//
+// The invoices paid by the signed in user, last paid first, with the amount refunded of each
+func (c *Client) ListMyPayments(ctx context.Context) ([]PaymentResponse, error) {
+	var data []PaymentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/billing/payments", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListCoaches calls GET /coaches: List coaches
+//
+// The coaches with access to the records of the signed in athlete, newest grant first
+func (c *Client) ListCoaches(ctx context.Context) ([]MemberResponse, error) {
+	var data []MemberResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/coaches", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GrantCoachAccess calls POST /coaches: Grant coach access
+//
+// Give the user signed up with the email read access to the records of the signed in athlete, ex:
+// injuries
+func (c *Client) GrantCoachAccess(ctx context.Context, body *GrantCoachRequest) (*MemberResponse, error) {
+	var data MemberResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/coaches", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RevokeCoachAccess calls DELETE /coaches/{id}: Revoke coach access
+//
+// Remove the access of a coach to the records of the signed in athlete
+func (c *Client) RevokeCoachAccess(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/coaches/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetConsents calls GET /consents: Get consents
+//
+// The current versions of the terms of service and privacy policy with the versions the account
+// accepted, and its choice about marketing emails. Reachable while documents are pending.
+func (c *Client) GetConsents(ctx context.Context) (*ConsentResponse, error) {
+	var data ConsentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/consents", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// AcceptLegalDocuments calls POST /consents: Accept legal documents
+//
+// Accept the current version of the terms of service, the privacy policy or both. Other endpoints
+// answer 428 CONSENT_REQUIRED, with the versions to accept by document, until the current versions
+// are accepted.
+func (c *Client) AcceptLegalDocuments(ctx context.Context, body *AcceptRequest) (*ConsentResponse, error) {
+	var data ConsentResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/consents", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SetMarketingConsent calls PUT /consents/marketing: Set marketing consent
+//
+// Opt in or out of marketing emails, the time of the latest opt in and opt out are kept apart from
+// the legal documents
+func (c *Client) SetMarketingConsent(ctx context.Context, body *MarketingRequest) (*MarketingResponse, error) {
+	var data MarketingResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/consents/marketing", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListPairedDevices calls GET /devices: List paired devices
+//
+// Every device of the user that is not revoked, newest first
+func (c *Client) ListPairedDevices(ctx context.Context) ([]DeviceResponse, error) {
+	var data []DeviceResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/devices", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PairDevice calls POST /devices: Pair a device
+//
+// Register a watch or companion app install and return its device token and signing secret. They
+// are shown once. The token never expires and only authorizes the session ingestion of this device
+// until the device is revoked. The secret signs its batches and is never sent.
+func (c *Client) PairDevice(ctx context.Context, body *PairDeviceRequest) (*PairDeviceResponse, error) {
+	var data PairDeviceResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/devices", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RevokeDevice calls DELETE /devices/{id}: Revoke a device
+//
+// Unpair a device of the user, its token stops working immediately
+func (c *Client) RevokeDevice(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/devices/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UploadDeviceSessions calls POST /devices/{id}/sessions: Upload device sessions
+//
+// Import a batch of up to 500 sessions recorded by the device, authenticated with the device token
+// as bearer. The body is JSON, the same document as msgpack (application/msgpack) or the
+// swimo.v1.ImportSessionsRequest protobuf message (application/x-protobuf). With replay protection
+// enabled, the batch is signed: X-Swimo-Timestamp (unix seconds), X-Swimo-Nonce (16 to 128
+// characters, unique per batch) and X-Swimo-Signature, the hex HMAC-SHA256 keyed with the signing
+// secret returned at pairing of timestamp, nonce, method, path and body joined by newlines.
+func (c *Client) UploadDeviceSessions(ctx context.Context, id string, body *TrainingImportSessionsRequest) (*TrainingImportSessionsResponse, error) {
+	var data TrainingImportSessionsResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/devices/" + url.PathEscape(id) + "/sessions", body: body, auth: authDevice}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListEquipment calls GET /equipment: List equipment
+//
+// Every piece of gear of the user with its lifetime usage, active gear first then newest first
+func (c *Client) ListEquipment(ctx context.Context) ([]EquipmentResponse, error) {
+	var data []EquipmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/equipment", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RegisterEquipment calls POST /equipment: Register equipment
+//
+// Register a piece of gear of the user, ex: fins, paddles or a wetsuit
+func (c *Client) RegisterEquipment(ctx context.Context, body *EquipmentRequest) (*EquipmentResponse, error) {
+	var data EquipmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/equipment", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// EquipmentUsageParams are the query parameters of EquipmentUsage
+type EquipmentUsageParams struct {
+	// Season, the current year by default
+	Year *int
+}
+
+func (p *EquipmentUsageParams) values() url.Values {
+	q := url.Values{}
+	if p.Year != nil {
+		q.Set("year", strconv.Itoa(*p.Year))
+	}
+	return q
+}
+
+// EquipmentUsage calls GET /equipment/stats: Equipment usage
+//
+// Sessions, distance and duration with each kind of gear and each piece of gear of the user during
+// a calendar year, ex: the wetsuit sessions of the season. A session counts once per kind.
+func (c *Client) EquipmentUsage(ctx context.Context, params *EquipmentUsageParams) (*EquipmentStatsResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data EquipmentStatsResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/equipment/stats", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetEquipment calls GET /equipment/{id}: Get equipment
+//
+// Get a piece of gear of the user with its lifetime usage
+func (c *Client) GetEquipment(ctx context.Context, id string) (*EquipmentResponse, error) {
+	var data EquipmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/equipment/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateEquipment calls PUT /equipment/{id}: Update equipment
+//
+// Replace the details of a piece of gear of the user, retired gear keeps its usage
+func (c *Client) UpdateEquipment(ctx context.Context, id string, body *EquipmentRequest) (*EquipmentResponse, error) {
+	var data EquipmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/equipment/" + url.PathEscape(id), body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteEquipment calls DELETE /equipment/{id}: Delete equipment
+//
+// Delete a piece of gear of the user and untag it from its sessions, retire it instead to keep its
+// usage
+func (c *Client) DeleteEquipment(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/equipment/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// TrackAnalyticsEvents calls POST /events: Track analytics events
+//
+// Queue up to 100 product events of the app. Events are attributed to the token, sampled, stripped
+// of personal data and delivered in the background, an accepted event may still be dropped.
+func (c *Client) TrackAnalyticsEvents(ctx context.Context, body *TrackEventsRequest) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/events", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListInjuries calls GET /injuries: List injuries
+//
+// The injury and recovery log of the user, ongoing injuries first then latest first
+func (c *Client) ListInjuries(ctx context.Context) ([]InjuryResponse, error) {
+	var data []InjuryResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/injuries", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// LogInjury calls POST /injuries: Log injury
+//
+// Add an injury to the injury and recovery log of the user, without a resolved date it is ongoing
+func (c *Client) LogInjury(ctx context.Context, body *InjuryRequest) (*InjuryResponse, error) {
+	var data InjuryResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/injuries", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetInjury calls GET /injuries/{id}: Get injury
+//
+// Get an injury of the user
+func (c *Client) GetInjury(ctx context.Context, id string) (*InjuryResponse, error) {
+	var data InjuryResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/injuries/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateInjury calls PUT /injuries/{id}: Update injury
+//
+// Replace an injury of the user, send the resolved date once recovered
+func (c *Client) UpdateInjury(ctx context.Context, id string, body *InjuryRequest) (*InjuryResponse, error) {
+	var data InjuryResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/injuries/" + url.PathEscape(id), body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteInjury calls DELETE /injuries/{id}: Delete injury
+//
+// Delete an injury of the user
+func (c *Client) DeleteInjury(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/injuries/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DownloadStoredFileParams are the query parameters of DownloadStoredFile
+type DownloadStoredFileParams struct {
+	// Signed link expiry, unix seconds
+	Expires *int
+	// Signed link signature
+	Signature *string
+}
+
+func (p *DownloadStoredFileParams) values() url.Values {
+	q := url.Values{}
+	if p.Expires != nil {
+		q.Set("expires", strconv.Itoa(*p.Expires))
+	}
+	if p.Signature != nil {
+		q.Set("signature", *p.Signature)
+	}
+	return q
+}
+
+// DownloadStoredFile calls GET /media/{key}: Download a stored file
+//
+// Public media (avatars, training thumbnails and videos) redirect to a short lived signed link of
+// the storage. With the local storage driver, signed links are served here.
+func (c *Client) DownloadStoredFile(ctx context.Context, key string, params *DownloadStoredFileParams) (io.ReadCloser, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	return c.download(ctx, request{method: http.MethodGet, path: "/media/" + url.PathEscape(key), query: query})
+}
+
+// GetActiveCheckIn calls GET /pools/check-in: Get the active check in
+//
+// The pool the signed in swimmer is checked in at, until check out or expiry
+func (c *Client) GetActiveCheckIn(ctx context.Context) (*CheckInResponse, error) {
+	var data CheckInResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/pools/check-in", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CheckInAtPool calls POST /pools/check-in: Check in at a pool
+//
+// Check the signed in swimmer in at the pool of the code read from its QR, or else at the nearest
+// pool whose geofence holds the location. Sessions finished in the next 4 hours, until check out
+// or another check in, are swum at the pool.
+func (c *Client) CheckInAtPool(ctx context.Context, body *CheckInRequest) (*CheckInResponse, error) {
+	var data CheckInResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/pools/check-in", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CheckOut calls DELETE /pools/check-in: Check out
+//
+// End the active check in of the signed in swimmer, sessions finished later have no pool
+func (c *Client) CheckOut(ctx context.Context) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/pools/check-in", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListMyPools calls GET /pools/mine: List my pools
+//
+// The pools the signed in swimmer swam at, last swum first, with the sessions, distance and
+// duration swum at each
+func (c *Client) ListMyPools(ctx context.Context) ([]MyPoolResponse, error) {
+	var data []MyPoolResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/pools/mine", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetLaneOccupancy calls GET /pools/{id}/occupancy: Get lane occupancy
+//
+// The lanes of a managed pool as last published by its staff, with the swimmers in each and
+// whether one can join. No token is needed so lobby screens can show it.
+func (c *Client) GetLaneOccupancy(ctx context.Context, id string) (*OccupancyResponse, error) {
+	var data OccupancyResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/pools/" + url.PathEscape(id) + "/occupancy"}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListRacesParams are the query parameters of ListRaces
+type ListRacesParams struct {
+	// Race status, one of: upcoming, open, closed
+	Status *string
+	// Page number
+	Page *int
+	// Number of items per page
+	Limit *int
+	// Sort field and direction, one of: starts_at.asc, starts_at.desc, name.asc, name.desc
+	Sort *string
+}
+
+func (p *ListRacesParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != nil {
+		q.Set("status", *p.Status)
+	}
+	if p.Page != nil {
+		q.Set("page", strconv.Itoa(*p.Page))
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Sort != nil {
+		q.Set("sort", *p.Sort)
+	}
+	return q
+}
+
+// ListRaces calls GET /races: List races
+//
+// Virtual races, newest first by default, with the entry of the signed in swimmer in the races
+// they registered to. Filter by status: upcoming, open (between the start and the end) or closed.
+func (c *Client) ListRaces(ctx context.Context, params *ListRacesParams) ([]RaceResponse, *Meta, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []RaceResponse
+	raw, err := c.do(ctx, request{method: http.MethodGet, path: "/races", query: query, auth: authUser}, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := decodeMeta(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, meta, nil
+}
+
+// GetRace calls GET /races/{id}: Get a race
+//
+// A virtual race with its entry and finisher counts, and the entry of the signed in swimmer when
+// registered
+func (c *Client) GetRace(ctx context.Context, id string) (*RaceResponse, error) {
+	var data RaceResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/races/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DownloadRaceCertificate calls GET /races/{id}/certificate: Download a race certificate
+//
+// The finisher certificate of the signed in swimmer as an SVG image, with their time and rank
+func (c *Client) DownloadRaceCertificate(ctx context.Context, id string) (io.ReadCloser, error) {
+	return c.download(ctx, request{method: http.MethodGet, path: "/races/" + url.PathEscape(id) + "/certificate", auth: authUser})
+}
+
+// ListRaceFinishersParams are the query parameters of ListRaceFinishers
+type ListRaceFinishersParams struct {
+	// Page number
+	Page *int
+	// Number of items per page
+	Limit *int
+}
+
+func (p *ListRaceFinishersParams) values() url.Values {
+	q := url.Values{}
+	if p.Page != nil {
+		q.Set("page", strconv.Itoa(*p.Page))
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	return q
+}
+
+// ListRaceFinishers calls GET /races/{id}/finishers: List race finishers
+//
+// The finishers of a race ranked fastest first, earlier results first on a tie
+func (c *Client) ListRaceFinishers(ctx context.Context, id string, params *ListRaceFinishersParams) ([]FinisherResponse, *Meta, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []FinisherResponse
+	raw, err := c.do(ctx, request{method: http.MethodGet, path: "/races/" + url.PathEscape(id) + "/finishers", query: query, auth: authUser}, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := decodeMeta(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, meta, nil
+}
+
+// RegisterToRace calls POST /races/{id}/register: Register to a race
+//
+// Enter the signed in swimmer in a race, possible until the race ends. Registering again keeps the
+// entry.
+func (c *Client) RegisterToRace(ctx context.Context, id string) (*RaceResponse, error) {
+	var data RaceResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/races/" + url.PathEscape(id) + "/register", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SubmitRaceResult calls POST /races/{id}/result: Submit a race result
+//
+// Submit a recorded session as the result of the signed in swimmer, until 48 hours after the race
+// ends. The session must start during the race, cover at least the race distance, be plausible
+// and, pro-rated to the race distance, finish within the cutoff. A slower session than the current
+// result is accepted but doesn't replace it.
+func (c *Client) SubmitRaceResult(ctx context.Context, id string, body *ResultRequest) (*RaceResponse, error) {
+	var data RaceResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/races/" + url.PathEscape(id) + "/result", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RefreshJWTToken calls POST /refresh-token: Refresh JWT token
+//
+// Generate new access token using refresh token
+func (c *Client) RefreshJWTToken(ctx context.Context, body *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	var data RefreshTokenResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/refresh-token", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	c.SetTokens(Tokens{Token: data.Token, RefreshToken: data.RefreshToken})
+	return &data, nil
+}
+
+// SignInUser calls POST /sign-in: Sign in user
+//
+// Authenticate user with email and password, returns JWT tokens. A sign in from a device or
+// country not seen before on the account emails an alert with a "this wasn't me" link.
+func (c *Client) SignInUser(ctx context.Context, body *SignInRequest) (*SignInResponse, error) {
+	var data SignInResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/sign-in", body: body}, &data); err != nil {
+		return nil, err
+	}
+	c.SetTokens(Tokens{Token: data.Token, RefreshToken: data.RefreshToken})
+	return &data, nil
+}
+
+// DenySignIn calls POST /sign-in-alerts/deny: Deny sign in
+//
+// Report the sign in of an alert email as not made by the owner of the account, with the token of
+// its link. The account is locked and every session revoked, access tokens already issued stay
+// valid until they expire. A token works once.
+func (c *Client) DenySignIn(ctx context.Context, body *DenySignInRequest) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/sign-in-alerts/deny", body: body}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SignInGuest calls POST /sign-in-guest: Sign in guest
+//
+// Authenticate guest user without credentials, returns limited access tokens
+func (c *Client) SignInGuest(ctx context.Context, body *SignInGuestRequest) (*SignInGuestResponse, error) {
+	var data SignInGuestResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/sign-in-guest", body: body}, &data); err != nil {
+		return nil, err
+	}
+	c.SetTokens(Tokens{Token: data.Token, RefreshToken: data.RefreshToken})
+	return &data, nil
+}
+
+// SignOutUser calls POST /sign-out: Sign out user
+//
+// Revoke user session and invalidate JWT tokens
+func (c *Client) SignOutUser(ctx context.Context) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/sign-out", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	c.SetTokens(Tokens{})
+	return &data, nil
+}
+
+// SignUpNewUser calls POST /sign-up: Sign up new user
+//
+// Register a new user account with email, password, and profile information
+func (c *Client) SignUpNewUser(ctx context.Context, body *SignUpRequest) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/sign-up", body: body}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// HeartRateZonesParams are the query parameters of HeartRateZones
+type HeartRateZonesParams struct {
+	// Rolling period ending now, one of: week, month, year
+	Period *string
+}
+
+func (p *HeartRateZonesParams) values() url.Values {
+	q := url.Values{}
+	if p.Period != nil {
+		q.Set("period", *p.Period)
+	}
+	return q
+}
+
+// HeartRateZones calls GET /stats/hr-zones: Heart rate zones
+//
+// Time spent in each of the five heart rate zones over a rolling period, aggregated and per
+// session. Only laps recorded with an average heart rate count. Zones are shares of the max heart
+// rate set in the preferences, or 220 minus the age when unset. Injuries overlapping the period
+// are listed so charts can show them.
+func (c *Client) HeartRateZones(ctx context.Context, params *HeartRateZonesParams) (*HeartRateZonesResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data HeartRateZonesResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/stats/hr-zones", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// OpenWaterSeasonParams are the query parameters of OpenWaterSeason
+type OpenWaterSeasonParams struct {
+	// Season, the current year by default
+	Year *int
+}
+
+func (p *OpenWaterSeasonParams) values() url.Values {
+	q := url.Values{}
+	if p.Year != nil {
+		q.Set("year", strconv.Itoa(*p.Year))
+	}
+	return q
+}
+
+// OpenWaterSeason calls GET /stats/open-water: Open water season
+//
+// Sessions, distance, duration, wetsuit sessions and water temperatures of the open water sessions
+// of a calendar year, in total and for each month in UTC. Injuries overlapping the year are listed
+// so charts can show them.
+func (c *Client) OpenWaterSeason(ctx context.Context, params *OpenWaterSeasonParams) (*OpenWaterStatsResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data OpenWaterStatsResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/stats/open-water", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// TrainingLoadParams are the query parameters of TrainingLoad
+type TrainingLoadParams struct {
+	// Days of the range, today included
+	Days *int
+}
+
+func (p *TrainingLoadParams) values() url.Values {
+	q := url.Values{}
+	if p.Days != nil {
+		q.Set("days", strconv.Itoa(*p.Days))
+	}
+	return q
+}
+
+// TrainingLoad calls GET /stats/training-load: Training load
+//
+// Acute (7 day) and chronic (42 day) training load for each UTC day of the range ending today. The
+// load of a session is its minutes times its perceived exertion (RPE), sessions without one count
+// as moderate. Both loads are exponentially weighted moving averages, an acute:chronic ratio above
+// the risk threshold flags a sharp ramp up. Injuries overlapping the range are listed so charts
+// can show them.
+func (c *Client) TrainingLoad(ctx context.Context, params *TrainingLoadParams) (*TrainingLoadResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data TrainingLoadResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/stats/training-load", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SyncOfflineTrainingSessions calls POST /sync/sessions: Sync offline training sessions
+//
+// Sync a batch of sessions recorded offline, identified by a UUID generated on the device. A known
+// clientId updates its session: trainingId and startedAt keep the server value, distance, duration
+// and laps keep the value edited last by updatedAt. Results are keyed by clientId (by index when
+// it is missing) with the status created, updated, unchanged or rejected, and the fields that kept
+// the server value in conflicts.
+func (c *Client) SyncOfflineTrainingSessions(ctx context.Context, body *TrainingSyncSessionsRequest) (*TrainingSyncSessionsResponse, error) {
+	var data TrainingSyncSessionsResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/sync/sessions", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetTrainingsWithPaginationParams are the query parameters of GetTrainingsWithPagination
+type GetTrainingsWithPaginationParams struct {
+	// Page number
+	Page *int
+	// Number of items per page
+	Limit *int
+	// Sort field and direction, one of: name.asc, name.desc, level.asc, level.desc, created_at.asc,
+	// created_at.desc
+	Sort *string
+	// Search term for training name and description
+	Search *string
+	// Category code to filter by
+	Category *string
+	// Level to filter by
+	Level *string
+	// Comma separated filters to count the trainings of by value, returned in meta.facets
+	Facets *string
+}
+
+func (p *GetTrainingsWithPaginationParams) values() url.Values {
+	q := url.Values{}
+	if p.Page != nil {
+		q.Set("page", strconv.Itoa(*p.Page))
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Sort != nil {
+		q.Set("sort", *p.Sort)
+	}
+	if p.Search != nil {
+		q.Set("search", *p.Search)
+	}
+	if p.Category != nil {
+		q.Set("category", *p.Category)
+	}
+	if p.Level != nil {
+		q.Set("level", *p.Level)
+	}
+	if p.Facets != nil {
+		q.Set("facets", *p.Facets)
+	}
+	return q
+}
+
+// GetTrainingsWithPagination calls GET /trainings: Get trainings with pagination
+//
+// Retrieve a paginated list of trainings with optional search, filters and sorting. With facets,
+// the number of trainings per category and level is returned along the page, each count applying
+// every filter but its own. A search without results is an empty page, not an error.
+func (c *Client) GetTrainingsWithPagination(ctx context.Context, params *GetTrainingsWithPaginationParams) ([]TrainingItemResponse, *Meta, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []TrainingItemResponse
+	raw, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings", query: query, auth: authUser}, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := decodeMeta(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, meta, nil
+}
+
+// CreateNewTraining calls POST /trainings: Create a new training
+//
+// Create a new training with the provided details. Trainings created by admins are published right
+// away, those of other accounts are submitted for review and only listed once approved.
+func (c *Client) CreateNewTraining(ctx context.Context, body *TrainingRequest) (*TrainingResponse, error) {
+	var data TrainingResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/trainings", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListPossibleDuplicateSessions calls GET /trainings/sessions/duplicates: List possible duplicate
+// sessions
+//
+// List up to 100 pending pairs of sessions of the user overlapping for at least half of the
+// shorter one, ex: a manual entry and the watch import of the same swim. Newest first.
+func (c *Client) ListPossibleDuplicateSessions(ctx context.Context) ([]TrainingDuplicateResponse, error) {
+	var data []TrainingDuplicateResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings/sessions/duplicates", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DismissPossibleDuplicate calls POST /trainings/sessions/duplicates/{id}/dismiss: Dismiss a
+// possible duplicate
+//
+// Mark the pair as distinct sessions, both are kept and the pair is not flagged again
+func (c *Client) DismissPossibleDuplicate(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/trainings/sessions/duplicates/" + url.PathEscape(id) + "/dismiss", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// MergeDuplicateSessions calls POST /trainings/sessions/duplicates/{id}/merge: Merge duplicate
+// sessions
+//
+// Keep one session of the pair, the one stored first unless keepId is sent, and delete the other.
+// The kept session takes the laps, water conditions, client id and training of the deleted one
+// where it has none.
+func (c *Client) MergeDuplicateSessions(ctx context.Context, id string, body *TrainingMergeDuplicateRequest) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/trainings/sessions/duplicates/" + url.PathEscape(id) + "/merge", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ExportTrainingSessionsParams are the query parameters of ExportTrainingSessions
+type ExportTrainingSessionsParams struct {
+	// Stream format, one of: json, ndjson
+	Format *string
+}
+
+func (p *ExportTrainingSessionsParams) values() url.Values {
+	q := url.Values{}
+	if p.Format != nil {
+		q.Set("format", *p.Format)
+	}
+	return q
+}
+
+// ExportTrainingSessions calls GET /trainings/sessions/export: Export training sessions
+//
+// Stream every training session of the user, newest first. Send Accept: application/x-ndjson (or
+// format=ndjson) for one session per line, otherwise the sessions are streamed as the data array.
+func (c *Client) ExportTrainingSessions(ctx context.Context, params *ExportTrainingSessionsParams) ([]TrainingSessionExportResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data []TrainingSessionExportResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings/sessions/export", query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ExportTrainingSessionsToFile calls POST /trainings/sessions/export/file: Export training
+// sessions to a file
+//
+// Write every training session of the user to an NDJSON file and return a link downloading it
+// until expiresAt
+func (c *Client) ExportTrainingSessionsToFile(ctx context.Context) (*TrainingExportFileResponse, error) {
+	var data TrainingExportFileResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/trainings/sessions/export/file", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ImportTrainingSessions calls POST /trainings/sessions/import: Import training sessions
+//
+// Import a batch of sessions recorded elsewhere (ex: a watch), with optional laps, in a single
+// transaction
+func (c *Client) ImportTrainingSessions(ctx context.Context, body *TrainingImportSessionsRequest) (*TrainingImportSessionsResponse, error) {
+	var data TrainingImportSessionsResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/trainings/sessions/import", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetUsersLastTrainingSession calls GET /trainings/sessions/last: Get user's last training session
+//
+// Retrieve the most recent training session
+func (c *Client) GetUsersLastTrainingSession(ctx context.Context) (*TrainingSessionResponse, error) {
+	var data TrainingSessionResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings/sessions/last", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetTrainingSession calls GET /trainings/sessions/{id}: Get a training session
+//
+// Get a session of the user with its laps and, for open water sessions, its water conditions
+func (c *Client) GetTrainingSession(ctx context.Context, id string) (*TrainingSessionDetailResponse, error) {
+	var data TrainingSessionDetailResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings/sessions/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteTrainingSession calls DELETE /trainings/sessions/{id}: Delete a training session
+//
+// Remove a session from the history, stats and exports of the user. Admins can restore it.
+func (c *Client) DeleteTrainingSession(ctx context.Context, id string) (*Message, error) {
+	var data Message
+	if _, err := c.do(ctx, request{method: http.MethodDelete, path: "/trainings/sessions/" + url.PathEscape(id), auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateWaterConditions calls PUT /trainings/sessions/{id}/conditions: Update water conditions
+//
+// Replace the water conditions of an open water session. With latitude and longitude, the water
+// temperature, wave height and current speed left empty are filled from the weather at the start
+// of the session when a provider is configured.
+func (c *Client) UpdateWaterConditions(ctx context.Context, id string, body *TrainingConditionsRequest) (*TrainingSessionDetailResponse, error) {
+	var data TrainingSessionDetailResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/trainings/sessions/" + url.PathEscape(id) + "/conditions", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// TagSessionEquipment calls PUT /trainings/sessions/{id}/equipment: Tag session equipment
+//
+// Replace the gear used in a session of the user with up to 10 pieces of their gear, an empty list
+// clears it
+func (c *Client) TagSessionEquipment(ctx context.Context, id string, body *SessionEquipmentRequest) ([]EquipmentResponse, error) {
+	var data []EquipmentResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/trainings/sessions/" + url.PathEscape(id) + "/equipment", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListMyTrainingSubmissions calls GET /trainings/submissions: List my training submissions
+//
+// List up to 100 trainings submitted by the user with their review status and the reason of a
+// rejection. Newest first.
+func (c *Client) ListMyTrainingSubmissions(ctx context.Context) ([]TrainingReviewResponse, error) {
+	var data []TrainingReviewResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings/submissions", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetTrainingByIDParams are the query parameters of GetTrainingByID
+type GetTrainingByIDParams struct {
+	// Comma separated aggregates to embed
+	Include *string
+}
+
+func (p *GetTrainingByIDParams) values() url.Values {
+	q := url.Values{}
+	if p.Include != nil {
+		q.Set("include", *p.Include)
+	}
+	return q
+}
+
+// GetTrainingByID calls GET /trainings/{id}: Get training by ID
+//
+// Retrieve detailed training information by training ID. Trainings under review or rejected are
+// only visible to their author and admins. With include, aggregates are embedded in the same
+// response: completions is the number of sessions of the user on the training.
+func (c *Client) GetTrainingByID(ctx context.Context, id string, params *GetTrainingByIDParams) (*TrainingResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	var data TrainingResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/trainings/" + url.PathEscape(id), query: query, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// FinishTrainingSession calls POST /trainings/{id}/finish: Finish a training session
+//
+// Complete an ongoing training session with distance and duration metrics. Open water sessions may
+// carry their water conditions. Sessions faster or slower than plausible for the training level,
+// longer than 6 hours or not a whole number of pool lengths are rejected unless an admin sets
+// override.
+func (c *Client) FinishTrainingSession(ctx context.Context, id string, body *TrainingFinishSessionRequest) (*TrainingSessionResponse, error) {
+	var data TrainingSessionResponse
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/trainings/" + url.PathEscape(id) + "/finish", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UploadTrainingMediaForm is the multipart form of UploadTrainingMedia
+type UploadTrainingMediaForm struct {
+	// Thumbnail image
+	Thumbnail *File
+	// Version of the training read, sent before the files
+	Version *int
+	// Video
+	Video *File
+}
+
+func (f *UploadTrainingMediaForm) fields() map[string]any {
+	fields := map[string]any{}
+	if f.Thumbnail != nil {
+		fields["thumbnail"] = f.Thumbnail
+	}
+	if f.Version != nil {
+		fields["version"] = *f.Version
+	}
+	if f.Video != nil {
+		fields["video"] = f.Video
+	}
+	return fields
+}
+
+// UploadTrainingMedia calls PUT /trainings/{id}/media: Upload training media
+//
+// Replace the thumbnail (JPEG, PNG or WebP) and/or the video (MP4, WebM or QuickTime) of a
+// training with the files of a multipart form. Files are streamed to storage, large videos are
+// uploaded in parts. With a version, sent before the files, a training edited since is refused
+// with the current version. Only the author of the training and admins can replace its media.
+func (c *Client) UploadTrainingMedia(ctx context.Context, id string, form *UploadTrainingMediaForm) (*TrainingResponse, error) {
+	var fields map[string]any
+	if form != nil {
+		fields = form.fields()
+	}
+	var data TrainingResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/trainings/" + url.PathEscape(id) + "/media", form: fields, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UploadAvatarForm is the multipart form of UploadAvatar
+type UploadAvatarForm struct {
+	// Avatar image
+	Avatar *File
+}
+
+func (f *UploadAvatarForm) fields() map[string]any {
+	fields := map[string]any{}
+	if f.Avatar != nil {
+		fields["avatar"] = f.Avatar
+	}
+	return fields
+}
+
+// UploadAvatar calls PUT /users/me/avatar: Upload avatar
+//
+// Replace the avatar of the user with a JPEG, PNG or WebP image up to 5MB, sent as the avatar
+// field of a multipart form
+func (c *Client) UploadAvatar(ctx context.Context, form *UploadAvatarForm) (*AvatarResponse, error) {
+	var fields map[string]any
+	if form != nil {
+		fields = form.fields()
+	}
+	var data AvatarResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/users/me/avatar", form: fields, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetNotificationPreferences calls GET /users/me/preferences: Get notification preferences
+//
+// Get the time zone and the notifications the user receives
+func (c *Client) GetNotificationPreferences(ctx context.Context) (*PreferencesResponse, error) {
+	var data PreferencesResponse
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/users/me/preferences", auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateNotificationPreferences calls PUT /users/me/preferences: Update notification preferences
+//
+// Set the IANA time zone of the user, used to send scheduled mail in the morning of the user, and
+// opt in or out of the weekly digest. With a version, preferences edited since, ex: from another
+// device, are refused with the current version.
+func (c *Client) UpdateNotificationPreferences(ctx context.Context, body *PreferencesRequest) (*PreferencesResponse, error) {
+	var data PreferencesResponse
+	if _, err := c.do(ctx, request{method: http.MethodPut, path: "/users/me/preferences", body: body, auth: authUser}, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func decodeMeta(raw json.RawMessage) (*Meta, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var meta Meta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
@@ -0,0 +1,170 @@
+// Package crypto encrypts sensitive column values at the repository boundary with AES-256-GCM.
+// Ciphertexts carry the id of their key, ex: enc:k1:<base64 nonce and sealed value>, so keys can
+// be rotated: new values use the current key and older keys stay available to read. Plaintext
+// starting with a prefix is stored escaped, raw:enc:..., so it is never read as a ciphertext.
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix marks encrypted values, values without it are read as plaintext written before
+// encryption. rawPrefix escapes plaintext starting with either prefix.
+const (
+	prefix    = "enc:"
+	rawPrefix = "raw:"
+)
+
+// KeySize is the length of the AES-256 keys
+const KeySize = 32
+
+var (
+	ErrUnknownKey = errors.New("value encrypted with an unknown key")
+	ErrMalformed  = errors.New("malformed encrypted value")
+)
+
+// Cipher encrypts with the current key and decrypts with any of its keys. A nil Cipher stores
+// values as plaintext, for deployments without keys.
+type Cipher struct {
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// New creates a cipher encrypting with the key current of keys, keys by id
+func New(current string, keys map[string][]byte) (*Cipher, error) {
+	c := &Cipher{current: current, aeads: make(map[string]cipher.AEAD, len(keys))}
+
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key id %q", id)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, got %d", id, KeySize, len(key))
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		if c.aeads[id], err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := c.aeads[current]; !ok {
+		return nil, fmt.Errorf("current key %q is not one of the keys", current)
+	}
+	return c, nil
+}
+
+// ParseKeys reads keys written as comma separated id=base64 pairs, ex: k1=q5nM...,k0=Xa9...
+func ParseKeys(s string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("key %q must be written id=base64", entry)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+
+	return keys, nil
+}
+
+// Encrypt seals plaintext with the current key. aad binds the value to its row, ex: the id of
+// the owner, so a ciphertext copied to another row fails to decrypt. A nil Cipher returns the
+// plaintext, escaped when it starts with a prefix.
+func (c *Cipher) Encrypt(plaintext, aad string) (string, error) {
+	if c == nil {
+		if strings.HasPrefix(plaintext, prefix) || strings.HasPrefix(plaintext, rawPrefix) {
+			return rawPrefix + plaintext, nil
+		}
+		return plaintext, nil
+	}
+
+	aead := c.aeads[c.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(aad))
+	return prefix + c.current + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value written by Encrypt with the same aad, plaintext values are returned as
+// written, unescaped
+func (c *Cipher) Decrypt(value, aad string) (string, error) {
+	if plaintext, ok := strings.CutPrefix(value, rawPrefix); ok {
+		return plaintext, nil
+	}
+
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrUnknownKey
+	}
+
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", ErrMalformed
+	}
+
+	aead, ok := c.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(aad))
+	if err != nil {
+		return "", ErrMalformed
+	}
+	return string(plaintext), nil
+}
+
+// EncryptPtr is Encrypt for nullable columns, nil stays nil
+func (c *Cipher) EncryptPtr(plaintext *string, aad string) (*string, error) {
+	if plaintext == nil {
+		return nil, nil
+	}
+
+	value, err := c.Encrypt(*plaintext, aad)
+	return &value, err
+}
+
+// DecryptPtr is Decrypt for nullable columns, nil stays nil
+func (c *Cipher) DecryptPtr(value *string, aad string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	plaintext, err := c.Decrypt(*value, aad)
+	if err != nil {
+		return nil, err
+	}
+	return &plaintext, nil
+}
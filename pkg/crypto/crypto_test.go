@@ -0,0 +1,121 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newCipher(t *testing.T, current string, ids ...string) *Cipher {
+	t.Helper()
+
+	keys := make(map[string][]byte, len(ids))
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, KeySize)
+	}
+
+	c, err := New(current, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRoundTrip(t *testing.T) {
+	plaintexts := []string{
+		"",
+		"Strained left shoulder, rest a week",
+		"enc:k1:looks like a ciphertext",
+		"enc:",
+		"raw:already escaped",
+		"raw:enc:k1:both",
+	}
+
+	ciphers := map[string]*Cipher{
+		"nil":   nil,
+		"keyed": newCipher(t, "k1", "k1"),
+	}
+
+	for name, c := range ciphers {
+		for _, plaintext := range plaintexts {
+			value, err := c.Encrypt(plaintext, "user-1")
+			if err != nil {
+				t.Fatalf("%s: Encrypt(%q) error = %v", name, plaintext, err)
+			}
+			if c != nil && !strings.HasPrefix(value, "enc:k1:") {
+				t.Errorf("%s: Encrypt(%q) = %q, want a ciphertext", name, plaintext, value)
+			}
+
+			got, err := c.Decrypt(value, "user-1")
+			if err != nil {
+				t.Fatalf("%s: Decrypt(Encrypt(%q)) error = %v", name, plaintext, err)
+			}
+			if got != plaintext {
+				t.Errorf("%s: Decrypt(Encrypt(%q)) = %q", name, plaintext, got)
+			}
+		}
+	}
+}
+
+func TestPlaintextWrittenWithoutKeysIsReadWithKeys(t *testing.T) {
+	keyed := newCipher(t, "k1", "k1")
+
+	for _, plaintext := range []string{"Sore knee", "enc:k1:not a ciphertext", "raw:note"} {
+		value, err := (*Cipher)(nil).Encrypt(plaintext, "user-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := keyed.Decrypt(value, "user-1")
+		if err != nil || got != plaintext {
+			t.Errorf("Decrypt(%q) = %q, %v, want %q", value, got, err, plaintext)
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	old := newCipher(t, "k1", "k1")
+	value, err := old.Encrypt("Sore knee", "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// k2 is current, k1 is kept to read older values
+	rotated := newCipher(t, "k2", "k1", "k2")
+	got, err := rotated.Decrypt(value, "user-1")
+	if err != nil || got != "Sore knee" {
+		t.Fatalf("Decrypt() with the old key = %q, %v", got, err)
+	}
+
+	value, err = rotated.Encrypt("Sore knee", "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(value, "enc:k2:") {
+		t.Errorf("Encrypt() = %q, want the k2 key", value)
+	}
+	if got, err := rotated.Decrypt(value, "user-1"); err != nil || got != "Sore knee" {
+		t.Errorf("Decrypt() with the new key = %q, %v", got, err)
+	}
+
+	// Dropping k1 too early is reported, not read as plaintext
+	if _, err := newCipher(t, "k2", "k2").Decrypt(value[:4]+"k1"+value[6:], "user-1"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() with a dropped key error = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestDecryptRejects(t *testing.T) {
+	c := newCipher(t, "k1", "k1")
+	value, err := c.Encrypt("Sore knee", "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Decrypt(value, "user-2"); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Decrypt() of another row error = %v, want ErrMalformed", err)
+	}
+	if _, err := (*Cipher)(nil).Decrypt(value, "user-1"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() without keys error = %v, want ErrUnknownKey", err)
+	}
+}
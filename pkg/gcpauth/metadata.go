@@ -0,0 +1,105 @@
+// Package gcpauth authenticates Google Cloud REST calls with the workload service account
+// of the metadata server (GCE, GKE, Cloud Run), without pulling in the Google SDKs.
+package gcpauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	metadataEmailURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/email"
+)
+
+// Metadata caches the service account token and email read from the metadata server
+type Metadata struct {
+	client *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	email   string
+}
+
+func NewMetadata(client *http.Client) *Metadata {
+	return &Metadata{client: client}
+}
+
+// AccessToken returns a cached OAuth token, refreshed a minute before expiry
+func (m *Metadata) AccessToken(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != "" && time.Now().Before(m.expires) {
+		return m.token, nil
+	}
+
+	res, err := m.get(ctx, metadataTokenURL)
+	if err != nil {
+		return "", fmt.Errorf("gcp metadata token: %w", err)
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("gcp metadata token: invalid response: %w", err)
+	}
+
+	m.token = body.AccessToken
+	m.expires = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - time.Minute)
+	return m.token, nil
+}
+
+// Email returns the service account email, needed to sign blobs on its behalf
+func (m *Metadata) Email(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.email != "" {
+		return m.email, nil
+	}
+
+	res, err := m.get(ctx, metadataEmailURL)
+	if err != nil {
+		return "", fmt.Errorf("gcp metadata email: %w", err)
+	}
+	defer res.Body.Close()
+
+	email, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("gcp metadata email: %w", err)
+	}
+
+	m.email = strings.TrimSpace(string(email))
+	return m.email, nil
+}
+
+func (m *Metadata) get(ctx context.Context, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/i18n"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies the service in ErrorInfo details
+const errorDomain = "swimo.id"
+
+// statusCodes maps the HTTP status of catalog entries to gRPC codes
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+}
+
+// Error converts an error returned by a service to a gRPC status, the same way response.Err
+// renders it over HTTP: validation errors become InvalidArgument with field violations,
+// catalog errors keep their stable code in an ErrorInfo detail, anything else is internal.
+func Error(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	var verr *validator.ValidationError
+	if errors.As(err, &verr) {
+		st := status.New(codes.InvalidArgument, i18n.Translate(lang, "Validation errors"))
+
+		details := &errdetails.BadRequest{}
+		for field, msg := range verr.Errors {
+			details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: i18n.Translate(lang, msg),
+			})
+		}
+		return withDetails(st, details)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	entry, ok := response.Lookup(err)
+	if !ok {
+		return status.Error(codes.Internal, i18n.Translate(lang, "Internal server error"))
+	}
+
+	code, ok := statusCodes[entry.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	st := status.New(code, i18n.Translate(lang, entry.Message))
+	return withDetails(st, &errdetails.ErrorInfo{Reason: entry.Code, Domain: errorDomain})
+}
+
+// withDetails attaches details to st, falling back to the bare status
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails.Err()
+	}
+	return st.Err()
+}
@@ -0,0 +1,278 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/i18n"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/middleware"
+	"github.com/rizkyharahap/swimo/pkg/ratelimit"
+	"github.com/rizkyharahap/swimo/pkg/security"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type clientIPKey struct{}
+
+// Options configures the interceptors shared by every service
+type Options struct {
+	// Keys returns the accepted JWT secrets by kid, read per call so refreshed secrets and
+	// rotated keys apply without restart
+	Keys func() map[string]string
+	// PublicMethods are full method names callable without an access token,
+	// ex: /swimo.v1.AuthService/SignIn
+	PublicMethods []string
+
+	// RateLimitStore limits the PublicMethods per client IP, like the sign in routes over
+	// HTTP. A nil store disables limiting.
+	RateLimitStore ratelimit.Store
+	// RateLimits is read per call so limits can be hot reloaded
+	RateLimits func() (max int, window time.Duration)
+	// TrustedProxies is the number of proxies in front of the gateway appending to
+	// X-Forwarded-For, see ClientIP
+	TrustedProxies int
+}
+
+func unaryRecover(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("Panic recovered", "error", r, "method", info.FullMethod, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "Internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func streamRecover(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("Panic recovered", "error", r, "method", info.FullMethod, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "Internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// unaryLogging logs every call and converts returned errors to gRPC statuses
+func unaryLogging(log *logger.Logger, proxies int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, log := callContext(ctx, log, proxies)
+
+		res, err := handler(ctx, req)
+		err = Error(ctx, err)
+
+		logCall(log, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+func streamLogging(log *logger.Logger, proxies int) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, log := callContext(ss.Context(), log, proxies)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		err = Error(ctx, err)
+
+		logCall(log, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// callContext attaches the request id, locale, client IP and logger to the call context
+func callContext(ctx context.Context, log *logger.Logger, proxies int) (context.Context, *logger.Logger) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := first(md, "x-request-id")
+	if id == "" || len(id) > 128 {
+		b := make([]byte, 16)
+		rand.Read(b)
+		id = hex.EncodeToString(b)
+	}
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+
+	log = log.With("request_id", id)
+	ctx = log.WithContext(ctx)
+	ctx = i18n.WithLocale(ctx, i18n.Negotiate(first(md, "accept-language")))
+	ctx = context.WithValue(ctx, clientIPKey{}, clientIP(ctx, md, proxies))
+
+	return ctx, log
+}
+
+// ClientIP returns the client IP of the call, resolved once by the logging interceptor
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// clientIP returns the address of the peer. Calls from the loopback come through the gateway,
+// which appends the address of its HTTP client to X-Forwarded-For: that entry, or the one
+// proxies hops further left when proxies sit in front of the gateway, is the client. Anything
+// left of it was written by the client and any other peer may send the metadata it wants, so
+// neither is trusted.
+func clientIP(ctx context.Context, md metadata.MD, proxies int) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			hops := strings.Split(strings.Join(values, ","), ",")
+			return strings.TrimSpace(hops[max(len(hops)-1-proxies, 0)])
+		}
+	}
+	return host
+}
+
+// unaryRateLimit limits the methods per client IP within a fixed window. It fails open when
+// the store is unavailable, like the HTTP rate limit.
+func unaryRateLimit(store ratelimit.Store, log *logger.Logger, limits func() (int, time.Duration), methods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := allow(ctx, store, log, limits, methods, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamRateLimit(store ratelimit.Store, log *logger.Logger, limits func() (int, time.Duration), methods []string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(ss.Context(), store, log, limits, methods, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// allow counts the call against the bucket of its client IP, returning ResourceExhausted past
+// the limit
+func allow(ctx context.Context, store ratelimit.Store, log *logger.Logger, limits func() (int, time.Duration), methods []string, method string) error {
+	if store == nil || limits == nil || !slices.Contains(methods, method) {
+		return nil
+	}
+
+	limit, window := limits()
+	ip := ClientIP(ctx)
+	if limit <= 0 || ip == "" {
+		return nil
+	}
+
+	// Shares the buckets of the HTTP sign in routes, a client can't double its budget by
+	// switching transport
+	res, err := store.Allow(ctx, "auth:ip:"+ip, limit, window)
+	if err != nil {
+		log.Warn("Rate limit store failed", "group", "auth", "error", err)
+		return nil
+	}
+	if !res.Allowed {
+		return status.Error(codes.ResourceExhausted, i18n.Translate(i18n.FromContext(ctx), "Too many requests"))
+	}
+	return nil
+}
+
+func logCall(log *logger.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	args := []any{"method", method, "code", code.String(), "duration", time.Since(start)}
+
+	switch code {
+	case codes.OK:
+		log.Info("gRPC call completed", args...)
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		log.Error("gRPC call failed", args...)
+	default:
+		log.Warn("gRPC call failed", args...)
+	}
+}
+
+// unaryAuth verifies the bearer token of the authorization metadata and stores its claims
+func unaryAuth(keys func() map[string]string, public []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if slices.Contains(public, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, keys())
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(keys func() map[string]string, public []string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(public, info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), keys())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, keys map[string]string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	header := first(md, "authorization")
+	if header == "" {
+		return nil, status.Error(codes.Unauthenticated, "Missing Authorization header")
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "Invalid Authorization format")
+	}
+
+	claims, err := security.VerifyJWT(token, keys)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired token")
+	}
+
+	// Impersonated requests are audited by the HTTP middleware only
+	if claims.Imp != nil {
+		return nil, status.Error(codes.PermissionDenied, "Impersonation tokens are only accepted over HTTP")
+	}
+
+	return middleware.WithAuth(ctx, claims), nil
+}
+
+// serverStream overrides the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// first returns the first metadata value of key
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
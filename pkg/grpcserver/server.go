@@ -0,0 +1,175 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+)
+
+// Service registers a gRPC service and its gateway handlers
+type Service interface {
+	RegisterGRPC(s *grpc.Server)
+	RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+}
+
+// Server serves gRPC services on their own port, optionally with a REST gateway in front
+type Server struct {
+	cfg     config.GRPCConfig
+	log     *logger.Logger
+	server  *grpc.Server
+	gateway *http.Server
+	conn    *grpc.ClientConn
+
+	services []Service
+
+	shutdownTimeout time.Duration
+}
+
+// NewServer creates a gRPC server with the given interceptor options and services
+func NewServer(cfg config.GRPCConfig, log *logger.Logger, opts Options, services ...Service) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			unaryRecover(log),
+			unaryLogging(log, opts.TrustedProxies),
+			unaryRateLimit(opts.RateLimitStore, log, opts.RateLimits, opts.PublicMethods),
+			unaryAuth(opts.Keys, opts.PublicMethods),
+		),
+		grpc.ChainStreamInterceptor(
+			streamRecover(log),
+			streamLogging(log, opts.TrustedProxies),
+			streamRateLimit(opts.RateLimitStore, log, opts.RateLimits, opts.PublicMethods),
+			streamAuth(opts.Keys, opts.PublicMethods),
+		),
+	)
+
+	for _, svc := range services {
+		svc.RegisterGRPC(server)
+	}
+	if cfg.Reflection {
+		reflection.Register(server)
+	}
+
+	return &Server{
+		cfg:             cfg,
+		log:             log,
+		server:          server,
+		services:        services,
+		shutdownTimeout: 30 * time.Second, // Default shutdown timeout
+	}
+}
+
+// Start listens on the gRPC port and, when configured, starts the gateway.
+// It returns once both listeners are bound, serving errors are sent to errs.
+func (s *Server) Start(ctx context.Context, errs chan<- error) error {
+	addr := s.address(s.cfg.Port)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	go func() {
+		s.log.Info("Starting gRPC server", "addr", addr, "reflection", s.cfg.Reflection)
+
+		if err := s.server.Serve(ln); err != nil && err != grpc.ErrServerStopped {
+			errs <- fmt.Errorf("grpc server error: %w", err)
+		}
+	}()
+
+	if s.cfg.GatewayPort == 0 {
+		return nil
+	}
+
+	// The gateway is a regular client of the local gRPC server
+	s.conn, err = grpc.NewClient(fmt.Sprintf("localhost:%d", s.cfg.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial the grpc server: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaders),
+	)
+	for _, svc := range s.services {
+		if err := svc.RegisterGateway(ctx, mux, s.conn); err != nil {
+			return fmt.Errorf("failed to register gateway handlers: %w", err)
+		}
+	}
+
+	s.gateway = &http.Server{
+		Addr:              s.address(s.cfg.GatewayPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	gatewayLn, err := net.Listen("tcp", s.gateway.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.gateway.Addr, err)
+	}
+
+	go func() {
+		s.log.Info("Starting gRPC gateway", "addr", s.gateway.Addr)
+
+		if err := s.gateway.Serve(gatewayLn); err != nil && err != http.ErrServerClosed {
+			errs <- fmt.Errorf("grpc gateway error: %w", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop drains in-flight calls, forcing the stop once the shutdown timeout passes
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if s.gateway != nil {
+		if err := s.gateway.Shutdown(ctx); err != nil {
+			s.log.Error("gRPC gateway shutdown failed", "error", err)
+		}
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.log.Warn("gRPC graceful stop timed out, closing open streams")
+		s.server.Stop()
+	}
+
+	s.log.Info("gRPC server stopped")
+}
+
+// address returns host:port, handling empty host
+func (s *Server) address(port int) string {
+	host := s.cfg.Host
+	if host == "" {
+		host = "0.0.0.0"
+	}
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// gatewayHeaders forwards the headers the services read besides the runtime defaults
+func gatewayHeaders(key string) (string, bool) {
+	switch http.CanonicalHeaderKey(key) {
+	case "X-Request-Id", "Accept-Language":
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
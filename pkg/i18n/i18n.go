@@ -0,0 +1,208 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default is the source language, messages are written in it and used as catalog keys
+const Default = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// placeholderRegex finds {name} placeholders in catalog keys
+var placeholderRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+// catalog holds the translations of one language
+type catalog struct {
+	messages  map[string]string // exact English message -> translation
+	templates []template        // parameterized messages, most specific first
+}
+
+// template is a catalog key with placeholders, ex: "{field} must be at least {n} characters"
+type template struct {
+	pattern     *regexp.Regexp
+	names       []string
+	translation string
+	literalLen  int
+}
+
+var catalogs = mustLoad()
+
+// mustLoad parses the embedded catalogs, a broken catalog is a build mistake
+func mustLoad() map[string]*catalog {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	res := make(map[string]*catalog)
+	for _, entry := range entries {
+		raw, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+
+		res[strings.TrimSuffix(entry.Name(), ".json")] = newCatalog(messages)
+	}
+	return res
+}
+
+func newCatalog(messages map[string]string) *catalog {
+	c := &catalog{messages: make(map[string]string)}
+
+	for key, translation := range messages {
+		if !placeholderRegex.MatchString(key) {
+			c.messages[key] = translation
+			continue
+		}
+
+		t := template{translation: translation}
+		var pattern strings.Builder
+		pattern.WriteString("^")
+
+		last := 0
+		for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(key, -1) {
+			literal := key[last:loc[0]]
+			pattern.WriteString(regexp.QuoteMeta(literal))
+			pattern.WriteString("(.+?)")
+			t.literalLen += len(literal)
+			t.names = append(t.names, key[loc[2]:loc[3]])
+			last = loc[1]
+		}
+		pattern.WriteString(regexp.QuoteMeta(key[last:]))
+		pattern.WriteString("$")
+		t.literalLen += len(key) - last
+
+		t.pattern = regexp.MustCompile(pattern.String())
+		c.templates = append(c.templates, t)
+	}
+
+	// "{field} must be at least {n} characters" must win over "{field} must be at least {n}"
+	sort.Slice(c.templates, func(i, j int) bool {
+		return c.templates[i].literalLen > c.templates[j].literalLen
+	})
+
+	return c
+}
+
+// Supported reports whether lang has a catalog or is the source language
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok || lang == Default
+}
+
+// Translate returns the message in lang, falling back to the English message when no
+// translation exists. Parameterized messages are matched against catalog templates and
+// their {field} values translated too, ex: "Weight must be a positive number" in id
+// becomes "Berat badan harus berupa angka positif".
+func Translate(lang, message string) string {
+	c, ok := catalogs[lang]
+	if !ok || message == "" {
+		return message
+	}
+
+	if translation, ok := c.lookup(message); ok {
+		return translation
+	}
+
+	for _, t := range c.templates {
+		match := t.pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+
+		res := t.translation
+		for i, name := range t.names {
+			value := match[i+1]
+			if translation, ok := c.lookup(value); ok {
+				value = translation
+			}
+			res = strings.ReplaceAll(res, "{"+name+"}", value)
+		}
+		return res
+	}
+
+	return message
+}
+
+// lookup finds an exact message, lowercase words ("password" in "does not match password")
+// reuse the capitalized label translation
+func (c *catalog) lookup(message string) (string, bool) {
+	if translation, ok := c.messages[message]; ok {
+		return translation, true
+	}
+
+	r, size := utf8.DecodeRuneInString(message)
+	if !unicode.IsLower(r) {
+		return "", false
+	}
+
+	translation, ok := c.messages[string(unicode.ToUpper(r))+message[size:]]
+	if !ok {
+		return "", false
+	}
+
+	r, size = utf8.DecodeRuneInString(translation)
+	return string(unicode.ToLower(r)) + translation[size:], true
+}
+
+// Negotiate picks the best supported language of an Accept-Language header, ex:
+// "id-ID,id;q=0.9,en;q=0.8" returns "id". Regions are ignored, unknown languages fall back to Default.
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := Default, 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if base == "in" { // legacy code for Indonesian
+			base = "id"
+		}
+
+		if q > bestQ && Supported(base) {
+			best, bestQ = base, q
+		}
+	}
+
+	return best
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context carrying the negotiated language
+func WithLocale(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, localeKey{}, lang)
+}
+
+// FromContext returns the language of the request, Default when none was negotiated
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(localeKey{}).(string); ok {
+		return lang
+	}
+	return Default
+}
@@ -2,12 +2,19 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
 )
 
 type Logger struct {
 	*slog.Logger
+	out *output // shared by every logger derived through With
 }
 
 type Config struct {
@@ -15,60 +22,219 @@ type Config struct {
 	Format string // json|text
 	File   string
 	AddSrc bool
+
+	// Sinks lists outputs written concurrently: stderr|file|syslog.
+	// Empty keeps the old behavior, file when File is set else stderr.
+	Sinks    []string
+	Rotation RotationConfig
+	Syslog   SyslogConfig
+
+	// Redact lists the field names masked whole on top of passwords, tokens and secrets,
+	// nil uses DefaultRedactFields. See Redactor.
+	Redact []string
+}
+
+// SyslogConfig configures the syslog sink
+type SyslogConfig struct {
+	Network string // udp|tcp, empty for the local daemon
+	Addr    string
+	Tag     string
 }
 
 func New(cfg Config) *Logger {
 	var handler slog.Handler
 
-	// Set log level
-	var level slog.Level
-	switch cfg.Level {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
+	// Set log level, kept in a LevelVar so it can change at runtime
+	level := new(slog.LevelVar)
+	level.Set(parseLevel(cfg.Level))
+
+	// Every attribute goes through the redactor before reaching a sink
+	redactor := NewRedactor(cfg.Redact)
 
 	// Create handler options
 	opts := &slog.HandlerOptions{
-		Level:     level,
-		AddSource: cfg.AddSrc,
+		Level:       level,
+		AddSource:   cfg.AddSrc,
+		ReplaceAttr: redactor.ReplaceAttr,
 	}
 
-	// Determine output writer
-	var writer = os.Stderr
-	if cfg.File != "" {
-		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err != nil {
-			log := slog.New(slog.NewTextHandler(os.Stderr, opts))
-			log.Error("failed to open log file, using stderr", "error", err)
-		} else {
-			writer = file
-		}
-	}
+	// Determine output writers
+	out := newOutput(cfg, opts)
+	out.level = level
+	out.redactor = redactor
 
 	// Create handler based on format
 	switch cfg.Format {
 	case "json":
-		handler = slog.NewJSONHandler(writer, opts)
+		handler = slog.NewJSONHandler(out, opts)
 	default:
-		handler = slog.NewTextHandler(writer, opts)
+		handler = slog.NewTextHandler(out, opts)
 	}
 
 	// Create logger
 	logger := slog.New(handler)
-	return &Logger{Logger: logger}
+	return &Logger{Logger: logger, out: out}
+}
+
+// parseLevel maps debug|info|warn|error to a slog level, defaulting to info
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SetLevel changes the level of this logger and every logger derived from it
+func (l *Logger) SetLevel(level string) {
+	if l.out != nil && l.out.level != nil {
+		l.out.level.Set(parseLevel(level))
+	}
+}
+
+// Redactor returns the redactor applied to every log line, for values logged after their own
+// parsing such as request bodies
+func (l *Logger) Redactor() *Redactor {
+	if l.out == nil || l.out.redactor == nil {
+		return NewRedactor(nil)
+	}
+	return l.out.redactor
+}
+
+// Reopen reopens the log file sink, called on SIGHUP after logrotate moved the file
+func (l *Logger) Reopen() error {
+	if l.out == nil || l.out.file == nil {
+		return nil
+	}
+	return l.out.file.Reopen()
+}
+
+// Close stops the SIGHUP listener and closes file and syslog sinks
+func (l *Logger) Close() error {
+	if l.out == nil {
+		return nil
+	}
+	return l.out.Close()
+}
+
+// output fans each log line out to every sink
+type output struct {
+	writers  []io.Writer
+	closers  []io.Closer
+	file     *RotatingFile
+	level    *slog.LevelVar
+	redactor *Redactor
+	stop     chan os.Signal
+	once     sync.Once
+}
+
+// newOutput opens the configured sinks, falling back to stderr when none could be opened
+func newOutput(cfg Config, opts *slog.HandlerOptions) *output {
+	out := &output{}
+
+	sinks := cfg.Sinks
+	if len(sinks) == 0 {
+		sinks = []string{"stderr"}
+		if cfg.File != "" {
+			sinks = []string{"file"}
+		}
+	}
+
+	var errs []error
+	for _, sink := range sinks {
+		switch sink {
+		case "stderr":
+			out.writers = append(out.writers, os.Stderr)
+		case "file":
+			if cfg.File == "" || out.file != nil {
+				continue
+			}
+
+			file, err := NewRotatingFile(cfg.File, cfg.Rotation)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			out.file = file
+			out.writers = append(out.writers, file)
+			out.closers = append(out.closers, file)
+		case "syslog":
+			writer, err := newSyslogWriter(cfg.Syslog.Network, cfg.Syslog.Addr, cfg.Syslog.Tag)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			out.writers = append(out.writers, writer)
+			out.closers = append(out.closers, writer)
+		}
+	}
+
+	if len(out.writers) == 0 {
+		out.writers = []io.Writer{os.Stderr}
+	}
+
+	if len(errs) > 0 {
+		log := slog.New(slog.NewTextHandler(os.Stderr, opts))
+		log.Error("failed to open log sink", "error", errors.Join(errs...))
+	}
+
+	// Reopen the file on SIGHUP so external rotation doesn't keep writing to the moved file
+	if out.file != nil {
+		out.stop = make(chan os.Signal, 1)
+		signal.Notify(out.stop, syscall.SIGHUP)
+
+		go func() {
+			for range out.stop {
+				if err := out.file.Reopen(); err != nil {
+					slog.New(slog.NewTextHandler(os.Stderr, opts)).Error("failed to reopen log file", "error", err)
+				}
+			}
+		}()
+	}
+
+	return out
+}
+
+// Write sends p to every sink, a failing sink doesn't stop the others
+func (o *output) Write(p []byte) (int, error) {
+	var errs []error
+	for _, w := range o.writers {
+		if _, err := w.Write(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(p), errors.Join(errs...)
+}
+
+func (o *output) Close() error {
+	var errs []error
+
+	o.once.Do(func() {
+		if o.stop != nil {
+			signal.Stop(o.stop)
+			close(o.stop)
+		}
+
+		for _, c := range o.closers {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+
+	return errors.Join(errs...)
 }
 
 // With returns a new Logger with additional key-value pairs
 func (l *Logger) With(args ...any) *Logger {
-	return &Logger{Logger: l.Logger.With(args...)}
+	return &Logger{Logger: l.Logger.With(args...), out: l.out}
 }
 
 // WithContext returns a context with the logger embedded
@@ -76,13 +242,43 @@ func (l *Logger) WithContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, loggerKey{}, l)
 }
 
-// FromContext extracts a logger from context
+// FromContext extracts a logger from context. Request scoped loggers already carry
+// request_id, trace_id, account_id and kind, added by the HTTP middlewares.
 func FromContext(ctx context.Context) *Logger {
 	if logger, ok := ctx.Value(loggerKey{}).(*Logger); ok {
 		return logger
 	}
 	// Return default logger if none found in context
-	return New(Config{Level: "info", Format: "text"})
+	return Default()
+}
+
+// HasContext reports whether ctx carries a logger
+func HasContext(ctx context.Context) bool {
+	_, ok := ctx.Value(loggerKey{}).(*Logger)
+	return ok
+}
+
+// WithAttrs returns a context whose logger carries the additional key-value pairs
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	return FromContext(ctx).With(args...).WithContext(ctx)
+}
+
+var defaultLogger atomic.Pointer[Logger]
+
+// SetDefault sets the logger returned by FromContext for contexts without one
+func SetDefault(l *Logger) {
+	defaultLogger.Store(l)
+	slog.SetDefault(l.Logger)
+}
+
+// Default returns the application logger, a stderr info logger until SetDefault is called
+func Default() *Logger {
+	if l := defaultLogger.Load(); l != nil {
+		return l
+	}
+
+	defaultLogger.CompareAndSwap(nil, New(Config{Level: "info", Format: "text"}))
+	return defaultLogger.Load()
 }
 
 type loggerKey struct{}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces sensitive values in log output
+const Redacted = "[REDACTED]"
+
+// sensitiveSubstrings are matched case-insensitively within field names, covering password,
+// passwordHash, token, refreshToken, secret, ... whatever the configured field list says
+var sensitiveSubstrings = []string{"password", "token", "secret"}
+
+// DefaultRedactFields are the field names, matched case-insensitively, masked without config
+var DefaultRedactFields = []string{"email", "name", "full_name", "fullname", "phone", "authorization", "cookie"}
+
+var (
+	emailPattern  = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	bcryptPattern = regexp.MustCompile(`\$2[aby]?\$\d{2}\$[./A-Za-z0-9]{53}`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"']+`)
+	// key=value pairs of query strings and SQL literals with a sensitive key
+	pairPattern = regexp.MustCompile(`(?i)([A-Za-z_]*(?:password|token|secret)[A-Za-z_]*\s*=\s*)('[^']*'|[^&\s,)]+)`)
+)
+
+// Redactor masks personal data and credentials before they reach a log sink. Fields named
+// after a sensitive key are replaced whole, other text has emails, tokens and password hashes
+// masked wherever they appear, ex: in SQL errors quoting the offending row.
+type Redactor struct {
+	fields map[string]bool
+}
+
+// NewRedactor creates a redactor masking the fields named in fields on top of passwords,
+// tokens and secrets, nil uses DefaultRedactFields
+func NewRedactor(fields []string) *Redactor {
+	if fields == nil {
+		fields = DefaultRedactFields
+	}
+
+	r := &Redactor{fields: make(map[string]bool, len(fields))}
+	for _, field := range fields {
+		r.fields[strings.ToLower(field)] = true
+	}
+	return r
+}
+
+// SensitiveKey reports whether values of the field key are masked whole
+func (r *Redactor) SensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if r.fields[key] {
+		return true
+	}
+
+	for _, sensitive := range sensitiveSubstrings {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask returns s with emails shortened to their first letter and domain, and tokens, bearer
+// credentials and password hashes replaced
+func (r *Redactor) Mask(s string) string {
+	if strings.Contains(s, "@") {
+		s = emailPattern.ReplaceAllString(s, "$1***@$2")
+	}
+	if strings.Contains(s, "eyJ") {
+		s = jwtPattern.ReplaceAllString(s, Redacted)
+	}
+	if strings.Contains(s, "$2") {
+		s = bcryptPattern.ReplaceAllString(s, Redacted)
+	}
+	if strings.Contains(s, "=") {
+		s = pairPattern.ReplaceAllString(s, "${1}"+Redacted)
+	}
+	return bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr masking every attribute, errors included
+func (r *Redactor) ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if r.SensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(r.Mask(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = slog.StringValue(r.Mask(err.Error()))
+		}
+	}
+	return a
+}
@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is appended to rotated file names, ex: app-2025-01-02T15-04-05.000.log
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotationConfig controls when the log file is rotated and how many backups are kept
+type RotationConfig struct {
+	MaxSizeMB  int  // rotate once the file exceeds this size, 0 = never
+	MaxAgeDays int  // remove backups older than this, 0 = keep forever
+	MaxBackups int  // keep at most this many backups, 0 = keep all
+	Compress   bool // gzip rotated files
+}
+
+// RotatingFile is an io.WriteCloser appending to a file that is rotated by size,
+// with old backups pruned by age and count
+type RotatingFile struct {
+	path string
+	cfg  RotationConfig
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+
+	pruneMu sync.Mutex
+}
+
+// NewRotatingFile opens (or creates) the log file at path
+func NewRotatingFile(path string, cfg RotationConfig) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, cfg: cfg}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends p to the file, rotating first if it would exceed the max size
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if maxSize := int64(f.cfg.MaxSizeMB) << 20; maxSize > 0 && f.size+int64(len(p)) > maxSize && f.size > 0 {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Reopen closes and reopens the file, used after an external tool moved it (ex: logrotate + SIGHUP)
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.close(); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+// Close closes the underlying file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.close()
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *RotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// rotate moves the current file to a timestamped backup and starts a new one
+func (f *RotatingFile) rotate() error {
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(f.path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(f.path, ext), time.Now().Format(backupTimeFormat), ext)
+
+	if err := os.Rename(f.path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	go f.prune()
+	return nil
+}
+
+// prune compresses fresh backups and removes those beyond the age and count limits
+func (f *RotatingFile) prune() {
+	f.pruneMu.Lock()
+	defer f.pruneMu.Unlock()
+
+	ext := filepath.Ext(f.path)
+	prefix := filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, prefix) && name != filepath.Base(f.path) {
+			backups = append(backups, filepath.Join(filepath.Dir(f.path), name))
+		}
+	}
+
+	// Timestamped names sort oldest first
+	sort.Strings(backups)
+
+	cutoff := time.Now().AddDate(0, 0, -f.cfg.MaxAgeDays)
+	for i, backup := range backups {
+		expired := f.cfg.MaxBackups > 0 && i < len(backups)-f.cfg.MaxBackups
+		if f.cfg.MaxAgeDays > 0 {
+			if info, err := os.Stat(backup); err == nil && info.ModTime().Before(cutoff) {
+				expired = true
+			}
+		}
+
+		switch {
+		case expired:
+			os.Remove(backup)
+		case f.cfg.Compress && !strings.HasSuffix(backup, ".gz"):
+			compressFile(backup)
+		}
+	}
+}
+
+// compressFile gzips src into src.gz and removes the original
+func compressFile(src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(src+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		out.Close()
+		os.Remove(src + ".gz")
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
@@ -0,0 +1,13 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// newSyslogWriter connects to the syslog daemon, an empty address uses the local socket
+func newSyslogWriter(network, addr, tag string) (io.WriteCloser, error) {
+	return syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// Message is an email with a plain text body and an optional HTML alternative
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New creates a mailer for the driver selected in config, nil when mail is disabled
+func New(cfg config.MailerConfig, log *logger.Logger) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTP(cfg), nil
+	case "log":
+		return logMailer{log}, nil
+	case "", "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver %q", cfg.Driver)
+	}
+}
+
+// logMailer prints messages instead of sending them, for local development
+type logMailer struct {
+	log *logger.Logger
+}
+
+func (m logMailer) Send(ctx context.Context, msg Message) error {
+	m.log.Info("Mail not sent, log mailer", "to", msg.To, "subject", msg.Subject, "text", msg.Text)
+	return nil
+}
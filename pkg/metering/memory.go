@@ -0,0 +1,95 @@
+package metering
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+// cleanupEvery controls how many requests pass between sweeps of expired counters
+const cleanupEvery = 1024
+
+type counterKey struct {
+	account string
+	period  string // day or month key
+}
+
+// MemoryStore keeps counters in process memory, suitable for single instance deployments.
+// Days older than the retention are dropped.
+type MemoryStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	days      map[counterKey]map[string]int64
+	months    map[counterKey]int64
+	records   int
+}
+
+// NewMemoryStore creates a new in-memory store keeping days for retention
+func NewMemoryStore(retention time.Duration) *MemoryStore {
+	return &MemoryStore{
+		retention: retention,
+		days:      make(map[counterKey]map[string]int64),
+		months:    make(map[counterKey]int64),
+	}
+}
+
+func (s *MemoryStore) Record(ctx context.Context, account, client string, metered bool, at time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records++
+	if s.records%cleanupEvery == 0 {
+		s.cleanup(at)
+	}
+
+	key := counterKey{account, dayKey(at)}
+	if s.days[key] == nil {
+		s.days[key] = make(map[string]int64)
+	}
+	s.days[key][client]++
+
+	if !metered {
+		return 0, nil
+	}
+
+	key.period = monthKey(at)
+	s.months[key]++
+	return s.months[key], nil
+}
+
+func (s *MemoryStore) Days(ctx context.Context, account string, from, to time.Time) ([]Day, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var days []Day
+	for day := dayOf(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if requests := s.days[counterKey{account, dayKey(day)}]; requests != nil {
+			days = append(days, Day{Date: day, Requests: maps.Clone(requests)})
+		}
+	}
+
+	return days, nil
+}
+
+func (s *MemoryStore) Month(ctx context.Context, account string, at time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.months[counterKey{account, monthKey(at)}], nil
+}
+
+// cleanup removes days past the retention and months before the current one
+func (s *MemoryStore) cleanup(now time.Time) {
+	oldest, month := dayKey(now.Add(-s.retention)), monthKey(now)
+	for key := range s.days {
+		if key.period < oldest {
+			delete(s.days, key)
+		}
+	}
+	for key := range s.months {
+		if key.period < month {
+			delete(s.months, key)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package metering
+
+import (
+	"context"
+	"time"
+)
+
+// ClientApp is the client of requests made with an access token of the app
+const ClientApp = "app"
+
+// Day holds the requests of an account on a UTC day, by client
+type Day struct {
+	Date     time.Time // midnight UTC
+	Requests map[string]int64
+}
+
+// Store counts the requests of accounts per client and UTC day, and the metered requests of
+// each account per UTC month so a quota can be enforced on them
+type Store interface {
+	// Record counts a request of account by client at the given time. A metered request also
+	// counts against the month of the account, its count in that month is returned, else 0.
+	Record(ctx context.Context, account, client string, metered bool, at time.Time) (int64, error)
+	// Days returns the requests of account on the days from and to fall on and every day
+	// between, oldest first. Days without requests are left out.
+	Days(ctx context.Context, account string, from, to time.Time) ([]Day, error)
+	// Month returns the metered requests of account in the month at falls on
+	Month(ctx context.Context, account string, at time.Time) (int64, error)
+}
+
+// DeviceClient is the client of requests made with the token of a paired device
+func DeviceClient(id string) string {
+	return "device:" + id
+}
+
+// MonthEnd returns when the month at falls on ends, the quota of the next one starts there
+func MonthEnd(at time.Time) time.Time {
+	y, m, _ := at.UTC().Date()
+	return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// dayOf truncates at to midnight UTC
+func dayOf(at time.Time) time.Time {
+	y, m, d := at.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dayKey(at time.Time) string {
+	return at.UTC().Format(time.DateOnly)
+}
+
+func monthKey(at time.Time) string {
+	return at.UTC().Format("2006-01")
+}
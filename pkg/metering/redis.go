@@ -0,0 +1,102 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// recordScript counts the request in the day hash and, when metered, the month counter,
+// setting their expiry on first write, atomically
+var recordScript = redis.NewScript(`
+redis.call("HINCRBY", KEYS[1], ARGV[1], 1)
+if redis.call("TTL", KEYS[1]) < 0 then
+	redis.call("EXPIRE", KEYS[1], ARGV[2])
+end
+if #KEYS < 2 then
+	return 0
+end
+local count = redis.call("INCR", KEYS[2])
+if count == 1 then
+	redis.call("EXPIREAT", KEYS[2], ARGV[3])
+end
+return count
+`)
+
+// RedisStore keeps counters in Redis, shared by every instance. A day is a hash of requests
+// by client expiring after the retention, a month a counter expiring a day after it ends.
+type RedisStore struct {
+	client    *redis.Client
+	prefix    string
+	retention time.Duration
+}
+
+// NewRedisStore creates a new Redis backed store keeping days for retention
+func NewRedisStore(client *redis.Client, prefix string, retention time.Duration) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix, retention: retention}
+}
+
+func (s *RedisStore) Record(ctx context.Context, account, client string, metered bool, at time.Time) (int64, error) {
+	keys := []string{s.dayKey(account, at)}
+	if metered {
+		keys = append(keys, s.monthKey(account, at))
+	}
+
+	expireAt := MonthEnd(at).AddDate(0, 0, 1).Unix()
+	return recordScript.Run(ctx, s.client, keys, client, int64(s.retention/time.Second), expireAt).Int64()
+}
+
+func (s *RedisStore) Days(ctx context.Context, account string, from, to time.Time) ([]Day, error) {
+	var dates []time.Time
+	for day := dayOf(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day)
+	}
+
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(dates))
+	for i, day := range dates {
+		cmds[i] = pipe.HGetAll(ctx, s.dayKey(account, day))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	var days []Day
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		if len(values) == 0 {
+			continue
+		}
+
+		requests := make(map[string]int64, len(values))
+		for client, value := range values {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			requests[client] = n
+		}
+		days = append(days, Day{Date: dates[i], Requests: requests})
+	}
+
+	return days, nil
+}
+
+func (s *RedisStore) Month(ctx context.Context, account string, at time.Time) (int64, error) {
+	count, err := s.client.Get(ctx, s.monthKey(account, at)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return count, err
+}
+
+func (s *RedisStore) dayKey(account string, at time.Time) string {
+	return s.prefix + "day:" + account + ":" + dayKey(at)
+}
+
+func (s *RedisStore) monthKey(account string, at time.Time) string {
+	return s.prefix + "month:" + account + ":" + monthKey(at)
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// combinedTimeFormat is the timestamp layout of the Apache combined log format
+const combinedTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogOptions configures the access log
+type AccessLogOptions struct {
+	// Format is combined (Apache combined log format followed by the latency in
+	// milliseconds) or json (one object per line)
+	Format string
+	// IPHeader is read for the client address behind a proxy, ex: X-Forwarded-For
+	IPHeader string
+	// TrustedProxies is the number of proxies appending to IPHeader, see ClientIP
+	TrustedProxies int
+}
+
+// accessEntry is one line of the access log, the json format writes it as is
+type accessEntry struct {
+	Time      time.Time `json:"time"`
+	RemoteIP  string    `json:"remote_ip"`
+	Method    string    `json:"method"`
+	URI       string    `json:"uri"`
+	Proto     string    `json:"proto"`
+	Status    int       `json:"status"`
+	Bytes     int64     `json:"bytes"`
+	Referer   string    `json:"referer,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	LatencyMS float64   `json:"latency_ms"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// AccessLogMiddleware writes one line per request to out, apart from the application logs,
+// so GoAccess or a Loki dashboard can read it without parsing slog lines. It must run after
+// RequestIDMiddleware. A nil out disables the access log.
+func AccessLogMiddleware(out io.Writer, opts AccessLogOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if out == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			wrapped := &countingWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(wrapped, r)
+
+			entry := accessEntry{
+				Time:      start,
+				RemoteIP:  ClientIP(r, opts.IPHeader, opts.TrustedProxies),
+				Method:    r.Method,
+				URI:       r.URL.RequestURI(),
+				Proto:     r.Proto,
+				Status:    wrapped.status,
+				Bytes:     wrapped.bytes,
+				Referer:   r.Referer(),
+				UserAgent: r.UserAgent(),
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+				RequestID: RequestIDFromContext(r.Context()),
+			}
+
+			// One write per line, concurrent requests never interleave
+			var line bytes.Buffer
+			if opts.Format == "json" {
+				json.NewEncoder(&line).Encode(entry)
+			} else {
+				writeCombined(&line, &entry)
+			}
+			out.Write(line.Bytes())
+		})
+	}
+}
+
+// writeCombined formats entry in the Apache combined log format with the latency appended,
+// GoAccess reads it with --log-format='%h %^[%d:%t %^] "%r" %s %b "%R" "%u" %L'
+func writeCombined(buf *bytes.Buffer, e *accessEntry) {
+	size := "-"
+	if e.Bytes > 0 {
+		size = fmt.Sprint(e.Bytes)
+	}
+
+	fmt.Fprintf(buf, "%s - - [%s] \"%s %s %s\" %d %s %q %q %.0f\n",
+		e.RemoteIP,
+		e.Time.Format(combinedTimeFormat),
+		e.Method, e.URI, e.Proto,
+		e.Status,
+		size,
+		orDash(e.Referer),
+		orDash(e.UserAgent),
+		e.LatencyMS,
+	)
+}
+
+// orDash returns "-", the combined log placeholder, for empty values
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// countingWriter captures the status code and the number of body bytes written
+type countingWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (cw *countingWriter) WriteHeader(code int) {
+	cw.status = code
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.ResponseWriter.Write(p)
+	cw.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer to flush streamed responses
+func (cw *countingWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// BasicAuthMiddleware requires HTTP basic credentials, meant for internal pages such as the API docs
+func BasicAuthMiddleware(realm, user, password string) func(http.Handler) http.Handler {
+	// Hashing first makes the comparison constant time regardless of the input length
+	wantUser := sha256.Sum256([]byte(user))
+	wantPass := sha256.Sum256([]byte(password))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, p, ok := r.BasicAuth()
+			gotUser := sha256.Sum256([]byte(u))
+			gotPass := sha256.Sum256([]byte(p))
+
+			userOK := subtle.ConstantTimeCompare(gotUser[:], wantUser[:]) == 1
+			passOK := subtle.ConstantTimeCompare(gotPass[:], wantPass[:]) == 1
+			if !ok || !userOK || !passOK {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", realm))
+				response.Fail(w, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid credentials")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// BodyLimit creates middleware capping the request body at limit bytes.
+// Declared oversized bodies are rejected upfront, chunked bodies fail on read
+// with *http.MaxBytesError which handlers turn into 413 via response.DecodeError.
+func BodyLimit(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				response.RequestTooLarge(w)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// redactedValue replaces sensitive values in logged bodies
+const redactedValue = logger.Redacted
+
+// sensitiveJSONField catches sensitive string fields in truncated JSON that can't be parsed
+var sensitiveJSONField = regexp.MustCompile(`(?i)("[^"]*(?:password|token|secret)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// BodyLoggingMiddleware creates middleware that logs request and response bodies.
+// It is opt-in and meant for staging: bodies are size capped, requests are sampled
+// and the fields of the logger's redactor are masked before anything reaches the log.
+func BodyLoggingMiddleware(cfg config.BodyLogConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !cfg.Enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.SampleRate < 1 && rand.Float64() >= cfg.SampleRate {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reqBody := &cappedBuffer{max: cfg.MaxBytes}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = &teeReadCloser{ReadCloser: r.Body, buf: reqBody}
+			}
+
+			wrapped := &bodyLogWriter{ResponseWriter: w, buf: &cappedBuffer{max: cfg.MaxBytes}, status: http.StatusOK}
+
+			next.ServeHTTP(wrapped, r)
+
+			log := logger.FromContext(r.Context())
+			redactor := log.Redactor()
+			log.Info("Request body",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", wrapped.status,
+				"request_body", formatBody(redactor, r.Header.Get("Content-Type"), reqBody),
+				"response_body", formatBody(redactor, wrapped.Header().Get("Content-Type"), wrapped.buf),
+			)
+		})
+	}
+}
+
+// formatBody returns a redacted, printable representation of a captured body
+func formatBody(redactor *logger.Redactor, contentType string, buf *cappedBuffer) string {
+	if buf.Len() == 0 {
+		return ""
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+
+	var body string
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		body = redactJSON(redactor, buf.Bytes(), buf.truncated)
+	case mediaType == "application/x-www-form-urlencoded":
+		body = redactForm(redactor, buf.String())
+	case strings.HasPrefix(mediaType, "text/"), mediaType == "application/x-ndjson":
+		body = buf.String()
+	default:
+		return "[" + mediaType + " body omitted]"
+	}
+
+	if buf.truncated {
+		body += "...[truncated]"
+	}
+	return body
+}
+
+// redactJSON masks sensitive fields at any depth of a JSON document
+func redactJSON(redactor *logger.Redactor, data []byte, truncated bool) string {
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if truncated || decoder.Decode(&doc) != nil {
+		return sensitiveJSONField.ReplaceAllString(string(data), `${1}"`+redactedValue+`"`)
+	}
+
+	redacted, err := json.Marshal(redactValue(redactor, doc))
+	if err != nil {
+		return ""
+	}
+	return string(redacted)
+}
+
+func redactValue(redactor *logger.Redactor, v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if redactor.SensitiveKey(key) {
+				value[key] = redactedValue
+			} else {
+				value[key] = redactValue(redactor, item)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redactValue(redactor, item)
+		}
+	}
+	return v
+}
+
+// redactForm masks sensitive fields of an urlencoded form body
+func redactForm(redactor *logger.Redactor, body string) string {
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return "[unparsable form body omitted]"
+	}
+
+	for key := range values {
+		if redactor.SensitiveKey(key) {
+			values[key] = []string{redactedValue}
+		}
+	}
+	return values.Encode()
+}
+
+// cappedBuffer keeps at most max bytes and records whether more were written
+type cappedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) capture(p []byte) {
+	if remaining := b.max - b.Len(); remaining < len(p) {
+		p = p[:max(remaining, 0)]
+		b.truncated = true
+	}
+	b.Write(p)
+}
+
+// teeReadCloser copies what the handler reads from the request body
+type teeReadCloser struct {
+	io.ReadCloser
+	buf *cappedBuffer
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if n > 0 {
+		t.buf.capture(p[:n])
+	}
+	return n, err
+}
+
+// bodyLogWriter copies the response body while passing it through
+type bodyLogWriter struct {
+	http.ResponseWriter
+	buf    *cappedBuffer
+	status int
+}
+
+func (bw *bodyLogWriter) WriteHeader(code int) {
+	bw.status = code
+	bw.ResponseWriter.WriteHeader(code)
+}
+
+func (bw *bodyLogWriter) Write(data []byte) (int, error) {
+	bw.buf.capture(data)
+	return bw.ResponseWriter.Write(data)
+}
+
+// Flush keeps streaming responses working
+func (bw *bodyLogWriter) Flush() {
+	if f, ok := bw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (bw *bodyLogWriter) Unwrap() http.ResponseWriter {
+	return bw.ResponseWriter
+}
@@ -1,6 +1,10 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
 
 // Chain creates a middleware chain from multiple middlewares
 func Chain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
@@ -19,3 +23,36 @@ func Apply(handler http.Handler, middlewares ...func(http.Handler) http.Handler)
 	}
 	return handler
 }
+
+// When applies middleware to the requests match accepts, the others go straight to next
+func When(match func(r *http.Request) bool, middleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if match(r) {
+				wrapped.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Skip applies middleware to every request except those to one of paths, ex: the probes
+// polled by load balancers don't need CORS or compression
+func Skip(middleware func(http.Handler) http.Handler, paths ...string) func(http.Handler) http.Handler {
+	return When(func(r *http.Request) bool {
+		return !slices.Contains(paths, r.URL.Path)
+	}, middleware)
+}
+
+// Only applies middleware to the requests whose path starts with one of prefixes,
+// ex: /api/ to leave the docs and media downloads alone
+func Only(middleware func(http.Handler) http.Handler, prefixes ...string) func(http.Handler) http.Handler {
+	return When(func(r *http.Request) bool {
+		return slices.ContainsFunc(prefixes, func(prefix string) bool {
+			return strings.HasPrefix(r.URL.Path, prefix)
+		})
+	}, middleware)
+}
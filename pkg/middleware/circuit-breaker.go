@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// Breaker is the view of a circuit breaker needed to fail fast
+type Breaker interface {
+	Open() bool
+	RetryAfter() time.Duration
+}
+
+// CircuitBreakerMiddleware answers 503 with Retry-After while the breaker is open,
+// so requests don't queue on a dependency known to be down
+func CircuitBreakerMiddleware(b Breaker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if b == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if b.Open() {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(b.RetryAfter().Seconds()))))
+				response.Fail(w, http.StatusServiceUnavailable, response.CodeUnavailable, "Service temporarily unavailable")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,50 +2,260 @@ package middleware
 
 import (
 	"compress/gzip"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/rizkyharahap/swimo/config"
 )
 
-// CompressionMiddleware creates middleware that compresses HTTP responses
-func CompressionMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Check if client accepts compression
-		acceptEncoding := r.Header.Get("Accept-Encoding")
-		if !strings.Contains(acceptEncoding, "gzip") {
-			// Client doesn't accept compression, proceed normally
-			next.ServeHTTP(w, r)
-			return
-		}
+// incompressibleTypes are content type prefixes that are already compressed
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/pdf",
+	"application/octet-stream",
+	"application/vnd.apache.parquet",
+}
+
+// encoder is implemented by both gzip and brotli writers
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var (
+	gzipPool = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	brotliPool = sync.Pool{New: func() any {
+		return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression)
+	}}
+)
+
+// CompressionMiddleware creates middleware that compresses HTTP responses.
+// Responses are buffered up to MinSize bytes before deciding, so tiny bodies and
+// already compressed content types are sent as-is with their Content-Length.
+func CompressionMiddleware(cfg config.CompressionConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			// Check if client accepts compression
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), cfg.Brotli)
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+				// Client doesn't accept compression, proceed normally
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Wrap response writer
+			compressedWriter := &compressResponseWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        cfg.MinSize,
+				status:         http.StatusOK,
+			}
+			defer compressedWriter.close()
+
+			// Call next handler
+			next.ServeHTTP(compressedWriter, r)
+		})
+	}
+}
 
-		// Create gzip response writer
-		gzWriter := gzip.NewWriter(w)
-		defer gzWriter.Close()
+// negotiateEncoding picks brotli (when enabled) or gzip from the Accept-Encoding header
+func negotiateEncoding(acceptEncoding string, brotliEnabled bool) string {
+	var gzipOK, brotliOK bool
 
-		// Wrap response writer
-		compressedWriter := &gzipResponseWriter{
-			ResponseWriter: w,
-			gzipWriter:     gzWriter,
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
 		}
 
-		// Set content encoding header
-		w.Header().Set("Content-Encoding", "gzip")
-		w.Header().Del("Content-Length") // Content length will change after compression
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "gzip":
+			gzipOK = true
+		case "br":
+			brotliOK = true
+		}
+	}
+
+	switch {
+	case brotliOK && brotliEnabled:
+		return "br"
+	case gzipOK:
+		return "gzip"
+	default:
+		return ""
+	}
+}
 
-		// Call next handler
-		next.ServeHTTP(compressedWriter, r)
-	})
+// isCompressible reports whether a content type benefits from compression
+func isCompressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
 }
 
-// gzipResponseWriter wraps http.ResponseWriter to handle gzip compression
-type gzipResponseWriter struct {
+// compressResponseWriter buffers the start of the body to decide whether to compress
+type compressResponseWriter struct {
 	http.ResponseWriter
-	gzipWriter *gzip.Writer
+	encoding    string
+	minSize     int
+	status      int
+	wroteHeader bool
+	decided     bool
+	compressing bool
+	buf         []byte
+	enc         encoder
 }
 
-func (gz *gzipResponseWriter) Write(data []byte) (int, error) {
-	return gz.gzipWriter.Write(data)
+func (cw *compressResponseWriter) WriteHeader(statusCode int) {
+	if cw.wroteHeader {
+		return
+	}
+
+	cw.status = statusCode
+	cw.wroteHeader = true
+
+	// Informational, no content and not modified responses have no body to compress
+	if statusCode < http.StatusOK || statusCode == http.StatusNoContent || statusCode == http.StatusNotModified {
+		cw.decide(false)
+	}
 }
 
-func (gz *gzipResponseWriter) WriteHeader(statusCode int) {
-	gz.ResponseWriter.WriteHeader(statusCode)
+func (cw *compressResponseWriter) Write(data []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.decided {
+		if cw.compressing {
+			return cw.enc.Write(data)
+		}
+		return cw.ResponseWriter.Write(data)
+	}
+
+	cw.buf = append(cw.buf, data...)
+	if len(cw.buf) >= cw.minSize {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush sends buffered data to the client, used by streaming responses
+func (cw *compressResponseWriter) Flush() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			cw.WriteHeader(http.StatusOK)
+		}
+		cw.decide(true)
+	}
+
+	if cw.compressing {
+		cw.enc.Flush()
+	}
+
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// decide writes the status line and buffered body, compressing if allowed
+func (cw *compressResponseWriter) decide(compress bool) error {
+	if cw.decided {
+		return nil
+	}
+	cw.decided = true
+
+	header := cw.ResponseWriter.Header()
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" && len(cw.buf) > 0 {
+		contentType = http.DetectContentType(cw.buf)
+	}
+
+	cw.compressing = compress && header.Get("Content-Encoding") == "" && isCompressible(contentType)
+
+	if cw.compressing {
+		// Content length will change after compression
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+
+		if cw.encoding == "br" {
+			cw.enc = brotliPool.Get().(*brotli.Writer)
+		} else {
+			cw.enc = gzipPool.Get().(*gzip.Writer)
+		}
+		cw.enc.Reset(cw.ResponseWriter)
+	} else if cw.wroteHeader && header.Get("Content-Length") == "" && cw.status >= http.StatusOK &&
+		cw.status != http.StatusNoContent && cw.status != http.StatusNotModified && !compress {
+		// Whole body is buffered, keep Content-Length semantics intact
+		header.Set("Content-Length", strconv.Itoa(len(cw.buf)))
+	}
+
+	if cw.wroteHeader {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+
+	if len(cw.buf) == 0 {
+		return nil
+	}
+
+	buf := cw.buf
+	cw.buf = nil
+
+	var err error
+	if cw.compressing {
+		_, err = cw.enc.Write(buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// close flushes anything still buffered and returns the encoder to its pool
+func (cw *compressResponseWriter) close() {
+	// Body smaller than the threshold, send it uncompressed
+	cw.decide(false)
+
+	if !cw.compressing {
+		return
+	}
+
+	cw.enc.Close()
+	cw.enc.Reset(io.Discard)
+
+	if cw.encoding == "br" {
+		brotliPool.Put(cw.enc)
+	} else {
+		gzipPool.Put(cw.enc)
+	}
+	cw.enc = nil
 }
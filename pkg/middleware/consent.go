@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// PendingConsents returns the versions of the legal documents an account has yet to accept, by document
+type PendingConsents func(ctx context.Context, accountID string) (map[string]string, error)
+
+// ConsentMiddleware answers 428 to accounts that haven't accepted the current version of the
+// terms or privacy policy. It runs after the authentication middleware, guests and support
+// impersonating a user pass through. A failed lookup lets the request through rather than
+// locking every user out.
+func ConsentMiddleware(pending PendingConsents) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := AuthFromContext(r.Context())
+			if claims == nil || claims.Aid == nil || claims.Imp != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			documents, err := pending(r.Context(), *claims.Aid)
+			if err != nil {
+				logger.FromContext(r.Context()).Warn("consent check failed, request let through", "error", err)
+			} else if len(documents) > 0 {
+				response.ConsentRequired(w, documents)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
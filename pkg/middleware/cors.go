@@ -2,34 +2,93 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/rizkyharahap/swimo/config"
 )
 
-// CORSMiddleware creates middleware that handles CORS headers
+// CORSMiddleware creates middleware that handles CORS headers.
+// Allowed origins may be exact ("https://app.swimo.id"), wildcard subdomains
+// ("https://*.swimo.id") or "*". The request origin is echoed back unless "*"
+// is allowed without credentials, since browsers reject "*" with credentials.
 func CORSMiddleware(cfg config.CORSConfig) func(http.Handler) http.Handler {
+	return DynamicCORSMiddleware(func() config.CORSConfig { return cfg })
+}
+
+// DynamicCORSMiddleware is CORSMiddleware reading its config per request, used for hot reload.
+// The origin matcher is rebuilt only when the allow-list changes.
+func DynamicCORSMiddleware(load func() config.CORSConfig) func(http.Handler) http.Handler {
+	var compiled atomic.Pointer[compiledOrigins]
+
+	matcher := func(allowed []string) originMatcher {
+		if c := compiled.Load(); c != nil && slices.Equal(c.allowed, allowed) {
+			return c.matcher
+		}
+
+		c := &compiledOrigins{allowed: allowed, matcher: newOriginMatcher(allowed)}
+		compiled.Store(c)
+		return c.matcher
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Set CORS headers
-			if cfg.AllowOrigins != "" {
-				w.Header().Set("Access-Control-Allow-Origin", cfg.AllowOrigins)
+			cfg := load()
+			origins := matcher(cfg.AllowOrigins)
+			maxAge := strconv.Itoa(int(cfg.MaxAge / time.Second))
+
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			// Responses differ per origin, caches must key on it
+			w.Header().Add("Vary", "Origin")
+
+			if origin == "" || !origins.match(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
 			}
-			if cfg.AllowMethods != "" {
-				w.Header().Set("Access-Control-Allow-Methods", cfg.AllowMethods)
+
+			// Set CORS headers
+			if origins.any && !cfg.Credentials {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
 			}
-			if cfg.AllowHeaders != "" {
-				w.Header().Set("Access-Control-Allow-Headers", cfg.AllowHeaders)
+			if cfg.Credentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
 			}
 			if cfg.ExposeHeaders != "" {
 				w.Header().Set("Access-Control-Expose-Headers", cfg.ExposeHeaders)
 			}
-			if cfg.Credentials {
-				w.Header().Set("Access-Control-Allow-Credentials", "true")
-			}
 
 			// Handle preflight requests
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusOK)
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+				if cfg.AllowMethods != "" {
+					w.Header().Set("Access-Control-Allow-Methods", cfg.AllowMethods)
+				}
+
+				if cfg.AllowHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", cfg.AllowHeaders)
+				} else if reqHeaders := r.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", reqHeaders)
+				}
+
+				if cfg.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", maxAge)
+				}
+
+				w.WriteHeader(http.StatusNoContent)
 				return
 			}
 
@@ -42,10 +101,73 @@ func CORSMiddleware(cfg config.CORSConfig) func(http.Handler) http.Handler {
 // DefaultCORSConfig returns default CORS configuration
 func DefaultCORSConfig() config.CORSConfig {
 	return config.CORSConfig{
-		AllowOrigins:  "*",
+		AllowOrigins:  []string{"*"},
 		AllowMethods:  "GET, POST, PUT, DELETE, OPTIONS",
 		AllowHeaders:  "Content-Type, Authorization",
 		ExposeHeaders: "",
 		Credentials:   false,
+		MaxAge:        10 * time.Minute,
 	}
 }
+
+// compiledOrigins caches the matcher built for an allow-list
+type compiledOrigins struct {
+	allowed []string
+	matcher originMatcher
+}
+
+// originMatcher matches request origins against the allow-list
+type originMatcher struct {
+	any      bool
+	exact    map[string]bool
+	wildcard []wildcardOrigin
+}
+
+// wildcardOrigin is an origin pattern like https://*.swimo.id split around the "*"
+type wildcardOrigin struct {
+	prefix string
+	suffix string
+}
+
+func newOriginMatcher(allowed []string) originMatcher {
+	m := originMatcher{exact: make(map[string]bool)}
+
+	for _, origin := range allowed {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+
+		switch {
+		case origin == "":
+			continue
+		case origin == "*":
+			m.any = true
+		case strings.Contains(origin, "*"):
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			m.wildcard = append(m.wildcard, wildcardOrigin{prefix: prefix, suffix: suffix})
+		default:
+			m.exact[origin] = true
+		}
+	}
+
+	return m
+}
+
+func (m originMatcher) match(origin string) bool {
+	if m.any {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if m.exact[origin] {
+		return true
+	}
+
+	for _, w := range m.wildcard {
+		if len(origin) > len(w.prefix)+len(w.suffix) &&
+			strings.HasPrefix(origin, w.prefix) &&
+			strings.HasSuffix(origin, w.suffix) {
+			return true
+		}
+	}
+
+	return false
+}
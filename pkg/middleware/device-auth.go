@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/security"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+// DeviceAuthenticator resolves a device token to the claims of the paired device and the secret
+// its requests are signed with, nil when it has none. Its errors are rendered with response.Err.
+type DeviceAuthenticator func(ctx context.Context, token string) (*security.Claim, []byte, error)
+
+type signingSecretKey struct{}
+
+// DeviceSigningSecret returns the signing secret of the device authenticated by
+// DeviceAuthMiddleware, nil when it has none. It is the Secret of the replay protection of
+// device routes.
+func DeviceSigningSecret(r *http.Request) []byte {
+	secret, _ := r.Context().Value(signingSecretKey{}).([]byte)
+	return secret
+}
+
+// DeviceAuthMiddleware authenticates paired devices by their long lived token instead of an
+// access token. The claims identify the owner of the device, so account rate limits, tenant
+// scoping and handlers reading AuthFromContext apply unchanged.
+func DeviceAuthMiddleware(authenticate DeviceAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(w, r)
+			if !ok {
+				return
+			}
+
+			claims, secret, err := authenticate(r.Context(), token)
+			if err != nil {
+				response.Err(w, err)
+				return
+			}
+
+			if id := tenant.FromContext(r.Context()); id != "" && (claims.Org == nil || *claims.Org != id) {
+				response.Fail(w, http.StatusForbidden, response.CodeForbidden, "Token was issued for another organization")
+				return
+			}
+
+			ctx := context.WithValue(WithAuth(r.Context(), claims), signingSecretKey{}, secret)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// EncodingMiddleware negotiates the response encoding from Accept, ex: application/msgpack
+// for the watch client. The response writers encode to it, falling back to JSON.
+func EncodingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		mediaType := response.NegotiateEncoding(r.Header.Get("Accept"))
+		if mediaType == response.ContentTypeJSON {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(response.WithEncoding(w, mediaType), r)
+	})
+}
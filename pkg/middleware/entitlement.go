@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// Entitled reports whether a user holds an entitlement of a paid plan
+type Entitled func(ctx context.Context, userID, entitlement string) (bool, error)
+
+// EntitlementMiddleware answers 402 to callers without the entitlement, guests included. It runs
+// after the authentication middleware. A failed lookup answers 503, a database outage must not
+// open paid features to every account.
+func EntitlementMiddleware(entitlement string, entitled Entitled) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := AuthFromContext(r.Context())
+			if claims == nil || claims.Uid == nil {
+				response.Fail(w, http.StatusPaymentRequired, response.CodePaymentRequired, "Upgrade to premium to use this feature")
+				return
+			}
+
+			ok, err := entitled(r.Context(), *claims.Uid, entitlement)
+			if err != nil {
+				// Fail closed, like the replay protection
+				logger.FromContext(r.Context()).Warn("Entitlement check failed", "entitlement", entitlement, "error", err)
+				response.Fail(w, http.StatusServiceUnavailable, response.CodeUnavailable, "Service temporarily unavailable")
+				return
+			}
+			if !ok {
+				response.Fail(w, http.StatusPaymentRequired, response.CodePaymentRequired, "Upgrade to premium to use this feature")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -11,7 +11,7 @@ func ErrorHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				response.JSON(w, http.StatusInternalServerError, response.Message{Message: "Internal Server Error"})
+				response.Fail(w, http.StatusInternalServerError, response.CodeInternal, "Internal Server Error")
 				return
 			}
 		}()
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+// ImpersonationRecorder stores one request made with an impersonation token, status is the
+// status of the response
+type ImpersonationRecorder func(ctx context.Context, claims *security.Claim, r *http.Request, status int)
+
+// ImpersonationMiddleware refuses the writes of read only impersonation tokens and records every
+// request made while impersonating, refused ones included. It runs after the authentication
+// middleware, requests with the user's own tokens pass through untouched.
+func ImpersonationMiddleware(record ImpersonationRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := AuthFromContext(r.Context())
+			if claims == nil || claims.Imp == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rw := &responseWriter{w, http.StatusOK}
+			if claims.Ro && !isReadMethod(r.Method) {
+				response.Fail(rw, http.StatusForbidden, response.CodeForbidden, "Impersonation token is read only")
+			} else {
+				next.ServeHTTP(rw, r)
+			}
+
+			// The record outlives a client that hung up
+			record(context.WithoutCancel(r.Context()), claims, r, rw.status)
+		})
+	}
+}
+
+// isReadMethod reports whether the method is safe, it does not change any state
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
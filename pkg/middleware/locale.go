@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/i18n"
+)
+
+// LocaleMiddleware negotiates the response language from Accept-Language. The language is
+// stored in the request context and sent as Content-Language, where the response writers
+// read it to translate messages.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+
+		w.Header().Set("Content-Language", lang)
+		w.Header().Add("Vary", "Accept-Language")
+
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), lang)))
+	})
+}
@@ -1,47 +1,98 @@
 package middleware
 
 import (
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metrics"
+	"github.com/rizkyharahap/swimo/pkg/response"
 )
 
+// LoggingOptions configures request logging and latency metrics
+type LoggingOptions struct {
+	// SlowThreshold logs requests slower than this as warning, 0 disables it
+	SlowThreshold time.Duration
+	// Duration receives request latency in seconds labeled by method, route and status
+	Duration *metrics.Histogram
+	// SamplePaths is the fraction of requests to a path that is logged, ex: 0 for the probes
+	// polled by load balancers. Failed and slow requests are always logged.
+	SamplePaths map[string]float64
+}
+
 // LoggingMiddleware creates middleware that logs HTTP requests and responses
-func LoggingMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
+func LoggingMiddleware(log *logger.Logger, opts LoggingOptions) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			// Attach request and trace IDs to every log line of this request
+			log := log
+			if id := RequestIDFromContext(r.Context()); id != "" {
+				log = log.With("request_id", id)
+			}
+			if id := TraceIDFromContext(r.Context()); id != "" {
+				log = log.With("trace_id", id)
+			}
+
 			// Create response wrapper to capture status code
 			wrapped := &responseWriter{w, http.StatusOK}
 
+			sampled := true
+			if rate, ok := opts.SamplePaths[r.URL.Path]; ok {
+				sampled = rate > 0 && rand.Float64() < rate
+			}
+
 			// Log incoming request
-			log.Info("Request started",
-				"method", r.Method,
-				"path", r.URL.Path,
-				"query", r.URL.RawQuery,
-				"user_agent", r.UserAgent(),
-				"remote_addr", r.RemoteAddr,
-				"proto", r.Proto,
-			)
-
-			// Add logger to context
+			if sampled {
+				log.Info("Request started",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"query", r.URL.RawQuery,
+					"user_agent", r.UserAgent(),
+					"remote_addr", r.RemoteAddr,
+					"proto", r.Proto,
+				)
+			}
+
+			// Add logger and route holder to context
 			ctx := log.WithContext(r.Context())
-			r = r.WithContext(ctx)
+			r, route := withRouteHolder(r.WithContext(ctx))
 
 			// Call next handler
 			next.ServeHTTP(wrapped, r)
 
-			// Log completion
+			// Log completion, a failing probe is logged even when its path is sampled out
 			duration := time.Since(start)
-			log.Info("Request completed",
-				"method", r.Method,
-				"path", r.URL.Path,
-				"status", wrapped.status,
-				"duration_ms", duration.Milliseconds(),
-				"duration", duration.String(),
-			)
+			// Event streams stay open by design, they are never slow
+			slow := opts.SlowThreshold > 0 && duration >= opts.SlowThreshold &&
+				wrapped.Header().Get("Content-Type") != response.ContentTypeEventStream
+			if sampled || slow || wrapped.status >= http.StatusInternalServerError {
+				log.Info("Request completed",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"route", route.route(),
+					"status", wrapped.status,
+					"duration_ms", duration.Milliseconds(),
+					"duration", duration.String(),
+				)
+			}
+
+			if slow {
+				log.Warn("Slow request",
+					"method", r.Method,
+					"route", route.route(),
+					"status", wrapped.status,
+					"duration_ms", duration.Milliseconds(),
+					"threshold_ms", opts.SlowThreshold.Milliseconds(),
+				)
+			}
+
+			if opts.Duration != nil {
+				opts.Duration.Observe(duration.Seconds(), r.Method, route.route(), strconv.Itoa(wrapped.status))
+			}
 		})
 	}
 }
@@ -56,3 +107,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.status = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap lets http.ResponseController reach the underlying writer to flush streamed responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
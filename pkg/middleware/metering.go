@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/metering"
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// kindDevice is the claim kind of requests made with a device token
+const kindDevice = "device"
+
+// MeteringOptions configures the metering of authenticated requests
+type MeteringOptions struct {
+	// Quota is read per request so it can be hot reloaded, it returns the device requests an
+	// account may make per UTC month, 0 lifts the quota
+	Quota func() int
+}
+
+// Metering counts every request of an account per client and day in store. Requests of paired
+// devices, the API keys of an account, also count against its monthly quota: they get Quota-*
+// headers and 429 once it is used up. Guests are not counted, a nil store disables metering.
+// It must run after the auth middleware.
+func Metering(store metering.Store, log *logger.Logger, opts MeteringOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if store == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claim := AuthFromContext(r.Context())
+			if claim == nil || claim.Aid == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client, metered := metering.ClientApp, claim.Kind == kindDevice
+			if metered {
+				client = metering.DeviceClient(claim.Sub)
+			}
+
+			now := time.Now()
+			count, err := store.Record(r.Context(), *claim.Aid, client, metered, now)
+			if err != nil {
+				// Fail open, an unavailable store must not take the API down
+				log.Warn("Metering store failed", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			quota := 0
+			if metered && opts.Quota != nil {
+				quota = opts.Quota()
+			}
+			if quota <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reset := strconv.Itoa(int(metering.MonthEnd(now).Sub(now) / time.Second))
+			w.Header().Set("Quota-Limit", strconv.Itoa(quota))
+			w.Header().Set("Quota-Remaining", strconv.FormatInt(max(int64(quota)-count, 0), 10))
+			w.Header().Set("Quota-Reset", reset)
+
+			if count > int64(quota) {
+				w.Header().Set("Retry-After", reset)
+				response.Fail(w, http.StatusTooManyRequests, response.CodeQuotaExceeded, "Monthly API quota exceeded")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
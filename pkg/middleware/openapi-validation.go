@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// OpenAPIValidation rejects requests whose parameters, content type or body don't match the
+// operation documented in doc, with 422 and errors keyed by parameter name or JSON pointer,
+// ex: "/sessions/0/trainingId". Requests to undocumented routes pass through.
+// Authentication is left to the auth middleware.
+func OpenAPIValidation(doc *openapi3.T) (func(http.Handler) http.Handler, error) {
+	// Match on the path only, the documented host is the public one and differs per environment
+	servers := make(openapi3.Servers, 0, len(doc.Servers))
+	for _, server := range doc.Servers {
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server URL %q: %w", server.URL, err)
+		}
+		servers = append(servers, &openapi3.Server{URL: u.Path})
+	}
+
+	routed := *doc
+	routed.Servers = servers
+
+	router, err := legacy.NewRouter(&routed)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				// Undocumented routes, ex: health checks and metrics
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Reads and restores the body
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    opts,
+			})
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					response.RequestTooLarge(w)
+					return
+				}
+
+				response.ValidationError(w, openAPIErrors(err))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// openAPIErrors flattens validation errors into field -> message
+func openAPIErrors(err error) map[string]string {
+	res := make(map[string]string)
+
+	var collect func(reqErr *openapi3filter.RequestError, err error)
+	collect = func(reqErr *openapi3filter.RequestError, err error) {
+		switch e := err.(type) {
+		case openapi3.MultiError:
+			for _, item := range e {
+				collect(reqErr, item)
+			}
+		case *openapi3filter.RequestError:
+			if e.Err == nil {
+				res[fieldKey(e, nil)] = e.Reason
+				return
+			}
+			collect(e, e.Err)
+		case *openapi3.SchemaError:
+			res[fieldKey(reqErr, e)] = e.Reason
+		default:
+			if reqErr == nil {
+				res["request"] = err.Error()
+				return
+			}
+			res[fieldKey(reqErr, nil)] = strings.TrimPrefix(reqErr.Reason+": "+err.Error(), ": ")
+		}
+	}
+	collect(nil, err)
+
+	return res
+}
+
+// fieldKey names the failing input: the parameter name, the body JSON pointer or Content-Type
+func fieldKey(reqErr *openapi3filter.RequestError, schemaErr *openapi3.SchemaError) string {
+	if reqErr != nil && reqErr.Parameter != nil {
+		return reqErr.Parameter.Name
+	}
+
+	if schemaErr != nil {
+		return "/" + strings.Join(schemaErr.JSONPointer(), "/")
+	}
+
+	if reqErr != nil && strings.Contains(reqErr.Reason, "Content-Type") {
+		return "Content-Type"
+	}
+	return "body"
+}
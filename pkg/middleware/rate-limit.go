@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/ratelimit"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+// RateLimitKeyFunc returns the bucket key for a request, empty key skips limiting
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimitOptions configures a rate limit for a route group
+type RateLimitOptions struct {
+	Name    string // group name, prefixes every bucket key
+	Max     int
+	Window  time.Duration
+	KeyFunc RateLimitKeyFunc
+
+	// Limits, when set, is read per request instead of Max and Window so limits can be hot reloaded
+	Limits func() (max int, window time.Duration)
+	// LimitsFor, when set, picks the limits of each request, ex: by CallerKind. It wins over Limits.
+	LimitsFor func(r *http.Request) (max int, window time.Duration)
+}
+
+// RateLimit creates middleware limiting requests per key within a fixed window,
+// emitting RateLimit-* headers. A nil store disables limiting.
+func RateLimit(store ratelimit.Store, log *logger.Logger, opts RateLimitOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if store == nil || (opts.Limits == nil && opts.LimitsFor == nil && opts.Max <= 0) {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit, window := opts.Max, opts.Window
+			switch {
+			case opts.LimitsFor != nil:
+				limit, window = opts.LimitsFor(r)
+			case opts.Limits != nil:
+				limit, window = opts.Limits()
+			}
+
+			key := opts.KeyFunc(r)
+			if key == "" || limit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := store.Allow(r.Context(), opts.Name+":"+key, limit, window)
+			if err != nil {
+				// Fail open, an unavailable store must not take the API down
+				log.Warn("Rate limit store failed", "group", opts.Name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reset := strconv.Itoa(int((res.Reset + time.Second - 1) / time.Second))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", reset)
+
+			if !res.Allowed {
+				w.Header().Set("Retry-After", reset)
+				response.Fail(w, http.StatusTooManyRequests, response.CodeTooManyRequests, "Too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GlobalKey puts every request into a single bucket
+func GlobalKey(r *http.Request) string {
+	return "global"
+}
+
+// IPKey keys requests by client IP, read from header (ex: X-Forwarded-For) when set, see ClientIP
+func IPKey(header string, trustedProxies int) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		return "ip:" + ClientIP(r, header, trustedProxies)
+	}
+}
+
+// AccountKey keys requests by authenticated account, falling back to the session for guests.
+// It must run after AuthMiddleware.
+func AccountKey(r *http.Request) string {
+	claim := AuthFromContext(r.Context())
+	if claim == nil {
+		return ""
+	}
+
+	if claim.Aid != nil {
+		return "account:" + *claim.Aid
+	}
+	return "session:" + claim.Sub
+}
+
+// CallerKind returns the kind of caller quotas are picked for: admin for admin accounts, else
+// the kind of the token, guest, user or device. It must run after AuthMiddleware.
+func CallerKind(r *http.Request) string {
+	claim := AuthFromContext(r.Context())
+	switch {
+	case claim == nil:
+		return ""
+	case claim.IsAdmin():
+		return security.RoleAdmin
+	default:
+		return claim.Kind
+	}
+}
+
+// ClientIP returns the client IP from header or the connection remote address. Each proxy
+// appends the address it received the request from to the header, so only the entries added by
+// our own proxies can be trusted: the client writes whatever it wants on the left. The client IP
+// is the entry trustedProxies hops from the right, ex: the last one behind a single load
+// balancer. A header with fewer entries didn't pass every proxy and its left-most entry is used.
+func ClientIP(r *http.Request, header string, trustedProxies int) string {
+	if header != "" {
+		if v := r.Header.Values(header); len(v) > 0 {
+			hops := strings.Split(strings.Join(v, ","), ",")
+			i := max(len(hops)-max(trustedProxies, 1), 0)
+			return strings.TrimSpace(hops[i])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// ReadinessMiddleware answers 503 with Retry-After until ready reports true, for handlers
+// depending on something connecting in the background such as a lazily connected database
+func ReadinessMiddleware(ready func() bool, retryAfter time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if ready == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !ready() {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				response.Fail(w, http.StatusServiceUnavailable, response.CodeUnavailable, "Service is starting, try again shortly")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,11 +1,11 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
 	"runtime/debug"
 
 	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/response"
 )
 
 // RecoverMiddleware creates middleware that recovers from panics
@@ -18,23 +18,18 @@ func RecoverMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
 					stack := debug.Stack()
 
 					// Log the panic with stack trace
+					requestID := RequestIDFromContext(r.Context())
 					log.Error("Panic recovered",
 						"error", err,
+						"request_id", requestID,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"remote_addr", r.RemoteAddr,
 						"stack", string(stack),
 					)
 
-					// Set content type
-					w.Header().Set("Content-Type", "application/json")
-
 					// Return internal server error
-					w.WriteHeader(http.StatusInternalServerError)
-
-					// Write error response
-					response := fmt.Sprintf(`{"status":%d,"error":{"code":"INTERNAL_ERROR","message":"Internal server error"}}`, http.StatusInternalServerError)
-					w.Write([]byte(response))
+					response.InternalError(w)
 				}
 			}()
 
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/ratelimit"
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+// Headers of signed machine to machine requests
+const (
+	HeaderSignatureTimestamp = "X-Swimo-Timestamp" // unix seconds the request was signed at
+	HeaderSignatureNonce     = "X-Swimo-Nonce"     // random value, unique per request
+	HeaderSignature          = "X-Swimo-Signature" // hex HMAC-SHA256, see SignRequest
+)
+
+// Nonces shorter than this are too easy to repeat by chance, longer ones are padding
+const (
+	minNonceLength = 16
+	maxNonceLength = 128
+)
+
+// ReplayOptions configures the replay protection of a route group
+type ReplayOptions struct {
+	// Window is how far the signed timestamp may be from the server clock, either way
+	Window time.Duration
+	// Secret returns the key requests are signed with, ex: DeviceSigningSecret. It must not be
+	// sent with the request, or a captured request could be signed again. Requests without a
+	// secret are rejected.
+	Secret func(r *http.Request) []byte
+	// KeyFunc scopes nonces, ex: AccountKey, so callers can't burn each other's nonces
+	KeyFunc RateLimitKeyFunc
+}
+
+// ReplayProtection creates middleware rejecting signed requests that are stale, tampered with
+// or already received. Nonces are remembered in store for twice the window, the whole time a
+// timestamp is accepted. It reads the body to check the signature, so it must run after
+// BodyLimit. A nil store disables the protection.
+func ReplayProtection(store ratelimit.Store, log *logger.Logger, opts ReplayOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if store == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timestamp := r.Header.Get(HeaderSignatureTimestamp)
+			nonce := r.Header.Get(HeaderSignatureNonce)
+			signature, err := hex.DecodeString(r.Header.Get(HeaderSignature))
+			if timestamp == "" || len(nonce) < minNonceLength || len(nonce) > maxNonceLength || err != nil || len(signature) == 0 {
+				response.Fail(w, http.StatusUnauthorized, response.CodeUnauthorized, "Missing or invalid request signature")
+				return
+			}
+
+			unix, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil || time.Since(time.Unix(unix, 0)).Abs() > opts.Window {
+				response.Fail(w, http.StatusUnauthorized, response.CodeUnauthorized, "Request timestamp is outside the accepted window")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.DecodeError(w, err)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			secret := opts.Secret(r)
+			if len(secret) == 0 || !hmac.Equal(signature, SignRequest(secret, timestamp, nonce, r.Method, r.URL.RequestURI(), body)) {
+				response.Fail(w, http.StatusUnauthorized, response.CodeUnauthorized, "Missing or invalid request signature")
+				return
+			}
+
+			res, err := store.Allow(r.Context(), "nonce:"+opts.KeyFunc(r)+":"+nonce, 1, 2*opts.Window)
+			if err != nil {
+				// Fail closed, unlike rate limits: a replayed batch would be stored twice
+				log.Warn("Nonce store failed", "error", err)
+				response.Fail(w, http.StatusServiceUnavailable, response.CodeUnavailable, "Service temporarily unavailable")
+				return
+			}
+			if !res.Allowed {
+				response.Fail(w, http.StatusConflict, response.CodeConflict, "Request was already received")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SignRequest returns the HMAC-SHA256 of a request signed at timestamp with nonce, over
+// "{timestamp}\n{nonce}\n{METHOD}\n{path and query}\n{body}"
+func SignRequest(secret []byte, timestamp, nonce, method, uri string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	for _, part := range []string{timestamp, nonce, method, uri} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{'\n'})
+	}
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/ratelimit"
+	"github.com/rizkyharahap/swimo/pkg/security"
+)
+
+const testNonce = "0123456789abcdef"
+
+var testSecret = []byte("sws_test-secret")
+
+func TestSignRequest(t *testing.T) {
+	body := []byte(`{"sessions":[]}`)
+
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte("1700000000\n" + testNonce + "\nPOST\n/api/v1/devices/d1/sessions?x=1\n"))
+	mac.Write(body)
+	want := mac.Sum(nil)
+
+	got := SignRequest(testSecret, "1700000000", testNonce, "POST", "/api/v1/devices/d1/sessions?x=1", body)
+	if !hmac.Equal(got, want) {
+		t.Fatalf("SignRequest() = %x, want %x", got, want)
+	}
+
+	// Every signed part changes the signature
+	changed := map[string][]byte{
+		"secret":    SignRequest([]byte("other"), "1700000000", testNonce, "POST", "/api/v1/devices/d1/sessions?x=1", body),
+		"timestamp": SignRequest(testSecret, "1700000001", testNonce, "POST", "/api/v1/devices/d1/sessions?x=1", body),
+		"nonce":     SignRequest(testSecret, "1700000000", testNonce+"0", "POST", "/api/v1/devices/d1/sessions?x=1", body),
+		"method":    SignRequest(testSecret, "1700000000", testNonce, "PUT", "/api/v1/devices/d1/sessions?x=1", body),
+		"uri":       SignRequest(testSecret, "1700000000", testNonce, "POST", "/api/v1/devices/d1/sessions?x=2", body),
+		"body":      SignRequest(testSecret, "1700000000", testNonce, "POST", "/api/v1/devices/d1/sessions?x=1", []byte(`{}`)),
+	}
+	for part, sig := range changed {
+		if hmac.Equal(sig, want) {
+			t.Errorf("signature unchanged when the %s changes", part)
+		}
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store down")
+}
+
+func TestReplayProtection(t *testing.T) {
+	const uri = "/api/v1/devices/d1/sessions"
+	body := []byte(`{"sessions":[]}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	signed := func(timestamp, nonce string, secret, body []byte) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, uri, bytes.NewReader(body))
+		r.Header.Set(HeaderSignatureTimestamp, timestamp)
+		r.Header.Set(HeaderSignatureNonce, nonce)
+		r.Header.Set(HeaderSignature, hex.EncodeToString(SignRequest(secret, timestamp, nonce, r.Method, uri, body)))
+		return r
+	}
+
+	newHandler := func(store ratelimit.Store) http.Handler {
+		protect := ReplayProtection(store, logger.New(logger.Config{Level: "error"}), ReplayOptions{
+			Window:  5 * time.Minute,
+			Secret:  func(r *http.Request) []byte { return testSecret },
+			KeyFunc: func(r *http.Request) string { return "account:a1" },
+		})
+		return protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+	}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"valid signature", signed(now, testNonce, testSecret, body), http.StatusCreated},
+		{"missing signature", httptest.NewRequest(http.MethodPost, uri, bytes.NewReader(body)), http.StatusUnauthorized},
+		{"short nonce", signed(now, "short", testSecret, body), http.StatusUnauthorized},
+		{"timestamp before the window", signed(strconv.FormatInt(time.Now().Add(-6*time.Minute).Unix(), 10), testNonce, testSecret, body), http.StatusUnauthorized},
+		{"timestamp after the window", signed(strconv.FormatInt(time.Now().Add(6*time.Minute).Unix(), 10), testNonce, testSecret, body), http.StatusUnauthorized},
+		{"signed with another secret", signed(now, testNonce, []byte("sws_captured-token"), body), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newHandler(ratelimit.NewMemoryStore()).ServeHTTP(rec, tt.req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+
+	t.Run("tampered body", func(t *testing.T) {
+		r := signed(now, testNonce, testSecret, body)
+		r.Body = httptest.NewRequest(http.MethodPost, uri, bytes.NewReader([]byte(`{"sessions":[{}]}`))).Body
+
+		rec := httptest.NewRecorder()
+		newHandler(ratelimit.NewMemoryStore()).ServeHTTP(rec, r)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("nonce received twice", func(t *testing.T) {
+		handler := newHandler(ratelimit.NewMemoryStore())
+
+		for i, want := range []int{http.StatusCreated, http.StatusConflict} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signed(now, testNonce, testSecret, body))
+			if rec.Code != want {
+				t.Errorf("request %d: status = %d, want %d", i+1, rec.Code, want)
+			}
+		}
+
+		// Another nonce is a new request
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, signed(now, testNonce+"-2", testSecret, body))
+		if rec.Code != http.StatusCreated {
+			t.Errorf("new nonce: status = %d, want %d", rec.Code, http.StatusCreated)
+		}
+	})
+
+	t.Run("nonce store down", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newHandler(failingStore{}).ServeHTTP(rec, signed(now, testNonce, testSecret, body))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+		}
+	})
+}
+
+func TestDeviceSigningSecret(t *testing.T) {
+	authenticate := func(ctx context.Context, token string) (*security.Claim, []byte, error) {
+		return &security.Claim{Sub: "d1"}, testSecret, nil
+	}
+
+	var got []byte
+	handler := DeviceAuthMiddleware(authenticate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = DeviceSigningSecret(r)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/devices/d1/sessions", nil)
+	r.Header.Set("Authorization", "Bearer swd_token")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	// The secret of the device, never the token sent with the request
+	if !bytes.Equal(got, testSecret) {
+		t.Errorf("DeviceSigningSecret() = %q, want %q", got, testSecret)
+	}
+}
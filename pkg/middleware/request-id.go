@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+)
+
+const (
+	requestIDKey ctxKey = "requestId"
+	traceIDKey   ctxKey = "traceId"
+)
+
+// headerTraceParent is the W3C trace context header, ex: 00-<trace-id>-<span-id>-01
+const headerTraceParent = "traceparent"
+
+// maxRequestIDLength limits client supplied ids so they can't bloat logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware accepts an incoming X-Request-ID or generates a new one,
+// stores it in the request context and echoes it back in the response headers.
+// The trace id is taken from a W3C traceparent header so logs join upstream traces.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(response.HeaderRequestID)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(response.HeaderRequestID, id)
+
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		if traceID := parseTraceParent(r.Header.Get(headerTraceParent)); traceID != "" {
+			ctx = context.WithValue(ctx, traceIDKey, traceID)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext extracts the request ID from context
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// TraceIDFromContext extracts the upstream trace ID from context
+func TraceIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(traceIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// parseTraceParent returns the trace id of a version 00 traceparent header
+func parseTraceParent(header string) string {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 {
+		return ""
+	}
+
+	traceID := strings.ToLower(parts[1])
+	if _, err := hex.DecodeString(traceID); err != nil || traceID == strings.Repeat("0", 32) {
+		return ""
+	}
+	return traceID
+}
+
+// newRequestID returns a random 128-bit hex encoded id
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// isValidRequestID only accepts short printable ids without spaces
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// routeKey is the context key of the matched route holder
+const routeKey ctxKey = "route"
+
+// unmatchedRoute labels requests no route pattern matched, keeping metric cardinality bounded
+const unmatchedRoute = "unmatched"
+
+// routeHolder is filled in by CaptureRoute once the mux has matched the request
+type routeHolder struct {
+	pattern string
+}
+
+// withRouteHolder stores an empty route holder in the request context
+func withRouteHolder(r *http.Request) (*http.Request, *routeHolder) {
+	holder := &routeHolder{}
+	return r.WithContext(context.WithValue(r.Context(), routeKey, holder)), holder
+}
+
+// CaptureRoute wraps the mux so outer middlewares can read the matched route pattern.
+// It must be the innermost wrapper, ServeMux only sets Request.Pattern on the request it receives.
+func CaptureRoute(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+
+		if holder, ok := r.Context().Value(routeKey).(*routeHolder); ok {
+			holder.pattern = r.Pattern
+		}
+	})
+}
+
+// route returns the matched pattern without the method prefix, ex: /api/v1/trainings/{id}
+func (h *routeHolder) route() string {
+	if h.pattern == "" {
+		return unmatchedRoute
+	}
+
+	if _, path, ok := strings.Cut(h.pattern, " "); ok {
+		return path
+	}
+	return h.pattern
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/tenant"
+)
+
+// TenantOptions configures the tenant resolution
+type TenantOptions struct {
+	// BaseDomain is the domain tenants are served under, ex: swimo.id for aquatic.swimo.id
+	BaseDomain string
+	// Lookup returns the organization id of a subdomain, its errors are rendered with response.Err
+	Lookup func(ctx context.Context, slug string) (string, error)
+}
+
+// TenantMiddleware scopes the request to the organization addressed by the subdomain of Host.
+// Requests to the base domain itself, or any other host, keep the default tenant and are
+// scoped later from the organization claim of their token.
+func TenantMiddleware(opts TenantOptions) func(http.Handler) http.Handler {
+	suffix := "." + strings.TrimPrefix(opts.BaseDomain, ".")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			slug := subdomain(r.Host, suffix)
+			if slug == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			id, err := opts.Lookup(r.Context(), slug)
+			if err != nil {
+				response.Err(w, err)
+				return
+			}
+
+			ctx := tenant.WithID(r.Context(), id)
+			ctx = logger.WithAttrs(ctx, "organization_id", id)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// subdomain returns the single label in front of suffix, ex: aquatic for aquatic.swimo.id
+func subdomain(host, suffix string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	slug, ok := strings.CutSuffix(host, suffix)
+	if !ok || slug == "" || strings.Contains(slug, ".") {
+		return ""
+	}
+	return slug
+}
@@ -0,0 +1,122 @@
+// Package pagination parses and validates page, limit and sort query parameters
+// so list endpoints share the same rules and never build ORDER BY from user input.
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/rizkyharahap/swimo/pkg/response"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// Options are the per endpoint defaults and limits
+type Options struct {
+	DefaultLimit int // used when limit is missing, 10 when zero
+	MaxLimit     int // largest accepted limit, 100 when zero
+	Sorts        SortSpec
+	Count        CountMode
+}
+
+// CountMode selects how the total of a list is computed
+type CountMode int
+
+const (
+	CountExact    CountMode = iota // COUNT(*) on every request
+	CountEstimate                  // planner estimate for large results, exact for small ones
+)
+
+// Params is a validated page request
+type Params struct {
+	Page  int
+	Limit int
+	Sort  Sort
+	Count CountMode
+}
+
+// Total is the number of items of a list, Estimated when it came from planner statistics
+type Total struct {
+	Items     int  `json:"items"`
+	Estimated bool `json:"estimated"`
+}
+
+// Parse reads page, limit and sort from query values, reporting every invalid parameter at once
+func Parse(query url.Values, opts Options) (Params, *validator.ValidationError) {
+	if opts.DefaultLimit == 0 {
+		opts.DefaultLimit = 10
+	}
+	if opts.MaxLimit == 0 {
+		opts.MaxLimit = 100
+	}
+
+	params := Params{Page: 1, Limit: opts.DefaultLimit, Count: opts.Count}
+	errors := make(map[string]string)
+
+	if raw := query.Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			errors["page"] = "Page must be a number"
+		case page < 1:
+			errors["page"] = "Page must be at least 1"
+		default:
+			params.Page = page
+		}
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			errors["limit"] = "Limit must be a number"
+		case limit < 1:
+			errors["limit"] = "Limit must be at least 1"
+		case limit > opts.MaxLimit:
+			errors["limit"] = fmt.Sprintf("Limit must not exceed %d", opts.MaxLimit)
+		default:
+			params.Limit = limit
+		}
+	}
+
+	sort, err := opts.Sorts.Parse(query.Get("sort"))
+	if err != nil {
+		errors["sort"] = err.Error()
+	}
+	params.Sort = sort
+
+	if len(errors) > 0 {
+		return params, &validator.ValidationError{Errors: errors}
+	}
+
+	return params, nil
+}
+
+// Offset returns the number of rows skipped before the page
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// LimitOffset returns a LIMIT/OFFSET clause using placeholders starting at $argPos and its args
+func (p Params) LimitOffset(argPos int) (string, []any) {
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", argPos, argPos+1), []any{p.Limit, p.Offset()}
+}
+
+// Response returns the pagination metadata of a page given the list total
+func (p Params) Response(total Total) response.Pagination {
+	return response.Pagination{
+		Page:           p.Page,
+		Limit:          p.Limit,
+		TotalPages:     TotalPages(total.Items, p.Limit),
+		TotalItems:     total.Items,
+		TotalEstimated: total.Estimated,
+	}
+}
+
+// TotalPages returns the number of pages needed for total items
+func TotalPages(total, limit int) int {
+	if total <= 0 || limit <= 0 {
+		return 0
+	}
+	return (total + limit - 1) / limit
+}
@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// SortSpec whitelists the sortable fields of an endpoint.
+// Clients send "field.asc" or "field.desc", only mapped columns ever reach the SQL.
+type SortSpec struct {
+	Columns    map[string]string // public field -> SQL column, ex: "created_at" -> "t.created_at"
+	Default    string            // used when sort is missing, ex: "created_at.desc"
+	TieBreaker string            // unique column appended so pages are stable, ex: "id"
+}
+
+// Sort is a validated sort order
+type Sort struct {
+	Field  string
+	Desc   bool
+	column string
+	tie    string
+}
+
+// Parse validates raw against the whitelist, an empty raw returns the default order
+func (s SortSpec) Parse(raw string) (Sort, error) {
+	if raw == "" {
+		raw = s.Default
+	}
+	if raw == "" {
+		return Sort{}, nil
+	}
+
+	field, dir, _ := strings.Cut(raw, ".")
+	column, ok := s.Columns[field]
+	if !ok || (dir != "asc" && dir != "desc") {
+		def, _ := s.Parse(s.Default)
+		return def, errors.New("Sort must be one of: " + strings.Join(s.Keys(), ", "))
+	}
+
+	return Sort{Field: field, Desc: dir == "desc", column: column, tie: s.TieBreaker}, nil
+}
+
+// Keys lists every accepted sort value, ex: name.asc, name.desc
+func (s SortSpec) Keys() []string {
+	fields := make([]string, 0, len(s.Columns))
+	for field := range s.Columns {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	keys := make([]string, 0, len(fields)*2)
+	for _, field := range fields {
+		keys = append(keys, field+".asc", field+".desc")
+	}
+	return keys
+}
+
+// String returns the public form, ex: created_at.desc
+func (s Sort) String() string {
+	if s.Field == "" {
+		return ""
+	}
+	if s.Desc {
+		return s.Field + ".desc"
+	}
+	return s.Field + ".asc"
+}
+
+// OrderBy returns the ORDER BY clause built from whitelisted columns only
+func (s Sort) OrderBy() string {
+	if s.column == "" {
+		return ""
+	}
+
+	dir := " ASC"
+	if s.Desc {
+		dir = " DESC"
+	}
+
+	clause := " ORDER BY " + s.column + dir
+	if s.tie != "" && s.tie != s.column {
+		clause += ", " + s.tie + dir
+	}
+	return clause
+}
@@ -0,0 +1,96 @@
+// Package payment takes payments through a payment provider: checkouts of subscriptions,
+// refunds and the events the provider posts back to the webhook
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+var (
+	ErrUnavailable = errors.New("payment provider unavailable")
+	// ErrInvalidSignature is returned for webhook payloads not signed by the provider or too old
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Event types handled by the application
+const (
+	EventCheckoutCompleted   = "checkout.session.completed"
+	EventSubscriptionCreated = "customer.subscription.created"
+	EventSubscriptionUpdated = "customer.subscription.updated"
+	EventSubscriptionDeleted = "customer.subscription.deleted"
+	EventInvoicePaid         = "invoice.paid"
+	EventChargeRefunded      = "charge.refunded"
+)
+
+// Provider is implemented by every payment backend
+type Provider interface {
+	// CreateCheckout opens a hosted checkout subscribing to a recurring price. Backend failures
+	// wrap ErrUnavailable.
+	CreateCheckout(ctx context.Context, params CheckoutParams) (*Checkout, error)
+	// Refund gives back amount of a payment, in the smallest currency unit. Retries with the same
+	// idempotency key refund once.
+	Refund(ctx context.Context, paymentIntentID string, amount int64, idempotencyKey string) (*Refund, error)
+	// ParseEvent verifies the signature of a webhook payload and decodes its event
+	ParseEvent(payload []byte, signature string) (*Event, error)
+}
+
+type CheckoutParams struct {
+	PriceID    string
+	UserID     string // comes back with the events of the checkout and the subscription
+	CustomerID string // reused when the user subscribed before, empty for a new customer
+	SuccessURL string
+	CancelURL  string
+}
+
+type Checkout struct {
+	ID  string
+	URL string // where the user pays
+}
+
+type Refund struct {
+	ID     string
+	Status string
+	Amount int64
+}
+
+// Event is a webhook event, Object holds the fields of the object it's about the application reads
+type Event struct {
+	ID      string
+	Type    string
+	Created time.Time
+	Object  EventObject
+}
+
+// EventObject is the checkout session, subscription, invoice or charge of an event, fields an
+// object doesn't have are empty
+type EventObject struct {
+	ID                string            `json:"id"`
+	ClientReferenceID string            `json:"client_reference_id"` // checkout session
+	Customer          string            `json:"customer"`
+	Subscription      string            `json:"subscription"`   // checkout session and invoice
+	PaymentIntent     string            `json:"payment_intent"` // invoice and charge
+	Status            string            `json:"status"`
+	CurrentPeriodEnd  int64             `json:"current_period_end"` // subscription, unix seconds
+	CancelAtPeriodEnd bool              `json:"cancel_at_period_end"`
+	AmountPaid        int64             `json:"amount_paid"` // invoice
+	AmountRefunded    int64             `json:"amount_refunded"`
+	Currency          string            `json:"currency"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+// New creates the provider selected in config, nil when billing is disabled
+func New(cfg config.BillingConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "", "none":
+		return nil, nil
+	case "stripe":
+		return NewStripe(cfg.URL, cfg.SecretKey, cfg.WebhookSecret, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown billing provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,181 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// stripeVersion pins the shape of the objects, invoices still carry their payment intent.
+	// The webhook endpoint must be set to the same version.
+	stripeVersion = "2024-06-20"
+
+	// signatureTolerance is the oldest signed event accepted, older ones may be replayed
+	signatureTolerance = 5 * time.Minute
+)
+
+// Stripe calls the Stripe API with a secret key, ex: https://api.stripe.com
+type Stripe struct {
+	url           string
+	secretKey     string
+	webhookSecret string
+	client        *http.Client
+}
+
+func NewStripe(baseURL, secretKey, webhookSecret string, timeout time.Duration) *Stripe {
+	return &Stripe{
+		url:           strings.TrimSuffix(baseURL, "/") + "/v1",
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *Stripe) CreateCheckout(ctx context.Context, params CheckoutParams) (*Checkout, error) {
+	form := url.Values{
+		"mode":                                {"subscription"},
+		"line_items[0][price]":                {params.PriceID},
+		"line_items[0][quantity]":             {"1"},
+		"client_reference_id":                 {params.UserID},
+		"success_url":                         {params.SuccessURL},
+		"cancel_url":                          {params.CancelURL},
+		"subscription_data[metadata][userId]": {params.UserID},
+	}
+	if params.CustomerID != "" {
+		form.Set("customer", params.CustomerID)
+	}
+
+	var body struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := s.post(ctx, "/checkout/sessions", form, "", &body); err != nil {
+		return nil, err
+	}
+
+	return &Checkout{ID: body.ID, URL: body.URL}, nil
+}
+
+func (s *Stripe) Refund(ctx context.Context, paymentIntentID string, amount int64, idempotencyKey string) (*Refund, error) {
+	form := url.Values{
+		"payment_intent": {paymentIntentID},
+		"amount":         {strconv.FormatInt(amount, 10)},
+	}
+
+	var body struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		Amount int64  `json:"amount"`
+	}
+	if err := s.post(ctx, "/refunds", form, idempotencyKey, &body); err != nil {
+		return nil, err
+	}
+
+	return &Refund{ID: body.ID, Status: body.Status, Amount: body.Amount}, nil
+}
+
+// post sends a form encoded request, Stripe doesn't take JSON bodies. Stripe answers a request
+// sent again with the same idempotency key, when set, with the result of the first.
+func (s *Stripe) post(ctx context.Context, path string, form url.Values, idempotencyKey string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Stripe-Version", stripeVersion)
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var body struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		json.NewDecoder(res.Body).Decode(&body)
+		return fmt.Errorf("%w: status %d: %s", ErrUnavailable, res.StatusCode, body.Error.Message)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(dest); err != nil {
+		return fmt.Errorf("%w: invalid response: %w", ErrUnavailable, err)
+	}
+	return nil
+}
+
+// ParseEvent checks the Stripe-Signature header, ex: t=1700000000,v1=5257a8...: an HMAC-SHA256
+// of the timestamp and payload with the webhook secret, signed in the last minutes
+func (s *Stripe) ParseEvent(payload []byte, signature string) (*Event, error) {
+	var (
+		timestamp  int64
+		signatures [][]byte
+	)
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			if sig, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, sig)
+			}
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return nil, ErrInvalidSignature
+	}
+	if time.Since(time.Unix(timestamp, 0)).Abs() > signatureTolerance {
+		return nil, ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	valid := false
+	for _, sig := range signatures {
+		// Every v1 signature is compared, one per secret while the secret is rolled
+		if hmac.Equal(sig, expected) {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	var body struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Created int64  `json:"created"`
+		Data    struct {
+			Object EventObject `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+
+	return &Event{
+		ID:      body.ID,
+		Type:    body.Type,
+		Created: time.Unix(body.Created, 0).UTC(),
+		Object:  body.Data.Object,
+	}, nil
+}
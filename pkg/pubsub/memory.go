@@ -0,0 +1,56 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBus delivers messages in process memory, to the subscribers of this instance only
+type MemoryBus struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+// NewMemoryBus creates a new in-memory bus
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{subscribers: make(map[string]map[chan []byte]struct{})}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, channel string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[channel] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[channel] == nil {
+		b.subscribers[channel] = make(map[chan []byte]struct{})
+	}
+	b.subscribers[channel][ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.subscribers[channel], ch)
+		if len(b.subscribers[channel]) == 0 {
+			delete(b.subscribers, channel)
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
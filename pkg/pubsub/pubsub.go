@@ -0,0 +1,15 @@
+package pubsub
+
+import "context"
+
+// subscriberBuffer is how many messages a subscriber can lag behind before it misses some
+const subscriberBuffer = 16
+
+// Bus fans the messages published on a channel out to its subscribers. Delivery is best
+// effort: a subscriber lagging too far behind misses messages, one that connects later
+// doesn't get the earlier ones.
+type Bus interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe returns the messages published on channel from now on, closed once ctx is done
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
@@ -0,0 +1,57 @@
+package pubsub
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBus delivers messages through Redis pub/sub, to the subscribers of every instance
+type RedisBus struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisBus creates a new Redis backed bus
+func NewRedisBus(client *redis.Client, prefix string) *RedisBus {
+	return &RedisBus{client: client, prefix: prefix}
+}
+
+func (b *RedisBus) Publish(ctx context.Context, channel string, payload []byte) error {
+	return b.client.Publish(ctx, b.prefix+channel, payload).Err()
+}
+
+func (b *RedisBus) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := b.client.Subscribe(ctx, b.prefix+channel)
+
+	// Wait for the confirmation so messages published once Subscribe returns are received
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	ch := make(chan []byte, subscriberBuffer)
+	messages := sub.Channel(redis.WithChannelSize(subscriberBuffer))
+
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case ch <- []byte(msg.Payload):
+				default:
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
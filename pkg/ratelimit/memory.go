@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cleanupEvery controls how many hits pass between sweeps of expired buckets
+const cleanupEvery = 1024
+
+type bucket struct {
+	count   int64
+	resetAt time.Time
+}
+
+// MemoryStore is a fixed-window store kept in process memory, suitable for single instance deployments
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	hits    int
+}
+
+// NewMemoryStore creates a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	s.hits++
+	if s.hits%cleanupEvery == 0 {
+		s.cleanup(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok || !now.Before(b.resetAt) {
+		b = &bucket{resetAt: now.Add(window)}
+		s.buckets[key] = b
+	}
+
+	b.count++
+
+	return newResult(b.count, limit, b.resetAt.Sub(now)), nil
+}
+
+// cleanup removes buckets whose window has passed
+func (s *MemoryStore) cleanup(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.resetAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Result describes the state of a rate limit bucket after a hit
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // time until the current window resets
+}
+
+// Store counts hits per key within a fixed window
+type Store interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+}
+
+// newResult builds a Result from the hit count of the current window
+func newResult(count int64, limit int, reset time.Duration) Result {
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return Result{
+		Allowed:   count <= int64(limit),
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     reset,
+	}
+}
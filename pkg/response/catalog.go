@@ -0,0 +1,82 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/rizkyharahap/swimo/pkg/i18n"
+	"github.com/rizkyharahap/swimo/pkg/validator"
+)
+
+// CatalogEntry maps a domain error to its HTTP status, stable code and default message
+type CatalogEntry struct {
+	Err     error
+	Status  int
+	Code    string
+	Message string
+}
+
+var catalog struct {
+	mu      sync.RWMutex
+	entries []CatalogEntry
+}
+
+// RegisterErrors adds entries to the error catalog used by Err.
+// Entries are matched with errors.Is in registration order.
+func RegisterErrors(entries ...CatalogEntry) {
+	catalog.mu.Lock()
+	defer catalog.mu.Unlock()
+
+	catalog.entries = append(catalog.entries, entries...)
+}
+
+// Lookup returns the catalog entry matching err
+func Lookup(err error) (CatalogEntry, bool) {
+	catalog.mu.RLock()
+	defer catalog.mu.RUnlock()
+
+	for _, entry := range catalog.entries {
+		if errors.Is(err, entry.Err) {
+			return entry, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// Err writes the error envelope for err, errors missing from the catalog are internal errors.
+// Body limit errors surfacing from a streamed upload are 413, like in DecodeError, and
+// validation errors found by a usecase are 422 with their fields, like in ValidationError.
+// Details of an error implementing Details() map[string]string are sent as its fields.
+func Err(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		RequestTooLarge(w)
+		return
+	}
+
+	var verr *validator.ValidationError
+	if errors.As(err, &verr) {
+		ValidationError(w, verr.Errors)
+		return
+	}
+
+	entry, ok := Lookup(err)
+	if !ok {
+		InternalError(w)
+		return
+	}
+
+	var detailed interface{ Details() map[string]string }
+	if errors.As(err, &detailed) {
+		write(w, entry.Status, Error{
+			Code:      entry.Code,
+			Message:   i18n.Translate(locale(w), entry.Message),
+			Errors:    detailed.Details(),
+			RequestID: requestID(w),
+		})
+		return
+	}
+
+	Fail(w, entry.Status, entry.Code, entry.Message)
+}
@@ -0,0 +1,18 @@
+package response
+
+// Generic error codes, one per failure class. Stable: clients match on them.
+const (
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeConsentRequired  = "CONSENT_REQUIRED"
+	CodePaymentRequired  = "PAYMENT_REQUIRED"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeQuotaExceeded    = "QUOTA_EXCEEDED"
+	CodeInternal         = "INTERNAL_ERROR"
+	CodeUnavailable      = "SERVICE_UNAVAILABLE"
+)
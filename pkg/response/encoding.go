@@ -0,0 +1,128 @@
+package response
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Media types of the response encodings
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeMsgPack  = "application/msgpack"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// mediaTypes maps accepted aliases to the encoding sent back
+var mediaTypes = map[string]string{
+	"application/json":                ContentTypeJSON,
+	"application/msgpack":             ContentTypeMsgPack,
+	"application/x-msgpack":           ContentTypeMsgPack,
+	"application/vnd.msgpack":         ContentTypeMsgPack,
+	"application/x-protobuf":          ContentTypeProtobuf,
+	"application/protobuf":            ContentTypeProtobuf,
+	"application/vnd.google.protobuf": ContentTypeProtobuf,
+}
+
+// ProtoMessage is implemented by data with a protobuf representation,
+// only those are sent as protobuf, other data falls back to JSON
+type ProtoMessage interface {
+	Proto() proto.Message
+}
+
+// NegotiateEncoding picks the response media type from an Accept header,
+// honoring q-values and defaulting to JSON
+func NegotiateEncoding(accept string) string {
+	best, bestQ := ContentTypeJSON, 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		encoding, ok := mediaTypes[mediaType]
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
+
+// encodingWriter carries the negotiated encoding down to the response writers
+type encodingWriter struct {
+	http.ResponseWriter
+	mediaType string
+}
+
+func (ew *encodingWriter) Unwrap() http.ResponseWriter {
+	return ew.ResponseWriter
+}
+
+// WithEncoding returns w with the media type the response writers encode to
+func WithEncoding(w http.ResponseWriter, mediaType string) http.ResponseWriter {
+	return &encodingWriter{ResponseWriter: w, mediaType: mediaType}
+}
+
+// encoding returns the negotiated media type, looking through wrapping middlewares
+func encoding(w http.ResponseWriter) string {
+	for w != nil {
+		if ew, ok := w.(*encodingWriter); ok {
+			return ew.mediaType
+		}
+
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+	return ContentTypeJSON
+}
+
+// write encodes v in the negotiated encoding. Protobuf only applies to successful
+// responses whose data has a protobuf representation, the envelope is dropped then.
+func write(w http.ResponseWriter, statusCode int, v any) {
+	switch encoding(w) {
+	case ContentTypeMsgPack:
+		var buf bytes.Buffer
+		enc := msgpack.NewEncoder(&buf)
+		enc.SetCustomStructTag("json") // same keys as the JSON responses
+		if err := enc.Encode(v); err == nil {
+			w.Header().Set("Content-Type", ContentTypeMsgPack)
+			w.WriteHeader(statusCode)
+			w.Write(buf.Bytes())
+			return
+		}
+
+	case ContentTypeProtobuf:
+		if success, ok := v.(Success); ok {
+			if msg, ok := success.Data.(ProtoMessage); ok {
+				if b, err := proto.Marshal(msg.Proto()); err == nil {
+					w.Header().Set("Content-Type", ContentTypeProtobuf)
+					w.WriteHeader(statusCode)
+					w.Write(b)
+					return
+				}
+			}
+		}
+	}
+
+	JSON(w, statusCode, v)
+}
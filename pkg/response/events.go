@@ -0,0 +1,73 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ContentTypeEventStream is the media type of server-sent events
+const ContentTypeEventStream = "text/event-stream"
+
+// EventStream writes server-sent events, read by EventSource in browsers. The status and
+// headers are sent by NewEventStream, failures can no longer be reported with an envelope.
+type EventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+	// idle is the longest the stream stays silent, between pings
+	idle time.Duration
+}
+
+// NewEventStream starts the stream, clients reconnect after retry when it ends and are
+// expected to be pinged at least every idle
+func NewEventStream(w http.ResponseWriter, retry, idle time.Duration) (*EventStream, error) {
+	s := &EventStream{w: w, rc: http.NewResponseController(w), idle: idle}
+
+	w.Header().Set("Content-Type", ContentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	// Proxies like nginx buffer responses unless told otherwise
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retry.Milliseconds()); err != nil {
+		return nil, err
+	}
+	return s, s.flush()
+}
+
+// Send writes one event of type name with data encoded as JSON
+func (s *EventStream) Send(name string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return s.SendRaw(name, b)
+}
+
+// SendRaw writes one event of type name with data already encoded as single line JSON
+func (s *EventStream) SendRaw(name string, data []byte) error {
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", name, data); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Ping writes a comment, keeping proxies from closing the idle connection
+func (s *EventStream) Ping() error {
+	if _, err := s.w.Write([]byte(": ping\n\n")); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// flush sends the event and pushes the write deadline past the next ping, so the server
+// write timeout only ends a stream whose client stalls
+func (s *EventStream) flush() error {
+	s.rc.SetWriteDeadline(time.Now().Add(s.idle + streamWriteTimeout))
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -2,53 +2,157 @@ package response
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/rizkyharahap/swimo/pkg/i18n"
 )
 
+// HeaderRequestID is the header carrying the request correlation ID
+const HeaderRequestID = "X-Request-ID"
+
+// Message is the data of responses that only confirm an action
 type Message struct {
-	Message string `json:"message"`
+	Message string `json:"message" example:"Sign out successfully"`
+}
+
+// Meta describes the response rather than the resource, present on every success
+type Meta struct {
+	RequestID  string      `json:"requestId,omitempty" example:"3f2a9c1e8b7d4c6a9e0f1b2c3d4e5f60"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Facets     Facets      `json:"facets,omitempty"`
 }
 
+// Facets counts the results matching each value of a list filter, by filter
+type Facets map[string][]FacetValue
+
+// FacetValue is a filter value and the number of results matching it, ex: Breaststroke (12)
+type FacetValue struct {
+	Value string `json:"value" example:"BREASTSTROKE"`
+	Label string `json:"label,omitempty" example:"Breaststroke"`
+	Count int    `json:"count" example:"12"`
+}
+
+// Success is the envelope of every successful API response
 type Success struct {
-	Data any `json:"data"`
+	Data any  `json:"data"`
+	Meta Meta `json:"meta"`
 }
 
 // Pagination represents the pagination metadata.
 type Pagination struct {
-	Page       int `json:"page" example:"1"`
-	Limit      int `json:"limit" example:"10"`
-	TotalPages int `json:"totalPages" example:"5"`
-}
-
-// SuccessPagination is a generic struct for paginated API responses.
-type SuccessPagination struct {
-	Data       any        `json:"data"`
-	Pagination Pagination `json:"pagination"`
+	Page           int  `json:"page" example:"1"`
+	Limit          int  `json:"limit" example:"10"`
+	TotalPages     int  `json:"totalPages" example:"5"`
+	TotalItems     int  `json:"totalItems" example:"48"`
+	TotalEstimated bool `json:"totalEstimated,omitempty" example:"false"` // totals are approximate on large lists
 }
 
+// Error is the envelope of every failed API response. Clients should branch on Code,
+// Message is meant for humans and may change.
 type Error struct {
-	Message string            `json:"message"`
-	Errors  map[string]string `json:"errors"`
+	Code      string            `json:"code" example:"VALIDATION_FAILED"`
+	Message   string            `json:"message" example:"Validation errors"`
+	Errors    map[string]string `json:"errors,omitempty"`
+	RequestID string            `json:"requestId,omitempty" example:"3f2a9c1e8b7d4c6a9e0f1b2c3d4e5f60"`
 }
 
-// JSON writes any struct as JSON response
+// JSON writes any value as JSON response, without an envelope.
+// Handlers use OK, Paginated and Fail, JSON is left for probes with their own format.
 func JSON(w http.ResponseWriter, statusCode int, data any) {
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", ContentTypeJSON)
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(data)
 }
 
+// OK writes data in the success envelope, in the encoding negotiated from Accept
+func OK(w http.ResponseWriter, statusCode int, data any) {
+	if msg, ok := data.(Message); ok {
+		msg.Message = i18n.Translate(locale(w), msg.Message)
+		data = msg
+	}
+
+	write(w, statusCode, Success{Data: data, Meta: Meta{RequestID: requestID(w)}})
+}
+
+// Paginated writes a page of data in the success envelope with its pagination metadata
+func Paginated(w http.ResponseWriter, statusCode int, data any, pagination Pagination) {
+	write(w, statusCode, Success{Data: data, Meta: Meta{RequestID: requestID(w), Pagination: &pagination}})
+}
+
+// Faceted writes a page of data like Paginated along with the facet counts of its filters
+func Faceted(w http.ResponseWriter, statusCode int, data any, pagination Pagination, facets Facets) {
+	write(w, statusCode, Success{Data: data, Meta: Meta{RequestID: requestID(w), Pagination: &pagination, Facets: facets}})
+}
+
+// Fail writes an error envelope with a machine readable code
+func Fail(w http.ResponseWriter, statusCode int, code, message string) {
+	write(w, statusCode, Error{Code: code, Message: i18n.Translate(locale(w), message), RequestID: requestID(w)})
+}
+
 // BadRequest handles invalid JSON or malformed requests
 func BadRequest(w http.ResponseWriter) {
-	JSON(w, http.StatusBadRequest, Message{Message: "Invalid request body"})
+	Fail(w, http.StatusBadRequest, CodeBadRequest, "Invalid request body")
+}
+
+// RequestTooLarge handles request bodies exceeding the configured limit
+func RequestTooLarge(w http.ResponseWriter) {
+	Fail(w, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "Request body too large")
+}
+
+// DecodeError handles request body decode failures, oversized bodies get 413 instead of 400
+func DecodeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		RequestTooLarge(w)
+		return
+	}
+
+	BadRequest(w)
 }
 
 // ValidationError wraps validation errors with 422 Unprocessable Entity
 func ValidationError(w http.ResponseWriter, errors map[string]string) {
-	JSON(w, http.StatusUnprocessableEntity, Error{Errors: errors, Message: "Validation errors"})
+	lang := locale(w)
+
+	translated := make(map[string]string, len(errors))
+	for field, msg := range errors {
+		translated[field] = i18n.Translate(lang, msg)
+	}
+
+	write(w, http.StatusUnprocessableEntity, Error{
+		Code:      CodeValidationFailed,
+		Message:   i18n.Translate(lang, "Validation errors"),
+		Errors:    translated,
+		RequestID: requestID(w),
+	})
+}
+
+// ConsentRequired answers 428 Precondition Required with the versions of the legal documents
+// to accept first, by document
+func ConsentRequired(w http.ResponseWriter, pending map[string]string) {
+	write(w, http.StatusPreconditionRequired, Error{
+		Code:      CodeConsentRequired,
+		Message:   i18n.Translate(locale(w), "Accept the updated terms to continue"),
+		Errors:    pending,
+		RequestID: requestID(w),
+	})
 }
 
 // InternalError wraps generic 500 Internal Server Error
 func InternalError(w http.ResponseWriter) {
-	JSON(w, http.StatusInternalServerError, Message{Message: "Internal server error"})
+	Fail(w, http.StatusInternalServerError, CodeInternal, "Internal server error")
+}
+
+// requestID returns the request ID set on the response by the request ID middleware
+func requestID(w http.ResponseWriter) string {
+	return w.Header().Get(HeaderRequestID)
+}
+
+// locale returns the language negotiated by the locale middleware
+func locale(w http.ResponseWriter) string {
+	if lang := w.Header().Get("Content-Language"); lang != "" {
+		return lang
+	}
+	return i18n.Default
 }
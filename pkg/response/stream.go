@@ -0,0 +1,138 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/i18n"
+)
+
+// ContentTypeNDJSON is the media type of newline delimited JSON, one item per line
+const ContentTypeNDJSON = "application/x-ndjson"
+
+const (
+	// streamFlushEvery is how many items are buffered before flushing to the client
+	streamFlushEvery = 100
+
+	// streamWriteTimeout replaces the server write timeout while streaming,
+	// pushed forward on every flush so a long export only fails when the client stalls
+	streamWriteTimeout = 30 * time.Second
+)
+
+// Stream writes a large result set item by item instead of materializing it.
+// Clients asking for application/x-ndjson (Accept header or ?format=ndjson) get one JSON
+// item per line, others get the usual success envelope with data as a chunked JSON array.
+type Stream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	enc     *json.Encoder
+	ndjson  bool
+	started bool
+	count   int
+}
+
+// NewStream negotiates the stream format, nothing is written until the first item
+func NewStream(w http.ResponseWriter, r *http.Request) *Stream {
+	ndjson := r.URL.Query().Get("format") == "ndjson" || strings.Contains(r.Header.Get("Accept"), ContentTypeNDJSON)
+	return &Stream{w: w, rc: http.NewResponseController(w), enc: json.NewEncoder(w), ndjson: ndjson}
+}
+
+// Started reports whether the status and headers were sent, after which
+// failures can no longer be reported with an error envelope
+func (s *Stream) Started() bool {
+	return s.started
+}
+
+// Count returns the number of items written
+func (s *Stream) Count() int {
+	return s.count
+}
+
+// Write sends one item
+func (s *Stream) Write(item any) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	if !s.ndjson && s.count > 0 {
+		if _, err := s.w.Write([]byte{','}); err != nil {
+			return err
+		}
+	}
+
+	// Encode appends a newline, which is the NDJSON delimiter and harmless inside an array
+	if err := s.enc.Encode(item); err != nil {
+		return err
+	}
+
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+// Close terminates the stream, an empty result is still a valid response
+func (s *Stream) Close() error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	if !s.ndjson {
+		meta, err := json.Marshal(Meta{RequestID: requestID(s.w)})
+		if err != nil {
+			return err
+		}
+		if _, err := s.w.Write(append(append([]byte(`],"meta":`), meta...), "}\n"...)); err != nil {
+			return err
+		}
+	}
+
+	return s.flush()
+}
+
+// Fail reports an error. Before the first item it's a regular error envelope, after it NDJSON
+// streams end with an error line and JSON streams are left unterminated so clients see truncation.
+func (s *Stream) Fail(statusCode int, code, message string) {
+	if !s.started {
+		Fail(s.w, statusCode, code, message)
+		return
+	}
+
+	if s.ndjson {
+		s.enc.Encode(map[string]Error{"error": {Code: code, Message: i18n.Translate(locale(s.w), message), RequestID: requestID(s.w)}})
+		s.flush()
+	}
+}
+
+func (s *Stream) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+
+	// Best effort, not every writer supports deadlines
+	s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+
+	if s.ndjson {
+		s.w.Header().Set("Content-Type", ContentTypeNDJSON)
+		s.w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	s.w.Header().Set("Content-Type", "application/json")
+	s.w.WriteHeader(http.StatusOK)
+	_, err := s.w.Write([]byte(`{"data":[`))
+	return err
+}
+
+func (s *Stream) flush() error {
+	s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package router
+
+import "net/http"
+
+// Middlewares groups the middleware chains shared by module routes
+type Middlewares struct {
+	// Public wraps endpoints reachable without authentication
+	Public func(http.Handler) http.Handler
+	// PublicRead wraps reads reachable without authentication that shared screens poll, ex:
+	// pool occupancy boards. They don't count against the sign in limit of their network.
+	PublicRead func(http.Handler) http.Handler
+	// Protected wraps endpoints requiring a valid access token
+	Protected func(http.Handler) http.Handler
+	// Expensive wraps the stats and search endpoints, Protected plus a tighter quota per
+	// kind of caller
+	Expensive func(http.Handler) http.Handler
+	// Premium wraps the endpoints of the premium plan, Expensive plus its entitlement when
+	// billing is enabled
+	Premium func(http.Handler) http.Handler
+	// Admin wraps endpoints reserved to admin accounts, on top of Protected
+	Admin func(http.Handler) http.Handler
+	// Upload wraps authenticated file uploads, limited by the storage upload size
+	// instead of the JSON body limit
+	Upload func(http.Handler) http.Handler
+	// Device wraps endpoints called by paired devices with their device token
+	// instead of an access token
+	Device func(http.Handler) http.Handler
+	// Webhook wraps the endpoints called back by third parties, who sign the raw body
+	// instead of sending an access token
+	Webhook func(http.Handler) http.Handler
+}
+
+// Module is implemented by every internal module exposing HTTP routes
+type Module interface {
+	Routes(mux *http.ServeMux, mw Middlewares)
+}
+
+// Register registers the routes of every module on mux
+func Register(mux *http.ServeMux, mw Middlewares, modules ...Module) {
+	for _, module := range modules {
+		module.Routes(mux, mw)
+	}
+}
+
+// Group registers routes sharing a middleware chain, so the chain isn't repeated per handler
+type Group struct {
+	mux        *http.ServeMux
+	middleware func(http.Handler) http.Handler
+}
+
+// NewGroup creates a group wrapping every route registered through it in middleware
+func NewGroup(mux *http.ServeMux, middleware func(http.Handler) http.Handler) *Group {
+	return &Group{mux: mux, middleware: middleware}
+}
+
+// Handle registers handler for pattern behind the group middleware
+func (g *Group) Handle(pattern string, handler http.Handler) {
+	g.mux.Handle(pattern, g.middleware(handler))
+}
+
+// HandleFunc registers fn for pattern behind the group middleware
+func (g *Group) HandleFunc(pattern string, fn http.HandlerFunc) {
+	g.Handle(pattern, fn)
+}
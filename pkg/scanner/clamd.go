@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize is the size of the INSTREAM chunks sent to clamd
+const clamdChunkSize = 64 << 10
+
+// Clamd streams files to a ClamAV daemon with the INSTREAM command.
+// Its StreamMaxLength must be at least STORAGE_MAX_UPLOAD_MB, larger files are reported unavailable.
+type Clamd struct {
+	network string
+	addr    string
+	timeout time.Duration
+}
+
+// NewClamd accepts host:port or unix:/path/to/clamd.sock
+func NewClamd(addr string, timeout time.Duration) *Clamd {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return &Clamd{network: "unix", addr: path, timeout: timeout}
+	}
+	return &Clamd{network: "tcp", addr: addr, timeout: timeout}
+}
+
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if err := c.stream(conn, r); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading clamd reply: %w", ErrUnavailable, err)
+	}
+
+	return parseClamdReply(strings.TrimRight(reply, "\x00"))
+}
+
+// stream sends r as length prefixed chunks terminated by an empty chunk
+func (c *Clamd) stream(conn net.Conn, r io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, werr := conn.Write(buf[:4+n]); werr != nil {
+				// clamd closes the stream once it is past its size limit, its reply tells why
+				return nil
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseClamdReply reads "stream: OK", "stream: <signature> FOUND" or "<message> ERROR"
+func parseClamdReply(reply string) (*Result, error) {
+	switch {
+	case strings.HasSuffix(reply, " OK"):
+		return &Result{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return &Result{Infected: true, Signature: signature}, nil
+	default:
+		return nil, fmt.Errorf("%w: clamd replied %q", ErrUnavailable, reply)
+	}
+}
@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTP posts files to an external scanning API. The API receives the raw file as
+// application/octet-stream and answers 200 with {"infected": bool, "signature": "..."}.
+type HTTP struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewHTTP(url, token string, timeout time.Duration) *HTTP {
+	return &HTTP{url: url, token: token, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *HTTP) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrUnavailable, res.StatusCode)
+	}
+
+	var verdict struct {
+		Infected  bool   `json:"infected"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&verdict); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %w", ErrUnavailable, err)
+	}
+
+	return &Result{Infected: verdict.Infected, Signature: verdict.Signature}, nil
+}
@@ -0,0 +1,44 @@
+// Package scanner checks uploaded files for malware before they are stored,
+// with a ClamAV daemon or an external scanning API
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+var (
+	ErrInfected    = errors.New("file rejected by malware scan")
+	ErrUnavailable = errors.New("malware scanner unavailable")
+)
+
+// Scanner is implemented by every scanning backend
+type Scanner interface {
+	// Scan reads r to the end, or until a verdict is reached, and reports what it found.
+	// Backend failures wrap ErrUnavailable so uploads fail closed.
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// Result is the verdict of a scan
+type Result struct {
+	Infected  bool
+	Signature string // name of the detected malware, ex: Eicar-Signature
+}
+
+// New creates the scanner selected in config, nil when scanning is disabled
+func New(cfg config.ScannerConfig) (Scanner, error) {
+	switch cfg.Driver {
+	case "", "none":
+		return nil, nil
+	case "clamd":
+		return NewClamd(cfg.Addr, cfg.Timeout), nil
+	case "http":
+		return NewHTTP(cfg.URL, cfg.Token, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown scanner driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// Job represents a periodic task run by the scheduler
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Jitter   time.Duration // random delay added before every run, spreads load across instances
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their own interval until stopped
+type Scheduler struct {
+	log    *logger.Logger
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+// New creates a new scheduler
+func New(log *logger.Logger) *Scheduler {
+	return &Scheduler{log: log}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches every registered job in its own goroutine
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			s.log.Warn("Scheduler job skipped: interval not set", "job", job.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+
+		s.log.Info("Scheduler job registered", "job", job.Name, "interval", job.Interval, "jitter", job.Jitter)
+	}
+}
+
+// Stop cancels all jobs and waits for running ones to finish or ctx to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.log.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler stop: %w", ctx.Err())
+	}
+}
+
+// loop waits for the job interval (plus jitter) and runs it until ctx is cancelled
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	for {
+		timer := time.NewTimer(job.Interval + jitter(job.Jitter))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.run(ctx, job)
+		}
+	}
+}
+
+// run executes a single job invocation, recovering from panics
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := time.Now()
+
+	defer func() {
+		if err := recover(); err != nil {
+			s.log.Error("Scheduler job panicked", "job", job.Name, "error", err)
+		}
+	}()
+
+	// Jobs log through logger.FromContext, tagged with the job name
+	ctx = s.log.With("job", job.Name).WithContext(ctx)
+
+	if err := job.Run(ctx); err != nil {
+		s.log.Error("Scheduler job failed", "job", job.Name, "duration", time.Since(start).String(), "error", err)
+		return
+	}
+
+	s.log.Info("Scheduler job completed", "job", job.Name, "duration", time.Since(start).String())
+}
+
+// jitter returns a random duration in [0, max)
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
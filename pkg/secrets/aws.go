@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/rizkyharahap/swimo/config"
+)
+
+// awsProvider reads secrets from AWS Secrets Manager using the default credential chain
+type awsProvider struct {
+	client *secretsmanager.Client
+}
+
+func newAWSProvider(ctx context.Context, cfg config.SecretsConfig) (*awsProvider, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.AWSRegion != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.AWSRegion))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+
+	return &awsProvider{client: secretsmanager.NewFromConfig(awsCfg)}, nil
+}
+
+func (p *awsProvider) Get(ctx context.Context, name string) (string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("aws %s: %w", name, ErrNotFound)
+		}
+		return "", fmt.Errorf("aws %s: %w", name, err)
+	}
+
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
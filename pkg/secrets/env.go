@@ -0,0 +1,41 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// envProvider reads secret://NAME from the environment variable NAME
+type envProvider struct{}
+
+func (envProvider) Get(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("env %s: %w", name, ErrNotFound)
+	}
+	return value, nil
+}
+
+// fileProvider reads secrets mounted as files, ex: docker/kubernetes secrets in /run/secrets
+type fileProvider struct {
+	dir string
+}
+
+func (p fileProvider) Get(ctx context.Context, name string) (string, error) {
+	// Secret names must stay inside the secrets directory
+	if strings.Contains(name, "..") || filepath.IsAbs(name) {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.dir, name))
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("file %s: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,71 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/gcpauth"
+)
+
+const gcpSecretURL = "https://secretmanager.googleapis.com/v1/projects/%s/secrets/%s/versions/latest:access"
+
+// gcpProvider reads secrets from Google Secret Manager over REST, authenticated
+// with the workload service account of the metadata server (GCE, GKE, Cloud Run)
+type gcpProvider struct {
+	project  string
+	client   *http.Client
+	metadata *gcpauth.Metadata
+}
+
+func newGCPProvider(cfg config.SecretsConfig) *gcpProvider {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return &gcpProvider{project: cfg.GCPProject, client: client, metadata: gcpauth.NewMetadata(client)}
+}
+
+func (p *gcpProvider) Get(ctx context.Context, name string) (string, error) {
+	token, err := p.metadata.AccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf(gcpSecretURL, url.PathEscape(p.project), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gcp request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("gcp %s: %w", name, ErrNotFound)
+	case res.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("gcp %s: unexpected status %d", name, res.StatusCode)
+	}
+
+	var body struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("gcp %s: invalid response: %w", name, err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(body.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("gcp %s: invalid payload: %w", name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,244 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/logger"
+)
+
+// refPrefix marks a config value as a reference to a secret, ex:
+// JWT_SECRET=secret://swimo/api#jwt reads field "jwt" of secret "swimo/api"
+const refPrefix = "secret://"
+
+var ErrNotFound = errors.New("secret not found")
+
+// Provider fetches raw secret values from a secrets backend
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// New creates the provider selected in config
+func New(ctx context.Context, cfg config.SecretsConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "", "env":
+		return envProvider{}, nil
+	case "file":
+		return fileProvider{dir: cfg.FileDir}, nil
+	case "vault":
+		return newVaultProvider(cfg), nil
+	case "aws":
+		return newAWSProvider(ctx, cfg)
+	case "gcp":
+		return newGCPProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", cfg.Provider)
+	}
+}
+
+// field is a config value that may hold a secret reference
+type field struct {
+	name  string
+	value func(cfg *config.Config) *string
+}
+
+// secretFields lists every config value resolvable from the secrets backend
+var secretFields = []field{
+	{"JWT_SECRET", func(c *config.Config) *string { return &c.Auth.JWTSecret }},
+	{"JWT_PREVIOUS_KEYS", func(c *config.Config) *string { return &c.Auth.JWTPreviousKeys }},
+	{"DATABASE_URL", func(c *config.Config) *string { return &c.Database.URL }},
+	{"DB_USER", func(c *config.Config) *string { return &c.Database.User }},
+	{"DB_PASSWORD", func(c *config.Config) *string { return &c.Database.Pass }},
+	{"REDIS_URL", func(c *config.Config) *string { return &c.Redis.URL }},
+	{"SWAGGER_PASSWORD", func(c *config.Config) *string { return &c.Swagger.Password }},
+	{"STORAGE_SIGNING_KEY", func(c *config.Config) *string { return &c.Storage.SigningKey }},
+	{"SCANNER_TOKEN", func(c *config.Config) *string { return &c.Scanner.Token }},
+	{"ANALYTICS_TOKEN", func(c *config.Config) *string { return &c.Analytics.Token }},
+	{"ANALYTICS_ID_SALT", func(c *config.Config) *string { return &c.Analytics.IDSalt }},
+	{"WAREHOUSE_ID_SALT", func(c *config.Config) *string { return &c.Warehouse.IDSalt }},
+	{"SMTP_PASSWORD", func(c *config.Config) *string { return &c.Mailer.Password }},
+	{"PII_ENCRYPTION_KEYS", func(c *config.Config) *string { return &c.Encryption.Keys }},
+	{"STRIPE_SECRET_KEY", func(c *config.Config) *string { return &c.Billing.SecretKey }},
+	{"STRIPE_WEBHOOK_SECRET", func(c *config.Config) *string { return &c.Billing.WebhookSecret }},
+}
+
+// ref points to a secret and optionally a field of its JSON value
+type ref struct {
+	name string
+	key  string
+}
+
+func parseRef(value string) (ref, bool) {
+	path, ok := strings.CutPrefix(value, refPrefix)
+	if !ok || path == "" {
+		return ref{}, false
+	}
+
+	name, key, _ := strings.Cut(path, "#")
+	return ref{name: name, key: key}, true
+}
+
+// Resolver replaces secret references in the config with their values.
+// Values only ever live in memory: they are never written back to env or disk.
+type Resolver struct {
+	mu   sync.Mutex
+	refs map[string]ref // field name -> reference seen on the last resolve
+}
+
+// NewResolver creates an empty resolver
+func NewResolver() *Resolver {
+	return &Resolver{refs: make(map[string]ref)}
+}
+
+// Resolve is a config.ResolveFunc, it builds the provider from cfg.Secrets on every call
+// so a reload can switch providers
+func (r *Resolver) Resolve(ctx context.Context, cfg *config.Config) error {
+	refs := make(map[string]ref)
+	for _, f := range secretFields {
+		if ref, ok := parseRef(*f.value(cfg)); ok {
+			refs[f.name] = ref
+		}
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	provider, err := New(ctx, cfg.Secrets)
+	if err != nil {
+		return err
+	}
+
+	if err := apply(ctx, provider, refs, cfg); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.refs = refs
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Watch re-fetches referenced secrets every interval and publishes changed values to the store.
+// Components reading the store (JWT signing and verification) pick them up immediately,
+// connection credentials apply to connections opened after the next restart or reconnect.
+func (r *Resolver) Watch(ctx context.Context, store *config.Store, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.refresh(ctx, store); err != nil {
+				logger.FromContext(ctx).Error("Secrets refresh failed, keeping current values", "error", err)
+			}
+		}
+	}
+}
+
+func (r *Resolver) refresh(ctx context.Context, store *config.Store) error {
+	r.mu.Lock()
+	refs := r.refs
+	r.mu.Unlock()
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	current := store.Load()
+	provider, err := New(ctx, current.Secrets)
+	if err != nil {
+		return err
+	}
+
+	next := *current
+	if err := apply(ctx, provider, refs, &next); err != nil {
+		return err
+	}
+
+	var changed []string
+	for _, f := range secretFields {
+		if *f.value(&next) != *f.value(current) {
+			changed = append(changed, f.name)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	store.Update(func(cfg *config.Config) {
+		for _, f := range secretFields {
+			*f.value(cfg) = *f.value(&next)
+		}
+	})
+
+	// Only names are logged, never values
+	logger.FromContext(ctx).Info("Secrets refreshed", "changed", changed)
+	return nil
+}
+
+// apply fetches every reference and writes the value into cfg
+func apply(ctx context.Context, provider Provider, refs map[string]ref, cfg *config.Config) error {
+	cache := make(map[string]string)
+
+	for _, f := range secretFields {
+		ref, ok := refs[f.name]
+		if !ok {
+			continue
+		}
+
+		raw, ok := cache[ref.name]
+		if !ok {
+			var err error
+			if raw, err = provider.Get(ctx, ref.name); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+			cache[ref.name] = raw
+		}
+
+		value, err := extract(raw, ref.key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+
+		*f.value(cfg) = value
+	}
+
+	return nil
+}
+
+// extract returns the whole secret or one field of a JSON object secret
+func extract(raw, key string) (string, error) {
+	if key == "" {
+		return strings.TrimSpace(raw), nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object, can't read field %q", key)
+	}
+
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("field %q: %w", key, ErrNotFound)
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
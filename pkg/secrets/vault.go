@@ -0,0 +1,69 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+// vaultProvider reads secrets from a HashiCorp Vault KV v2 engine.
+// The whole data map of the secret is returned as a JSON object.
+type vaultProvider struct {
+	addr      string
+	token     string
+	mount     string
+	namespace string
+	client    *http.Client
+}
+
+func newVaultProvider(cfg config.SecretsConfig) *vaultProvider {
+	return &vaultProvider{
+		addr:      strings.TrimRight(cfg.VaultAddr, "/"),
+		token:     cfg.VaultToken,
+		mount:     strings.Trim(cfg.VaultMount, "/"),
+		namespace: cfg.VaultNamespace,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *vaultProvider) Get(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.addr, p.mount, strings.Trim(name, "/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("vault %s: %w", name, ErrNotFound)
+	case res.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault %s: unexpected status %d", name, res.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("vault %s: invalid response: %w", name, err)
+	}
+
+	return string(body.Data.Data), nil
+}
@@ -0,0 +1,137 @@
+package security
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrReservedClaim = errors.New("claim name is reserved")
+
+// fixedClaims are the payload names of the Claim fields, extra claims can't use them. JSON
+// matches names case insensitively, so neither can their other casings.
+var fixedClaims = []string{"Sub", "Aid", "Uid", "Org", "Kind", "Role", "Imp", "Ro", "Iat", "Exp"}
+
+// ClaimOption adds claims to a token on top of the fixed ones, ex: scopes or a handle read by
+// every request, so handlers don't look them up again
+type ClaimOption func(c *Claim) error
+
+// WithExtra adds the claim name with the JSON encoding of value
+func WithExtra(name string, value any) ClaimOption {
+	return func(c *Claim) error {
+		return c.SetExtra(name, value)
+	}
+}
+
+// WithExtras adds every field of v, a struct or a map, as a claim named after its JSON name
+func WithExtras(v any) ClaimOption {
+	return func(c *Claim) error {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return fmt.Errorf("extra claims must encode to a JSON object: %w", err)
+		}
+
+		for name, value := range fields {
+			if err := c.setRaw(name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// SetExtra sets the claim name to the JSON encoding of value, ErrReservedClaim for the names
+// of the fixed claims
+func (c *Claim) SetExtra(name string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.setRaw(name, raw)
+}
+
+func (c *Claim) setRaw(name string, raw json.RawMessage) error {
+	if name == "" || isFixedClaim(name) {
+		return fmt.Errorf("%w: %q", ErrReservedClaim, name)
+	}
+
+	if c.Ext == nil {
+		c.Ext = make(map[string]json.RawMessage)
+	}
+	c.Ext[name] = raw
+	return nil
+}
+
+// Extra decodes the claim name into dest, reporting whether the token carries it
+func (c *Claim) Extra(name string, dest any) (bool, error) {
+	raw, ok := c.Ext[name]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, dest)
+}
+
+// DecodeExtras decodes every extra claim into dest, a pointer to a struct or a map, ex: the
+// struct given to WithExtras when the token was issued
+func (c *Claim) DecodeExtras(dest any) error {
+	raw, err := json.Marshal(c.Ext)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+// marshal encodes the payload, the extra claims flattened next to the fixed ones
+func (c *Claim) marshal() ([]byte, error) {
+	payload, err := json.Marshal(c)
+	if err != nil || len(c.Ext) == 0 {
+		return payload, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range c.Ext {
+		fields[name] = value
+	}
+	return json.Marshal(fields)
+}
+
+// parseClaims decodes a payload, every name other than the fixed claims is kept in Ext
+func parseClaims(payload []byte) (*Claim, error) {
+	var claims Claim
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if !isFixedClaim(name) {
+			if claims.Ext == nil {
+				claims.Ext = make(map[string]json.RawMessage, len(fields))
+			}
+			claims.Ext[name] = value
+		}
+	}
+
+	return &claims, nil
+}
+
+func isFixedClaim(name string) bool {
+	for _, fixed := range fixedClaims {
+		if strings.EqualFold(name, fixed) {
+			return true
+		}
+	}
+	return false
+}
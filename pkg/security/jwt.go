@@ -16,18 +16,52 @@ var (
 	ErrInvalidToken     = errors.New("invalid token format")
 	ErrInvalidSignature = errors.New("invalid token signature")
 	ErrExpiredToken     = errors.New("token expired")
+	ErrUnknownKey       = errors.New("token signed with an unknown or retired key")
+)
+
+// SigningKey is the key new tokens are signed with, ID is written as the kid header.
+// Tokens signed before key ids have no kid, they match the key with an empty ID.
+type SigningKey struct {
+	ID     string
+	Secret string
+}
+
+// header is the JOSE header of the tokens
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Account roles, guests have none
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 )
 
 type Claim struct {
 	Sub  string
 	Aid  *string
 	Uid  *string
+	Org  *string // organization of the session, nil for the default tenant
 	Kind string
+	Role string  // role of the account, empty for guests and tokens issued before roles
+	Imp  *string // account of the admin impersonating the user, nil for the user's own tokens
+	Ro   bool    // writes are refused, set on impersonation tokens unless asked otherwise
 	Iat  int64
 	Exp  int64
+	// Ext holds the claims added with ClaimOptions, flattened next to the fixed ones in the
+	// payload. Read them with Extra or DecodeExtras.
+	Ext map[string]json.RawMessage `json:"-"`
+}
+
+// IsAdmin reports whether the token was issued to an admin account
+func (c *Claim) IsAdmin() bool {
+	return c.Role == RoleAdmin
 }
 
-func NewAccessToken(secret string, ttl time.Duration, sessionId string, kind string, accountId, userId *string) (token string, exp time.Time, err error) {
+// NewAccessToken returns the access token of a session, opts add claims on top of the fixed ones
+func NewAccessToken(key SigningKey, ttl time.Duration, sessionId string, kind, role string, accountId, userId, orgId *string, opts ...ClaimOption) (token string, exp time.Time, err error) {
 	now := time.Now()
 	exp = now.Add(ttl)
 
@@ -35,12 +69,35 @@ func NewAccessToken(secret string, ttl time.Duration, sessionId string, kind str
 		Sub:  sessionId,
 		Aid:  accountId,
 		Uid:  userId,
+		Org:  orgId,
 		Kind: kind,
+		Role: role,
 		Iat:  now.Unix(),
 		Exp:  exp.Unix(),
 	}
+	for _, opt := range opts {
+		if err := opt(&claims); err != nil {
+			return "", time.Time{}, err
+		}
+	}
 
-	token, err = signJWT(&claims, secret)
+	token, err = signJWT(&claims, key)
+	return token, exp, err
+}
+
+// NewImpersonationToken returns an access token acting as the user of claims on behalf of an
+// admin account. It has no refresh token, support signs in again once it expires. The extra
+// claims of the user are kept.
+func NewImpersonationToken(key SigningKey, ttl time.Duration, claims Claim, impersonatorId string, readOnly bool) (token string, exp time.Time, err error) {
+	now := time.Now()
+	exp = now.Add(ttl)
+
+	claims.Imp = &impersonatorId
+	claims.Ro = readOnly
+	claims.Iat = now.Unix()
+	claims.Exp = exp.Unix()
+
+	token, err = signJWT(&claims, key)
 	return token, exp, err
 }
 
@@ -53,12 +110,29 @@ func NewRefreshToken(nBytes int) (string, error) {
 	return hex.EncodeToString(hash[:]), nil
 }
 
-func VerifyJWT(token, secret string) (*Claim, error) {
+// VerifyJWT checks token against the key of its kid in keys, secrets by kid. Removing a key
+// from keys retires it, every token it signed is refused.
+func VerifyJWT(token string, keys map[string]string) (*Claim, error) {
 	parts := strings.Split(token, ".")
 	if len(parts) != 3 {
 		return nil, ErrInvalidToken
 	}
 
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var h header
+	if err := json.Unmarshal(headerBytes, &h); err != nil || h.Alg != "HS256" {
+		return nil, ErrInvalidToken
+	}
+
+	secret, ok := keys[h.Kid]
+	if !ok || secret == "" {
+		return nil, ErrUnknownKey
+	}
+
 	data := parts[0] + "." + parts[1]
 	expectedSig := signHMACSHA256(data, secret)
 
@@ -71,8 +145,8 @@ func VerifyJWT(token, secret string) (*Claim, error) {
 		return nil, ErrInvalidToken
 	}
 
-	var claims Claim
-	if err := json.Unmarshal(payloadBytes, &claims); err != nil || claims.Sub == "" {
+	claims, err := parseClaims(payloadBytes)
+	if err != nil || claims.Sub == "" {
 		return nil, ErrInvalidToken
 	}
 
@@ -80,20 +154,24 @@ func VerifyJWT(token, secret string) (*Claim, error) {
 		return nil, ErrExpiredToken
 	}
 
-	return &claims, nil
+	return claims, nil
 }
 
 // signJWT builds and signs a JWT string (header.payload.signature)
-func signJWT(claims *Claim, secret string) (string, error) {
-	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
-	payload, err := json.Marshal(claims)
+func signJWT(claims *Claim, key SigningKey) (string, error) {
+	headerJSON, err := json.Marshal(header{Alg: "HS256", Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := claims.marshal()
 	if err != nil {
 		return "", err
 	}
 
+	headerEnc := base64.RawURLEncoding.EncodeToString(headerJSON)
 	payloadEnc := base64.RawURLEncoding.EncodeToString(payload)
-	data := header + "." + payloadEnc
-	signature := signHMACSHA256(data, secret)
+	data := headerEnc + "." + payloadEnc
+	signature := signHMACSHA256(data, key.Secret)
 
 	return data + "." + signature, nil
 }
@@ -104,3 +182,18 @@ func signHMACSHA256(data, secret string) string {
 	h.Write([]byte(data))
 	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
 }
+
+// NewOpaqueToken returns a random token with a readable prefix, ex: swd_3f2a...
+func NewOpaqueToken(prefix string, nBytes int) (string, error) {
+	b := make([]byte, nBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the digest stored in place of a long lived opaque token
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
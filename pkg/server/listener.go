@@ -0,0 +1,77 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd socket activation
+const listenFDsStart = 3
+
+// listen creates the listener of the main server based on the configured network
+func (s *Server) listen() (net.Listener, error) {
+	switch s.config.Listen.Network {
+	case "unix":
+		return s.listenUnix()
+	case "systemd":
+		return listenSystemd()
+	case "", "tcp":
+		return net.Listen("tcp", s.getAddress())
+	default:
+		return nil, fmt.Errorf("unknown listen network %q", s.config.Listen.Network)
+	}
+}
+
+// listenUnix listens on a unix domain socket, replacing a stale socket file left by a previous run
+func (s *Server) listenUnix() (net.Listener, error) {
+	path := s.config.Listen.SocketPath
+	if path == "" {
+		return nil, fmt.Errorf("unix listener requires a socket path")
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, os.FileMode(s.config.Listen.SocketMode)); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to chmod socket: %w", err)
+	}
+
+	return ln, nil
+}
+
+// listenSystemd uses the first socket inherited through systemd socket activation (LISTEN_PID/LISTEN_FDS)
+func listenSystemd() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, fmt.Errorf("no sockets passed by systemd for this process")
+	}
+
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, fmt.Errorf("no sockets passed by systemd")
+	}
+
+	// Don't leak activation variables to child processes
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	file := os.NewFile(uintptr(listenFDsStart), "systemd-socket")
+	defer file.Close()
+
+	ln, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use systemd socket: %w", err)
+	}
+
+	return ln, nil
+}
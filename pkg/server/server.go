@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,17 +13,17 @@ import (
 	"time"
 
 	"github.com/rizkyharahap/swimo/config"
-	"github.com/rizkyharahap/swimo/database"
 	"github.com/rizkyharahap/swimo/pkg/logger"
 )
 
 // Server represents the HTTP server
 type Server struct {
 	server          *http.Server
+	redirectServer  *http.Server
 	log             *logger.Logger
 	config          config.HTTPConfig
 	shutdownTimeout time.Duration
-	dbManager       *database.Manager
+	shutdownHooks   []func(ctx context.Context) error
 }
 
 // NewServer creates a new HTTP server with the given configuration
@@ -30,10 +32,19 @@ func NewServer(cfg config.HTTPConfig, log *logger.Logger) *Server {
 		config:          cfg,
 		log:             log,
 		shutdownTimeout: 30 * time.Second, // Default shutdown timeout
-		dbManager:       database.NewManager(log),
 	}
 }
 
+// OnShutdown registers a cleanup run after the server stops accepting requests, on a shutdown
+// signal or when it fails, ex: closing the database pool. Hooks run one at a time in
+// registration order, so register what uses a resource before the resource. They share the
+// shutdown timeout: a hook still running when it expires is abandoned and the remaining hooks
+// are skipped.
+func (s *Server) OnShutdown(fn func(ctx context.Context) error) *Server {
+	s.shutdownHooks = append(s.shutdownHooks, fn)
+	return s
+}
+
 // WithHandler sets the main handler for the server
 func (s *Server) WithHandler(handler http.Handler) *Server {
 	s.server = &http.Server{
@@ -56,25 +67,63 @@ func (s *Server) Start() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if s.config.TLS.Enabled {
+		if err := s.setupTLS(); err != nil {
+			return s.abort(ctx, err)
+		}
+	}
+
 	// Channel for errors
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, 2)
+
+	// Create listeners upfront so bind errors are reported immediately
+	var ln net.Listener
+	if !s.config.Prefork || s.config.TLS.Enabled {
+		var err error
+		if ln, err = s.listen(); err != nil {
+			return s.abort(ctx, fmt.Errorf("failed to listen: %w", err))
+		}
+	}
+
+	// Start HTTP to HTTPS redirect listener, ex: :80 next to the API on :443
+	if s.redirectServer != nil {
+		redirectLn, err := net.Listen("tcp", s.redirectServer.Addr)
+		if err != nil {
+			ln.Close()
+			return s.abort(ctx, fmt.Errorf("failed to listen for HTTP redirect: %w", err))
+		}
+
+		go func() {
+			s.log.Info("Starting HTTP redirect server", "addr", s.redirectServer.Addr)
+
+			if err := s.redirectServer.Serve(redirectLn); err != nil && err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("redirect server error: %w", err)
+			}
+		}()
+	}
 
 	// Start server in goroutine
 	go func() {
 		s.log.Info("Starting HTTP server",
+			"network", s.config.Listen.Network,
 			"host", s.config.Host,
 			"port", s.config.Port,
+			"socket", s.config.Listen.SocketPath,
 			"read_timeout", s.config.ReadTimeout,
 			"write_timeout", s.config.WriteTimeout,
 			"idle_timeout", s.config.IdleTimeout,
 			"prefork", s.config.Prefork,
+			"tls", s.config.TLS.Enabled,
 		)
 
 		var err error
-		if s.config.Prefork {
+		if s.config.TLS.Enabled {
+			// Certificates are provided through TLSConfig
+			err = s.server.ServeTLS(ln, "", "")
+		} else if s.config.Prefork {
 			err = s.startWithPrefork()
 		} else {
-			err = s.server.ListenAndServe()
+			err = s.server.Serve(ln)
 		}
 
 		if err != nil && err != http.ErrServerClosed {
@@ -89,13 +138,20 @@ func (s *Server) Start() error {
 	// Wait for either error or shutdown signal
 	select {
 	case err := <-serverErrors:
-		return err
+		return s.abort(ctx, err)
 	case <-shutdown:
 		s.log.Info("Shutdown signal received, starting graceful shutdown")
 		return s.gracefulShutdown(ctx)
 	}
 }
 
+// abort shuts down after Start failed with err, the hooks run as on a shutdown signal so queued
+// work is drained and resources released whichever way the server stops
+func (s *Server) abort(ctx context.Context, err error) error {
+	s.log.Error("Server failed, shutting down", "error", err)
+	return errors.Join(err, s.gracefulShutdown(ctx))
+}
+
 // gracefulShutdown performs graceful shutdown of the server
 func (s *Server) gracefulShutdown(ctx context.Context) error {
 	// Create context with timeout
@@ -104,26 +160,60 @@ func (s *Server) gracefulShutdown(ctx context.Context) error {
 
 	s.log.Info("Shutting down server...", "timeout", s.shutdownTimeout)
 
-	// Shutdown the server
+	// Drain both listeners at once, a slow API request must not keep the redirect open
+	var errs []error
+	redirectDone := make(chan error, 1)
+	if s.redirectServer != nil {
+		go func() { redirectDone <- s.redirectServer.Shutdown(shutdownCtx) }()
+	} else {
+		redirectDone <- nil
+	}
+
 	if err := s.server.Shutdown(shutdownCtx); err != nil {
 		s.log.Error("Server shutdown failed", "error", err)
-		return fmt.Errorf("server shutdown failed: %w", err)
+		errs = append(errs, fmt.Errorf("server shutdown failed: %w", err))
+	}
+	if err := <-redirectDone; err != nil {
+		s.log.Error("Redirect server shutdown failed", "error", err)
+		errs = append(errs, fmt.Errorf("redirect server shutdown failed: %w", err))
 	}
 
-	// Close database connections
-	if s.dbManager != nil {
-		s.log.Info("Closing database connections...")
-		if err := s.dbManager.CloseAll(); err != nil {
-			s.log.Error("Failed to close database connections", "error", err)
-			return fmt.Errorf("database shutdown failed: %w", err)
-		}
-		s.log.Info("Database connections closed successfully")
+	// Release what the application registered, ex: the database pool, even when draining failed
+	if err := s.runShutdownHooks(shutdownCtx); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
 
 	s.log.Info("Server shutdown completed successfully")
 	return nil
 }
 
+// runShutdownHooks runs the registered hooks in order until they are done or ctx expires.
+// A failing hook doesn't stop the others, every failure is returned.
+func (s *Server) runShutdownHooks(ctx context.Context) error {
+	var errs []error
+
+	for i, hook := range s.shutdownHooks {
+		done := make(chan error, 1)
+		go func() { done <- hook(ctx) }()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				s.log.Error("Shutdown hook failed", "hook", i, "error", err)
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			s.log.Error("Shutdown timed out, skipping remaining hooks", "hook", i, "skipped", len(s.shutdownHooks)-i-1)
+			return errors.Join(append(errs, fmt.Errorf("shutdown hook %d: %w", i, ctx.Err()))...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Stop stops the server gracefully
 func (s *Server) Stop() error {
 	if s.server == nil {
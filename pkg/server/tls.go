@@ -0,0 +1,80 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// setupTLS prepares the TLS configuration of the main server and, when enabled,
+// the plain HTTP listener redirecting to HTTPS (and answering ACME challenges)
+func (s *Server) setupTLS() error {
+	cfg := s.config.TLS
+
+	var challengeHandler func(http.Handler) http.Handler
+
+	switch {
+	case cfg.AutoCert:
+		if len(cfg.Domains) == 0 {
+			return fmt.Errorf("autocert requires at least one domain")
+		}
+
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.Domains...),
+			Cache:      autocert.DirCache(cfg.CacheDir),
+			Email:      cfg.Email,
+		}
+
+		s.server.TLSConfig = manager.TLSConfig()
+		challengeHandler = manager.HTTPHandler
+
+	case cfg.CertFile != "" && cfg.KeyFile != "":
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load tls certificate: %w", err)
+		}
+
+		s.server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	default:
+		return fmt.Errorf("tls enabled but neither cert/key files nor autocert configured")
+	}
+
+	s.server.TLSConfig.MinVersion = tls.VersionTLS12
+
+	if cfg.RedirectHTTP {
+		var handler http.Handler = http.HandlerFunc(s.redirectToHTTPS)
+		if challengeHandler != nil {
+			handler = challengeHandler(handler)
+		}
+
+		s.redirectServer = &http.Server{
+			Addr:         net.JoinHostPort(s.config.Host, strconv.Itoa(cfg.RedirectPort)),
+			Handler:      handler,
+			ReadTimeout:  s.config.ReadTimeout,
+			WriteTimeout: s.config.WriteTimeout,
+			IdleTimeout:  s.config.IdleTimeout,
+		}
+	}
+
+	return nil
+}
+
+// redirectToHTTPS permanently redirects plain HTTP requests to the HTTPS listener
+func (s *Server) redirectToHTTPS(w http.ResponseWriter, r *http.Request) {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+
+	if s.config.Port != 443 {
+		host = net.JoinHostPort(host, strconv.Itoa(s.config.Port))
+	}
+
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+}
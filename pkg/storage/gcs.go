@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+	"github.com/rizkyharahap/swimo/pkg/gcpauth"
+)
+
+const (
+	gcsHost       = "storage.googleapis.com"
+	gcsObjectURL  = "https://storage.googleapis.com/storage/v1/b/%s/o/%s"
+	gcsRewriteURL = "https://storage.googleapis.com/storage/v1/b/%[1]s/o/%[2]s/rewriteTo/b/%[1]s/o/%[3]s"
+	gcsUploadURL  = "https://storage.googleapis.com/upload/storage/v1/b/%s/o?uploadType=%s&name=%s"
+	gcsSignURL    = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:signBlob"
+)
+
+// GCS stores files in a Google Cloud Storage bucket over the JSON API, authenticated with
+// the workload service account. Signed links are signed by the IAM credentials API, the
+// service account needs the Service Account Token Creator role on itself.
+type GCS struct {
+	bucket   string
+	partSize int64
+	client   *http.Client
+	metadata *gcpauth.Metadata
+}
+
+func NewGCS(cfg config.StorageConfig) *GCS {
+	// Uploads are bounded by their context, not a client timeout
+	return &GCS{
+		bucket:   cfg.Bucket,
+		partSize: cfg.PartSize,
+		client:   &http.Client{},
+		metadata: gcpauth.NewMetadata(&http.Client{Timeout: 10 * time.Second}),
+	}
+}
+
+// Put sends bodies up to one part with a single request, larger ones as a resumable upload
+func (s *GCS) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if _, err := CleanKey(key); err != nil {
+		return err
+	}
+
+	buf := make([]byte, s.partSize)
+	n, err := io.ReadFull(r, buf)
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		endpoint := fmt.Sprintf(gcsUploadURL, s.bucket, "media", url.QueryEscape(key))
+		res, err := s.do(ctx, http.MethodPost, endpoint, bytes.NewReader(buf[:n]), map[string]string{"Content-Type": contentType})
+		if err != nil {
+			return err
+		}
+		return s.check(res, key, http.StatusOK)
+	case err != nil:
+		return err
+	}
+
+	session, err := s.startResumable(ctx, key, contentType)
+	if err != nil {
+		return err
+	}
+
+	// Every chunk but the last must be a multiple of 256KiB, part sizes are whole MBs
+	var offset int64
+	for {
+		last := n < len(buf)
+		total := "*"
+		if last {
+			total = strconv.FormatInt(offset+int64(n), 10)
+		}
+
+		contentRange := fmt.Sprintf("bytes %d-%d/%s", offset, offset+int64(n)-1, total)
+		if n == 0 {
+			contentRange = "bytes */" + total
+		}
+
+		res, err := s.do(ctx, http.MethodPut, session, bytes.NewReader(buf[:n]), map[string]string{"Content-Range": contentRange})
+		if err != nil {
+			return err
+		}
+
+		if last {
+			return s.check(res, key, http.StatusOK, http.StatusCreated)
+		}
+		if err := s.check(res, key, http.StatusPermanentRedirect); err != nil {
+			return err
+		}
+
+		offset += int64(n)
+		n, err = io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+	}
+}
+
+// startResumable opens a resumable upload session and returns its URL
+func (s *GCS) startResumable(ctx context.Context, key, contentType string) (string, error) {
+	endpoint := fmt.Sprintf(gcsUploadURL, s.bucket, "resumable", url.QueryEscape(key))
+	res, err := s.do(ctx, http.MethodPost, endpoint, nil, map[string]string{"X-Upload-Content-Type": contentType})
+	if err != nil {
+		return "", err
+	}
+
+	session := res.Header.Get("Location")
+	if err := s.check(res, key, http.StatusOK); err != nil {
+		return "", err
+	}
+	if session == "" {
+		return "", fmt.Errorf("gcs %s: resumable upload without session", key)
+	}
+	return session, nil
+}
+
+func (s *GCS) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	res, err := s.do(ctx, http.MethodGet, s.objectURL(key)+"?alt=media", nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, s.check(res, key, http.StatusOK)
+	}
+
+	return res.Body, &Object{Key: key, ContentType: res.Header.Get("Content-Type"), Size: res.ContentLength}, nil
+}
+
+// Copy rewrites src to dst, large objects may take several calls which resume with a token
+func (s *GCS) Copy(ctx context.Context, src, dst string) error {
+	if _, err := CleanKey(dst); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf(gcsRewriteURL, s.bucket, url.PathEscape(src), url.PathEscape(dst))
+	token := ""
+	for {
+		call := endpoint
+		if token != "" {
+			call += "?rewriteToken=" + url.QueryEscape(token)
+		}
+
+		res, err := s.do(ctx, http.MethodPost, call, nil, nil)
+		if err != nil {
+			return err
+		}
+		if res.StatusCode != http.StatusOK {
+			return s.check(res, src, http.StatusOK)
+		}
+
+		var rewrite struct {
+			Done         bool   `json:"done"`
+			RewriteToken string `json:"rewriteToken"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&rewrite)
+		res.Body.Close()
+		if err != nil {
+			return fmt.Errorf("gcs rewrite %s: invalid response: %w", src, err)
+		}
+
+		if rewrite.Done {
+			return nil
+		}
+		token = rewrite.RewriteToken
+	}
+}
+
+func (s *GCS) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, s.objectURL(key), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.check(res, key, http.StatusNoContent); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// SignURL builds a V4 signed link, see https://cloud.google.com/storage/docs/access-control/signing-urls-manually
+func (s *GCS) SignURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	email, err := s.metadata.Email(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	timestamp := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/auto/storage/goog4_request"
+
+	query := url.Values{}
+	query.Set("X-Goog-Algorithm", "GOOG4-RSA-SHA256")
+	query.Set("X-Goog-Credential", email+"/"+scope)
+	query.Set("X-Goog-Date", timestamp)
+	query.Set("X-Goog-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Goog-SignedHeaders", "host")
+
+	path := "/" + s.bucket + "/" + escapeKey(key)
+	canonical := strings.Join([]string{http.MethodGet, path, query.Encode(), "host:" + gcsHost + "\n", "host", "UNSIGNED-PAYLOAD"}, "\n")
+	digest := sha256.Sum256([]byte(canonical))
+
+	signature, err := s.signBlob(ctx, email, strings.Join([]string{"GOOG4-RSA-SHA256", timestamp, scope, hex.EncodeToString(digest[:])}, "\n"))
+	if err != nil {
+		return "", err
+	}
+	query.Set("X-Goog-Signature", hex.EncodeToString(signature))
+
+	return "https://" + gcsHost + path + "?" + query.Encode(), nil
+}
+
+// signBlob signs payload with the service account key held by Google
+func (s *GCS) signBlob(ctx context.Context, email, payload string) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{"payload": base64.StdEncoding.EncodeToString([]byte(payload))})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(ctx, http.MethodPost, fmt.Sprintf(gcsSignURL, url.PathEscape(email)), bytes.NewReader(body), map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gcs sign blob: unexpected status %d", res.StatusCode)
+	}
+
+	var signed struct {
+		SignedBlob string `json:"signedBlob"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&signed); err != nil {
+		return nil, fmt.Errorf("gcs sign blob: invalid response: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(signed.SignedBlob)
+}
+
+func (s *GCS) do(ctx context.Context, method, endpoint string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	token, err := s.metadata.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gcs request failed: %w", err)
+	}
+	return res, nil
+}
+
+// check closes res and turns an unexpected status into an error
+func (s *GCS) check(res *http.Response, key string, expected ...int) error {
+	defer res.Body.Close()
+
+	for _, status := range expected {
+		if res.StatusCode == status {
+			return nil
+		}
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return fmt.Errorf("gcs %s: unexpected status %d", key, res.StatusCode)
+}
+
+func (s *GCS) objectURL(key string) string {
+	return fmt.Sprintf(gcsObjectURL, s.bucket, url.PathEscape(key))
+}
+
+// escapeKey percent encodes every segment of key, keeping the slashes
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/rizkyharahap/swimo/config"
+)
+
+// S3 stores files in an S3 bucket, or any S3 compatible server through Endpoint,
+// using the default AWS credential chain
+type S3 struct {
+	client   *s3.Client
+	presign  *s3.PresignClient
+	bucket   string
+	partSize int64
+}
+
+func NewS3(ctx context.Context, cfg config.StorageConfig) (*S3, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.PathStyle
+	})
+
+	return &S3{client: client, presign: s3.NewPresignClient(client), bucket: cfg.Bucket, partSize: cfg.PartSize}, nil
+}
+
+// Put sends bodies up to one part with a single request, larger ones as a multipart upload
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if _, err := CleanKey(key); err != nil {
+		return err
+	}
+
+	part := make([]byte, s.partSize)
+	n, err := io.ReadFull(r, part)
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(s.bucket),
+			Key:         aws.String(key),
+			Body:        bytes.NewReader(part[:n]),
+			ContentType: aws.String(contentType),
+		})
+		return err
+	case err != nil:
+		return err
+	}
+
+	return s.putMultipart(ctx, key, io.MultiReader(bytes.NewReader(part), r), contentType)
+}
+
+func (s *S3) putMultipart(ctx context.Context, key string, r io.Reader, contentType string) error {
+	upload, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return err
+	}
+
+	parts, err := s.uploadParts(ctx, key, upload.UploadId, r)
+	if err != nil {
+		// Parts of an aborted upload are not billed, a detached context aborts canceled uploads too
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+
+		s.client.AbortMultipartUpload(abortCtx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(s.bucket),
+			Key:      aws.String(key),
+			UploadId: upload.UploadId,
+		})
+		return err
+	}
+
+	_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	return err
+}
+
+// uploadParts sends r one part at a time, only one part is held in memory
+func (s *S3) uploadParts(ctx context.Context, key string, uploadId *string, r io.Reader) ([]types.CompletedPart, error) {
+	var parts []types.CompletedPart
+	buf := make([]byte, s.partSize)
+
+	for number := int32(1); ; number++ {
+		n, err := io.ReadFull(r, buf)
+		if n == 0 && errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+
+		out, uploadErr := s.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(s.bucket),
+			Key:        aws.String(key),
+			UploadId:   uploadId,
+			PartNumber: aws.Int32(number),
+			Body:       bytes.NewReader(buf[:n]),
+		})
+		if uploadErr != nil {
+			return nil, uploadErr
+		}
+
+		parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(number)})
+
+		// A short read is the last part
+		if err != nil {
+			return parts, nil
+		}
+	}
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	if err != nil {
+		var noKey *types.NoSuchKey
+		if errors.As(err, &noKey) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	return out.Body, &Object{Key: key, ContentType: aws.ToString(out.ContentType), Size: aws.ToInt64(out.ContentLength)}, nil
+}
+
+func (s *S3) SignURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
+// Copy is a single server side copy, S3 limits it to objects up to 5GB
+func (s *S3) Copy(ctx context.Context, src, dst string) error {
+	if _, err := CleanKey(dst); err != nil {
+		return err
+	}
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(s.bucket),
+		CopySource: aws.String(s.bucket + "/" + escapeKey(src)),
+		Key:        aws.String(dst),
+	})
+	if err != nil {
+		var noKey *types.NoSuchKey
+		if errors.As(err, &noKey) {
+			return ErrNotFound
+		}
+	}
+	return err
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	return err
+}
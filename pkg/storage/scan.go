@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/rizkyharahap/swimo/pkg/logger"
+	"github.com/rizkyharahap/swimo/pkg/scanner"
+)
+
+// QuarantinePrefix holds uploads while they are scanned, and keeps rejected ones for review
+const QuarantinePrefix = "quarantine/"
+
+var ErrUploadsDisabled = errors.New("uploads disabled without malware scanner")
+
+// scanned scans every Put under prefixes before the file becomes reachable at its key
+type scanned struct {
+	Storage
+	scanner  scanner.Scanner
+	prefixes []string
+}
+
+// WithScanner returns st scanning user uploads, the keys starting with one of prefixes.
+// Uploads are written to the quarantine while streamed to the scanner, and copied to their
+// key once clean. With a nil scanner those uploads are refused.
+func WithScanner(st Storage, sc scanner.Scanner, prefixes ...string) Storage {
+	return &scanned{Storage: st, scanner: sc, prefixes: prefixes}
+}
+
+func (s *scanned) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if !s.scans(key) {
+		return s.Storage.Put(ctx, key, r, contentType)
+	}
+	if s.scanner == nil {
+		return ErrUploadsDisabled
+	}
+
+	quarantined := QuarantinePrefix + key
+	result, err := s.putScanned(ctx, quarantined, r, contentType)
+	if err != nil {
+		s.discard(ctx, quarantined)
+		return err
+	}
+
+	if result.Infected {
+		logger.FromContext(ctx).Warn("Upload quarantined by malware scan", "key", quarantined, "signature", result.Signature)
+		return scanner.ErrInfected
+	}
+
+	if err := s.Storage.Copy(ctx, quarantined, key); err != nil {
+		s.discard(ctx, quarantined)
+		return err
+	}
+	s.discard(ctx, quarantined)
+
+	return nil
+}
+
+// putScanned uploads r to key while the scanner reads the same bytes
+func (s *scanned) putScanned(ctx context.Context, key string, r io.Reader, contentType string) (*scanner.Result, error) {
+	pr, pw := io.Pipe()
+
+	type verdict struct {
+		result *scanner.Result
+		err    error
+	}
+	done := make(chan verdict, 1)
+
+	go func() {
+		result, err := s.scanner.Scan(ctx, pr)
+		// The scanner may stop at a verdict, the upload still has to go through
+		io.Copy(io.Discard, pr)
+		done <- verdict{result, err}
+	}()
+
+	err := s.Storage.Put(ctx, key, io.TeeReader(r, pw), contentType)
+	pw.CloseWithError(err)
+
+	v := <-done
+	if err != nil {
+		return nil, err
+	}
+	return v.result, v.err
+}
+
+// discard removes a quarantined upload, failures only leave it for manual cleanup
+func (s *scanned) discard(ctx context.Context, key string) {
+	if err := s.Storage.Delete(context.WithoutCancel(ctx), key); err != nil {
+		logger.FromContext(ctx).Warn("failed to delete quarantined upload", "key", key, "error", err)
+	}
+}
+
+func (s *scanned) scans(key string) bool {
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+// Package storage stores files (avatars, training media, exports) in a local directory,
+// S3 or Google Cloud Storage behind one interface. Keys are slash separated paths,
+// ex: trainings/8c4a2d27-56e2-4ef3-8a6e-43b812345abc/video-1a2b3c.mp4
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+// MediaPath is the API path serving stored files, see MediaURL
+const MediaPath = "/api/v1/media/"
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+	ErrTooLarge   = errors.New("file too large")
+)
+
+// Storage is implemented by every driver
+type Storage interface {
+	// Put streams r to key. Bodies larger than the configured part size are uploaded in
+	// parts, so large videos never have to fit in memory.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get opens the object at key, the caller closes the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// SignURL returns a link downloading key without credentials until ttl elapses
+	SignURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// Copy duplicates src to dst inside the backend, without downloading it
+	Copy(ctx context.Context, src, dst string) error
+	// Delete removes key, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Object describes a stored file
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
+
+// New creates the storage of the driver selected in config.
+// baseURL is the public URL of the API, local signed links point to it.
+func New(ctx context.Context, cfg config.StorageConfig, baseURL string) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocal(cfg.LocalDir, baseURL, cfg.SigningKey)
+	case "s3":
+		return NewS3(ctx, cfg)
+	case "gcs":
+		return NewGCS(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// MediaURL returns the stable API link of key. Fetching it redirects to a short lived
+// signed link, so it can be stored in the database and shown to clients as is.
+func MediaURL(baseURL, key string) string {
+	return baseURL + MediaPath + (&url.URL{Path: key}).EscapedPath()
+}
+
+// LimitReader reads from r until more than limit bytes arrive, then fails with ErrTooLarge
+func LimitReader(r io.Reader, limit int64) io.Reader {
+	return &limitedReader{r: r, left: limit}
+}
+
+type limitedReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.left < 0 {
+		return 0, ErrTooLarge
+	}
+
+	// One extra byte tells a body of exactly limit bytes from a larger one
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+// CleanKey rejects keys escaping their prefix or addressing a directory
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return key, nil
+}
@@ -0,0 +1,26 @@
+// Package tenant carries the organization (swim school) a request belongs to.
+// Requests without a tenant use the default one, the rows without organization.
+package tenant
+
+import "context"
+
+type ctxKey struct{}
+
+// WithID returns a copy of ctx scoped to the organization id
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the organization id of ctx, empty for the default tenant
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// ID returns the organization id of ctx as a query argument, nil for the default tenant
+func ID(ctx context.Context) *string {
+	if id := FromContext(ctx); id != "" {
+		return &id
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Struct validates a pointer to a struct against its `validate` tags and returns nil when valid.
+// String fields are trimmed in place first. Errors are keyed by the json (or query) field name,
+// nested structs and slices of structs are validated too, ex: "laps[2].distanceMeters".
+//
+// Supported rules, comma separated:
+//
+//	required        value must not be empty or zero
+//	min=N, max=N    string length, slice length or numeric value bounds
+//	gt=N            numeric value strictly greater than N, ex: gt=0 for positive numbers
+//	oneof=a b c     value must be one of the space separated options
+//	email, url      format checks, skipped for empty values
+//	uuid            canonical UUID, skipped for empty values
+//	phone           E.164 phone number, skipped for empty values
+//	date            date only, ex: 2025-09-21, skipped for empty values
+//	lower           lowercases the string in place before checking
+//	eqfield=Field   value must equal the sibling Go field, ex: eqfield=Password
+func Struct(v any) *ValidationError {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		panic("validator: Struct expects a pointer to a struct")
+	}
+
+	errors := make(map[string]string)
+	validateStruct(rv.Elem(), "", errors)
+
+	if len(errors) > 0 {
+		return &ValidationError{Errors: errors}
+	}
+	return nil
+}
+
+// rule is one parsed tag entry, ex: max=50
+type rule struct {
+	name  string
+	param string
+}
+
+// fieldSpec is the parsed validation metadata of a struct field
+type fieldSpec struct {
+	index int
+	key   string // error key, the json/query name
+	label string // human name used in messages
+	rules []rule
+}
+
+var specCache sync.Map // reflect.Type -> []fieldSpec
+
+func specsOf(t reflect.Type) []fieldSpec {
+	if cached, ok := specCache.Load(t); ok {
+		return cached.([]fieldSpec)
+	}
+
+	var specs []fieldSpec
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		spec := fieldSpec{index: i, key: fieldKey(f)}
+		spec.label = humanize(spec.key)
+
+		if tag := f.Tag.Get("validate"); tag != "" && tag != "-" {
+			for _, part := range strings.Split(tag, ",") {
+				name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+				spec.rules = append(spec.rules, rule{name: name, param: param})
+			}
+		}
+
+		specs = append(specs, spec)
+	}
+
+	specCache.Store(t, specs)
+	return specs
+}
+
+func validateStruct(sv reflect.Value, prefix string, errors map[string]string) {
+	for _, spec := range specsOf(sv.Type()) {
+		fv := sv.Field(spec.index)
+		key := spec.key
+		if prefix != "" {
+			key = prefix + "." + spec.key
+		}
+
+		// Embedded structs (ex: pagination params) share the parent's namespace
+		if sv.Type().Field(spec.index).Anonymous && fv.Kind() == reflect.Struct {
+			validateStruct(fv, prefix, errors)
+			continue
+		}
+
+		if fv.Kind() == reflect.String && fv.CanSet() {
+			fv.SetString(strings.TrimSpace(fv.String()))
+		}
+
+		if msg := checkField(sv, fv, spec); msg != "" {
+			errors[key] = msg
+			continue
+		}
+
+		validateNested(fv, key, errors)
+	}
+}
+
+// validateNested descends into struct, *struct and []struct values
+func validateNested(fv reflect.Value, key string, errors map[string]string) {
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if !fv.IsNil() {
+			validateNested(fv.Elem(), key, errors)
+		}
+	case reflect.Struct:
+		if fv.Type() != reflect.TypeOf(time.Time{}) {
+			validateStruct(fv, key, errors)
+		}
+	case reflect.Slice:
+		for i := 0; i < fv.Len(); i++ {
+			validateNested(fv.Index(i), fmt.Sprintf("%s[%d]", key, i), errors)
+		}
+	}
+}
+
+// checkField applies the rules of one field and returns the first failure message
+func checkField(parent, fv reflect.Value, spec fieldSpec) string {
+	// Optional pointers are only checked when set
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			for _, r := range spec.rules {
+				if r.name == "required" {
+					return spec.label + " is required"
+				}
+			}
+			return ""
+		}
+		fv = fv.Elem()
+	}
+
+	for _, r := range spec.rules {
+		switch r.name {
+		case "required":
+			if fv.IsZero() || (fv.Kind() == reflect.Slice && fv.Len() == 0) {
+				return spec.label + " is required"
+			}
+
+		case "lower":
+			if fv.Kind() == reflect.String && fv.CanSet() {
+				fv.SetString(strings.ToLower(fv.String()))
+			}
+
+		case "min", "max", "gt":
+			if msg := checkBound(fv, spec.label, r); msg != "" {
+				return msg
+			}
+
+		case "oneof":
+			options := strings.Fields(r.param)
+			value := fmt.Sprint(fv.Interface())
+			if !fv.IsZero() && !contains(options, value) {
+				return fmt.Sprintf("%s must be one of: %s", spec.label, strings.Join(options, ", "))
+			}
+
+		case "email":
+			if fv.String() != "" && !IsValidEmail(fv.String()) {
+				return spec.label + " is not a valid format"
+			}
+
+		case "url":
+			if fv.String() != "" && !IsValidURL(fv.String()) {
+				return spec.label + " is not a valid URL"
+			}
+
+		case "uuid":
+			if fv.String() != "" && !IsValidUUID(fv.String()) {
+				return spec.label + " is not a valid ID"
+			}
+
+		case "phone":
+			if fv.String() != "" && !IsValidPhone(fv.String()) {
+				return spec.label + " must be an international phone number, ex: +6281234567890"
+			}
+
+		case "date":
+			if fv.String() != "" && !IsValidDate(fv.String()) {
+				return spec.label + " must be a date (YYYY-MM-DD)"
+			}
+
+		case "eqfield":
+			other := parent.FieldByName(r.param)
+			if other.IsValid() && !fv.IsZero() && !reflect.DeepEqual(fv.Interface(), other.Interface()) {
+				return fmt.Sprintf("%s does not match %s", spec.label, strings.ToLower(humanize(r.param)))
+			}
+		}
+	}
+
+	return ""
+}
+
+// checkBound applies min, max and gt to lengths of strings and slices or to numbers
+func checkBound(fv reflect.Value, label string, r rule) string {
+	limit, err := strconv.ParseFloat(r.param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validator: invalid %s parameter %q", r.name, r.param))
+	}
+
+	var (
+		value float64
+		unit  string
+	)
+
+	switch fv.Kind() {
+	case reflect.String:
+		value, unit = float64(len([]rune(fv.String()))), " characters"
+	case reflect.Slice, reflect.Map:
+		value, unit = float64(fv.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = float64(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = float64(fv.Uint())
+	case reflect.Float32, reflect.Float64:
+		value = fv.Float()
+	default:
+		return ""
+	}
+
+	// Length rules don't apply to empty strings, required covers them
+	if fv.Kind() == reflect.String && value == 0 {
+		return ""
+	}
+
+	switch {
+	case r.name == "min" && value < limit:
+		return fmt.Sprintf("%s must be at least %s%s", label, r.param, unit)
+	case r.name == "max" && value > limit:
+		return fmt.Sprintf("%s must not exceed %s%s", label, r.param, unit)
+	case r.name == "gt" && value <= limit:
+		if limit == 0 {
+			return label + " must be a positive number"
+		}
+		return fmt.Sprintf("%s must be greater than %s", label, r.param)
+	}
+
+	return ""
+}
+
+// fieldKey returns the name a client uses for the field
+func fieldKey(f reflect.StructField) string {
+	for _, tag := range []string{"json", "query"} {
+		if name, _, _ := strings.Cut(f.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// humanize turns a camelCase name into a sentence case label, ex: confirmPassword -> Confirm password
+func humanize(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		switch {
+		case i == 0:
+			sb.WriteRune(unicode.ToUpper(r))
+		case unicode.IsUpper(r):
+			sb.WriteRune(' ')
+			sb.WriteRune(unicode.ToLower(r))
+		case r == '_':
+			sb.WriteRune(' ')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func contains(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,23 @@
 package validator
 
 import (
+	"fmt"
 	"net/mail"
 	"net/url"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 )
 
+var (
+	uuidRegex  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+)
+
+// dateLayout is the date only format accepted by ParseDateRange and the date rule, ex: 2025-09-21
+const dateLayout = time.DateOnly
+
 // ValidationError is a custom error type to hold multiple validation messages.
 type ValidationError struct {
 	Errors map[string]string
@@ -32,3 +44,87 @@ func IsValidURL(s string) bool {
 	_, err := url.ParseRequestURI(s)
 	return err == nil
 }
+
+// IsValidUUID reports whether s is a canonical hyphenated UUID
+func IsValidUUID(s string) bool {
+	return uuidRegex.MatchString(s)
+}
+
+// IsValidPhone reports whether s is an E.164 phone number, ex: +6281234567890
+func IsValidPhone(s string) bool {
+	return phoneRegex.MatchString(s)
+}
+
+// IsValidDate reports whether s is a date only, ex: 2025-09-21
+func IsValidDate(s string) bool {
+	_, err := time.Parse(dateLayout, s)
+	return err == nil
+}
+
+// IsOneOf reports whether v is one of the allowed values
+func IsOneOf[T comparable](v T, allowed ...T) bool {
+	return slices.Contains(allowed, v)
+}
+
+// DateRange is an inclusive range of instants, either bound may be zero for an open range
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// IsValidDateRange reports whether from is not after to, open bounds are always valid
+func IsValidDateRange(from, to time.Time) bool {
+	return from.IsZero() || to.IsZero() || !from.After(to)
+}
+
+// ParseDateRange parses optional from and to query values as RFC 3339 timestamps or dates.
+// A date only "to" covers the whole day. maxSpan limits the range length, 0 for no limit.
+func ParseDateRange(from, to string, maxSpan time.Duration) (DateRange, *ValidationError) {
+	var r DateRange
+	errors := make(map[string]string)
+
+	if from != "" {
+		t, err := parseDate(from, false)
+		if err != nil {
+			errors["from"] = "From must be a date (YYYY-MM-DD) or RFC 3339 timestamp"
+		}
+		r.From = t
+	}
+
+	if to != "" {
+		t, err := parseDate(to, true)
+		if err != nil {
+			errors["to"] = "To must be a date (YYYY-MM-DD) or RFC 3339 timestamp"
+		}
+		r.To = t
+	}
+
+	if len(errors) == 0 {
+		if !IsValidDateRange(r.From, r.To) {
+			errors["to"] = "To must not be before from"
+		} else if maxSpan > 0 && !r.From.IsZero() && !r.To.IsZero() && r.To.Sub(r.From) > maxSpan {
+			errors["to"] = fmt.Sprintf("Date range must not exceed %d days", int(maxSpan.Hours()/24))
+		}
+	}
+
+	if len(errors) > 0 {
+		return DateRange{}, &ValidationError{Errors: errors}
+	}
+	return r, nil
+}
+
+// parseDate accepts RFC 3339 or a UTC date, moved to the end of the day when endOfDay is set
+func parseDate(s string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
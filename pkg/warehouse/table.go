@@ -0,0 +1,148 @@
+package warehouse
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Supported file formats
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// TableWriter writes rows of one dataset to a file. Rows are structs whose `parquet`
+// tags name the columns, the same tags give the CSV header.
+type TableWriter[T any] interface {
+	Write(rows ...T) error
+	// Close flushes buffered rows and the file footer, it does not close the output
+	Close() error
+}
+
+// NewTableWriter creates a writer for the format, csv or parquet
+func NewTableWriter[T any](w io.Writer, format string) (TableWriter[T], error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter[T](w), nil
+	case FormatParquet:
+		return &parquetWriter[T]{w: parquet.NewGenericWriter[T](w, parquet.Compression(&parquet.Zstd))}, nil
+	default:
+		return nil, fmt.Errorf("unknown warehouse format %q", format)
+	}
+}
+
+// Extension returns the file extension of a format
+func Extension(format string) string {
+	return "." + format
+}
+
+// ContentType returns the media type of a format
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv"
+	}
+	return "application/vnd.apache.parquet"
+}
+
+type parquetWriter[T any] struct {
+	w *parquet.GenericWriter[T]
+}
+
+func (p *parquetWriter[T]) Write(rows ...T) error {
+	_, err := p.w.Write(rows)
+	return err
+}
+
+func (p *parquetWriter[T]) Close() error {
+	return p.w.Close()
+}
+
+// csvWriter writes the header on the first call and one record per row
+type csvWriter[T any] struct {
+	w       *csv.Writer
+	fields  []int
+	header  []string
+	started bool
+}
+
+func newCSVWriter[T any](w io.Writer) *csvWriter[T] {
+	c := &csvWriter[T]{w: csv.NewWriter(w)}
+
+	t := reflect.TypeFor[T]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("parquet"), ",")
+		if name == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		if name == "" {
+			name = t.Field(i).Name
+		}
+
+		c.fields = append(c.fields, i)
+		c.header = append(c.header, name)
+	}
+
+	return c
+}
+
+func (c *csvWriter[T]) Write(rows ...T) error {
+	if !c.started {
+		c.started = true
+		if err := c.w.Write(c.header); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(c.fields))
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i, field := range c.fields {
+			record[i] = formatValue(v.Field(field))
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *csvWriter[T]) Close() error {
+	// An empty dataset still gets its header, readers infer the columns from it
+	if !c.started {
+		if err := c.w.Write(c.header); err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// formatValue renders a column value, nil pointers are empty cells
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,76 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenMeteo reads the hourly forecast and history of the Open-Meteo marine API, ex:
+// https://marine-api.open-meteo.com/v1/marine. It needs no key.
+type OpenMeteo struct {
+	url    string
+	client *http.Client
+}
+
+func NewOpenMeteo(baseURL string, timeout time.Duration) *OpenMeteo {
+	return &OpenMeteo{url: strings.TrimSuffix(baseURL, "/") + "/v1/marine", client: &http.Client{Timeout: timeout}}
+}
+
+func (o *OpenMeteo) Marine(ctx context.Context, latitude, longitude float64, at time.Time) (*Conditions, error) {
+	hour := at.UTC().Truncate(time.Hour).Format("2006-01-02T15:04")
+
+	query := url.Values{
+		"latitude":   {strconv.FormatFloat(latitude, 'f', 4, 64)},
+		"longitude":  {strconv.FormatFloat(longitude, 'f', 4, 64)},
+		"hourly":     {"sea_surface_temperature,wave_height,ocean_current_velocity"},
+		"timezone":   {"GMT"},
+		"start_hour": {hour},
+		"end_hour":   {hour},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrUnavailable, res.StatusCode)
+	}
+
+	// Hours without data, ex: inland or out of the model range, are null
+	var body struct {
+		Hourly struct {
+			SeaSurfaceTemperature []*float64 `json:"sea_surface_temperature"`
+			WaveHeight            []*float64 `json:"wave_height"`
+			OceanCurrentVelocity  []*float64 `json:"ocean_current_velocity"` // km/h by default
+		} `json:"hourly"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %w", ErrUnavailable, err)
+	}
+
+	return &Conditions{
+		WaterTemperatureC: first(body.Hourly.SeaSurfaceTemperature),
+		WaveHeightMeters:  first(body.Hourly.WaveHeight),
+		CurrentSpeedKmh:   first(body.Hourly.OceanCurrentVelocity),
+	}, nil
+}
+
+func first(values []*float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
@@ -0,0 +1,40 @@
+// Package weather looks up the sea conditions at a place and time, used to fill the water
+// conditions of open water sessions the swimmer left empty
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rizkyharahap/swimo/config"
+)
+
+var ErrUnavailable = errors.New("weather provider unavailable")
+
+// Provider is implemented by every weather backend
+type Provider interface {
+	// Marine returns the conditions of the hour containing at, fields the provider has no data
+	// for are nil. Backend failures wrap ErrUnavailable.
+	Marine(ctx context.Context, latitude, longitude float64, at time.Time) (*Conditions, error)
+}
+
+// Conditions are the sea conditions at a place and hour
+type Conditions struct {
+	WaterTemperatureC *float64
+	WaveHeightMeters  *float64
+	CurrentSpeedKmh   *float64
+}
+
+// New creates the provider selected in config, nil when the integration is disabled
+func New(cfg config.WeatherConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "", "none":
+		return nil, nil
+	case "openmeteo":
+		return NewOpenMeteo(cfg.URL, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown weather provider %q", cfg.Provider)
+	}
+}
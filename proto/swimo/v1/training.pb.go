@@ -0,0 +1,1344 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: swimo/v1/training.proto
+
+package swimov1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Training struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CategoryCode  string                 `protobuf:"bytes,2,opt,name=category_code,json=categoryCode,proto3" json:"category_code,omitempty"`
+	CategoryName  string                 `protobuf:"bytes,3,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	Level         string                 `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Descriptions  string                 `protobuf:"bytes,6,opt,name=descriptions,proto3" json:"descriptions,omitempty"`
+	TimeLabel     string                 `protobuf:"bytes,7,opt,name=time_label,json=timeLabel,proto3" json:"time_label,omitempty"`
+	CaloriesKcal  int32                  `protobuf:"varint,8,opt,name=calories_kcal,json=caloriesKcal,proto3" json:"calories_kcal,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,9,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	VideoUrl      *string                `protobuf:"bytes,10,opt,name=video_url,json=videoUrl,proto3,oneof" json:"video_url,omitempty"`
+	Content       string                 `protobuf:"bytes,11,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Training) Reset() {
+	*x = Training{}
+	mi := &file_swimo_v1_training_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Training) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Training) ProtoMessage() {}
+
+func (x *Training) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Training.ProtoReflect.Descriptor instead.
+func (*Training) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Training) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Training) GetCategoryCode() string {
+	if x != nil {
+		return x.CategoryCode
+	}
+	return ""
+}
+
+func (x *Training) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+func (x *Training) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Training) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Training) GetDescriptions() string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return ""
+}
+
+func (x *Training) GetTimeLabel() string {
+	if x != nil {
+		return x.TimeLabel
+	}
+	return ""
+}
+
+func (x *Training) GetCaloriesKcal() int32 {
+	if x != nil {
+		return x.CaloriesKcal
+	}
+	return 0
+}
+
+func (x *Training) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Training) GetVideoUrl() string {
+	if x != nil && x.VideoUrl != nil {
+		return *x.VideoUrl
+	}
+	return ""
+}
+
+func (x *Training) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type TrainingItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Descriptions  string                 `protobuf:"bytes,4,opt,name=descriptions,proto3" json:"descriptions,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrainingItem) Reset() {
+	*x = TrainingItem{}
+	mi := &file_swimo_v1_training_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingItem) ProtoMessage() {}
+
+func (x *TrainingItem) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingItem.ProtoReflect.Descriptor instead.
+func (*TrainingItem) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TrainingItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrainingItem) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *TrainingItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrainingItem) GetDescriptions() string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return ""
+}
+
+func (x *TrainingItem) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+type TrainingLap struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Number          int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	DistanceMeters  int32                  `protobuf:"varint,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	StrokeCount     *int32                 `protobuf:"varint,4,opt,name=stroke_count,json=strokeCount,proto3,oneof" json:"stroke_count,omitempty"`
+	// Average heart rate in bpm, when the device reports it
+	AvgHeartRate  *int32 `protobuf:"varint,5,opt,name=avg_heart_rate,json=avgHeartRate,proto3,oneof" json:"avg_heart_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrainingLap) Reset() {
+	*x = TrainingLap{}
+	mi := &file_swimo_v1_training_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingLap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingLap) ProtoMessage() {}
+
+func (x *TrainingLap) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingLap.ProtoReflect.Descriptor instead.
+func (*TrainingLap) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TrainingLap) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TrainingLap) GetDistanceMeters() int32 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *TrainingLap) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TrainingLap) GetStrokeCount() int32 {
+	if x != nil && x.StrokeCount != nil {
+		return *x.StrokeCount
+	}
+	return 0
+}
+
+func (x *TrainingLap) GetAvgHeartRate() int32 {
+	if x != nil && x.AvgHeartRate != nil {
+		return *x.AvgHeartRate
+	}
+	return 0
+}
+
+type TrainingSession struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TrainingId      string                 `protobuf:"bytes,3,opt,name=training_id,json=trainingId,proto3" json:"training_id,omitempty"`
+	DistanceMeters  int32                  `protobuf:"varint,4,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Pace            float64                `protobuf:"fixed64,6,opt,name=pace,proto3" json:"pace,omitempty"`
+	CaloriesKcal    int32                  `protobuf:"varint,7,opt,name=calories_kcal,json=caloriesKcal,proto3" json:"calories_kcal,omitempty"`
+	Laps            []*TrainingLap         `protobuf:"bytes,8,rep,name=laps,proto3" json:"laps,omitempty"`
+	// Only set by ExportSessions
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrainingSession) Reset() {
+	*x = TrainingSession{}
+	mi := &file_swimo_v1_training_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingSession) ProtoMessage() {}
+
+func (x *TrainingSession) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingSession.ProtoReflect.Descriptor instead.
+func (*TrainingSession) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TrainingSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrainingSession) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TrainingSession) GetTrainingId() string {
+	if x != nil {
+		return x.TrainingId
+	}
+	return ""
+}
+
+func (x *TrainingSession) GetDistanceMeters() int32 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *TrainingSession) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TrainingSession) GetPace() float64 {
+	if x != nil {
+		return x.Pace
+	}
+	return 0
+}
+
+func (x *TrainingSession) GetCaloriesKcal() int32 {
+	if x != nil {
+		return x.CaloriesKcal
+	}
+	return 0
+}
+
+func (x *TrainingSession) GetLaps() []*TrainingLap {
+	if x != nil {
+		return x.Laps
+	}
+	return nil
+}
+
+func (x *TrainingSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetTrainingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrainingRequest) Reset() {
+	*x = GetTrainingRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrainingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrainingRequest) ProtoMessage() {}
+
+func (x *GetTrainingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrainingRequest.ProtoReflect.Descriptor instead.
+func (*GetTrainingRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTrainingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTrainingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Search        string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrainingsRequest) Reset() {
+	*x = ListTrainingsRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrainingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrainingsRequest) ProtoMessage() {}
+
+func (x *ListTrainingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrainingsRequest.ProtoReflect.Descriptor instead.
+func (*ListTrainingsRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTrainingsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTrainingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTrainingsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTrainingsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type Pagination struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	TotalPages     int32                  `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	TotalItems     int64                  `protobuf:"varint,4,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalEstimated bool                   `protobuf:"varint,5,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_swimo_v1_training_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Pagination) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Pagination) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
+type ListTrainingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trainings     []*TrainingItem        `protobuf:"bytes,1,rep,name=trainings,proto3" json:"trainings,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrainingsResponse) Reset() {
+	*x = ListTrainingsResponse{}
+	mi := &file_swimo_v1_training_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrainingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrainingsResponse) ProtoMessage() {}
+
+func (x *ListTrainingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrainingsResponse.ProtoReflect.Descriptor instead.
+func (*ListTrainingsResponse) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTrainingsResponse) GetTrainings() []*TrainingItem {
+	if x != nil {
+		return x.Trainings
+	}
+	return nil
+}
+
+func (x *ListTrainingsResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type CreateTrainingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryCode  string                 `protobuf:"bytes,1,opt,name=category_code,json=categoryCode,proto3" json:"category_code,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Descriptions  string                 `protobuf:"bytes,4,opt,name=descriptions,proto3" json:"descriptions,omitempty"`
+	TimeLabel     string                 `protobuf:"bytes,5,opt,name=time_label,json=time,proto3" json:"time_label,omitempty"`
+	CaloriesKcal  int32                  `protobuf:"varint,6,opt,name=calories_kcal,json=caloriesKcal,proto3" json:"calories_kcal,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,7,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	VideoUrl      string                 `protobuf:"bytes,8,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	Content       string                 `protobuf:"bytes,9,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTrainingRequest) Reset() {
+	*x = CreateTrainingRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTrainingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTrainingRequest) ProtoMessage() {}
+
+func (x *CreateTrainingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTrainingRequest.ProtoReflect.Descriptor instead.
+func (*CreateTrainingRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateTrainingRequest) GetCategoryCode() string {
+	if x != nil {
+		return x.CategoryCode
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetDescriptions() string {
+	if x != nil {
+		return x.Descriptions
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetTimeLabel() string {
+	if x != nil {
+		return x.TimeLabel
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetCaloriesKcal() int32 {
+	if x != nil {
+		return x.CaloriesKcal
+	}
+	return 0
+}
+
+func (x *CreateTrainingRequest) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *CreateTrainingRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type GetLastSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLastSessionRequest) Reset() {
+	*x = GetLastSessionRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLastSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLastSessionRequest) ProtoMessage() {}
+
+func (x *GetLastSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLastSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetLastSessionRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{9}
+}
+
+type TrainingLapInput struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DistanceMeters  int32                  `protobuf:"varint,1,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	StrokeCount     *int32                 `protobuf:"varint,3,opt,name=stroke_count,json=strokeCount,proto3,oneof" json:"stroke_count,omitempty"`
+	AvgHeartRate    *int32                 `protobuf:"varint,4,opt,name=avg_heart_rate,json=avgHeartRate,proto3,oneof" json:"avg_heart_rate,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TrainingLapInput) Reset() {
+	*x = TrainingLapInput{}
+	mi := &file_swimo_v1_training_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingLapInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingLapInput) ProtoMessage() {}
+
+func (x *TrainingLapInput) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingLapInput.ProtoReflect.Descriptor instead.
+func (*TrainingLapInput) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TrainingLapInput) GetDistanceMeters() int32 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *TrainingLapInput) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TrainingLapInput) GetStrokeCount() int32 {
+	if x != nil && x.StrokeCount != nil {
+		return *x.StrokeCount
+	}
+	return 0
+}
+
+func (x *TrainingLapInput) GetAvgHeartRate() int32 {
+	if x != nil && x.AvgHeartRate != nil {
+		return *x.AvgHeartRate
+	}
+	return 0
+}
+
+type FinishSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Training ID
+	Id              string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DistanceMeters  int32               `protobuf:"varint,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	DurationSeconds int32               `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Laps            []*TrainingLapInput `protobuf:"bytes,4,rep,name=laps,proto3" json:"laps,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FinishSessionRequest) Reset() {
+	*x = FinishSessionRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishSessionRequest) ProtoMessage() {}
+
+func (x *FinishSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishSessionRequest.ProtoReflect.Descriptor instead.
+func (*FinishSessionRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FinishSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FinishSessionRequest) GetDistanceMeters() int32 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *FinishSessionRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *FinishSessionRequest) GetLaps() []*TrainingLapInput {
+	if x != nil {
+		return x.Laps
+	}
+	return nil
+}
+
+type ImportSession struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TrainingId      string                 `protobuf:"bytes,1,opt,name=training_id,json=trainingId,proto3" json:"training_id,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DistanceMeters  int32                  `protobuf:"varint,3,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Laps            []*TrainingLapInput    `protobuf:"bytes,5,rep,name=laps,proto3" json:"laps,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportSession) Reset() {
+	*x = ImportSession{}
+	mi := &file_swimo_v1_training_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSession) ProtoMessage() {}
+
+func (x *ImportSession) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSession.ProtoReflect.Descriptor instead.
+func (*ImportSession) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ImportSession) GetTrainingId() string {
+	if x != nil {
+		return x.TrainingId
+	}
+	return ""
+}
+
+func (x *ImportSession) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ImportSession) GetDistanceMeters() int32 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *ImportSession) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *ImportSession) GetLaps() []*TrainingLapInput {
+	if x != nil {
+		return x.Laps
+	}
+	return nil
+}
+
+type ImportSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*ImportSession       `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSessionsRequest) Reset() {
+	*x = ImportSessionsRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSessionsRequest) ProtoMessage() {}
+
+func (x *ImportSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ImportSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImportSessionsRequest) GetSessions() []*ImportSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type ImportSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      int32                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Sessions      []*TrainingSession     `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSessionsResponse) Reset() {
+	*x = ImportSessionsResponse{}
+	mi := &file_swimo_v1_training_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSessionsResponse) ProtoMessage() {}
+
+func (x *ImportSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ImportSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportSessionsResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportSessionsResponse) GetSessions() []*TrainingSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type ExportSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSessionsRequest) Reset() {
+	*x = ExportSessionsRequest{}
+	mi := &file_swimo_v1_training_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSessionsRequest) ProtoMessage() {}
+
+func (x *ExportSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swimo_v1_training_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ExportSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_swimo_v1_training_proto_rawDescGZIP(), []int{15}
+}
+
+var File_swimo_v1_training_proto protoreflect.FileDescriptor
+
+const file_swimo_v1_training_proto_rawDesc = "" +
+	"\n" +
+	"\x17swimo/v1/training.proto\x12\bswimo.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe5\x02\n" +
+	"\bTraining\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rcategory_code\x18\x02 \x01(\tR\fcategoryCode\x12#\n" +
+	"\rcategory_name\x18\x03 \x01(\tR\fcategoryName\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\"\n" +
+	"\fdescriptions\x18\x06 \x01(\tR\fdescriptions\x12\x1d\n" +
+	"\n" +
+	"time_label\x18\a \x01(\tR\ttimeLabel\x12#\n" +
+	"\rcalories_kcal\x18\b \x01(\x05R\fcaloriesKcal\x12#\n" +
+	"\rthumbnail_url\x18\t \x01(\tR\fthumbnailUrl\x12 \n" +
+	"\tvideo_url\x18\n" +
+	" \x01(\tH\x00R\bvideoUrl\x88\x01\x01\x12\x18\n" +
+	"\acontent\x18\v \x01(\tR\acontentB\f\n" +
+	"\n" +
+	"_video_url\"\x91\x01\n" +
+	"\fTrainingItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\"\n" +
+	"\fdescriptions\x18\x04 \x01(\tR\fdescriptions\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\"\xf0\x01\n" +
+	"\vTrainingLap\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x05R\x0edistanceMeters\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x05R\x0fdurationSeconds\x12&\n" +
+	"\fstroke_count\x18\x04 \x01(\x05H\x00R\vstrokeCount\x88\x01\x01\x12)\n" +
+	"\x0eavg_heart_rate\x18\x05 \x01(\x05H\x01R\favgHeartRate\x88\x01\x01B\x0f\n" +
+	"\r_stroke_countB\x11\n" +
+	"\x0f_avg_heart_rate\"\xce\x02\n" +
+	"\x0fTrainingSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vtraining_id\x18\x03 \x01(\tR\n" +
+	"trainingId\x12'\n" +
+	"\x0fdistance_meters\x18\x04 \x01(\x05R\x0edistanceMeters\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x05R\x0fdurationSeconds\x12\x12\n" +
+	"\x04pace\x18\x06 \x01(\x01R\x04pace\x12#\n" +
+	"\rcalories_kcal\x18\a \x01(\x05R\fcaloriesKcal\x12)\n" +
+	"\x04laps\x18\b \x03(\v2\x15.swimo.v1.TrainingLapR\x04laps\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"$\n" +
+	"\x12GetTrainingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"l\n" +
+	"\x14ListTrainingsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\"\xa1\x01\n" +
+	"\n" +
+	"Pagination\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vtotal_pages\x18\x03 \x01(\x05R\n" +
+	"totalPages\x12\x1f\n" +
+	"\vtotal_items\x18\x04 \x01(\x03R\n" +
+	"totalItems\x12'\n" +
+	"\x0ftotal_estimated\x18\x05 \x01(\bR\x0etotalEstimated\"\x83\x01\n" +
+	"\x15ListTrainingsResponse\x124\n" +
+	"\ttrainings\x18\x01 \x03(\v2\x16.swimo.v1.TrainingItemR\ttrainings\x124\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x14.swimo.v1.PaginationR\n" +
+	"pagination\"\xa5\x02\n" +
+	"\x15CreateTrainingRequest\x12#\n" +
+	"\rcategory_code\x18\x01 \x01(\tR\fcategoryCode\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\"\n" +
+	"\fdescriptions\x18\x04 \x01(\tR\fdescriptions\x12\x18\n" +
+	"\n" +
+	"time_label\x18\x05 \x01(\tR\x04time\x12#\n" +
+	"\rcalories_kcal\x18\x06 \x01(\x05R\fcaloriesKcal\x12#\n" +
+	"\rthumbnail_url\x18\a \x01(\tR\fthumbnailUrl\x12\x1b\n" +
+	"\tvideo_url\x18\b \x01(\tR\bvideoUrl\x12\x18\n" +
+	"\acontent\x18\t \x01(\tR\acontent\"\x17\n" +
+	"\x15GetLastSessionRequest\"\xdd\x01\n" +
+	"\x10TrainingLapInput\x12'\n" +
+	"\x0fdistance_meters\x18\x01 \x01(\x05R\x0edistanceMeters\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x05R\x0fdurationSeconds\x12&\n" +
+	"\fstroke_count\x18\x03 \x01(\x05H\x00R\vstrokeCount\x88\x01\x01\x12)\n" +
+	"\x0eavg_heart_rate\x18\x04 \x01(\x05H\x01R\favgHeartRate\x88\x01\x01B\x0f\n" +
+	"\r_stroke_countB\x11\n" +
+	"\x0f_avg_heart_rate\"\xaa\x01\n" +
+	"\x14FinishSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x05R\x0edistanceMeters\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x05R\x0fdurationSeconds\x12.\n" +
+	"\x04laps\x18\x04 \x03(\v2\x1a.swimo.v1.TrainingLapInputR\x04laps\"\xef\x01\n" +
+	"\rImportSession\x12\x1f\n" +
+	"\vtraining_id\x18\x01 \x01(\tR\n" +
+	"trainingId\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12'\n" +
+	"\x0fdistance_meters\x18\x03 \x01(\x05R\x0edistanceMeters\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12.\n" +
+	"\x04laps\x18\x05 \x03(\v2\x1a.swimo.v1.TrainingLapInputR\x04laps\"L\n" +
+	"\x15ImportSessionsRequest\x123\n" +
+	"\bsessions\x18\x01 \x03(\v2\x17.swimo.v1.ImportSessionR\bsessions\"k\n" +
+	"\x16ImportSessionsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x05R\bimported\x125\n" +
+	"\bsessions\x18\x02 \x03(\v2\x19.swimo.v1.TrainingSessionR\bsessions\"\x17\n" +
+	"\x15ExportSessionsRequest2\xb0\x06\n" +
+	"\x0fTrainingService\x12_\n" +
+	"\vGetTraining\x12\x1c.swimo.v1.GetTrainingRequest\x1a\x12.swimo.v1.Training\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/trainings/{id}\x12k\n" +
+	"\rListTrainings\x12\x1e.swimo.v1.ListTrainingsRequest\x1a\x1f.swimo.v1.ListTrainingsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/trainings\x12c\n" +
+	"\x0eCreateTraining\x12\x1f.swimo.v1.CreateTrainingRequest\x1a\x12.swimo.v1.Training\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/trainings\x12u\n" +
+	"\x0eGetLastSession\x12\x1f.swimo.v1.GetLastSessionRequest\x1a\x19.swimo.v1.TrainingSession\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/trainings/sessions/last\x12t\n" +
+	"\rFinishSession\x12\x1e.swimo.v1.FinishSessionRequest\x1a\x19.swimo.v1.TrainingSession\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/trainings/{id}/finish\x12\x81\x01\n" +
+	"\x0eImportSessions\x12\x1f.swimo.v1.ImportSessionsRequest\x1a .swimo.v1.ImportSessionsResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/trainings/sessions/import\x12y\n" +
+	"\x0eExportSessions\x12\x1f.swimo.v1.ExportSessionsRequest\x1a\x19.swimo.v1.TrainingSession\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/trainings/sessions/export0\x01B6Z4github.com/rizkyharahap/swimo/proto/swimo/v1;swimov1b\x06proto3"
+
+var (
+	file_swimo_v1_training_proto_rawDescOnce sync.Once
+	file_swimo_v1_training_proto_rawDescData []byte
+)
+
+func file_swimo_v1_training_proto_rawDescGZIP() []byte {
+	file_swimo_v1_training_proto_rawDescOnce.Do(func() {
+		file_swimo_v1_training_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_swimo_v1_training_proto_rawDesc), len(file_swimo_v1_training_proto_rawDesc)))
+	})
+	return file_swimo_v1_training_proto_rawDescData
+}
+
+var file_swimo_v1_training_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_swimo_v1_training_proto_goTypes = []any{
+	(*Training)(nil),               // 0: swimo.v1.Training
+	(*TrainingItem)(nil),           // 1: swimo.v1.TrainingItem
+	(*TrainingLap)(nil),            // 2: swimo.v1.TrainingLap
+	(*TrainingSession)(nil),        // 3: swimo.v1.TrainingSession
+	(*GetTrainingRequest)(nil),     // 4: swimo.v1.GetTrainingRequest
+	(*ListTrainingsRequest)(nil),   // 5: swimo.v1.ListTrainingsRequest
+	(*Pagination)(nil),             // 6: swimo.v1.Pagination
+	(*ListTrainingsResponse)(nil),  // 7: swimo.v1.ListTrainingsResponse
+	(*CreateTrainingRequest)(nil),  // 8: swimo.v1.CreateTrainingRequest
+	(*GetLastSessionRequest)(nil),  // 9: swimo.v1.GetLastSessionRequest
+	(*TrainingLapInput)(nil),       // 10: swimo.v1.TrainingLapInput
+	(*FinishSessionRequest)(nil),   // 11: swimo.v1.FinishSessionRequest
+	(*ImportSession)(nil),          // 12: swimo.v1.ImportSession
+	(*ImportSessionsRequest)(nil),  // 13: swimo.v1.ImportSessionsRequest
+	(*ImportSessionsResponse)(nil), // 14: swimo.v1.ImportSessionsResponse
+	(*ExportSessionsRequest)(nil),  // 15: swimo.v1.ExportSessionsRequest
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+}
+var file_swimo_v1_training_proto_depIdxs = []int32{
+	2,  // 0: swimo.v1.TrainingSession.laps:type_name -> swimo.v1.TrainingLap
+	16, // 1: swimo.v1.TrainingSession.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: swimo.v1.ListTrainingsResponse.trainings:type_name -> swimo.v1.TrainingItem
+	6,  // 3: swimo.v1.ListTrainingsResponse.pagination:type_name -> swimo.v1.Pagination
+	10, // 4: swimo.v1.FinishSessionRequest.laps:type_name -> swimo.v1.TrainingLapInput
+	16, // 5: swimo.v1.ImportSession.started_at:type_name -> google.protobuf.Timestamp
+	10, // 6: swimo.v1.ImportSession.laps:type_name -> swimo.v1.TrainingLapInput
+	12, // 7: swimo.v1.ImportSessionsRequest.sessions:type_name -> swimo.v1.ImportSession
+	3,  // 8: swimo.v1.ImportSessionsResponse.sessions:type_name -> swimo.v1.TrainingSession
+	4,  // 9: swimo.v1.TrainingService.GetTraining:input_type -> swimo.v1.GetTrainingRequest
+	5,  // 10: swimo.v1.TrainingService.ListTrainings:input_type -> swimo.v1.ListTrainingsRequest
+	8,  // 11: swimo.v1.TrainingService.CreateTraining:input_type -> swimo.v1.CreateTrainingRequest
+	9,  // 12: swimo.v1.TrainingService.GetLastSession:input_type -> swimo.v1.GetLastSessionRequest
+	11, // 13: swimo.v1.TrainingService.FinishSession:input_type -> swimo.v1.FinishSessionRequest
+	13, // 14: swimo.v1.TrainingService.ImportSessions:input_type -> swimo.v1.ImportSessionsRequest
+	15, // 15: swimo.v1.TrainingService.ExportSessions:input_type -> swimo.v1.ExportSessionsRequest
+	0,  // 16: swimo.v1.TrainingService.GetTraining:output_type -> swimo.v1.Training
+	7,  // 17: swimo.v1.TrainingService.ListTrainings:output_type -> swimo.v1.ListTrainingsResponse
+	0,  // 18: swimo.v1.TrainingService.CreateTraining:output_type -> swimo.v1.Training
+	3,  // 19: swimo.v1.TrainingService.GetLastSession:output_type -> swimo.v1.TrainingSession
+	3,  // 20: swimo.v1.TrainingService.FinishSession:output_type -> swimo.v1.TrainingSession
+	14, // 21: swimo.v1.TrainingService.ImportSessions:output_type -> swimo.v1.ImportSessionsResponse
+	3,  // 22: swimo.v1.TrainingService.ExportSessions:output_type -> swimo.v1.TrainingSession
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_swimo_v1_training_proto_init() }
+func file_swimo_v1_training_proto_init() {
+	if File_swimo_v1_training_proto != nil {
+		return
+	}
+	file_swimo_v1_training_proto_msgTypes[0].OneofWrappers = []any{}
+	file_swimo_v1_training_proto_msgTypes[2].OneofWrappers = []any{}
+	file_swimo_v1_training_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_swimo_v1_training_proto_rawDesc), len(file_swimo_v1_training_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_swimo_v1_training_proto_goTypes,
+		DependencyIndexes: file_swimo_v1_training_proto_depIdxs,
+		MessageInfos:      file_swimo_v1_training_proto_msgTypes,
+	}.Build()
+	File_swimo_v1_training_proto = out.File
+	file_swimo_v1_training_proto_goTypes = nil
+	file_swimo_v1_training_proto_depIdxs = nil
+}
@@ -0,0 +1,361 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: swimo/v1/training.proto
+
+package swimov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TrainingService_GetTraining_FullMethodName    = "/swimo.v1.TrainingService/GetTraining"
+	TrainingService_ListTrainings_FullMethodName  = "/swimo.v1.TrainingService/ListTrainings"
+	TrainingService_CreateTraining_FullMethodName = "/swimo.v1.TrainingService/CreateTraining"
+	TrainingService_GetLastSession_FullMethodName = "/swimo.v1.TrainingService/GetLastSession"
+	TrainingService_FinishSession_FullMethodName  = "/swimo.v1.TrainingService/FinishSession"
+	TrainingService_ImportSessions_FullMethodName = "/swimo.v1.TrainingService/ImportSessions"
+	TrainingService_ExportSessions_FullMethodName = "/swimo.v1.TrainingService/ExportSessions"
+)
+
+// TrainingServiceClient is the client API for TrainingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TrainingService mirrors the REST training endpoints, the gateway maps it to the same paths.
+// Every method requires an access token in the authorization metadata.
+type TrainingServiceClient interface {
+	GetTraining(ctx context.Context, in *GetTrainingRequest, opts ...grpc.CallOption) (*Training, error)
+	ListTrainings(ctx context.Context, in *ListTrainingsRequest, opts ...grpc.CallOption) (*ListTrainingsResponse, error)
+	CreateTraining(ctx context.Context, in *CreateTrainingRequest, opts ...grpc.CallOption) (*Training, error)
+	GetLastSession(ctx context.Context, in *GetLastSessionRequest, opts ...grpc.CallOption) (*TrainingSession, error)
+	FinishSession(ctx context.Context, in *FinishSessionRequest, opts ...grpc.CallOption) (*TrainingSession, error)
+	ImportSessions(ctx context.Context, in *ImportSessionsRequest, opts ...grpc.CallOption) (*ImportSessionsResponse, error)
+	// ExportSessions streams every session of the user, newest first
+	ExportSessions(ctx context.Context, in *ExportSessionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrainingSession], error)
+}
+
+type trainingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrainingServiceClient(cc grpc.ClientConnInterface) TrainingServiceClient {
+	return &trainingServiceClient{cc}
+}
+
+func (c *trainingServiceClient) GetTraining(ctx context.Context, in *GetTrainingRequest, opts ...grpc.CallOption) (*Training, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Training)
+	err := c.cc.Invoke(ctx, TrainingService_GetTraining_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainingServiceClient) ListTrainings(ctx context.Context, in *ListTrainingsRequest, opts ...grpc.CallOption) (*ListTrainingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrainingsResponse)
+	err := c.cc.Invoke(ctx, TrainingService_ListTrainings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainingServiceClient) CreateTraining(ctx context.Context, in *CreateTrainingRequest, opts ...grpc.CallOption) (*Training, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Training)
+	err := c.cc.Invoke(ctx, TrainingService_CreateTraining_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainingServiceClient) GetLastSession(ctx context.Context, in *GetLastSessionRequest, opts ...grpc.CallOption) (*TrainingSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingSession)
+	err := c.cc.Invoke(ctx, TrainingService_GetLastSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainingServiceClient) FinishSession(ctx context.Context, in *FinishSessionRequest, opts ...grpc.CallOption) (*TrainingSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingSession)
+	err := c.cc.Invoke(ctx, TrainingService_FinishSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainingServiceClient) ImportSessions(ctx context.Context, in *ImportSessionsRequest, opts ...grpc.CallOption) (*ImportSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportSessionsResponse)
+	err := c.cc.Invoke(ctx, TrainingService_ImportSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainingServiceClient) ExportSessions(ctx context.Context, in *ExportSessionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrainingSession], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrainingService_ServiceDesc.Streams[0], TrainingService_ExportSessions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportSessionsRequest, TrainingSession]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrainingService_ExportSessionsClient = grpc.ServerStreamingClient[TrainingSession]
+
+// TrainingServiceServer is the server API for TrainingService service.
+// All implementations must embed UnimplementedTrainingServiceServer
+// for forward compatibility.
+//
+// TrainingService mirrors the REST training endpoints, the gateway maps it to the same paths.
+// Every method requires an access token in the authorization metadata.
+type TrainingServiceServer interface {
+	GetTraining(context.Context, *GetTrainingRequest) (*Training, error)
+	ListTrainings(context.Context, *ListTrainingsRequest) (*ListTrainingsResponse, error)
+	CreateTraining(context.Context, *CreateTrainingRequest) (*Training, error)
+	GetLastSession(context.Context, *GetLastSessionRequest) (*TrainingSession, error)
+	FinishSession(context.Context, *FinishSessionRequest) (*TrainingSession, error)
+	ImportSessions(context.Context, *ImportSessionsRequest) (*ImportSessionsResponse, error)
+	// ExportSessions streams every session of the user, newest first
+	ExportSessions(*ExportSessionsRequest, grpc.ServerStreamingServer[TrainingSession]) error
+	mustEmbedUnimplementedTrainingServiceServer()
+}
+
+// UnimplementedTrainingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTrainingServiceServer struct{}
+
+func (UnimplementedTrainingServiceServer) GetTraining(context.Context, *GetTrainingRequest) (*Training, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTraining not implemented")
+}
+func (UnimplementedTrainingServiceServer) ListTrainings(context.Context, *ListTrainingsRequest) (*ListTrainingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrainings not implemented")
+}
+func (UnimplementedTrainingServiceServer) CreateTraining(context.Context, *CreateTrainingRequest) (*Training, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTraining not implemented")
+}
+func (UnimplementedTrainingServiceServer) GetLastSession(context.Context, *GetLastSessionRequest) (*TrainingSession, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLastSession not implemented")
+}
+func (UnimplementedTrainingServiceServer) FinishSession(context.Context, *FinishSessionRequest) (*TrainingSession, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinishSession not implemented")
+}
+func (UnimplementedTrainingServiceServer) ImportSessions(context.Context, *ImportSessionsRequest) (*ImportSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportSessions not implemented")
+}
+func (UnimplementedTrainingServiceServer) ExportSessions(*ExportSessionsRequest, grpc.ServerStreamingServer[TrainingSession]) error {
+	return status.Error(codes.Unimplemented, "method ExportSessions not implemented")
+}
+func (UnimplementedTrainingServiceServer) mustEmbedUnimplementedTrainingServiceServer() {}
+func (UnimplementedTrainingServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTrainingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TrainingServiceServer will
+// result in compilation errors.
+type UnsafeTrainingServiceServer interface {
+	mustEmbedUnimplementedTrainingServiceServer()
+}
+
+func RegisterTrainingServiceServer(s grpc.ServiceRegistrar, srv TrainingServiceServer) {
+	// If the following call panics, it indicates UnimplementedTrainingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TrainingService_ServiceDesc, srv)
+}
+
+func _TrainingService_GetTraining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrainingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainingServiceServer).GetTraining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainingService_GetTraining_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainingServiceServer).GetTraining(ctx, req.(*GetTrainingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainingService_ListTrainings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrainingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainingServiceServer).ListTrainings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainingService_ListTrainings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainingServiceServer).ListTrainings(ctx, req.(*ListTrainingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainingService_CreateTraining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTrainingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainingServiceServer).CreateTraining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainingService_CreateTraining_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainingServiceServer).CreateTraining(ctx, req.(*CreateTrainingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainingService_GetLastSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainingServiceServer).GetLastSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainingService_GetLastSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainingServiceServer).GetLastSession(ctx, req.(*GetLastSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainingService_FinishSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainingServiceServer).FinishSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainingService_FinishSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainingServiceServer).FinishSession(ctx, req.(*FinishSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainingService_ImportSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainingServiceServer).ImportSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainingService_ImportSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainingServiceServer).ImportSessions(ctx, req.(*ImportSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainingService_ExportSessions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSessionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrainingServiceServer).ExportSessions(m, &grpc.GenericServerStream[ExportSessionsRequest, TrainingSession]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrainingService_ExportSessionsServer = grpc.ServerStreamingServer[TrainingSession]
+
+// TrainingService_ServiceDesc is the grpc.ServiceDesc for TrainingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TrainingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "swimo.v1.TrainingService",
+	HandlerType: (*TrainingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTraining",
+			Handler:    _TrainingService_GetTraining_Handler,
+		},
+		{
+			MethodName: "ListTrainings",
+			Handler:    _TrainingService_ListTrainings_Handler,
+		},
+		{
+			MethodName: "CreateTraining",
+			Handler:    _TrainingService_CreateTraining_Handler,
+		},
+		{
+			MethodName: "GetLastSession",
+			Handler:    _TrainingService_GetLastSession_Handler,
+		},
+		{
+			MethodName: "FinishSession",
+			Handler:    _TrainingService_FinishSession_Handler,
+		},
+		{
+			MethodName: "ImportSessions",
+			Handler:    _TrainingService_ImportSessions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportSessions",
+			Handler:       _TrainingService_ExportSessions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "swimo/v1/training.proto",
+}